@@ -0,0 +1,44 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/lang"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/forwarding"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// newTrustedProxies returns the trusted proxies set in the config.
+// Entries that are no valid address or CIDR network are logged and left out.
+func (server *Server) newTrustedProxies() *forwarding.TrustedProxies {
+	var proxies, _ = forwarding.NewTrustedProxies()
+	for _, entry := range server.Config.TrustedProxies {
+		if err := proxies.Add(entry); err != nil {
+			text.DefaultLogger.Error("Failed to add trusted proxy", entry+":", err)
+		}
+	}
+	return proxies
+}
+
+// forwardAddress sets the address of the client forwarded by a proxy with the login of the session logging in with the given name.
+// Only proxies connecting from a trusted address can forward addresses, so that clients can not spoof their address.
+// Once forwarded, the address is used for screening, GeoIP lookups and logging instead of the address of the proxy.
+// Returns false if the session got kicked because a trusted proxy forwarded an invalid address.
+func (server *Server) forwardAddress(session *net.MinecraftSession, name string, forwarded string) bool {
+	if forwarded == "" || server.TrustedProxies == nil {
+		return true
+	}
+	var connection = session.GetConnectionAddress()
+	if connection == nil || !server.TrustedProxies.IsTrusted(connection.IP) {
+		text.DefaultLogger.Debug(name, "has tried to forward an address from an untrusted address.")
+		return true
+	}
+	var address, err = forwarding.ParseAddress(forwarded, 0)
+	if err != nil {
+		text.DefaultLogger.Debug("Proxy", connection, "has forwarded an invalid address for", name+":", err)
+		session.Kick(server.Translate(session, lang.KickInvalidForwarding), false, false)
+		return false
+	}
+	session.SetForwardedAddress(address)
+	text.DefaultLogger.Debug(name, "is connecting from", address, "through proxy", connection)
+	return true
+}
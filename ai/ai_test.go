@@ -0,0 +1,252 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds/blocks"
+)
+
+// testWorld is a world of a flat stone floor at y 0, with extra blocks set.
+type testWorld map[blocks.Position]string
+
+func (world testWorld) GetBlockName(position blocks.Position) string {
+	if name, ok := world[position]; ok {
+		return name
+	}
+	if position.Y == 0 {
+		return "stone"
+	}
+	return "air"
+}
+
+type testBody struct {
+	position r3.Vector
+}
+
+func (body *testBody) GetRuntimeId() uint64 {
+	return 1
+}
+
+func (body *testBody) GetPosition() r3.Vector {
+	return body.position
+}
+
+func (body *testBody) Move(position r3.Vector, yaw float64, pitch float64) {
+	body.position = position
+}
+
+type testTarget struct {
+	position r3.Vector
+}
+
+func (target *testTarget) GetRuntimeId() uint64 {
+	return 2
+}
+
+func (target *testTarget) GetPosition() r3.Vector {
+	return target.position
+}
+
+func TestFindPathAroundWall(t *testing.T) {
+	var world = testWorld{}
+	for z := int32(-3); z <= 3; z++ {
+		world[blocks.NewPosition(2, 1, z)] = "stone"
+		world[blocks.NewPosition(2, 2, z)] = "stone"
+	}
+	var path, ok = FindPath(world, blocks.NewPosition(0, 1, 0), blocks.NewPosition(4, 1, 0), DefaultMaxNodes)
+	if !ok {
+		t.Fatal("expected path around the wall")
+	}
+	if len(path) != 12 {
+		t.Errorf("expected path of 12 positions, got %v", len(path))
+	}
+	for _, position := range path {
+		if !CanStand(world, position) {
+			t.Errorf("path goes through %v", position)
+		}
+	}
+}
+
+func TestFindPathSteps(t *testing.T) {
+	var world = testWorld{blocks.NewPosition(1, 1, 0): "stone"}
+	var path, ok = FindPath(world, blocks.NewPosition(0, 1, 0), blocks.NewPosition(2, 1, 0), DefaultMaxNodes)
+	if !ok || len(path) != 2 || path[0] != blocks.NewPosition(1, 2, 0) {
+		t.Errorf("expected path over the step, got %v", path)
+	}
+}
+
+func TestFindPathUnreachable(t *testing.T) {
+	var world = testWorld{}
+	for _, position := range []blocks.Position{{X: 1, Y: 1, Z: 0}, {X: -1, Y: 1, Z: 0}, {X: 0, Y: 1, Z: 1}, {X: 0, Y: 1, Z: -1}} {
+		world[position] = "stone"
+		world[blocks.NewPosition(position.X, 2, position.Z)] = "stone"
+	}
+	if path, ok := FindPath(world, blocks.NewPosition(0, 1, 0), blocks.NewPosition(5, 1, 0), DefaultMaxNodes); ok || len(path) != 0 {
+		t.Errorf("expected no path out of the enclosure, got %v", path)
+	}
+}
+
+func TestMeleeAttack(t *testing.T) {
+	var world = testWorld{}
+	var body = &testBody{position: r3.Vector{X: 0.5, Y: 1, Z: 0.5}}
+	var target = &testTarget{position: r3.Vector{X: 6.5, Y: 1, Z: 0.5}}
+	var attacks int
+	var mob = NewMob(body, 0.25, NewMeleeAttack(16, 2, 3, 20))
+	mob.AttackFunction = func(mob *Mob, attacked Target, damage float32) {
+		if attacked != target || damage != 3 {
+			t.Errorf("unexpected attack on %v with damage %v", attacked, damage)
+		}
+		attacks++
+	}
+
+	var manager = NewManager()
+	manager.Add(mob)
+	for i := 0; i < 40; i++ {
+		manager.Tick(world, []Target{target})
+	}
+	if distance := body.position.Distance(target.position); distance > 2 {
+		t.Errorf("expected mob to reach the target, distance is %v", distance)
+	}
+	// The mob reaches the target after 16 ticks, and attacks every 20 ticks from then on.
+	if attacks != 2 {
+		t.Errorf("expected 2 attacks, got %v", attacks)
+	}
+
+	manager.PeacefulFunction = func() bool { return true }
+	for i := 0; i < 40; i++ {
+		manager.Tick(world, []Target{target})
+	}
+	if attacks != 2 {
+		t.Errorf("expected no attacks in peaceful worlds, got %v", attacks)
+	}
+}
+
+func TestBehaviorPriority(t *testing.T) {
+	var world = testWorld{}
+	var body = &testBody{position: r3.Vector{X: 0.5, Y: 1, Z: 0.5}}
+	var follow = NewFollow(10, 3)
+	var look = NewLookAtPlayer(10)
+	var mob = NewMob(body, 0.25, follow, look)
+
+	var context = &Context{World: world, Targets: []Target{&testTarget{position: r3.Vector{X: 8.5, Y: 1, Z: 0.5}}}}
+	mob.Tick(context)
+	if mob.GetActiveBehavior() != follow || !mob.IsMoving() {
+		t.Fatal("expected mob to follow the target")
+	}
+	context.Targets = []Target{&testTarget{position: r3.Vector{X: 2.5, Y: 1, Z: 0.5}}}
+	mob.Tick(context)
+	if mob.GetActiveBehavior() != look || mob.IsMoving() {
+		t.Error("expected mob to stop and look at the close target")
+	}
+}
+
+func TestLeash(t *testing.T) {
+	var world = testWorld{}
+	var body = &testBody{position: r3.Vector{X: 0.5, Y: 1, Z: 0.5}}
+	var holder = &testTarget{position: r3.Vector{X: 8.5, Y: 1, Z: 0.5}}
+	var mob = NewMob(body, 0.25)
+	var broken bool
+	mob.LeashBreakFunction = func(mob *Mob, from Target) {
+		broken = from == holder
+	}
+	mob.Leash(holder)
+
+	var context = &Context{World: world}
+	for i := 0; i < 20; i++ {
+		mob.Tick(context)
+	}
+	if distance := body.position.Distance(holder.position); distance > LeashLength+0.1 {
+		t.Errorf("expected leash to pull the mob to the holder, distance is %v", distance)
+	}
+	holder.position.X = 30
+	mob.Tick(context)
+	if _, ok := mob.GetLeashHolder(); ok || !broken {
+		t.Error("expected leash to break")
+	}
+}
+
+func TestFollowOwner(t *testing.T) {
+	var world = testWorld{}
+	var body = &testBody{position: r3.Vector{X: 0.5, Y: 1, Z: 0.5}}
+	var owner = &testTarget{position: r3.Vector{X: 11.5, Y: 1, Z: 0.5}}
+	var mob = NewMob(body, 0.25, NewTameableBehaviors()...)
+	var context = &Context{World: world, OwnerFunction: func(id string) (Target, bool) {
+		return owner, id == "owner"
+	}}
+
+	mob.Tick(context)
+	if mob.IsMoving() {
+		t.Fatal("expected wild mob not to follow the owner")
+	}
+	mob.SetOwner("owner")
+	for i := 0; i < 60; i++ {
+		mob.Tick(context)
+	}
+	if distance := body.position.Distance(owner.position); distance > 2.5 {
+		t.Errorf("expected tamed mob to follow its owner, distance is %v", distance)
+	}
+
+	mob.SetSitting(true)
+	owner.position.X = 30.5
+	mob.Tick(context)
+	if mob.IsMoving() || body.position.Distance(owner.position) < 5 {
+		t.Error("expected sitting mob to stay in place")
+	}
+	mob.SetSitting(false)
+	mob.Tick(context)
+	if distance := body.position.Distance(owner.position); distance > 2 {
+		t.Errorf("expected mob to teleport to its far away owner, distance is %v", distance)
+	}
+}
+
+func TestBreed(t *testing.T) {
+	var world = testWorld{}
+	var first = NewMob(&testBody{position: r3.Vector{X: 0.5, Y: 1, Z: 0.5}}, 0.25, NewPassiveBehaviors()...)
+	var second = NewMob(&testBody{position: r3.Vector{X: 5.5, Y: 1, Z: 0.5}}, 0.25, NewPassiveBehaviors()...)
+	var other = NewMob(&testBody{position: r3.Vector{X: 0.5, Y: 1, Z: 2.5}}, 0.25, NewPassiveBehaviors()...)
+	first.Species, second.Species, other.Species = 11, 11, 12
+	var births int
+	for _, mob := range []*Mob{first, second} {
+		mob.BreedFunction = func(mob *Mob, partner *Mob) {
+			births++
+		}
+	}
+	first.SetInLove(LoveDuration)
+	other.SetInLove(LoveDuration)
+	context := &Context{World: world, Mobs: []*Mob{first, second, other}}
+	first.Tick(context)
+	if _, ok := first.GetActiveBehavior().(*Breed); ok {
+		t.Fatal("expected mob not to walk to a mob of another species")
+	}
+
+	second.SetInLove(LoveDuration)
+	for i := 0; i < 40 && births == 0; i++ {
+		first.Tick(context)
+		second.Tick(context)
+	}
+	if births != 1 {
+		t.Fatalf("expected the mobs to breed once, got %v births", births)
+	}
+	if first.IsInLove() || first.CanFallInLove() {
+		t.Error("expected breeding to end love and start the cooldown")
+	}
+}
+
+func TestGrowUp(t *testing.T) {
+	var mob = NewMob(&testBody{}, 0.25)
+	var grown bool
+	mob.GrowUpFunction = func(mob *Mob) {
+		grown = true
+	}
+	mob.SetAge(-2)
+	if !mob.IsBaby() || mob.SetInLove(LoveDuration) {
+		t.Fatal("expected baby not to fall in love")
+	}
+	var context = &Context{World: testWorld{}}
+	mob.Tick(context)
+	mob.Tick(context)
+	if mob.IsBaby() || !grown {
+		t.Error("expected baby to grow up")
+	}
+}
@@ -0,0 +1,213 @@
+package ai
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds/blocks"
+)
+
+// EyeHeight is the height of the eyes of players, which mobs look at.
+const EyeHeight = 1.62
+
+// RepathInterval is the amount of ticks after which mobs find a new path to a moving target.
+const RepathInterval = 10
+
+// Context is the state of the dimension passed to the behaviors of mobs every tick.
+type Context struct {
+	// World is the world the mobs are in.
+	World World
+	// Targets are the entities in the dimension that mobs may look at, follow or attack.
+	Targets []Target
+	// Tick is the current tick of the manager ticking the mobs.
+	Tick int64
+	// Mobs are all mobs of the manager ticking the mobs, which mobs may breed with.
+	Mobs []*Mob
+	// OwnerFunction returns the owner with the given ID of tamed mobs, and a bool indicating if it is nearby.
+	// Tamed mobs do not follow their owner if nil.
+	OwnerFunction func(owner string) (Target, bool)
+	// Peaceful indicates if the world has the peaceful difficulty, in which mobs do not attack.
+	Peaceful bool
+}
+
+// GetNearestTarget returns the target closest to the position within the given range.
+// A bool is returned indicating if there was a target in range.
+func (context *Context) GetNearestTarget(position r3.Vector, maxDistance float64) (Target, bool) {
+	var nearest Target
+	var nearestDistance = maxDistance
+	for _, target := range context.Targets {
+		if distance := target.GetPosition().Distance(position); distance <= nearestDistance {
+			nearest, nearestDistance = target, distance
+		}
+	}
+	return nearest, nearest != nil
+}
+
+// toBlockPosition returns the position of the block the vector is in.
+func toBlockPosition(vector r3.Vector) blocks.Position {
+	return blocks.NewPosition(int32(math.Floor(vector.X)), uint32(math.Max(0, math.Floor(vector.Y))), int32(math.Floor(vector.Z)))
+}
+
+// Wander makes the mob walk to a random position nearby once in a while.
+type Wander struct {
+	// Radius is the maximum horizontal distance of the positions the mob walks to.
+	Radius int32
+	// Chance is the chance of 1 in Chance every tick that the mob starts walking while idle.
+	Chance int
+}
+
+// NewWander returns a new wander behavior.
+func NewWander(radius int32, chance int) *Wander {
+	return &Wander{Radius: radius, Chance: chance}
+}
+
+// IsActive checks if the mob is wandering, or starts wandering by chance.
+func (wander *Wander) IsActive(mob *Mob, context *Context) bool {
+	if mob.GetActiveBehavior() == wander && mob.IsMoving() {
+		return true
+	}
+	return wander.Chance > 0 && rand.Intn(wander.Chance) == 0
+}
+
+// Tick makes the mob walk to a random position it can stand at, if it is not yet walking.
+func (wander *Wander) Tick(mob *Mob, context *Context) {
+	if mob.IsMoving() {
+		return
+	}
+	var position = mob.GetBlockPosition()
+	var x = position.X + rand.Int31n(wander.Radius*2+1) - wander.Radius
+	var z = position.Z + rand.Int31n(wander.Radius*2+1) - wander.Radius
+	for y := position.Y + 2; y+4 >= position.Y && y > 0; y-- {
+		if goal := blocks.NewPosition(x, y, z); CanStand(context.World, goal) {
+			mob.MoveTo(context.World, goal)
+			return
+		}
+	}
+}
+
+// LookAtPlayer makes the mob look at the nearest target in range.
+type LookAtPlayer struct {
+	// Range is the maximum distance of targets the mob looks at.
+	Range float64
+}
+
+// NewLookAtPlayer returns a new look at player behavior.
+func NewLookAtPlayer(lookRange float64) *LookAtPlayer {
+	return &LookAtPlayer{Range: lookRange}
+}
+
+// IsActive checks if there is a target in range.
+func (look *LookAtPlayer) IsActive(mob *Mob, context *Context) bool {
+	var _, ok = context.GetNearestTarget(mob.GetBody().GetPosition(), look.Range)
+	return ok
+}
+
+// Tick turns the mob to the eyes of the nearest target.
+func (look *LookAtPlayer) Tick(mob *Mob, context *Context) {
+	if target, ok := context.GetNearestTarget(mob.GetBody().GetPosition(), look.Range); ok {
+		mob.LookAt(target.GetPosition().Add(r3.Vector{Y: EyeHeight}))
+	}
+}
+
+// Follow makes the mob walk to the nearest target in range, until it is close to the target.
+type Follow struct {
+	// Range is the maximum distance of targets the mob follows.
+	Range float64
+	// Distance is the distance to the target at which the mob stops walking.
+	Distance float64
+
+	lastPath int64
+}
+
+// NewFollow returns a new follow behavior.
+func NewFollow(followRange float64, distance float64) *Follow {
+	return &Follow{Range: followRange, Distance: distance}
+}
+
+// IsActive checks if there is a target in range that is not yet close to the mob.
+func (follow *Follow) IsActive(mob *Mob, context *Context) bool {
+	var target, ok = context.GetNearestTarget(mob.GetBody().GetPosition(), follow.Range)
+	return ok && target.GetPosition().Distance(mob.GetBody().GetPosition()) > follow.Distance
+}
+
+// Tick makes the mob walk to the nearest target, finding a new path once in a while as the target moves.
+func (follow *Follow) Tick(mob *Mob, context *Context) {
+	var target, ok = context.GetNearestTarget(mob.GetBody().GetPosition(), follow.Range)
+	if !ok {
+		return
+	}
+	follow.lastPath = chase(mob, context, target, follow.lastPath)
+}
+
+// chase makes the mob walk to the target, if the mob is not walking or the last path was found
+// at least RepathInterval ticks ago. Returns the tick the last path was found at.
+func chase(mob *Mob, context *Context, target Target, lastPath int64) int64 {
+	if mob.IsMoving() && context.Tick-lastPath < RepathInterval {
+		return lastPath
+	}
+	mob.MoveTo(context.World, toBlockPosition(target.GetPosition()))
+	return context.Tick
+}
+
+// MeleeAttack makes the mob chase the nearest target in range, and attack it once it is within reach.
+type MeleeAttack struct {
+	// Range is the maximum distance of targets the mob chases.
+	Range float64
+	// Reach is the maximum distance of targets the mob attacks.
+	Reach float64
+	// Damage is the damage dealt by attacks.
+	Damage float32
+	// Cooldown is the minimum amount of ticks between two attacks.
+	Cooldown int64
+
+	lastPath   int64
+	lastAttack int64
+}
+
+// NewMeleeAttack returns a new melee attack behavior.
+func NewMeleeAttack(attackRange float64, reach float64, damage float32, cooldown int64) *MeleeAttack {
+	return &MeleeAttack{Range: attackRange, Reach: reach, Damage: damage, Cooldown: cooldown, lastAttack: -cooldown}
+}
+
+// IsActive checks if there is a target in range. Mobs never attack in peaceful worlds.
+func (attack *MeleeAttack) IsActive(mob *Mob, context *Context) bool {
+	if context.Peaceful {
+		return false
+	}
+	var _, ok = context.GetNearestTarget(mob.GetBody().GetPosition(), attack.Range)
+	return ok
+}
+
+// Tick makes the mob attack the nearest target if it is within reach, or chase it otherwise.
+func (attack *MeleeAttack) Tick(mob *Mob, context *Context) {
+	var target, ok = context.GetNearestTarget(mob.GetBody().GetPosition(), attack.Range)
+	if !ok {
+		return
+	}
+	if target.GetPosition().Distance(mob.GetBody().GetPosition()) > attack.Reach {
+		attack.lastPath = chase(mob, context, target, attack.lastPath)
+		return
+	}
+	mob.Stop()
+	mob.LookAt(target.GetPosition().Add(r3.Vector{Y: EyeHeight}))
+	if context.Tick-attack.lastAttack < attack.Cooldown {
+		return
+	}
+	attack.lastAttack = context.Tick
+	if mob.AttackFunction != nil {
+		mob.AttackFunction(mob, target, attack.Damage)
+	}
+}
+
+// NewPassiveBehaviors returns the behaviors of passive mobs, such as cows and pigs,
+// which breed when in love, and wander around and look at players nearby otherwise.
+func NewPassiveBehaviors() []Behavior {
+	return []Behavior{NewBreed(8), NewWander(8, 120), NewLookAtPlayer(6)}
+}
+
+// NewHostileBehaviors returns the behaviors of hostile mobs, such as zombies,
+// which chase and attack players nearby, and wander around otherwise.
+func NewHostileBehaviors(damage float32) []Behavior {
+	return []Behavior{NewMeleeAttack(16, 2, damage, 20), NewWander(8, 120), NewLookAtPlayer(8)}
+}
@@ -0,0 +1,123 @@
+package ai
+
+const (
+	// LoveDuration is the amount of ticks a mob stays in love after being fed, looking for a partner.
+	LoveDuration = 600
+	// BreedCooldown is the amount of ticks after breeding during which a mob can not fall in love again.
+	BreedCooldown = 6000
+	// BreedDistance is the distance between two mobs in love at which they breed.
+	BreedDistance = 1.5
+)
+
+// SetInLove makes the mob look for a partner to breed with for the given amount of ticks.
+// Returns false if the mob can not fall in love, because it is a baby, already in love or recently bred.
+func (mob *Mob) SetInLove(ticks int64) bool {
+	if !mob.CanFallInLove() {
+		return false
+	}
+	mob.love = ticks
+	return true
+}
+
+// CanFallInLove checks if the mob is an adult that is not in love and did not breed recently.
+func (mob *Mob) CanFallInLove() bool {
+	return !mob.IsBaby() && mob.love == 0 && mob.breedCooldown == 0
+}
+
+// IsInLove checks if the mob is looking for a partner to breed with.
+func (mob *Mob) IsInLove() bool {
+	return mob.love > 0
+}
+
+// SetAge sets the age of the mob in ticks. Mobs with a negative age are babies, which grow up once their age reaches 0.
+func (mob *Mob) SetAge(age int64) {
+	mob.age = age
+}
+
+// GetAge returns the age of the mob in ticks, which is negative for babies.
+func (mob *Mob) GetAge() int64 {
+	return mob.age
+}
+
+// IsBaby checks if the mob still has to grow up.
+func (mob *Mob) IsBaby() bool {
+	return mob.age < 0
+}
+
+// tickBreeding counts down the love and breeding cooldown of the mob, and ages babies.
+// The GrowUpFunction of the mob gets called when a baby grows up.
+func (mob *Mob) tickBreeding() {
+	if mob.love > 0 {
+		mob.love--
+	}
+	if mob.breedCooldown > 0 {
+		mob.breedCooldown--
+	}
+	if mob.age < 0 {
+		mob.age++
+		if mob.age == 0 && mob.GrowUpFunction != nil {
+			mob.GrowUpFunction(mob)
+		}
+	}
+}
+
+// Breed makes mobs in love walk to the nearest partner of their species in love, and breed once they meet.
+type Breed struct {
+	// Range is the maximum distance of partners the mob walks to.
+	Range float64
+
+	lastPath int64
+}
+
+// NewBreed returns a new breed behavior.
+func NewBreed(breedRange float64) *Breed {
+	return &Breed{Range: breedRange}
+}
+
+// IsActive checks if the mob is in love and has a partner in range.
+func (breed *Breed) IsActive(mob *Mob, context *Context) bool {
+	var _, ok = breed.findPartner(mob, context)
+	return ok
+}
+
+// Tick makes the mob walk to its partner, or breed with it if they are close enough.
+// Both mobs stop being in love after breeding, and get the breeding cooldown.
+func (breed *Breed) Tick(mob *Mob, context *Context) {
+	var partner, ok = breed.findPartner(mob, context)
+	if !ok {
+		return
+	}
+	var target = partner.GetBody()
+	if target.GetPosition().Distance(mob.GetBody().GetPosition()) > BreedDistance {
+		breed.lastPath = chase(mob, context, target, breed.lastPath)
+		return
+	}
+	mob.Stop()
+	for _, parent := range []*Mob{mob, partner} {
+		parent.love = 0
+		parent.breedCooldown = BreedCooldown
+	}
+	if mob.BreedFunction != nil {
+		mob.BreedFunction(mob, partner)
+	}
+}
+
+// findPartner returns the nearest other mob of the same species in love within range of the mob,
+// and a bool indicating if there was one.
+func (breed *Breed) findPartner(mob *Mob, context *Context) (*Mob, bool) {
+	if !mob.IsInLove() {
+		return nil, false
+	}
+	var position = mob.GetBody().GetPosition()
+	var partner *Mob
+	var nearest = breed.Range
+	for _, other := range context.Mobs {
+		if other == mob || other.Species != mob.Species || !other.IsInLove() {
+			continue
+		}
+		if distance := other.GetBody().GetPosition().Distance(position); distance <= nearest {
+			partner, nearest = other, distance
+		}
+	}
+	return partner, partner != nil
+}
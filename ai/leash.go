@@ -0,0 +1,55 @@
+package ai
+
+const (
+	// LeashLength is the distance between a leashed mob and its holder at which the leash starts pulling the mob.
+	LeashLength = 6.0
+	// LeashBreakDistance is the distance between a leashed mob and its holder at which the leash breaks.
+	LeashBreakDistance = 10.0
+	// LeashPull is the part of the stretch of the leash over its length a leashed mob is pulled every tick.
+	LeashPull = 0.4
+)
+
+// Leash leashes the mob to the holder, which is usually a player or a leash knot.
+// Leashed mobs are pulled towards their holder when they get further away than the leash length.
+func (mob *Mob) Leash(holder Target) {
+	mob.leashHolder = holder
+}
+
+// Unleash removes the leash of the mob. Returns false if the mob was not leashed.
+func (mob *Mob) Unleash() bool {
+	var leashed = mob.leashHolder != nil
+	mob.leashHolder = nil
+	return leashed
+}
+
+// GetLeashHolder returns the holder of the leash of the mob, and a bool indicating if the mob is leashed.
+func (mob *Mob) GetLeashHolder() (Target, bool) {
+	return mob.leashHolder, mob.leashHolder != nil
+}
+
+// pullLeash pulls the mob towards the holder of its leash if the leash is stretched,
+// overriding the movement of its behaviors. The leash breaks when the holder gets too far away,
+// in which case the LeashBreakFunction of the mob gets called.
+func (mob *Mob) pullLeash() {
+	if mob.leashHolder == nil {
+		return
+	}
+	var holder = mob.leashHolder
+	var position = mob.body.GetPosition()
+	var delta = holder.GetPosition().Sub(position)
+	var distance = delta.Norm()
+	if distance > LeashBreakDistance {
+		mob.leashHolder = nil
+		if mob.LeashBreakFunction != nil {
+			mob.LeashBreakFunction(mob, holder)
+		}
+		return
+	}
+	if distance <= LeashLength {
+		return
+	}
+	mob.Stop()
+	mob.yaw, _ = getRotation(position, holder.GetPosition())
+	mob.pitch = 0
+	mob.body.Move(position.Add(delta.Mul((distance-LeashLength)*LeashPull/distance)), mob.yaw, mob.pitch)
+}
@@ -0,0 +1,96 @@
+package ai
+
+import (
+	"sync"
+)
+
+// Manager keeps track of the mobs of a dimension, and ticks them.
+type Manager struct {
+	mutex sync.Mutex
+	mobs  map[uint64]*Mob
+	tick  int64
+	queue []*Mob
+
+	// TickLimit is the maximum amount of mobs ticked every tick.
+	// Mobs over the limit are ticked in the next ticks, before any mob gets ticked again.
+	// All mobs are ticked every tick if TickLimit is 0 or less.
+	TickLimit int
+	// OwnerFunction returns the owner with the given ID of tamed mobs, and a bool indicating if it is nearby.
+	// It is passed to the behaviors of mobs in their context.
+	OwnerFunction func(owner string) (Target, bool)
+	// PeacefulFunction checks if the world of the mobs has the peaceful difficulty, in which mobs do not attack.
+	// Mobs attack in any world if nil.
+	PeacefulFunction func() bool
+}
+
+// NewManager returns a new manager without mobs.
+func NewManager() *Manager {
+	return &Manager{mobs: make(map[uint64]*Mob)}
+}
+
+// Add adds the mob to the manager.
+func (manager *Manager) Add(mob *Mob) {
+	manager.mutex.Lock()
+	manager.mobs[mob.GetBody().GetRuntimeId()] = mob
+	manager.mutex.Unlock()
+}
+
+// Remove removes the mob with the given runtime ID.
+// Returns false if there was no such mob.
+func (manager *Manager) Remove(runtimeId uint64) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var _, ok = manager.mobs[runtimeId]
+	delete(manager.mobs, runtimeId)
+	return ok
+}
+
+// GetMob returns the mob with the given runtime ID, and a bool indicating if it exists.
+func (manager *Manager) GetMob(runtimeId uint64) (*Mob, bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var mob, ok = manager.mobs[runtimeId]
+	return mob, ok
+}
+
+// GetMobs returns all mobs of the manager.
+func (manager *Manager) GetMobs() []*Mob {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var mobs = make([]*Mob, 0, len(manager.mobs))
+	for _, mob := range manager.mobs {
+		mobs = append(mobs, mob)
+	}
+	return mobs
+}
+
+// Tick ticks the mobs in the world, which may target the given targets.
+// Returns the amount of mobs deferred to the next ticks because of the tick limit.
+func (manager *Manager) Tick(world World, targets []Target) int {
+	manager.mutex.Lock()
+	manager.tick++
+	var context = &Context{World: world, Targets: targets, Tick: manager.tick, OwnerFunction: manager.OwnerFunction}
+	context.Peaceful = manager.PeacefulFunction != nil && manager.PeacefulFunction()
+	context.Mobs = make([]*Mob, 0, len(manager.mobs))
+	for _, mob := range manager.mobs {
+		context.Mobs = append(context.Mobs, mob)
+	}
+	if len(manager.queue) == 0 {
+		manager.queue = append(manager.queue, context.Mobs...)
+	}
+	var mobs = manager.queue
+	if manager.TickLimit > 0 && len(mobs) > manager.TickLimit {
+		mobs = mobs[:manager.TickLimit]
+	}
+	manager.queue = manager.queue[len(mobs):]
+	var deferred = len(manager.queue)
+	manager.mutex.Unlock()
+
+	for _, mob := range mobs {
+		// Mobs removed while waiting in the queue are no longer ticked.
+		if current, ok := manager.GetMob(mob.GetBody().GetRuntimeId()); ok && current == mob {
+			mob.Tick(context)
+		}
+	}
+	return deferred
+}
@@ -0,0 +1,182 @@
+package ai
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds/blocks"
+)
+
+// Body is the entity a mob controls.
+type Body interface {
+	// GetRuntimeId returns the runtime ID of the entity.
+	GetRuntimeId() uint64
+	// GetPosition returns the position of the entity.
+	GetPosition() r3.Vector
+	// Move moves the entity to the position, facing in the direction of the yaw and pitch.
+	Move(position r3.Vector, yaw float64, pitch float64)
+}
+
+// Target is an entity mobs look at, follow and attack, usually a player.
+type Target interface {
+	// GetRuntimeId returns the runtime ID of the entity.
+	GetRuntimeId() uint64
+	// GetPosition returns the position of the entity.
+	GetPosition() r3.Vector
+}
+
+// Behavior is a behavior of a mob, such as wandering around or attacking players.
+// Behaviors keep state for a single mob, so every mob needs its own behavior instances.
+type Behavior interface {
+	// IsActive checks if the behavior should be executed this tick.
+	IsActive(mob *Mob, context *Context) bool
+	// Tick executes the behavior for the mob.
+	Tick(mob *Mob, context *Context)
+}
+
+// Mob is an entity controlled by behaviors.
+// Every tick the first active behavior gets executed, so behaviors added first have the highest priority.
+type Mob struct {
+	body      Body
+	speed     float64
+	behaviors []Behavior
+	active    Behavior
+	path      []blocks.Position
+	yaw       float64
+	pitch     float64
+
+	leashHolder Target
+	owner       string
+	sitting     bool
+
+	love          int64
+	breedCooldown int64
+	age           int64
+
+	// Species identifies the kind of the mob, such as its entity type. Mobs only breed with mobs of the same species.
+	Species uint32
+	// AttackFunction gets called when the mob attacks the target with the given damage.
+	// Nothing is done if nil.
+	AttackFunction func(mob *Mob, target Target, damage float32)
+	// LeashBreakFunction gets called when the leash of the mob breaks because its holder got too far away.
+	// Nothing is done if nil.
+	LeashBreakFunction func(mob *Mob, holder Target)
+	// BreedFunction gets called when the mob breeds with the partner. Nothing is done if nil.
+	BreedFunction func(mob *Mob, partner *Mob)
+	// GrowUpFunction gets called when the mob grows up from a baby. Nothing is done if nil.
+	GrowUpFunction func(mob *Mob)
+}
+
+// NewMob returns a new mob controlling the body, walking the given amount of blocks per tick.
+func NewMob(body Body, speed float64, behaviors ...Behavior) *Mob {
+	return &Mob{body: body, speed: speed, behaviors: behaviors}
+}
+
+// GetBody returns the entity the mob controls.
+func (mob *Mob) GetBody() Body {
+	return mob.body
+}
+
+// GetSpeed returns the amount of blocks the mob walks per tick.
+func (mob *Mob) GetSpeed() float64 {
+	return mob.speed
+}
+
+// SetSpeed sets the amount of blocks the mob walks per tick.
+func (mob *Mob) SetSpeed(speed float64) {
+	mob.speed = speed
+}
+
+// AddBehavior adds a behavior with a lower priority than all behaviors of the mob.
+func (mob *Mob) AddBehavior(behavior Behavior) {
+	mob.behaviors = append(mob.behaviors, behavior)
+}
+
+// GetBehaviors returns the behaviors of the mob, ordered by priority.
+func (mob *Mob) GetBehaviors() []Behavior {
+	return mob.behaviors
+}
+
+// GetActiveBehavior returns the behavior executed last tick, or nil if no behavior was active.
+func (mob *Mob) GetActiveBehavior() Behavior {
+	return mob.active
+}
+
+// GetBlockPosition returns the position of the block the mob stands in.
+func (mob *Mob) GetBlockPosition() blocks.Position {
+	return toBlockPosition(mob.body.GetPosition())
+}
+
+// MoveTo finds a path to the goal and makes the mob follow it.
+// Returns false if no path in the direction of the goal was found.
+func (mob *Mob) MoveTo(world World, goal blocks.Position) bool {
+	mob.path, _ = FindPath(world, mob.GetBlockPosition(), goal, DefaultMaxNodes)
+	return len(mob.path) != 0
+}
+
+// IsMoving checks if the mob is following a path.
+func (mob *Mob) IsMoving() bool {
+	return len(mob.path) != 0
+}
+
+// Stop stops the mob from following its path.
+func (mob *Mob) Stop() {
+	mob.path = nil
+}
+
+// LookAt turns the mob to face the position.
+func (mob *Mob) LookAt(position r3.Vector) {
+	mob.yaw, mob.pitch = getRotation(mob.body.GetPosition(), position)
+	mob.body.Move(mob.body.GetPosition(), mob.yaw, mob.pitch)
+}
+
+// getRotation returns the yaw and pitch in degrees of an entity at the position facing the target.
+func getRotation(position r3.Vector, target r3.Vector) (float64, float64) {
+	var delta = target.Sub(position)
+	var yaw = -math.Atan2(delta.X, delta.Z) * 180 / math.Pi
+	var pitch = -math.Atan2(delta.Y, math.Hypot(delta.X, delta.Z)) * 180 / math.Pi
+	return yaw, pitch
+}
+
+// Tick executes the first active behavior of the mob, and moves the mob along its path.
+// The path of the mob is dropped when another behavior becomes active.
+// Leashed mobs are pulled towards the holder of their leash afterwards.
+func (mob *Mob) Tick(context *Context) {
+	mob.tickBreeding()
+	var active Behavior
+	for _, behavior := range mob.behaviors {
+		if behavior.IsActive(mob, context) {
+			active = behavior
+			break
+		}
+	}
+	if active != mob.active {
+		mob.Stop()
+		mob.active = active
+	}
+	if active != nil {
+		active.Tick(mob, context)
+	}
+	mob.walk()
+	mob.pullLeash()
+}
+
+// walk moves the mob towards the next position of its path.
+func (mob *Mob) walk() {
+	if len(mob.path) == 0 {
+		return
+	}
+	var position = mob.body.GetPosition()
+	var next = r3.Vector{X: float64(mob.path[0].X) + 0.5, Y: float64(mob.path[0].Y), Z: float64(mob.path[0].Z) + 0.5}
+	var delta = next.Sub(position)
+	if delta.Norm() <= mob.speed {
+		mob.path = mob.path[1:]
+		position = next
+	} else {
+		position = position.Add(delta.Mul(mob.speed / delta.Norm()))
+	}
+	// Mobs look where they walk, but keep looking straight ahead when walking up or down.
+	mob.yaw, _ = getRotation(mob.body.GetPosition(), next)
+	mob.pitch = 0
+	mob.body.Move(position, mob.yaw, mob.pitch)
+}
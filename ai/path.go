@@ -0,0 +1,210 @@
+package ai
+
+import (
+	"container/heap"
+
+	"github.com/irmine/worlds/blocks"
+)
+
+// DefaultMaxNodes is the maximum amount of positions visited when finding a path.
+const DefaultMaxNodes = 512
+
+// MaxFallDistance is the maximum amount of blocks mobs drop down while following a path.
+const MaxFallDistance = 3
+
+// World gives mobs access to the blocks they move through.
+type World interface {
+	// GetBlockName returns the name of the block at the given position.
+	GetBlockName(position blocks.Position) string
+}
+
+// passable are the blocks without collision, that mobs walk through.
+var passable = map[string]bool{
+	"air":                   true,
+	"tallgrass":             true,
+	"double_plant":          true,
+	"yellow_flower":         true,
+	"red_flower":            true,
+	"sapling":               true,
+	"deadbush":              true,
+	"brown_mushroom":        true,
+	"red_mushroom":          true,
+	"torch":                 true,
+	"redstone_torch":        true,
+	"unlit_redstone_torch":  true,
+	"redstone_wire":         true,
+	"snow_layer":            true,
+	"wheat":                 true,
+	"carrots":               true,
+	"potatoes":              true,
+	"beetroot":              true,
+	"reeds":                 true,
+	"vine":                  true,
+	"rail":                  true,
+	"golden_rail":           true,
+	"detector_rail":         true,
+	"activator_rail":        true,
+	"lever":                 true,
+	"stone_button":          true,
+	"wooden_button":         true,
+	"stone_pressure_plate":  true,
+	"wooden_pressure_plate": true,
+	"standing_sign":         true,
+	"wall_sign":             true,
+	"carpet":                true,
+}
+
+// liquids are the blocks mobs neither walk through nor stand on.
+var liquids = map[string]bool{
+	"water":         true,
+	"flowing_water": true,
+	"lava":          true,
+	"flowing_lava":  true,
+}
+
+// IsPassable checks if mobs can walk through the block with the given name.
+func IsPassable(name string) bool {
+	return passable[name]
+}
+
+// IsSolid checks if mobs can stand on the block with the given name.
+func IsSolid(name string) bool {
+	return !passable[name] && !liquids[name]
+}
+
+// CanStand checks if a mob can stand at the position:
+// the block below is solid, and the position and the block above it are passable.
+func CanStand(world World, position blocks.Position) bool {
+	if position.Y == 0 {
+		return false
+	}
+	return IsPassable(world.GetBlockName(position)) &&
+		IsPassable(world.GetBlockName(blocks.NewPosition(position.X, position.Y+1, position.Z))) &&
+		IsSolid(world.GetBlockName(blocks.NewPosition(position.X, position.Y-1, position.Z)))
+}
+
+// node is a position visited while finding a path.
+type node struct {
+	position blocks.Position
+	parent   *node
+	cost     int
+	estimate int
+	index    int
+}
+
+// nodeQueue is a priority queue of nodes, ordered by their estimated total cost.
+type nodeQueue []*node
+
+func (queue nodeQueue) Len() int {
+	return len(queue)
+}
+
+func (queue nodeQueue) Less(i, j int) bool {
+	return queue[i].cost+queue[i].estimate < queue[j].cost+queue[j].estimate
+}
+
+func (queue nodeQueue) Swap(i, j int) {
+	queue[i], queue[j] = queue[j], queue[i]
+	queue[i].index, queue[j].index = i, j
+}
+
+func (queue *nodeQueue) Push(value interface{}) {
+	var n = value.(*node)
+	n.index = len(*queue)
+	*queue = append(*queue, n)
+}
+
+func (queue *nodeQueue) Pop() interface{} {
+	var old = *queue
+	var n = old[len(old)-1]
+	*queue = old[:len(old)-1]
+	return n
+}
+
+// distance returns the Manhattan distance between two positions.
+func distance(a blocks.Position, b blocks.Position) int {
+	return abs(int(a.X)-int(b.X)) + abs(int(a.Y)-int(b.Y)) + abs(int(a.Z)-int(b.Z))
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// neighbours returns the positions a mob standing at the position can walk to:
+// the adjacent positions on the same level, one block up, or at most MaxFallDistance blocks down.
+func neighbours(world World, position blocks.Position) []blocks.Position {
+	var result = make([]blocks.Position, 0, 4)
+	var headroom = IsPassable(world.GetBlockName(blocks.NewPosition(position.X, position.Y+2, position.Z)))
+	for _, direction := range [][2]int32{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+		var x, z = position.X + direction[0], position.Z + direction[1]
+		if headroom && CanStand(world, blocks.NewPosition(x, position.Y+1, z)) {
+			result = append(result, blocks.NewPosition(x, position.Y+1, z))
+			continue
+		}
+		for y := position.Y; y+MaxFallDistance >= position.Y && y > 0; y-- {
+			var next = blocks.NewPosition(x, y, z)
+			if CanStand(world, next) {
+				result = append(result, next)
+				break
+			}
+			// The mob can only drop down if nothing blocks its way.
+			if !IsPassable(world.GetBlockName(next)) || !IsPassable(world.GetBlockName(blocks.NewPosition(x, y+1, z))) {
+				break
+			}
+		}
+	}
+	return result
+}
+
+// FindPath finds a path from the start to the goal, visiting at most maxNodes positions.
+// The path returned does not include the start. If the goal could not be reached,
+// the path to the visited position closest to the goal is returned, with false.
+func FindPath(world World, start blocks.Position, goal blocks.Position, maxNodes int) ([]blocks.Position, bool) {
+	var startNode = &node{position: start, estimate: distance(start, goal)}
+	var visited = map[blocks.Position]*node{start: startNode}
+	var closest = startNode
+	var queue = &nodeQueue{startNode}
+
+	for queue.Len() > 0 && len(visited) <= maxNodes {
+		var current = heap.Pop(queue).(*node)
+		current.index = -1
+		if current.position == goal {
+			return buildPath(current), true
+		}
+		if current.estimate < closest.estimate {
+			closest = current
+		}
+		for _, position := range neighbours(world, current.position) {
+			var cost = current.cost + 1
+			if other, ok := visited[position]; ok {
+				if cost >= other.cost {
+					continue
+				}
+				other.cost, other.parent = cost, current
+				if other.index >= 0 {
+					heap.Fix(queue, other.index)
+				}
+				continue
+			}
+			var next = &node{position: position, parent: current, cost: cost, estimate: distance(position, goal)}
+			visited[position] = next
+			heap.Push(queue, next)
+		}
+	}
+	return buildPath(closest), false
+}
+
+// buildPath returns the positions from the start to the given node, excluding the start.
+func buildPath(end *node) []blocks.Position {
+	var path []blocks.Position
+	for current := end; current.parent != nil; current = current.parent {
+		path = append(path, current.position)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
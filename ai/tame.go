@@ -0,0 +1,114 @@
+package ai
+
+import "math/rand"
+
+// SetOwner tames the mob for the owner with the given ID, usually the UUID of a player.
+// An empty ID makes the mob wild again, which also makes it stand up.
+func (mob *Mob) SetOwner(owner string) {
+	mob.owner = owner
+	if owner == "" {
+		mob.sitting = false
+	}
+}
+
+// GetOwner returns the ID of the owner of the mob, or an empty string if the mob is not tamed.
+func (mob *Mob) GetOwner() string {
+	return mob.owner
+}
+
+// IsTamed checks if the mob has an owner.
+func (mob *Mob) IsTamed() bool {
+	return mob.owner != ""
+}
+
+// SetSitting makes a tamed mob sit down or stand up. Sitting mobs do not move until they stand up.
+func (mob *Mob) SetSitting(sitting bool) {
+	mob.sitting = sitting
+}
+
+// IsSitting checks if the mob sits.
+func (mob *Mob) IsSitting() bool {
+	return mob.sitting
+}
+
+// GetOwnerTarget returns the owner of the tamed mob, and a bool indicating if the owner is nearby.
+// Owners are looked up with the OwnerFunction of the context.
+func (context *Context) GetOwnerTarget(mob *Mob) (Target, bool) {
+	if !mob.IsTamed() || context.OwnerFunction == nil {
+		return nil, false
+	}
+	return context.OwnerFunction(mob.GetOwner())
+}
+
+// Sit keeps tamed mobs that sit in place.
+type Sit struct{}
+
+// NewSit returns a new sit behavior.
+func NewSit() *Sit {
+	return &Sit{}
+}
+
+// IsActive checks if the mob is tamed and sits.
+func (sit *Sit) IsActive(mob *Mob, context *Context) bool {
+	return mob.IsTamed() && mob.IsSitting()
+}
+
+// Tick keeps the mob in place.
+func (sit *Sit) Tick(mob *Mob, context *Context) {
+	mob.Stop()
+}
+
+// FollowOwner makes a tamed mob follow its owner once the owner gets too far away,
+// and teleports the mob to its owner if the owner gets even further away.
+type FollowOwner struct {
+	// StartDistance is the distance to the owner at which the mob starts following it.
+	StartDistance float64
+	// StopDistance is the distance to the owner at which the mob stops following it.
+	StopDistance float64
+	// TeleportDistance is the distance to the owner at which the mob teleports to it.
+	TeleportDistance float64
+
+	lastPath int64
+}
+
+// NewFollowOwner returns a new follow owner behavior.
+func NewFollowOwner(startDistance float64, stopDistance float64, teleportDistance float64) *FollowOwner {
+	return &FollowOwner{StartDistance: startDistance, StopDistance: stopDistance, TeleportDistance: teleportDistance}
+}
+
+// IsActive checks if the owner of the mob is further away than the start distance,
+// or further away than the stop distance while the mob is already following it.
+func (follow *FollowOwner) IsActive(mob *Mob, context *Context) bool {
+	var owner, ok = context.GetOwnerTarget(mob)
+	if !ok {
+		return false
+	}
+	var distance = owner.GetPosition().Distance(mob.GetBody().GetPosition())
+	if mob.GetActiveBehavior() == follow {
+		return distance > follow.StopDistance
+	}
+	return distance > follow.StartDistance
+}
+
+// Tick makes the mob walk to its owner, or teleports it next to its owner if the owner is too far away.
+func (follow *FollowOwner) Tick(mob *Mob, context *Context) {
+	var owner, ok = context.GetOwnerTarget(mob)
+	if !ok {
+		return
+	}
+	var position = owner.GetPosition()
+	if position.Distance(mob.GetBody().GetPosition()) > follow.TeleportDistance {
+		mob.Stop()
+		position.X += rand.Float64()*2 - 1
+		position.Z += rand.Float64()*2 - 1
+		mob.body.Move(position, mob.yaw, 0)
+		return
+	}
+	follow.lastPath = chase(mob, context, owner, follow.lastPath)
+}
+
+// NewTameableBehaviors returns the behaviors of tameable mobs, such as wolves and cats,
+// which wander around like passive mobs until tamed, after which they sit on command and follow their owner.
+func NewTameableBehaviors() []Behavior {
+	return append([]Behavior{NewSit(), NewFollowOwner(10, 2, 12)}, NewPassiveBehaviors()...)
+}
@@ -0,0 +1,27 @@
+package gomine
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/combat"
+	"github.com/BobbyShrd/gominetest/net"
+)
+
+// ArmorWindowId is the window ID of the armor inventory of a player.
+const ArmorWindowId uint32 = 120
+
+// protect returns the damage dealt by the source to the player of the target session, after its armor reduced it.
+// The armor takes durability damage, and changed armor slots, including pieces that broke, are sent to the player again.
+func (server *Server) protect(target *net.MinecraftSession, damage float32, source combat.Source) float32 {
+	var armor = target.GetPlayer().GetArmor()
+	var pieces = armor.GetContents()
+	var reduced = combat.ReduceDamage(damage, pieces, source, server.DamageOptions.Enchantments)
+
+	var random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	for _, slot := range combat.DamageArmor(pieces, damage, source, random) {
+		armor.SetItem(slot, pieces[slot])
+		target.SendInventorySlot(ArmorWindowId, uint32(slot), pieces[slot])
+	}
+	return reduced
+}
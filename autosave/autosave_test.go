@@ -0,0 +1,45 @@
+package autosave
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDirtyChunks(t *testing.T) {
+	var dirty = NewDirtyChunks()
+	dirty.Mark(1, 2)
+	dirty.Mark(1, 2)
+	dirty.Mark(-3, 4)
+	if dirty.Len() != 2 || !dirty.IsDirty(-3, 4) {
+		t.Fatalf("expected two dirty chunks, got %v", dirty.Len())
+	}
+	if positions := dirty.Take(); len(positions) != 2 {
+		t.Errorf("expected to take two chunks, got %v", positions)
+	}
+	if dirty.Len() != 0 || dirty.IsDirty(1, 2) {
+		t.Error("expected chunks to be clean after taking them")
+	}
+}
+
+func TestSaver(t *testing.T) {
+	var saver = NewSaver(2, 0)
+	var ran int
+	var operations = []Operation{
+		func() error { ran++; return nil },
+		func() error { ran++; return errors.New("failed") },
+		func() error {
+			ran++
+			if _, ok := saver.Save(nil); ok {
+				t.Error("expected saves not to overlap")
+			}
+			return nil
+		},
+	}
+	var errs, ok = saver.Save(operations)
+	if !ok || ran != 3 || len(errs) != 1 {
+		t.Errorf("expected three operations with one error, got %v operations and %v", ran, errs)
+	}
+	if saver.IsSaving() {
+		t.Error("expected save to be finished")
+	}
+}
@@ -0,0 +1,53 @@
+package autosave
+
+import "sync"
+
+// ChunkPosition is the position of a chunk in chunk coordinates.
+type ChunkPosition struct {
+	X, Z int32
+}
+
+// DirtyChunks keeps track of the chunks of a dimension that changed since they were last saved.
+type DirtyChunks struct {
+	mutex  sync.Mutex
+	chunks map[ChunkPosition]bool
+}
+
+// NewDirtyChunks returns a new set of dirty chunks without any chunks.
+func NewDirtyChunks() *DirtyChunks {
+	return &DirtyChunks{chunks: make(map[ChunkPosition]bool)}
+}
+
+// Mark marks the chunk at the given chunk coordinates as changed.
+func (dirty *DirtyChunks) Mark(x, z int32) {
+	dirty.mutex.Lock()
+	dirty.chunks[ChunkPosition{x, z}] = true
+	dirty.mutex.Unlock()
+}
+
+// IsDirty checks if the chunk at the given chunk coordinates changed since it was last saved.
+func (dirty *DirtyChunks) IsDirty(x, z int32) bool {
+	dirty.mutex.Lock()
+	defer dirty.mutex.Unlock()
+	return dirty.chunks[ChunkPosition{x, z}]
+}
+
+// Len returns the amount of dirty chunks.
+func (dirty *DirtyChunks) Len() int {
+	dirty.mutex.Lock()
+	defer dirty.mutex.Unlock()
+	return len(dirty.chunks)
+}
+
+// Take returns the positions of all dirty chunks and marks them as clean.
+// Chunks that fail to save should be marked again, so that they are saved next time.
+func (dirty *DirtyChunks) Take() []ChunkPosition {
+	dirty.mutex.Lock()
+	defer dirty.mutex.Unlock()
+	var positions = make([]ChunkPosition, 0, len(dirty.chunks))
+	for position := range dirty.chunks {
+		positions = append(positions, position)
+	}
+	dirty.chunks = make(map[ChunkPosition]bool)
+	return positions
+}
@@ -0,0 +1,64 @@
+package autosave
+
+import (
+	"sync"
+	"time"
+)
+
+// Operation is a single save operation, such as saving a chunk or the data of a player.
+type Operation func() error
+
+// Saver runs save operations in batches, pausing between batches so that
+// disk writes do not compete with the server tick for too long at once.
+// Only one save runs at a time.
+type Saver struct {
+	mutex  sync.Mutex
+	saving bool
+
+	// BatchSize is the amount of operations run before pausing. Operations are not batched if it is 0 or less.
+	BatchSize int
+	// Pause is the time paused between batches.
+	Pause time.Duration
+}
+
+// NewSaver returns a new saver running the given amount of operations per batch, pausing between batches.
+func NewSaver(batchSize int, pause time.Duration) *Saver {
+	return &Saver{BatchSize: batchSize, Pause: pause}
+}
+
+// IsSaving checks if a save is currently running.
+func (saver *Saver) IsSaving() bool {
+	saver.mutex.Lock()
+	defer saver.mutex.Unlock()
+	return saver.saving
+}
+
+// Save runs all operations and returns the errors of the operations that failed.
+// Save blocks until all operations ran, so it should be called off the main tick, for example in an asynchronous task.
+// If another save is still running, no operations are run and false is returned.
+func (saver *Saver) Save(operations []Operation) ([]error, bool) {
+	saver.mutex.Lock()
+	if saver.saving {
+		saver.mutex.Unlock()
+		return nil, false
+	}
+	saver.saving = true
+	saver.mutex.Unlock()
+
+	defer func() {
+		saver.mutex.Lock()
+		saver.saving = false
+		saver.mutex.Unlock()
+	}()
+
+	var errs []error
+	for i, operation := range operations {
+		if i != 0 && saver.BatchSize > 0 && i%saver.BatchSize == 0 {
+			time.Sleep(saver.Pause)
+		}
+		if err := operation(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs, true
+}
@@ -0,0 +1,169 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/blockentities"
+	"github.com/BobbyShrd/gominetest/combat"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/binutils"
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+	"github.com/irmine/worlds/chunks"
+)
+
+// Legacy block IDs of unlit and lit furnaces.
+const (
+	furnaceBlockId    = 61
+	litFurnaceBlockId = 62
+)
+
+// GetBlockEntityManager returns the block entity manager of the given dimension.
+// A new manager is created if the dimension did not yet have one.
+func (server *Server) GetBlockEntityManager(dimension *worlds.Dimension) *blockentities.Manager {
+	server.blockEntityMutex.Lock()
+	defer server.blockEntityMutex.Unlock()
+	var manager, ok = server.blockEntityManagers[dimension]
+	if !ok {
+		manager = blockentities.NewManager()
+		manager.TickLimit = server.Config.MaxBlockEntityTicks
+		manager.FurnaceLitFunction = func(position blocks.Position, lit bool) {
+			server.setFurnaceLit(dimension, position, lit)
+		}
+		manager.CollectItemsFunction = func(position blocks.Position, collect func(stack *items.Stack) bool) {
+			server.collectItems(dimension, position, collect)
+		}
+		server.blockEntityManagers[dimension] = manager
+	}
+	return manager
+}
+
+// SetBlockEntityStore makes the block entities of the dimension persist in the given directory,
+// saving them with the chunk they are in.
+func (server *Server) SetBlockEntityStore(dimension *worlds.Dimension, directory string) {
+	server.GetBlockEntityManager(dimension).SetStore(blockentities.NewStore(directory))
+}
+
+// NewHopper returns a new hopper at the given position,
+// using the transfer cooldown specified in the configuration.
+// The hopper still has to be added to the block entity manager of a dimension.
+// Hopper blocks get a hopper when they are first opened, like chests, after which it keeps moving items.
+func (server *Server) NewHopper(position blocks.Position, facing byte) *blockentities.Hopper {
+	var cooldown = server.Config.HopperTransferCooldown
+	if cooldown <= 0 {
+		cooldown = blockentities.DefaultTransferCooldown
+	}
+	return blockentities.NewHopper(position, facing, cooldown)
+}
+
+// AddBlockEntity adds a block entity to the block entity manager of the dimension,
+// and sends it to all viewers of the dimension if it can be serialized.
+func (server *Server) AddBlockEntity(dimension *worlds.Dimension, blockEntity blockentities.BlockEntity) {
+	server.GetBlockEntityManager(dimension).Add(blockEntity)
+	server.UpdateBlockEntity(dimension, blockEntity)
+}
+
+// UpdateBlockEntity sends the data of the block entity to all viewers of the dimension.
+// Nothing is done if the block entity can not be serialized.
+func (server *Server) UpdateBlockEntity(dimension *worlds.Dimension, blockEntity blockentities.BlockEntity) {
+	var serializable, ok = blockEntity.(blockentities.Serializable)
+	if !ok {
+		return
+	}
+	var namedTag = encodeBlockEntity(serializable)
+	for _, viewer := range dimension.GetViewers() {
+		if session, ok := viewer.(*net.MinecraftSession); ok {
+			session.SendBlockEntityData(serializable.GetPosition(), namedTag)
+		}
+	}
+}
+
+// sendChunkBlockEntities sends the block entities in a chunk loaded by a session.
+func (server *Server) sendChunkBlockEntities(session *net.MinecraftSession, chunk *chunks.Chunk) {
+	var manager = server.GetBlockEntityManager(session.GetPlayer().GetDimension())
+	for _, blockEntity := range manager.GetInChunk(chunk.X, chunk.Z) {
+		if serializable, ok := blockEntity.(blockentities.Serializable); ok {
+			session.SendBlockEntityData(serializable.GetPosition(), encodeBlockEntity(serializable))
+		}
+	}
+}
+
+// editSign sets the text of the sign at the position to the text written by the session.
+// Edits are only accepted for sign blocks within reach of the player. The block entity of the sign is created
+// if the sign block did not have one yet. A refused edit is undone by sending the sign to the session again.
+func (server *Server) editSign(session *net.MinecraftSession, position blocks.Position, signText string) {
+	var player = session.GetPlayer()
+	var dimension = player.GetDimension()
+	var manager = server.GetBlockEntityManager(dimension)
+	var blockEntity, exists = manager.Get(position)
+	var sign, isSign = blockEntity.(*blockentities.Sign)
+	var center = r3.Vector{X: float64(position.X) + 0.5, Y: float64(position.Y) + 0.5, Z: float64(position.Z) + 0.5}
+	if !isSignBlock(server.GetDimensionWorld(dimension).GetBlockName(position)) || (exists && !isSign) || !combat.InReach(player.Position, center) {
+		if serializable, ok := blockEntity.(blockentities.Serializable); ok {
+			session.SendBlockEntityData(position, encodeBlockEntity(serializable))
+		}
+		return
+	}
+	var command = server.CommandSignManager.ParseSign(session, signText)
+	if !exists {
+		sign = blockentities.NewSign(position, signText)
+		sign.Command = command
+		server.AddBlockEntity(dimension, sign)
+		return
+	}
+	sign.Text = signText
+	sign.Command = command
+	server.UpdateBlockEntity(dimension, sign)
+}
+
+// isSignBlock checks if the block with the given name is a sign.
+func isSignBlock(name string) bool {
+	for _, sign := range SignBlocks {
+		if name == sign {
+			return true
+		}
+	}
+	return false
+}
+
+// setFurnaceLit changes the furnace block at the position to a lit or unlit furnace,
+// keeping the direction the furnace is facing.
+func (server *Server) setFurnaceLit(dimension *worlds.Dimension, position blocks.Position, lit bool) {
+	var world = server.GetDimensionWorld(dimension)
+	var data = world.GetBlockData(position)
+	if lit {
+		world.PlaceBlock(position, "lit_furnace", litFurnaceBlockId, data)
+	} else {
+		world.PlaceBlock(position, "furnace", furnaceBlockId, data)
+	}
+}
+
+// encodeBlockEntity returns the block entity encoded as little endian NBT, as sent to clients.
+func encodeBlockEntity(blockEntity blockentities.Serializable) []byte {
+	return encodeNBT(blockentities.ToNBT(blockEntity))
+}
+
+// encodeNBT returns the compound encoded as little endian network NBT, as sent to clients.
+func encodeNBT(compound *gonbt.Compound) []byte {
+	var writer = gonbt.NewWriter(true, binutils.LittleEndian)
+	writer.WriteUncompressedCompound(compound)
+	return writer.GetData()
+}
+
+// tickBlockEntities ticks the block entities of all dimensions.
+func (server *Server) tickBlockEntities() {
+	server.blockEntityMutex.Lock()
+	var managers = make(map[*worlds.Dimension]*blockentities.Manager, len(server.blockEntityManagers))
+	for dimension, manager := range server.blockEntityManagers {
+		managers[dimension] = manager
+	}
+	server.blockEntityMutex.Unlock()
+
+	for dimension, manager := range managers {
+		if deferred := manager.Tick(); deferred > 0 {
+			server.Metrics.DeferredBlockEntityTicks.Add(uint64(deferred))
+			server.warnTickBudget("block entity", dimension, deferred)
+		}
+	}
+}
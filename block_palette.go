@@ -0,0 +1,52 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/info"
+	"github.com/BobbyShrd/gominetest/palette"
+	"github.com/irmine/worlds/blocks"
+)
+
+// getRuntimeId returns the runtime ID of the block with the given legacy ID and data value in the palette of the latest protocol.
+// The built-in runtime ID table is used if no palettes are loaded.
+func getRuntimeId(palettes *palette.Manager, id int, data int) (uint32, bool) {
+	if blockPalette, ok := palettes.Get(int32(info.LatestProtocol)); ok {
+		return blockPalette.GetLegacyRuntimeId(id, data)
+	}
+	var runtimeId, ok = blocks.GetRuntimeId(id, data)
+	return uint32(runtimeId), ok
+}
+
+// GetLegacyBlock returns the legacy ID and data value of the block state in the palette of the latest protocol,
+// and a bool indicating if the state has a legacy ID. States without properties are looked up by name,
+// keeping their legacy data value. Nothing is found if no palettes are loaded.
+func (server *Server) GetLegacyBlock(state palette.State) (byte, byte, bool) {
+	var blockPalette, ok = server.BlockPalettes.Get(int32(info.LatestProtocol))
+	if !ok {
+		return 0, 0, false
+	}
+	if len(state.Properties) == 0 {
+		var id, ok = blockPalette.GetLegacyId(state.Name)
+		return byte(id), byte(state.LegacyData), ok
+	}
+	runtimeId, ok := blockPalette.GetRuntimeId(state)
+	if !ok {
+		return 0, 0, false
+	}
+	state, _ = blockPalette.GetState(runtimeId)
+	return byte(state.LegacyId), byte(state.LegacyData), state.LegacyId >= 0
+}
+
+// GetBlockRuntimeId returns the runtime ID of the block with the given legacy ID and data value.
+func (server *Server) GetBlockRuntimeId(id int, data int) (uint32, bool) {
+	return getRuntimeId(server.BlockPalettes, id, data)
+}
+
+// GetRuntimeIdsTable returns the runtime ID table sent to the session when it joins,
+// which is the palette of the protocol of the session. The built-in table is used if the protocol has no palette.
+func (server *Server) GetRuntimeIdsTable(session *net.MinecraftSession) []byte {
+	if blockPalette, ok := server.BlockPalettes.Get(session.GetProtocolNumber()); ok {
+		return blockPalette.EncodeTable()
+	}
+	return blocks.GetRuntimeIdsTable()
+}
@@ -0,0 +1,65 @@
+package blockentities
+
+import (
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	BannerId = "Banner"
+
+	TagBase     = "Base"
+	TagPatterns = "Patterns"
+	TagColor    = "Color"
+	TagPattern  = "Pattern"
+)
+
+// Pattern is a pattern drawn on a banner.
+type Pattern struct {
+	// Pattern is the short ID of the pattern, for example "bri" for a bordure indented.
+	Pattern string
+	// Color is the dye color of the pattern.
+	Color int32
+}
+
+// Banner is a banner with a base color and patterns drawn on it.
+type Banner struct {
+	*Base
+
+	// Color is the dye color of the base of the banner.
+	Color int32
+	// Patterns are the patterns of the banner, in the order they are drawn.
+	Patterns []Pattern
+}
+
+// NewBanner returns a new banner without patterns with the given base color at the given position.
+func NewBanner(position blocks.Position, color int32) *Banner {
+	return &Banner{Base: NewBase(BannerId, position), Color: color}
+}
+
+// WriteNBT writes the base color and patterns of the banner to the compound.
+func (banner *Banner) WriteNBT(compound *gonbt.Compound) {
+	compound.SetInt(TagBase, banner.Color)
+	var patterns = make([]gonbt.INamedTag, len(banner.Patterns))
+	for i, pattern := range banner.Patterns {
+		patterns[i] = gonbt.NewCompound("", map[string]gonbt.INamedTag{
+			TagPattern: gonbt.NewString(TagPattern, pattern.Pattern),
+			TagColor:   gonbt.NewInt(TagColor, pattern.Color),
+		})
+	}
+	compound.SetList(TagPatterns, gonbt.TAG_Compound, patterns)
+}
+
+// ReadNBT reads the base color and patterns of the banner from the compound.
+func (banner *Banner) ReadNBT(compound *gonbt.Compound) {
+	banner.Color = compound.GetInt(TagBase, 0)
+	banner.Patterns = nil
+	if !compound.HasTagWithType(TagPatterns, gonbt.TAG_List) {
+		return
+	}
+	for _, tag := range compound.GetList(TagPatterns, gonbt.TAG_Compound).GetTags() {
+		if pattern, ok := tag.(*gonbt.Compound); ok {
+			banner.Patterns = append(banner.Patterns, Pattern{pattern.GetString(TagPattern, ""), pattern.GetInt(TagColor, 0)})
+		}
+	}
+}
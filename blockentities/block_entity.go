@@ -0,0 +1,42 @@
+package blockentities
+
+import (
+	"github.com/irmine/worlds/blocks"
+)
+
+// BlockEntity is a block with additional data and behaviour,
+// such as the items of a chest or the text of a sign.
+type BlockEntity interface {
+	// GetId returns the save ID of the block entity, for example "Hopper".
+	GetId() string
+	// GetPosition returns the position of the block the block entity belongs to.
+	GetPosition() blocks.Position
+	// Tick ticks the block entity once every server tick.
+	// The manager passed is the manager of the dimension the block entity is in.
+	Tick(manager *Manager)
+}
+
+// Base is a struct implementing the basic functions of block entities.
+// Base should be embedded in block entity implementations.
+type Base struct {
+	id       string
+	position blocks.Position
+}
+
+// NewBase returns a new block entity base with the given save ID and position.
+func NewBase(id string, position blocks.Position) *Base {
+	return &Base{id, position}
+}
+
+// GetId returns the save ID of the block entity.
+func (base *Base) GetId() string {
+	return base.id
+}
+
+// GetPosition returns the position of the block entity.
+func (base *Base) GetPosition() blocks.Position {
+	return base.position
+}
+
+// Tick does nothing by default.
+func (base *Base) Tick(*Manager) {}
@@ -0,0 +1,133 @@
+package blockentities
+
+import (
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	ChestId         = "Chest"
+	ChestSize       = 27
+	DoubleChestSize = ChestSize * 2
+
+	TagPairX    = "pairx"
+	TagPairZ    = "pairz"
+	TagPairLead = "pairlead"
+)
+
+// Chest is a container storing items.
+// Two chests next to each other may be paired, forming a double chest.
+type Chest struct {
+	*Base
+	*Inventory
+
+	paired bool
+	pair   blocks.Position
+	lead   bool
+}
+
+// NewChest returns a new empty chest at the given position.
+func NewChest(position blocks.Position) *Chest {
+	return &Chest{Base: NewBase(ChestId, position), Inventory: NewInventory(ChestSize)}
+}
+
+// PairWith pairs the chest with another chest, forming a double chest.
+// The chest becomes the lead of the double chest, which holds the first half of its slots.
+func (chest *Chest) PairWith(other *Chest) {
+	chest.paired, chest.pair, chest.lead = true, other.GetPosition(), true
+	other.paired, other.pair, other.lead = true, chest.GetPosition(), false
+}
+
+// Unpair removes the pair of the chest.
+// The chest it was paired with should be unpaired as well.
+func (chest *Chest) Unpair() {
+	chest.paired, chest.lead = false, false
+}
+
+// GetPair returns the position of the chest this chest is paired with,
+// and a bool indicating if the chest is paired.
+func (chest *Chest) GetPair() (blocks.Position, bool) {
+	return chest.pair, chest.paired
+}
+
+// IsLead checks if the chest is the lead of a double chest.
+func (chest *Chest) IsLead() bool {
+	return chest.lead
+}
+
+// WriteNBT writes the items and the pair of the chest to the compound.
+func (chest *Chest) WriteNBT(compound *gonbt.Compound) {
+	chest.WriteItems(compound)
+	if chest.paired {
+		compound.SetInt(TagPairX, chest.pair.X)
+		compound.SetInt(TagPairZ, chest.pair.Z)
+		var lead byte
+		if chest.lead {
+			lead = 1
+		}
+		compound.SetByte(TagPairLead, lead)
+	}
+}
+
+// ReadNBT reads the items and the pair of the chest from the compound.
+func (chest *Chest) ReadNBT(compound *gonbt.Compound) {
+	chest.ReadItems(compound)
+	chest.paired = compound.HasTagWithType(TagPairX, gonbt.TAG_Int) && compound.HasTagWithType(TagPairZ, gonbt.TAG_Int)
+	if chest.paired {
+		chest.pair = blocks.NewPosition(compound.GetInt(TagPairX, 0), chest.GetPosition().Y, compound.GetInt(TagPairZ, 0))
+		chest.lead = compound.GetByte(TagPairLead, 0) == 1
+	}
+}
+
+// DoubleChest is the container formed by two paired chests.
+// The slots of the lead chest come first, followed by the slots of the other chest.
+type DoubleChest struct {
+	lead  *Chest
+	other *Chest
+}
+
+// NewDoubleChest returns a new double chest formed by the lead chest and the other chest.
+func NewDoubleChest(lead *Chest, other *Chest) *DoubleChest {
+	return &DoubleChest{lead, other}
+}
+
+// GetId returns the save ID of chests.
+func (chest *DoubleChest) GetId() string {
+	return ChestId
+}
+
+// GetPosition returns the position of the lead chest.
+func (chest *DoubleChest) GetPosition() blocks.Position {
+	return chest.lead.GetPosition()
+}
+
+// GetChests returns the lead chest and the other chest of the double chest.
+func (chest *DoubleChest) GetChests() (*Chest, *Chest) {
+	return chest.lead, chest.other
+}
+
+// Tick does nothing, as the chests of the double chest are ticked by themselves.
+func (chest *DoubleChest) Tick(*Manager) {}
+
+// GetSize returns the amount of slots of both chests.
+func (chest *DoubleChest) GetSize() int {
+	return DoubleChestSize
+}
+
+// GetItem returns the item stack in the given slot of the double chest.
+func (chest *DoubleChest) GetItem(slot int) *items.Stack {
+	if slot >= ChestSize {
+		return chest.other.GetItem(slot - ChestSize)
+	}
+	return chest.lead.GetItem(slot)
+}
+
+// SetItem sets the item stack in the given slot of the double chest.
+func (chest *DoubleChest) SetItem(slot int, stack *items.Stack) {
+	if slot >= ChestSize {
+		chest.other.SetItem(slot-ChestSize, stack)
+		return
+	}
+	chest.lead.SetItem(slot, stack)
+}
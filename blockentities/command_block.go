@@ -0,0 +1,64 @@
+package blockentities
+
+import (
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	CommandBlockId = "CommandBlock"
+
+	TagCommand      = "Command"
+	TagLastOutput   = "LastOutput"
+	TagSuccessCount = "SuccessCount"
+	TagTrackOutput  = "TrackOutput"
+	TagPowered      = "powered"
+)
+
+// CommandBlock is a command block, which executes its command once every time it gets powered by redstone.
+type CommandBlock struct {
+	*Base
+
+	// Command is the command executed by the command block, which may be prefixed with a slash.
+	Command string
+	// LastOutput is the output of the last execution of the command, if TrackOutput is true.
+	LastOutput string
+	// SuccessCount is the success count of the last execution of the command.
+	SuccessCount int32
+	// TrackOutput indicates if the output of the command is kept in LastOutput.
+	TrackOutput bool
+	// Powered indicates if the command block is powered by redstone.
+	// The command is only executed when the command block goes from unpowered to powered.
+	Powered bool
+}
+
+// NewCommandBlock returns a new command block without a command at the given position.
+func NewCommandBlock(position blocks.Position) *CommandBlock {
+	return &CommandBlock{Base: NewBase(CommandBlockId, position), TrackOutput: true}
+}
+
+// WriteNBT writes the command, the output of the last execution and the power of the command block to the compound.
+func (block *CommandBlock) WriteNBT(compound *gonbt.Compound) {
+	compound.SetString(TagCommand, block.Command)
+	compound.SetString(TagLastOutput, block.LastOutput)
+	compound.SetInt(TagSuccessCount, block.SuccessCount)
+	compound.SetByte(TagTrackOutput, boolByte(block.TrackOutput))
+	compound.SetByte(TagPowered, boolByte(block.Powered))
+}
+
+// ReadNBT reads the command, the output of the last execution and the power of the command block from the compound.
+func (block *CommandBlock) ReadNBT(compound *gonbt.Compound) {
+	block.Command = compound.GetString(TagCommand, "")
+	block.LastOutput = compound.GetString(TagLastOutput, "")
+	block.SuccessCount = compound.GetInt(TagSuccessCount, 0)
+	block.TrackOutput = compound.GetByte(TagTrackOutput, 1) != 0
+	block.Powered = compound.GetByte(TagPowered, 0) != 0
+}
+
+// boolByte returns 1 if the value is true, and 0 otherwise.
+func boolByte(value bool) byte {
+	if value {
+		return 1
+	}
+	return 0
+}
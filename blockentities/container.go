@@ -0,0 +1,121 @@
+package blockentities
+
+import (
+	"github.com/BobbyShrd/gominetest/items"
+)
+
+// Container is a block entity holding items, such as a chest or a hopper.
+// Empty slots are represented by nil item stacks.
+type Container interface {
+	BlockEntity
+	// GetSize returns the amount of slots of the container.
+	GetSize() int
+	// GetItem returns the item stack in the given slot, or nil if the slot is empty.
+	GetItem(slot int) *items.Stack
+	// SetItem sets the item stack in the given slot. Passing nil clears the slot.
+	SetItem(slot int, stack *items.Stack)
+}
+
+// Inventory is a fixed size slice of item stacks.
+// Inventory implements the item functions of the Container interface,
+// and should be embedded in container block entities.
+type Inventory struct {
+	slots []*items.Stack
+}
+
+// NewInventory returns a new empty inventory with the given amount of slots.
+func NewInventory(size int) *Inventory {
+	return &Inventory{make([]*items.Stack, size)}
+}
+
+// GetSize returns the amount of slots of the inventory.
+func (inventory *Inventory) GetSize() int {
+	return len(inventory.slots)
+}
+
+// GetItem returns the item stack in the given slot, or nil if the slot is empty or out of range.
+func (inventory *Inventory) GetItem(slot int) *items.Stack {
+	if slot < 0 || slot >= len(inventory.slots) {
+		return nil
+	}
+	return inventory.slots[slot]
+}
+
+// SetItem sets the item stack in the given slot.
+// Stacks with a count of 0 or less clear the slot.
+func (inventory *Inventory) SetItem(slot int, stack *items.Stack) {
+	if slot < 0 || slot >= len(inventory.slots) {
+		return
+	}
+	if stack != nil && stack.Count <= 0 {
+		stack = nil
+	}
+	inventory.slots[slot] = stack
+}
+
+// IsEmpty checks if all slots of the inventory are empty.
+func (inventory *Inventory) IsEmpty() bool {
+	for _, stack := range inventory.slots {
+		if stack != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// AddItem adds as many items of the stack to the container as possible.
+// Items are first stacked on existing stacks of the same item, and then put into empty slots,
+// without exceeding the maximum stack size of the item in any slot.
+// The count of the stack is decreased by the amount of items added,
+// and the amount of items added is returned.
+func AddItem(container Container, stack *items.Stack) int {
+	var added = 0
+	for slot := 0; slot < container.GetSize() && stack.Count > 0; slot++ {
+		var existing = container.GetItem(slot)
+		if existing == nil {
+			continue
+		}
+		if _, _, count := stack.StackOn(existing); count > 0 {
+			added += count
+			container.SetItem(slot, existing)
+		}
+	}
+	var maxStackSize = stack.GetMaximumStackSize()
+	if maxStackSize <= 0 {
+		maxStackSize = stack.Count
+	}
+	for slot := 0; slot < container.GetSize() && stack.Count > 0; slot++ {
+		if container.GetItem(slot) != nil {
+			continue
+		}
+		var moved = *stack
+		if moved.Count > maxStackSize {
+			moved.Count = maxStackSize
+		}
+		added += moved.Count
+		stack.Count -= moved.Count
+		container.SetItem(slot, &moved)
+	}
+	return added
+}
+
+// TransferItem moves a single item from the first non-empty slot
+// of the source container that fits into the target container.
+// Returns true if an item was moved.
+func TransferItem(source Container, target Container) bool {
+	for slot := 0; slot < source.GetSize(); slot++ {
+		var stack = source.GetItem(slot)
+		if stack == nil {
+			continue
+		}
+		var single = *stack
+		single.Count = 1
+		if AddItem(target, &single) == 0 {
+			continue
+		}
+		stack.Count--
+		source.SetItem(slot, stack)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package blockentities
+
+import (
+	"testing"
+
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/irmine/worlds/blocks"
+)
+
+func TestHopperTransfer(t *testing.T) {
+	var manager = NewManager()
+	var source = NewHopper(blocks.NewPosition(0, 1, 0), FaceDown, 0)
+	var target = NewHopper(blocks.NewPosition(0, 0, 0), FaceDown, 0)
+	manager.Add(source)
+	manager.Add(target)
+
+	var stone, _ = items.DefaultManager.Get("minecraft:stone", 3)
+	if AddItem(source, stone) != 3 {
+		t.Fatal("expected 3 items to be added")
+	}
+
+	source.Tick(manager)
+	if source.GetItem(0).Count != 2 || target.GetItem(0).Count != 1 {
+		t.Error("expected 1 item to be pushed into the hopper below")
+	}
+
+	source.Tick(manager)
+	source.Tick(manager)
+	if !source.IsEmpty() || target.GetItem(0).Count != 3 {
+		t.Error("expected all items to be pushed into the hopper below")
+	}
+}
+
+func TestAddItem(t *testing.T) {
+	var chest = NewChest(blocks.NewPosition(0, 0, 0))
+	var partial, _ = items.DefaultManager.Get("minecraft:stone", 60)
+	chest.SetItem(1, partial)
+
+	var stone, _ = items.DefaultManager.Get("minecraft:stone", 74)
+	if added := AddItem(chest, stone); added != 74 || stone.Count != 0 {
+		t.Fatalf("expected all 74 items to be added, got %v with %v left", added, stone.Count)
+	}
+	if chest.GetItem(1).Count != 64 {
+		t.Errorf("expected the partially filled slot to be filled up, got %v", chest.GetItem(1).Count)
+	}
+	if chest.GetItem(0).Count != 64 || chest.GetItem(2).Count != 6 {
+		t.Errorf("expected the remainder to be split over empty slots, got %v and %v", chest.GetItem(0).Count, chest.GetItem(2).Count)
+	}
+}
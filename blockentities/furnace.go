@@ -0,0 +1,176 @@
+package blockentities
+
+import (
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	FurnaceId   = "Furnace"
+	FurnaceSize = 3
+
+	// SmeltTime is the amount of ticks it takes a furnace to smelt an item.
+	SmeltTime = 200
+
+	TagBurnTime     = "BurnTime"
+	TagBurnDuration = "BurnDuration"
+	TagCookTime     = "CookTime"
+)
+
+// Slots of a furnace.
+const (
+	FurnaceInput = iota
+	FurnaceFuel
+	FurnaceResult
+)
+
+// SmeltingRecipes maps the string IDs of items to the string IDs of the items they get smelted into.
+var SmeltingRecipes = map[string]string{
+	"minecraft:cobblestone": "minecraft:stone",
+	"minecraft:sand":        "minecraft:glass",
+	"minecraft:iron_ore":    "minecraft:iron_ingot",
+	"minecraft:gold_ore":    "minecraft:gold_ingot",
+	"minecraft:oak_log":     "minecraft:charcoal",
+	"minecraft:clay_ball":   "minecraft:brick",
+	"minecraft:beef":        "minecraft:cooked_beef",
+	"minecraft:porkchop":    "minecraft:cooked_porkchop",
+	"minecraft:chicken":     "minecraft:cooked_chicken",
+	"minecraft:potato":      "minecraft:baked_potato",
+}
+
+// FuelDurations maps the string IDs of fuel items to the amount of ticks they burn.
+var FuelDurations = map[string]int{
+	"minecraft:coal":       1600,
+	"minecraft:charcoal":   1600,
+	"minecraft:coal_block": 16000,
+	"minecraft:oak_log":    300,
+	"minecraft:oak_planks": 300,
+	"minecraft:stick":      100,
+	"minecraft:bamboo":     50,
+}
+
+// Furnace is a container which smelts items using fuel.
+type Furnace struct {
+	*Base
+	*Inventory
+
+	burnTime     int
+	burnDuration int
+	cookTime     int
+}
+
+// NewFurnace returns a new empty, unlit furnace at the given position.
+func NewFurnace(position blocks.Position) *Furnace {
+	return &Furnace{Base: NewBase(FurnaceId, position), Inventory: NewInventory(FurnaceSize)}
+}
+
+// IsLit checks if the furnace is burning fuel.
+func (furnace *Furnace) IsLit() bool {
+	return furnace.burnTime > 0
+}
+
+// GetBurnTime returns the amount of ticks the current fuel keeps burning.
+func (furnace *Furnace) GetBurnTime() int {
+	return furnace.burnTime
+}
+
+// GetCookTime returns the amount of ticks the current input item has been smelting.
+func (furnace *Furnace) GetCookTime() int {
+	return furnace.cookTime
+}
+
+// getResult returns the item the input of the furnace smelts into.
+// A bool is returned which is false if the input can not be smelted, or the result does not fit in the result slot.
+func (furnace *Furnace) getResult() (*items.Stack, bool) {
+	var input = furnace.GetItem(FurnaceInput)
+	if input == nil {
+		return nil, false
+	}
+	var id, ok = SmeltingRecipes[input.GetId()]
+	if !ok {
+		return nil, false
+	}
+	result, ok := items.DefaultManager.Get(id, 1)
+	if !ok {
+		return nil, false
+	}
+	if existing := furnace.GetItem(FurnaceResult); existing != nil {
+		if canStack, count := result.CanStackOn(existing); !canStack || count == 0 {
+			return nil, false
+		}
+	}
+	return result, true
+}
+
+// Tick burns fuel and smelts the input of the furnace.
+// A new fuel item is only burned if there is an item that can be smelted.
+func (furnace *Furnace) Tick(manager *Manager) {
+	var wasLit = furnace.IsLit()
+	var result, canSmelt = furnace.getResult()
+	if !furnace.IsLit() && canSmelt {
+		furnace.burnFuel()
+	}
+
+	if furnace.IsLit() {
+		furnace.burnTime--
+		if canSmelt {
+			furnace.cookTime++
+			if furnace.cookTime >= SmeltTime {
+				furnace.smelt(result)
+				furnace.cookTime = 0
+			}
+		} else {
+			furnace.cookTime = 0
+		}
+	} else {
+		furnace.cookTime = 0
+	}
+
+	if furnace.IsLit() != wasLit && manager.FurnaceLitFunction != nil {
+		manager.FurnaceLitFunction(furnace.GetPosition(), furnace.IsLit())
+	}
+}
+
+// burnFuel consumes a fuel item, if the fuel slot holds one.
+func (furnace *Furnace) burnFuel() {
+	var fuel = furnace.GetItem(FurnaceFuel)
+	if fuel == nil {
+		return
+	}
+	var duration, ok = FuelDurations[fuel.GetId()]
+	if !ok {
+		return
+	}
+	furnace.burnTime, furnace.burnDuration = duration, duration
+	fuel.Count--
+	furnace.SetItem(FurnaceFuel, fuel)
+}
+
+// smelt moves an item from the input slot to the result slot as the given result.
+func (furnace *Furnace) smelt(result *items.Stack) {
+	var input = furnace.GetItem(FurnaceInput)
+	input.Count--
+	furnace.SetItem(FurnaceInput, input)
+	if existing := furnace.GetItem(FurnaceResult); existing != nil {
+		existing.Count++
+		return
+	}
+	furnace.SetItem(FurnaceResult, result)
+}
+
+// WriteNBT writes the items and the burning progress of the furnace to the compound.
+func (furnace *Furnace) WriteNBT(compound *gonbt.Compound) {
+	furnace.WriteItems(compound)
+	compound.SetShort(TagBurnTime, int16(furnace.burnTime))
+	compound.SetShort(TagBurnDuration, int16(furnace.burnDuration))
+	compound.SetShort(TagCookTime, int16(furnace.cookTime))
+}
+
+// ReadNBT reads the items and the burning progress of the furnace from the compound.
+func (furnace *Furnace) ReadNBT(compound *gonbt.Compound) {
+	furnace.ReadItems(compound)
+	furnace.burnTime = int(compound.GetShort(TagBurnTime, 0))
+	furnace.burnDuration = int(compound.GetShort(TagBurnDuration, 0))
+	furnace.cookTime = int(compound.GetShort(TagCookTime, 0))
+}
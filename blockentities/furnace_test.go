@@ -0,0 +1,82 @@
+package blockentities
+
+import (
+	"testing"
+
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/irmine/worlds/blocks"
+)
+
+func TestFurnaceSmelting(t *testing.T) {
+	var manager = NewManager()
+	var furnace = NewFurnace(blocks.NewPosition(0, 0, 0))
+	var lit []bool
+	manager.FurnaceLitFunction = func(position blocks.Position, value bool) {
+		lit = append(lit, value)
+	}
+
+	var ore, _ = items.DefaultManager.Get("minecraft:iron_ore", 3)
+	var stick, _ = items.DefaultManager.Get("minecraft:stick", 1)
+	furnace.SetItem(FurnaceInput, ore)
+	furnace.SetItem(FurnaceFuel, stick)
+
+	for i := 0; i < SmeltTime; i++ {
+		furnace.Tick(manager)
+	}
+	// A stick only burns for 100 ticks, so nothing is smelted.
+	if furnace.GetItem(FurnaceResult) != nil || furnace.IsLit() {
+		t.Fatal("expected furnace to run out of fuel before smelting")
+	}
+	if len(lit) != 2 || !lit[0] || lit[1] {
+		t.Errorf("expected furnace to be lit and unlit once, got %v", lit)
+	}
+
+	var coal, _ = items.DefaultManager.Get("minecraft:coal", 1)
+	furnace.SetItem(FurnaceFuel, coal)
+	for i := 0; i < SmeltTime*3; i++ {
+		furnace.Tick(manager)
+	}
+	var result = furnace.GetItem(FurnaceResult)
+	if result == nil || result.GetId() != "minecraft:iron_ingot" || result.Count != 3 {
+		t.Fatalf("expected 3 iron ingots, got %v", result)
+	}
+	if furnace.GetItem(FurnaceInput) != nil || furnace.GetItem(FurnaceFuel) != nil {
+		t.Error("expected input and fuel to be consumed")
+	}
+}
+
+func TestNBT(t *testing.T) {
+	var chest = NewChest(blocks.NewPosition(1, 2, 3))
+	var stone, _ = items.DefaultManager.Get("minecraft:stone", 5)
+	chest.SetItem(4, stone)
+
+	var blockEntity, ok = FromNBT(ToNBT(chest))
+	if !ok {
+		t.Fatal("expected chest to be read")
+	}
+	var read, isChest = blockEntity.(*Chest)
+	if !isChest || read.GetPosition() != chest.GetPosition() {
+		t.Fatalf("expected chest at %v, got %v", chest.GetPosition(), blockEntity)
+	}
+	if item := read.GetItem(4); item == nil || item.GetId() != "minecraft:stone" || item.Count != 5 {
+		t.Errorf("expected 5 stone in slot 4, got %v", item)
+	}
+
+	var banner = NewBanner(blocks.NewPosition(0, 0, 0), 15)
+	banner.Patterns = []Pattern{{"bri", 1}, {"cre", 4}}
+	blockEntity, _ = FromNBT(ToNBT(banner))
+	if read := blockEntity.(*Banner); read.Color != 15 || len(read.Patterns) != 2 || read.Patterns[1] != (Pattern{"cre", 4}) {
+		t.Errorf("expected banner to be read with its patterns, got %+v", read)
+	}
+
+	var commandBlock = NewCommandBlock(blocks.NewPosition(0, 0, 0))
+	commandBlock.Command, commandBlock.SuccessCount, commandBlock.Powered = "say hi", 1, true
+	blockEntity, _ = FromNBT(ToNBT(commandBlock))
+	if read := blockEntity.(*CommandBlock); read.Command != "say hi" || read.SuccessCount != 1 || !read.Powered || !read.TrackOutput {
+		t.Errorf("expected command block to be read with its command and power, got %+v", read)
+	}
+
+	if _, ok := New("Unknown", blocks.NewPosition(0, 0, 0)); ok {
+		t.Error("expected unknown ID not to be registered")
+	}
+}
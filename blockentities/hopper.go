@@ -0,0 +1,155 @@
+package blockentities
+
+import (
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	HopperId   = "Hopper"
+	HopperSize = 5
+
+	// DefaultTransferCooldown is the amount of ticks a vanilla hopper waits after moving an item.
+	DefaultTransferCooldown = 8
+
+	TagTransferCooldown = "TransferCooldown"
+	// TagFacing holds the face the hopper outputs to. It is not part of vanilla hoppers, which read it from their block,
+	// but keeps hoppers loaded from the store outputting to the same face.
+	TagFacing = "Facing"
+)
+
+// Faces a hopper can output to, as stored in the block data of hoppers.
+const (
+	FaceDown  byte = 0
+	FaceNorth byte = 2
+	FaceSouth byte = 3
+	FaceWest  byte = 4
+	FaceEast  byte = 5
+)
+
+// Hopper is a container which moves items between containers.
+// Every transfer, a hopper pushes an item into the container it is facing,
+// pulls an item from the container above it, and collects item entities above it.
+type Hopper struct {
+	*Base
+	*Inventory
+
+	// TransferCooldown is the amount of ticks the hopper waits after moving items.
+	TransferCooldown int
+
+	facing   byte
+	cooldown int
+}
+
+// NewHopper returns a new empty hopper at the given position, outputting to the given face.
+func NewHopper(position blocks.Position, facing byte, transferCooldown int) *Hopper {
+	return &Hopper{NewBase(HopperId, position), NewInventory(HopperSize), transferCooldown, facing, 0}
+}
+
+// GetFacing returns the face the hopper outputs items to.
+func (hopper *Hopper) GetFacing() byte {
+	return hopper.facing
+}
+
+// SetFacing sets the face the hopper outputs items to.
+func (hopper *Hopper) SetFacing(facing byte) {
+	hopper.facing = facing
+}
+
+// GetFacingPosition returns the position of the block the hopper outputs items to.
+// A bool is returned which is false if the hopper is facing out of the world.
+func (hopper *Hopper) GetFacingPosition() (blocks.Position, bool) {
+	var position = hopper.GetPosition()
+	switch hopper.facing {
+	case FaceNorth:
+		position.Z--
+	case FaceSouth:
+		position.Z++
+	case FaceWest:
+		position.X--
+	case FaceEast:
+		position.X++
+	default:
+		if position.Y == 0 {
+			return position, false
+		}
+		position.Y--
+	}
+	return position, true
+}
+
+// WriteNBT writes the items, the remaining transfer cooldown and the facing of the hopper to the compound.
+func (hopper *Hopper) WriteNBT(compound *gonbt.Compound) {
+	hopper.WriteItems(compound)
+	compound.SetInt(TagTransferCooldown, int32(hopper.cooldown))
+	compound.SetByte(TagFacing, hopper.facing)
+}
+
+// ReadNBT reads the items, the remaining transfer cooldown and the facing of the hopper from the compound.
+func (hopper *Hopper) ReadNBT(compound *gonbt.Compound) {
+	hopper.ReadItems(compound)
+	hopper.cooldown = int(compound.GetInt(TagTransferCooldown, 0))
+	hopper.facing = compound.GetByte(TagFacing, FaceDown)
+}
+
+// Tick moves items if the transfer cooldown of the hopper has passed.
+func (hopper *Hopper) Tick(manager *Manager) {
+	if hopper.cooldown > 0 {
+		hopper.cooldown--
+		return
+	}
+
+	var moved = hopper.push(manager)
+	if hopper.pull(manager) {
+		moved = true
+	}
+	if hopper.collect(manager) {
+		moved = true
+	}
+	if moved {
+		hopper.cooldown = hopper.TransferCooldown
+	}
+}
+
+// push moves an item into the container the hopper is facing.
+func (hopper *Hopper) push(manager *Manager) bool {
+	var position, ok = hopper.GetFacingPosition()
+	if !ok {
+		return false
+	}
+	target, ok := manager.GetContainer(position)
+	if !ok {
+		return false
+	}
+	return TransferItem(hopper, target)
+}
+
+// pull moves an item from the container above the hopper into the hopper.
+func (hopper *Hopper) pull(manager *Manager) bool {
+	var position = hopper.GetPosition()
+	position.Y++
+	var source, ok = manager.GetContainer(position)
+	if !ok {
+		return false
+	}
+	return TransferItem(source, hopper)
+}
+
+// collect collects item entities inside of the block above the hopper.
+func (hopper *Hopper) collect(manager *Manager) bool {
+	if manager.CollectItemsFunction == nil {
+		return false
+	}
+	var position = hopper.GetPosition()
+	position.Y++
+
+	var collected = false
+	manager.CollectItemsFunction(position, func(stack *items.Stack) bool {
+		if AddItem(hopper, stack) > 0 {
+			collected = true
+		}
+		return stack.Count <= 0
+	})
+	return collected
+}
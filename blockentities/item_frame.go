@@ -0,0 +1,58 @@
+package blockentities
+
+import (
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	ItemFrameId = "ItemFrame"
+
+	// ItemFrameRotations is the amount of rotations an item in an item frame can have.
+	ItemFrameRotations = 8
+
+	TagItem           = "Item"
+	TagItemRotation   = "ItemRotation"
+	TagItemDropChance = "ItemDropChance"
+)
+
+// ItemFrame is a frame displaying a single item, which can be rotated.
+type ItemFrame struct {
+	*Base
+
+	// Item is the item displayed in the frame, or nil if the frame is empty.
+	Item *items.Stack
+	// Rotation is the rotation of the item, ranging from 0 to ItemFrameRotations.
+	Rotation byte
+}
+
+// NewItemFrame returns a new empty item frame at the given position.
+func NewItemFrame(position blocks.Position) *ItemFrame {
+	return &ItemFrame{Base: NewBase(ItemFrameId, position)}
+}
+
+// Rotate rotates the item in the frame once clockwise.
+func (frame *ItemFrame) Rotate() {
+	frame.Rotation = (frame.Rotation + 1) % ItemFrameRotations
+}
+
+// WriteNBT writes the item and its rotation to the compound.
+func (frame *ItemFrame) WriteNBT(compound *gonbt.Compound) {
+	if frame.Item == nil {
+		return
+	}
+	compound.SetCompound(TagItem, writeItem(frame.Item).GetTags())
+	compound.SetByte(TagItemRotation, frame.Rotation)
+	compound.SetFloat(TagItemDropChance, 1)
+}
+
+// ReadNBT reads the item and its rotation from the compound.
+func (frame *ItemFrame) ReadNBT(compound *gonbt.Compound) {
+	frame.Item, frame.Rotation = nil, compound.GetByte(TagItemRotation, 0)
+	if item := compound.GetCompound(TagItem); item != nil {
+		if stack, ok := readItem(item); ok {
+			frame.Item = stack
+		}
+	}
+}
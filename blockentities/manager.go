@@ -0,0 +1,281 @@
+package blockentities
+
+import (
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/irmine/worlds/blocks"
+)
+
+// Manager is a struct managing the block entities of a dimension.
+// Block entities are indexed by their position, and get ticked by the manager.
+type Manager struct {
+	mutex         sync.RWMutex
+	blockEntities map[blocks.Position]BlockEntity
+	queue         []BlockEntity
+	store         *Store
+	loaded        map[chunkPosition]bool
+
+	// TickLimit is the maximum amount of block entities ticked every tick.
+	// Block entities over the limit are ticked in the next ticks, before any block entity gets ticked again.
+	// All block entities are ticked every tick if TickLimit is 0 or less.
+	TickLimit int
+
+	// CollectItemsFunction gets called by hoppers to collect item entities
+	// inside of the block at the given position. The collect function passed
+	// should be called with the item stack of every item entity found, and
+	// returns true if the item entity was emptied and should be removed.
+	// Item entities are not collected if CollectItemsFunction is nil.
+	CollectItemsFunction func(position blocks.Position, collect func(stack *items.Stack) bool)
+	// FurnaceLitFunction gets called when a furnace at the given position starts or stops burning fuel,
+	// so the furnace block can be changed to a lit or unlit furnace. Nothing is done if nil.
+	FurnaceLitFunction func(position blocks.Position, lit bool)
+}
+
+// chunkPosition is the position of a chunk in chunk coordinates.
+type chunkPosition struct {
+	x, z int32
+}
+
+// NewManager returns a new block entity manager without any block entities.
+func NewManager() *Manager {
+	return &Manager{blockEntities: make(map[blocks.Position]BlockEntity), loaded: make(map[chunkPosition]bool)}
+}
+
+// SetStore makes the manager save the block entities of a chunk to the store when the chunk gets saved or unloaded,
+// and load them again when the chunk gets loaded. Block entities are not persisted if the store is nil.
+func (manager *Manager) SetStore(store *Store) {
+	manager.mutex.Lock()
+	manager.store = store
+	manager.mutex.Unlock()
+}
+
+// LoadChunk adds the block entities saved in the chunk at the given chunk coordinates.
+// Block entities already in the manager are kept. Nothing is loaded if the chunk was loaded before,
+// or if the manager has no store.
+func (manager *Manager) LoadChunk(x, z int32) error {
+	manager.mutex.Lock()
+	var store = manager.store
+	if store == nil || manager.loaded[chunkPosition{x, z}] {
+		manager.mutex.Unlock()
+		return nil
+	}
+	manager.loaded[chunkPosition{x, z}] = true
+	manager.mutex.Unlock()
+
+	var blockEntities, err = store.LoadChunk(x, z)
+	manager.mutex.Lock()
+	for _, blockEntity := range blockEntities {
+		if _, ok := manager.blockEntities[blockEntity.GetPosition()]; !ok {
+			manager.blockEntities[blockEntity.GetPosition()] = blockEntity
+		}
+	}
+	manager.mutex.Unlock()
+	return err
+}
+
+// SaveChunk saves the serializable block entities in the chunk at the given chunk coordinates to the store.
+// Nothing is saved if the manager has no store.
+func (manager *Manager) SaveChunk(x, z int32) error {
+	manager.mutex.RLock()
+	var store = manager.store
+	manager.mutex.RUnlock()
+	if store == nil {
+		return nil
+	}
+	var serializable []Serializable
+	for _, blockEntity := range manager.GetInChunk(x, z) {
+		if blockEntity, ok := blockEntity.(Serializable); ok {
+			serializable = append(serializable, blockEntity)
+		}
+	}
+	return store.SaveChunk(x, z, serializable)
+}
+
+// UnloadChunk saves the block entities in the chunk at the given chunk coordinates,
+// and removes them from the manager. Block entities are kept if the manager has no store,
+// as they could not be loaded again.
+func (manager *Manager) UnloadChunk(x, z int32) error {
+	manager.mutex.RLock()
+	var store = manager.store
+	manager.mutex.RUnlock()
+	if store == nil {
+		return nil
+	}
+	var err = manager.SaveChunk(x, z)
+	manager.mutex.Lock()
+	for position := range manager.blockEntities {
+		if position.X>>4 == x && position.Z>>4 == z {
+			delete(manager.blockEntities, position)
+		}
+	}
+	delete(manager.loaded, chunkPosition{x, z})
+	manager.mutex.Unlock()
+	return err
+}
+
+// SaveAll saves the block entities of all loaded chunks, and of all chunks holding block entities.
+// The first error that occurred is returned.
+func (manager *Manager) SaveAll() error {
+	manager.mutex.RLock()
+	var chunks = make(map[chunkPosition]bool, len(manager.loaded))
+	for chunk := range manager.loaded {
+		chunks[chunk] = true
+	}
+	for position := range manager.blockEntities {
+		chunks[chunkPosition{position.X >> 4, position.Z >> 4}] = true
+	}
+	manager.mutex.RUnlock()
+
+	var err error
+	for chunk := range chunks {
+		if saveErr := manager.SaveChunk(chunk.x, chunk.z); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}
+	return err
+}
+
+// Add adds a block entity to the manager,
+// overwriting any block entity at the same position.
+func (manager *Manager) Add(blockEntity BlockEntity) {
+	manager.mutex.Lock()
+	manager.blockEntities[blockEntity.GetPosition()] = blockEntity
+	manager.mutex.Unlock()
+}
+
+// Remove removes the block entity at the given position.
+// The chest a removed chest was paired with gets unpaired.
+// Returns true if a block entity was removed.
+func (manager *Manager) Remove(position blocks.Position) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var blockEntity, ok = manager.blockEntities[position]
+	delete(manager.blockEntities, position)
+	if chest, isChest := blockEntity.(*Chest); isChest {
+		if pairPosition, paired := chest.GetPair(); paired {
+			if pair, isChest := manager.blockEntities[pairPosition].(*Chest); isChest {
+				if pairedWith, _ := pair.GetPair(); pairedWith == position {
+					pair.Unpair()
+				}
+			}
+		}
+	}
+	return ok
+}
+
+// Get returns the block entity at the given position.
+// A bool is returned indicating if a block entity was found.
+func (manager *Manager) Get(position blocks.Position) (BlockEntity, bool) {
+	manager.mutex.RLock()
+	var blockEntity, ok = manager.blockEntities[position]
+	manager.mutex.RUnlock()
+	return blockEntity, ok
+}
+
+// GetContainer returns the container block entity at the given position.
+// A bool is returned indicating if a container was found.
+func (manager *Manager) GetContainer(position blocks.Position) (Container, bool) {
+	var blockEntity, ok = manager.Get(position)
+	if !ok {
+		return nil, false
+	}
+	container, ok := blockEntity.(Container)
+	return container, ok
+}
+
+// GetChest returns the container of the chest at the given position.
+// A double chest is returned if the chest is paired with another chest in the manager.
+// A bool is returned indicating if a chest was found.
+func (manager *Manager) GetChest(position blocks.Position) (Container, bool) {
+	var blockEntity, _ = manager.Get(position)
+	var chest, ok = blockEntity.(*Chest)
+	if !ok {
+		return nil, false
+	}
+	var pairPosition, paired = chest.GetPair()
+	if !paired {
+		return chest, true
+	}
+	pairEntity, _ := manager.Get(pairPosition)
+	var pair, isChest = pairEntity.(*Chest)
+	if !isChest {
+		return chest, true
+	}
+	if position, ok := pair.GetPair(); !ok || position != chest.GetPosition() {
+		return chest, true
+	}
+	if pair.IsLead() {
+		return NewDoubleChest(pair, chest), true
+	}
+	return NewDoubleChest(chest, pair), true
+}
+
+// PairChest pairs the chest with an unpaired chest next to it, if there is one.
+// The chest paired with is returned, or nil if the chest was not paired.
+func (manager *Manager) PairChest(chest *Chest) *Chest {
+	if _, paired := chest.GetPair(); paired {
+		return nil
+	}
+	var position = chest.GetPosition()
+	for _, offset := range [][2]int32{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+		var blockEntity, _ = manager.Get(blocks.NewPosition(position.X+offset[0], position.Y, position.Z+offset[1]))
+		if other, ok := blockEntity.(*Chest); ok {
+			if _, paired := other.GetPair(); !paired {
+				other.PairWith(chest)
+				return other
+			}
+		}
+	}
+	return nil
+}
+
+// GetAll returns all block entities of the manager.
+func (manager *Manager) GetAll() []BlockEntity {
+	manager.mutex.RLock()
+	var blockEntities = make([]BlockEntity, 0, len(manager.blockEntities))
+	for _, blockEntity := range manager.blockEntities {
+		blockEntities = append(blockEntities, blockEntity)
+	}
+	manager.mutex.RUnlock()
+	return blockEntities
+}
+
+// GetInChunk returns all block entities in the chunk with the given chunk coordinates.
+func (manager *Manager) GetInChunk(chunkX int32, chunkZ int32) []BlockEntity {
+	manager.mutex.RLock()
+	var blockEntities []BlockEntity
+	for position, blockEntity := range manager.blockEntities {
+		if position.X>>4 == chunkX && position.Z>>4 == chunkZ {
+			blockEntities = append(blockEntities, blockEntity)
+		}
+	}
+	manager.mutex.RUnlock()
+	return blockEntities
+}
+
+// Tick ticks the block entities of the manager.
+// Returns the amount of block entities deferred to the next ticks because of the tick limit.
+func (manager *Manager) Tick() int {
+	manager.mutex.Lock()
+	if len(manager.queue) == 0 {
+		for _, blockEntity := range manager.blockEntities {
+			manager.queue = append(manager.queue, blockEntity)
+		}
+	}
+	var blockEntities = manager.queue
+	if manager.TickLimit > 0 && len(blockEntities) > manager.TickLimit {
+		blockEntities = blockEntities[:manager.TickLimit]
+	}
+	manager.queue = manager.queue[len(blockEntities):]
+	var deferred = len(manager.queue)
+	manager.mutex.Unlock()
+
+	for _, blockEntity := range blockEntities {
+		// Block entities removed or replaced while waiting in the queue are no longer ticked.
+		if current, ok := manager.Get(blockEntity.GetPosition()); ok && current == blockEntity {
+			blockEntity.Tick(manager)
+		}
+	}
+	return deferred
+}
@@ -0,0 +1,44 @@
+package blockentities
+
+import (
+	"testing"
+
+	"github.com/irmine/worlds/blocks"
+)
+
+type counter struct {
+	*Base
+	ticks int
+}
+
+func (counter *counter) Tick(*Manager) {
+	counter.ticks++
+}
+
+func TestTickLimit(t *testing.T) {
+	var manager = NewManager()
+	manager.TickLimit = 2
+	var counters []*counter
+	for i := int32(0); i < 5; i++ {
+		var blockEntity = &counter{Base: NewBase("Counter", blocks.NewPosition(i, 0, 0))}
+		counters = append(counters, blockEntity)
+		manager.Add(blockEntity)
+	}
+
+	for _, expected := range []int{3, 1, 0} {
+		if deferred := manager.Tick(); deferred != expected {
+			t.Errorf("expected %v deferred block entities, got %v", expected, deferred)
+		}
+	}
+	for _, blockEntity := range counters {
+		if blockEntity.ticks != 1 {
+			t.Errorf("expected block entity at %v to be ticked once, got %v", blockEntity.GetPosition(), blockEntity.ticks)
+		}
+	}
+
+	manager.Remove(blocks.NewPosition(4, 0, 0))
+	manager.TickLimit = 0
+	if deferred := manager.Tick(); deferred != 0 || counters[4].ticks != 1 {
+		t.Error("expected all remaining block entities to be ticked without a limit")
+	}
+}
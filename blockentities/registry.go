@@ -0,0 +1,154 @@
+package blockentities
+
+import (
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+// NBT tag names shared by all block entities.
+const (
+	TagId     = "id"
+	TagX      = "x"
+	TagY      = "y"
+	TagZ      = "z"
+	TagItems  = "Items"
+	TagSlot   = "Slot"
+	TagName   = "Name"
+	TagCount  = "Count"
+	TagDamage = "Damage"
+)
+
+// Serializable is a block entity that can be saved as NBT, and sent to clients.
+type Serializable interface {
+	BlockEntity
+	// WriteNBT writes the data of the block entity to the compound.
+	WriteNBT(compound *gonbt.Compound)
+	// ReadNBT reads the data of the block entity from the compound.
+	ReadNBT(compound *gonbt.Compound)
+}
+
+var registryMutex sync.RWMutex
+
+// registry holds the functions returning new block entities, by save ID.
+var registry = map[string]func(position blocks.Position) Serializable{
+	ChestId:   func(position blocks.Position) Serializable { return NewChest(position) },
+	FurnaceId: func(position blocks.Position) Serializable { return NewFurnace(position) },
+	SignId:    func(position blocks.Position) Serializable { return NewSign(position, "") },
+	BannerId:  func(position blocks.Position) Serializable { return NewBanner(position, 0) },
+	ItemFrameId: func(position blocks.Position) Serializable {
+		return NewItemFrame(position)
+	},
+	HopperId: func(position blocks.Position) Serializable {
+		return NewHopper(position, FaceDown, DefaultTransferCooldown)
+	},
+	CommandBlockId: func(position blocks.Position) Serializable {
+		return NewCommandBlock(position)
+	},
+}
+
+// Register registers a function returning new block entities with the given save ID at a position.
+// Registering an ID twice overwrites the function registered first.
+func Register(id string, newFunction func(position blocks.Position) Serializable) {
+	registryMutex.Lock()
+	registry[id] = newFunction
+	registryMutex.Unlock()
+}
+
+// IsRegistered checks if a block entity with the given save ID is registered.
+func IsRegistered(id string) bool {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	var _, ok = registry[id]
+	return ok
+}
+
+// New returns a new block entity with the given save ID at the position.
+// A bool is returned indicating if the ID was registered.
+func New(id string, position blocks.Position) (Serializable, bool) {
+	registryMutex.RLock()
+	var newFunction, ok = registry[id]
+	registryMutex.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return newFunction(position), true
+}
+
+// ToNBT returns the block entity as an NBT compound, holding its save ID and position.
+func ToNBT(blockEntity Serializable) *gonbt.Compound {
+	var position = blockEntity.GetPosition()
+	var compound = gonbt.NewCompound("", make(map[string]gonbt.INamedTag))
+	compound.SetString(TagId, blockEntity.GetId())
+	compound.SetInt(TagX, position.X)
+	compound.SetInt(TagY, int32(position.Y))
+	compound.SetInt(TagZ, position.Z)
+	blockEntity.WriteNBT(compound)
+	return compound
+}
+
+// FromNBT returns the block entity held by an NBT compound.
+// A bool is returned which is false if the compound holds no block entity with a registered save ID.
+func FromNBT(compound *gonbt.Compound) (Serializable, bool) {
+	var position = blocks.NewPosition(compound.GetInt(TagX, 0), uint32(compound.GetInt(TagY, 0)), compound.GetInt(TagZ, 0))
+	var blockEntity, ok = New(compound.GetString(TagId, ""), position)
+	if !ok {
+		return nil, false
+	}
+	blockEntity.ReadNBT(compound)
+	return blockEntity, true
+}
+
+// WriteItems writes the items of the inventory to a list in the compound.
+// Empty slots are left out.
+func (inventory *Inventory) WriteItems(compound *gonbt.Compound) {
+	var list []gonbt.INamedTag
+	for slot, stack := range inventory.slots {
+		if stack == nil {
+			continue
+		}
+		var item = writeItem(stack)
+		item.SetByte(TagSlot, byte(slot))
+		list = append(list, item)
+	}
+	compound.SetList(TagItems, gonbt.TAG_Compound, list)
+}
+
+// ReadItems reads the items of the inventory from a list in the compound.
+// Items of unknown types are left out.
+func (inventory *Inventory) ReadItems(compound *gonbt.Compound) {
+	if !compound.HasTagWithType(TagItems, gonbt.TAG_List) {
+		return
+	}
+	for _, tag := range compound.GetList(TagItems, gonbt.TAG_Compound).GetTags() {
+		var item, ok = tag.(*gonbt.Compound)
+		if !ok {
+			continue
+		}
+		if stack, ok := readItem(item); ok {
+			inventory.SetItem(int(item.GetByte(TagSlot, 0)), stack)
+		}
+	}
+}
+
+// writeItem returns a compound holding the string ID, count and damage of the item stack.
+func writeItem(stack *items.Stack) *gonbt.Compound {
+	var item = gonbt.NewCompound("", make(map[string]gonbt.INamedTag))
+	item.SetString(TagName, stack.GetId())
+	item.SetByte(TagCount, byte(stack.Count))
+	item.SetShort(TagDamage, stack.Durability)
+	return item
+}
+
+// readItem returns the item stack held by a compound written by writeItem.
+// A bool is returned which is false if the item type is unknown.
+func readItem(item *gonbt.Compound) (*items.Stack, bool) {
+	var stack, ok = items.DefaultManager.Get(item.GetString(TagName, ""), int(item.GetByte(TagCount, 0)))
+	if !ok {
+		return nil, false
+	}
+	stack.Durability = item.GetShort(TagDamage, 0)
+	return stack, true
+}
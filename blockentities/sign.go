@@ -0,0 +1,40 @@
+package blockentities
+
+import (
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	SignId  = "Sign"
+	TagText = "Text"
+)
+
+// Sign is a sign holding text, with lines separated by newlines.
+type Sign struct {
+	*Base
+
+	// Text is the text written on the sign.
+	Text string
+	// Command is the command executed when the sign is clicked, or empty if the sign is not a command sign.
+	Command string
+}
+
+// NewSign returns a new sign with the given text at the given position.
+func NewSign(position blocks.Position, text string) *Sign {
+	return &Sign{Base: NewBase(SignId, position), Text: text}
+}
+
+// WriteNBT writes the text of the sign to the compound, and its command if it is a command sign.
+func (sign *Sign) WriteNBT(compound *gonbt.Compound) {
+	compound.SetString(TagText, sign.Text)
+	if sign.Command != "" {
+		compound.SetString(TagCommand, sign.Command)
+	}
+}
+
+// ReadNBT reads the text and command of the sign from the compound.
+func (sign *Sign) ReadNBT(compound *gonbt.Compound) {
+	sign.Text = compound.GetString(TagText, "")
+	sign.Command = compound.GetString(TagCommand, "")
+}
@@ -0,0 +1,52 @@
+package blockentities
+
+import (
+	"github.com/BobbyShrd/gominetest/chunkstore"
+	"github.com/irmine/gonbt"
+)
+
+// TagBlockEntities is the name of the list holding the block entities of a chunk file.
+const TagBlockEntities = "BlockEntities"
+
+// Store stores the block entities of a dimension, with one NBT file for every chunk holding block entities.
+type Store struct {
+	chunks *chunkstore.Store
+}
+
+// NewStore returns a new store keeping its files in the given directory.
+// The directory is created once the first chunk gets saved.
+func NewStore(directory string) *Store {
+	return &Store{chunkstore.New(directory, TagBlockEntities)}
+}
+
+// GetDirectory returns the directory the store keeps its files in.
+func (store *Store) GetDirectory() string {
+	return store.chunks.GetDirectory()
+}
+
+// SaveChunk saves the block entities in the chunk at the given chunk coordinates,
+// replacing the block entities saved before. The file of the chunk is removed if there are no block entities.
+func (store *Store) SaveChunk(x, z int32, blockEntities []Serializable) error {
+	var tags = make([]gonbt.INamedTag, len(blockEntities))
+	for i, blockEntity := range blockEntities {
+		tags[i] = ToNBT(blockEntity)
+	}
+	return store.chunks.Save(x, z, tags)
+}
+
+// LoadChunk returns the block entities saved in the chunk at the given chunk coordinates.
+// Block entities with a save ID that is not registered are left out.
+// No block entities are returned if the chunk has no file.
+func (store *Store) LoadChunk(x, z int32) ([]Serializable, error) {
+	var compounds, err = store.chunks.Load(x, z)
+	if err != nil {
+		return nil, err
+	}
+	var blockEntities []Serializable
+	for _, compound := range compounds {
+		if blockEntity, ok := FromNBT(compound); ok {
+			blockEntities = append(blockEntities, blockEntity)
+		}
+	}
+	return blockEntities, nil
+}
@@ -0,0 +1,41 @@
+package blockentities
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/irmine/worlds/blocks"
+)
+
+func TestStoreChunks(t *testing.T) {
+	var directory, err = ioutil.TempDir("", "blockentities")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	var manager = NewManager()
+	manager.SetStore(NewStore(directory))
+	var commandSign = NewSign(blocks.NewPosition(3, 64, 5), "Hello\nWorld")
+	commandSign.Command = "say hello"
+	manager.Add(commandSign)
+	manager.Add(NewSign(blocks.NewPosition(20, 64, 5), "Other chunk"))
+	if err := manager.UnloadChunk(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := manager.Get(blocks.NewPosition(3, 64, 5)); ok {
+		t.Fatal("expected the sign to be removed when its chunk was unloaded")
+	}
+	if _, ok := manager.Get(blocks.NewPosition(20, 64, 5)); !ok {
+		t.Fatal("expected the sign in the other chunk to be kept")
+	}
+
+	if err := manager.LoadChunk(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	var blockEntity, _ = manager.Get(blocks.NewPosition(3, 64, 5))
+	if sign, ok := blockEntity.(*Sign); !ok || sign.Text != "Hello\nWorld" || sign.Command != "say hello" {
+		t.Errorf("expected the sign to be loaded again with its text and command, got %v", blockEntity)
+	}
+}
@@ -0,0 +1,196 @@
+package breaking
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/loot"
+)
+
+const (
+	// EnchantmentSilkTouch is the ID of the silk touch enchantment, making blocks drop themselves.
+	EnchantmentSilkTouch = "silk_touch"
+	// EnchantmentFortune is the ID of the fortune enchantment, multiplying the drops of ores.
+	EnchantmentFortune = "fortune"
+)
+
+// Block holds the properties of a block deciding how fast it breaks and what it drops.
+type Block struct {
+	// Hardness is the hardness of the block. A negative hardness means the block is unbreakable.
+	Hardness float64
+	// Tool is the tool type breaking the block faster.
+	Tool ToolType
+	// Tier is the minimum tier of Tool required for the block to drop anything,
+	// or TierNone if the block drops items when broken by any tool.
+	Tier int
+	// Item is the item string ID of the block itself, dropped when broken with silk touch.
+	Item string
+	// Drop is the item string ID dropped when the block gets broken,
+	// or an empty string if the block only drops when broken with silk touch.
+	Drop string
+	// Count is the amount of items dropped.
+	Count loot.Range
+	// Fortune indicates if the amount of items dropped gets multiplied by the fortune enchantment.
+	Fortune bool
+}
+
+// CanHarvest checks if the block drops items when broken with the tool.
+func (block Block) CanHarvest(tool Tool) bool {
+	return block.Tier == TierNone || (tool.Type == block.Tool && tool.Tier >= block.Tier)
+}
+
+// GetBreakTime returns the time in ticks it takes to break the block with the item.
+// The multiplier is the mining speed multiplier of the player breaking the block, for example because of its effects.
+// A break time of -1 is returned for unbreakable blocks, and 0 for blocks breaking instantly.
+func (block Block) GetBreakTime(item *items.Stack, multiplier float64) int64 {
+	if block.Hardness < 0 {
+		return -1
+	}
+	if block.Hardness == 0 {
+		return 0
+	}
+	var tool = GetTool(item)
+	var speed = 1.0
+	if block.Tool != ToolNone && tool.Type == block.Tool {
+		speed = tool.Speed
+		if level := item.GetEnchantmentLevel(EnchantmentEfficiency); level > 0 {
+			speed += float64(level*level + 1)
+		}
+	}
+	speed *= multiplier
+
+	var progress = speed / block.Hardness / 100
+	if block.CanHarvest(tool) {
+		progress = speed / block.Hardness / 30
+	}
+	if progress >= 1 {
+		return 0
+	}
+	return int64(math.Ceil(1 / progress))
+}
+
+// GetDrops returns the items dropped when the block gets broken with the item.
+// Blocks drop themselves when broken with silk touch, and the amount of ores dropped gets increased by fortune.
+// Drops of which the item type is not registered in the item manager are left out.
+func (block Block) GetDrops(item *items.Stack, random *rand.Rand, manager *items.Manager) []*items.Stack {
+	if !block.CanHarvest(GetTool(item)) {
+		return nil
+	}
+	var id, count = block.Drop, block.Count.Roll(random)
+	if item != nil && item.GetEnchantmentLevel(EnchantmentSilkTouch) > 0 {
+		id, count = block.Item, 1
+	} else if block.Fortune && item != nil {
+		if level := item.GetEnchantmentLevel(EnchantmentFortune); level > 0 {
+			if bonus := random.Intn(level+2) - 1; bonus > 0 {
+				count *= bonus + 1
+			}
+		}
+	}
+	if id == "" || count <= 0 {
+		return nil
+	}
+	var stack, ok = manager.Get(id, count)
+	if !ok {
+		return nil
+	}
+	return []*items.Stack{stack}
+}
+
+// Registry holds the properties of blocks, indexed by block name.
+type Registry struct {
+	mutex  sync.RWMutex
+	blocks map[string]Block
+}
+
+// DefaultRegistry is the registry holding the properties of vanilla blocks.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry returns a new registry holding the properties of vanilla blocks.
+func NewRegistry() *Registry {
+	var registry = &Registry{blocks: make(map[string]Block)}
+	registry.registerDefaults()
+	return registry
+}
+
+// Register sets the properties of the block with the given name.
+func (registry *Registry) Register(name string, block Block) {
+	registry.mutex.Lock()
+	registry.blocks[name] = block
+	registry.mutex.Unlock()
+}
+
+// Get returns the properties of the block with the given name.
+// Blocks not registered have a hardness of 1 and drop themselves.
+func (registry *Registry) Get(name string) Block {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	if block, ok := registry.blocks[name]; ok {
+		return block
+	}
+	return self(name, 1, ToolNone, TierNone)
+}
+
+// self returns a block with the given name dropping itself.
+func self(name string, hardness float64, tool ToolType, tier int) Block {
+	var id = "minecraft:" + name
+	return Block{Hardness: hardness, Tool: tool, Tier: tier, Item: id, Drop: id, Count: loot.NewRange(1, 1)}
+}
+
+// registerDefaults registers the properties of vanilla blocks.
+func (registry *Registry) registerDefaults() {
+	registry.Register("air", Block{})
+	registry.Register("bedrock", Block{Hardness: -1})
+
+	for _, name := range []string{"cobblestone", "brick_block"} {
+		registry.Register(name, self(name, 2, ToolPickaxe, TierWood))
+	}
+	registry.Register("sandstone", self("sandstone", 0.8, ToolPickaxe, TierWood))
+	registry.Register("end_stone", self("end_stone", 3, ToolPickaxe, TierWood))
+	registry.Register("coal_block", self("coal_block", 5, ToolPickaxe, TierWood))
+	registry.Register("netherrack", self("netherrack", 0.4, ToolPickaxe, TierWood))
+	registry.Register("furnace", self("furnace", 3.5, ToolPickaxe, TierWood))
+	registry.Register("obsidian", self("obsidian", 50, ToolPickaxe, TierDiamond))
+	registry.Register("iron_ore", self("iron_ore", 3, ToolPickaxe, TierStone))
+	registry.Register("gold_ore", self("gold_ore", 3, ToolPickaxe, TierIron))
+
+	var stone = self("stone", 1.5, ToolPickaxe, TierWood)
+	stone.Drop = "minecraft:cobblestone"
+	registry.Register("stone", stone)
+
+	registry.registerOre("coal_ore", "minecraft:coal", TierWood, loot.NewRange(1, 1))
+	registry.registerOre("diamond_ore", "minecraft:diamond", TierIron, loot.NewRange(1, 1))
+	registry.registerOre("emerald_ore", "minecraft:emerald", TierIron, loot.NewRange(1, 1))
+	registry.registerOre("lapis_ore", "minecraft:lapis_lazuli", TierStone, loot.NewRange(4, 9))
+	registry.registerOre("redstone_ore", "minecraft:redstone", TierIron, loot.NewRange(4, 5))
+	registry.registerOre("quartz_ore", "minecraft:quartz", TierWood, loot.NewRange(1, 1))
+
+	for _, name := range []string{"dirt", "sand"} {
+		registry.Register(name, self(name, 0.5, ToolShovel, TierNone))
+	}
+	registry.Register("snow_layer", Block{Hardness: 0.1, Tool: ToolShovel, Tier: TierWood, Item: "minecraft:snow_layer", Drop: "minecraft:snowball", Count: loot.NewRange(1, 1)})
+	registry.Register("gravel", self("gravel", 0.6, ToolShovel, TierNone))
+	registry.Register("grass", Block{Hardness: 0.6, Tool: ToolShovel, Item: "minecraft:grass", Drop: "minecraft:dirt", Count: loot.NewRange(1, 1)})
+	registry.Register("farmland", Block{Hardness: 0.6, Tool: ToolShovel, Item: "minecraft:dirt", Drop: "minecraft:dirt", Count: loot.NewRange(1, 1)})
+	registry.Register("clay", Block{Hardness: 0.6, Tool: ToolShovel, Item: "minecraft:clay", Drop: "minecraft:clay_ball", Count: loot.NewRange(4, 4)})
+
+	registry.Register("log", Block{Hardness: 2, Tool: ToolAxe, Item: "minecraft:oak_log", Drop: "minecraft:oak_log", Count: loot.NewRange(1, 1)})
+	registry.Register("planks", Block{Hardness: 2, Tool: ToolAxe, Item: "minecraft:oak_planks", Drop: "minecraft:oak_planks", Count: loot.NewRange(1, 1)})
+	registry.Register("crafting_table", self("crafting_table", 2.5, ToolAxe, TierNone))
+	registry.Register("chest", self("chest", 2.5, ToolAxe, TierNone))
+	registry.Register("bookshelf", Block{Hardness: 1.5, Tool: ToolAxe, Item: "minecraft:bookshelf", Drop: "minecraft:book", Count: loot.NewRange(3, 3)})
+
+	registry.Register("leaves", Block{Hardness: 0.2, Tool: ToolShears, Item: "minecraft:leaves"})
+	registry.Register("glass", Block{Hardness: 0.3, Item: "minecraft:glass"})
+	registry.Register("ice", Block{Hardness: 0.5, Tool: ToolPickaxe, Item: "minecraft:ice"})
+	registry.Register("glowstone", Block{Hardness: 0.3, Item: "minecraft:glowstone", Drop: "minecraft:glowstone_dust", Count: loot.NewRange(2, 4)})
+	registry.Register("wool", self("wool", 0.8, ToolShears, TierNone))
+	registry.Register("sapling", Block{Item: "minecraft:oak_sapling"})
+	registry.Register("tnt", self("tnt", 0, ToolNone, TierNone))
+}
+
+// registerOre registers an ore dropping the given item, of which the amount gets multiplied by fortune.
+func (registry *Registry) registerOre(name string, drop string, tier int, count loot.Range) {
+	registry.Register(name, Block{Hardness: 3, Tool: ToolPickaxe, Tier: tier, Item: "minecraft:" + name, Drop: drop, Count: count, Fortune: true})
+}
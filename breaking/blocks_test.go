@@ -0,0 +1,67 @@
+package breaking
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/BobbyShrd/gominetest/items"
+)
+
+func TestBreakTime(t *testing.T) {
+	var registry = NewRegistry()
+	var stone = registry.Get("stone")
+	if ticks := stone.GetBreakTime(nil, 1); ticks != 150 {
+		t.Errorf("expected stone to break in 150 ticks by hand, got %v", ticks)
+	}
+	var pickaxe, _ = items.DefaultManager.Get("minecraft:air", 1)
+	pickaxe.Type = items.NewBreakable("minecraft:wooden_pickaxe")
+	if ticks := stone.GetBreakTime(pickaxe, 1); ticks != 23 {
+		t.Errorf("expected stone to break in 23 ticks with a wooden pickaxe, got %v", ticks)
+	}
+	if ticks := stone.GetBreakTime(pickaxe, 0.3); ticks <= 23 {
+		t.Errorf("expected mining fatigue to slow down breaking, got %v ticks", ticks)
+	}
+	if ticks := registry.Get("bedrock").GetBreakTime(pickaxe, 1); ticks != -1 {
+		t.Errorf("expected bedrock to be unbreakable, got %v", ticks)
+	}
+	if ticks := registry.Get("tnt").GetBreakTime(nil, 1); ticks != 0 {
+		t.Errorf("expected tnt to break instantly, got %v", ticks)
+	}
+}
+
+func TestDrops(t *testing.T) {
+	var registry = NewRegistry()
+	var random = rand.New(rand.NewSource(1))
+	var stone = registry.Get("stone")
+	if drops := stone.GetDrops(nil, random, items.DefaultManager); len(drops) != 0 {
+		t.Errorf("expected stone broken by hand not to drop, got %v", drops)
+	}
+
+	var pickaxe, _ = items.DefaultManager.Get("minecraft:air", 1)
+	pickaxe.Type = items.NewBreakable("minecraft:wooden_pickaxe")
+	var drops = stone.GetDrops(pickaxe, random, items.DefaultManager)
+	if len(drops) != 1 || drops[0].GetId() != "minecraft:cobblestone" {
+		t.Errorf("expected stone to drop cobblestone, got %v", drops)
+	}
+	if drops := registry.Get("diamond_ore").GetDrops(pickaxe, random, items.DefaultManager); len(drops) != 0 {
+		t.Errorf("expected diamond ore broken with a wooden pickaxe not to drop, got %v", drops)
+	}
+	if drops := registry.Get("glass").GetDrops(nil, random, items.DefaultManager); len(drops) != 0 {
+		t.Errorf("expected glass not to drop without silk touch, got %v", drops)
+	}
+}
+
+func TestGetTool(t *testing.T) {
+	var pickaxe, _ = items.DefaultManager.Get("minecraft:air", 1)
+	pickaxe.Type = items.NewBreakable("minecraft:iron_pickaxe")
+	if tool := GetTool(pickaxe); tool.Type != ToolPickaxe || tool.Tier != TierIron {
+		t.Errorf("unexpected tool %v for iron pickaxe", tool)
+	}
+	pickaxe.Type = items.NewBreakable("minecraft:golden_axe")
+	if tool := GetTool(pickaxe); tool.Type != ToolAxe || tool.Tier != TierWood || tool.Speed != 12 {
+		t.Errorf("unexpected tool %v for golden axe", tool)
+	}
+	if tool := GetTool(nil); tool != Hand {
+		t.Errorf("expected hand without item, got %v", tool)
+	}
+}
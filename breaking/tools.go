@@ -0,0 +1,83 @@
+package breaking
+
+import (
+	"strings"
+
+	"github.com/BobbyShrd/gominetest/items"
+)
+
+// ToolType is a type of tool, which breaks some blocks faster than others.
+type ToolType byte
+
+const (
+	ToolNone ToolType = iota
+	ToolPickaxe
+	ToolAxe
+	ToolShovel
+	ToolHoe
+	ToolSword
+	ToolShears
+)
+
+// Tool tiers, deciding which blocks a tool is able to harvest.
+// Golden tools have the same tier as wooden tools, but break blocks faster.
+const (
+	TierNone = iota
+	TierWood
+	TierStone
+	TierIron
+	TierDiamond
+)
+
+// EnchantmentEfficiency is the ID of the efficiency enchantment, increasing the speed of tools.
+const EnchantmentEfficiency = "efficiency"
+
+// Tool is the tool type, tier and speed of an item.
+type Tool struct {
+	Type  ToolType
+	Tier  int
+	Speed float64
+}
+
+// Hand is the tool used when breaking blocks without holding a tool.
+var Hand = Tool{ToolNone, TierNone, 1}
+
+// toolTypes holds the tool types, indexed by item string ID suffix.
+var toolTypes = map[string]ToolType{
+	"_pickaxe": ToolPickaxe,
+	"_axe":     ToolAxe,
+	"_shovel":  ToolShovel,
+	"_hoe":     ToolHoe,
+	"_sword":   ToolSword,
+}
+
+// materials holds the tier and speed of tools, indexed by the material prefix of their item string ID.
+var materials = map[string]Tool{
+	"minecraft:wooden_":  {Tier: TierWood, Speed: 2},
+	"minecraft:stone_":   {Tier: TierStone, Speed: 4},
+	"minecraft:iron_":    {Tier: TierIron, Speed: 6},
+	"minecraft:diamond_": {Tier: TierDiamond, Speed: 8},
+	"minecraft:golden_":  {Tier: TierWood, Speed: 12},
+}
+
+// GetTool returns the tool of the item, or Hand if the item is not a tool.
+func GetTool(item *items.Stack) Tool {
+	if item == nil || item.Count == 0 {
+		return Hand
+	}
+	var id = item.GetId()
+	if id == "minecraft:shears" {
+		return Tool{ToolShears, TierNone, 1.5}
+	}
+	for suffix, toolType := range toolTypes {
+		if !strings.HasSuffix(id, suffix) {
+			continue
+		}
+		for prefix, material := range materials {
+			if strings.HasPrefix(id, prefix) {
+				return Tool{toolType, material.Tier, material.Speed}
+			}
+		}
+	}
+	return Hand
+}
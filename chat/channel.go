@@ -0,0 +1,96 @@
+package chat
+
+import (
+	"github.com/BobbyShrd/gominetest/net"
+)
+
+const (
+	Global = "global"
+	Local  = "local"
+	World  = "world"
+	Staff  = "staff"
+)
+
+// Channel is a scope chat messages can be sent in.
+// Every channel has a filter function, which decides
+// whether a receiver should receive a message of a sender.
+type Channel struct {
+	name       string
+	permission string
+	filter     func(sender *net.MinecraftSession, receiver *net.MinecraftSession) bool
+}
+
+// NewChannel returns a new channel with the given name and filter function.
+// The filter function gets called for every potential receiver of a message.
+func NewChannel(name string, filter func(sender *net.MinecraftSession, receiver *net.MinecraftSession) bool) *Channel {
+	return &Channel{name, "", filter}
+}
+
+// NewGlobalChannel returns a channel in which every player receives messages.
+func NewGlobalChannel() *Channel {
+	return NewChannel(Global, func(sender *net.MinecraftSession, receiver *net.MinecraftSession) bool {
+		return true
+	})
+}
+
+// NewLocalChannel returns a channel in which only players
+// within the given radius of the sender receive messages.
+func NewLocalChannel(radius float64) *Channel {
+	return NewChannel(Local, func(sender *net.MinecraftSession, receiver *net.MinecraftSession) bool {
+		if !sender.HasSpawned() || !receiver.HasSpawned() {
+			return false
+		}
+		if sender.GetPlayer().GetDimension() != receiver.GetPlayer().GetDimension() {
+			return false
+		}
+		return sender.GetPlayer().Position.Distance(receiver.GetPlayer().Position) <= radius
+	})
+}
+
+// NewWorldChannel returns a channel in which only players
+// in the same level as the sender receive messages.
+func NewWorldChannel() *Channel {
+	return NewChannel(World, func(sender *net.MinecraftSession, receiver *net.MinecraftSession) bool {
+		if !sender.HasSpawned() || !receiver.HasSpawned() {
+			return false
+		}
+		return sender.GetPlayer().GetDimension().GetLevel() == receiver.GetPlayer().GetDimension().GetLevel()
+	})
+}
+
+// NewStaffChannel returns a channel in which only players
+// with the given permission receive messages.
+// Players need the permission to chat in the channel.
+func NewStaffChannel(permission string) *Channel {
+	var channel = NewChannel(Staff, func(sender *net.MinecraftSession, receiver *net.MinecraftSession) bool {
+		return receiver.HasPermission(permission)
+	})
+	channel.SetPermission(permission)
+	return channel
+}
+
+// GetName returns the name of the channel.
+func (channel *Channel) GetName() string {
+	return channel.name
+}
+
+// CanReceive checks if the receiver can receive messages sent by the sender in this channel.
+func (channel *Channel) CanReceive(sender *net.MinecraftSession, receiver *net.MinecraftSession) bool {
+	return channel.filter(sender, receiver)
+}
+
+// GetPermission returns the permission needed to chat in the channel.
+// An empty string is returned if no permission is needed.
+func (channel *Channel) GetPermission() string {
+	return channel.permission
+}
+
+// SetPermission sets the permission needed to chat in the channel.
+func (channel *Channel) SetPermission(permission string) {
+	channel.permission = permission
+}
+
+// CanChat checks if the session is allowed to chat in this channel.
+func (channel *Channel) CanChat(session *net.MinecraftSession) bool {
+	return channel.permission == "" || session.HasPermission(channel.permission)
+}
@@ -0,0 +1,269 @@
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/google/uuid"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// StaffPermission is the permission needed to chat in and receive messages of the staff channel.
+	StaffPermission = "gomine.chat.staff"
+	// DefaultFormat is the format of chat messages used if no format is set.
+	DefaultFormat = "<{display_name}> {message}"
+)
+
+var (
+	UnknownChannel      = errors.New("unknown chat channel")
+	NoChannelPermission = errors.New("no permission to chat in this channel")
+)
+
+// Manager is a struct managing chat channels.
+// It keeps track of registered channels, the channel selected by every player,
+// and players that are muted.
+type Manager struct {
+	mutex          sync.RWMutex
+	defaultChannel string
+	channels       map[string]*Channel
+	selected       map[uuid.UUID]string
+	muted          map[uuid.UUID]time.Time
+	mutePath       string
+}
+
+// Mute is a muted player in the mute list, stored in mutes.json.
+// The expiry is zero if the mute does not expire.
+type Mute struct {
+	UUID   uuid.UUID `json:"uuid"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// NewManager returns a new chat manager with the global, local, world and staff channels registered.
+// The local channel uses the given radius. The global channel is the default channel.
+func NewManager(localRadius float64) *Manager {
+	var manager = &Manager{sync.RWMutex{}, Global, make(map[string]*Channel), make(map[uuid.UUID]string), make(map[uuid.UUID]time.Time), ""}
+	manager.RegisterChannel(NewGlobalChannel())
+	manager.RegisterChannel(NewLocalChannel(localRadius))
+	manager.RegisterChannel(NewWorldChannel())
+	manager.RegisterChannel(NewStaffChannel(StaffPermission))
+	return manager
+}
+
+// RegisterChannel registers a new channel, overwriting any channel with the same name.
+func (manager *Manager) RegisterChannel(channel *Channel) {
+	manager.mutex.Lock()
+	manager.channels[channel.GetName()] = channel
+	manager.mutex.Unlock()
+}
+
+// IsChannelRegistered checks if a channel with the given name is registered.
+func (manager *Manager) IsChannelRegistered(name string) bool {
+	manager.mutex.RLock()
+	var _, ok = manager.channels[name]
+	manager.mutex.RUnlock()
+	return ok
+}
+
+// GetChannelByName returns a channel by its name, and an error if it could not be found.
+func (manager *Manager) GetChannelByName(name string) (*Channel, error) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var channel, ok = manager.channels[name]
+	if !ok {
+		return nil, UnknownChannel
+	}
+	return channel, nil
+}
+
+// LoadMutes loads the mutes from the JSON file at the given path,
+// and saves mutes to the file from then on. No mutes are loaded if the file does not exist.
+func (manager *Manager) LoadMutes(path string) error {
+	manager.mutex.Lock()
+	manager.mutePath = path
+	manager.mutex.Unlock()
+	var file, err = ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var mutes []Mute
+	if err := json.Unmarshal(file, &mutes); err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	for _, mute := range mutes {
+		manager.muted[mute.UUID] = mute.Expiry
+	}
+	manager.mutex.Unlock()
+	return nil
+}
+
+// SaveMutes writes the mutes that have not expired to the file they were loaded from.
+// Nothing is written if no mutes were loaded.
+func (manager *Manager) SaveMutes() error {
+	manager.mutex.RLock()
+	var path = manager.mutePath
+	var mutes = make([]Mute, 0, len(manager.muted))
+	for id, expiry := range manager.muted {
+		if expiry.IsZero() || time.Now().Before(expiry) {
+			mutes = append(mutes, Mute{id, expiry})
+		}
+	}
+	manager.mutex.RUnlock()
+	if path == "" {
+		return nil
+	}
+	var encoded, err = json.MarshalIndent(mutes, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, encoded, 0644)
+}
+
+// GetChannels returns a name => channel map of all registered channels.
+// The map is a copy, so it can be used while channels get registered.
+func (manager *Manager) GetChannels() map[string]*Channel {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var channels = make(map[string]*Channel, len(manager.channels))
+	for name, channel := range manager.channels {
+		channels[name] = channel
+	}
+	return channels
+}
+
+// SetDefaultChannel sets the channel players chat in if they have not selected one.
+func (manager *Manager) SetDefaultChannel(name string) error {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if _, ok := manager.channels[name]; !ok {
+		return UnknownChannel
+	}
+	manager.defaultChannel = name
+	return nil
+}
+
+// SetChannel selects the channel with the given name for the given session.
+// An error is returned if the session has no permission to chat in the channel.
+func (manager *Manager) SetChannel(session *net.MinecraftSession, name string) error {
+	var channel, err = manager.GetChannelByName(name)
+	if err != nil {
+		return err
+	}
+	if !channel.CanChat(session) {
+		return NoChannelPermission
+	}
+	manager.mutex.Lock()
+	manager.selected[session.GetUUID()] = name
+	manager.mutex.Unlock()
+	return nil
+}
+
+// GetChannel returns the channel the given session is chatting in.
+// The default channel is returned if the session has not selected any.
+func (manager *Manager) GetChannel(session *net.MinecraftSession) *Channel {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var name, ok = manager.selected[session.GetUUID()]
+	if channel, registered := manager.channels[name]; ok && registered {
+		return channel
+	}
+	return manager.channels[manager.defaultChannel]
+}
+
+// Mute mutes the given session for the given duration.
+// The session is muted until unmuted if the duration is 0.
+// Mutes are kept when the session leaves the server, and across restarts once saved with SaveMutes.
+func (manager *Manager) Mute(session *net.MinecraftSession, duration time.Duration) {
+	var expiry time.Time
+	if duration > 0 {
+		expiry = time.Now().Add(duration)
+	}
+	manager.mutex.Lock()
+	manager.muted[session.GetUUID()] = expiry
+	manager.mutex.Unlock()
+}
+
+// Unmute unmutes the given session.
+// Returns false if the session was not muted.
+func (manager *Manager) Unmute(session *net.MinecraftSession) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var _, ok = manager.muted[session.GetUUID()]
+	delete(manager.muted, session.GetUUID())
+	return ok
+}
+
+// IsMuted checks if the given session is muted.
+// Mutes that have expired get removed.
+func (manager *Manager) IsMuted(session *net.MinecraftSession) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var expiry, ok = manager.muted[session.GetUUID()]
+	if !ok {
+		return false
+	}
+	if !expiry.IsZero() && time.Now().After(expiry) {
+		delete(manager.muted, session.GetUUID())
+		return false
+	}
+	return true
+}
+
+// GetMuteExpiry returns the time the mute of the given session expires,
+// and a bool indicating if the session is muted. The time is zero if the mute does not expire.
+func (manager *Manager) GetMuteExpiry(session *net.MinecraftSession) (time.Time, bool) {
+	if !manager.IsMuted(session) {
+		return time.Time{}, false
+	}
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var expiry, ok = manager.muted[session.GetUUID()]
+	return expiry, ok
+}
+
+// RemoveSession clears the channel selection of the given session.
+func (manager *Manager) RemoveSession(session *net.MinecraftSession) {
+	manager.mutex.Lock()
+	delete(manager.selected, session.GetUUID())
+	manager.mutex.Unlock()
+}
+
+// GetReceivers returns all sessions that should receive a message
+// from the sender, in the channel the sender is chatting in.
+func (manager *Manager) GetReceivers(sender *net.MinecraftSession, sessions map[string]*net.MinecraftSession) []*net.MinecraftSession {
+	return manager.GetChannelReceivers(manager.GetChannel(sender), sender, sessions)
+}
+
+// GetChannelReceivers returns all sessions that should receive a message
+// from the sender in the given channel.
+func (manager *Manager) GetChannelReceivers(channel *Channel, sender *net.MinecraftSession, sessions map[string]*net.MinecraftSession) []*net.MinecraftSession {
+	var receivers []*net.MinecraftSession
+	for _, receiver := range sessions {
+		if receiver == sender || channel.CanReceive(sender, receiver) {
+			receivers = append(receivers, receiver)
+		}
+	}
+	return receivers
+}
+
+// Format formats a chat message of the sender using the given format, or the default format if it is empty.
+// The placeholders {name}, {display_name}, {channel} and {message} are replaced.
+func Format(format string, sender *net.MinecraftSession, channel string, message string) string {
+	if format == "" {
+		format = DefaultFormat
+	}
+	return strings.NewReplacer(
+		"{name}", sender.GetName(),
+		"{display_name}", sender.GetDisplayName(),
+		"{channel}", channel,
+		"{message}", message,
+	).Replace(format)
+}
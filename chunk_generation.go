@@ -0,0 +1,36 @@
+package gomine
+
+import (
+	"math"
+
+	"github.com/BobbyShrd/gominetest/chunkgen"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/generation"
+)
+
+// SetGenerator sets the generator of a dimension,
+// running its chunk generation on the chunk generation pool of the server.
+func (server *Server) SetGenerator(dimension *worlds.Dimension, generator generation.Generator) {
+	dimension.SetGenerator(chunkgen.NewGenerator(generator, server.ChunkGenerationPool, server.rankChunks(dimension)))
+}
+
+// rankChunks returns a rank function for chunks in the given dimension.
+// Chunks are owned by the nearest player in the dimension, and prioritised by the squared distance to it.
+func (server *Server) rankChunks(dimension *worlds.Dimension) chunkgen.RankFunction {
+	return func(x, z int32) (string, float64) {
+		var owner string
+		var nearest = math.Inf(1)
+		for name, session := range server.SessionManager.GetSessions() {
+			var player = session.GetPlayer()
+			if player == nil || player.GetDimension() != dimension {
+				continue
+			}
+			var dx = float64(x) - math.Floor(player.Position.X/16)
+			var dz = float64(z) - math.Floor(player.Position.Z/16)
+			if distance := dx*dx + dz*dz; distance < nearest {
+				owner, nearest = name, distance
+			}
+		}
+		return owner, nearest
+	}
+}
@@ -0,0 +1,64 @@
+package gomine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/irmine/worlds/chunks"
+)
+
+// newTestWorld returns a dimension world that does not load chunks itself,
+// but records the callbacks of the chunks it was asked to load.
+func newTestWorld(loads map[chunkKey][]func(chunk *chunks.Chunk)) *DimensionWorld {
+	return &DimensionWorld{requests: make(map[chunkKey]*ChunkRequest), loadChunk: func(x, z int32, function func(chunk *chunks.Chunk)) {
+		loads[chunkKey{x, z}] = append(loads[chunkKey{x, z}], function)
+	}}
+}
+
+func TestChunkRequest(t *testing.T) {
+	var loads = make(map[chunkKey][]func(chunk *chunks.Chunk))
+	var world = newTestWorld(loads)
+
+	var request = world.RequestChunk(1, -2)
+	if world.RequestChunk(1, -2) != request {
+		t.Fatal("expected requests of a loading chunk to share the same handle")
+	}
+	if len(loads[chunkKey{1, -2}]) != 1 {
+		t.Fatalf("expected the chunk to be loaded once, got %v loads", len(loads[chunkKey{1, -2}]))
+	}
+	if _, ok := request.WaitTimeout(time.Millisecond); ok {
+		t.Error("expected the wait to time out before the chunk is loaded")
+	}
+	if _, ok := request.Get(); ok {
+		t.Error("expected no chunk before it is loaded")
+	}
+
+	var chunk = &chunks.Chunk{}
+	var before, after *chunks.Chunk
+	request.Then(func(loaded *chunks.Chunk) {
+		before = loaded
+	})
+	if before != nil {
+		t.Error("expected the callback not to be called before the chunk is loaded")
+	}
+	loads[chunkKey{1, -2}][0](chunk)
+	if before != chunk {
+		t.Error("expected the callback added before completion to be called with the chunk")
+	}
+	request.Then(func(loaded *chunks.Chunk) {
+		after = loaded
+	})
+	if after != chunk {
+		t.Error("expected the callback added after completion to be called immediately")
+	}
+	if loaded, ok := request.WaitTimeout(time.Millisecond); !ok || loaded != chunk {
+		t.Error("expected the wait to return the loaded chunk")
+	}
+
+	if world.RequestChunk(1, -2) == request {
+		t.Error("expected a new handle after the chunk was loaded")
+	}
+	if len(loads[chunkKey{1, -2}]) != 2 {
+		t.Errorf("expected the chunk to be loaded again, got %v loads", len(loads[chunkKey{1, -2}]))
+	}
+}
@@ -0,0 +1,40 @@
+package chunkgen
+
+import (
+	"github.com/irmine/worlds/chunks"
+	"github.com/irmine/worlds/generation"
+)
+
+// Generator wraps the generator of a dimension, running chunk generation on a pool.
+type Generator struct {
+	generation.Generator
+	pool *Pool
+	rank RankFunction
+}
+
+// NewGenerator returns a new generator generating chunks with the given generator on the pool.
+// The rank function determines the player that requested a chunk and its priority.
+func NewGenerator(generator generation.Generator, pool *Pool, rank RankFunction) *Generator {
+	return &Generator{generator, pool, rank}
+}
+
+// GenerateNewChunk generates the chunk at the given chunk coordinates on the pool, and waits for it.
+// The dimension loading the chunk still needs it if its job gets cancelled,
+// so cancelled chunks are queued again to be generated after all requested chunks.
+// Chunks are generated directly if the pool was closed.
+func (generator *Generator) GenerateNewChunk(x, z int32) *chunks.Chunk {
+	var chunk *chunks.Chunk
+	var generate = func() {
+		chunk = generator.Generator.GenerateNewChunk(x, z)
+	}
+	var job = NewJob(x, z, generator.rank, generate)
+	for {
+		if generator.pool.Submit(job) != nil {
+			return generator.Generator.GenerateNewChunk(x, z)
+		}
+		if job.Wait() {
+			return chunk
+		}
+		job = NewJob(x, z, nil, generate)
+	}
+}
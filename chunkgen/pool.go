@@ -0,0 +1,215 @@
+package chunkgen
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"sync"
+)
+
+var (
+	PoolClosed = errors.New("chunk generation pool is closed")
+)
+
+// RankFunction returns the player that requested the chunk at the given chunk coordinates,
+// and the priority of generating it. Chunks with the lowest priority value get generated first.
+// An empty owner is returned if no player requested the chunk.
+type RankFunction func(x, z int32) (owner string, priority float64)
+
+// Job is the generation of a single chunk.
+type Job struct {
+	X, Z int32
+
+	owner    string
+	priority float64
+	rank     RankFunction
+	run      func()
+	index    int
+	done     chan struct{}
+	ran      bool
+}
+
+// NewJob returns a new job for the chunk at the given chunk coordinates.
+// The rank function is used to determine the owner and priority of the job,
+// and may be nil if the job has no owner and should be generated last.
+func NewJob(x, z int32, rank RankFunction, run func()) *Job {
+	return &Job{X: x, Z: z, priority: math.Inf(1), rank: rank, run: run, index: -1, done: make(chan struct{})}
+}
+
+// GetOwner returns the name of the player that requested the chunk of the job.
+func (job *Job) GetOwner() string {
+	return job.owner
+}
+
+// GetPriority returns the priority of the job. Jobs with the lowest priority value run first.
+func (job *Job) GetPriority() float64 {
+	return job.priority
+}
+
+// Wait waits until the job ran or got cancelled.
+// Returns true if the job ran, and false if it was cancelled.
+func (job *Job) Wait() bool {
+	<-job.done
+	return job.ran
+}
+
+// update updates the owner and priority of the job using its rank function.
+func (job *Job) update() {
+	if job.rank != nil {
+		job.owner, job.priority = job.rank(job.X, job.Z)
+	}
+}
+
+// queue is a priority queue of jobs, implementing heap.Interface.
+type queue []*Job
+
+func (q queue) Len() int           { return len(q) }
+func (q queue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+
+func (q queue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *queue) Push(x interface{}) {
+	var job = x.(*Job)
+	job.index = len(*q)
+	*q = append(*q, job)
+}
+
+func (q *queue) Pop() interface{} {
+	var old = *q
+	var job = old[len(old)-1]
+	old[len(old)-1] = nil
+	job.index = -1
+	*q = old[:len(old)-1]
+	return job
+}
+
+// Pool runs chunk generation jobs on a fixed amount of workers.
+// Queued jobs run in order of priority, so that chunks nearest to players get generated first.
+// The amount of queued jobs is bounded: submitting blocks while the queue is full,
+// so that players exploring quickly can not make the queue grow without limit.
+type Pool struct {
+	mutex      sync.Mutex
+	notEmpty   *sync.Cond
+	notFull    *sync.Cond
+	queue      queue
+	maxPending int
+	closed     bool
+	waitGroup  sync.WaitGroup
+}
+
+// NewPool returns a new pool with the given amount of workers,
+// which queues at most maxPending jobs at once.
+func NewPool(workers int, maxPending int) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	if maxPending < 1 {
+		maxPending = 1
+	}
+	var pool = &Pool{maxPending: maxPending}
+	pool.notEmpty = sync.NewCond(&pool.mutex)
+	pool.notFull = sync.NewCond(&pool.mutex)
+	pool.waitGroup.Add(workers)
+	for i := 0; i < workers; i++ {
+		go pool.work()
+	}
+	return pool
+}
+
+// Submit queues a job, blocking while the queue is full.
+// Returns PoolClosed if the pool was closed, in which case the job is cancelled.
+func (pool *Pool) Submit(job *Job) error {
+	job.update()
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	for len(pool.queue) >= pool.maxPending && !pool.closed {
+		pool.notFull.Wait()
+	}
+	if pool.closed {
+		close(job.done)
+		return PoolClosed
+	}
+	heap.Push(&pool.queue, job)
+	pool.notEmpty.Signal()
+	return nil
+}
+
+// Cancel cancels a queued job. Returns false if the job was not queued,
+// for example because it is already running.
+func (pool *Pool) Cancel(job *Job) bool {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	if job.index < 0 || job.index >= len(pool.queue) || pool.queue[job.index] != job {
+		return false
+	}
+	heap.Remove(&pool.queue, job.index)
+	close(job.done)
+	pool.notFull.Signal()
+	return true
+}
+
+// Reprioritize updates the owner and priority of all queued jobs,
+// for example after players moved. Jobs of players that left the server lose their owner,
+// and get generated after all chunks requested by players that are still online.
+func (pool *Pool) Reprioritize() {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	for _, job := range pool.queue {
+		job.update()
+	}
+	heap.Init(&pool.queue)
+}
+
+// GetPendingCount returns the amount of queued jobs.
+func (pool *Pool) GetPendingCount() int {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	return len(pool.queue)
+}
+
+// Close cancels all queued jobs and waits for running jobs to finish.
+func (pool *Pool) Close() {
+	pool.mutex.Lock()
+	if pool.closed {
+		pool.mutex.Unlock()
+		return
+	}
+	pool.closed = true
+	for _, job := range pool.queue {
+		job.index = -1
+		close(job.done)
+	}
+	pool.queue = nil
+	pool.notEmpty.Broadcast()
+	pool.notFull.Broadcast()
+	pool.mutex.Unlock()
+
+	pool.waitGroup.Wait()
+}
+
+// work runs jobs until the pool gets closed.
+func (pool *Pool) work() {
+	defer pool.waitGroup.Done()
+	for {
+		pool.mutex.Lock()
+		for len(pool.queue) == 0 && !pool.closed {
+			pool.notEmpty.Wait()
+		}
+		if pool.closed {
+			pool.mutex.Unlock()
+			return
+		}
+		var job = heap.Pop(&pool.queue).(*Job)
+		pool.notFull.Signal()
+		pool.mutex.Unlock()
+
+		job.run()
+		job.ran = true
+		close(job.done)
+	}
+}
@@ -0,0 +1,161 @@
+package chunkgen
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockPool returns a pool with one worker, which is blocked until the returned function is called.
+func blockPool(maxPending int) (*Pool, func()) {
+	var pool = NewPool(1, maxPending)
+	var started = make(chan struct{})
+	var release = make(chan struct{})
+	pool.Submit(NewJob(0, 0, nil, func() {
+		close(started)
+		<-release
+	}))
+	<-started
+	return pool, func() { close(release) }
+}
+
+// distance ranks chunks by their distance to the origin, owned by the given player.
+func distance(owner string) RankFunction {
+	return func(x, z int32) (string, float64) {
+		return owner, float64(x*x + z*z)
+	}
+}
+
+func TestPriority(t *testing.T) {
+	var pool, release = blockPool(16)
+	defer pool.Close()
+
+	var mutex sync.Mutex
+	var order []int32
+	var jobs []*Job
+	for _, x := range []int32{5, 1, 3, 2, 4} {
+		var x = x
+		var job = NewJob(x, 0, distance("Steve"), func() {
+			mutex.Lock()
+			order = append(order, x)
+			mutex.Unlock()
+		})
+		jobs = append(jobs, job)
+		pool.Submit(job)
+	}
+	release()
+	for _, job := range jobs {
+		job.Wait()
+	}
+	for i, x := range order {
+		if x != int32(i+1) {
+			t.Fatalf("expected chunks nearest to the origin first, got %v", order)
+		}
+	}
+}
+
+func TestCancel(t *testing.T) {
+	var pool, release = blockPool(16)
+	defer pool.Close()
+
+	var steve = NewJob(1, 0, distance("Steve"), func() {})
+	var alex = NewJob(2, 0, distance("Alex"), func() {})
+	pool.Submit(steve)
+	pool.Submit(alex)
+
+	if !pool.Cancel(steve) {
+		t.Error("expected the queued job to be cancelled")
+	}
+	if pool.Cancel(steve) {
+		t.Error("expected a cancelled job not to be cancelled again")
+	}
+	release()
+	if steve.Wait() {
+		t.Error("expected the cancelled job not to run")
+	}
+	if !alex.Wait() {
+		t.Error("expected the other job to run")
+	}
+}
+
+func TestReprioritizeOwnerLeft(t *testing.T) {
+	var pool, release = blockPool(16)
+	defer pool.Close()
+
+	var mutex sync.Mutex
+	var online = map[string]bool{"Steve": true, "Alex": true}
+	var order []string
+	var rank = func(owner string) RankFunction {
+		return func(x, z int32) (string, float64) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if !online[owner] {
+				return "", math.Inf(1)
+			}
+			return owner, float64(x*x + z*z)
+		}
+	}
+	var run = func(owner string) func() {
+		return func() {
+			mutex.Lock()
+			order = append(order, owner)
+			mutex.Unlock()
+		}
+	}
+	var steve = NewJob(1, 0, rank("Steve"), run("Steve"))
+	var alex = NewJob(2, 0, rank("Alex"), run("Alex"))
+	pool.Submit(steve)
+	pool.Submit(alex)
+
+	mutex.Lock()
+	delete(online, "Steve")
+	mutex.Unlock()
+	pool.Reprioritize()
+	if steve.GetOwner() != "" {
+		t.Errorf("expected the job of the player that left to lose its owner, got %v", steve.GetOwner())
+	}
+	release()
+	steve.Wait()
+	alex.Wait()
+	if len(order) != 2 || order[0] != "Alex" {
+		t.Errorf("expected the job of the player that left to run last, got %v", order)
+	}
+}
+
+func TestBackpressure(t *testing.T) {
+	var pool, release = blockPool(1)
+	defer pool.Close()
+
+	pool.Submit(NewJob(1, 0, nil, func() {}))
+	var submitted = make(chan struct{})
+	go func() {
+		pool.Submit(NewJob(2, 0, nil, func() {}))
+		close(submitted)
+	}()
+
+	select {
+	case <-submitted:
+		t.Fatal("expected submitting to block while the queue is full")
+	case <-time.After(time.Millisecond * 50):
+	}
+	release()
+	select {
+	case <-submitted:
+	case <-time.After(time.Second * 2):
+		t.Fatal("expected submitting to continue after the queue drained")
+	}
+}
+
+func TestClose(t *testing.T) {
+	var pool, release = blockPool(16)
+	var job = NewJob(1, 0, nil, func() {})
+	pool.Submit(job)
+	release()
+	pool.Close()
+
+	job.Wait()
+	if err := pool.Submit(NewJob(2, 0, nil, func() {})); err != PoolClosed {
+		t.Errorf("expected PoolClosed after closing, got %v", err)
+	}
+}
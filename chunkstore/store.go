@@ -0,0 +1,112 @@
+package chunkstore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/irmine/binutils"
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+// Store stores data the chunk format of the world does not hold, such as entities or waterlogged blocks,
+// with one NBT file for every chunk holding any. Every file holds a list of compounds under the tag of the store.
+type Store struct {
+	mutex     sync.Mutex
+	directory string
+	tag       string
+}
+
+// New returns a new store keeping its files in the given directory, holding its compounds under the given tag.
+// The directory is created once the first chunk gets saved. Nothing is saved or loaded if the directory is empty.
+func New(directory, tag string) *Store {
+	return &Store{directory: directory, tag: tag}
+}
+
+// GetDirectory returns the directory the store keeps its files in.
+func (store *Store) GetDirectory() string {
+	return store.directory
+}
+
+// IsPersistent checks if the store saves its chunks, which it does if it has a directory.
+func (store *Store) IsPersistent() bool {
+	return store.directory != ""
+}
+
+// getPath returns the path of the file of the chunk at the given chunk coordinates.
+func (store *Store) getPath(x, z int32) string {
+	return filepath.Join(store.directory, fmt.Sprintf("%v.%v.nbt", x, z))
+}
+
+// Save saves the compounds of the chunk at the given chunk coordinates, replacing the compounds saved before.
+// The file of the chunk is removed if there are no compounds.
+func (store *Store) Save(x, z int32, compounds []gonbt.INamedTag) error {
+	if !store.IsPersistent() {
+		return nil
+	}
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	var path = store.getPath(x, z)
+	if len(compounds) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	var writer = gonbt.NewWriter(false, binutils.LittleEndian)
+	writer.WriteUncompressedCompound(gonbt.NewCompound("", map[string]gonbt.INamedTag{
+		store.tag: gonbt.NewList(store.tag, gonbt.TAG_Compound, compounds),
+	}))
+
+	if err := os.MkdirAll(store.directory, 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, writer.GetData(), 0644)
+}
+
+// Load returns the compounds saved in the chunk at the given chunk coordinates.
+// No compounds are returned if the chunk has no file.
+func (store *Store) Load(x, z int32) ([]*gonbt.Compound, error) {
+	if !store.IsPersistent() {
+		return nil, nil
+	}
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	var data, err = ioutil.ReadFile(store.getPath(x, z))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var compound = gonbt.NewReader(data, false, binutils.LittleEndian).ReadIntoCompound()
+	if compound == nil {
+		return nil, fmt.Errorf("invalid %v data in chunk %v, %v", store.tag, x, z)
+	}
+
+	var compounds []*gonbt.Compound
+	for _, tag := range compound.GetList(store.tag, gonbt.TAG_Compound).GetTags() {
+		if compound, ok := tag.(*gonbt.Compound); ok {
+			compounds = append(compounds, compound)
+		}
+	}
+	return compounds, nil
+}
+
+// PositionToNBT returns a compound holding the coordinates of the position.
+func PositionToNBT(position blocks.Position) *gonbt.Compound {
+	return gonbt.NewCompound("", map[string]gonbt.INamedTag{
+		"X": gonbt.NewInt("X", position.X),
+		"Y": gonbt.NewInt("Y", int32(position.Y)),
+		"Z": gonbt.NewInt("Z", position.Z),
+	})
+}
+
+// PositionFromNBT returns the position with the coordinates held by the compound.
+func PositionFromNBT(compound *gonbt.Compound) blocks.Position {
+	return blocks.NewPosition(compound.GetInt("X", 0), uint32(compound.GetInt("Y", 0)), compound.GetInt("Z", 0))
+}
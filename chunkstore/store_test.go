@@ -0,0 +1,40 @@
+package chunkstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+func TestStore(t *testing.T) {
+	var directory, _ = ioutil.TempDir("", "chunkstore")
+	defer os.RemoveAll(directory)
+	var store = New(filepath.Join(directory, "water"), "Water")
+
+	var position = blocks.NewPosition(-3, 70, 17)
+	if err := store.Save(-1, 1, []gonbt.INamedTag{PositionToNBT(position)}); err != nil {
+		t.Fatal(err)
+	}
+	var compounds, err = store.Load(-1, 1)
+	if err != nil || len(compounds) != 1 || PositionFromNBT(compounds[0]) != position {
+		t.Fatalf("expected the saved position to be loaded, got %v, %v", len(compounds), err)
+	}
+
+	if err := store.Save(-1, 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(store.getPath(-1, 1)); !os.IsNotExist(err) {
+		t.Error("expected the file of the empty chunk to be removed")
+	}
+	if compounds, err := store.Load(-1, 1); err != nil || len(compounds) != 0 {
+		t.Errorf("expected no compounds in a chunk without file, got %v, %v", len(compounds), err)
+	}
+
+	if err := New("", "Water").Save(0, 0, []gonbt.INamedTag{PositionToNBT(position)}); err != nil {
+		t.Errorf("expected stores without directory not to save, got %v", err)
+	}
+}
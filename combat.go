@@ -0,0 +1,312 @@
+package gomine
+
+import (
+	"strings"
+
+	"github.com/BobbyShrd/gominetest/combat"
+	"github.com/BobbyShrd/gominetest/events"
+	"github.com/BobbyShrd/gominetest/gamerules"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/players"
+	"github.com/BobbyShrd/gominetest/resources"
+	"github.com/BobbyShrd/gominetest/text"
+	"github.com/golang/geo/r3"
+)
+
+// SpawnPosition is the position players spawn at when joining, and respawn at after dying.
+var SpawnPosition = r3.Vector{X: 0, Y: 7, Z: 0}
+
+// MaximumHealth is the health players respawn with.
+const MaximumHealth = players.MaximumHealth
+
+// AttackEvent gets emitted when a player attacks another player, before any damage is dealt.
+// Handlers may cancel the event, or change the damage and knockback of the attack.
+type AttackEvent struct {
+	events.Cancelled
+	attacker *net.MinecraftSession
+	target   *net.MinecraftSession
+	item     *items.Stack
+
+	// Damage is the damage dealt to the target.
+	Damage float32
+	// Knockback is the motion the target gets knocked back with.
+	Knockback r3.Vector
+}
+
+// NewAttackEvent returns a new attack event for an attack of the attacker on the target with the given item.
+func NewAttackEvent(attacker *net.MinecraftSession, target *net.MinecraftSession, item *items.Stack, damage float32, knockback r3.Vector) *AttackEvent {
+	return &AttackEvent{attacker: attacker, target: target, item: item, Damage: damage, Knockback: knockback}
+}
+
+// GetAttacker returns the session of the attacking player.
+func (event *AttackEvent) GetAttacker() *net.MinecraftSession {
+	return event.attacker
+}
+
+// GetTarget returns the session of the attacked player.
+func (event *AttackEvent) GetTarget() *net.MinecraftSession {
+	return event.target
+}
+
+// GetItem returns the item the attacker attacked with, which may be nil.
+func (event *AttackEvent) GetItem() *items.Stack {
+	return event.item
+}
+
+// CombatViolationEvent gets emitted when a player attacks another player in a way a legitimate client could not have,
+// for example from too far away. The attack is ignored.
+type CombatViolationEvent struct {
+	attacker  *net.MinecraftSession
+	target    *net.MinecraftSession
+	violation combat.Violation
+	hit       combat.Hit
+}
+
+// NewCombatViolationEvent returns a new combat violation event for an impossible hit of the attacker on the target.
+func NewCombatViolationEvent(attacker *net.MinecraftSession, target *net.MinecraftSession, violation combat.Violation, hit combat.Hit) *CombatViolationEvent {
+	return &CombatViolationEvent{attacker, target, violation, hit}
+}
+
+// GetAttacker returns the session of the attacking player.
+func (event *CombatViolationEvent) GetAttacker() *net.MinecraftSession {
+	return event.attacker
+}
+
+// GetTarget returns the session of the attacked player.
+func (event *CombatViolationEvent) GetTarget() *net.MinecraftSession {
+	return event.target
+}
+
+// GetViolation returns the reason the hit was impossible.
+func (event *CombatViolationEvent) GetViolation() combat.Violation {
+	return event.violation
+}
+
+// GetHit returns the positions and rotation of the hit.
+func (event *CombatViolationEvent) GetHit() combat.Hit {
+	return event.hit
+}
+
+// GetSessionByRuntimeId returns the session of the player with the given entity runtime ID,
+// and a bool indicating if such a player is online.
+func (server *Server) GetSessionByRuntimeId(runtimeId uint64) (*net.MinecraftSession, bool) {
+	for _, session := range server.SessionManager.GetSessions() {
+		if session.GetPlayer().GetRuntimeId() == runtimeId {
+			return session, true
+		}
+	}
+	return nil, false
+}
+
+// HandleAttack handles an attack of the session on the entity with the given runtime ID, using the given item.
+// Returns false if the attack was ignored, because PvP is disabled, the target could not be found,
+// could not be reached or was hurt too recently. If hits are validated, impossible hits
+// are ignored as well, and emit a combat violation event.
+// The damage depends on the damage options of the server, and sweeping attacks also hurt players next to the target.
+// Targets blocking with a shield in the direction of the attacker take no damage, and players can not attack their own pets.
+// Attacks on mobs are handled regardless of PvP, and hurt the mob instead.
+func (server *Server) HandleAttack(session *net.MinecraftSession, runtimeId uint64, item *items.Stack) bool {
+	if !session.HasSpawned() {
+		return false
+	}
+	if server.isOwnPet(session, runtimeId) {
+		return false
+	}
+	if mob, ok := server.GetMobManager(session.GetPlayer().GetDimension()).GetMob(runtimeId); ok {
+		return server.attackMob(session, mob)
+	}
+	if !server.Config.AllowPvP {
+		return false
+	}
+	var target, ok = server.GetSessionByRuntimeId(runtimeId)
+	if !ok || target == session || !target.HasSpawned() {
+		return false
+	}
+	var attacker, victim = session.GetPlayer(), target.GetPlayer()
+	if attacker.GetDimension() != victim.GetDimension() || !combat.InReach(attacker.Position, victim.Position) {
+		return false
+	}
+	if !server.GetWorldSettings(attacker.GetDimension().GetLevel()).PvP || !server.IsGameRuleEnabled(attacker.GetDimension().GetLevel(), gamerules.PvP) {
+		return false
+	}
+	if server.Config.ValidateHits && !server.validateHit(session, target) {
+		return false
+	}
+	var charge = server.CombatManager.Attack(attacker.GetRuntimeId(), combat.GetCooldown(item))
+	if !server.CombatManager.TryHurt(runtimeId) {
+		return false
+	}
+
+	var result = server.DamageOptions.Calculate(item, attacker.GetEffects().GetAttackBonus(), charge, server.isFalling(session))
+	var damage = result.Amount * victim.GetEffects().GetDamageMultiplier()
+	var event = NewAttackEvent(session, target, item, damage, server.KnockbackProfile.Get(item).GetMotion(attacker.Position, victim.Position))
+	server.EventManager.Emit(event)
+	if event.IsCancelled() {
+		return false
+	}
+	if server.blockWithShield(target, attacker.Position, item) {
+		return true
+	}
+
+	if result.Critical {
+		server.broadcastAnimate(target, combat.AnimateCriticalHit)
+	}
+	server.hurt(session, target, event.Damage, event.Knockback)
+	if result.Sweep > 0 {
+		server.sweep(session, target, result.Sweep)
+	}
+	return true
+}
+
+// hurt deals damage to the player of the target session, and knocks it back with the given motion.
+// The damage is reduced by the armor of the player, which is killed by the attacker if its health drops to 0.
+func (server *Server) hurt(attacker *net.MinecraftSession, target *net.MinecraftSession, damage float32, knockback r3.Vector) {
+	var victim = target.GetPlayer()
+	var health = victim.GetHealth() - server.protect(target, damage, combat.SourceAttack)
+	if health <= 0 {
+		server.kill(target, attacker.GetDisplayName())
+		return
+	}
+	victim.SetHealth(health)
+	target.SendUpdateAttributes(victim.GetRuntimeId(), victim.GetAttributeMap())
+	server.pushPlayer(target, knockback)
+	server.broadcastHurt(target, combat.EntityEventHurt)
+}
+
+// sweep hurts all players next to the target of a sweeping attack of the attacker with the given damage.
+func (server *Server) sweep(attacker *net.MinecraftSession, target *net.MinecraftSession, damage float32) {
+	var center = target.GetPlayer().Position
+	for _, session := range server.SessionManager.GetSessions() {
+		var player = session.GetPlayer()
+		if session == attacker || session == target || !session.HasSpawned() || player.GetDimension() != target.GetPlayer().GetDimension() {
+			continue
+		}
+		if player.Position.Sub(center).Norm2() > combat.SweepRadius*combat.SweepRadius || !server.CombatManager.TryHurt(player.GetRuntimeId()) {
+			continue
+		}
+		if server.blockWithShield(session, attacker.GetPlayer().Position, nil) {
+			continue
+		}
+		var knockback = server.KnockbackProfile.GetGlobal().GetMotion(attacker.GetPlayer().Position, player.Position)
+		server.hurt(attacker, session, damage*player.GetEffects().GetDamageMultiplier(), knockback)
+	}
+}
+
+// isFalling checks if the player of the session is in the air, below the highest point of its jump or fall.
+func (server *Server) isFalling(session *net.MinecraftSession) bool {
+	server.fallMutex.Lock()
+	defer server.fallMutex.Unlock()
+	var fallStart, ok = server.fallHeights[session.GetName()]
+	return ok && session.GetPlayer().Position.Y < fallStart
+}
+
+// validateHit checks if the attack of the session on the target is possible for a legitimate client.
+// A combat violation event is emitted if it is not.
+func (server *Server) validateHit(session *net.MinecraftSession, target *net.MinecraftSession) bool {
+	var attacker = session.GetPlayer()
+	var reach = combat.SurvivalReach
+	if server.IsCreative(session) {
+		reach = combat.CreativeReach
+	}
+	var hit = combat.Hit{Attacker: attacker.Position, Yaw: attacker.Rotation.Yaw, Pitch: attacker.Rotation.Pitch, Target: target.GetPlayer().Position}
+	var violation = hit.Validate(reach, server.Config.HitTolerance)
+	if violation == combat.NoViolation {
+		return true
+	}
+	server.EventManager.Emit(NewCombatViolationEvent(session, target, violation, hit))
+	return false
+}
+
+// newKnockbackProfile returns the knockback profile with the global and weapon knockback of the configuration.
+// Weapon knockback values left out of the configuration use the global knockback.
+func newKnockbackProfile(config *resources.GoMineConfig) *combat.KnockbackProfile {
+	var global = combat.Knockback{Horizontal: config.KnockbackHorizontal, Vertical: config.KnockbackVertical}
+	var profile = combat.NewKnockbackProfile(global)
+	for id, setting := range config.WeaponKnockback {
+		var knockback = global
+		if setting.Horizontal != nil {
+			knockback.Horizontal = *setting.Horizontal
+		}
+		if setting.Vertical != nil {
+			knockback.Vertical = *setting.Vertical
+		}
+		profile.SetWeapon(weaponId(id), knockback)
+	}
+	return profile
+}
+
+// weaponId returns the item string ID of a weapon name, adding the "minecraft:" namespace if it has none.
+func weaponId(name string) string {
+	if strings.Contains(name, ":") {
+		return strings.ToLower(name)
+	}
+	return "minecraft:" + strings.ToLower(name)
+}
+
+// kill kills the player of the target session, and respawns it at the spawn position.
+// The killer is the name of the player or mob that killed the player.
+func (server *Server) kill(target *net.MinecraftSession, killer string) {
+	server.die(target, "was slain by "+killer)
+}
+
+// die kills the player of the target session, broadcasting the death message after its name,
+// and respawns it at the spawn of its spawn world, like when joining. Nothing happens if a totem or a plugin prevents the death.
+func (server *Server) die(target *net.MinecraftSession, message string) {
+	if server.preventDeath(target, &message) {
+		return
+	}
+	var player = target.GetPlayer()
+	server.Dismount(target)
+	server.broadcastHurt(target, combat.EntityEventDeath)
+	server.BroadcastComponent(text.NewComponent().Color(text.Red).Text(target.GetDisplayName() + " " + message))
+	if !server.IsGameRuleEnabled(player.GetDimension().GetLevel(), gamerules.KeepInventory) {
+		server.dropInventory(target)
+	}
+
+	player.SetHealth(MaximumHealth)
+	target.ClearEffects()
+	server.resetEnvironment(target)
+	target.SendUpdateAttributes(player.GetRuntimeId(), player.GetAttributeMap())
+	var dimension, spawn = server.GetSpawnDimension(target)
+	server.Teleport(target, spawn, dimension)
+	server.CombatManager.Remove(player.GetRuntimeId())
+}
+
+// dropInventory drops the contents of the inventory, the armor and the offhand of the player of the target session
+// at its position, and clears them.
+func (server *Server) dropInventory(target *net.MinecraftSession) {
+	var player = target.GetPlayer()
+	var drops = player.GetInventory().Clear()
+	drops = append(drops, player.GetArmor().Clear()...)
+	drops = append(drops, player.GetOffhand().Clear()...)
+	for _, drop := range drops {
+		server.DropItem(player.GetDimension(), player.Position, drop)
+	}
+	server.resendWindows(target)
+}
+
+// broadcastAnimate plays an animation of the player of the target session,
+// to the player itself and to all its viewers.
+func (server *Server) broadcastAnimate(target *net.MinecraftSession, action int32) {
+	var player = target.GetPlayer()
+	target.SendAnimate(action, player.GetRuntimeId(), 0)
+	for _, viewer := range player.GetViewers() {
+		if viewer, ok := viewer.(*net.MinecraftSession); ok {
+			viewer.SendAnimate(action, player.GetRuntimeId(), 0)
+		}
+	}
+}
+
+// broadcastHurt plays the entity event and the attack sound of the player of the target session,
+// to the player itself and to all its viewers.
+func (server *Server) broadcastHurt(target *net.MinecraftSession, eventId byte) {
+	var player = target.GetPlayer()
+	server.BroadcastPlayerEvent(target, eventId, 0)
+	target.SendLevelSoundEvent(combat.SoundAttackStrong, player.Position, -1)
+	for _, viewer := range player.GetViewers() {
+		if viewer, ok := viewer.(*net.MinecraftSession); ok {
+			viewer.SendLevelSoundEvent(combat.SoundAttackStrong, player.Position, -1)
+		}
+	}
+}
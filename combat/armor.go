@@ -0,0 +1,189 @@
+package combat
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/BobbyShrd/gominetest/items"
+)
+
+// Slots of the armor inventory of players.
+const (
+	SlotHelmet = iota
+	SlotChestplate
+	SlotLeggings
+	SlotBoots
+	// ArmorSlots is the amount of armor slots.
+	ArmorSlots
+)
+
+const (
+	// MaxArmorReduction is the maximum part of the damage, out of 25, that armor reduces.
+	MaxArmorReduction = 20
+	// MaxProtection is the maximum protection factor of the enchantments of armor, out of 25.
+	MaxProtection = 20
+
+	// EnchantmentProtection is the ID of the protection enchantment.
+	EnchantmentProtection = "protection"
+	// EnchantmentFireProtection is the ID of the fire protection enchantment.
+	EnchantmentFireProtection = "fire_protection"
+	// EnchantmentBlastProtection is the ID of the blast protection enchantment.
+	EnchantmentBlastProtection = "blast_protection"
+	// EnchantmentProjectileProtection is the ID of the projectile protection enchantment.
+	EnchantmentProjectileProtection = "projectile_protection"
+	// EnchantmentUnbreaking is the ID of the unbreaking enchantment.
+	EnchantmentUnbreaking = "unbreaking"
+)
+
+// Source is the source of damage, deciding which armor and enchantments protect against it.
+type Source int
+
+const (
+	// SourceAttack is damage of attacks by players and mobs.
+	SourceAttack Source = iota
+	// SourceFire is damage of fire and lava.
+	SourceFire
+	// SourceExplosion is damage of explosions.
+	SourceExplosion
+	// SourceProjectile is damage of projectiles, such as arrows.
+	SourceProjectile
+	// SourceUnblockable is damage that armor does not protect against, such as drowning.
+	SourceUnblockable
+)
+
+// specialProtection holds the enchantments protecting against a specific source of damage, indexed by source.
+var specialProtection = map[Source]string{
+	SourceFire:       EnchantmentFireProtection,
+	SourceExplosion:  EnchantmentBlastProtection,
+	SourceProjectile: EnchantmentProjectileProtection,
+}
+
+// Armor is a piece of armor that can be worn in an armor slot.
+type Armor struct {
+	// Slot is the armor slot the piece is worn in.
+	Slot int
+	// Points is the amount of armor points the piece gives.
+	Points float32
+	// Toughness is the armor toughness of the piece, reducing the effect of high damage on the protection.
+	Toughness float32
+	// Durability is the amount of damage the piece takes before breaking.
+	Durability int16
+}
+
+// armorPieces holds all pieces of armor, indexed by item string ID.
+var armorPieces = map[string]Armor{
+	"minecraft:leather_helmet":     {SlotHelmet, 1, 0, 55},
+	"minecraft:leather_chestplate": {SlotChestplate, 3, 0, 80},
+	"minecraft:leather_leggings":   {SlotLeggings, 2, 0, 75},
+	"minecraft:leather_boots":      {SlotBoots, 1, 0, 65},
+
+	"minecraft:golden_helmet":     {SlotHelmet, 2, 0, 77},
+	"minecraft:golden_chestplate": {SlotChestplate, 5, 0, 112},
+	"minecraft:golden_leggings":   {SlotLeggings, 3, 0, 105},
+	"minecraft:golden_boots":      {SlotBoots, 1, 0, 91},
+
+	"minecraft:chainmail_helmet":     {SlotHelmet, 2, 0, 165},
+	"minecraft:chainmail_chestplate": {SlotChestplate, 5, 0, 240},
+	"minecraft:chainmail_leggings":   {SlotLeggings, 4, 0, 225},
+	"minecraft:chainmail_boots":      {SlotBoots, 1, 0, 195},
+
+	"minecraft:iron_helmet":     {SlotHelmet, 2, 0, 165},
+	"minecraft:iron_chestplate": {SlotChestplate, 6, 0, 240},
+	"minecraft:iron_leggings":   {SlotLeggings, 5, 0, 225},
+	"minecraft:iron_boots":      {SlotBoots, 2, 0, 195},
+
+	"minecraft:diamond_helmet":     {SlotHelmet, 3, 2, 363},
+	"minecraft:diamond_chestplate": {SlotChestplate, 8, 2, 528},
+	"minecraft:diamond_leggings":   {SlotLeggings, 6, 2, 495},
+	"minecraft:diamond_boots":      {SlotBoots, 3, 2, 429},
+}
+
+// RegisterArmor registers the item with the given string ID as a piece of armor.
+func RegisterArmor(itemId string, armor Armor) {
+	armorPieces[itemId] = armor
+}
+
+// GetArmor returns the armor of the given item, and a bool indicating if the item is a piece of armor.
+func GetArmor(item *items.Stack) (Armor, bool) {
+	if item == nil || item.Count == 0 {
+		return Armor{}, false
+	}
+	var armor, ok = armorPieces[item.GetId()]
+	return armor, ok
+}
+
+// GetArmorItemIds returns the string IDs of all pieces of armor.
+func GetArmorItemIds() []string {
+	var ids = make([]string, 0, len(armorPieces))
+	for id := range armorPieces {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ReduceDamage returns the damage dealt by the source to an entity wearing the given pieces of armor.
+// The armor points and toughness of the pieces reduce the damage first,
+// after which protection enchantments reduce it further if enchantments is true.
+// Damage of unblockable sources is not reduced.
+func ReduceDamage(damage float32, pieces []*items.Stack, source Source, enchantments bool) float32 {
+	if source == SourceUnblockable || damage <= 0 {
+		return damage
+	}
+	var points, toughness float32
+	var protection int
+	for _, piece := range pieces {
+		var armor, ok = GetArmor(piece)
+		if !ok {
+			continue
+		}
+		points += armor.Points
+		toughness += armor.Toughness
+		protection += piece.GetEnchantmentLevel(EnchantmentProtection)
+		if enchantment, ok := specialProtection[source]; ok {
+			protection += piece.GetEnchantmentLevel(enchantment) * 2
+		}
+	}
+
+	var reduction = float32(math.Max(float64(points/5), float64(points-damage/(2+toughness/4))))
+	if reduction > MaxArmorReduction {
+		reduction = MaxArmorReduction
+	}
+	damage *= 1 - reduction/25
+	if enchantments {
+		if protection > MaxProtection {
+			protection = MaxProtection
+		}
+		damage *= 1 - float32(protection)/25
+	}
+	return damage
+}
+
+// DamageArmor consumes the durability of the pieces of armor after the wearer took damage from the source.
+// Every piece takes a quarter of the damage, and at least 1. Unbreaking gives pieces a chance not to take damage.
+// The durability of items holds the damage taken, like the data value of breakable items.
+// The slots of all pieces that took damage are returned, and pieces that broke are set to nil.
+func DamageArmor(pieces []*items.Stack, damage float32, source Source, random *rand.Rand) []int {
+	if source == SourceUnblockable || damage <= 0 {
+		return nil
+	}
+	var taken = int16(damage / 4)
+	if taken < 1 {
+		taken = 1
+	}
+	var changed []int
+	for slot, piece := range pieces {
+		var armor, ok = GetArmor(piece)
+		if !ok {
+			continue
+		}
+		if level := piece.GetEnchantmentLevel(EnchantmentUnbreaking); level > 0 && random.Float64() >= 0.6+0.4/float64(level+1) {
+			continue
+		}
+		piece.Durability += taken
+		if piece.Durability >= armor.Durability {
+			pieces[slot] = nil
+		}
+		changed = append(changed, slot)
+	}
+	return changed
+}
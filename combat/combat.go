@@ -0,0 +1,166 @@
+package combat
+
+import (
+	"sync"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/golang/geo/r3"
+)
+
+const (
+	// FistDamage is the damage dealt by attacking without a weapon.
+	FistDamage float32 = 1
+
+	// KnockbackHorizontal is the horizontal speed targets get knocked back with.
+	KnockbackHorizontal = 0.4
+	// KnockbackVertical is the vertical speed targets get knocked up with.
+	KnockbackVertical = 0.4
+
+	// Reach is the maximum distance between an attacker and its target.
+	// Attacks from further away are ignored, as the client could not have made them.
+	Reach = 8.0
+
+	// EntityEventHurt is the entity event playing the hurt animation of an entity.
+	EntityEventHurt byte = 2
+	// EntityEventDeath is the entity event playing the death animation of an entity.
+	EntityEventDeath byte = 3
+	// EntityEventConsumeTotem is the entity event playing the animation and sound of a totem saving an entity from death.
+	EntityEventConsumeTotem byte = 65
+	// SoundAttackStrong is the level sound event of a damaging attack.
+	SoundAttackStrong uint32 = 43
+)
+
+// DefaultCooldown is the time an entity can not be hurt again after getting hurt.
+var DefaultCooldown = time.Millisecond * 500
+
+// weaponDamage holds the damage dealt by weapons, indexed by item string ID.
+var weaponDamage = map[string]float32{
+	"minecraft:wooden_sword":  4,
+	"minecraft:golden_sword":  4,
+	"minecraft:stone_sword":   5,
+	"minecraft:iron_sword":    6,
+	"minecraft:diamond_sword": 7,
+
+	"minecraft:wooden_axe":  3,
+	"minecraft:golden_axe":  3,
+	"minecraft:stone_axe":   4,
+	"minecraft:iron_axe":    5,
+	"minecraft:diamond_axe": 6,
+
+	"minecraft:wooden_pickaxe":  2,
+	"minecraft:golden_pickaxe":  2,
+	"minecraft:stone_pickaxe":   3,
+	"minecraft:iron_pickaxe":    4,
+	"minecraft:diamond_pickaxe": 5,
+
+	"minecraft:wooden_shovel":  2,
+	"minecraft:golden_shovel":  2,
+	"minecraft:stone_shovel":   3,
+	"minecraft:iron_shovel":    4,
+	"minecraft:diamond_shovel": 5,
+}
+
+// RegisterWeapon sets the damage dealt by attacking with the item with the given string ID.
+func RegisterWeapon(itemId string, damage float32) {
+	weaponDamage[itemId] = damage
+}
+
+// GetDamage returns the damage dealt by attacking with the given item.
+// Fist damage is returned for nil items and items that are no weapon.
+func GetDamage(item *items.Stack) float32 {
+	if item == nil || item.Count == 0 {
+		return FistDamage
+	}
+	if damage, ok := weaponDamage[item.GetId()]; ok {
+		return damage
+	}
+	return FistDamage
+}
+
+// GetKnockback returns the motion of a target at the given position
+// after getting hit by an attacker at the attacker position, using the default knockback.
+func GetKnockback(attacker r3.Vector, target r3.Vector) r3.Vector {
+	return DefaultKnockback.GetMotion(attacker, target)
+}
+
+// InReach checks if an attacker at the given position can reach a target at the target position.
+func InReach(attacker r3.Vector, target r3.Vector) bool {
+	return attacker.Sub(target).Norm2() <= Reach*Reach
+}
+
+// Manager keeps track of recently hurt entities,
+// so that entities can not be hurt again during their cooldown.
+// Manager also keeps track of the last attacks of entities, to compute the charge of their attacks,
+// and of the shields disabled by axes.
+type Manager struct {
+	mutex    sync.Mutex
+	cooldown time.Duration
+	hurt     map[uint64]time.Time
+	attacks  map[uint64]time.Time
+	shields  map[uint64]time.Time
+}
+
+// NewManager returns a new manager, in which entities can not be hurt again during the given cooldown.
+func NewManager(cooldown time.Duration) *Manager {
+	return &Manager{cooldown: cooldown, hurt: make(map[uint64]time.Time), attacks: make(map[uint64]time.Time), shields: make(map[uint64]time.Time)}
+}
+
+// Attack marks the entity with the given runtime ID as attacking, and returns the charge of the attack.
+// The charge ranges from 0 right after another attack, to 1 once the attack cooldown passed.
+func (manager *Manager) Attack(runtimeId uint64, attackCooldown time.Duration) float64 {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var now = time.Now()
+	var last, ok = manager.attacks[runtimeId]
+	manager.attacks[runtimeId] = now
+	if !ok || attackCooldown <= 0 {
+		return 1
+	}
+	var charge = float64(now.Sub(last)) / float64(attackCooldown)
+	if charge > 1 {
+		return 1
+	}
+	return charge
+}
+
+// TryHurt marks the entity with the given runtime ID as hurt.
+// Returns false if the entity is still in its cooldown, in which case it should not be hurt.
+func (manager *Manager) TryHurt(runtimeId uint64) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var now = time.Now()
+	if last, ok := manager.hurt[runtimeId]; ok && now.Sub(last) < manager.cooldown {
+		return false
+	}
+	manager.hurt[runtimeId] = now
+	return true
+}
+
+// DisableShield disables the shield of the entity with the given runtime ID for the shield cooldown.
+func (manager *Manager) DisableShield(runtimeId uint64) {
+	manager.mutex.Lock()
+	manager.shields[runtimeId] = time.Now().Add(ShieldCooldown)
+	manager.mutex.Unlock()
+}
+
+// IsShieldDisabled checks if the shield of the entity with the given runtime ID is disabled by an axe.
+func (manager *Manager) IsShieldDisabled(runtimeId uint64) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var until, ok = manager.shields[runtimeId]
+	if ok && time.Now().After(until) {
+		delete(manager.shields, runtimeId)
+		return false
+	}
+	return ok
+}
+
+// Remove forgets the entity with the given runtime ID, for example because it left the server.
+func (manager *Manager) Remove(runtimeId uint64) {
+	manager.mutex.Lock()
+	delete(manager.hurt, runtimeId)
+	delete(manager.attacks, runtimeId)
+	delete(manager.shields, runtimeId)
+	manager.mutex.Unlock()
+}
@@ -0,0 +1,153 @@
+package combat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/golang/geo/r3"
+)
+
+func TestKnockback(t *testing.T) {
+	var motion = GetKnockback(r3.Vector{}, r3.Vector{X: 3, Z: 4})
+	if math.Abs(motion.X-0.24) > 1e-9 || math.Abs(motion.Z-0.32) > 1e-9 || motion.Y != KnockbackVertical {
+		t.Errorf("expected the target to be pushed away from the attacker, got %v", motion)
+	}
+	if motion := GetKnockback(r3.Vector{}, r3.Vector{Y: 1}); motion.X != 0 || motion.Z != 0 {
+		t.Errorf("expected only vertical knockback for targets straight above, got %v", motion)
+	}
+}
+
+func TestCooldown(t *testing.T) {
+	var manager = NewManager(time.Millisecond * 50)
+	if !manager.TryHurt(1) {
+		t.Fatal("expected the first hit to hurt")
+	}
+	if manager.TryHurt(1) {
+		t.Error("expected hits during the cooldown not to hurt")
+	}
+	if !manager.TryHurt(2) {
+		t.Error("expected other entities to be hurt")
+	}
+	time.Sleep(time.Millisecond * 60)
+	if !manager.TryHurt(1) {
+		t.Error("expected hits after the cooldown to hurt")
+	}
+}
+
+func TestDamage(t *testing.T) {
+	if damage := GetDamage(nil); damage != FistDamage {
+		t.Errorf("expected fist damage without an item, got %v", damage)
+	}
+}
+
+func TestValidateHit(t *testing.T) {
+	// Looking straight ahead along the positive Z axis.
+	var hit = Hit{Attacker: r3.Vector{}, Target: r3.Vector{Z: 3}}
+	if violation := hit.Validate(SurvivalReach, 0); violation != NoViolation {
+		t.Errorf("expected hit in front within reach to be valid, got %v", violation)
+	}
+
+	hit.Target = r3.Vector{Z: 5}
+	if violation := hit.Validate(SurvivalReach, 0); violation != ViolationReach {
+		t.Errorf("expected reach violation, got %v", violation)
+	}
+	if violation := hit.Validate(SurvivalReach, 2); violation != NoViolation {
+		t.Errorf("expected hit to be valid with tolerance, got %v", violation)
+	}
+
+	hit.Target = r3.Vector{Z: -3}
+	if violation := hit.Validate(SurvivalReach, 0); violation != ViolationAngle {
+		t.Errorf("expected angle violation for a target behind the attacker, got %v", violation)
+	}
+	hit.Yaw = 180
+	if violation := hit.Validate(SurvivalReach, 0); violation != NoViolation {
+		t.Errorf("expected hit to be valid after turning around, got %v", violation)
+	}
+}
+
+func TestKnockbackProfile(t *testing.T) {
+	var profile = NewKnockbackProfile(Knockback{Horizontal: 0.5, Vertical: 0.3})
+	var stick, _ = items.DefaultManager.Get("minecraft:stick", 1)
+	profile.SetWeapon("minecraft:stick", Knockback{Horizontal: 1, Vertical: 0.2})
+
+	if knockback := profile.Get(nil); knockback.Horizontal != 0.5 {
+		t.Errorf("expected global knockback for fists, got %v", knockback)
+	}
+	if motion := profile.Get(stick).GetMotion(r3.Vector{}, r3.Vector{X: 2}); motion.X != 1 || motion.Y != 0.2 {
+		t.Errorf("expected weapon knockback for the stick, got %v", motion)
+	}
+	if !profile.RemoveWeapon("minecraft:stick") || profile.Get(stick) != profile.GetGlobal() {
+		t.Error("expected global knockback after removing the weapon knockback")
+	}
+}
+
+func TestDamageOptions(t *testing.T) {
+	var options = DamageOptions{Criticals: true, Sweeping: true, Cooldown: true, Enchantments: true}
+	var stick, _ = items.DefaultManager.Get("minecraft:stick", 1)
+
+	var damage = options.Calculate(stick, 1, 1, false)
+	if damage.Amount != FistDamage+1 || damage.Critical || damage.Sweep != 0 {
+		t.Errorf("expected fist damage with bonus, got %+v", damage)
+	}
+	if damage := options.Calculate(stick, 1, 1, true); !damage.Critical || damage.Amount != (FistDamage+1)*CriticalMultiplier {
+		t.Errorf("expected critical hit while falling, got %+v", damage)
+	}
+	if damage := options.Calculate(nil, 0, 0.5, true); damage.Critical || damage.Amount != FistDamage*0.4 {
+		t.Errorf("expected reduced damage without critical for a half charged attack, got %+v", damage)
+	}
+
+	options.Cooldown = false
+	if damage := options.Calculate(nil, 0, 0, false); damage.Amount != FistDamage {
+		t.Errorf("expected charge to be ignored without cooldown, got %+v", damage)
+	}
+}
+
+func TestAttackCharge(t *testing.T) {
+	var manager = NewManager(DefaultCooldown)
+	if charge := manager.Attack(1, time.Millisecond*50); charge != 1 {
+		t.Errorf("expected first attack to be fully charged, got %v", charge)
+	}
+	if charge := manager.Attack(1, time.Millisecond*50); charge >= 1 {
+		t.Errorf("expected second attack not to be charged, got %v", charge)
+	}
+	time.Sleep(time.Millisecond * 60)
+	if charge := manager.Attack(1, time.Millisecond*50); charge != 1 {
+		t.Errorf("expected attack to be charged after the cooldown, got %v", charge)
+	}
+}
+
+func TestArmor(t *testing.T) {
+	var pieces = make([]*items.Stack, ArmorSlots)
+	for slot, id := range []string{"minecraft:diamond_helmet", "minecraft:diamond_chestplate", "minecraft:diamond_leggings", "minecraft:diamond_boots"} {
+		pieces[slot], _ = items.DefaultManager.Get(id, 1)
+	}
+	if damage := ReduceDamage(10, pieces, SourceAttack, true); math.Abs(float64(damage)-3) > 1e-5 {
+		t.Errorf("expected full diamond armor to reduce 10 damage to 3, got %v", damage)
+	}
+	if damage := ReduceDamage(10, pieces, SourceUnblockable, true); damage != 10 {
+		t.Errorf("expected unblockable damage not to be reduced, got %v", damage)
+	}
+
+	pieces[SlotBoots].Durability = 428
+	var changed = DamageArmor(pieces, 10, SourceAttack, rand.New(rand.NewSource(0)))
+	if len(changed) != ArmorSlots || pieces[SlotHelmet].Durability != 2 {
+		t.Errorf("expected all pieces to take 2 damage, got slots %v", changed)
+	}
+	if pieces[SlotBoots] != nil {
+		t.Error("expected boots without durability left to break")
+	}
+}
+
+func TestShield(t *testing.T) {
+	if !IsFacing(r3.Vector{}, 0, r3.Vector{Z: 2}) || IsFacing(r3.Vector{}, 0, r3.Vector{Z: -2}) {
+		t.Error("expected only attackers in front to be faced")
+	}
+	var manager = NewManager(0)
+	manager.DisableShield(1)
+	if !manager.IsShieldDisabled(1) || manager.IsShieldDisabled(2) {
+		t.Error("expected only the shield of the hit entity to be disabled")
+	}
+}
@@ -0,0 +1,120 @@
+package combat
+
+import (
+	"strings"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/items"
+)
+
+const (
+	// CriticalMultiplier is the multiplier of the damage of critical hits.
+	CriticalMultiplier = 1.5
+	// CriticalCharge is the minimum attack charge required for critical hits and sweeping attacks.
+	CriticalCharge = 0.9
+
+	// SharpnessBonus is the extra damage dealt per level of sharpness.
+	SharpnessBonus = 1.25
+	// SweepDamage is the damage dealt to entities next to the target of a sweeping attack.
+	SweepDamage float32 = 1
+	// SweepRadius is the distance from the target within which entities get hurt by a sweeping attack.
+	SweepRadius = 1.0
+
+	// AnimateCriticalHit is the animate action showing critical hit particles around an entity.
+	AnimateCriticalHit int32 = 4
+
+	// EnchantmentSharpness is the ID of the sharpness enchantment.
+	EnchantmentSharpness = "sharpness"
+	// EnchantmentSweepingEdge is the ID of the sweeping edge enchantment.
+	EnchantmentSweepingEdge = "sweeping_edge"
+)
+
+// FistCooldown is the time it takes to fully charge an attack without a weapon.
+var FistCooldown = time.Millisecond * 250
+
+// weaponCooldowns holds the time it takes to fully charge an attack with a weapon, indexed by item string ID suffix.
+var weaponCooldowns = map[string]time.Duration{
+	"_sword":   time.Millisecond * 625,
+	"_axe":     time.Millisecond * 1000,
+	"_pickaxe": time.Millisecond * 833,
+	"_shovel":  time.Millisecond * 1000,
+	"_hoe":     time.Millisecond * 1000,
+}
+
+// IsSword checks if the item is a sword.
+func IsSword(item *items.Stack) bool {
+	return item != nil && item.Count > 0 && strings.HasSuffix(item.GetId(), "_sword")
+}
+
+// GetCooldown returns the time it takes to fully charge an attack with the given item.
+func GetCooldown(item *items.Stack) time.Duration {
+	if item == nil || item.Count == 0 {
+		return FistCooldown
+	}
+	for suffix, cooldown := range weaponCooldowns {
+		if strings.HasSuffix(item.GetId(), suffix) {
+			return cooldown
+		}
+	}
+	return FistCooldown
+}
+
+// GetEnchantmentBonus returns the extra damage dealt by the enchantments of the item.
+func GetEnchantmentBonus(item *items.Stack) float32 {
+	if item == nil || item.Count == 0 {
+		return 0
+	}
+	return float32(item.GetEnchantmentLevel(EnchantmentSharpness)) * SharpnessBonus
+}
+
+// DamageOptions decide which combat mechanics are used when calculating the damage of attacks.
+type DamageOptions struct {
+	// Criticals enables critical hits, dealing extra damage when the attacker is falling.
+	Criticals bool
+	// Sweeping enables sweeping attacks of swords, also hurting entities next to the target.
+	Sweeping bool
+	// Cooldown enables the attack cooldown, reducing the damage of attacks made before being fully charged.
+	Cooldown bool
+	// Enchantments enables the damage bonus of enchantments, such as sharpness,
+	// and the damage reduction of protection enchantments on armor.
+	Enchantments bool
+}
+
+// Damage is the damage dealt by an attack.
+type Damage struct {
+	// Amount is the damage dealt to the target.
+	Amount float32
+	// Critical is true if the attack was a critical hit.
+	Critical bool
+	// Sweep is the damage dealt to entities next to the target, or 0 if the attack was not a sweeping attack.
+	Sweep float32
+}
+
+// Calculate returns the damage of an attack with the item. The bonus is the extra damage dealt by the attacker,
+// for example because of its effects. The charge ranges from 0 to 1, and is ignored if the cooldown is disabled.
+// Falling is true if the attacker was falling while attacking.
+func (options DamageOptions) Calculate(item *items.Stack, bonus float32, charge float64, falling bool) Damage {
+	if !options.Cooldown {
+		charge = 1
+	}
+	var damage = Damage{Amount: (GetDamage(item) + bonus) * float32(0.2+charge*charge*0.8)}
+	if options.Enchantments {
+		damage.Amount += GetEnchantmentBonus(item) * float32(charge)
+	}
+	if options.Criticals && falling && charge > CriticalCharge {
+		damage.Amount *= CriticalMultiplier
+		damage.Critical = true
+	}
+	if options.Sweeping && !falling && charge > CriticalCharge && IsSword(item) {
+		damage.Sweep = SweepDamage
+		if options.Enchantments {
+			if level := item.GetEnchantmentLevel(EnchantmentSweepingEdge); level > 0 {
+				damage.Sweep += damage.Amount * float32(level) / float32(level+1)
+			}
+		}
+	}
+	if damage.Amount < 0 {
+		damage.Amount = 0
+	}
+	return damage
+}
@@ -0,0 +1,102 @@
+package combat
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+)
+
+const (
+	// SurvivalReach is the maximum distance between the eyes of a survival attacker and the hitbox of its target.
+	SurvivalReach = 3.0
+	// CreativeReach is the maximum distance between the eyes of a creative attacker and the hitbox of its target.
+	CreativeReach = 6.0
+	// MaxHitAngle is the maximum angle in degrees between the direction an attacker
+	// is looking in and the direction of the center of its target.
+	MaxHitAngle = 75.0
+
+	// PlayerEyeHeight is the height of the eyes of players above their position.
+	PlayerEyeHeight = 1.62
+	// PlayerWidth is the width of the hitbox of players.
+	PlayerWidth = 0.6
+	// PlayerHeight is the height of the hitbox of players.
+	PlayerHeight = 1.8
+)
+
+// Violation is a reason for a hit to be impossible for a legitimate client.
+type Violation int
+
+const (
+	// NoViolation is returned for hits that are possible.
+	NoViolation Violation = iota
+	// ViolationReach is returned for hits on targets too far away.
+	ViolationReach
+	// ViolationAngle is returned for hits on targets the attacker is not looking at.
+	ViolationAngle
+)
+
+// String returns a readable name of the violation.
+func (violation Violation) String() string {
+	switch violation {
+	case ViolationReach:
+		return "reach"
+	case ViolationAngle:
+		return "angle"
+	}
+	return "none"
+}
+
+// Hit is an attack of an attacker on a target, of which the positions are the positions of their feet.
+type Hit struct {
+	Attacker r3.Vector
+	Yaw      float64
+	Pitch    float64
+	Target   r3.Vector
+}
+
+// GetDistance returns the distance between the eyes of the attacker and the closest point of the hitbox of the target,
+// after growing the hitbox by the given tolerance.
+func (hit Hit) GetDistance(tolerance float64) float64 {
+	var eyes = hit.Attacker.Add(r3.Vector{Y: PlayerEyeHeight})
+	var half = PlayerWidth/2 + tolerance
+	var closest = r3.Vector{
+		X: clamp(eyes.X, hit.Target.X-half, hit.Target.X+half),
+		Y: clamp(eyes.Y, hit.Target.Y-tolerance, hit.Target.Y+PlayerHeight+tolerance),
+		Z: clamp(eyes.Z, hit.Target.Z-half, hit.Target.Z+half),
+	}
+	return eyes.Sub(closest).Norm()
+}
+
+// GetAngle returns the angle in degrees between the direction the attacker is looking in,
+// and the direction from its eyes to the center of the hitbox of the target.
+func (hit Hit) GetAngle() float64 {
+	var eyes = hit.Attacker.Add(r3.Vector{Y: PlayerEyeHeight})
+	var direction = hit.Target.Add(r3.Vector{Y: PlayerHeight / 2}).Sub(eyes)
+	if direction.Norm() < 1e-4 {
+		return 0
+	}
+	var yaw, pitch = hit.Yaw * math.Pi / 180, hit.Pitch * math.Pi / 180
+	var look = r3.Vector{X: -math.Sin(yaw) * math.Cos(pitch), Y: -math.Sin(pitch), Z: math.Cos(yaw) * math.Cos(pitch)}
+	var cos = look.Dot(direction) / direction.Norm()
+	return math.Acos(clamp(cos, -1, 1)) * 180 / math.Pi
+}
+
+// Validate checks if the hit is possible with the given reach.
+// The tolerance is the amount of blocks the hitbox of the target is grown by,
+// to allow for targets moving while the attack was being sent.
+// The angle is not checked for targets closer than a block, as their center may be far off the line of sight.
+func (hit Hit) Validate(reach float64, tolerance float64) Violation {
+	var distance = hit.GetDistance(tolerance)
+	if distance > reach {
+		return ViolationReach
+	}
+	if distance > 1 && hit.GetAngle() > MaxHitAngle {
+		return ViolationAngle
+	}
+	return NoViolation
+}
+
+// clamp returns the value limited to the range of min and max.
+func clamp(value float64, min float64, max float64) float64 {
+	return math.Max(min, math.Min(max, value))
+}
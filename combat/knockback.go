@@ -0,0 +1,99 @@
+package combat
+
+import (
+	"math"
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/golang/geo/r3"
+)
+
+// Knockback is the strength targets get knocked back with when getting hit.
+type Knockback struct {
+	// Horizontal is the horizontal speed targets get pushed away from the attacker with.
+	Horizontal float64
+	// Vertical is the vertical speed targets get knocked up with.
+	Vertical float64
+}
+
+// DefaultKnockback is the knockback of attacks when no other knockback is configured.
+var DefaultKnockback = Knockback{KnockbackHorizontal, KnockbackVertical}
+
+// GetMotion returns the motion of a target at the given position
+// after getting hit by an attacker at the attacker position.
+// Targets are pushed away from the attacker, and upwards.
+func (knockback Knockback) GetMotion(attacker r3.Vector, target r3.Vector) r3.Vector {
+	var x, z = target.X - attacker.X, target.Z - attacker.Z
+	var length = math.Sqrt(x*x + z*z)
+	if length < 1e-4 {
+		return r3.Vector{Y: knockback.Vertical}
+	}
+	return r3.Vector{X: x / length * knockback.Horizontal, Y: knockback.Vertical, Z: z / length * knockback.Horizontal}
+}
+
+// KnockbackProfile holds the knockback of attacks,
+// which may be overridden for attacks with specific weapons.
+type KnockbackProfile struct {
+	mutex   sync.RWMutex
+	global  Knockback
+	weapons map[string]Knockback
+}
+
+// NewKnockbackProfile returns a new knockback profile using the global knockback for all attacks.
+func NewKnockbackProfile(global Knockback) *KnockbackProfile {
+	return &KnockbackProfile{global: global, weapons: make(map[string]Knockback)}
+}
+
+// GetGlobal returns the knockback of attacks with items that have no knockback of their own.
+func (profile *KnockbackProfile) GetGlobal() Knockback {
+	profile.mutex.RLock()
+	defer profile.mutex.RUnlock()
+	return profile.global
+}
+
+// SetGlobal sets the knockback of attacks with items that have no knockback of their own.
+func (profile *KnockbackProfile) SetGlobal(knockback Knockback) {
+	profile.mutex.Lock()
+	profile.global = knockback
+	profile.mutex.Unlock()
+}
+
+// SetWeapon sets the knockback of attacks with the item with the given string ID.
+func (profile *KnockbackProfile) SetWeapon(itemId string, knockback Knockback) {
+	profile.mutex.Lock()
+	profile.weapons[itemId] = knockback
+	profile.mutex.Unlock()
+}
+
+// RemoveWeapon makes attacks with the item with the given string ID use the global knockback again.
+// Returns false if the item had no knockback of its own.
+func (profile *KnockbackProfile) RemoveWeapon(itemId string) bool {
+	profile.mutex.Lock()
+	defer profile.mutex.Unlock()
+	var _, ok = profile.weapons[itemId]
+	delete(profile.weapons, itemId)
+	return ok
+}
+
+// GetWeapons returns the knockback of all items with knockback of their own, indexed by item string ID.
+func (profile *KnockbackProfile) GetWeapons() map[string]Knockback {
+	profile.mutex.RLock()
+	defer profile.mutex.RUnlock()
+	var weapons = make(map[string]Knockback, len(profile.weapons))
+	for id, knockback := range profile.weapons {
+		weapons[id] = knockback
+	}
+	return weapons
+}
+
+// Get returns the knockback of attacks with the given item, which may be nil.
+func (profile *KnockbackProfile) Get(item *items.Stack) Knockback {
+	profile.mutex.RLock()
+	defer profile.mutex.RUnlock()
+	if item != nil && item.Count > 0 {
+		if knockback, ok := profile.weapons[item.GetId()]; ok {
+			return knockback
+		}
+	}
+	return profile.global
+}
@@ -0,0 +1,45 @@
+package combat
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/golang/geo/r3"
+)
+
+const (
+	// ShieldItemId is the string ID of the shield item.
+	ShieldItemId = "minecraft:shield"
+	// MaxBlockAngle is the maximum angle in degrees between the direction a blocking entity is looking in,
+	// and the direction to its attacker, for the attack to be blocked.
+	MaxBlockAngle = 90.0
+)
+
+// ShieldCooldown is the time a shield can not be used after getting hit by an axe.
+var ShieldCooldown = time.Second * 5
+
+// IsShield checks if the item is a shield.
+func IsShield(item *items.Stack) bool {
+	return item != nil && item.Count > 0 && item.GetId() == ShieldItemId
+}
+
+// IsAxe checks if the item is an axe, which disables the shield of the entity it hits.
+func IsAxe(item *items.Stack) bool {
+	return item != nil && item.Count > 0 && strings.HasSuffix(item.GetId(), "_axe")
+}
+
+// IsFacing checks if an entity at the given position, looking in the direction of the yaw,
+// faces an attacker at the attacker position closely enough to block its attacks with a shield.
+// Only the horizontal direction is taken into account.
+func IsFacing(position r3.Vector, yaw float64, attacker r3.Vector) bool {
+	var direction = r3.Vector{X: attacker.X - position.X, Z: attacker.Z - position.Z}
+	if direction.Norm() < 1e-4 {
+		return true
+	}
+	var radians = yaw * math.Pi / 180
+	var look = r3.Vector{X: -math.Sin(radians), Z: math.Cos(radians)}
+	var cos = look.Dot(direction) / direction.Norm()
+	return math.Acos(clamp(cos, -1, 1))*180/math.Pi <= MaxBlockAngle
+}
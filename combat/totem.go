@@ -0,0 +1,29 @@
+package combat
+
+import "github.com/BobbyShrd/gominetest/items"
+
+const (
+	// TotemHealth is the health an entity is left with after a totem saved it from death.
+	TotemHealth float32 = 1
+	// TotemRegenerationDuration is the duration in ticks of the regeneration given by a totem.
+	TotemRegenerationDuration = 900
+	// TotemAbsorptionDuration is the duration in ticks of the absorption given by a totem.
+	TotemAbsorptionDuration = 100
+	// TotemFireResistanceDuration is the duration in ticks of the fire resistance given by a totem.
+	TotemFireResistanceDuration = 800
+)
+
+// totems holds the items that save the entity holding them from death, indexed by item string ID.
+var totems = map[string]bool{
+	"minecraft:totem": true,
+}
+
+// RegisterTotem registers the item with the given string ID as an item saving the entity holding it from death.
+func RegisterTotem(itemId string) {
+	totems[itemId] = true
+}
+
+// IsTotem checks if the item saves the entity holding it from death.
+func IsTotem(item *items.Stack) bool {
+	return item != nil && item.Count > 0 && totems[item.GetId()]
+}
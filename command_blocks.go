@@ -0,0 +1,180 @@
+package gomine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/BobbyShrd/gominetest/blockentities"
+	"github.com/BobbyShrd/gominetest/combat"
+	"github.com/BobbyShrd/gominetest/commands"
+	"github.com/BobbyShrd/gominetest/gamerules"
+	"github.com/BobbyShrd/gominetest/lang"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/redstone"
+	"github.com/BobbyShrd/gominetest/text"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	// CommandBlock is the name of impulse command blocks, which execute their command once when powered.
+	// Repeating and chain command blocks are not supported.
+	CommandBlock = "command_block"
+
+	// CommandBlockWindowId is the window ID of the command block editor. It is above the IDs used for container windows.
+	CommandBlockWindowId byte = 101
+	// CommandBlockWindowType is the window type of the command block editor.
+	CommandBlockWindowType byte = 16
+)
+
+// CommandBlockSender is the command sender of commands executed by command blocks.
+// Command blocks have all permissions, as only players allowed to edit command blocks can set their command.
+// Messages sent to a command block are kept as the output of its command.
+type CommandBlockSender struct {
+	dimension *worlds.Dimension
+	position  blocks.Position
+	output    []string
+}
+
+// GetName returns the name of command blocks used in messages, such as the output of commands.
+func (sender *CommandBlockSender) GetName() string {
+	return "@"
+}
+
+// GetDimension returns the dimension of the command block.
+func (sender *CommandBlockSender) GetDimension() *worlds.Dimension {
+	return sender.dimension
+}
+
+// GetPosition returns the position of the command block.
+func (sender *CommandBlockSender) GetPosition() blocks.Position {
+	return sender.position
+}
+
+// HasPermission returns true, as command blocks have all permissions.
+func (sender *CommandBlockSender) HasPermission(string) bool {
+	return true
+}
+
+// SendMessage keeps the message as output of the command, translated to the default language.
+func (sender *CommandBlockSender) SendMessage(message ...interface{}) {
+	for i, value := range message {
+		if component, ok := value.(*text.Component); ok {
+			message[i] = component.Translated(translateDefault)
+		}
+	}
+	sender.output = append(sender.output, strings.TrimSuffix(fmt.Sprintln(message...), "\n"))
+}
+
+// GetOutput returns all messages sent to the command block, separated by newlines.
+func (sender *CommandBlockSender) GetOutput() string {
+	return strings.Join(sender.output, "\n")
+}
+
+// registerCommandBlocks lets command blocks be powered by redstone, and opened by players allowed to edit them.
+func (server *Server) registerCommandBlocks() {
+	server.RedstoneEngine.RegisterBehavior(func(world redstone.World, position blocks.Position, powered bool) {
+		if world, ok := world.(*DimensionWorld); ok {
+			server.PowerCommandBlock(world.GetDimension(), position, powered)
+		}
+	}, CommandBlock)
+
+	server.InteractionRegistry.RegisterBlockHandler(func(session *net.MinecraftSession, position blocks.Position, _ string) bool {
+		if !server.canEditCommandBlock(session, position) {
+			return false
+		}
+		var commandBlock = server.getCommandBlock(session.GetPlayer().GetDimension(), position)
+		session.SendBlockEntityData(position, encodeBlockEntity(commandBlock))
+		session.SendContainerOpen(CommandBlockWindowId, CommandBlockWindowType, position)
+		return true
+	}, CommandBlock)
+}
+
+// getCommandBlock returns the block entity of the command block at the position in the dimension.
+// The block entity is created if the command block did not have one yet.
+func (server *Server) getCommandBlock(dimension *worlds.Dimension, position blocks.Position) *blockentities.CommandBlock {
+	var blockEntity, _ = server.GetBlockEntityManager(dimension).Get(position)
+	if commandBlock, ok := blockEntity.(*blockentities.CommandBlock); ok {
+		return commandBlock
+	}
+	var commandBlock = blockentities.NewCommandBlock(position)
+	server.AddBlockEntity(dimension, commandBlock)
+	return commandBlock
+}
+
+// PowerCommandBlock sets whether the command block at the position in the dimension is powered by redstone.
+// The command of the command block is executed when it goes from unpowered to powered.
+func (server *Server) PowerCommandBlock(dimension *worlds.Dimension, position blocks.Position, powered bool) {
+	var commandBlock = server.getCommandBlock(dimension, position)
+	var rising = powered && !commandBlock.Powered
+	commandBlock.Powered = powered
+	if rising && strings.TrimSpace(commandBlock.Command) != "" {
+		server.ExecuteCommandBlock(dimension, commandBlock)
+	}
+}
+
+// ExecuteCommandBlock executes the command of the command block in the dimension, keeping its success count and output.
+// The output is sent to players allowed to edit command blocks and logged if the commandBlockOutput game rule is enabled.
+func (server *Server) ExecuteCommandBlock(dimension *worlds.Dimension, commandBlock *blockentities.CommandBlock) {
+	var sender = &CommandBlockSender{dimension: dimension, position: commandBlock.GetPosition()}
+	var output = commands.NewOutput()
+	if command, args, ok := server.ParseCommand(commandBlock.Command); ok {
+		output = command.Execute(sender, args)
+	} else {
+		output.TranslateError("commands.generic.unknown", strings.TrimLeft(commandBlock.Command, "/"))
+	}
+	commands.SendOutput(sender, output)
+
+	commandBlock.SuccessCount = int32(output.GetSuccessCount())
+	commandBlock.LastOutput = ""
+	if commandBlock.TrackOutput {
+		commandBlock.LastOutput = sender.GetOutput()
+	}
+	server.UpdateBlockEntity(dimension, commandBlock)
+
+	if len(sender.output) == 0 || !server.IsGameRuleEnabled(dimension.GetLevel(), gamerules.CommandBlockOutput) {
+		return
+	}
+	for _, message := range sender.output {
+		var component = text.NewComponent().Color(text.Gray).Text("[" + sender.GetName() + ": " + message + "]")
+		for _, session := range server.GetSessionsInWorld(dimension.GetLevel()) {
+			if session.HasPermission("gomine.commandblock") {
+				session.SendMessage(component)
+			}
+		}
+		text.DefaultLogger.Info(component.Translated(translateDefault))
+	}
+}
+
+// canEditCommandBlock checks if the session may edit the command block at the position.
+// Only players in creative mode with permission to edit command blocks can edit them, when within reach.
+func (server *Server) canEditCommandBlock(session *net.MinecraftSession, position blocks.Position) bool {
+	var player = session.GetPlayer()
+	var center = r3.Vector{X: float64(position.X) + 0.5, Y: float64(position.Y) + 0.5, Z: float64(position.Z) + 0.5}
+	return session.HasPermission("gomine.commandblock") && server.IsCreative(session) &&
+		server.GetDimensionWorld(player.GetDimension()).GetBlockName(position) == CommandBlock && combat.InReach(player.Position, center)
+}
+
+// editCommandBlock sets the command of the command block at the position to the command written by the session.
+// A refused edit is undone by sending the command block to the session again, and reported to the session in a form,
+// as the player would otherwise not notice that the command was not saved.
+func (server *Server) editCommandBlock(session *net.MinecraftSession, position blocks.Position, command string, trackOutput bool) {
+	var dimension = session.GetPlayer().GetDimension()
+	if !server.canEditCommandBlock(session, position) {
+		if blockEntity, ok := server.GetBlockEntityManager(dimension).Get(position); ok {
+			if serializable, ok := blockEntity.(blockentities.Serializable); ok {
+				session.SendBlockEntityData(position, encodeBlockEntity(serializable))
+			}
+		}
+		session.ReportError(net.NewFormError(server.Translate(session, lang.ErrorCommandBlock), nil))
+		return
+	}
+	var commandBlock = server.getCommandBlock(dimension, position)
+	commandBlock.Command = strings.TrimSpace(command)
+	commandBlock.TrackOutput = trackOutput
+	if !trackOutput {
+		commandBlock.LastOutput = ""
+	}
+	server.UpdateBlockEntity(dimension, commandBlock)
+}
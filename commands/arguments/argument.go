@@ -0,0 +1,145 @@
+package arguments
+
+// Parameter types of command arguments, as sent to clients for autocompletion.
+const (
+	TypeInt      uint32 = 0x01
+	TypeFloat    uint32 = 0x02
+	TypeValue    uint32 = 0x03
+	TypeTarget   uint32 = 0x06
+	TypeString   uint32 = 0x1b
+	TypePosition uint32 = 0x1d
+
+	// FlagValid is set on the parameter type of every valid argument.
+	FlagValid uint32 = 0x100000
+	// FlagEnum is set on the parameter type of enum arguments,
+	// in which case the lower bits hold the index of the enum.
+	FlagEnum uint32 = 0x200000
+)
+
+// Argument is an argument of a command.
+// Arguments validate and convert the input of the command sender,
+// after which the output gets passed to the command function.
+type Argument struct {
+	name          string
+	typeName      string
+	optional      bool
+	merge         bool
+	inputAmount   int
+	parameterType uint32
+	enumName      string
+	enumValues    []string
+	output        interface{}
+	validator     func(value string) bool
+	converter     func(value string) interface{}
+	combiner      func(values []interface{}) interface{}
+}
+
+// NewArgument returns a new argument taking the given amount of inputs.
+// The output is the zero value of the argument, which determines the type passed to the command function,
+// and is passed when an optional argument is left out.
+// Every input is validated with the validator and converted with the converter.
+func NewArgument(name string, typeName string, optional bool, inputAmount int, parameterType uint32, output interface{}, validator func(string) bool, converter func(string) interface{}) *Argument {
+	return &Argument{name: name, typeName: typeName, optional: optional, inputAmount: inputAmount, parameterType: parameterType, output: output, validator: validator, converter: converter}
+}
+
+// GetName returns the name of the argument.
+func (argument *Argument) GetName() string {
+	return argument.name
+}
+
+// GetTypeName returns the name of the type of the argument, as shown in the command usage.
+func (argument *Argument) GetTypeName() string {
+	return argument.typeName
+}
+
+// IsOptional checks if the argument may be left out.
+func (argument *Argument) IsOptional() bool {
+	return argument.optional
+}
+
+// SetOptional sets whether the argument may be left out.
+func (argument *Argument) SetOptional(value bool) {
+	argument.optional = value
+}
+
+// GetInputAmount returns the amount of inputs the argument takes.
+func (argument *Argument) GetInputAmount() int {
+	return argument.inputAmount
+}
+
+// SetInputAmount sets the amount of inputs the argument takes.
+func (argument *Argument) SetInputAmount(amount int) {
+	argument.inputAmount = amount
+}
+
+// ShouldMerge checks if the inputs of the argument should be merged into one string.
+func (argument *Argument) ShouldMerge() bool {
+	return argument.merge
+}
+
+// SetShouldMerge sets whether the inputs of the argument should be merged into one string.
+func (argument *Argument) SetShouldMerge(value bool) {
+	argument.merge = value
+}
+
+// GetParameterType returns the parameter type of the argument.
+// Enum arguments return TypeString, as their type depends on the index of their enum.
+func (argument *Argument) GetParameterType() uint32 {
+	return argument.parameterType
+}
+
+// IsEnum checks if the argument only accepts a fixed set of values.
+func (argument *Argument) IsEnum() bool {
+	return len(argument.enumValues) != 0
+}
+
+// GetEnumName returns the name of the enum of the argument.
+func (argument *Argument) GetEnumName() string {
+	return argument.enumName
+}
+
+// GetEnumValues returns the values accepted by an enum argument.
+func (argument *Argument) GetEnumValues() []string {
+	return argument.enumValues
+}
+
+// IsValidValue checks if the given input is valid for the argument.
+func (argument *Argument) IsValidValue(value string) bool {
+	if argument.validator == nil {
+		return true
+	}
+	return argument.validator(value)
+}
+
+// ConvertValue converts the given input into the output type of the argument.
+func (argument *Argument) ConvertValue(value string) interface{} {
+	if argument.converter == nil {
+		return value
+	}
+	return argument.converter(value)
+}
+
+// CombineValues combines the converted inputs of an argument taking multiple inputs into one output.
+// A slice with all values is returned if the argument has no combiner.
+func (argument *Argument) CombineValues(values []interface{}) interface{} {
+	if argument.combiner == nil {
+		return values
+	}
+	return argument.combiner(values)
+}
+
+// SetCombiner sets the function used to combine the inputs of an argument taking multiple inputs.
+func (argument *Argument) SetCombiner(combiner func(values []interface{}) interface{}) {
+	argument.combiner = combiner
+}
+
+// GetOutput returns the default output of the argument, which is passed when an optional argument is left out.
+// The outputs of parsed commands are not stored in the argument, as commands may be executed concurrently.
+func (argument *Argument) GetOutput() interface{} {
+	return argument.output
+}
+
+// SetOutput sets the default output of the argument, which is passed when an optional argument is left out.
+func (argument *Argument) SetOutput(value interface{}) {
+	argument.output = value
+}
@@ -0,0 +1,73 @@
+package arguments
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Coordinate is one coordinate of a position argument.
+// Relative coordinates are prefixed with a '~' and are relative to the position of the sender.
+type Coordinate struct {
+	Value    float64
+	Relative bool
+}
+
+// Resolve returns the absolute value of the coordinate, using the given origin for relative coordinates.
+func (coordinate Coordinate) Resolve(origin float64) float64 {
+	if coordinate.Relative {
+		return origin + coordinate.Value
+	}
+	return coordinate.Value
+}
+
+// Position is the output of a position argument.
+type Position struct {
+	X, Y, Z Coordinate
+}
+
+// IsRelative checks if any of the coordinates of the position is relative.
+func (position Position) IsRelative() bool {
+	return position.X.Relative || position.Y.Relative || position.Z.Relative
+}
+
+// Resolve returns the absolute coordinates of the position, relative to the given origin.
+func (position Position) Resolve(x, y, z float64) (float64, float64, float64) {
+	return position.X.Resolve(x), position.Y.Resolve(y), position.Z.Resolve(z)
+}
+
+// NewPosition returns a new argument taking three coordinates.
+// Coordinates may be relative, for example: "~ ~1 ~-2".
+func NewPosition(name string, optional bool) *Argument {
+	var argument = NewArgument(name, "x y z", optional, 3, TypePosition, Position{}, func(value string) bool {
+		var _, ok = parseCoordinate(value)
+		return ok
+	}, func(value string) interface{} {
+		var coordinate, _ = parseCoordinate(value)
+		return coordinate
+	})
+	argument.SetCombiner(func(values []interface{}) interface{} {
+		var position = Position{}
+		if len(values) == 3 {
+			position.X, _ = values[0].(Coordinate)
+			position.Y, _ = values[1].(Coordinate)
+			position.Z, _ = values[2].(Coordinate)
+		}
+		return position
+	})
+	return argument
+}
+
+// parseCoordinate parses a coordinate, which may be prefixed with a '~' to be relative.
+func parseCoordinate(value string) (Coordinate, bool) {
+	var coordinate = Coordinate{}
+	if strings.HasPrefix(value, "~") {
+		coordinate.Relative = true
+		value = value[1:]
+		if value == "" {
+			return coordinate, true
+		}
+	}
+	var f, err = strconv.ParseFloat(value, 64)
+	coordinate.Value = f
+	return coordinate, err == nil
+}
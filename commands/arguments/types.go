@@ -0,0 +1,70 @@
+package arguments
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/BobbyShrd/gominetest/selectors"
+)
+
+// NewString returns a new argument taking one word.
+func NewString(name string, optional bool) *Argument {
+	return NewArgument(name, "string", optional, 1, TypeString, "", nil, nil)
+}
+
+// NewInt returns a new argument taking an integer.
+func NewInt(name string, optional bool) *Argument {
+	return NewArgument(name, "int", optional, 1, TypeInt, 0, func(value string) bool {
+		var _, err = strconv.Atoi(value)
+		return err == nil
+	}, func(value string) interface{} {
+		var i, _ = strconv.Atoi(value)
+		return i
+	})
+}
+
+// NewFloat returns a new argument taking a floating point number.
+func NewFloat(name string, optional bool) *Argument {
+	return NewArgument(name, "float", optional, 1, TypeFloat, float64(0), func(value string) bool {
+		var _, err = strconv.ParseFloat(value, 64)
+		return err == nil
+	}, func(value string) interface{} {
+		var f, _ = strconv.ParseFloat(value, 64)
+		return f
+	})
+}
+
+// NewEnum returns a new argument only accepting the given values, case insensitively.
+// The enum name is shown to clients when autocompleting the argument.
+func NewEnum(name string, optional bool, enumName string, values ...string) *Argument {
+	var argument = NewArgument(name, enumName, optional, 1, TypeString, "", func(value string) bool {
+		for _, enumValue := range values {
+			if strings.EqualFold(value, enumValue) {
+				return true
+			}
+		}
+		return false
+	}, func(value string) interface{} {
+		for _, enumValue := range values {
+			if strings.EqualFold(value, enumValue) {
+				return enumValue
+			}
+		}
+		return value
+	})
+	argument.enumName = enumName
+	argument.enumValues = values
+	return argument
+}
+
+// NewTarget returns a new argument taking a player name or target selector, such as "@a[r=10]".
+// The output is the parsed *selectors.Selector, which still has to be resolved into targets.
+func NewTarget(name string, optional bool) *Argument {
+	return NewArgument(name, "target", optional, 1, TypeTarget, (*selectors.Selector)(nil), func(value string) bool {
+		var _, err = selectors.Parse(value)
+		return err == nil
+	}, func(value string) interface{} {
+		var selector, _ = selectors.Parse(value)
+		return selector
+	})
+}
@@ -15,7 +15,6 @@ type Command struct {
 	permission        string
 	aliases           []string
 	arguments         []*arguments.Argument
-	argumentTypes     []string
 	usage             string
 	permissionExempt  bool
 	executionFunction interface{}
@@ -89,24 +88,23 @@ func (command *Command) SetArguments(arguments []*arguments.Argument) {
 
 // AppendArgument adds one argument to the command.
 func (command *Command) AppendArgument(argument *arguments.Argument) {
-	command.argumentTypes = append(command.argumentTypes, reflect.TypeOf(argument.GetOutput()).Name())
-
 	command.arguments = append(command.arguments, argument)
+	command.usage = ""
 }
 
 // parseUsage parses the usage into a readable and clear one.
 func (command *Command) parseUsage() {
 	if command.usage == "" {
 		var usage = text.Yellow + "Usage: /" + command.GetName() + " "
-		for index, argument := range command.GetArguments() {
+		for _, argument := range command.GetArguments() {
 			if argument.IsOptional() {
 				usage += "["
 			} else {
 				usage += "<"
 			}
 
-			usage += argument.GetName() + ": " + command.argumentTypes[index]
-			if argument.GetInputAmount() > 1 && command.argumentTypes[index] != "string" {
+			usage += argument.GetName() + ": " + argument.GetTypeName()
+			if argument.GetInputAmount() > 1 && !argument.ShouldMerge() && argument.GetParameterType() != arguments.TypePosition {
 				usage += "(" + strconv.Itoa(argument.GetInputAmount()) + ")"
 			}
 
@@ -121,44 +119,57 @@ func (command *Command) parseUsage() {
 	}
 }
 
+// CanExecute checks if the sender is allowed to execute the command.
+func (command *Command) CanExecute(sender Sender) bool {
+	return !command.IsPermissionChecked() || sender.HasPermission(command.GetPermission())
+}
+
 // Execute executes the command with the given sender and command arguments.
-func (command *Command) Execute(sender Sender, commandArgs []string) {
-	if _, ok := command.parse(sender, commandArgs); !ok {
-		return
+// The output of the command is returned, holding the errors of invalid arguments
+// and the messages the command function added to it.
+func (command *Command) Execute(sender Sender, commandArgs []string) *Output {
+	var output = NewOutput()
+	var values, ok = command.parse(sender, commandArgs, output)
+	if !ok {
+		return output
 	}
-	command.parseArgsAndExecute(sender)
+	command.parseArgsAndExecute(sender, output, values)
+	return output
 }
 
 // Parse checks and parses the values of a command.
-func (command *Command) parse(sender Sender, commandArgs []string) ([]*arguments.Argument, bool) {
-	if command.IsPermissionChecked() && !sender.HasPermission(command.GetPermission()) {
-		sender.SendMessage("You do not have permission to execute this command.")
-		return []*arguments.Argument{}, false
+// The values are returned in the order of the arguments, as parsed for this execution only.
+func (command *Command) parse(sender Sender, commandArgs []string, commandOutput *Output) ([]interface{}, bool) {
+	if !command.CanExecute(sender) {
+		commandOutput.TranslateError("commands.generic.permission")
+		return nil, false
 	}
 
 	var stringIndex = 0
+	var values = make([]interface{}, len(command.arguments))
 	if len(commandArgs) == 0 {
 		if len(command.GetArguments()) == 0 {
-			return command.GetArguments(), true
+			return values, true
 		}
-		sender.SendMessage(command.GetUsage())
+		commandOutput.Error(command.GetUsage())
 		return nil, false
 	}
-	for _, argument := range command.arguments {
+	for index, argument := range command.arguments {
 		var i = 0
 		var output []string
 
 		for i < argument.GetInputAmount() {
 			if len(commandArgs) < stringIndex+i+1 {
 				if !argument.IsOptional() {
-					sender.SendMessage(command.GetUsage())
+					commandOutput.Error(command.GetUsage())
 					return nil, false
 				}
 			} else {
 				commandArgs[stringIndex+i] = strings.TrimSpace(commandArgs[stringIndex+i])
 
 				if !argument.IsValidValue(commandArgs[stringIndex+i]) {
-					sender.SendMessage(command.GetUsage())
+					commandOutput.Error("'" + commandArgs[stringIndex+i] + "' is not a valid " + argument.GetTypeName() + " for " + argument.GetName() + ".")
+					commandOutput.Error(command.GetUsage())
 					return nil, false
 				}
 				output = append(output, commandArgs[stringIndex+i])
@@ -173,21 +184,23 @@ func (command *Command) parse(sender Sender, commandArgs []string) ([]*arguments
 		}
 
 		if argument.ShouldMerge() {
-			argument.SetOutput(strings.Join(output, " "))
+			values[index] = strings.Join(output, " ")
 		} else {
-			if len(processedOutput) == 1 {
-				argument.SetOutput(processedOutput[0])
+			if len(processedOutput) == 1 && argument.GetInputAmount() == 1 {
+				values[index] = processedOutput[0]
+			} else if len(processedOutput) != 0 {
+				values[index] = argument.CombineValues(processedOutput)
 			} else {
-				argument.SetOutput(processedOutput)
+				values[index] = argument.GetOutput()
 			}
 		}
 	}
-	return command.GetArguments(), true
+	return values, true
 }
 
-// ParseArgsAndExecute parses the arguments into an output able to be typed against.
-// After parsing, the command gets called.
-func (command *Command) parseArgsAndExecute(sender Sender) {
+// ParseArgsAndExecute passes the parsed values of the arguments to the command function, and calls it.
+// Parameters of the type *Output receive the output of the command.
+func (command *Command) parseArgsAndExecute(sender Sender, output *Output, values []interface{}) {
 	var method = reflect.ValueOf(command.executionFunction)
 	var input = make([]reflect.Value, method.Type().NumIn())
 
@@ -198,8 +211,12 @@ func (command *Command) parseArgsAndExecute(sender Sender) {
 			input[i] = reflect.ValueOf(sender)
 			continue
 		}
+		if method.Type().In(i) == outputType {
+			input[i] = reflect.ValueOf(output)
+			continue
+		}
 
-		input[i] = reflect.ValueOf(command.arguments[argOffset].GetOutput())
+		input[i] = reflect.ValueOf(values[argOffset])
 		argOffset++
 	}
 
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/BobbyShrd/gominetest/commands/arguments"
+)
+
+type testSender struct {
+	messages []interface{}
+}
+
+func (sender *testSender) HasPermission(string) bool {
+	return true
+}
+
+func (sender *testSender) SendMessage(message ...interface{}) {
+	sender.messages = append(sender.messages, message...)
+}
+
+func TestSplitArguments(t *testing.T) {
+	var args = SplitArguments(`tell  "Some Player" hi \"there\" "say \"hi\""`)
+	var expected = []string{"tell", "Some Player", "hi", `"there"`, `say "hi"`}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("expected %q, got %q", expected, args)
+	}
+}
+
+func TestTypedArguments(t *testing.T) {
+	var executed bool
+	var command = NewCommand("test", "", "test", []string{}, func(sender Sender, amount int, speed float64, mode string, position arguments.Position) {
+		executed = true
+		if amount != 5 || speed != 1.5 || mode != "creative" {
+			t.Errorf("unexpected arguments %v %v %v", amount, speed, mode)
+		}
+		if x, y, z := position.Resolve(10, 20, 30); x != 10 || y != 21 || z != 4 {
+			t.Errorf("unexpected position %v %v %v", x, y, z)
+		}
+	})
+	command.AppendArgument(arguments.NewInt("amount", false))
+	command.AppendArgument(arguments.NewFloat("speed", false))
+	command.AppendArgument(arguments.NewEnum("mode", false, "GameMode", "survival", "creative"))
+	command.AppendArgument(arguments.NewPosition("position", false))
+
+	var sender = &testSender{}
+	var output = command.Execute(sender, []string{"5", "1.5", "Creative", "~", "~1", "4"})
+	if !executed {
+		t.Fatalf("expected command to execute, got messages %v", output.GetMessages())
+	}
+
+	executed = false
+	command.Execute(sender, []string{"five", "1.5", "creative", "0", "0", "0"})
+	if executed {
+		t.Error("expected command not to execute with an invalid int")
+	}
+	command.Execute(sender, []string{"5", "1.5", "spectator", "0", "0", "0"})
+	if executed {
+		t.Error("expected command not to execute with an invalid enum value")
+	}
+}
+
+type deniedSender struct {
+	testSender
+}
+
+func (sender *deniedSender) HasPermission(string) bool {
+	return false
+}
+
+func TestPermission(t *testing.T) {
+	var executed bool
+	var command = NewCommand("test", "", "test", []string{}, func(sender Sender) {
+		executed = true
+	})
+	var sender = &deniedSender{}
+	if command.CanExecute(sender) {
+		t.Error("expected sender without permission not to be allowed to execute the command")
+	}
+	command.Execute(sender, []string{})
+	if executed {
+		t.Error("expected command not to execute without permission")
+	}
+	command.ExemptFromPermissionCheck(true)
+	if !command.CanExecute(sender) {
+		t.Error("expected exempt command to be executable without permission")
+	}
+}
+
+func TestOutput(t *testing.T) {
+	var command = NewCommand("test", "", "test", []string{}, func(sender Sender, output *Output, amount int) {
+		output.Print("affected", amount)
+		output.SetSuccessCount(amount)
+	})
+	command.AppendArgument(arguments.NewInt("amount", false))
+
+	var sender = &testSender{}
+	var output = command.Execute(sender, []string{"3"})
+	if count := output.GetSuccessCount(); count != 3 {
+		t.Errorf("expected success count 3, got %v", count)
+	}
+	if messages := output.GetMessages(); len(messages) != 1 || messages[0].Text != "affected 3" || messages[0].Error {
+		t.Errorf("unexpected messages %v", messages)
+	}
+
+	output = command.Execute(sender, []string{"three"})
+	if !output.HasErrors() || output.GetSuccessCount() != 0 {
+		t.Errorf("expected invalid argument to fail, got %v", output.GetMessages())
+	}
+	if len(sender.messages) != 0 {
+		t.Errorf("expected output not to be sent to the sender, got %v", sender.messages)
+	}
+}
+
+func TestConcurrentExecute(t *testing.T) {
+	var command = NewCommand("test", "", "test", []string{}, func(output *Output, amount int) {
+		output.SetSuccessCount(amount)
+	})
+	command.AppendArgument(arguments.NewInt("amount", false))
+
+	var waitGroup sync.WaitGroup
+	for i := 1; i <= 50; i++ {
+		waitGroup.Add(1)
+		go func(amount int) {
+			defer waitGroup.Done()
+			if count := command.Execute(&testSender{}, []string{strconv.Itoa(amount)}).GetSuccessCount(); count != amount {
+				t.Errorf("expected the arguments of every execution to be kept apart, got %v for %v", count, amount)
+			}
+		}(i)
+	}
+	waitGroup.Wait()
+}
+
+func TestComplete(t *testing.T) {
+	var manager = NewManager()
+	manager.RegisterCommand(NewCommand("teleport", "", "test", []string{"tp"}, func() {}))
+	manager.RegisterCommand(NewCommand("time", "", "test", []string{}, func() {}))
+	manager.RegisterCommand(NewCommand("stop", "", "test", []string{}, func() {}))
+
+	if names := manager.Complete("t"); !reflect.DeepEqual(names, []string{"teleport", "time", "tp"}) {
+		t.Errorf("expected teleport, time and tp, got %v", names)
+	}
+	if names := manager.Complete("x"); len(names) != 0 {
+		t.Errorf("expected no completions, got %v", names)
+	}
+}
+
+func TestCompleteLine(t *testing.T) {
+	var manager = NewManager()
+	var gamemode = NewCommand("gamemode", "", "test", []string{}, func(string, string) {})
+	gamemode.AppendArgument(arguments.NewEnum("mode", false, "GameMode", "survival", "creative", "spectator"))
+	gamemode.AppendArgument(arguments.NewTarget("player", true))
+	manager.RegisterCommand(gamemode)
+
+	var sender = &testSender{}
+	if lines := manager.CompleteLine(sender, "/game", nil); !reflect.DeepEqual(lines, []string{"/gamemode"}) {
+		t.Errorf("expected /gamemode, got %v", lines)
+	}
+	if lines := manager.CompleteLine(sender, "gamemode s", nil); !reflect.DeepEqual(lines, []string{"gamemode spectator", "gamemode survival"}) {
+		t.Errorf("expected spectator and survival, got %v", lines)
+	}
+	if lines := manager.CompleteLine(sender, "gamemode creative St", []string{"Steve", "Alex"}); !reflect.DeepEqual(lines, []string{"gamemode creative Steve"}) {
+		t.Errorf("expected Steve, got %v", lines)
+	}
+	if lines := manager.CompleteLine(sender, "gamemode creative Steve x", nil); len(lines) != 0 {
+		t.Errorf("expected no completions past the last argument, got %v", lines)
+	}
+}
+
+func TestOptionalArgumentReset(t *testing.T) {
+	var received float64
+	var command = NewCommand("test", "", "test", []string{}, func(sender Sender, sound string, volume float64) {
+		received = volume
+	})
+	command.AppendArgument(arguments.NewString("sound", false))
+	command.AppendArgument(arguments.NewFloat("volume", true))
+
+	var sender = &testSender{}
+	command.Execute(sender, []string{"random.click", "2.5"})
+	if received != 2.5 {
+		t.Fatalf("expected volume 2.5, got %v", received)
+	}
+	command.Execute(sender, []string{"random.click"})
+	if received != 0 {
+		t.Errorf("expected left out optional argument to be reset, got %v", received)
+	}
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/BobbyShrd/gominetest/commands/arguments"
+)
+
+// CompleteLine returns the completions of the last word of a command line typed by the sender, as complete lines.
+// The first word completes to the names and aliases of the commands the sender may execute.
+// Arguments complete to the values of enum arguments, and to the given player names for target arguments.
+// A leading slash in the line is kept in the completions.
+func (holder *Manager) CompleteLine(sender Sender, line string, players []string) []string {
+	var prefix = ""
+	if strings.HasPrefix(line, "/") {
+		prefix, line = "/", line[1:]
+	}
+	var words = strings.Split(line, " ")
+	var last = words[len(words)-1]
+	prefix += line[:len(line)-len(last)]
+
+	var candidates []string
+	if len(words) == 1 {
+		for _, name := range holder.Complete(strings.ToLower(last)) {
+			if command, err := holder.GetCommand(name); err == nil && command.CanExecute(sender) {
+				candidates = append(candidates, name)
+			}
+		}
+	} else {
+		var command, err = holder.GetCommand(strings.ToLower(words[0]))
+		if err != nil || !command.CanExecute(sender) {
+			return nil
+		}
+		var argument, ok = command.argumentAt(len(words) - 2)
+		if !ok {
+			return nil
+		}
+		for _, value := range argumentValues(argument, players) {
+			if strings.HasPrefix(strings.ToLower(value), strings.ToLower(last)) {
+				candidates = append(candidates, value)
+			}
+		}
+	}
+
+	sort.Strings(candidates)
+	var completions = make([]string, len(candidates))
+	for i, candidate := range candidates {
+		completions[i] = prefix + candidate
+	}
+	return completions
+}
+
+// argumentAt returns the argument taking the input at the given index, and false if the command takes fewer inputs.
+func (command *Command) argumentAt(index int) (*arguments.Argument, bool) {
+	for _, argument := range command.GetArguments() {
+		if argument.ShouldMerge() || index < argument.GetInputAmount() {
+			return argument, true
+		}
+		index -= argument.GetInputAmount()
+	}
+	return nil, false
+}
+
+// argumentValues returns the values an argument completes to.
+func argumentValues(argument *arguments.Argument, players []string) []string {
+	if argument.IsEnum() {
+		return argument.GetEnumValues()
+	}
+	if argument.GetParameterType() == arguments.TypeTarget {
+		return players
+	}
+	return nil
+}
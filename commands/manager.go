@@ -2,6 +2,8 @@ package commands
 
 import (
 	"errors"
+	"sort"
+	"strings"
 )
 
 type Manager struct {
@@ -70,6 +72,29 @@ func (holder *Manager) RegisterCommand(command *Command) {
 	}
 }
 
+// GetCommands returns all registered commands, sorted by name.
+func (holder *Manager) GetCommands() []*Command {
+	var commands = make([]*Command, 0, len(holder.commands))
+	for _, command := range holder.commands {
+		commands = append(commands, command)
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].GetName() < commands[j].GetName()
+	})
+	return commands
+}
+
+// GetAvailableCommands returns all registered commands the given sender is allowed to execute, sorted by name.
+func (holder *Manager) GetAvailableCommands(sender Sender) []*Command {
+	var commands []*Command
+	for _, command := range holder.GetCommands() {
+		if command.CanExecute(sender) {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
 // AliasExists checks if the given alias exists or not.
 func (holder *Manager) AliasExists(aliasName string) bool {
 	var _, exists = holder.aliases[aliasName]
@@ -85,3 +110,20 @@ func (holder *Manager) registerAlias(aliasName string, command *Command) {
 func (holder *Manager) deregisterAlias(aliasName string) {
 	delete(holder.aliases, aliasName)
 }
+
+// Complete returns the names and aliases of all registered commands starting with the given prefix, sorted.
+func (holder *Manager) Complete(prefix string) []string {
+	var names []string
+	for name := range holder.commands {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	for alias := range holder.aliases {
+		if strings.HasPrefix(alias, prefix) {
+			names = append(names, alias)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// outputType is the type of output parameters of command functions.
+var outputType = reflect.TypeOf(&Output{})
+
+// Message is a single message in the output of a command.
+type Message struct {
+	// Text is the text of the message, which may be a translation key.
+	Text string
+	// Translated indicates if the text is a translation key, translated by the client.
+	Translated bool
+	// Parameters are the translation parameters of the message, if the text is a translation key.
+	Parameters []string
+	// Error indicates if the message is an error.
+	Error bool
+}
+
+// Output collects the messages and the success count of an executed command,
+// so the server can send them to the sender in the way fitting the sender,
+// such as the command output of the client, the console or an RCON response.
+// Command functions receive the output if they have a *commands.Output parameter.
+type Output struct {
+	mutex        sync.Mutex
+	messages     []Message
+	successCount int
+	counted      bool
+}
+
+// NewOutput returns a new empty output.
+func NewOutput() *Output {
+	return &Output{}
+}
+
+// Print adds a message to the output.
+func (output *Output) Print(message ...interface{}) {
+	output.add(Message{Text: sprint(message...)})
+}
+
+// Translate adds a translated message with the given translation parameters to the output.
+func (output *Output) Translate(key string, parameters ...string) {
+	output.add(Message{Text: key, Translated: true, Parameters: parameters})
+}
+
+// Error adds an error message to the output.
+func (output *Output) Error(message ...interface{}) {
+	output.add(Message{Text: sprint(message...), Error: true})
+}
+
+// TranslateError adds a translated error message with the given translation parameters to the output.
+func (output *Output) TranslateError(key string, parameters ...string) {
+	output.add(Message{Text: key, Translated: true, Parameters: parameters, Error: true})
+}
+
+// SetSuccessCount sets the amount of times the command succeeded, for example the amount of players affected.
+func (output *Output) SetSuccessCount(count int) {
+	output.mutex.Lock()
+	output.successCount, output.counted = count, true
+	output.mutex.Unlock()
+}
+
+// GetSuccessCount returns the amount of times the command succeeded.
+// Commands that did not set a success count succeeded once if no errors were added.
+func (output *Output) GetSuccessCount() int {
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+	if output.counted {
+		return output.successCount
+	}
+	for _, message := range output.messages {
+		if message.Error {
+			return 0
+		}
+	}
+	return 1
+}
+
+// GetMessages returns all messages in the output, in the order they were added.
+func (output *Output) GetMessages() []Message {
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+	return append([]Message(nil), output.messages...)
+}
+
+// HasErrors checks if any error messages were added to the output.
+func (output *Output) HasErrors() bool {
+	for _, message := range output.GetMessages() {
+		if message.Error {
+			return true
+		}
+	}
+	return false
+}
+
+// add adds the message to the output.
+func (output *Output) add(message Message) {
+	output.mutex.Lock()
+	output.messages = append(output.messages, message)
+	output.mutex.Unlock()
+}
+
+// sprint formats the message like SendMessage of senders, separating values with spaces.
+func sprint(message ...interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(message...), "\n")
+}
@@ -1,6 +1,29 @@
 package commands
 
+import (
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// Sender is the executor of a command, such as a player, the console or an RCON client.
 type Sender interface {
 	HasPermission(string) bool
 	SendMessage(...interface{})
 }
+
+// SendOutput sends all messages in the output of a command to the sender,
+// for senders that have no way of showing command output other than messages.
+// Error messages are sent in red.
+func SendOutput(sender Sender, output *Output) {
+	for _, message := range output.GetMessages() {
+		var component = text.NewComponent()
+		if message.Error {
+			component.Color(text.Red)
+		}
+		if message.Translated {
+			component.Translate(message.Text, message.Parameters...)
+		} else {
+			component.Text(message.Text)
+		}
+		sender.SendMessage(component)
+	}
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"strings"
+)
+
+// SplitArguments splits the given command text into its arguments.
+// Arguments are separated by spaces, unless they are surrounded by double quotes.
+// Quotes can be escaped with a backslash to be part of an argument.
+func SplitArguments(commandText string) []string {
+	var args []string
+	var current strings.Builder
+	var quoted, escaped, hasArgument bool
+
+	for _, char := range commandText {
+		switch {
+		case escaped:
+			current.WriteRune(char)
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '"':
+			quoted = !quoted
+			hasArgument = true
+		case char == ' ' && !quoted:
+			if hasArgument {
+				args = append(args, current.String())
+				current.Reset()
+				hasArgument = false
+			}
+		default:
+			current.WriteRune(char)
+			hasArgument = true
+		}
+	}
+	if hasArgument {
+		args = append(args, current.String())
+	}
+	return args
+}
@@ -0,0 +1,57 @@
+package gomine
+
+import (
+	"time"
+
+	"github.com/BobbyShrd/gominetest/lang"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/screening"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// ScreeningRequestTimeout is the timeout of requests to the screening API.
+const ScreeningRequestTimeout = time.Second * 3
+
+// newScreener returns a new screener with the providers set in the config:
+// the local list if it exists, and the HTTP API if a URL is set.
+func (server *Server) newScreener() *screening.Screener {
+	var screener = screening.NewScreener(time.Duration(server.Config.ScreeningCacheMinutes) * time.Minute)
+	if server.Config.ScreeningList != "" {
+		var list, err = screening.LoadListProvider(server.ServerPath + server.Config.ScreeningList)
+		if err != nil {
+			text.DefaultLogger.Error("Failed to load screening list:", err)
+		} else {
+			screener.AddProvider(list)
+		}
+	}
+	if server.Config.ScreeningAPI != "" {
+		screener.AddProvider(screening.NewHTTPProvider(server.Config.ScreeningAPI, server.Config.ScreeningAPIField, ScreeningRequestTimeout))
+	}
+	return screener
+}
+
+// screenConnection screens the address of the session logging in with the given name, if screening is enabled.
+// Logins are allowed if screening fails, so an unavailable API does not lock out all players.
+// Returns false if the session got kicked because the login was blocked.
+func (server *Server) screenConnection(session *net.MinecraftSession, name string) bool {
+	if server.Screener == nil || session.GetAddress() == nil {
+		return true
+	}
+	var result, err = server.Screener.Screen(session.GetAddress().IP)
+	if err != nil {
+		text.DefaultLogger.Debug("Failed to screen connection of", name+":", err)
+		return true
+	}
+
+	var event = NewConnectionScreenedEvent(session, name, result, result.Flagged && server.Config.ScreeningAction == "block")
+	server.EventManager.Emit(event)
+	if event.Block {
+		text.DefaultLogger.Info(name, "has been blocked from joining through a VPN or proxy.")
+		session.Kick(server.Translate(session, lang.KickProxy), false, false)
+		return false
+	}
+	if result.Flagged {
+		text.DefaultLogger.Notice(name, "is joining through a VPN or proxy. ("+result.Provider+": "+result.Reason+")")
+	}
+	return true
+}
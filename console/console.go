@@ -0,0 +1,151 @@
+package console
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Prompt is the prompt shown in front of the line being typed.
+const Prompt = "> "
+
+// Console reads commands typed in a terminal. If the input is a terminal, the console edits lines itself,
+// supporting the cursor keys, a history of commands and tab completion.
+// Otherwise complete lines are read, for example when the input is a pipe.
+type Console struct {
+	// ReadFunction gets called with every line read.
+	ReadFunction func(line string)
+	// InterruptFunction gets called when Ctrl+C is pressed while no line is being typed.
+	InterruptFunction func()
+
+	mutex   sync.Mutex
+	input   *os.File
+	output  io.Writer
+	editor  *Editor
+	restore func()
+}
+
+// New returns a new console reading from the input and writing the prompt to the output.
+// Lines are completed using the complete function, which may be nil.
+func New(input *os.File, output io.Writer, complete func(line string) []string) *Console {
+	var editor = NewEditor()
+	editor.CompleteFunction = complete
+	return &Console{input: input, output: output, editor: editor}
+}
+
+// Start starts reading from the input on a new goroutine.
+// Returns true if the input is a terminal and line editing is enabled.
+func (console *Console) Start() bool {
+	var restore, err = makeRaw(console.input.Fd())
+	if err != nil {
+		go console.readLines()
+		return false
+	}
+	console.restore = restore
+	console.redraw()
+	go console.readKeys()
+	return true
+}
+
+// Close restores the terminal to the state it was in before the console started.
+func (console *Console) Close() {
+	console.mutex.Lock()
+	defer console.mutex.Unlock()
+	if console.restore != nil {
+		console.restore()
+		console.restore = nil
+		io.WriteString(console.output, "\r\x1b[K")
+	}
+}
+
+// Write writes output, such as log messages, above the line being typed.
+func (console *Console) Write(p []byte) (int, error) {
+	console.mutex.Lock()
+	defer console.mutex.Unlock()
+	if console.restore == nil {
+		return console.output.Write(p)
+	}
+	io.WriteString(console.output, "\r\x1b[K")
+	var n, err = console.output.Write(p)
+	console.draw()
+	return n, err
+}
+
+// readLines reads complete lines from the input until it gets closed.
+func (console *Console) readLines() {
+	var reader = bufio.NewReader(console.input)
+	for {
+		var line, err = reader.ReadString('\n')
+		if line = strings.TrimRight(line, "\r\n"); line != "" && console.ReadFunction != nil {
+			console.ReadFunction(line)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// readKeys reads keys from the input in raw mode and passes them to the editor until the input gets closed.
+func (console *Console) readKeys() {
+	var reader = bufio.NewReader(console.input)
+	for {
+		var key, _, err = reader.ReadRune()
+		if err != nil {
+			return
+		}
+		if key == keyCtrlC && console.editor.GetLine() == "" {
+			if console.InterruptFunction != nil {
+				console.InterruptFunction()
+			}
+			continue
+		}
+
+		console.mutex.Lock()
+		var line, done, completions = console.editor.Handle(key)
+		if done {
+			io.WriteString(console.output, "\r\x1b[K"+Prompt+line+"\n")
+		}
+		if len(completions) != 0 {
+			io.WriteString(console.output, "\r\x1b[K"+strings.Join(completions, "  ")+"\n")
+		}
+		console.draw()
+		console.mutex.Unlock()
+
+		if done && strings.TrimSpace(line) != "" && console.ReadFunction != nil {
+			console.ReadFunction(line)
+		}
+	}
+}
+
+// redraw draws the prompt and the line being typed.
+func (console *Console) redraw() {
+	console.mutex.Lock()
+	console.draw()
+	console.mutex.Unlock()
+}
+
+// draw draws the prompt and the line being typed, and moves the cursor to its position in the line.
+// The mutex of the console must be locked.
+func (console *Console) draw() {
+	var line = []rune(console.editor.GetLine())
+	var back = len(line) - console.editor.GetCursor()
+	var drawn = "\r\x1b[K" + Prompt + string(line)
+	if back > 0 {
+		drawn += "\x1b[" + itoa(back) + "D"
+	}
+	io.WriteString(console.output, drawn)
+}
+
+// itoa returns the decimal representation of a non-negative number.
+func itoa(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	var digits []byte
+	for ; n > 0; n /= 10 {
+		digits = append([]byte{byte('0' + n%10)}, digits...)
+	}
+	return string(digits)
+}
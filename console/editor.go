@@ -0,0 +1,211 @@
+package console
+
+import (
+	"sort"
+	"strings"
+)
+
+// Control characters and escape sequences handled by the editor.
+const (
+	keyCtrlA     = 0x01
+	keyCtrlC     = 0x03
+	keyCtrlE     = 0x05
+	keyBackspace = 0x08
+	keyTab       = 0x09
+	keyEnter     = 0x0d
+	keyNewline   = 0x0a
+	keyCtrlU     = 0x15
+	keyEscape    = 0x1b
+	keyDelete    = 0x7f
+)
+
+// MaxHistory is the maximum amount of lines remembered in the history of an editor.
+const MaxHistory = 100
+
+// Editor edits a single line of input one key at a time, with a cursor, a history of entered lines
+// and tab completion. Keys are expected as sent by a terminal in raw mode.
+type Editor struct {
+	// CompleteFunction returns the completions of the line in front of the cursor.
+	// Nothing is completed if CompleteFunction is nil.
+	CompleteFunction func(line string) []string
+
+	line    []rune
+	cursor  int
+	history []string
+	// historyIndex is the index of the history entry shown, or len(history) if a new line is being edited.
+	historyIndex int
+	// escape holds the escape sequence being read.
+	escape []byte
+	// listed indicates if the last key was a tab which listed all completions.
+	listed bool
+}
+
+// NewEditor returns a new editor with an empty line.
+func NewEditor() *Editor {
+	return &Editor{}
+}
+
+// GetLine returns the line being edited.
+func (editor *Editor) GetLine() string {
+	return string(editor.line)
+}
+
+// GetCursor returns the position of the cursor in the line, in runes.
+func (editor *Editor) GetCursor() int {
+	return editor.cursor
+}
+
+// Handle handles a key read from the terminal. If the key finished the line, the line is returned with true,
+// and the editor is cleared for the next line. Completions listed are returned when tab got pressed twice
+// with multiple possible completions.
+func (editor *Editor) Handle(key rune) (line string, done bool, completions []string) {
+	if editor.escape != nil {
+		editor.handleEscape(byte(key))
+		return "", false, nil
+	}
+	var tab = key == keyTab
+	defer func() {
+		if !tab {
+			editor.listed = false
+		}
+	}()
+
+	switch key {
+	case keyEnter, keyNewline:
+		line = editor.GetLine()
+		editor.addHistory(line)
+		editor.line, editor.cursor = nil, 0
+		return line, true, nil
+	case keyCtrlC, keyCtrlU:
+		editor.line, editor.cursor = nil, 0
+	case keyCtrlA:
+		editor.cursor = 0
+	case keyCtrlE:
+		editor.cursor = len(editor.line)
+	case keyBackspace, keyDelete:
+		if editor.cursor > 0 {
+			editor.line = append(editor.line[:editor.cursor-1], editor.line[editor.cursor:]...)
+			editor.cursor--
+		}
+	case keyTab:
+		return "", false, editor.complete()
+	case keyEscape:
+		editor.escape = []byte{}
+	default:
+		if key < 0x20 {
+			return "", false, nil
+		}
+		editor.insert([]rune{key})
+	}
+	return "", false, nil
+}
+
+// handleEscape handles a byte of an escape sequence, such as the arrow keys.
+func (editor *Editor) handleEscape(b byte) {
+	editor.escape = append(editor.escape, b)
+	if len(editor.escape) == 1 {
+		if b != '[' && b != 'O' {
+			editor.escape = nil
+		}
+		return
+	}
+	if b >= '0' && b <= '9' {
+		// Parameters of sequences such as the delete key, which ends with a tilde.
+		return
+	}
+	var sequence = string(editor.escape[1:])
+	editor.escape = nil
+	switch sequence {
+	case "A":
+		editor.showHistory(editor.historyIndex - 1)
+	case "B":
+		editor.showHistory(editor.historyIndex + 1)
+	case "C":
+		if editor.cursor < len(editor.line) {
+			editor.cursor++
+		}
+	case "D":
+		if editor.cursor > 0 {
+			editor.cursor--
+		}
+	case "H", "1~":
+		editor.cursor = 0
+	case "F", "4~":
+		editor.cursor = len(editor.line)
+	case "3~":
+		if editor.cursor < len(editor.line) {
+			editor.line = append(editor.line[:editor.cursor], editor.line[editor.cursor+1:]...)
+		}
+	}
+}
+
+// addHistory adds a line to the history, unless it is empty or the same as the last line.
+func (editor *Editor) addHistory(line string) {
+	if strings.TrimSpace(line) != "" && (len(editor.history) == 0 || editor.history[len(editor.history)-1] != line) {
+		editor.history = append(editor.history, line)
+		if len(editor.history) > MaxHistory {
+			editor.history = editor.history[1:]
+		}
+	}
+	editor.historyIndex = len(editor.history)
+}
+
+// showHistory replaces the line with the history entry at the index.
+// An empty line is shown when moving past the last entry.
+func (editor *Editor) showHistory(index int) {
+	if index < 0 || index > len(editor.history) {
+		return
+	}
+	editor.historyIndex = index
+	editor.line = nil
+	if index < len(editor.history) {
+		editor.line = []rune(editor.history[index])
+	}
+	editor.cursor = len(editor.line)
+}
+
+// complete completes the line in front of the cursor. A single completion gets inserted followed by a space,
+// and multiple completions get completed up to their common prefix.
+// The completions are returned if tab was pressed twice without anything left to complete.
+func (editor *Editor) complete() []string {
+	if editor.CompleteFunction == nil {
+		return nil
+	}
+	var prefix = string(editor.line[:editor.cursor])
+	var completions = editor.CompleteFunction(prefix)
+	if len(completions) == 0 {
+		return nil
+	}
+	sort.Strings(completions)
+	var completion = completions[0] + " "
+	if len(completions) > 1 {
+		completion = commonPrefix(completions)
+	}
+	if len(completion) > len(prefix) && strings.HasPrefix(completion, prefix) {
+		editor.insert([]rune(completion[len(prefix):]))
+		editor.listed = false
+		return nil
+	}
+	if len(completions) > 1 && editor.listed {
+		return completions
+	}
+	editor.listed = true
+	return nil
+}
+
+// insert inserts runes at the cursor, moving the cursor behind them.
+func (editor *Editor) insert(runes []rune) {
+	editor.line = append(editor.line[:editor.cursor], append(runes, editor.line[editor.cursor:]...)...)
+	editor.cursor += len(runes)
+}
+
+// commonPrefix returns the longest prefix shared by all strings.
+func commonPrefix(values []string) string {
+	var prefix = values[0]
+	for _, value := range values[1:] {
+		for !strings.HasPrefix(value, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
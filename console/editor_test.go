@@ -0,0 +1,72 @@
+package console
+
+import (
+	"reflect"
+	"testing"
+)
+
+// typeKeys passes all keys in the string to the editor, returning the result of the last key.
+func typeKeys(editor *Editor, keys string) (string, bool, []string) {
+	var line string
+	var done bool
+	var completions []string
+	for _, key := range keys {
+		line, done, completions = editor.Handle(key)
+	}
+	return line, done, completions
+}
+
+func TestEditorLine(t *testing.T) {
+	var editor = NewEditor()
+	typeKeys(editor, "stpo")
+	// Move left, delete the character before the cursor and retype it behind the next character.
+	typeKeys(editor, "\x1b[D\x7f\x1b[Cp")
+	if line := editor.GetLine(); line != "stop" {
+		t.Fatalf("expected line stop, got %v", line)
+	}
+	if line, done, _ := typeKeys(editor, "\r"); !done || line != "stop" {
+		t.Fatalf("expected finished line stop, got %v (%v)", line, done)
+	}
+	if editor.GetLine() != "" || editor.GetCursor() != 0 {
+		t.Errorf("expected editor to be cleared after enter")
+	}
+
+	typeKeys(editor, "list\r")
+	typeKeys(editor, "\x1b[A\x1b[A")
+	if line := editor.GetLine(); line != "stop" {
+		t.Errorf("expected history entry stop, got %v", line)
+	}
+	typeKeys(editor, "\x1b[B\x1b[B")
+	if line := editor.GetLine(); line != "" {
+		t.Errorf("expected empty line after moving past the history, got %v", line)
+	}
+}
+
+func TestEditorComplete(t *testing.T) {
+	var editor = NewEditor()
+	editor.CompleteFunction = func(line string) []string {
+		var completions []string
+		for _, name := range []string{"teleport", "tell", "time"} {
+			if len(name) >= len(line) && name[:len(line)] == line {
+				completions = append(completions, name)
+			}
+		}
+		return completions
+	}
+
+	typeKeys(editor, "ti\t")
+	if line := editor.GetLine(); line != "time " {
+		t.Errorf("expected single completion time followed by a space, got %q", line)
+	}
+
+	typeKeys(editor, "\x15te\t")
+	if line := editor.GetLine(); line != "tel" {
+		t.Errorf("expected common prefix tel, got %q", line)
+	}
+	if _, _, completions := typeKeys(editor, "\t"); completions != nil {
+		t.Errorf("expected no completions listed after the first tab, got %v", completions)
+	}
+	if _, _, completions := typeKeys(editor, "\t"); !reflect.DeepEqual(completions, []string{"teleport", "tell"}) {
+		t.Errorf("expected teleport and tell listed, got %v", completions)
+	}
+}
@@ -0,0 +1,29 @@
+package console
+
+import (
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// Sender is the command sender of commands typed in the console.
+// The console has every permission, so the server can be administered without a client.
+type Sender struct{}
+
+// NewSender returns a new console command sender.
+func NewSender() *Sender {
+	return &Sender{}
+}
+
+// GetName returns the name of the console used in messages, such as the output of commands.
+func (sender *Sender) GetName() string {
+	return "CONSOLE"
+}
+
+// HasPermission always returns true, as the console has every permission.
+func (sender *Sender) HasPermission(string) bool {
+	return true
+}
+
+// SendMessage logs the message, such as the output of a command executed by the console.
+func (sender *Sender) SendMessage(message ...interface{}) {
+	text.DefaultLogger.Info(message...)
+}
@@ -0,0 +1,28 @@
+package console
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// makeRaw disables line buffering and echoing of the terminal with the given file descriptor,
+// so the console receives every key pressed. Output processing is left enabled.
+// A function restoring the previous state of the terminal is returned,
+// or an error if the file descriptor is not a terminal.
+func makeRaw(fd uintptr) (func(), error) {
+	var state syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCGETS, uintptr(unsafe.Pointer(&state))); errno != 0 {
+		return nil, errno
+	}
+	var raw = state
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO | syscall.ISIG | syscall.IEXTEN
+	raw.Iflag &^= syscall.ICRNL | syscall.IXON
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCSETS, uintptr(unsafe.Pointer(&raw))); errno != 0 {
+		return nil, errno
+	}
+	return func() {
+		syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCSETS, uintptr(unsafe.Pointer(&state)))
+	}, nil
+}
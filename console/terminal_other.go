@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package console
+
+import (
+	"errors"
+)
+
+// makeRaw returns an error, as raw terminal input is only supported on Linux.
+// The console falls back to reading complete lines.
+func makeRaw(uintptr) (func(), error) {
+	return nil, errors.New("raw terminal input is not supported on this platform")
+}
@@ -0,0 +1,43 @@
+package gomine
+
+import (
+	"errors"
+
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/text"
+	"github.com/irmine/worlds"
+)
+
+var InvalidDifficulty = errors.New("difficulty does not exist")
+
+// SetDifficulty sets the difficulty of the level, saves its settings and sends the difficulty to the players in the level.
+// InvalidDifficulty is returned if the difficulty is not one of the difficulties in the levels package.
+func (server *Server) SetDifficulty(level *worlds.Level, difficulty int32) error {
+	if _, ok := levels.DifficultyNames[difficulty]; !ok {
+		return InvalidDifficulty
+	}
+	server.worldMutex.Lock()
+	if settings, ok := server.worldSettings[level]; ok {
+		settings.Difficulty = difficulty
+	}
+	server.worldMutex.Unlock()
+	text.DefaultLogger.LogError(server.SaveWorldSettings(level))
+
+	for _, session := range server.getLevelSessions(level) {
+		session.SendSetDifficulty(uint32(difficulty))
+	}
+	return nil
+}
+
+// handleSetDifficulty handles a player changing the difficulty in the world settings of the client.
+// Players without permission to change the difficulty get the difficulty of the world sent again,
+// which undoes the change in their client.
+func (server *Server) handleSetDifficulty(session *net.MinecraftSession, difficulty int32) {
+	var level = session.GetPlayer().GetDimension().GetLevel()
+	if !session.HasPermission("gomine.difficulty") || server.SetDifficulty(level, difficulty) != nil {
+		session.SendSetDifficulty(uint32(server.GetWorldSettings(level).Difficulty))
+		return
+	}
+	text.DefaultLogger.Info(session.GetName(), "set the difficulty of", level.GetName(), "to", levels.DifficultyNames[difficulty])
+}
@@ -0,0 +1,170 @@
+package gomine
+
+import (
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/autosave"
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/info"
+	"github.com/BobbyShrd/gominetest/palette"
+	"github.com/BobbyShrd/gominetest/waterlog"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+	"github.com/irmine/worlds/chunks"
+	utils2 "github.com/irmine/worlds/utils"
+)
+
+// DimensionWorld gives block mechanics, such as pistons, access to the blocks of a dimension.
+// Every change made is broadcasted to the viewers of the dimension.
+type DimensionWorld struct {
+	dimension    *worlds.Dimension
+	loadChunk    func(x, z int32, function func(chunk *chunks.Chunk))
+	requestMutex sync.Mutex
+	requests     map[chunkKey]*ChunkRequest
+	dirty        *autosave.DirtyChunks
+	palettes     *palette.Manager
+	layer        *waterlog.Layer
+	custom       *palette.Layer
+	heights      levels.HeightRange
+}
+
+// NewDimensionWorld returns a new dimension world for the given dimension,
+// placing blocks with the runtime IDs of the given block palettes within the given height range.
+func NewDimensionWorld(dimension *worlds.Dimension, palettes *palette.Manager, heights levels.HeightRange) *DimensionWorld {
+	return &DimensionWorld{dimension: dimension, loadChunk: dimension.LoadChunk, requests: make(map[chunkKey]*ChunkRequest), dirty: autosave.NewDirtyChunks(), palettes: palettes, layer: waterlog.NewLayer(""), custom: palette.NewLayer(""), heights: heights}
+}
+
+// GetDimensionWorld returns the world of the given dimension.
+// The same world is returned for every call with the same dimension.
+func (server *Server) GetDimensionWorld(dimension *worlds.Dimension) *DimensionWorld {
+	server.dimensionWorldMutex.Lock()
+	defer server.dimensionWorldMutex.Unlock()
+	var world, ok = server.dimensionWorlds[dimension]
+	if !ok {
+		world = NewDimensionWorld(dimension, server.BlockPalettes, server.GetHeightRange(dimension))
+		server.dimensionWorlds[dimension] = world
+	}
+	return world
+}
+
+// GetDimension returns the dimension of the world.
+func (world *DimensionWorld) GetDimension() *worlds.Dimension {
+	return world.dimension
+}
+
+// GetDirtyChunks returns the chunks of the world that changed since they were last saved.
+func (world *DimensionWorld) GetDirtyChunks() *autosave.DirtyChunks {
+	return world.dirty
+}
+
+// MarkDirty marks the chunk holding the given position as changed, so that it gets saved by the next save.
+func (world *DimensionWorld) MarkDirty(position blocks.Position) {
+	world.dirty.Mark(position.X>>4, position.Z>>4)
+}
+
+// GetBlockName returns the name of the block at the given position.
+// Air is returned if the block could not be found.
+func (world *DimensionWorld) GetBlockName(position blocks.Position) string {
+	var block, err = world.dimension.GetBlockAt(utils2.PositionToVector(position))
+	if err != nil {
+		return "air"
+	}
+	return block.GetName()
+}
+
+// GetBlockData returns the data value of the block at the given position.
+func (world *DimensionWorld) GetBlockData(position blocks.Position) byte {
+	var block, err = world.dimension.GetBlockAt(utils2.PositionToVector(position))
+	if err != nil {
+		return 0
+	}
+	return byte(block.GetData())
+}
+
+// MoveBlock moves the block at one position to another, leaving air behind.
+// Blocks are not moved outside of the height range of the world.
+func (world *DimensionWorld) MoveBlock(from blocks.Position, to blocks.Position) {
+	var block, err = world.dimension.GetBlockAt(utils2.PositionToVector(from))
+	if err != nil || !world.isInRange(to) {
+		return
+	}
+	world.SetWaterlogged(to, waterlog.Place(block.GetName(), world.GetBlockName(to), world.GetBlockData(to), world.IsWaterlogged(to)))
+	if state, ok := world.custom.Get(from); ok {
+		world.custom.Set(to, state)
+	} else {
+		world.custom.Remove(to)
+	}
+	world.dimension.SetBlockAt(utils2.PositionToVector(to), block)
+	world.MarkDirty(to)
+	if runtimeId, ok := world.GetBlockRuntimeId(to); ok {
+		world.broadcastUpdateBlock(to, runtimeId)
+	}
+	world.PlaceBlock(from, "air", 0, 0)
+}
+
+// GetBlockRuntimeId returns the runtime ID of the block at the given position in the block palette,
+// and a bool indicating if the palette holds the block. Custom states are looked up by their state,
+// and other blocks by their legacy ID and data value.
+func (world *DimensionWorld) GetBlockRuntimeId(position blocks.Position) (uint32, bool) {
+	if state, ok := world.custom.Get(position); ok {
+		var blockPalette, ok = world.palettes.Get(int32(info.LatestProtocol))
+		if !ok {
+			return 0, false
+		}
+		return blockPalette.GetRuntimeId(state)
+	}
+	var block, err = world.dimension.GetBlockAt(utils2.PositionToVector(position))
+	if err != nil {
+		return 0, false
+	}
+	return getRuntimeId(world.palettes, int(block.GetId()), int(block.GetData()))
+}
+
+// PlaceBlock places a block with the given name, legacy ID and data value at the given position.
+// Placing a water source in a waterloggable block waterlogs the block instead of replacing it,
+// and the water of a waterlogged block is kept or drained depending on the block placed.
+// Blocks outside of the height range of the world are not placed.
+func (world *DimensionWorld) PlaceBlock(position blocks.Position, name string, id int32, data byte) {
+	if !world.isInRange(position) {
+		return
+	}
+	var existing = world.GetBlockName(position)
+	if waterlog.IsWaterSource(name, data) && waterlog.Fill(existing) {
+		world.SetWaterlogged(position, true)
+		return
+	}
+	var runtimeId, ok = getRuntimeId(world.palettes, int(id), int(data))
+	if !ok {
+		return
+	}
+	world.SetWaterlogged(position, waterlog.Place(name, existing, world.GetBlockData(position), world.IsWaterlogged(position)))
+	world.custom.Remove(position)
+	world.dimension.SetBlockAt(utils2.PositionToVector(position), blocks.New(blocks.NewBlockState(name, int32(runtimeId), id, int32(data))))
+	world.MarkDirty(position)
+	world.broadcastUpdateBlock(position, uint32(runtimeId))
+}
+
+// BroadcastBlockEvent sends a block event to all viewers of the dimension.
+func (world *DimensionWorld) BroadcastBlockEvent(position blocks.Position, eventType int32, eventData int32) {
+	for _, viewer := range world.dimension.GetViewers() {
+		if session, ok := viewer.(*net.MinecraftSession); ok {
+			session.SendBlockEvent(position, eventType, eventData)
+		}
+	}
+}
+
+// broadcastUpdateBlock sends the new runtime ID of the block at the position to all viewers of the dimension.
+func (world *DimensionWorld) broadcastUpdateBlock(position blocks.Position, runtimeId uint32) {
+	world.broadcastUpdateLayer(position, runtimeId, waterlog.LayerBlock)
+}
+
+// broadcastUpdateLayer sends the new runtime ID of the block in the given storage layer at the position
+// to all viewers of the dimension.
+func (world *DimensionWorld) broadcastUpdateLayer(position blocks.Position, runtimeId uint32, layer uint32) {
+	for _, viewer := range world.dimension.GetViewers() {
+		if session, ok := viewer.(*net.MinecraftSession); ok {
+			session.SendUpdateBlock(position, runtimeId, layer)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/disguises"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/protocol"
+	"github.com/BobbyShrd/gominetest/players"
+	"github.com/BobbyShrd/gominetest/skins"
+	"github.com/google/uuid"
+	data2 "github.com/irmine/worlds/entities/data"
+)
+
+// DisguiseTarget is an entity that can be disguised: a player, or an NPC spawned as a player by a plugin.
+type DisguiseTarget interface {
+	GetUUID() uuid.UUID
+	GetRuntimeId() uint64
+	GetEntityData() map[uint32][]interface{}
+}
+
+// Disguise disguises the target as seen by the given viewers, or by every player if no viewers are given.
+// The disguise is sent right away to the players that see the target, and to other players once the target is spawned to them.
+// Players do not see their own disguise.
+func (server *Server) Disguise(target DisguiseTarget, disguise *disguises.Disguise, viewers ...*net.MinecraftSession) {
+	var names = make([]string, len(viewers))
+	for i, viewer := range viewers {
+		names[i] = viewer.GetName()
+	}
+	if server.Disguises.IsDisguised(target.GetUUID().String()) {
+		server.Undisguise(target)
+	}
+	server.disguiseMutex.Lock()
+	server.disguiseTargets[target.GetUUID().String()] = target
+	server.disguiseMutex.Unlock()
+	server.Disguises.Set(target.GetUUID().String(), disguise, names...)
+	server.broadcastDisguise(target)
+}
+
+// Undisguise removes the disguise of the target, and shows its real skin and size to the players that saw the disguise.
+// The real skin can only be shown if the target has a GetSkin method, like players do.
+func (server *Server) Undisguise(target DisguiseTarget) bool {
+	var id = target.GetUUID().String()
+	var seen = make(map[*net.MinecraftSession]*disguises.Disguise)
+	for _, session := range server.getDisguiseViewers(target) {
+		if disguise, ok := server.Disguises.Get(id, session.GetName()); ok {
+			seen[session] = disguise
+		}
+	}
+	if _, ok := server.Disguises.Remove(id); !ok {
+		return false
+	}
+	server.disguiseMutex.Lock()
+	delete(server.disguiseTargets, id)
+	server.disguiseMutex.Unlock()
+
+	for session, disguise := range seen {
+		if skinned, ok := target.(interface{ GetSkin() *skins.Skin }); ok && disguise.Skin != nil && skinned.GetSkin() != nil {
+			var skin = skinned.GetSkin()
+			session.SendPlayerSkin(target.GetUUID(), skin.Id, skin.GetGeometryName(), skin.Geometry, skin.Image.Data, skin.Cape.Data)
+		}
+		if disguise.Scale != 0 {
+			session.SendSetEntityData(target.GetRuntimeId(), target.GetEntityData())
+		}
+	}
+	return true
+}
+
+// GetDisguise returns the disguise of the target as seen by the viewer, and a bool indicating if the viewer sees a disguise.
+func (server *Server) GetDisguise(target DisguiseTarget, viewer *net.MinecraftSession) (*disguises.Disguise, bool) {
+	return server.Disguises.Get(target.GetUUID().String(), viewer.GetName())
+}
+
+// applyDisguises sends the disguises of targets other than players seen by a player that just spawned or changed dimension.
+// The disguises of players are sent when they are spawned to the player, in sendSpawnedDisguise.
+func (server *Server) applyDisguises(session *net.MinecraftSession) {
+	server.disguiseMutex.Lock()
+	var targets = make([]DisguiseTarget, 0, len(server.disguiseTargets))
+	for _, target := range server.disguiseTargets {
+		if _, ok := target.(*players.Player); !ok {
+			targets = append(targets, target)
+		}
+	}
+	server.disguiseMutex.Unlock()
+
+	for _, target := range targets {
+		if disguise, ok := server.Disguises.Get(target.GetUUID().String(), session.GetName()); ok {
+			sendDisguise(session, target, disguise)
+		}
+	}
+}
+
+// sendSpawnedDisguise sends the disguise of the player of the session to the viewer it was just spawned to,
+// as the spawn shows the real size of the player.
+func (server *Server) sendSpawnedDisguise(session *net.MinecraftSession, viewer *net.MinecraftSession) {
+	if disguise, ok := server.Disguises.Get(session.GetUUID().String(), viewer.GetName()); ok {
+		sendDisguise(viewer, session.GetPlayer(), disguise)
+	}
+}
+
+// broadcastDisguise sends the disguise of the target to every player that sees it.
+func (server *Server) broadcastDisguise(target DisguiseTarget) {
+	for _, session := range server.getDisguiseViewers(target) {
+		if disguise, ok := server.Disguises.Get(target.GetUUID().String(), session.GetName()); ok {
+			sendDisguise(session, target, disguise)
+		}
+	}
+}
+
+// getDisguiseViewers returns the spawned players the target is shown to, leaving out the target itself.
+// Players are shown to their viewers, while other targets are assumed to be shown to every player.
+func (server *Server) getDisguiseViewers(target DisguiseTarget) []*net.MinecraftSession {
+	var viewers []*net.MinecraftSession
+	if player, ok := target.(*players.Player); ok {
+		for _, viewer := range player.GetViewers() {
+			if session, ok := viewer.(*net.MinecraftSession); ok && session.HasSpawned() {
+				viewers = append(viewers, session)
+			}
+		}
+		return viewers
+	}
+	for _, session := range server.SessionManager.GetSessions() {
+		if session.HasSpawned() && session.GetUUID() != target.GetUUID() {
+			viewers = append(viewers, session)
+		}
+	}
+	return viewers
+}
+
+// removeDisguise forgets the disguise of a player leaving the server.
+func (server *Server) removeDisguise(session *net.MinecraftSession) {
+	var id = session.GetUUID().String()
+	if _, ok := server.Disguises.Remove(id); ok {
+		server.disguiseMutex.Lock()
+		delete(server.disguiseTargets, id)
+		server.disguiseMutex.Unlock()
+	}
+}
+
+// sendDisguise sends the skin and size of the disguise of the target to the session.
+// The size is sent as a copy of the entity data of the target, with its scale replaced.
+func sendDisguise(session *net.MinecraftSession, target DisguiseTarget, disguise *disguises.Disguise) {
+	if skin := disguise.Skin; skin != nil {
+		session.SendPlayerSkin(target.GetUUID(), skin.Id, skin.GetGeometryName(), skin.Geometry, skin.Image.Data, skin.Cape.Data)
+	}
+	if disguise.Scale == 0 {
+		return
+	}
+	var entityData = make(map[uint32][]interface{})
+	for key, value := range target.GetEntityData() {
+		entityData[key] = value
+	}
+	if scale, ok := entityData[data2.EntityDataScale]; ok && len(scale) == 2 {
+		entityData[data2.EntityDataScale] = []interface{}{scale[0], disguise.Scale}
+	}
+	session.SendSetEntityData(target.GetRuntimeId(), entityData)
+}
+
+// disguisedListEntry is a player list entry with the skin of a disguise.
+type disguisedListEntry struct {
+	protocol.PlayerListEntry
+	skin *skins.Skin
+}
+
+// getListEntry returns the player list entry of the player of the session as seen by the viewer,
+// which has the skin of the disguise of the player if the viewer sees one.
+func (server *Server) getListEntry(session *net.MinecraftSession, viewer *net.MinecraftSession) protocol.PlayerListEntry {
+	if session != viewer {
+		if disguise, ok := server.Disguises.Get(session.GetUUID().String(), viewer.GetName()); ok && disguise.Skin != nil {
+			return disguisedListEntry{session.GetPlayer(), disguise.Skin}
+		}
+	}
+	return session.GetPlayer()
+}
+
+// GetPlatformChatId returns the platform chat ID of the disguised player.
+func (entry disguisedListEntry) GetPlatformChatId() string {
+	if chatPlayer, ok := entry.PlayerListEntry.(platformChatEntry); ok {
+		return chatPlayer.GetPlatformChatId()
+	}
+	return ""
+}
+
+func (entry disguisedListEntry) GetSkinId() string {
+	return entry.skin.Id
+}
+
+func (entry disguisedListEntry) GetSkinData() []byte {
+	return entry.skin.Image.Data
+}
+
+func (entry disguisedListEntry) GetCapeData() []byte {
+	return entry.skin.Cape.Data
+}
+
+func (entry disguisedListEntry) GetGeometryName() string {
+	return entry.skin.GetGeometryName()
+}
+
+func (entry disguisedListEntry) GetGeometryData() string {
+	return entry.skin.Geometry
+}
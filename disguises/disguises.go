@@ -0,0 +1,92 @@
+package disguises
+
+import (
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/skins"
+)
+
+// Disguise changes the way a player or NPC looks to its viewers.
+type Disguise struct {
+	// Skin is the skin shown instead of the real skin, including its geometry. Nil keeps the real skin.
+	Skin *skins.Skin
+	// Scale is the size shown instead of the real size, 1 being the normal size. 0 keeps the real size.
+	Scale float32
+}
+
+// entry is a disguise of a target, and the viewers that see it.
+type entry struct {
+	disguise *Disguise
+	viewers  map[string]bool
+}
+
+// Manager manages the disguises of players and NPCs, by the UUID of the disguised entity.
+// Disguises are seen either by every viewer, or only by selected viewers, by name.
+type Manager struct {
+	mutex     sync.RWMutex
+	disguises map[string]entry
+}
+
+// NewManager returns a new disguise manager without disguises.
+func NewManager() *Manager {
+	return &Manager{disguises: make(map[string]entry)}
+}
+
+// Set disguises the target with the given UUID. The disguise is seen by the viewers with the given names,
+// or by every viewer if no names are given. A previous disguise of the target is replaced.
+func (manager *Manager) Set(target string, disguise *Disguise, viewers ...string) {
+	var e = entry{disguise: disguise}
+	if len(viewers) != 0 {
+		e.viewers = make(map[string]bool, len(viewers))
+		for _, viewer := range viewers {
+			e.viewers[viewer] = true
+		}
+	}
+	manager.mutex.Lock()
+	manager.disguises[target] = e
+	manager.mutex.Unlock()
+}
+
+// Remove removes the disguise of the target with the given UUID, and returns the removed disguise.
+// Returns false if the target was not disguised.
+func (manager *Manager) Remove(target string) (*Disguise, bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var e, ok = manager.disguises[target]
+	if !ok {
+		return nil, false
+	}
+	delete(manager.disguises, target)
+	return e.disguise, true
+}
+
+// Get returns the disguise of the target with the given UUID as seen by the viewer with the given name.
+// Returns false if the target is not disguised, or if the viewer does not see the disguise.
+func (manager *Manager) Get(target string, viewer string) (*Disguise, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var e, ok = manager.disguises[target]
+	if !ok || (e.viewers != nil && !e.viewers[viewer]) {
+		return nil, false
+	}
+	return e.disguise, true
+}
+
+// IsDisguised checks if the target with the given UUID is disguised for any viewer.
+func (manager *Manager) IsDisguised(target string) bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var _, ok = manager.disguises[target]
+	return ok
+}
+
+// GetTargets returns the UUIDs of all disguised targets.
+func (manager *Manager) GetTargets() []string {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var targets = make([]string, 0, len(manager.disguises))
+	for target := range manager.disguises {
+		targets = append(targets, target)
+	}
+	return targets
+}
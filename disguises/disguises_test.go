@@ -0,0 +1,29 @@
+package disguises
+
+import (
+	"testing"
+)
+
+func TestViewers(t *testing.T) {
+	var manager = NewManager()
+	var small = &Disguise{Scale: 0.5}
+	manager.Set("npc", small)
+	manager.Set("player", small, "Steve")
+
+	if _, ok := manager.Get("npc", "Alex"); !ok {
+		t.Error("expected a disguise without viewers to be seen by everyone")
+	}
+	if _, ok := manager.Get("player", "Alex"); ok {
+		t.Error("expected the disguise not to be seen by viewers that were not selected")
+	}
+	if disguise, ok := manager.Get("player", "Steve"); !ok || disguise != small {
+		t.Error("expected the disguise to be seen by the selected viewer")
+	}
+
+	if _, ok := manager.Remove("player"); !ok || manager.IsDisguised("player") {
+		t.Error("expected the disguise to be removed")
+	}
+	if targets := manager.GetTargets(); len(targets) != 1 || targets[0] != "npc" {
+		t.Errorf("expected only the NPC to be disguised, got %v", targets)
+	}
+}
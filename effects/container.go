@@ -0,0 +1,201 @@
+package effects
+
+import (
+	"math"
+	"sync"
+)
+
+// Effect is an effect of a certain type applied to an entity.
+type Effect struct {
+	Type *Type
+	// Amplifier is the level of the effect, starting at 0 for level I.
+	Amplifier int32
+	// Duration is the remaining duration of the effect in ticks.
+	Duration int32
+	// Particles indicates if particles are shown around the entity.
+	Particles bool
+}
+
+// NewEffect returns a new effect of the given type.
+func NewEffect(effectType *Type, amplifier int32, duration int32, particles bool) *Effect {
+	return &Effect{effectType, amplifier, duration, particles}
+}
+
+// IsInstant checks if the effect type is applied once, instead of over a duration.
+func (effectType *Type) IsInstant() bool {
+	return effectType.Id == InstantHealth || effectType.Id == InstantDamage
+}
+
+// GetInstantHealth returns the health an instant effect adds to an entity.
+// The health is negative for instant damage, and 0 for effects that are not instant.
+func (effect *Effect) GetInstantHealth() float32 {
+	switch effect.Type.Id {
+	case InstantHealth:
+		return float32(int32(4) << uint(effect.Amplifier))
+	case InstantDamage:
+		return -float32(int32(6) << uint(effect.Amplifier))
+	}
+	return 0
+}
+
+// getInterval returns the interval in ticks between applications of a periodic effect,
+// or 0 if the effect is not periodic.
+func (effect *Effect) getInterval() int32 {
+	var interval int32
+	switch effect.Type.Id {
+	case Regeneration:
+		interval = 50
+	case Poison:
+		interval = 25
+	case Wither:
+		interval = 40
+	default:
+		return 0
+	}
+	if interval >>= uint(effect.Amplifier); interval < 1 {
+		interval = 1
+	}
+	return interval
+}
+
+// Container holds the effects of an entity, with at most one effect per type.
+type Container struct {
+	mutex   sync.RWMutex
+	effects map[int32]*Effect
+}
+
+// NewContainer returns a new container without effects.
+func NewContainer() *Container {
+	return &Container{effects: make(map[int32]*Effect)}
+}
+
+// Add adds the effect to the container.
+// An existing effect of the same type is only replaced if the new effect
+// has a higher amplifier, or the same amplifier and a longer duration.
+// Returns true if the effect was added. Instant effects are never added.
+func (container *Container) Add(effect *Effect) bool {
+	if effect.Type.IsInstant() || effect.Duration <= 0 {
+		return false
+	}
+	container.mutex.Lock()
+	defer container.mutex.Unlock()
+
+	if existing, ok := container.effects[effect.Type.Id]; ok {
+		if existing.Amplifier > effect.Amplifier || (existing.Amplifier == effect.Amplifier && existing.Duration >= effect.Duration) {
+			return false
+		}
+	}
+	container.effects[effect.Type.Id] = effect
+	return true
+}
+
+// Remove removes the effect with the given ID.
+// The removed effect is returned, along with a bool indicating if the container had the effect.
+func (container *Container) Remove(id int32) (*Effect, bool) {
+	container.mutex.Lock()
+	var effect, ok = container.effects[id]
+	delete(container.effects, id)
+	container.mutex.Unlock()
+	return effect, ok
+}
+
+// Get returns the effect with the given ID.
+// A bool is returned indicating success.
+func (container *Container) Get(id int32) (*Effect, bool) {
+	container.mutex.RLock()
+	var effect, ok = container.effects[id]
+	container.mutex.RUnlock()
+	return effect, ok
+}
+
+// Has checks if the container has an effect with the given ID.
+func (container *Container) Has(id int32) bool {
+	var _, ok = container.Get(id)
+	return ok
+}
+
+// GetEffects returns all effects in the container.
+func (container *Container) GetEffects() []*Effect {
+	container.mutex.RLock()
+	var effects = make([]*Effect, 0, len(container.effects))
+	for _, effect := range container.effects {
+		effects = append(effects, effect)
+	}
+	container.mutex.RUnlock()
+	return effects
+}
+
+// Clear removes all effects from the container, and returns the removed effects.
+func (container *Container) Clear() []*Effect {
+	var effects = container.GetEffects()
+	container.mutex.Lock()
+	container.effects = make(map[int32]*Effect)
+	container.mutex.Unlock()
+	return effects
+}
+
+// Tick ticks all effects in the container, and removes expired effects.
+// The health gained or lost by periodic effects such as regeneration and poison
+// is returned, along with the effects that expired.
+func (container *Container) Tick() (float32, []*Effect) {
+	container.mutex.Lock()
+	defer container.mutex.Unlock()
+
+	var health float32
+	var expired []*Effect
+	for id, effect := range container.effects {
+		if interval := effect.getInterval(); interval != 0 && effect.Duration%interval == 0 {
+			if effect.Type.Id == Regeneration {
+				health++
+			} else {
+				health--
+			}
+		}
+		effect.Duration--
+		if effect.Duration <= 0 {
+			delete(container.effects, id)
+			expired = append(expired, effect)
+		}
+	}
+	return health, expired
+}
+
+// GetLevel returns the level of the effect with the given ID, or 0 if the container does not have the effect.
+func (container *Container) GetLevel(id int32) float64 {
+	if effect, ok := container.Get(id); ok {
+		return float64(effect.Amplifier + 1)
+	}
+	return 0
+}
+
+// GetSpeedMultiplier returns the multiplier of the movement speed of the entity,
+// as changed by speed and slowness effects.
+func (container *Container) GetSpeedMultiplier() float64 {
+	var multiplier = 1 + 0.2*container.GetLevel(Speed) - 0.15*container.GetLevel(Slowness)
+	if multiplier < 0 {
+		return 0
+	}
+	return multiplier
+}
+
+// GetAttackBonus returns the damage added to attacks of the entity,
+// as changed by strength and weakness effects.
+func (container *Container) GetAttackBonus() float32 {
+	return float32(3*container.GetLevel(Strength) - 4*container.GetLevel(Weakness))
+}
+
+// GetDamageMultiplier returns the multiplier of damage dealt to the entity,
+// as changed by resistance effects.
+func (container *Container) GetDamageMultiplier() float32 {
+	var multiplier = 1 - 0.2*container.GetLevel(Resistance)
+	if multiplier < 0 {
+		return 0
+	}
+	return float32(multiplier)
+}
+
+// GetMiningMultiplier returns the multiplier of the speed at which the entity breaks blocks,
+// as changed by haste and mining fatigue effects.
+func (container *Container) GetMiningMultiplier() float64 {
+	return (1 + 0.2*container.GetLevel(Haste)) * math.Pow(0.3, math.Min(container.GetLevel(MiningFatigue), 4))
+}
@@ -0,0 +1,61 @@
+package effects
+
+import "testing"
+
+func TestContainerAdd(t *testing.T) {
+	var speed, _ = GetTypeByName("Speed")
+	var container = NewContainer()
+	if !container.Add(NewEffect(speed, 0, 100, true)) {
+		t.Error("expected effect to be added")
+	}
+	if container.Add(NewEffect(speed, 0, 50, true)) {
+		t.Error("expected shorter effect of the same level not to replace the existing effect")
+	}
+	if !container.Add(NewEffect(speed, 1, 20, true)) {
+		t.Error("expected effect of a higher level to replace the existing effect")
+	}
+	var instant, _ = GetType(InstantHealth)
+	if container.Add(NewEffect(instant, 0, 1, true)) {
+		t.Error("expected instant effect not to be added")
+	}
+	if multiplier := container.GetSpeedMultiplier(); multiplier < 1.39 || multiplier > 1.41 {
+		t.Errorf("unexpected speed multiplier %v", multiplier)
+	}
+}
+
+func TestContainerTick(t *testing.T) {
+	var regeneration, _ = GetType(Regeneration)
+	var container = NewContainer()
+	container.Add(NewEffect(regeneration, 0, 100, true))
+
+	var total float32
+	var expired []*Effect
+	for i := 0; i < 100; i++ {
+		var health, e = container.Tick()
+		total += health
+		expired = append(expired, e...)
+	}
+	if total != 2 {
+		t.Errorf("expected 2 health to be regenerated, got %v", total)
+	}
+	if len(expired) != 1 || container.Has(Regeneration) {
+		t.Error("expected regeneration to expire")
+	}
+}
+
+func TestMiningMultiplier(t *testing.T) {
+	var haste, _ = GetType(Haste)
+	var fatigue, _ = GetType(MiningFatigue)
+	var container = NewContainer()
+	if multiplier := container.GetMiningMultiplier(); multiplier != 1 {
+		t.Errorf("expected mining multiplier 1 without effects, got %v", multiplier)
+	}
+	container.Add(NewEffect(haste, 1, 100, true))
+	if multiplier := container.GetMiningMultiplier(); multiplier < 1.39 || multiplier > 1.41 {
+		t.Errorf("unexpected mining multiplier %v with haste II", multiplier)
+	}
+	container.Add(NewEffect(fatigue, 0, 100, true))
+	if multiplier := container.GetMiningMultiplier(); multiplier < 0.41 || multiplier > 0.43 {
+		t.Errorf("unexpected mining multiplier %v with haste II and mining fatigue", multiplier)
+	}
+}
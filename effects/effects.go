@@ -0,0 +1,114 @@
+package effects
+
+import (
+	"errors"
+	"strings"
+)
+
+// Effect IDs, as used by the client.
+const (
+	Speed int32 = iota + 1
+	Slowness
+	Haste
+	MiningFatigue
+	Strength
+	InstantHealth
+	InstantDamage
+	JumpBoost
+	Nausea
+	Regeneration
+	Resistance
+	FireResistance
+	WaterBreathing
+	Invisibility
+	Blindness
+	NightVision
+	Hunger
+	Weakness
+	Poison
+	Wither
+	HealthBoost
+	Absorption
+	Saturation
+	Levitation
+)
+
+// Mob effect events, sent to the client to add, change or remove an effect.
+const (
+	MobEffectAdd byte = iota + 1
+	MobEffectModify
+	MobEffectRemove
+)
+
+var UnknownEffect = errors.New("unknown effect")
+
+// Type is a type of effect, such as speed or poison.
+type Type struct {
+	// Id is the ID of the effect sent to the client.
+	Id int32
+	// Name is the name of the effect, as used in commands.
+	Name string
+	// Harmful indicates if the effect is bad for the entity it is applied to.
+	Harmful bool
+}
+
+// types holds all registered effect types, indexed by ID.
+var types = make(map[int32]*Type)
+
+// typesByName holds all registered effect types, indexed by name.
+var typesByName = make(map[string]*Type)
+
+func init() {
+	RegisterType(&Type{Speed, "speed", false})
+	RegisterType(&Type{Slowness, "slowness", true})
+	RegisterType(&Type{Haste, "haste", false})
+	RegisterType(&Type{MiningFatigue, "mining_fatigue", true})
+	RegisterType(&Type{Strength, "strength", false})
+	RegisterType(&Type{InstantHealth, "instant_health", false})
+	RegisterType(&Type{InstantDamage, "instant_damage", true})
+	RegisterType(&Type{JumpBoost, "jump_boost", false})
+	RegisterType(&Type{Nausea, "nausea", true})
+	RegisterType(&Type{Regeneration, "regeneration", false})
+	RegisterType(&Type{Resistance, "resistance", false})
+	RegisterType(&Type{FireResistance, "fire_resistance", false})
+	RegisterType(&Type{WaterBreathing, "water_breathing", false})
+	RegisterType(&Type{Invisibility, "invisibility", false})
+	RegisterType(&Type{Blindness, "blindness", true})
+	RegisterType(&Type{NightVision, "night_vision", false})
+	RegisterType(&Type{Hunger, "hunger", true})
+	RegisterType(&Type{Weakness, "weakness", true})
+	RegisterType(&Type{Poison, "poison", true})
+	RegisterType(&Type{Wither, "wither", true})
+	RegisterType(&Type{HealthBoost, "health_boost", false})
+	RegisterType(&Type{Absorption, "absorption", false})
+	RegisterType(&Type{Saturation, "saturation", false})
+	RegisterType(&Type{Levitation, "levitation", true})
+}
+
+// RegisterType registers a new effect type.
+// Types previously registered with the same ID or name are overwritten.
+func RegisterType(effectType *Type) {
+	types[effectType.Id] = effectType
+	typesByName[strings.ToLower(effectType.Name)] = effectType
+}
+
+// GetType returns the effect type with the given ID.
+func GetType(id int32) (*Type, error) {
+	if effectType, ok := types[id]; ok {
+		return effectType, nil
+	}
+	return nil, UnknownEffect
+}
+
+// GetTypeByName returns the effect type with the given name, ignoring case.
+func GetTypeByName(name string) (*Type, error) {
+	if effectType, ok := typesByName[strings.ToLower(name)]; ok {
+		return effectType, nil
+	}
+	return nil, UnknownEffect
+}
+
+// GetTypes returns all registered effect types, indexed by ID.
+func GetTypes() map[int32]*Type {
+	return types
+}
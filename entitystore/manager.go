@@ -0,0 +1,156 @@
+package entitystore
+
+import (
+	"math"
+	"sync"
+)
+
+// Entity is an entity that can be saved with the chunk it is in.
+type Entity interface {
+	// ToRecord returns the current state of the entity to persist.
+	ToRecord() Record
+	// IsPersistent checks if the entity should be saved with its chunk.
+	// Players, and NPCs that are managed elsewhere, are not persistent.
+	IsPersistent() bool
+}
+
+// chunkPosition is the position of a chunk in chunk coordinates.
+type chunkPosition struct {
+	x, z int32
+}
+
+// GetChunk returns the chunk coordinates of the chunk holding the given record.
+func GetChunk(record Record) (int32, int32) {
+	return int32(math.Floor(record.Position.X)) >> 4, int32(math.Floor(record.Position.Z)) >> 4
+}
+
+// Manager keeps track of the persistent entities of a dimension.
+// Entities are saved to the store when their chunk gets saved or unloaded,
+// and spawned again when their chunk gets loaded.
+type Manager struct {
+	mutex    sync.Mutex
+	store    *Store
+	entities map[Entity]bool
+	loaded   map[chunkPosition]bool
+
+	// SpawnFunction spawns an entity from a record when the chunk holding it gets loaded.
+	// The returned entity is tracked, unless it is nil. Entities are not spawned if SpawnFunction is nil.
+	SpawnFunction func(record Record) Entity
+}
+
+// NewManager returns a new manager saving entities to the given store.
+func NewManager(store *Store) *Manager {
+	return &Manager{store: store, entities: make(map[Entity]bool), loaded: make(map[chunkPosition]bool)}
+}
+
+// GetStore returns the store of the manager.
+func (manager *Manager) GetStore() *Store {
+	return manager.store
+}
+
+// Track makes the manager save the entity with the chunk it is in.
+func (manager *Manager) Track(entity Entity) {
+	manager.mutex.Lock()
+	manager.entities[entity] = true
+	manager.mutex.Unlock()
+}
+
+// Untrack stops the entity from being saved, for example because it died.
+func (manager *Manager) Untrack(entity Entity) {
+	manager.mutex.Lock()
+	delete(manager.entities, entity)
+	manager.mutex.Unlock()
+}
+
+// IsChunkLoaded checks if the entities of the chunk at the given chunk coordinates have been loaded.
+func (manager *Manager) IsChunkLoaded(x, z int32) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	return manager.loaded[chunkPosition{x, z}]
+}
+
+// LoadChunk spawns the entities saved in the chunk at the given chunk coordinates, and returns them.
+// Nothing is spawned if the entities of the chunk were already loaded.
+func (manager *Manager) LoadChunk(x, z int32) ([]Entity, error) {
+	manager.mutex.Lock()
+	if manager.loaded[chunkPosition{x, z}] {
+		manager.mutex.Unlock()
+		return nil, nil
+	}
+	manager.loaded[chunkPosition{x, z}] = true
+	manager.mutex.Unlock()
+
+	var records, err = manager.store.LoadChunk(x, z)
+	if err != nil || manager.SpawnFunction == nil {
+		return nil, err
+	}
+	var spawned []Entity
+	for _, record := range records {
+		if entity := manager.SpawnFunction(record); entity != nil {
+			manager.Track(entity)
+			spawned = append(spawned, entity)
+		}
+	}
+	return spawned, nil
+}
+
+// SaveChunk saves the persistent entities in the chunk at the given chunk coordinates.
+func (manager *Manager) SaveChunk(x, z int32) error {
+	var _, records = manager.collect(x, z)
+	return manager.store.SaveChunk(x, z, records)
+}
+
+// UnloadChunk saves the persistent entities in the chunk at the given chunk coordinates,
+// and stops tracking them. The entities that were saved are returned, so they can be despawned.
+func (manager *Manager) UnloadChunk(x, z int32) ([]Entity, error) {
+	var entities, records = manager.collect(x, z)
+	manager.mutex.Lock()
+	for _, entity := range entities {
+		delete(manager.entities, entity)
+	}
+	delete(manager.loaded, chunkPosition{x, z})
+	manager.mutex.Unlock()
+	return entities, manager.store.SaveChunk(x, z, records)
+}
+
+// SaveAll saves the entities of all loaded chunks, and of all chunks holding tracked entities.
+// The first error that occurred is returned.
+func (manager *Manager) SaveAll() error {
+	manager.mutex.Lock()
+	var chunks = make(map[chunkPosition]bool, len(manager.loaded))
+	for chunk := range manager.loaded {
+		chunks[chunk] = true
+	}
+	for entity := range manager.entities {
+		var x, z = GetChunk(entity.ToRecord())
+		chunks[chunkPosition{x, z}] = true
+	}
+	manager.mutex.Unlock()
+
+	var err error
+	for chunk := range chunks {
+		if saveErr := manager.SaveChunk(chunk.x, chunk.z); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}
+	return err
+}
+
+// collect returns the tracked persistent entities in the chunk at the given chunk coordinates, and their records.
+func (manager *Manager) collect(x, z int32) ([]Entity, []Record) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var entities []Entity
+	var records []Record
+	for entity := range manager.entities {
+		if !entity.IsPersistent() {
+			continue
+		}
+		var record = entity.ToRecord()
+		if chunkX, chunkZ := GetChunk(record); chunkX == x && chunkZ == z {
+			entities = append(entities, entity)
+			records = append(records, record)
+		}
+	}
+	return entities, records
+}
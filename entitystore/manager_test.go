@@ -0,0 +1,89 @@
+package entitystore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/geo/r3"
+)
+
+type testEntity struct {
+	record     Record
+	persistent bool
+}
+
+func (entity *testEntity) ToRecord() Record {
+	return entity.record
+}
+
+func (entity *testEntity) IsPersistent() bool {
+	return entity.persistent
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	var directory, err = ioutil.TempDir("", "entities")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	var manager = NewManager(NewStore(directory))
+	var zombie = &testEntity{Record{
+		Type:       32,
+		Position:   r3.Vector{X: 20.5, Y: 64, Z: -3.5},
+		Yaw:        90,
+		Pitch:      -45,
+		Health:     15,
+		CustomData: map[string]string{"owner": "Steve"},
+	}, true}
+	var npc = &testEntity{Record{Type: 51, Position: r3.Vector{X: 21, Y: 64, Z: -2}}, false}
+	manager.Track(zombie)
+	manager.Track(npc)
+
+	var unloaded, _ = manager.UnloadChunk(1, -1)
+	if len(unloaded) != 1 || unloaded[0] != zombie {
+		t.Fatalf("expected only the persistent entity to be unloaded, got %v", unloaded)
+	}
+
+	var spawned []Record
+	manager.SpawnFunction = func(record Record) Entity {
+		spawned = append(spawned, record)
+		return &testEntity{record, true}
+	}
+	if _, err := manager.LoadChunk(1, -1); err != nil {
+		t.Fatal(err)
+	}
+	if len(spawned) != 1 {
+		t.Fatalf("expected one entity to be respawned, got %v", spawned)
+	}
+	var record = spawned[0]
+	if record.Type != 32 || record.Position != zombie.record.Position || record.Yaw != 90 || record.Pitch != -45 || record.Health != 15 || record.CustomData["owner"] != "Steve" {
+		t.Errorf("expected the respawned entity to match the saved one, got %+v", record)
+	}
+
+	if entities, _ := manager.LoadChunk(1, -1); len(entities) != 0 {
+		t.Error("expected a loaded chunk not to spawn its entities again")
+	}
+}
+
+func TestEmptyChunk(t *testing.T) {
+	var directory, err = ioutil.TempDir("", "entities")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	var store = NewStore(directory)
+	store.SaveChunk(0, 0, []Record{{Type: 32}})
+	if err := store.SaveChunk(0, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(directory, "0.0.nbt")); !os.IsNotExist(err) {
+		t.Error("expected the file of a chunk without entities to be removed")
+	}
+	if records, err := store.LoadChunk(5, 5); err != nil || len(records) != 0 {
+		t.Errorf("expected no records for a chunk without a file, got %v, %v", records, err)
+	}
+}
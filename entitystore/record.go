@@ -0,0 +1,82 @@
+package entitystore
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gonbt"
+)
+
+// NBT tag names of entity records.
+const (
+	TagEntities   = "Entities"
+	TagType       = "EntityType"
+	TagPosition   = "Pos"
+	TagRotation   = "Rotation"
+	TagHealth     = "Health"
+	TagCustomData = "CustomData"
+)
+
+// Record is the persisted state of an entity.
+type Record struct {
+	// Type is the legacy entity type ID of the entity.
+	Type uint32
+	// Position is the position of the entity in its dimension.
+	Position r3.Vector
+	// Yaw and Pitch are the rotation of the entity.
+	Yaw, Pitch float64
+	// Health is the health of the entity.
+	Health float32
+	// CustomData is the persistent metadata attached to the entity by plugins, as encoded by metadata.Store.
+	CustomData map[string]string
+}
+
+// ToNBT returns the record as an NBT compound.
+func (record Record) ToNBT() *gonbt.Compound {
+	var customData = make(map[string]gonbt.INamedTag, len(record.CustomData))
+	for key, value := range record.CustomData {
+		customData[key] = gonbt.NewString(key, value)
+	}
+	return gonbt.NewCompound("", map[string]gonbt.INamedTag{
+		TagType: gonbt.NewInt(TagType, int32(record.Type)),
+		TagPosition: gonbt.NewList(TagPosition, gonbt.TAG_Double, []gonbt.INamedTag{
+			gonbt.NewDouble("", record.Position.X),
+			gonbt.NewDouble("", record.Position.Y),
+			gonbt.NewDouble("", record.Position.Z),
+		}),
+		TagRotation: gonbt.NewList(TagRotation, gonbt.TAG_Float, []gonbt.INamedTag{
+			gonbt.NewFloat("", float32(record.Yaw)),
+			gonbt.NewFloat("", float32(record.Pitch)),
+		}),
+		TagHealth:     gonbt.NewFloat(TagHealth, record.Health),
+		TagCustomData: gonbt.NewCompound(TagCustomData, customData),
+	})
+}
+
+// RecordFromNBT returns the record held by an NBT compound.
+// A bool is returned which is false if the compound holds no valid record.
+func RecordFromNBT(compound *gonbt.Compound) (Record, bool) {
+	if !compound.HasTagWithType(TagType, gonbt.TAG_Int) || !compound.HasTagWithType(TagPosition, gonbt.TAG_List) {
+		return Record{}, false
+	}
+	var position = compound.GetList(TagPosition, gonbt.TAG_Double).GetTags()
+	if len(position) != 3 {
+		return Record{}, false
+	}
+	var record = Record{
+		Type:       uint32(compound.GetInt(TagType, 0)),
+		Position:   r3.Vector{X: position[0].Interface().(float64), Y: position[1].Interface().(float64), Z: position[2].Interface().(float64)},
+		Health:     compound.GetFloat(TagHealth, 0),
+		CustomData: make(map[string]string),
+	}
+	if rotation := compound.GetList(TagRotation, gonbt.TAG_Float).GetTags(); len(rotation) == 2 {
+		record.Yaw = float64(rotation[0].Interface().(float32))
+		record.Pitch = float64(rotation[1].Interface().(float32))
+	}
+	if compound.HasTagWithType(TagCustomData, gonbt.TAG_Compound) {
+		for key, tag := range compound.GetCompound(TagCustomData).GetTags() {
+			if value, ok := tag.Interface().(string); ok {
+				record.CustomData[key] = value
+			}
+		}
+	}
+	return record, true
+}
@@ -0,0 +1,48 @@
+package entitystore
+
+import (
+	"github.com/BobbyShrd/gominetest/chunkstore"
+	"github.com/irmine/gonbt"
+)
+
+// Store stores the entities of a dimension, with one NBT file for every chunk holding entities.
+type Store struct {
+	chunks *chunkstore.Store
+}
+
+// NewStore returns a new store keeping its files in the given directory.
+// The directory is created once the first chunk gets saved.
+func NewStore(directory string) *Store {
+	return &Store{chunkstore.New(directory, TagEntities)}
+}
+
+// GetDirectory returns the directory the store keeps its files in.
+func (store *Store) GetDirectory() string {
+	return store.chunks.GetDirectory()
+}
+
+// SaveChunk saves the records of the entities in the chunk at the given chunk coordinates,
+// replacing the records saved before. The file of the chunk is removed if there are no records.
+func (store *Store) SaveChunk(x, z int32, records []Record) error {
+	var tags = make([]gonbt.INamedTag, len(records))
+	for i, record := range records {
+		tags[i] = record.ToNBT()
+	}
+	return store.chunks.Save(x, z, tags)
+}
+
+// LoadChunk returns the records of the entities saved in the chunk at the given chunk coordinates.
+// No records are returned if the chunk has no file.
+func (store *Store) LoadChunk(x, z int32) ([]Record, error) {
+	var compounds, err = store.chunks.Load(x, z)
+	if err != nil {
+		return nil, err
+	}
+	var records []Record
+	for _, compound := range compounds {
+		if record, ok := RecordFromNBT(compound); ok {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
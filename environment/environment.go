@@ -0,0 +1,257 @@
+package environment
+
+import (
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/ai"
+)
+
+const (
+	// MaxAir is the air supply of an entity with its head out of water, in ticks.
+	MaxAir = 300
+	// AirRegeneration is the air regained every tick with the head out of water.
+	AirRegeneration = 4
+	// DrowningInterval is the amount of ticks without air between drowning damage.
+	DrowningInterval = 20
+	// DrowningDamage is the damage dealt by drowning.
+	DrowningDamage float32 = 2
+
+	// SuffocationInterval is the amount of ticks between suffocation damage.
+	SuffocationInterval = 10
+	// SuffocationDamage is the damage dealt by suffocating inside a block.
+	SuffocationDamage float32 = 1
+
+	// ContactInterval is the amount of ticks between damage dealt by standing in fire or lava.
+	ContactInterval = 10
+	// FireDamage is the damage dealt by standing in fire.
+	FireDamage float32 = 1
+	// LavaDamage is the damage dealt by standing in lava.
+	LavaDamage float32 = 4
+	// FireTicks is the amount of ticks an entity keeps burning after standing in fire.
+	FireTicks = 160
+	// LavaFireTicks is the amount of ticks an entity keeps burning after standing in lava.
+	LavaFireTicks = 300
+
+	// BurningInterval is the amount of ticks between damage dealt by being on fire.
+	BurningInterval = 20
+	// BurningDamage is the damage dealt by being on fire.
+	BurningDamage float32 = 1
+
+	// VoidInterval is the amount of ticks between void damage.
+	VoidInterval = 10
+	// VoidDamage is the damage dealt by falling out of the world.
+	VoidDamage float32 = 4
+)
+
+// Cause is the cause of environmental damage.
+type Cause int
+
+const (
+	Drowning Cause = iota + 1
+	Suffocation
+	Fire
+	Lava
+	Burning
+	Void
+)
+
+// deathMessages holds the death messages of players killed by environmental damage, indexed by cause.
+var deathMessages = map[Cause]string{
+	Drowning:    "drowned",
+	Suffocation: "suffocated in a wall",
+	Fire:        "went up in flames",
+	Lava:        "tried to swim in lava",
+	Burning:     "burned to death",
+	Void:        "fell out of the world",
+}
+
+// GetDeathMessage returns the message broadcasted after the name of a player killed by the cause.
+func (cause Cause) GetDeathMessage() string {
+	return deathMessages[cause]
+}
+
+// Damage is environmental damage dealt to an entity.
+type Damage struct {
+	Amount float32
+	Cause  Cause
+}
+
+// water are the blocks that entities drown in and that extinguish burning entities.
+var water = map[string]bool{
+	"water":         true,
+	"flowing_water": true,
+}
+
+// lava are the blocks that burn entities standing in them.
+var lava = map[string]bool{
+	"lava":         true,
+	"flowing_lava": true,
+}
+
+// breathable are the blocks mobs can not walk through, which do not suffocate entities either.
+var breathable = map[string]bool{
+	"fire":             true,
+	"glass":            true,
+	"glass_pane":       true,
+	"ladder":           true,
+	"fence":            true,
+	"fence_gate":       true,
+	"cobblestone_wall": true,
+	"iron_bars":        true,
+	"web":              true,
+}
+
+// IsWater checks if the block with the given name is water.
+func IsWater(name string) bool {
+	return water[name]
+}
+
+// IsLava checks if the block with the given name is lava.
+func IsLava(name string) bool {
+	return lava[name]
+}
+
+// IsFire checks if the block with the given name is fire.
+func IsFire(name string) bool {
+	return name == "fire"
+}
+
+// IsSuffocating checks if entities suffocate with their head inside the block with the given name.
+func IsSuffocating(name string) bool {
+	return ai.IsSolid(name) && !breathable[name]
+}
+
+// Surroundings are the blocks around an entity, and the effects protecting it from them.
+type Surroundings struct {
+	// Head is the name of the block at the eyes of the entity.
+	Head string
+	// Feet is the name of the block at the feet of the entity.
+	Feet string
+	// WaterBreathing is true if the entity does not lose air under water.
+	WaterBreathing bool
+	// FireResistance is true if the entity is not hurt by fire and lava.
+	FireResistance bool
+	// InVoid is true if the entity is far enough below the world to take void damage.
+	InVoid bool
+}
+
+// State is the environmental state of an entity: its air supply and the amount of ticks it is on fire.
+type State struct {
+	mutex            sync.Mutex
+	air              int
+	fireTicks        int
+	suffocationTicks int
+	contactTicks     int
+	voidTicks        int
+}
+
+// NewState returns a new state with a full air supply, not on fire.
+func NewState() *State {
+	return &State{air: MaxAir}
+}
+
+// GetAir returns the remaining air supply in ticks.
+func (state *State) GetAir() int {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	if state.air < 0 {
+		return 0
+	}
+	return state.air
+}
+
+// GetFireTicks returns the amount of ticks the entity keeps burning.
+func (state *State) GetFireTicks() int {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	return state.fireTicks
+}
+
+// SetFireTicks sets the amount of ticks the entity keeps burning, setting it on fire if more than 0.
+func (state *State) SetFireTicks(ticks int) {
+	state.mutex.Lock()
+	state.fireTicks = ticks
+	state.mutex.Unlock()
+}
+
+// IsOnFire checks if the entity is burning.
+func (state *State) IsOnFire() bool {
+	return state.GetFireTicks() > 0
+}
+
+// Reset restores the air supply and extinguishes the entity, for example after it respawned.
+func (state *State) Reset() {
+	state.mutex.Lock()
+	state.air, state.fireTicks, state.suffocationTicks, state.contactTicks, state.voidTicks = MaxAir, 0, 0, 0, 0
+	state.mutex.Unlock()
+}
+
+// Tick updates the state with the surroundings of the entity, and returns the damage dealt to the entity this tick.
+func (state *State) Tick(surroundings Surroundings) []Damage {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	var damage []Damage
+
+	if IsWater(surroundings.Head) && !surroundings.WaterBreathing {
+		if state.air--; state.air <= -DrowningInterval {
+			state.air = 0
+			damage = append(damage, Damage{DrowningDamage, Drowning})
+		}
+	} else if state.air < MaxAir {
+		if state.air += AirRegeneration; state.air > MaxAir {
+			state.air = MaxAir
+		}
+	}
+
+	if IsSuffocating(surroundings.Head) {
+		if state.suffocationTicks%SuffocationInterval == 0 {
+			damage = append(damage, Damage{SuffocationDamage, Suffocation})
+		}
+		state.suffocationTicks++
+	} else {
+		state.suffocationTicks = 0
+	}
+
+	var contact Damage
+	switch {
+	case IsLava(surroundings.Feet) || IsLava(surroundings.Head):
+		contact = Damage{LavaDamage, Lava}
+		if state.fireTicks < LavaFireTicks {
+			state.fireTicks = LavaFireTicks
+		}
+	case IsFire(surroundings.Feet) || IsFire(surroundings.Head):
+		contact = Damage{FireDamage, Fire}
+		if state.fireTicks < FireTicks {
+			state.fireTicks = FireTicks
+		}
+	}
+	if contact.Cause != 0 {
+		if state.contactTicks%ContactInterval == 0 && !surroundings.FireResistance {
+			damage = append(damage, contact)
+		}
+		state.contactTicks++
+	} else {
+		state.contactTicks = 0
+	}
+
+	if IsWater(surroundings.Feet) || IsWater(surroundings.Head) {
+		state.fireTicks = 0
+	}
+	if state.fireTicks > 0 {
+		// Standing in fire or lava already hurts the entity, so burning only hurts it once it left them.
+		if state.fireTicks%BurningInterval == 0 && contact.Cause == 0 && !surroundings.FireResistance {
+			damage = append(damage, Damage{BurningDamage, Burning})
+		}
+		state.fireTicks--
+	}
+
+	if surroundings.InVoid {
+		if state.voidTicks%VoidInterval == 0 {
+			damage = append(damage, Damage{VoidDamage, Void})
+		}
+		state.voidTicks++
+	} else {
+		state.voidTicks = 0
+	}
+	return damage
+}
@@ -0,0 +1,71 @@
+package environment
+
+import "testing"
+
+// tick ticks the state the given amount of times in the same surroundings, and returns the total damage dealt.
+func tick(state *State, surroundings Surroundings, ticks int) float32 {
+	var total float32
+	for i := 0; i < ticks; i++ {
+		for _, damage := range state.Tick(surroundings) {
+			total += damage.Amount
+		}
+	}
+	return total
+}
+
+func TestDrowning(t *testing.T) {
+	var state = NewState()
+	var underwater = Surroundings{Head: "water", Feet: "water"}
+	if damage := tick(state, underwater, MaxAir); damage != 0 || state.GetAir() != 0 {
+		t.Fatalf("expected air to run out without damage, got air %v and damage %v", state.GetAir(), damage)
+	}
+	if damage := tick(state, underwater, DrowningInterval*3); damage != DrowningDamage*3 {
+		t.Errorf("expected three times drowning damage, got %v", damage)
+	}
+	if damage := tick(state, Surroundings{Head: "water", WaterBreathing: true}, DrowningInterval*3); damage != 0 {
+		t.Errorf("expected no damage with water breathing, got %v", damage)
+	}
+	tick(state, Surroundings{Head: "air"}, MaxAir/AirRegeneration+1)
+	if air := state.GetAir(); air != MaxAir {
+		t.Errorf("expected air to be regained, got %v", air)
+	}
+}
+
+func TestSuffocation(t *testing.T) {
+	var state = NewState()
+	if damage := tick(state, Surroundings{Head: "stone"}, SuffocationInterval*2); damage != SuffocationDamage*2 {
+		t.Errorf("expected suffocation damage twice, got %v", damage)
+	}
+	if damage := tick(state, Surroundings{Head: "glass"}, SuffocationInterval); damage != 0 {
+		t.Errorf("expected no suffocation in glass, got %v", damage)
+	}
+}
+
+func TestFire(t *testing.T) {
+	var state = NewState()
+	if damage := tick(state, Surroundings{Head: "air", Feet: "lava"}, ContactInterval); damage != LavaDamage || !state.IsOnFire() {
+		t.Fatalf("expected lava damage once and to be on fire, got %v", damage)
+	}
+	if damage := tick(state, Surroundings{Head: "air", Feet: "air"}, BurningInterval); damage != BurningDamage {
+		t.Errorf("expected burning damage once after leaving lava, got %v", damage)
+	}
+	tick(state, Surroundings{Head: "air", Feet: "water"}, 1)
+	if state.IsOnFire() {
+		t.Error("expected water to extinguish the fire")
+	}
+
+	state.SetFireTicks(FireTicks)
+	if damage := tick(state, Surroundings{Head: "air", Feet: "fire", FireResistance: true}, FireTicks); damage != 0 {
+		t.Errorf("expected no damage with fire resistance, got %v", damage)
+	}
+}
+
+func TestVoid(t *testing.T) {
+	var state = NewState()
+	if damage := tick(state, Surroundings{Head: "air", Feet: "air", InVoid: true}, VoidInterval*2); damage != VoidDamage*2 {
+		t.Errorf("expected void damage twice, got %v", damage)
+	}
+	if damage := tick(state, Surroundings{Head: "air", Feet: "air"}, VoidInterval); damage != 0 {
+		t.Errorf("expected no damage above the void, got %v", damage)
+	}
+}
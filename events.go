@@ -0,0 +1,247 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/chat"
+	"github.com/BobbyShrd/gominetest/events"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/screening"
+	"github.com/BobbyShrd/gominetest/skins"
+)
+
+// ChatEvent gets emitted when a player sends a chat message, before it is sent to any receiver.
+// Handlers may cancel the event, or change the message, format and receivers of the message.
+type ChatEvent struct {
+	events.Cancelled
+	session *net.MinecraftSession
+	channel *chat.Channel
+
+	// Message is the message sent by the player.
+	Message string
+	// Format is the format the message gets formatted with, as used by chat.Format.
+	Format string
+	// Receivers are all sessions that will receive the message.
+	Receivers []*net.MinecraftSession
+}
+
+// NewChatEvent returns a new chat event for a message of the session in the given channel.
+func NewChatEvent(session *net.MinecraftSession, channel *chat.Channel, message string, format string, receivers []*net.MinecraftSession) *ChatEvent {
+	return &ChatEvent{session: session, channel: channel, Message: message, Format: format, Receivers: receivers}
+}
+
+// GetSession returns the session of the player that sent the message.
+func (event *ChatEvent) GetSession() *net.MinecraftSession {
+	return event.session
+}
+
+// GetChannel returns the channel the message was sent in.
+func (event *ChatEvent) GetChannel() *chat.Channel {
+	return event.channel
+}
+
+// GetFormattedMessage returns the message formatted with the format of the event.
+func (event *ChatEvent) GetFormattedMessage() string {
+	return chat.Format(event.Format, event.session, event.channel.GetName(), event.Message)
+}
+
+// NameConflictEvent gets emitted when a player logs in with the name of a player that is already online.
+// Handlers may cancel the event to refuse the new login, or change whether the existing session gets kicked.
+type NameConflictEvent struct {
+	events.Cancelled
+	existing *net.MinecraftSession
+	session  *net.MinecraftSession
+	name     string
+
+	// KickExisting decides if the existing session gets kicked in favour of the new login.
+	// The new login is refused if false.
+	KickExisting bool
+}
+
+// NewNameConflictEvent returns a new name conflict event for a login of the session with the name of the existing session.
+func NewNameConflictEvent(existing *net.MinecraftSession, session *net.MinecraftSession, name string, kickExisting bool) *NameConflictEvent {
+	return &NameConflictEvent{existing: existing, session: session, name: name, KickExisting: kickExisting}
+}
+
+// GetExisting returns the session of the player that is already online.
+func (event *NameConflictEvent) GetExisting() *net.MinecraftSession {
+	return event.existing
+}
+
+// GetSession returns the session that is logging in.
+// The session does not yet have a player at the time of the event.
+func (event *NameConflictEvent) GetSession() *net.MinecraftSession {
+	return event.session
+}
+
+// GetName returns the conflicting name.
+func (event *NameConflictEvent) GetName() string {
+	return event.name
+}
+
+// ConnectionScreenedEvent gets emitted for every login that has been screened for VPN and proxy addresses.
+// Handlers may change whether the login gets blocked.
+type ConnectionScreenedEvent struct {
+	session *net.MinecraftSession
+	name    string
+	result  screening.Result
+
+	// Block decides if the login gets refused.
+	// Block is true by default if the address was flagged and the screening action is "block".
+	Block bool
+}
+
+// NewConnectionScreenedEvent returns a new connection screened event for the login of the session with the given name.
+func NewConnectionScreenedEvent(session *net.MinecraftSession, name string, result screening.Result, block bool) *ConnectionScreenedEvent {
+	return &ConnectionScreenedEvent{session: session, name: name, result: result, Block: block}
+}
+
+// GetSession returns the session that is logging in.
+// The session does not yet have a player at the time of the event.
+func (event *ConnectionScreenedEvent) GetSession() *net.MinecraftSession {
+	return event.session
+}
+
+// GetName returns the name the session is logging in with.
+func (event *ConnectionScreenedEvent) GetName() string {
+	return event.name
+}
+
+// GetResult returns the result of the screening.
+func (event *ConnectionScreenedEvent) GetResult() screening.Result {
+	return event.result
+}
+
+// PlayerDeathEvent gets emitted when a player is about to die, after totems had the chance to save it.
+// Handlers may cancel the event to prevent the death, in which case the damage that would have killed the player is not dealt.
+type PlayerDeathEvent struct {
+	events.Cancelled
+	session *net.MinecraftSession
+
+	// Message is the death message broadcasted after the name of the player.
+	Message string
+}
+
+// NewPlayerDeathEvent returns a new player death event for the player of the session, dying with the given message.
+func NewPlayerDeathEvent(session *net.MinecraftSession, message string) *PlayerDeathEvent {
+	return &PlayerDeathEvent{session: session, Message: message}
+}
+
+// GetSession returns the session of the dying player.
+func (event *PlayerDeathEvent) GetSession() *net.MinecraftSession {
+	return event.session
+}
+
+// TameEvent gets emitted when a player feeds a taming item to a wild tameable mob, after the taming item was used.
+// Handlers may cancel the event to keep the mob wild, or change whether the attempt succeeds.
+type TameEvent struct {
+	events.Cancelled
+	session *net.MinecraftSession
+	entity  *PersistentEntity
+
+	// Tamed decides if the mob gets tamed. It is decided by chance before the event is emitted.
+	Tamed bool
+}
+
+// NewTameEvent returns a new tame event for the player of the session taming the entity.
+func NewTameEvent(session *net.MinecraftSession, entity *PersistentEntity, tamed bool) *TameEvent {
+	return &TameEvent{session: session, entity: entity, Tamed: tamed}
+}
+
+// GetSession returns the session of the player taming the mob.
+func (event *TameEvent) GetSession() *net.MinecraftSession {
+	return event.session
+}
+
+// GetEntity returns the entity of the mob being tamed.
+func (event *TameEvent) GetEntity() *PersistentEntity {
+	return event.entity
+}
+
+// SkinChangeEvent gets emitted when a player changes its skin in game, after the new skin was validated.
+// Handlers may cancel the event to keep the current skin, or replace the skin.
+type SkinChangeEvent struct {
+	events.Cancelled
+	session *net.MinecraftSession
+
+	// Skin is the skin the player changes to.
+	Skin *skins.Skin
+}
+
+// NewSkinChangeEvent returns a new skin change event for the player of the session changing to the skin.
+func NewSkinChangeEvent(session *net.MinecraftSession, skin *skins.Skin) *SkinChangeEvent {
+	return &SkinChangeEvent{session: session, Skin: skin}
+}
+
+// GetSession returns the session of the player changing its skin.
+func (event *SkinChangeEvent) GetSession() *net.MinecraftSession {
+	return event.session
+}
+
+// TransferEvent gets emitted when a player gets transferred to another server.
+// Handlers may cancel the event to keep the player on this server, or change the server the player gets transferred to.
+type TransferEvent struct {
+	events.Cancelled
+	session *net.MinecraftSession
+
+	// Address is the address of the server the player gets transferred to.
+	Address string
+	// Port is the port of the server the player gets transferred to.
+	Port uint16
+}
+
+// NewTransferEvent returns a new transfer event for the player of the session getting transferred to the server at the address and port.
+func NewTransferEvent(session *net.MinecraftSession, address string, port uint16) *TransferEvent {
+	return &TransferEvent{session: session, Address: address, Port: port}
+}
+
+// GetSession returns the session of the player getting transferred.
+func (event *TransferEvent) GetSession() *net.MinecraftSession {
+	return event.session
+}
+
+// JoinPhaseEvent gets emitted when a session advances to the next phase of the join sequence.
+type JoinPhaseEvent struct {
+	session  *net.MinecraftSession
+	previous net.JoinPhase
+	phase    net.JoinPhase
+}
+
+// NewJoinPhaseEvent returns a new join phase event for the session advancing from the previous phase to the phase.
+func NewJoinPhaseEvent(session *net.MinecraftSession, previous net.JoinPhase, phase net.JoinPhase) *JoinPhaseEvent {
+	return &JoinPhaseEvent{session: session, previous: previous, phase: phase}
+}
+
+// GetSession returns the session that advanced to the next join phase.
+func (event *JoinPhaseEvent) GetSession() *net.MinecraftSession {
+	return event.session
+}
+
+// GetPrevious returns the join phase the session was in before.
+func (event *JoinPhaseEvent) GetPrevious() net.JoinPhase {
+	return event.previous
+}
+
+// GetPhase returns the join phase the session advanced to.
+func (event *JoinPhaseEvent) GetPhase() net.JoinPhase {
+	return event.phase
+}
+
+// PackDownloadEvent gets emitted when a chunk of a pack is sent to a session downloading the pack.
+type PackDownloadEvent struct {
+	session  *net.MinecraftSession
+	progress net.PackProgress
+}
+
+// NewPackDownloadEvent returns a new pack download event for the session, with the download progress of the pack.
+func NewPackDownloadEvent(session *net.MinecraftSession, progress net.PackProgress) *PackDownloadEvent {
+	return &PackDownloadEvent{session: session, progress: progress}
+}
+
+// GetSession returns the session downloading the pack.
+func (event *PackDownloadEvent) GetSession() *net.MinecraftSession {
+	return event.session
+}
+
+// GetProgress returns the download progress of the pack, including the chunk just sent.
+func (event *PackDownloadEvent) GetProgress() net.PackProgress {
+	return event.progress
+}
@@ -0,0 +1,125 @@
+package events
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+const (
+	PriorityFirst   = 0
+	PriorityDefault = 5
+	PriorityLast    = 10
+)
+
+var InvalidHandler = errors.New("event handler must be a function with exactly one event parameter")
+
+// Cancellable is implemented by events that can be cancelled by handlers.
+type Cancellable interface {
+	IsCancelled() bool
+	SetCancelled(value bool)
+}
+
+// Cancelled is a struct implementing Cancellable,
+// which should be embedded in events that can be cancelled.
+type Cancelled struct {
+	cancelled bool
+}
+
+// IsCancelled checks if the event was cancelled.
+func (cancelled *Cancelled) IsCancelled() bool {
+	return cancelled.cancelled
+}
+
+// SetCancelled sets the event cancelled or not cancelled.
+func (cancelled *Cancelled) SetCancelled(value bool) {
+	cancelled.cancelled = value
+}
+
+// Handler is a function handling one type of event.
+// The type of event handled is the type of the only parameter of the function.
+type Handler struct {
+	function  reflect.Value
+	eventType reflect.Type
+	priority  int
+}
+
+// GetPriority returns the priority of the handler in an integer 0 - 10.
+// 0 is executed first, 10 is executed last.
+func (handler *Handler) GetPriority() int {
+	return handler.priority
+}
+
+// Manager is a struct managing event handlers.
+// Handlers get registered for the type of event they handle,
+// and get called in order of priority when such an event gets emitted.
+type Manager struct {
+	mutex    sync.RWMutex
+	handlers map[reflect.Type][]*Handler
+}
+
+// NewManager returns a new event manager without any handlers.
+func NewManager() *Manager {
+	return &Manager{sync.RWMutex{}, make(map[reflect.Type][]*Handler)}
+}
+
+// RegisterHandler registers a handling function with the given priority, clamped to 0 - 10.
+// The function must have exactly one parameter, which is the event type handled, for example:
+// manager.RegisterHandler(func(event *gomine.ChatEvent) {}, events.PriorityDefault)
+// The handler returned can be used to deregister the function.
+func (manager *Manager) RegisterHandler(function interface{}, priority int) (*Handler, error) {
+	var value = reflect.ValueOf(function)
+	if value.Kind() != reflect.Func || value.Type().NumIn() != 1 {
+		return nil, InvalidHandler
+	}
+	if priority < PriorityFirst {
+		priority = PriorityFirst
+	} else if priority > PriorityLast {
+		priority = PriorityLast
+	}
+	var handler = &Handler{value, value.Type().In(0), priority}
+
+	manager.mutex.Lock()
+	var handlers = append(manager.handlers[handler.eventType], handler)
+	sort.SliceStable(handlers, func(i, j int) bool {
+		return handlers[i].priority < handlers[j].priority
+	})
+	manager.handlers[handler.eventType] = handlers
+	manager.mutex.Unlock()
+	return handler, nil
+}
+
+// DeregisterHandler deregisters a handler previously registered.
+func (manager *Manager) DeregisterHandler(handler *Handler) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var handlers = manager.handlers[handler.eventType]
+	for i, registered := range handlers {
+		if registered == handler {
+			manager.handlers[handler.eventType] = append(handlers[:i:i], handlers[i+1:]...)
+			return
+		}
+	}
+}
+
+// HasHandlers checks if any handlers are registered for the type of the given event.
+func (manager *Manager) HasHandlers(event interface{}) bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return len(manager.handlers[reflect.TypeOf(event)]) != 0
+}
+
+// Emit calls all handlers registered for the type of the event, in order of priority.
+// Handlers of cancellable events are called even if the event was cancelled,
+// so handlers with a later priority may uncancel the event.
+func (manager *Manager) Emit(event interface{}) {
+	manager.mutex.RLock()
+	var handlers = manager.handlers[reflect.TypeOf(event)]
+	manager.mutex.RUnlock()
+
+	var input = []reflect.Value{reflect.ValueOf(event)}
+	for _, handler := range handlers {
+		handler.function.Call(input)
+	}
+}
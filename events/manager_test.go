@@ -0,0 +1,41 @@
+package events
+
+import (
+	"testing"
+)
+
+type testEvent struct {
+	Cancelled
+	calls []int
+}
+
+func TestManager(t *testing.T) {
+	var manager = NewManager()
+	if _, err := manager.RegisterHandler(func() {}, PriorityDefault); err != InvalidHandler {
+		t.Error("expected function without parameters to be rejected")
+	}
+
+	manager.RegisterHandler(func(event *testEvent) {
+		event.calls = append(event.calls, 2)
+		event.SetCancelled(true)
+	}, PriorityLast)
+	var first, _ = manager.RegisterHandler(func(event *testEvent) {
+		event.calls = append(event.calls, 1)
+	}, PriorityFirst)
+
+	var event = &testEvent{}
+	manager.Emit(event)
+	if len(event.calls) != 2 || event.calls[0] != 1 || event.calls[1] != 2 {
+		t.Error("expected handlers to be called in order of priority, got", event.calls)
+	}
+	if !event.IsCancelled() {
+		t.Error("expected event to be cancelled")
+	}
+
+	manager.DeregisterHandler(first)
+	event = &testEvent{}
+	manager.Emit(event)
+	if len(event.calls) != 1 {
+		t.Error("expected deregistered handler not to be called")
+	}
+}
@@ -0,0 +1,91 @@
+package gomine
+
+import (
+	"math"
+
+	"github.com/BobbyShrd/gominetest/farming"
+	"github.com/BobbyShrd/gominetest/gamerules"
+	"github.com/BobbyShrd/gominetest/interactions"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	// faceUp is the face of the top of a block.
+	faceUp byte = 1
+	// trampleFallDistance is the distance players have to fall onto farmland to trample it.
+	trampleFallDistance = 0.75
+)
+
+// Hoes are the string IDs of all items tilling dirt into farmland.
+var Hoes = []string{"minecraft:wooden_hoe", "minecraft:stone_hoe", "minecraft:iron_hoe", "minecraft:diamond_hoe", "minecraft:golden_hoe"}
+
+// SetFarmStore makes the farmland, crops and plants in the dimension persist in the given directory,
+// saving them with the chunk they are in, so that they keep growing after restarting.
+func (server *Server) SetFarmStore(dimension *worlds.Dimension, directory string) {
+	server.FarmManager.SetStore(server.GetDimensionWorld(dimension), directory)
+}
+
+// registerFarming registers the item behaviors of hoes, seeds, saplings, stacked plants and bone meal.
+// Seeds, saplings, stacked plants and bone meal are consumed when used, unless the player is in creative mode.
+func (server *Server) registerFarming() {
+	server.InteractionRegistry.RegisterItemBehavior(interactions.ItemBehaviorFunction(func(session *net.MinecraftSession, _ *items.Stack, position blocks.Position, face byte) bool {
+		return face == faceUp && server.FarmManager.Till(server.GetDimensionWorld(session.GetPlayer().GetDimension()), position)
+	}), Hoes...)
+
+	server.InteractionRegistry.RegisterItemBehavior(interactions.ItemBehaviorFunction(func(session *net.MinecraftSession, item *items.Stack, position blocks.Position, face byte) bool {
+		return face == faceUp && server.consumeUsed(session, server.FarmManager.Plant(server.GetDimensionWorld(session.GetPlayer().GetDimension()), position, item.GetId()))
+	}), farming.GetSeeds()...)
+
+	server.InteractionRegistry.RegisterItemBehavior(interactions.ItemBehaviorFunction(func(session *net.MinecraftSession, item *items.Stack, position blocks.Position, face byte) bool {
+		return face == faceUp && server.consumeUsed(session, server.FarmManager.PlacePlant(server.GetDimensionWorld(session.GetPlayer().GetDimension()), position, item.GetId()))
+	}), farming.GetPlantItems()...)
+
+	server.InteractionRegistry.RegisterItemBehavior(interactions.ItemBehaviorFunction(func(session *net.MinecraftSession, _ *items.Stack, position blocks.Position, _ byte) bool {
+		return server.consumeUsed(session, server.FarmManager.ApplyBoneMeal(server.GetDimensionWorld(session.GetPlayer().GetDimension()), position))
+	}), "minecraft:bone_meal")
+}
+
+// consumeUsed consumes the item held by the session if it was used, unless the player is in creative mode.
+// Returns whether the item was used.
+func (server *Server) consumeUsed(session *net.MinecraftSession, used bool) bool {
+	if used && !server.IsCreative(session) {
+		server.consumeHeldItem(session)
+	}
+	return used
+}
+
+// getRandomTickSpeed returns the random tick speed game rule of the level of the world.
+func (server *Server) getRandomTickSpeed(world farming.World) int {
+	var dimensionWorld, ok = world.(*DimensionWorld)
+	if !ok {
+		return farming.DefaultRandomTickSpeed
+	}
+	return int(server.GetGameRules(dimensionWorld.GetDimension().GetLevel()).GetInt(gamerules.RandomTickSpeed))
+}
+
+// handleFall keeps track of the height players fall from,
+// and tramples the farmland players land on after falling far enough.
+func (server *Server) handleFall(session *net.MinecraftSession, x, y, z float64, onGround bool) {
+	var name = session.GetName()
+	server.fallMutex.Lock()
+	var fallStart, falling = server.fallHeights[name]
+	if !onGround {
+		if !falling || y > fallStart {
+			server.fallHeights[name] = y
+		}
+		server.fallMutex.Unlock()
+		return
+	}
+	delete(server.fallHeights, name)
+	server.fallMutex.Unlock()
+
+	var feet = math.Floor(y - playerEyeHeight)
+	if !falling || fallStart-y < trampleFallDistance || feet < 0 {
+		return
+	}
+	var position = blocks.NewPosition(int32(math.Floor(x)), uint32(feet), int32(math.Floor(z)))
+	server.FarmManager.Trample(server.GetDimensionWorld(session.GetPlayer().GetDimension()), position)
+}
@@ -0,0 +1,101 @@
+package farming
+
+import (
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/irmine/worlds/blocks"
+)
+
+// World is the world crops grow in.
+// It is implemented by the server for every dimension.
+// Implementations must be comparable, as worlds are used to keep track of farmland and crops.
+type World interface {
+	// GetBlockName returns the name of the block at the given position.
+	GetBlockName(position blocks.Position) string
+	// GetBlockData returns the data value of the block at the given position.
+	GetBlockData(position blocks.Position) byte
+	// PlaceBlock places a block with the given name, legacy ID and data value at the given position.
+	PlaceBlock(position blocks.Position, name string, id int32, data byte)
+	// GetHeightRange returns the range of heights blocks can be placed at in the world.
+	GetHeightRange() levels.HeightRange
+}
+
+const (
+	Farmland = "farmland"
+	Dirt     = "dirt"
+	Air      = "air"
+
+	FarmlandId int32 = 60
+	DirtId     int32 = 3
+
+	// MaxMoisture is the moisture of hydrated farmland.
+	MaxMoisture byte = 7
+	// HydrationRange is the horizontal distance water hydrates farmland from.
+	HydrationRange = 4
+)
+
+// Crop is a block growing on farmland.
+type Crop struct {
+	// Name is the name of the crop block.
+	Name string
+	// Id is the legacy block ID of the crop block.
+	Id int32
+	// MaxAge is the age of the crop when it is fully grown.
+	MaxAge byte
+	// Seed is the string ID of the item planting the crop.
+	// Seeds are dropped when a crop that is not yet fully grown gets broken.
+	Seed string
+	// LootTable is the loot table rolled when a fully grown crop gets broken.
+	LootTable string
+}
+
+// crops holds all crops, indexed by block name.
+var crops = map[string]Crop{
+	"wheat":    {"wheat", 59, 7, "minecraft:wheat_seeds", "blocks/wheat"},
+	"carrots":  {"carrots", 141, 7, "minecraft:carrot", "blocks/carrots"},
+	"potatoes": {"potatoes", 142, 7, "minecraft:potato", "blocks/potatoes"},
+	"beetroot": {"beetroot", 244, 7, "minecraft:beetroot_seeds", "blocks/beetroot"},
+}
+
+// tillableBlocks are the blocks that turn into farmland when tilled with a hoe.
+var tillableBlocks = map[string]bool{
+	"dirt":       true,
+	"grass":      true,
+	"grass_path": true,
+}
+
+// waterBlocks are the blocks that hydrate farmland.
+var waterBlocks = map[string]bool{
+	"water":         true,
+	"flowing_water": true,
+}
+
+// RegisterCrop registers a new crop, or overwrites the crop with the same name.
+func RegisterCrop(crop Crop) {
+	crops[crop.Name] = crop
+}
+
+// GetCrop returns the crop with the given block name, and a bool indicating if it exists.
+func GetCrop(name string) (Crop, bool) {
+	var crop, ok = crops[name]
+	return crop, ok
+}
+
+// GetCropBySeed returns the crop planted by the item with the given string ID,
+// and a bool indicating if any crop is planted by it.
+func GetCropBySeed(itemId string) (Crop, bool) {
+	for _, crop := range crops {
+		if crop.Seed == itemId {
+			return crop, true
+		}
+	}
+	return Crop{}, false
+}
+
+// GetSeeds returns the string IDs of all items planting crops.
+func GetSeeds() []string {
+	var seeds []string
+	for _, crop := range crops {
+		seeds = append(seeds, crop.Seed)
+	}
+	return seeds
+}
@@ -0,0 +1,373 @@
+package farming
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/loot"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	// DefaultRandomTickSpeed is the vanilla amount of random ticks per section every tick.
+	DefaultRandomTickSpeed = 3
+	// SectionSize is the amount of blocks in a 16x16x16 section.
+	SectionSize = 4096
+)
+
+// key identifies a position in a world.
+type key struct {
+	world    World
+	position blocks.Position
+}
+
+// Manager keeps track of farmland, crops and plants, and makes them grow and dry out using random ticks.
+// Only blocks that have been tilled, planted or interacted with receive random ticks.
+type Manager struct {
+	mutex       sync.Mutex
+	random      *rand.Rand
+	tracked     map[key]bool
+	stores      map[World]*store
+	lootManager *loot.Manager
+
+	// RandomTickSpeed is the amount of random ticks every section of 4096 blocks receives every tick.
+	RandomTickSpeed int
+	// RandomTickSpeedFunction gets called to get the random tick speed of a world, such as the game rule of its level.
+	// RandomTickSpeed is used for all worlds if RandomTickSpeedFunction is nil.
+	RandomTickSpeedFunction func(world World) int
+	// DropItemsFunction gets called with the items dropped when a crop gets broken.
+	// Items are not dropped if DropItemsFunction is nil.
+	DropItemsFunction func(world World, position blocks.Position, drops []*items.Stack)
+}
+
+// NewManager returns a new farming manager rolling the loot tables of crops from the given loot manager.
+func NewManager(lootManager *loot.Manager, randomTickSpeed int) *Manager {
+	return &Manager{
+		random:          rand.New(rand.NewSource(time.Now().UnixNano())),
+		tracked:         make(map[key]bool),
+		stores:          make(map[World]*store),
+		lootManager:     lootManager,
+		RandomTickSpeed: randomTickSpeed,
+	}
+}
+
+// Track makes the farmland, crop or plant at the given position receive random ticks.
+func (manager *Manager) Track(world World, position blocks.Position) {
+	manager.mutex.Lock()
+	manager.tracked[key{world, position}] = true
+	if store, ok := manager.stores[world]; ok {
+		store.loaded[[2]int32{position.X >> 4, position.Z >> 4}] = true
+	}
+	manager.mutex.Unlock()
+}
+
+// Untrack stops the given position from receiving random ticks.
+func (manager *Manager) Untrack(world World, position blocks.Position) {
+	manager.mutex.Lock()
+	delete(manager.tracked, key{world, position})
+	manager.mutex.Unlock()
+}
+
+// GetTrackedCount returns the amount of farmland, crops and plants receiving random ticks.
+func (manager *Manager) GetTrackedCount() int {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	return len(manager.tracked)
+}
+
+// Till turns the dirt or grass at the given position into farmland.
+// Returns false if the block could not be tilled.
+func (manager *Manager) Till(world World, position blocks.Position) bool {
+	if !tillableBlocks[world.GetBlockName(position)] {
+		return false
+	}
+	if above, ok := offsetUp(position); !ok || world.GetBlockName(above) != Air {
+		return false
+	}
+	world.PlaceBlock(position, Farmland, FarmlandId, 0)
+	manager.Track(world, position)
+	return true
+}
+
+// Plant plants the crop of the given seed item on the farmland at the given position.
+// Returns false if the item is no seed, or if the crop could not be planted.
+func (manager *Manager) Plant(world World, position blocks.Position, seed string) bool {
+	var crop, ok = GetCropBySeed(seed)
+	if !ok || world.GetBlockName(position) != Farmland {
+		return false
+	}
+	var above, hasAbove = offsetUp(position)
+	if !hasAbove || world.GetBlockName(above) != Air {
+		return false
+	}
+	world.PlaceBlock(above, crop.Name, crop.Id, 0)
+	manager.Track(world, position)
+	manager.Track(world, above)
+	return true
+}
+
+// ApplyBoneMeal makes the crop at the given position grow by two to five stages.
+// Saplings have a chance of advancing a growth stage, and bamboo grows one or two blocks.
+// Returns false if the block can not be fertilised, or if the crop was already fully grown.
+func (manager *Manager) ApplyBoneMeal(world World, position blocks.Position) bool {
+	var name = world.GetBlockName(position)
+	if name == Sapling {
+		if manager.randomInt(100) < 45 {
+			manager.advanceSapling(world, position)
+		}
+		manager.Track(world, position)
+		return true
+	}
+	if name == "bamboo" {
+		var plant, _ = GetStackedPlant(name)
+		if !manager.growStackedPlant(world, position, plant) {
+			return false
+		}
+		if manager.randomInt(2) == 0 {
+			manager.growStackedPlant(world, position, plant)
+		}
+		return true
+	}
+	var crop, ok = GetCrop(name)
+	if !ok {
+		return false
+	}
+	var age = world.GetBlockData(position)
+	if age >= crop.MaxAge {
+		return false
+	}
+	age += byte(2 + manager.randomInt(4))
+	if age > crop.MaxAge {
+		age = crop.MaxAge
+	}
+	world.PlaceBlock(position, crop.Name, crop.Id, age)
+	manager.Track(world, position)
+	return true
+}
+
+// DropsItems checks if the items of blocks with the given name are dropped by the manager when they get broken.
+func DropsItems(name string) bool {
+	if _, ok := GetCrop(name); ok || name == Sapling {
+		return true
+	}
+	var _, ok = GetStackedPlant(name)
+	return ok
+}
+
+// Break handles the block at the given position being broken.
+// Crops and plants drop their items, and the crops and plants on top of the block break.
+// The block itself is not removed.
+func (manager *Manager) Break(world World, position blocks.Position) {
+	var name = world.GetBlockName(position)
+	if _, ok := GetCrop(name); ok || name == Sapling {
+		manager.drop(world, position, manager.GetDrops(world, position))
+	} else if plant, ok := GetStackedPlant(name); ok {
+		manager.dropItem(world, position, plant.Item)
+	}
+	if above, ok := offsetUp(position); ok {
+		var aboveName = world.GetBlockName(above)
+		if _, isCrop := GetCrop(aboveName); (isCrop && name == Farmland) || aboveName == Sapling {
+			manager.Break(world, above)
+			world.PlaceBlock(above, Air, 0, 0)
+		} else if plant, isPlant := GetStackedPlant(aboveName); isPlant {
+			manager.breakStackedPlant(world, position, plant)
+		}
+	}
+	manager.Untrack(world, position)
+}
+
+// drop drops the given items at the given position using the DropItemsFunction.
+func (manager *Manager) drop(world World, position blocks.Position, drops []*items.Stack) {
+	if manager.DropItemsFunction != nil && len(drops) != 0 {
+		manager.DropItemsFunction(world, position, drops)
+	}
+}
+
+// dropItem drops one item with the given string ID at the given position.
+func (manager *Manager) dropItem(world World, position blocks.Position, item string) {
+	if stack, ok := items.DefaultManager.Get(item, 1); ok {
+		manager.drop(world, position, []*items.Stack{stack})
+	}
+}
+
+// Trample turns the farmland at the given position back into dirt,
+// breaking the crop on top of it.
+func (manager *Manager) Trample(world World, position blocks.Position) bool {
+	if world.GetBlockName(position) != Farmland {
+		return false
+	}
+	manager.Break(world, position)
+	world.PlaceBlock(position, Dirt, DirtId, 0)
+	return true
+}
+
+// GetDrops returns the items dropped by the crop or plant at the given position.
+// Fully grown crops roll their loot table, and other crops drop their seed.
+// Saplings and stacked plants drop the item planting them.
+func (manager *Manager) GetDrops(world World, position blocks.Position) []*items.Stack {
+	var name = world.GetBlockName(position)
+	if name == Sapling {
+		if sapling, ok := items.DefaultManager.Get(GetSaplingItem(world.GetBlockData(position)&SaplingTypeMask), 1); ok {
+			return []*items.Stack{sapling}
+		}
+		return nil
+	}
+	if plant, ok := GetStackedPlant(name); ok {
+		if stack, ok := items.DefaultManager.Get(plant.Item, 1); ok {
+			return []*items.Stack{stack}
+		}
+		return nil
+	}
+	var crop, ok = GetCrop(name)
+	if !ok {
+		return nil
+	}
+	if world.GetBlockData(position) >= crop.MaxAge {
+		if table, ok := manager.lootManager.GetTable(crop.LootTable); ok {
+			manager.mutex.Lock()
+			defer manager.mutex.Unlock()
+			return table.Roll(manager.random, items.DefaultManager)
+		}
+	}
+	if seed, ok := items.DefaultManager.Get(crop.Seed, 1); ok {
+		return []*items.Stack{seed}
+	}
+	return nil
+}
+
+// IsHydrated checks if there is water near the farmland at the given position.
+// Water hydrates farmland within four blocks horizontally, on the same level or one block above.
+func IsHydrated(world World, position blocks.Position) bool {
+	for x := position.X - HydrationRange; x <= position.X+HydrationRange; x++ {
+		for z := position.Z - HydrationRange; z <= position.Z+HydrationRange; z++ {
+			for y := position.Y; y <= position.Y+1; y++ {
+				if waterBlocks[world.GetBlockName(blocks.NewPosition(x, y, z))] {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// RandomTick handles a random tick of the block at the given position.
+// Farmland hydrates or dries out, crops and stacked plants grow, and saplings grow into trees.
+// The position stops receiving random ticks if it is no longer farmland, a crop or a plant.
+func (manager *Manager) RandomTick(world World, position blocks.Position) {
+	var name = world.GetBlockName(position)
+	if name == Farmland {
+		manager.tickFarmland(world, position)
+		return
+	}
+	if name == Sapling {
+		manager.tickSapling(world, position)
+		return
+	}
+	if crop, ok := GetCrop(name); ok {
+		manager.tickCrop(world, position, crop)
+		return
+	}
+	if plant, ok := GetStackedPlant(name); ok {
+		manager.tickStackedPlant(world, position, plant)
+		return
+	}
+	manager.Untrack(world, position)
+}
+
+// tickFarmland hydrates farmland near water, or dries it out.
+// Dry farmland without a crop on top turns back into dirt.
+func (manager *Manager) tickFarmland(world World, position blocks.Position) {
+	var moisture = world.GetBlockData(position)
+	if IsHydrated(world, position) {
+		if moisture != MaxMoisture {
+			world.PlaceBlock(position, Farmland, FarmlandId, MaxMoisture)
+		}
+		return
+	}
+	if moisture > 0 {
+		world.PlaceBlock(position, Farmland, FarmlandId, moisture-1)
+		return
+	}
+	if above, ok := offsetUp(position); ok {
+		if _, isCrop := GetCrop(world.GetBlockName(above)); isCrop {
+			return
+		}
+	}
+	world.PlaceBlock(position, Dirt, DirtId, 0)
+	manager.Untrack(world, position)
+}
+
+// tickCrop makes a crop grow by one stage, with a higher chance if the farmland below is hydrated.
+// Crops that are not on farmland break.
+func (manager *Manager) tickCrop(world World, position blocks.Position, crop Crop) {
+	var below = position
+	if below.Y == 0 {
+		return
+	}
+	below.Y--
+	if world.GetBlockName(below) != Farmland {
+		manager.Break(world, position)
+		world.PlaceBlock(position, Air, 0, 0)
+		return
+	}
+	var age = world.GetBlockData(position)
+	if age >= crop.MaxAge {
+		return
+	}
+	var points = 2
+	if world.GetBlockData(below) > 0 {
+		points = 4
+	}
+	if manager.randomInt(25/points+1) == 0 {
+		world.PlaceBlock(position, crop.Name, crop.Id, age+1)
+	}
+}
+
+// Tick gives every tracked block a random tick with the chance a block in a section of its world has to receive one.
+func (manager *Manager) Tick() {
+	manager.mutex.Lock()
+	var speeds = make(map[World]int)
+	var ticked []key
+	for k := range manager.tracked {
+		var speed, ok = speeds[k.world]
+		if !ok {
+			speed = manager.getRandomTickSpeed(k.world)
+			speeds[k.world] = speed
+		}
+		if speed > 0 && manager.random.Intn(SectionSize) < speed {
+			ticked = append(ticked, k)
+		}
+	}
+	manager.mutex.Unlock()
+
+	for _, k := range ticked {
+		manager.RandomTick(k.world, k.position)
+	}
+}
+
+// getRandomTickSpeed returns the amount of random ticks every section of the world receives every tick.
+func (manager *Manager) getRandomTickSpeed(world World) int {
+	if manager.RandomTickSpeedFunction != nil {
+		return manager.RandomTickSpeedFunction(world)
+	}
+	return manager.RandomTickSpeed
+}
+
+// randomInt returns a random number in the range [0, n).
+func (manager *Manager) randomInt(n int) int {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	return manager.random.Intn(n)
+}
+
+// offsetUp returns the position above the given position.
+// A bool is returned which is false if the position would be above the world.
+func offsetUp(position blocks.Position) (blocks.Position, bool) {
+	if position.Y+1 >= 256 {
+		return position, false
+	}
+	position.Y++
+	return position, true
+}
@@ -0,0 +1,130 @@
+package farming
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/BobbyShrd/gominetest/loot"
+	"github.com/irmine/worlds/blocks"
+)
+
+type testBlock struct {
+	name string
+	data byte
+}
+
+type testWorld struct {
+	blocks map[blocks.Position]testBlock
+}
+
+func newTestWorld() *testWorld {
+	return &testWorld{make(map[blocks.Position]testBlock)}
+}
+
+func (world *testWorld) GetBlockName(position blocks.Position) string {
+	if block, ok := world.blocks[position]; ok {
+		return block.name
+	}
+	return Air
+}
+
+func (world *testWorld) GetBlockData(position blocks.Position) byte {
+	return world.blocks[position].data
+}
+
+func (world *testWorld) PlaceBlock(position blocks.Position, name string, _ int32, data byte) {
+	world.blocks[position] = testBlock{name, data}
+}
+
+func (world *testWorld) GetHeightRange() levels.HeightRange {
+	return levels.HeightRange{Min: 0, Max: 256}
+}
+
+func TestFarming(t *testing.T) {
+	var manager = NewManager(loot.NewManager(), SectionSize)
+	var world = newTestWorld()
+	var soil = blocks.NewPosition(0, 10, 0)
+	var crop = blocks.NewPosition(0, 11, 0)
+	world.blocks[soil] = testBlock{"grass", 0}
+	world.blocks[blocks.NewPosition(3, 10, 0)] = testBlock{"water", 0}
+
+	if !manager.Till(world, soil) || world.GetBlockName(soil) != Farmland {
+		t.Fatal("expected grass to be tilled")
+	}
+	if !manager.Plant(world, soil, "minecraft:wheat_seeds") || world.GetBlockName(crop) != "wheat" {
+		t.Fatal("expected wheat to be planted")
+	}
+
+	for i := 0; i < 1000 && world.GetBlockData(crop) < 7; i++ {
+		manager.Tick()
+	}
+	if world.GetBlockData(soil) != MaxMoisture {
+		t.Error("expected farmland next to water to be hydrated")
+	}
+	if world.GetBlockData(crop) != 7 {
+		t.Fatal("expected wheat to be fully grown")
+	}
+
+	var dropped []*items.Stack
+	manager.DropItemsFunction = func(_ World, _ blocks.Position, drops []*items.Stack) {
+		dropped = drops
+	}
+	if !manager.Trample(world, soil) || world.GetBlockName(soil) != Dirt || world.GetBlockName(crop) != Air {
+		t.Error("expected trampled farmland to turn into dirt and break the crop")
+	}
+	if len(dropped) == 0 || dropped[0].GetId() != "minecraft:wheat" {
+		t.Errorf("expected fully grown wheat to drop wheat, got %v", dropped)
+	}
+}
+
+func TestRandomTickSpeedFunction(t *testing.T) {
+	var manager = NewManager(loot.NewManager(), SectionSize)
+	var world = newTestWorld()
+	var soil = blocks.NewPosition(0, 10, 0)
+	var crop = blocks.NewPosition(0, 11, 0)
+	world.blocks[soil] = testBlock{"grass", 0}
+	manager.Till(world, soil)
+	manager.Plant(world, soil, "minecraft:wheat_seeds")
+
+	manager.RandomTickSpeedFunction = func(World) int {
+		return 0
+	}
+	for i := 0; i < 1000; i++ {
+		manager.Tick()
+	}
+	if world.GetBlockData(crop) != 0 {
+		t.Error("expected wheat not to grow in worlds with a random tick speed of 0")
+	}
+}
+
+func TestStore(t *testing.T) {
+	var directory, err = ioutil.TempDir("", "farming")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	var manager = NewManager(loot.NewManager(), SectionSize)
+	var world = newTestWorld()
+	var soil = blocks.NewPosition(3, 10, 5)
+	world.blocks[soil] = testBlock{"grass", 0}
+	manager.SetStore(world, directory)
+	manager.Till(world, soil)
+	manager.Plant(world, soil, "minecraft:wheat_seeds")
+
+	if err := manager.UnloadChunk(world, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if manager.GetTrackedCount() != 0 {
+		t.Fatal("expected the crop to stop receiving random ticks when its chunk was unloaded")
+	}
+	if err := manager.LoadChunk(world, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if manager.GetTrackedCount() != 2 {
+		t.Errorf("expected the farmland and crop to be loaded again, got %v", manager.GetTrackedCount())
+	}
+}
@@ -0,0 +1,307 @@
+package farming
+
+import (
+	"github.com/BobbyShrd/gominetest/structures"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	Sapling = "sapling"
+
+	SaplingId int32 = 6
+
+	// SaplingTypeMask is the mask of the data value of saplings holding the sapling type.
+	SaplingTypeMask byte = 0x07
+	// SaplingAgeBit is set in the data value of saplings that grow into a tree on their next growth stage.
+	SaplingAgeBit byte = 0x08
+)
+
+// saplingItems holds the sapling types planted by sapling items, indexed by item string ID.
+var saplingItems = map[string]byte{
+	"minecraft:oak_sapling":      0,
+	"minecraft:spruce_sapling":   1,
+	"minecraft:birch_sapling":    2,
+	"minecraft:jungle_sapling":   3,
+	"minecraft:acacia_sapling":   4,
+	"minecraft:dark_oak_sapling": 5,
+}
+
+// saplingTrees holds the names of the tree templates saplings grow into, indexed by sapling type.
+// Dark oak saplings need a 2x2 of saplings to grow, and do not grow on their own.
+var saplingTrees = map[byte]string{
+	0: structures.OakTree,
+	1: structures.SpruceTree,
+	2: structures.BirchTree,
+	3: structures.JungleTree,
+	4: structures.AcaciaTree,
+}
+
+// saplingSoil are the blocks saplings can be planted on.
+var saplingSoil = map[string]bool{
+	"dirt":     true,
+	"grass":    true,
+	"podzol":   true,
+	"farmland": true,
+}
+
+// StackedPlant is a plant that grows by stacking blocks on top of itself, like sugar cane.
+type StackedPlant struct {
+	// Name is the name of the plant block.
+	Name string
+	// Id is the legacy block ID of the plant block.
+	Id int32
+	// Item is the string ID of the item planting the plant, which is also dropped when it breaks.
+	Item string
+	// MaxHeight is the height the plant stops growing at.
+	MaxHeight int
+	// MaxAge is the age the top block of the plant has to reach before a block grows on top of it.
+	// The age is kept in the data value of the top block, and increases every random tick.
+	MaxAge byte
+	// Chance is the one in Chance chance of the plant growing on a random tick.
+	Chance int
+	// Soil are the blocks the plant can be planted on.
+	Soil map[string]bool
+	// NeedsWater specifies if the soil of the plant needs water next to it.
+	NeedsWater bool
+	// NeedsSpace specifies if all blocks next to the plant need to be air.
+	NeedsSpace bool
+}
+
+// stackedPlants holds all stacked plants, indexed by block name.
+var stackedPlants = map[string]StackedPlant{
+	"reeds": {"reeds", 83, "minecraft:sugar_cane", 3, 15, 1,
+		map[string]bool{"dirt": true, "grass": true, "podzol": true, "sand": true}, true, false},
+	"cactus": {"cactus", 81, "minecraft:cactus", 3, 15, 1,
+		map[string]bool{"sand": true}, false, true},
+	"bamboo": {"bamboo", 418, "minecraft:bamboo", 16, 0, 3,
+		map[string]bool{"dirt": true, "grass": true, "podzol": true, "sand": true, "gravel": true}, false, false},
+}
+
+// RegisterStackedPlant registers a new stacked plant, or overwrites the stacked plant with the same name.
+func RegisterStackedPlant(plant StackedPlant) {
+	stackedPlants[plant.Name] = plant
+}
+
+// GetStackedPlant returns the stacked plant with the given block name, and a bool indicating if it exists.
+func GetStackedPlant(name string) (StackedPlant, bool) {
+	var plant, ok = stackedPlants[name]
+	return plant, ok
+}
+
+// GetStackedPlantByItem returns the stacked plant planted by the item with the given string ID,
+// and a bool indicating if any stacked plant is planted by it.
+func GetStackedPlantByItem(itemId string) (StackedPlant, bool) {
+	for _, plant := range stackedPlants {
+		if plant.Item == itemId {
+			return plant, true
+		}
+	}
+	return StackedPlant{}, false
+}
+
+// GetPlantItems returns the string IDs of all items planting saplings and stacked plants.
+func GetPlantItems() []string {
+	var plantItems []string
+	for item := range saplingItems {
+		plantItems = append(plantItems, item)
+	}
+	for _, plant := range stackedPlants {
+		plantItems = append(plantItems, plant.Item)
+	}
+	return plantItems
+}
+
+// GetSaplingItem returns the string ID of the item of the given sapling type.
+func GetSaplingItem(saplingType byte) string {
+	for item, t := range saplingItems {
+		if t == saplingType {
+			return item
+		}
+	}
+	return ""
+}
+
+// PlacePlant plants the sapling or stacked plant of the given item on top of the block at the given position.
+// Returns false if the item plants nothing, or if the plant can not grow there.
+func (manager *Manager) PlacePlant(world World, position blocks.Position, item string) bool {
+	var above, ok = offsetUp(position)
+	if !ok || world.GetBlockName(above) != Air {
+		return false
+	}
+	if saplingType, isSapling := saplingItems[item]; isSapling {
+		if !saplingSoil[world.GetBlockName(position)] {
+			return false
+		}
+		world.PlaceBlock(above, Sapling, SaplingId, saplingType)
+		manager.Track(world, above)
+		return true
+	}
+	if plant, isPlant := GetStackedPlantByItem(item); isPlant {
+		if !plant.canStay(world, above) {
+			return false
+		}
+		world.PlaceBlock(above, plant.Name, plant.Id, 0)
+		manager.Track(world, above)
+		return true
+	}
+	return false
+}
+
+// GrowTree grows the sapling at the given position into a tree.
+// Returns false if the block is no sapling, or if there is no room for the tree.
+func (manager *Manager) GrowTree(world World, position blocks.Position) bool {
+	if world.GetBlockName(position) != Sapling {
+		return false
+	}
+	var name, ok = saplingTrees[world.GetBlockData(position)&SaplingTypeMask]
+	if !ok {
+		return false
+	}
+	template, ok := structures.GetTemplate(name)
+	if !ok {
+		return false
+	}
+	manager.mutex.Lock()
+	var structure = template(manager.random)
+	manager.mutex.Unlock()
+
+	if !structure.Place(world, position) {
+		return false
+	}
+	manager.Untrack(world, position)
+	return true
+}
+
+// tickSapling advances the growth stage of a sapling, growing it into a tree after its second stage.
+func (manager *Manager) tickSapling(world World, position blocks.Position) {
+	if manager.randomInt(7) != 0 {
+		return
+	}
+	manager.advanceSapling(world, position)
+}
+
+// advanceSapling sets the age bit of a sapling, or grows it into a tree if it was already set.
+func (manager *Manager) advanceSapling(world World, position blocks.Position) {
+	var data = world.GetBlockData(position)
+	if data&SaplingAgeBit == 0 {
+		world.PlaceBlock(position, Sapling, SaplingId, data|SaplingAgeBit)
+		return
+	}
+	manager.GrowTree(world, position)
+}
+
+// tickStackedPlant ages the top block of a stacked plant, growing a new block on top once it is old enough.
+// Stacked plants that can no longer stay where they are break.
+func (manager *Manager) tickStackedPlant(world World, position blocks.Position, plant StackedPlant) {
+	if !plant.canStay(world, position) {
+		manager.Break(world, position)
+		world.PlaceBlock(position, Air, 0, 0)
+		return
+	}
+	var above, ok = offsetUp(position)
+	if !ok || world.GetBlockName(above) != Air || plant.height(world, position) >= plant.MaxHeight {
+		return
+	}
+	if plant.Chance > 1 && manager.randomInt(plant.Chance) != 0 {
+		return
+	}
+	var age = world.GetBlockData(position)
+	if age < plant.MaxAge {
+		world.PlaceBlock(position, plant.Name, plant.Id, age+1)
+		return
+	}
+	world.PlaceBlock(position, plant.Name, plant.Id, 0)
+	world.PlaceBlock(above, plant.Name, plant.Id, 0)
+	manager.Track(world, above)
+}
+
+// growStackedPlant grows a block on top of the stacked plant at the given position,
+// ignoring the age of the plant. Returns false if the plant could not grow.
+func (manager *Manager) growStackedPlant(world World, position blocks.Position, plant StackedPlant) bool {
+	var top = position
+	for {
+		var above, ok = offsetUp(top)
+		if !ok {
+			return false
+		}
+		if world.GetBlockName(above) != plant.Name {
+			if world.GetBlockName(above) != Air || plant.height(world, top) >= plant.MaxHeight {
+				return false
+			}
+			world.PlaceBlock(above, plant.Name, plant.Id, 0)
+			manager.Track(world, above)
+			return true
+		}
+		top = above
+	}
+}
+
+// breakStackedPlant breaks all blocks of a stacked plant above the given position,
+// as they can not stay without the block below them.
+func (manager *Manager) breakStackedPlant(world World, position blocks.Position, plant StackedPlant) {
+	for {
+		var above, ok = offsetUp(position)
+		if !ok || world.GetBlockName(above) != plant.Name {
+			return
+		}
+		manager.dropItem(world, above, plant.Item)
+		world.PlaceBlock(above, Air, 0, 0)
+		manager.Untrack(world, above)
+		position = above
+	}
+}
+
+// height returns the amount of blocks of the plant stacked up to and including the given position.
+func (plant StackedPlant) height(world World, position blocks.Position) int {
+	var height = 1
+	for position.Y > 0 {
+		position.Y--
+		if world.GetBlockName(position) != plant.Name {
+			break
+		}
+		height++
+	}
+	return height
+}
+
+// canStay checks if the plant can stay at the given position.
+func (plant StackedPlant) canStay(world World, position blocks.Position) bool {
+	if position.Y == 0 {
+		return false
+	}
+	var below = position
+	below.Y--
+	var belowName = world.GetBlockName(below)
+	if plant.NeedsSpace {
+		for _, side := range horizontalNeighbours(position) {
+			if world.GetBlockName(side) != Air {
+				return false
+			}
+		}
+	}
+	if belowName == plant.Name {
+		return true
+	}
+	if !plant.Soil[belowName] {
+		return false
+	}
+	if plant.NeedsWater {
+		for _, side := range horizontalNeighbours(below) {
+			if waterBlocks[world.GetBlockName(side)] {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// horizontalNeighbours returns the four positions horizontally next to the given position.
+func horizontalNeighbours(position blocks.Position) []blocks.Position {
+	return []blocks.Position{
+		blocks.NewPosition(position.X+1, position.Y, position.Z),
+		blocks.NewPosition(position.X-1, position.Y, position.Z),
+		blocks.NewPosition(position.X, position.Y, position.Z+1),
+		blocks.NewPosition(position.X, position.Y, position.Z-1),
+	}
+}
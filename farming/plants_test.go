@@ -0,0 +1,89 @@
+package farming
+
+import (
+	"testing"
+
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/loot"
+	"github.com/irmine/worlds/blocks"
+)
+
+func TestSaplingGrowth(t *testing.T) {
+	var manager = NewManager(loot.NewManager(), SectionSize)
+	var world = newTestWorld()
+	var soil = blocks.NewPosition(0, 10, 0)
+	var sapling = blocks.NewPosition(0, 11, 0)
+	world.blocks[soil] = testBlock{"grass", 0}
+
+	if !manager.PlacePlant(world, soil, "minecraft:birch_sapling") || world.GetBlockName(sapling) != Sapling {
+		t.Fatal("expected a sapling to be planted")
+	}
+	for i := 0; i < 1000 && world.GetBlockName(sapling) == Sapling; i++ {
+		manager.Tick()
+	}
+	if world.GetBlockName(sapling) != "log" || world.GetBlockData(sapling) != 2 {
+		t.Fatalf("expected the sapling to grow into a birch tree, got %v", world.blocks[sapling])
+	}
+	if manager.GetTrackedCount() != 0 {
+		t.Error("expected the tree to no longer be tracked")
+	}
+}
+
+func TestSaplingWithoutRoom(t *testing.T) {
+	var manager = NewManager(loot.NewManager(), SectionSize)
+	var world = newTestWorld()
+	var sapling = blocks.NewPosition(0, 11, 0)
+	world.blocks[sapling] = testBlock{Sapling, SaplingAgeBit}
+	world.blocks[blocks.NewPosition(0, 13, 0)] = testBlock{"stone", 0}
+
+	if manager.GrowTree(world, sapling) || world.GetBlockName(sapling) != Sapling {
+		t.Error("expected a sapling below stone not to grow")
+	}
+}
+
+func TestSugarCaneGrowth(t *testing.T) {
+	var manager = NewManager(loot.NewManager(), SectionSize)
+	var world = newTestWorld()
+	var soil = blocks.NewPosition(0, 10, 0)
+	world.blocks[soil] = testBlock{"sand", 0}
+
+	if manager.PlacePlant(world, soil, "minecraft:sugar_cane") {
+		t.Fatal("expected sugar cane not to be planted without water")
+	}
+	world.blocks[blocks.NewPosition(1, 10, 0)] = testBlock{"water", 0}
+	if !manager.PlacePlant(world, soil, "minecraft:sugar_cane") {
+		t.Fatal("expected sugar cane to be planted next to water")
+	}
+	for i := 0; i < 5000; i++ {
+		manager.Tick()
+	}
+	for y := uint32(11); y <= 13; y++ {
+		if world.GetBlockName(blocks.NewPosition(0, y, 0)) != "reeds" {
+			t.Fatalf("expected sugar cane at height %v", y)
+		}
+	}
+	if world.GetBlockName(blocks.NewPosition(0, 14, 0)) != Air {
+		t.Fatal("expected sugar cane to stop growing at three blocks")
+	}
+
+	var dropped int
+	manager.DropItemsFunction = func(World, blocks.Position, []*items.Stack) {
+		dropped++
+	}
+	manager.Break(world, blocks.NewPosition(0, 12, 0))
+	if world.GetBlockName(blocks.NewPosition(0, 13, 0)) != Air || dropped != 2 {
+		t.Errorf("expected the sugar cane above the broken block to break, got %v drops", dropped)
+	}
+}
+
+func TestCactusNeedsSpace(t *testing.T) {
+	var manager = NewManager(loot.NewManager(), SectionSize)
+	var world = newTestWorld()
+	var soil = blocks.NewPosition(0, 10, 0)
+	world.blocks[soil] = testBlock{"sand", 0}
+	world.blocks[blocks.NewPosition(1, 11, 0)] = testBlock{"stone", 0}
+
+	if manager.PlacePlant(world, soil, "minecraft:cactus") {
+		t.Error("expected a cactus not to be planted next to a block")
+	}
+}
@@ -0,0 +1,132 @@
+package farming
+
+import (
+	"github.com/BobbyShrd/gominetest/chunkstore"
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+// TagTracked is the name of the list holding the farmland, crops and plants of a chunk file.
+const TagTracked = "Tracked"
+
+// store holds the files the farmland, crops and plants of a world are saved in,
+// and the chunks of the world that were loaded from them.
+type store struct {
+	files  *chunkstore.Store
+	loaded map[[2]int32]bool
+}
+
+// SetStore makes the farmland, crops and plants of the world persist in the given directory,
+// with one NBT file for every chunk holding any, so that they keep receiving random ticks after restarting.
+func (manager *Manager) SetStore(world World, directory string) {
+	manager.mutex.Lock()
+	manager.stores[world] = &store{files: chunkstore.New(directory, TagTracked), loaded: make(map[[2]int32]bool)}
+	manager.mutex.Unlock()
+}
+
+// getStore returns the store of the world, and a bool indicating if the world has one.
+func (manager *Manager) getStore(world World) (*store, bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var store, ok = manager.stores[world]
+	return store, ok
+}
+
+// getTrackedInChunk returns all tracked positions of the world in the chunk with the given coordinates.
+func (manager *Manager) getTrackedInChunk(world World, x, z int32) []blocks.Position {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var positions []blocks.Position
+	for k := range manager.tracked {
+		if k.world == world && k.position.X>>4 == x && k.position.Z>>4 == z {
+			positions = append(positions, k.position)
+		}
+	}
+	return positions
+}
+
+// LoadChunk loads the farmland, crops and plants saved in the chunk of the world at the given chunk coordinates,
+// making them receive random ticks again. Nothing is loaded if the chunk was loaded before,
+// or if the world has no store.
+func (manager *Manager) LoadChunk(world World, x, z int32) error {
+	var store, ok = manager.getStore(world)
+	if !ok {
+		return nil
+	}
+	manager.mutex.Lock()
+	if store.loaded[[2]int32{x, z}] {
+		manager.mutex.Unlock()
+		return nil
+	}
+	store.loaded[[2]int32{x, z}] = true
+	manager.mutex.Unlock()
+
+	var compounds, err = store.files.Load(x, z)
+	if err != nil {
+		return err
+	}
+	for _, compound := range compounds {
+		manager.Track(world, chunkstore.PositionFromNBT(compound))
+	}
+	return nil
+}
+
+// SaveChunk saves the farmland, crops and plants in the chunk of the world at the given chunk coordinates,
+// replacing the ones saved before. The file of the chunk is removed if it holds none.
+func (manager *Manager) SaveChunk(world World, x, z int32) error {
+	var store, ok = manager.getStore(world)
+	if !ok {
+		return nil
+	}
+	var positions = manager.getTrackedInChunk(world, x, z)
+	var tags = make([]gonbt.INamedTag, len(positions))
+	for i, position := range positions {
+		tags[i] = chunkstore.PositionToNBT(position)
+	}
+	return store.files.Save(x, z, tags)
+}
+
+// SaveAll saves the farmland, crops and plants of all chunks of the world that were loaded
+// or planted in since the store was set. The first error that occurred is returned, after all other chunks were saved.
+func (manager *Manager) SaveAll(world World) error {
+	var store, ok = manager.getStore(world)
+	if !ok {
+		return nil
+	}
+	manager.mutex.Lock()
+	var chunks = make([][2]int32, 0, len(store.loaded))
+	for chunk := range store.loaded {
+		chunks = append(chunks, chunk)
+	}
+	manager.mutex.Unlock()
+
+	var first error
+	for _, chunk := range chunks {
+		if err := manager.SaveChunk(world, chunk[0], chunk[1]); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// UnloadChunk saves the farmland, crops and plants in the chunk of the world at the given chunk coordinates,
+// and stops them from receiving random ticks until the chunk is loaded again.
+// They keep receiving random ticks if the world has no store.
+func (manager *Manager) UnloadChunk(world World, x, z int32) error {
+	var store, ok = manager.getStore(world)
+	if !ok {
+		return nil
+	}
+	if err := manager.SaveChunk(world, x, z); err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	for k := range manager.tracked {
+		if k.world == world && k.position.X>>4 == x && k.position.Z>>4 == z {
+			delete(manager.tracked, k)
+		}
+	}
+	delete(store.loaded, [2]int32{x, z})
+	manager.mutex.Unlock()
+	return nil
+}
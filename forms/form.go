@@ -0,0 +1,113 @@
+package forms
+
+import (
+	"encoding/json"
+
+	"github.com/BobbyShrd/gominetest/net"
+)
+
+const (
+	TypeSimple = "form"
+	TypeModal  = "modal"
+)
+
+// Form is a form that can be sent to a client.
+// Every form encodes itself to the JSON the client expects,
+// and handles the response data the client sends back.
+type Form interface {
+	// GetData returns the JSON encoded form data.
+	GetData() ([]byte, error)
+	// HandleResponse handles the JSON response data of the client.
+	// Response data is "null" if the client closed the form.
+	HandleResponse(responder *net.MinecraftSession, data []byte)
+}
+
+// Button is a button of a simple form.
+type Button struct {
+	Text string `json:"text"`
+}
+
+// SimpleForm is a form with a title, content and a list of buttons.
+type SimpleForm struct {
+	Title   string
+	Content string
+	Buttons []Button
+	// SubmitFunction gets called with the index of the button clicked.
+	SubmitFunction func(responder *net.MinecraftSession, button int)
+	// CloseFunction gets called if the client closed the form without clicking a button.
+	CloseFunction func(responder *net.MinecraftSession)
+}
+
+// NewSimpleForm returns a new simple form with the given title and content.
+func NewSimpleForm(title string, content string) *SimpleForm {
+	return &SimpleForm{Title: title, Content: content}
+}
+
+// AddButton adds a button with the given text to the form.
+func (form *SimpleForm) AddButton(text string) {
+	form.Buttons = append(form.Buttons, Button{text})
+}
+
+// GetData returns the JSON encoded form data.
+func (form *SimpleForm) GetData() ([]byte, error) {
+	var buttons = form.Buttons
+	if buttons == nil {
+		buttons = []Button{}
+	}
+	return json.Marshal(map[string]interface{}{
+		"type":    TypeSimple,
+		"title":   form.Title,
+		"content": form.Content,
+		"buttons": buttons,
+	})
+}
+
+// HandleResponse handles the JSON response data of the client.
+func (form *SimpleForm) HandleResponse(responder *net.MinecraftSession, data []byte) {
+	var button *int
+	if err := json.Unmarshal(data, &button); err != nil || button == nil || *button < 0 || *button >= len(form.Buttons) {
+		if form.CloseFunction != nil {
+			form.CloseFunction(responder)
+		}
+		return
+	}
+	if form.SubmitFunction != nil {
+		form.SubmitFunction(responder, *button)
+	}
+}
+
+// ModalForm is a form with a title, content and two buttons.
+type ModalForm struct {
+	Title   string
+	Content string
+	Button1 string
+	Button2 string
+	// SubmitFunction gets called with true if the first button was clicked,
+	// and false if the second button was clicked or the form was closed.
+	SubmitFunction func(responder *net.MinecraftSession, value bool)
+}
+
+// NewModalForm returns a new modal form with the given title, content and buttons.
+func NewModalForm(title string, content string, button1 string, button2 string) *ModalForm {
+	return &ModalForm{Title: title, Content: content, Button1: button1, Button2: button2}
+}
+
+// GetData returns the JSON encoded form data.
+func (form *ModalForm) GetData() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type":    TypeModal,
+		"title":   form.Title,
+		"content": form.Content,
+		"button1": form.Button1,
+		"button2": form.Button2,
+	})
+}
+
+// HandleResponse handles the JSON response data of the client.
+func (form *ModalForm) HandleResponse(responder *net.MinecraftSession, data []byte) {
+	var value bool
+	json.Unmarshal(data, &value)
+	if form.SubmitFunction != nil {
+		form.SubmitFunction(responder, value)
+	}
+}
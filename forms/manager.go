@@ -0,0 +1,119 @@
+package forms
+
+import (
+	"sync"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/google/uuid"
+)
+
+// RejectDuration is the time within which clients that close a form are considered to have rejected it,
+// as players can not close forms this fast themselves. The latency of the client is added to it.
+const RejectDuration = 300 * time.Millisecond
+
+// pendingForm is a form sent to a session, with the time it was sent.
+type pendingForm struct {
+	form Form
+	sent time.Time
+}
+
+// Manager keeps track of forms sent to sessions,
+// so responses of clients can be passed to the right form.
+// Forms rejected by clients are sent as chat menus instead, if the form has a fallback.
+type Manager struct {
+	mutex   sync.Mutex
+	counter uint32
+	pending map[uuid.UUID]map[uint32]pendingForm
+	menus   map[uuid.UUID]Menu
+}
+
+// NewManager returns a new form manager.
+func NewManager() *Manager {
+	return &Manager{sync.Mutex{}, 0, make(map[uuid.UUID]map[uint32]pendingForm), make(map[uuid.UUID]Menu)}
+}
+
+// SendForm sends a form to the session, and returns the ID of the form.
+// An error is returned if the form data could not be encoded.
+func (manager *Manager) SendForm(session *net.MinecraftSession, form Form) (uint32, error) {
+	var data, err = form.GetData()
+	if err != nil {
+		return 0, err
+	}
+
+	manager.mutex.Lock()
+	manager.counter++
+	var id = manager.counter
+	if _, ok := manager.pending[session.GetUUID()]; !ok {
+		manager.pending[session.GetUUID()] = make(map[uint32]pendingForm)
+	}
+	manager.pending[session.GetUUID()][id] = pendingForm{form, time.Now()}
+	manager.mutex.Unlock()
+
+	session.SendModalFormRequest(id, string(data))
+	return id, nil
+}
+
+// HandleResponse passes the response data of a client to the form with the given ID.
+// Forms closed within RejectDuration and the latency of the session are sent as chat menu instead, if they have a fallback.
+// Returns false if no form with the ID was sent to the session.
+func (manager *Manager) HandleResponse(session *net.MinecraftSession, id uint32, data string) bool {
+	manager.mutex.Lock()
+	var pending, ok = manager.pending[session.GetUUID()][id]
+	if ok {
+		delete(manager.pending[session.GetUUID()], id)
+	}
+	manager.mutex.Unlock()
+
+	if !ok {
+		return false
+	}
+	var rejectDuration = RejectDuration + time.Duration(session.GetPing())*time.Millisecond
+	if fallback, ok := pending.form.(Fallback); ok && data == "null" && time.Since(pending.sent) < rejectDuration {
+		manager.SendMenu(session, fallback.GetMenu())
+		return true
+	}
+	pending.form.HandleResponse(session, []byte(data))
+	return true
+}
+
+// SendMenu sends the menu to the session in chat, replacing the menu sent to it before.
+func (manager *Manager) SendMenu(session *net.MinecraftSession, menu Menu) {
+	manager.mutex.Lock()
+	manager.menus[session.GetUUID()] = menu
+	manager.mutex.Unlock()
+	for _, line := range menu.GetLines() {
+		session.SendMessage(line)
+	}
+}
+
+// SelectOption selects the option with the given number, counting from 1, of the last menu sent to the session.
+// NoMenu is returned if no menu was sent to the session, and InvalidOption if the menu has no option with the number.
+// A menu can only be selected from once.
+func (manager *Manager) SelectOption(session *net.MinecraftSession, number int) error {
+	manager.mutex.Lock()
+	var menu, ok = manager.menus[session.GetUUID()]
+	if ok && number >= 1 && number <= len(menu.Options) {
+		delete(manager.menus, session.GetUUID())
+	}
+	manager.mutex.Unlock()
+
+	if !ok {
+		return NoMenu
+	}
+	if number < 1 || number > len(menu.Options) {
+		return InvalidOption
+	}
+	if menu.SubmitFunction != nil {
+		menu.SubmitFunction(session, number-1)
+	}
+	return nil
+}
+
+// RemoveSession removes all pending forms of the session.
+func (manager *Manager) RemoveSession(session *net.MinecraftSession) {
+	manager.mutex.Lock()
+	delete(manager.pending, session.GetUUID())
+	delete(manager.menus, session.GetUUID())
+	manager.mutex.Unlock()
+}
@@ -0,0 +1,63 @@
+package forms
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+var (
+	NoMenu        = errors.New("no menu was sent")
+	InvalidOption = errors.New("menu has no such option")
+)
+
+// MenuCommand is the command players select options of chat menus with, followed by the number of the option.
+const MenuCommand = "/menu"
+
+// Menu is a numbered list of options sent in chat, of which players select one with the menu command.
+// Menus are sent instead of forms to clients that reject forms.
+type Menu struct {
+	Title   string
+	Content string
+	Options []string
+	// SubmitFunction gets called with the index of the option selected.
+	SubmitFunction func(responder *net.MinecraftSession, option int)
+}
+
+// Fallback is a form that can be shown as a chat menu to clients that reject forms.
+type Fallback interface {
+	// GetMenu returns the chat menu with the same options as the form.
+	GetMenu() Menu
+}
+
+// GetLines returns the lines of chat the menu is sent as, with the options numbered from 1.
+func (menu Menu) GetLines() []*text.Component {
+	var lines = []*text.Component{text.NewComponent().Color(text.Yellow).Bold().Text(menu.Title)}
+	if menu.Content != "" {
+		lines = append(lines, text.NewComponent().Text(menu.Content))
+	}
+	for i, option := range menu.Options {
+		lines = append(lines, text.NewComponent().Color(text.Orange).Text("["+strconv.Itoa(i+1)+"] ").Color(text.White).Text(option))
+	}
+	return append(lines, text.NewComponent().Color(text.Gray).Text("Type ").Command(MenuCommand+" <number>").Text(" to select an option."))
+}
+
+// GetMenu returns the chat menu with the buttons of the form as options.
+func (form *SimpleForm) GetMenu() Menu {
+	var options = make([]string, len(form.Buttons))
+	for i, button := range form.Buttons {
+		options[i] = button.Text
+	}
+	return Menu{Title: form.Title, Content: form.Content, Options: options, SubmitFunction: form.SubmitFunction}
+}
+
+// GetMenu returns the chat menu with the two buttons of the form as options.
+func (form *ModalForm) GetMenu() Menu {
+	return Menu{Title: form.Title, Content: form.Content, Options: []string{form.Button1, form.Button2}, SubmitFunction: func(responder *net.MinecraftSession, option int) {
+		if form.SubmitFunction != nil {
+			form.SubmitFunction(responder, option == 0)
+		}
+	}}
+}
@@ -0,0 +1,220 @@
+package gamerules
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	UnknownRule  = errors.New("game rule does not exist")
+	InvalidValue = errors.New("value is not valid for the type of the game rule")
+)
+
+// Type is the type of the value of a game rule.
+type Type int
+
+const (
+	Bool Type = iota
+	Int
+	Float
+)
+
+// Rule is a game rule with a typed value.
+type Rule struct {
+	// Name is the name of the game rule, such as "doDaylightCycle".
+	// Game rules are looked up case insensitively.
+	Name string
+	// Type is the type of the value of the game rule.
+	Type Type
+	// Default is the value of the game rule in worlds that did not set it.
+	// It is a bool, int32 or float32, depending on the type.
+	Default interface{}
+}
+
+// Names of the game rules that are registered by default.
+// Only game rules the server acts on are registered, so that setting a game rule never goes without effect.
+const (
+	CommandBlockOutput  = "commandBlockOutput"
+	DoDaylightCycle     = "doDaylightCycle"
+	DoEntityDrops       = "doEntityDrops"
+	DoMobLoot           = "doMobLoot"
+	DoTileDrops         = "doTileDrops"
+	DoWeatherCycle      = "doWeatherCycle"
+	DrowningDamage      = "drowningDamage"
+	FireDamage          = "fireDamage"
+	KeepInventory       = "keepInventory"
+	PvP                 = "pvp"
+	RandomTickSpeed     = "randomTickSpeed"
+	SendCommandFeedback = "sendCommandFeedback"
+	ShowCoordinates     = "showCoordinates"
+)
+
+// registry holds all registered game rules, indexed by lower case name.
+var registry = map[string]Rule{}
+
+func init() {
+	for _, name := range []string{CommandBlockOutput, DoDaylightCycle, DoEntityDrops, DoMobLoot, DoTileDrops, DoWeatherCycle,
+		DrowningDamage, FireDamage, PvP, SendCommandFeedback} {
+		Register(Rule{Name: name, Type: Bool, Default: true})
+	}
+	Register(Rule{Name: KeepInventory, Type: Bool, Default: false})
+	Register(Rule{Name: ShowCoordinates, Type: Bool, Default: false})
+	Register(Rule{Name: RandomTickSpeed, Type: Int, Default: int32(1)})
+}
+
+// Register registers a game rule, so that it can be set in worlds.
+// Registering a game rule with the name of an existing game rule overwrites it.
+func Register(rule Rule) {
+	registry[strings.ToLower(rule.Name)] = rule
+}
+
+// Get returns the game rule with the given name, and a bool indicating if it is registered.
+func Get(name string) (Rule, bool) {
+	var rule, ok = registry[strings.ToLower(name)]
+	return rule, ok
+}
+
+// GetNames returns the names of all registered game rules, sorted alphabetically.
+func GetNames() []string {
+	var names = make([]string, 0, len(registry))
+	for _, rule := range registry {
+		names = append(names, rule.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse parses the string value for the game rule, returning a bool, int32 or float32 depending on its type.
+func (rule Rule) Parse(value string) (interface{}, error) {
+	switch rule.Type {
+	case Bool:
+		var b, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, InvalidValue
+		}
+		return b, nil
+	case Int:
+		var i, err = strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, InvalidValue
+		}
+		return int32(i), nil
+	}
+	var f, err = strconv.ParseFloat(value, 32)
+	if err != nil {
+		return nil, InvalidValue
+	}
+	return float32(f), nil
+}
+
+// Format returns the value of the game rule as string, such as "true" or "3".
+func Format(value interface{}) string {
+	switch value := value.(type) {
+	case bool:
+		return strconv.FormatBool(value)
+	case int32:
+		return strconv.Itoa(int(value))
+	case float32:
+		return strconv.FormatFloat(float64(value), 'g', -1, 32)
+	}
+	return ""
+}
+
+// Rules holds the values of the game rules of a world.
+// Game rules that were not set have their default value.
+type Rules struct {
+	mutex  sync.RWMutex
+	values map[string]interface{}
+}
+
+// New returns new game rules with all game rules at their default value.
+func New() *Rules {
+	return &Rules{values: make(map[string]interface{})}
+}
+
+// FromStrings returns game rules with the values in the map, indexed by game rule name, as stored in level data.
+// Values of unknown game rules and invalid values are left out.
+func FromStrings(values map[string]string) *Rules {
+	var rules = New()
+	for name, value := range values {
+		rules.Set(name, value)
+	}
+	return rules
+}
+
+// ToStrings returns the values of all game rules that were set as strings, indexed by lower case name,
+// as stored in level data.
+func (rules *Rules) ToStrings() map[string]string {
+	rules.mutex.RLock()
+	defer rules.mutex.RUnlock()
+	var values = make(map[string]string, len(rules.values))
+	for name, value := range rules.values {
+		values[name] = Format(value)
+	}
+	return values
+}
+
+// Get returns the value of the game rule with the given name, and a bool indicating if the game rule exists.
+// The default value is returned if the game rule was not set.
+func (rules *Rules) Get(name string) (interface{}, bool) {
+	var rule, ok = Get(name)
+	if !ok {
+		return nil, false
+	}
+	rules.mutex.RLock()
+	defer rules.mutex.RUnlock()
+	if value, ok := rules.values[strings.ToLower(name)]; ok {
+		return value, true
+	}
+	return rule.Default, true
+}
+
+// GetBool returns the value of the bool game rule with the given name, or false if it is not a bool game rule.
+func (rules *Rules) GetBool(name string) bool {
+	var value, _ = rules.Get(name)
+	var b, _ = value.(bool)
+	return b
+}
+
+// GetInt returns the value of the int game rule with the given name, or 0 if it is not an int game rule.
+func (rules *Rules) GetInt(name string) int32 {
+	var value, _ = rules.Get(name)
+	var i, _ = value.(int32)
+	return i
+}
+
+// GetFloat returns the value of the float game rule with the given name, or 0 if it is not a float game rule.
+func (rules *Rules) GetFloat(name string) float32 {
+	var value, _ = rules.Get(name)
+	var f, _ = value.(float32)
+	return f
+}
+
+// Set parses the string value and sets the game rule with the given name to it.
+// UnknownRule is returned if the game rule does not exist, and InvalidValue if the value does not fit its type.
+func (rules *Rules) Set(name string, value string) error {
+	var rule, ok = Get(name)
+	if !ok {
+		return UnknownRule
+	}
+	var parsed, err = rule.Parse(value)
+	if err != nil {
+		return err
+	}
+	rules.mutex.Lock()
+	rules.values[strings.ToLower(name)] = parsed
+	rules.mutex.Unlock()
+	return nil
+}
+
+// GetAll returns the values of all registered game rules, indexed by game rule name.
+func (rules *Rules) GetAll() map[string]interface{} {
+	var values = make(map[string]interface{}, len(registry))
+	for _, rule := range registry {
+		values[rule.Name], _ = rules.Get(rule.Name)
+	}
+	return values
+}
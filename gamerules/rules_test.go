@@ -0,0 +1,41 @@
+package gamerules
+
+import "testing"
+
+func TestRules(t *testing.T) {
+	var rules = FromStrings(map[string]string{"keepinventory": "true", "randomtickspeed": "3", "unknown": "1", "pvp": "maybe"})
+	if !rules.GetBool(KeepInventory) {
+		t.Error("expected keepInventory to be loaded")
+	}
+	if rules.GetInt(RandomTickSpeed) != 3 {
+		t.Errorf("expected randomTickSpeed 3, got %v", rules.GetInt(RandomTickSpeed))
+	}
+	if !rules.GetBool(PvP) {
+		t.Error("expected invalid values to be left out")
+	}
+	if !rules.GetBool(DoDaylightCycle) || rules.GetBool(ShowCoordinates) {
+		t.Error("expected game rules that were not set to have their default value")
+	}
+
+	if err := rules.Set("DoDaylightCycle", "false"); err != nil || rules.GetBool(DoDaylightCycle) {
+		t.Errorf("expected doDaylightCycle to be disabled, got error %v", err)
+	}
+	if err := rules.Set(RandomTickSpeed, "true"); err != InvalidValue {
+		t.Errorf("expected invalid value, got %v", err)
+	}
+	if err := rules.Set("unknown", "true"); err != UnknownRule {
+		t.Errorf("expected unknown game rule, got %v", err)
+	}
+
+	Register(Rule{Name: "gravity", Type: Float, Default: float32(1)})
+	if err := rules.Set("gravity", "0.5"); err != nil || rules.GetFloat("gravity") != 0.5 {
+		t.Errorf("expected gravity 0.5, got %v", rules.GetFloat("gravity"))
+	}
+	var values = rules.ToStrings()
+	if len(values) != 4 || values["dodaylightcycle"] != "false" || values["gravity"] != "0.5" {
+		t.Errorf("unexpected game rules to store: %v", values)
+	}
+	if len(rules.GetAll()) != len(GetNames()) {
+		t.Error("expected all registered game rules to be returned")
+	}
+}
@@ -0,0 +1,57 @@
+package geoip
+
+import (
+	"net"
+	"strings"
+)
+
+// countryLanguages holds the language most commonly spoken in countries, indexed by ISO country code.
+var countryLanguages = map[string]string{
+	"US": "en_US",
+	"GB": "en_GB",
+	"IE": "en_GB",
+	"AU": "en_GB",
+	"NZ": "en_GB",
+	"CA": "en_US",
+	"DE": "de_DE",
+	"AT": "de_DE",
+	"CH": "de_DE",
+	"FR": "fr_FR",
+	"BE": "fr_FR",
+	"ES": "es_ES",
+	"MX": "es_ES",
+	"AR": "es_ES",
+	"NL": "nl_NL",
+	"BR": "pt_BR",
+	"PT": "pt_BR",
+}
+
+// GetCountry returns the ISO code of the country the IP address is located in.
+// The registered country of the network is used if the location is not known.
+// A bool is returned indicating if the country could be found.
+func (reader *Reader) GetCountry(ip net.IP) (string, bool) {
+	var record, ok, err = reader.Lookup(ip)
+	if err != nil || !ok {
+		return "", false
+	}
+	var fields, _ = record.(map[string]interface{})
+	for _, key := range []string{"country", "registered_country"} {
+		if country, ok := fields[key].(map[string]interface{}); ok {
+			if code, ok := country["iso_code"].(string); ok {
+				return code, true
+			}
+		}
+	}
+	return "", false
+}
+
+// GetLanguage returns the language most commonly spoken in the country with the given ISO code,
+// or an empty string if the language of the country is not known.
+func GetLanguage(country string) string {
+	return countryLanguages[strings.ToUpper(country)]
+}
+
+// SetLanguage sets the language of the country with the given ISO code.
+func SetLanguage(country string, language string) {
+	countryLanguages[strings.ToUpper(country)] = language
+}
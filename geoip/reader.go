@@ -0,0 +1,287 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"math"
+	"net"
+)
+
+// metadataMarker marks the start of the metadata at the end of a MaxMind DB file.
+var metadataMarker = []byte("\xab\xcd\xefMaxMind.com")
+
+var (
+	InvalidDatabase = errors.New("invalid MaxMind DB file")
+	InvalidAddress  = errors.New("invalid IP address")
+)
+
+// Reader reads records from a MaxMind DB (MMDB) file, such as the GeoLite2 country database.
+// The whole file is kept in memory.
+type Reader struct {
+	buffer     []byte
+	nodeCount  uint
+	recordSize uint
+	ipVersion  uint
+	dataOffset uint
+	ipv4Start  uint
+}
+
+// Open opens the MaxMind DB file at the given path.
+func Open(path string) (*Reader, error) {
+	var buffer, err = ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReader(buffer)
+}
+
+// NewReader returns a new reader reading the MaxMind DB in the given buffer.
+func NewReader(buffer []byte) (*Reader, error) {
+	var start = bytes.LastIndex(buffer, metadataMarker)
+	if start == -1 {
+		return nil, InvalidDatabase
+	}
+	var metadata, _, err = (&decoder{buffer[start+len(metadataMarker):]}).decode(0)
+	if err != nil {
+		return nil, err
+	}
+	var fields, ok = metadata.(map[string]interface{})
+	if !ok {
+		return nil, InvalidDatabase
+	}
+	var reader = &Reader{buffer: buffer}
+	reader.nodeCount = getUint(fields["node_count"])
+	reader.recordSize = getUint(fields["record_size"])
+	reader.ipVersion = getUint(fields["ip_version"])
+	if reader.recordSize != 24 && reader.recordSize != 28 && reader.recordSize != 32 {
+		return nil, InvalidDatabase
+	}
+	reader.dataOffset = reader.nodeCount*reader.recordSize/4 + 16
+	if reader.dataOffset > uint(start) {
+		return nil, InvalidDatabase
+	}
+
+	// IPv4 addresses are stored in the ::/96 subtree of IPv6 databases.
+	if reader.ipVersion == 6 {
+		for i := 0; i < 96 && reader.ipv4Start < reader.nodeCount; i++ {
+			reader.ipv4Start = reader.readNode(reader.ipv4Start, 0)
+		}
+	}
+	return reader, nil
+}
+
+// getUint returns the unsigned integer in the decoded value, or 0 if it is not an unsigned integer.
+func getUint(value interface{}) uint {
+	if i, ok := value.(uint64); ok {
+		return uint(i)
+	}
+	return 0
+}
+
+// readNode returns the left (bit 0) or right (bit 1) record of the node with the given index.
+func (reader *Reader) readNode(node uint, bit uint) uint {
+	var offset = node * reader.recordSize / 4
+	var b = reader.buffer[offset : offset+reader.recordSize/4]
+	switch reader.recordSize {
+	case 24:
+		if bit == 0 {
+			return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3])<<16 | uint(b[4])<<8 | uint(b[5])
+	case 28:
+		if bit == 0 {
+			return uint(b[3]&0xf0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0f)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default:
+		if bit == 0 {
+			return uint(binary.BigEndian.Uint32(b[0:4]))
+		}
+		return uint(binary.BigEndian.Uint32(b[4:8]))
+	}
+}
+
+// Lookup returns the record of the network the IP address is in.
+// A bool is returned indicating if a record was found.
+func (reader *Reader) Lookup(ip net.IP) (interface{}, bool, error) {
+	var address = ip.To4()
+	var node uint
+	if address != nil {
+		node = reader.ipv4Start
+	} else if address = ip.To16(); address == nil || reader.ipVersion == 4 {
+		return nil, false, InvalidAddress
+	}
+
+	for i := 0; i < len(address)*8 && node < reader.nodeCount; i++ {
+		node = reader.readNode(node, uint(address[i/8]>>(7-uint(i%8))&1))
+	}
+	if node <= reader.nodeCount {
+		return nil, false, nil
+	}
+	var offset = node - reader.nodeCount - 16
+	if reader.dataOffset+offset >= uint(len(reader.buffer)) {
+		return nil, false, InvalidDatabase
+	}
+	var record, _, err = (&decoder{reader.buffer[reader.dataOffset:]}).decode(offset)
+	return record, err == nil, err
+}
+
+// decoder decodes values in the data section of a MaxMind DB.
+type decoder struct {
+	buffer []byte
+}
+
+// Data types of values in a MaxMind DB.
+const (
+	typeExtended = iota
+	typePointer
+	typeString
+	typeDouble
+	typeBytes
+	typeUint16
+	typeUint32
+	typeMap
+	typeInt32
+	typeUint64
+	typeUint128
+	typeArray
+	typeContainer
+	typeEndMarker
+	typeBoolean
+	typeFloat
+)
+
+// decode decodes the value at the given offset,
+// and returns the value and the offset following the value.
+func (decoder *decoder) decode(offset uint) (interface{}, uint, error) {
+	var b, err = decoder.read(offset, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+	offset++
+	var control = b[0]
+	var dataType = uint(control >> 5)
+
+	if dataType == typePointer {
+		var pointerSize = uint(control>>3&3) + 1
+		b, err := decoder.read(offset, pointerSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		var pointer uint
+		switch pointerSize {
+		case 1:
+			pointer = uint(control&7)<<8 | uint(b[0])
+		case 2:
+			pointer = (uint(control&7)<<16 | uint(b[0])<<8 | uint(b[1])) + 2048
+		case 3:
+			pointer = (uint(control&7)<<24 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])) + 526336
+		default:
+			pointer = uint(binary.BigEndian.Uint32(b))
+		}
+		value, _, err := decoder.decode(pointer)
+		return value, offset + pointerSize, err
+	}
+
+	if dataType == typeExtended {
+		b, err := decoder.read(offset, 1)
+		if err != nil {
+			return nil, 0, err
+		}
+		dataType = 7 + uint(b[0])
+		offset++
+	}
+
+	var size = uint(control & 0x1f)
+	if size >= 29 {
+		var extra = size - 28
+		b, err := decoder.read(offset, extra)
+		if err != nil {
+			return nil, 0, err
+		}
+		offset += extra
+		switch extra {
+		case 1:
+			size = 29 + uint(b[0])
+		case 2:
+			size = 285 + (uint(b[0])<<8 | uint(b[1]))
+		default:
+			size = 65821 + (uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2]))
+		}
+	}
+
+	switch dataType {
+	case typeMap:
+		var m = make(map[string]interface{}, size)
+		for i := uint(0); i < size; i++ {
+			var key, value interface{}
+			if key, offset, err = decoder.decode(offset); err != nil {
+				return nil, 0, err
+			}
+			if value, offset, err = decoder.decode(offset); err != nil {
+				return nil, 0, err
+			}
+			var name, ok = key.(string)
+			if !ok {
+				return nil, 0, InvalidDatabase
+			}
+			m[name] = value
+		}
+		return m, offset, nil
+	case typeArray:
+		var array = make([]interface{}, size)
+		for i := range array {
+			if array[i], offset, err = decoder.decode(offset); err != nil {
+				return nil, 0, err
+			}
+		}
+		return array, offset, nil
+	case typeBoolean:
+		return size != 0, offset, nil
+	}
+
+	b, err = decoder.read(offset, size)
+	if err != nil {
+		return nil, 0, err
+	}
+	offset += size
+	switch dataType {
+	case typeString:
+		return string(b), offset, nil
+	case typeBytes, typeUint128:
+		return b, offset, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, InvalidDatabase
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), offset, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, InvalidDatabase
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(b)), offset, nil
+	case typeUint16, typeUint32, typeUint64:
+		var value uint64
+		for _, c := range b {
+			value = value<<8 | uint64(c)
+		}
+		return value, offset, nil
+	case typeInt32:
+		var value uint32
+		for _, c := range b {
+			value = value<<8 | uint32(c)
+		}
+		return int32(value), offset, nil
+	}
+	return nil, 0, InvalidDatabase
+}
+
+// read returns the given amount of bytes at the offset.
+func (decoder *decoder) read(offset uint, length uint) ([]byte, error) {
+	if offset+length > uint(len(decoder.buffer)) {
+		return nil, InvalidDatabase
+	}
+	return decoder.buffer[offset : offset+length], nil
+}
@@ -0,0 +1,67 @@
+package geoip
+
+import (
+	"net"
+	"testing"
+)
+
+// buildDatabase builds an IPv4 database with 24 bit records,
+// holding a record with country DE for the network 1.0.0.0/8.
+func buildDatabase() []byte {
+	const nodeCount = 8
+	var record = func(value uint) []byte {
+		return []byte{byte(value >> 16), byte(value >> 8), byte(value)}
+	}
+	var buffer []byte
+	for node := uint(0); node < nodeCount; node++ {
+		if node < nodeCount-1 {
+			buffer = append(buffer, record(node+1)...)
+			buffer = append(buffer, record(nodeCount)...)
+		} else {
+			buffer = append(buffer, record(nodeCount)...)
+			buffer = append(buffer, record(nodeCount+16)...)
+		}
+	}
+	buffer = append(buffer, make([]byte, 16)...)
+
+	buffer = append(buffer, 0xe1, 0x47)
+	buffer = append(buffer, "country"...)
+	buffer = append(buffer, 0xe1, 0x48)
+	buffer = append(buffer, "iso_code"...)
+	buffer = append(buffer, 0x42)
+	buffer = append(buffer, "DE"...)
+
+	buffer = append(buffer, metadataMarker...)
+	buffer = append(buffer, 0xe3, 0x4a)
+	buffer = append(buffer, "node_count"...)
+	buffer = append(buffer, 0xc1, nodeCount)
+	buffer = append(buffer, 0x4b)
+	buffer = append(buffer, "record_size"...)
+	buffer = append(buffer, 0xa1, 24)
+	buffer = append(buffer, 0x4a)
+	buffer = append(buffer, "ip_version"...)
+	buffer = append(buffer, 0xa1, 4)
+	return buffer
+}
+
+func TestReaderGetCountry(t *testing.T) {
+	var reader, err = NewReader(buildDatabase())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if country, ok := reader.GetCountry(net.ParseIP("1.2.3.4")); !ok || country != "DE" {
+		t.Errorf("expected country DE, got %q", country)
+	}
+	if _, ok := reader.GetCountry(net.ParseIP("2.2.3.4")); ok {
+		t.Error("expected no country for an address outside the database")
+	}
+	if GetLanguage("de") != "de_DE" {
+		t.Errorf("expected language de_DE, got %q", GetLanguage("de"))
+	}
+}
+
+func TestNewReaderInvalid(t *testing.T) {
+	if _, err := NewReader([]byte("not a database")); err != InvalidDatabase {
+		t.Errorf("expected InvalidDatabase, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package gomine
+
+import (
+	"math"
+
+	"github.com/BobbyShrd/gominetest/effects"
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/players"
+	"github.com/golang/geo/r3"
+)
+
+// SendAbilities sends the abilities of the player of the session to the client.
+func (server *Server) SendAbilities(session *net.MinecraftSession) {
+	var player = session.GetPlayer()
+	var abilities = player.GetAbilities()
+	session.SendAdventureSettings(abilities.GetFlags(), abilities.GetCommandPermissionLevel(), abilities.GetActionPermissions(), abilities.GetPermissionLevel(), player.GetUniqueId())
+}
+
+// SetAbilities sets the abilities of the player of the session, and sends them to the client.
+func (server *Server) SetAbilities(session *net.MinecraftSession, abilities players.Abilities) {
+	session.GetPlayer().SetAbilities(abilities)
+	server.SendAbilities(session)
+}
+
+// SetAllowFlight sets if the player of the session is allowed to fly, and sends it to the client.
+// Flight is reset to the default of the game mode when the player changes worlds.
+func (server *Server) SetAllowFlight(session *net.MinecraftSession, allow bool) {
+	session.GetPlayer().SetAllowFlight(allow)
+	server.SendAbilities(session)
+}
+
+// applyGameModeAbilities sets the abilities of the player of the session to those of the game mode, and sends them.
+// Players in creative may fly, and operators get the operator badge.
+func (server *Server) applyGameModeAbilities(session *net.MinecraftSession, gameMode int32) {
+	var abilities = session.GetPlayer().GetAbilities()
+	abilities.AllowFlight = gameMode == levels.Creative
+	abilities.WorldImmutable = gameMode == levels.Adventure
+	abilities.Operator = session.GetOpLevel() > 0
+	server.SetAbilities(session, abilities)
+}
+
+// handleFlightToggle handles the player of the session starting or stopping to fly.
+// Players that are not allowed to fly get their abilities sent again, which makes the client stop flying.
+func (server *Server) handleFlightToggle(session *net.MinecraftSession, flying bool) {
+	var player = session.GetPlayer()
+	if flying && !player.CanFly() {
+		server.SendAbilities(session)
+		return
+	}
+	player.SetFlying(flying)
+}
+
+const (
+	// FlyingMovementMultiplier is the multiplier of the maximum horizontal movement distance of flying players.
+	FlyingMovementMultiplier = 2.5
+	// JumpVelocity is the vertical velocity in blocks per tick players get when jumping.
+	JumpVelocity = 0.42
+	// JumpBoostVelocity is the vertical velocity added to jumps for every level of jump boost.
+	JumpBoostVelocity = 0.1
+	// LevitationVelocity is the vertical velocity players rise with for every level of levitation.
+	LevitationVelocity = 0.05
+	// Gravity is the vertical velocity in blocks per tick players lose every tick they are in the air.
+	Gravity = 0.08
+	// VerticalDrag is the factor the vertical velocity of players is multiplied with every tick they are in the air.
+	VerticalDrag = 0.98
+	// StepHeight is the height players may walk up without jumping, such as slabs and stairs.
+	StepHeight = 0.6
+	// RiseLeniency is the distance in blocks players may rise more than their vertical velocity allows,
+	// to allow for delayed packets and small differences with the physics of the client.
+	RiseLeniency = 0.1
+)
+
+// climbable are the blocks players may rise in without standing on the ground.
+var climbable = map[string]bool{
+	"ladder":        true,
+	"vine":          true,
+	"water":         true,
+	"flowing_water": true,
+	"lava":          true,
+	"flowing_lava":  true,
+}
+
+// riseState is the vertical movement of a player, as tracked between two movement updates.
+type riseState struct {
+	// airborne is true if the player was neither on the ground nor in a climbable block at the last update.
+	airborne bool
+	// velocity is the vertical velocity in blocks per tick the player may rise with at the next update.
+	// It holds the speed of the last fall while on the ground, as players bounce back on slime blocks.
+	velocity float64
+}
+
+// getMovementDistance returns the maximum horizontal distance the player of the session may move between two movement updates.
+func getMovementDistance(session *net.MinecraftSession) float64 {
+	var distance = session.GetPlayer().GetMaximumMovementDistance()
+	if session.GetPlayer().IsFlying() {
+		distance *= FlyingMovementMultiplier
+	}
+	return distance
+}
+
+// isValidRise checks if the player of the session may rise from the old to the new height in one movement update,
+// and keeps track of its ground state and vertical velocity for the next update.
+// Players on the ground or in a climbable block may jump or step up, while players in the air
+// may only rise as far as the velocity left of their jump, bounce or knockback allows, which gravity lowers every tick.
+// Players that are allowed to fly may rise any distance.
+func (server *Server) isValidRise(session *net.MinecraftSession, oldY float64, newY float64, onGround bool) bool {
+	var player = session.GetPlayer()
+	var name = session.GetName()
+	server.riseMutex.Lock()
+	defer server.riseMutex.Unlock()
+	if player.CanFly() {
+		delete(server.rises, name)
+		return true
+	}
+	var state = server.rises[name]
+	var container = player.GetEffects()
+	var velocity = state.velocity
+	if !state.airborne {
+		velocity = math.Max(velocity, JumpVelocity+JumpBoostVelocity*container.GetLevel(effects.JumpBoost))
+	}
+	velocity = math.Max(velocity, LevitationVelocity*container.GetLevel(effects.Levitation))
+
+	var allowed = velocity
+	if !state.airborne {
+		allowed = math.Max(allowed, StepHeight)
+	}
+	var rise = newY - oldY
+	if rise > allowed+RiseLeniency {
+		return false
+	}
+
+	var world = server.GetDimensionWorld(player.GetDimension())
+	var climbing = climbable[blockNameAt(world, player.Position.X, newY-playerEyeHeight, player.Position.Z)]
+	if onGround || climbing {
+		server.rises[name] = riseState{velocity: math.Max(-rise, 0)}
+		return true
+	}
+	server.rises[name] = riseState{airborne: true, velocity: (math.Min(rise, velocity) - Gravity) * VerticalDrag}
+	return true
+}
+
+// pushPlayer sends the motion to the player of the session, such as knockback,
+// and allows it to rise with the vertical velocity of the motion.
+func (server *Server) pushPlayer(session *net.MinecraftSession, motion r3.Vector) {
+	server.riseMutex.Lock()
+	var state = server.rises[session.GetName()]
+	state.velocity = math.Max(state.velocity, motion.Y)
+	server.rises[session.GetName()] = state
+	server.riseMutex.Unlock()
+	session.SendSetEntityMotion(session.GetPlayer().GetRuntimeId(), motion)
+}
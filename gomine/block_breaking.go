@@ -0,0 +1,105 @@
+package gomine
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/breaking"
+	"github.com/BobbyShrd/gominetest/farming"
+	"github.com/BobbyShrd/gominetest/gamerules"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds/blocks"
+)
+
+// breakStart is the block a player started breaking, and the tick it started breaking it.
+type breakStart struct {
+	position blocks.Position
+	tick     int64
+}
+
+// GetBlockHardness returns the hardness of a block with the given name.
+func GetBlockHardness(name string) float64 {
+	return breaking.DefaultRegistry.Get(name).Hardness
+}
+
+// SetBlockHardness sets the hardness of a block with the given name.
+func SetBlockHardness(name string, hardness float64) {
+	var block = breaking.DefaultRegistry.Get(name)
+	block.Hardness = hardness
+	breaking.DefaultRegistry.Register(name, block)
+}
+
+// GetBreakTime returns the time in ticks it takes to break a block with the given hardness.
+// Blocks break instantly in creative mode, and a break time of -1 is returned for unbreakable blocks.
+func GetBreakTime(hardness float64, creative bool) int64 {
+	if hardness < 0 {
+		return -1
+	}
+	if creative || hardness == 0 {
+		return 0
+	}
+	return int64(math.Ceil(hardness * 1.5 * 20))
+}
+
+// GetPlayerBreakTime returns the time in ticks it takes for the session to break the block with the given name
+// using the item, taking the effects of the player and the game mode of its world into account.
+func (server *Server) GetPlayerBreakTime(session *net.MinecraftSession, blockName string, item *items.Stack) int64 {
+	var block = breaking.DefaultRegistry.Get(blockName)
+	var player = session.GetPlayer()
+	if block.Hardness >= 0 && server.IsCreative(session) {
+		return 0
+	}
+	return block.GetBreakTime(item, player.GetEffects().GetMiningMultiplier())
+}
+
+// StartBreak remembers the session started breaking the block at the position in the current tick.
+func (server *Server) StartBreak(session *net.MinecraftSession, position blocks.Position) {
+	server.breakMutex.Lock()
+	server.breakStarts[session.GetName()] = breakStart{position, server.GetCurrentTick()}
+	server.breakMutex.Unlock()
+}
+
+// AbortBreak forgets the block the session started breaking.
+func (server *Server) AbortBreak(session *net.MinecraftSession) {
+	server.breakMutex.Lock()
+	delete(server.breakStarts, session.GetName())
+	server.breakMutex.Unlock()
+}
+
+// canBreak checks if the session took long enough breaking the block at the position with the item.
+// Breaks faster than the break time, minus the break tolerance of the configuration, are rejected.
+func (server *Server) canBreak(session *net.MinecraftSession, position blocks.Position, blockName string, item *items.Stack) bool {
+	var breakTime = server.GetPlayerBreakTime(session, blockName, item)
+	if breakTime < 0 {
+		return false
+	}
+	server.breakMutex.Lock()
+	var start, ok = server.breakStarts[session.GetName()]
+	delete(server.breakStarts, session.GetName())
+	server.breakMutex.Unlock()
+
+	if !server.Config.ValidateBreaking || breakTime == 0 {
+		return true
+	}
+	if !ok || start.position != position {
+		return false
+	}
+	return float64(server.GetCurrentTick()-start.tick) >= float64(breakTime)*(1-server.Config.BreakTolerance)
+}
+
+// dropBlockItems drops the items of the block with the given name broken at the position by the session using the item.
+// Nothing is dropped in creative mode, or for crops and plants, which are dropped by the farming manager.
+func (server *Server) dropBlockItems(session *net.MinecraftSession, position blocks.Position, blockName string, item *items.Stack) {
+	var dimension = session.GetPlayer().GetDimension()
+	if farming.DropsItems(blockName) || server.IsCreative(session) || !server.IsGameRuleEnabled(dimension.GetLevel(), gamerules.DoTileDrops) {
+		return
+	}
+	var random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	var center = r3.Vector{X: float64(position.X) + 0.5, Y: float64(position.Y) + 0.5, Z: float64(position.Z) + 0.5}
+	for _, stack := range breaking.DefaultRegistry.Get(blockName).GetDrops(item, random, items.DefaultManager) {
+		server.DropItem(dimension, center, stack)
+	}
+}
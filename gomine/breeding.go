@@ -0,0 +1,122 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/ai"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/irmine/worlds"
+	data2 "github.com/irmine/worlds/entities/data"
+)
+
+const (
+	// GrowUpDuration is the amount of ticks it takes babies to grow up.
+	GrowUpDuration = 24000
+	// GrowUpFeedBonus is the part of the time left to grow up that feeding a baby skips.
+	GrowUpFeedBonus = 0.1
+	// BabyScale is the scale of babies compared to adults.
+	BabyScale float32 = 0.5
+)
+
+// breedItems holds the items mobs fall in love with, by legacy entity type ID.
+var breedItems = map[uint32][]string{
+	10: {"minecraft:wheat_seeds", "minecraft:beetroot_seeds"},
+	11: {"minecraft:wheat"},
+	12: {"minecraft:carrot", "minecraft:potato", "minecraft:beetroot"},
+	13: {"minecraft:wheat"},
+	14: {"minecraft:beef", "minecraft:cooked_beef", "minecraft:porkchop", "minecraft:cooked_porkchop", "minecraft:chicken", "minecraft:cooked_chicken"},
+	16: {"minecraft:wheat"},
+	18: {"minecraft:carrot"},
+	75: {"minecraft:cod"},
+}
+
+// RegisterBreedItem makes mobs with the entity type fall in love when fed the item with the given string ID.
+func RegisterBreedItem(entityType uint32, itemId string) {
+	breedItems[entityType] = append(breedItems[entityType], itemId)
+}
+
+// IsBreedItem checks if mobs with the entity type fall in love when fed the item.
+func IsBreedItem(entityType uint32, item *items.Stack) bool {
+	if item == nil || item.Count <= 0 {
+		return false
+	}
+	for _, itemId := range breedItems[entityType] {
+		if item.GetId() == itemId {
+			return true
+		}
+	}
+	return false
+}
+
+// feedAnimal handles the player of the session feeding the item to a mob. Adults fall in love,
+// showing hearts to the viewers of the mob, and babies grow up a little faster.
+// Tameable mobs have to be tamed before they breed. Returns false if the mob did not take the item.
+func (server *Server) feedAnimal(session *net.MinecraftSession, dimension *worlds.Dimension, mob *ai.Mob, item *items.Stack) bool {
+	var entity = mob.GetBody().(mobBody)
+	if !IsBreedItem(entity.GetEntityType(), item) || (IsTameable(entity.GetEntityType()) && !mob.IsTamed()) {
+		return false
+	}
+	if mob.IsBaby() {
+		mob.SetAge(mob.GetAge() - int64(float64(mob.GetAge())*GrowUpFeedBonus))
+	} else if !mob.SetInLove(ai.LoveDuration) {
+		return false
+	}
+	if !server.IsCreative(session) {
+		server.consumeHeldItem(session)
+	}
+	if mob.IsInLove() {
+		entity.BroadcastEvent(EntityEventLoveParticles, 0)
+	}
+	return true
+}
+
+// breed spawns a baby of the species of the parents in between them.
+// No baby is born if the dimension already holds as many mobs as the mob cap of the configuration.
+func (server *Server) breed(dimension *worlds.Dimension, parent *ai.Mob, partner *ai.Mob) {
+	var manager = server.GetMobManager(dimension)
+	if server.Config.MobCap > 0 && len(manager.GetMobs()) >= server.Config.MobCap {
+		return
+	}
+	var position = parent.GetBody().GetPosition().Add(partner.GetBody().GetPosition()).Mul(0.5)
+	var entity = server.SpawnPersistentEntity(dimension, parent.Species, position)
+	if baby, ok := manager.GetMob(entity.GetRuntimeId()); ok {
+		server.makeBaby(baby, GrowUpDuration)
+	}
+}
+
+// makeBaby makes the mob a baby that grows up after the given amount of ticks.
+// The time left to grow up is saved with the entity of the mob.
+func (server *Server) makeBaby(mob *ai.Mob, ticks int64) {
+	var entity = mob.GetBody().(mobBody)
+	mob.SetAge(-ticks)
+	entity.SetSaveFunction(func() {
+		entity.GetMetadata().SetPersistent(mobNamespace, "age", int(mob.GetAge()))
+	})
+	setBabyData(entity.PersistentEntity, true)
+}
+
+// growUp makes the baby mob an adult.
+func (server *Server) growUp(mob *ai.Mob) {
+	var entity = mob.GetBody().(mobBody)
+	entity.SetSaveFunction(nil)
+	entity.GetMetadata().Remove(mobNamespace, "age")
+	setBabyData(entity.PersistentEntity, false)
+}
+
+// loadAge makes the mob a baby again if it was saved as one.
+func (server *Server) loadAge(mob *ai.Mob) {
+	var entity = mob.GetBody().(mobBody)
+	if age, ok := entity.GetMetadata().GetInt(mobNamespace, "age"); ok && age < 0 {
+		server.makeBaby(mob, int64(-age))
+	}
+}
+
+// setBabyData sets the baby flag and the scale of the entity, and broadcasts them to its viewers.
+func setBabyData(entity *PersistentEntity, baby bool) {
+	var scale float32 = 1
+	if baby {
+		scale = BabyScale
+	}
+	entity.SetEntityProperty(data2.EntityDataBaby, baby)
+	entity.SetEntityProperty(data2.EntityDataScale, scale)
+	entity.BroadcastUpdatedEntityData()
+}
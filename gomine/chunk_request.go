@@ -0,0 +1,131 @@
+package gomine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/irmine/worlds/chunks"
+)
+
+// ChunkRequest is a handle to a chunk that is being loaded.
+// It can be polled, waited on, or given callbacks that run once the chunk is loaded.
+type ChunkRequest struct {
+	x, z      int32
+	mutex     sync.Mutex
+	done      chan struct{}
+	chunk     *chunks.Chunk
+	callbacks []func(chunk *chunks.Chunk)
+}
+
+// newChunkRequest returns a new pending request of the chunk at the given chunk coordinates.
+func newChunkRequest(x, z int32) *ChunkRequest {
+	return &ChunkRequest{x: x, z: z, done: make(chan struct{})}
+}
+
+// GetX returns the chunk X coordinate of the requested chunk.
+func (request *ChunkRequest) GetX() int32 {
+	return request.x
+}
+
+// GetZ returns the chunk Z coordinate of the requested chunk.
+func (request *ChunkRequest) GetZ() int32 {
+	return request.z
+}
+
+// Done returns a channel that is closed once the chunk is loaded.
+func (request *ChunkRequest) Done() <-chan struct{} {
+	return request.done
+}
+
+// IsDone checks if the chunk is loaded, without blocking.
+func (request *ChunkRequest) IsDone() bool {
+	select {
+	case <-request.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Get returns the chunk without blocking.
+// A bool is returned which is false if the chunk is not yet loaded.
+func (request *ChunkRequest) Get() (*chunks.Chunk, bool) {
+	if !request.IsDone() {
+		return nil, false
+	}
+	return request.chunk, true
+}
+
+// Wait blocks until the chunk is loaded, and returns it.
+func (request *ChunkRequest) Wait() *chunks.Chunk {
+	<-request.done
+	return request.chunk
+}
+
+// WaitTimeout blocks until the chunk is loaded or the timeout passed.
+// A bool is returned which is false if the timeout passed before the chunk was loaded.
+func (request *ChunkRequest) WaitTimeout(timeout time.Duration) (*chunks.Chunk, bool) {
+	var timer = time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-request.done:
+		return request.chunk, true
+	case <-timer.C:
+		return nil, false
+	}
+}
+
+// Then adds a function that gets called with the chunk once it is loaded.
+// The function is called immediately if the chunk is already loaded.
+// Functions are called on the goroutine that loaded the chunk, and should not block.
+func (request *ChunkRequest) Then(function func(chunk *chunks.Chunk)) {
+	request.mutex.Lock()
+	if !request.IsDone() {
+		request.callbacks = append(request.callbacks, function)
+		request.mutex.Unlock()
+		return
+	}
+	request.mutex.Unlock()
+	function(request.chunk)
+}
+
+// complete completes the request with the loaded chunk, and calls all its callbacks.
+func (request *ChunkRequest) complete(chunk *chunks.Chunk) {
+	request.mutex.Lock()
+	request.chunk = chunk
+	close(request.done)
+	var callbacks = request.callbacks
+	request.callbacks = nil
+	request.mutex.Unlock()
+
+	for _, callback := range callbacks {
+		callback(chunk)
+	}
+}
+
+// chunkKey identifies a chunk in a dimension by its chunk coordinates.
+type chunkKey struct {
+	x, z int32
+}
+
+// RequestChunk requests the chunk at the given chunk coordinates to be loaded,
+// and returns a handle to it. The chunk is generated if it does not yet exist.
+// Requests made for the same chunk while it is loading share the same handle.
+func (world *DimensionWorld) RequestChunk(x, z int32) *ChunkRequest {
+	world.requestMutex.Lock()
+	if request, ok := world.requests[chunkKey{x, z}]; ok {
+		world.requestMutex.Unlock()
+		return request
+	}
+	var request = newChunkRequest(x, z)
+	world.requests[chunkKey{x, z}] = request
+	world.requestMutex.Unlock()
+
+	world.loadChunk(x, z, func(chunk *chunks.Chunk) {
+		world.requestMutex.Lock()
+		delete(world.requests, chunkKey{x, z})
+		world.requestMutex.Unlock()
+		request.complete(chunk)
+	})
+	return request
+}
@@ -0,0 +1,92 @@
+package gomine
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/BobbyShrd/gominetest/blockentities"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/text"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+)
+
+// SignBlocks are the names of all blocks that can be used as command signs.
+var SignBlocks = []string{"standing_sign", "wall_sign"}
+
+// CommandSignManager keeps track of signs that execute a command when clicked.
+// A sign is a command sign if its first line matches the configured pattern,
+// in which case the remaining lines form the command executed as the clicking player.
+// The command is kept in the block entity of the sign, so that command signs are saved with the block entities of their dimension.
+type CommandSignManager struct {
+	server  *Server
+	pattern *regexp.Regexp
+}
+
+// NewCommandSignManager returns a new command sign manager using the given pattern.
+// Signs can not be turned into command signs if the pattern is empty or invalid.
+func NewCommandSignManager(server *Server, pattern string) *CommandSignManager {
+	var manager = &CommandSignManager{server: server}
+	if pattern != "" {
+		var expression, err = regexp.Compile(pattern)
+		text.DefaultLogger.LogError(err)
+		manager.pattern = expression
+	}
+	server.InteractionRegistry.RegisterBlockHandler(manager.handleClick, SignBlocks...)
+	return manager
+}
+
+// IsCommandSign checks if the sign at the given position in the dimension is a command sign.
+func (manager *CommandSignManager) IsCommandSign(dimension *worlds.Dimension, position blocks.Position) bool {
+	var _, ok = manager.GetCommand(dimension, position)
+	return ok
+}
+
+// GetCommand returns the command bound to the sign at the given position in the dimension.
+// A bool is returned indicating if the sign is a command sign.
+func (manager *CommandSignManager) GetCommand(dimension *worlds.Dimension, position blocks.Position) (string, bool) {
+	var blockEntity, _ = manager.server.GetBlockEntityManager(dimension).Get(position)
+	if sign, ok := blockEntity.(*blockentities.Sign); ok && sign.Command != "" {
+		return sign.Command, true
+	}
+	return "", false
+}
+
+// ParseSign returns the command of the sign text written by the session, or an empty string if the sign is no command sign.
+// The sign is a command sign if the first line matches the pattern,
+// and the session has permission to create command signs.
+func (manager *CommandSignManager) ParseSign(session *net.MinecraftSession, signText string) string {
+	if manager.pattern == nil {
+		return ""
+	}
+
+	var lines = strings.Split(signText, "\n")
+	if !manager.pattern.MatchString(text.ColoredString(lines[0]).StripAll()) {
+		return ""
+	}
+	if !session.HasPermission("gomine.commandsign.create") {
+		session.SendMessage(text.NewComponent().Color(text.Red).Text("You do not have permission to create command signs."))
+		return ""
+	}
+
+	var command = strings.TrimSpace(text.ColoredString(strings.Join(lines[1:], " ")).StripAll())
+	if command == "" {
+		session.SendMessage(text.NewComponent().Color(text.Red).Text("Command signs need a command on the lines below the first."))
+		return ""
+	}
+	session.SendMessage(text.NewComponent().Color(text.Yellow).Text("Command sign created for: ").Reset().Text(command))
+	return command
+}
+
+// handleClick executes the command of a command sign as the clicking session.
+// The permission of the command is checked against the clicking session as usual.
+func (manager *CommandSignManager) handleClick(session *net.MinecraftSession, position blocks.Position, _ string) bool {
+	var command, ok = manager.GetCommand(session.GetPlayer().GetDimension(), position)
+	if !ok {
+		return false
+	}
+	if !manager.server.DispatchCommand(session, command) {
+		session.SendMessage(text.NewComponent().Color(text.Red).Text("Command could not be found."))
+	}
+	return true
+}
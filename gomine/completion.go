@@ -0,0 +1,79 @@
+package gomine
+
+import (
+	"strings"
+
+	"github.com/BobbyShrd/gominetest/commands"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/packets/data"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+const (
+	// MaxSuggestions is the maximum amount of suggestions sent to a player after an invalid command.
+	MaxSuggestions = 5
+	// PlayerNamesEnum is the name of the soft enum holding the names of all online players,
+	// which clients complete target arguments with.
+	PlayerNamesEnum = "PlayerNames"
+)
+
+// CompleteCommand returns the completions of a command line typed by the sender, as complete lines.
+// Commands, enum arguments and target arguments are completed, the latter with the names of all online players.
+func (server *Server) CompleteCommand(sender commands.Sender, line string) []string {
+	var sessions = server.SessionManager.GetSessions()
+	var names = make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		names = append(names, session.GetName())
+	}
+	return server.CommandManager.CompleteLine(sender, line, names)
+}
+
+// completeConsoleLine returns the completions of a line typed in the console.
+func (server *Server) completeConsoleLine(line string) []string {
+	return server.CompleteCommand(server.ConsoleSender, line)
+}
+
+// suggestCommands sends the session the completions of the command text it sent, if the command was invalid.
+// The last word of the command is completed, so that typing part of a command or argument shows what it could be.
+func (server *Server) suggestCommands(session *net.MinecraftSession, commandText string) {
+	var suggestions = server.CompleteCommand(session, commandText)
+	if len(suggestions) == 0 {
+		return
+	}
+	if len(suggestions) > MaxSuggestions {
+		suggestions = suggestions[:MaxSuggestions]
+	}
+	for i, suggestion := range suggestions {
+		suggestions[i] = "/" + strings.TrimPrefix(suggestion, "/")
+	}
+	session.SendMessage(text.NewComponent().Color(text.Yellow).Text("Did you mean: ").Reset().Text(strings.Join(suggestions, ", ")))
+}
+
+// sendPlayerNames sets the player names soft enum of the session to the names of its own player and all spawned players.
+func (server *Server) sendPlayerNames(session *net.MinecraftSession) {
+	var names = []string{session.GetName()}
+	for _, online := range server.SessionManager.GetSessions() {
+		if online != session && online.HasSpawned() {
+			names = append(names, online.GetName())
+		}
+	}
+	session.SendUpdateSoftEnum(PlayerNamesEnum, names, data.SoftEnumSet)
+}
+
+// addPlayerName adds the name of the player of the joining session to the player names soft enum of all other spawned players.
+func (server *Server) addPlayerName(session *net.MinecraftSession) {
+	for _, online := range server.SessionManager.GetSessions() {
+		if online != session && online.HasSpawned() {
+			online.SendUpdateSoftEnum(PlayerNamesEnum, []string{session.GetName()}, data.SoftEnumAdd)
+		}
+	}
+}
+
+// removePlayerName removes the name of the player of the leaving session from the player names soft enum of all other spawned players.
+func (server *Server) removePlayerName(session *net.MinecraftSession) {
+	for _, online := range server.SessionManager.GetSessions() {
+		if online != session && online.HasSpawned() {
+			online.SendUpdateSoftEnum(PlayerNamesEnum, []string{session.GetName()}, data.SoftEnumRemove)
+		}
+	}
+}
@@ -0,0 +1,399 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/blockentities"
+	"github.com/BobbyShrd/gominetest/combat"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/packets/types"
+	"github.com/BobbyShrd/gominetest/windows"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+)
+
+// ItemFrameBlock is the name of item frame blocks.
+const ItemFrameBlock = "frame"
+
+// hopperFacingMask is the mask of the face a hopper outputs to in the block data of hoppers.
+const hopperFacingMask = 0x07
+
+// ContainerBlocks maps the names of blocks that can be opened to the save IDs of their block entities.
+var ContainerBlocks = map[string]string{
+	"chest":         blockentities.ChestId,
+	"trapped_chest": blockentities.ChestId,
+	"furnace":       blockentities.FurnaceId,
+	"lit_furnace":   blockentities.FurnaceId,
+	"hopper":        blockentities.HopperId,
+}
+
+// registerContainers registers the interactions opening containers,
+// and makes hoppers use the transfer cooldown of the configuration.
+func (server *Server) registerContainers() {
+	var names = make([]string, 0, len(ContainerBlocks))
+	for name := range ContainerBlocks {
+		names = append(names, name)
+	}
+	server.InteractionRegistry.RegisterBlockHandler(server.openContainer, names...)
+	blockentities.Register(blockentities.HopperId, func(position blocks.Position) blockentities.Serializable {
+		return server.NewHopper(position, blockentities.FaceDown)
+	})
+}
+
+// getContainer returns the container of the block with the given name at the position in the dimension.
+// The block entity of the container is created if it did not yet exist,
+// in which case new chests get paired with a chest next to them.
+// A bool is returned which is false if the block has no container.
+func (server *Server) getContainer(dimension *worlds.Dimension, position blocks.Position, blockName string) (blockentities.Container, bool) {
+	var manager = server.GetBlockEntityManager(dimension)
+	if _, ok := manager.Get(position); !ok {
+		var blockEntity, ok = blockentities.New(ContainerBlocks[blockName], position)
+		if !ok {
+			return nil, false
+		}
+		if hopper, ok := blockEntity.(*blockentities.Hopper); ok {
+			hopper.SetFacing(server.GetDimensionWorld(dimension).GetBlockData(position) & hopperFacingMask)
+		}
+		manager.Add(blockEntity)
+		if chest, ok := blockEntity.(*blockentities.Chest); ok {
+			if pair := manager.PairChest(chest); pair != nil {
+				server.UpdateBlockEntity(dimension, pair)
+			}
+		}
+		server.UpdateBlockEntity(dimension, blockEntity)
+	}
+	if chest, ok := manager.GetChest(position); ok {
+		return chest, true
+	}
+	return manager.GetContainer(position)
+}
+
+// openContainer opens a window of the container at the position for the session.
+// Any window the session had opened before gets closed.
+func (server *Server) openContainer(session *net.MinecraftSession, position blocks.Position, blockName string) bool {
+	var container, ok = server.getContainer(session.GetPlayer().GetDimension(), position, blockName)
+	if !ok {
+		return false
+	}
+	server.CloseWindow(session)
+	var window = server.WindowManager.Open(session.GetName(), container)
+	session.SendContainerOpen(window.GetId(), window.GetType(), position)
+	session.SendInventoryContent(uint32(window.GetId()), windows.GetContents(container))
+	return true
+}
+
+// CloseWindow closes the container window opened by the session, if it has one.
+// Trading windows are closed as well.
+func (server *Server) CloseWindow(session *net.MinecraftSession) {
+	server.closeTrade(session)
+	if window, ok := server.WindowManager.Close(session.GetName()); ok {
+		session.SendContainerClose(window.GetId())
+	}
+}
+
+// UIWindowId is the window ID of the UI inventory of a player. Its first slot is the cursor.
+const UIWindowId uint32 = 124
+
+// slotHolder is an inventory held by the server, of which the slots may be changed by inventory actions.
+type slotHolder interface {
+	GetSize() int
+	GetItem(slot int) *items.Stack
+	SetItem(slot int, stack *items.Stack)
+}
+
+// slotChange is an inventory action changing a slot of an inventory held by the server.
+type slotChange struct {
+	holder slotHolder
+	slot   int
+	item   *items.Stack
+}
+
+// balanceEntry is the amount of items of one kind taken out of slots during a transaction,
+// minus the amount of items of that kind put into slots.
+type balanceEntry struct {
+	kind  *items.Stack
+	count int
+}
+
+// itemBalance counts the items moved during an inventory transaction, by kind of item.
+// Items are conserved by the transaction if the balance of every kind ends at zero.
+type itemBalance []balanceEntry
+
+// take counts the items of the stack as taken out of a slot.
+func (balance *itemBalance) take(stack *items.Stack) {
+	balance.add(stack, 1)
+}
+
+// put counts the items of the stack as put into a slot.
+func (balance *itemBalance) put(stack *items.Stack) {
+	balance.add(stack, -1)
+}
+
+// add adds the count of the stack multiplied by sign to the entry of its kind.
+func (balance *itemBalance) add(stack *items.Stack, sign int) {
+	if stack == nil || stack.Count <= 0 {
+		return
+	}
+	for i, entry := range *balance {
+		if sameKind(entry.kind, stack) {
+			(*balance)[i].count += sign * stack.Count
+			return
+		}
+	}
+	*balance = append(*balance, balanceEntry{stack, sign * stack.Count})
+}
+
+// isBalanced checks if as many items of every kind were put into slots as were taken out.
+func (balance itemBalance) isBalanced() bool {
+	for _, entry := range balance {
+		if entry.count != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// handleInventoryActions handles an inventory transaction of the session, changing its inventory, its armor,
+// its offhand, its cursor and the container it opened, and dropping items thrown into the world.
+// The transaction is rejected and the contents are sent again if it does not hold up against the server-side contents.
+func (server *Server) handleInventoryActions(session *net.MinecraftSession, actions []types.InventoryAction) {
+	var changes, drops, offers, ok = server.validateTransaction(session, actions)
+	if !ok {
+		server.resendWindows(session)
+		return
+	}
+	var window, hasWindow = server.WindowManager.Get(session.GetName())
+	for _, change := range changes {
+		change.holder.SetItem(change.slot, change.item)
+		if hasWindow && change.holder == slotHolder(window.GetContainer()) {
+			server.broadcastSlot(session, window, uint32(change.slot), change.item)
+		}
+	}
+	var player = session.GetPlayer()
+	for _, drop := range drops {
+		server.DropItem(player.GetDimension(), player.Position, drop)
+	}
+	server.completeTrades(session, offers)
+}
+
+// validateTransaction checks the actions of an inventory transaction of the session as a whole.
+// The old item of every changed slot has to match the server-side contents, no slot may be changed twice,
+// and the items taken out of slots have to equal the items put into slots or dropped, by kind and count.
+// Players in creative may take items from and put items into the creative inventory, which is not counted.
+// Taking the output of a trading window consumes the items bought from the trade inputs.
+// The slot changes, the dropped items and the indices of the offers traded are returned,
+// with a bool which is false if the transaction is invalid.
+func (server *Server) validateTransaction(session *net.MinecraftSession, actions []types.InventoryAction) ([]slotChange, []*items.Stack, []int, bool) {
+	var changes []slotChange
+	var drops []*items.Stack
+	var outputs []types.InventoryAction
+	var balance itemBalance
+	var changed = make(map[slotHolder]map[int]bool)
+	for _, action := range actions {
+		if action.NewItem != nil && action.NewItem.Count > action.NewItem.GetMaximumStackSize() {
+			return nil, nil, nil, false
+		}
+		switch action.Source {
+		case types.SourceContainer, types.SourceTodo:
+			if action.WindowId == TradeUseInputsWindowId {
+				// The items bought are consumed by the server once the output is taken.
+				continue
+			}
+			if action.WindowId == TradeOutputWindowId {
+				outputs = append(outputs, action)
+				continue
+			}
+			var holder, ok = server.getSlotHolder(session, action)
+			var slot = int(action.Slot)
+			if !ok || slot >= holder.GetSize() || changed[holder][slot] || !sameItem(holder.GetItem(slot), action.OldItem) {
+				return nil, nil, nil, false
+			}
+			if changed[holder] == nil {
+				changed[holder] = make(map[int]bool)
+			}
+			changed[holder][slot] = true
+			balance.take(action.OldItem)
+			balance.put(action.NewItem)
+			changes = append(changes, slotChange{holder, slot, action.NewItem})
+		case types.SourceWorld:
+			// Items may only be thrown into the world, never taken out of it.
+			if action.OldItem != nil && action.OldItem.Count > 0 {
+				return nil, nil, nil, false
+			}
+			if action.NewItem != nil && action.NewItem.Count > 0 {
+				balance.put(action.NewItem)
+				drops = append(drops, action.NewItem)
+			}
+		case types.SourceCreative:
+			if !server.IsCreative(session) {
+				return nil, nil, nil, false
+			}
+		}
+	}
+	var offers []int
+	for _, output := range outputs {
+		var offer, ok = server.validateTradeOutput(session, output, &changes, &balance)
+		if !ok {
+			return nil, nil, nil, false
+		}
+		offers = append(offers, offer)
+	}
+	return changes, drops, offers, balance.isBalanced()
+}
+
+// getSlotHolder returns the server-side inventory of the window changed by the action of the session:
+// its inventory, armor, offhand, cursor, the container it opened or an input of its trading window.
+// A bool is returned which is false if the window is not held by the server, or if the item put into an armor slot can not be worn in it.
+func (server *Server) getSlotHolder(session *net.MinecraftSession, action types.InventoryAction) (slotHolder, bool) {
+	var windowId = action.WindowId
+	if action.Source == types.SourceTodo {
+		if input, ok := server.getTradeInput(session, windowId); ok {
+			return input, true
+		}
+		return nil, false
+	}
+	var player = session.GetPlayer()
+	switch windowId {
+	case int32(InventoryWindowId):
+		return player.GetInventory(), true
+	case int32(ArmorWindowId):
+		// Only pieces of armor worn in a slot may be put into that slot.
+		if action.NewItem != nil && action.NewItem.Count > 0 {
+			if armor, ok := combat.GetArmor(action.NewItem); !ok || armor.Slot != int(action.Slot) {
+				return nil, false
+			}
+		}
+		return player.GetArmor(), true
+	case int32(OffhandWindowId):
+		return player.GetOffhand(), true
+	case int32(UIWindowId):
+		return player.GetCursor(), true
+	}
+	if window, ok := server.WindowManager.Get(session.GetName()); ok && windowId == int32(window.GetId()) {
+		return window.GetContainer(), true
+	}
+	return nil, false
+}
+
+// broadcastSlot sends a changed slot of the container opened by the session
+// to all other players viewing the same container.
+func (server *Server) broadcastSlot(session *net.MinecraftSession, window *windows.Window, slot uint32, item *items.Stack) {
+	var dimension = session.GetPlayer().GetDimension()
+	for viewer, viewerWindow := range server.WindowManager.GetViewers(window.GetPosition()) {
+		if viewer == session.GetName() {
+			continue
+		}
+		if online, ok := server.SessionManager.GetSession(viewer); ok && online.GetPlayer().GetDimension() == dimension {
+			online.SendInventorySlot(uint32(viewerWindow.GetId()), slot, item)
+		}
+	}
+}
+
+// resendWindows sends the contents of the inventory, the armor, the offhand, the cursor and the opened container of the session again,
+// undoing changes made by the client.
+func (server *Server) resendWindows(session *net.MinecraftSession) {
+	session.SendInventoryContent(InventoryWindowId, session.GetPlayer().GetInventory().GetContents())
+	session.SendInventoryContent(ArmorWindowId, session.GetPlayer().GetArmor().GetContents())
+	session.SendInventoryContent(OffhandWindowId, session.GetPlayer().GetOffhand().GetContents())
+	session.SendInventoryContent(UIWindowId, session.GetPlayer().GetCursor().GetContents())
+	if window, ok := server.WindowManager.Get(session.GetName()); ok {
+		session.SendInventoryContent(uint32(window.GetId()), windows.GetContents(window.GetContainer()))
+	}
+}
+
+// closeDistantWindows closes the windows of players too far away from the container they opened,
+// or of which the container no longer exists.
+func (server *Server) closeDistantWindows() {
+	for viewer, window := range server.WindowManager.GetAll() {
+		var session, ok = server.SessionManager.GetSession(viewer)
+		if !ok {
+			continue
+		}
+		var player = session.GetPlayer()
+		if player.GetDimension() == nil {
+			continue
+		}
+		var position = window.GetPosition()
+		var center = r3.Vector{X: float64(position.X) + 0.5, Y: float64(position.Y) + 0.5, Z: float64(position.Z) + 0.5}
+		var _, exists = server.GetBlockEntityManager(player.GetDimension()).Get(position)
+		if !exists || player.Position.Sub(center).Norm2() > windows.MaxDistance*windows.MaxDistance {
+			server.CloseWindow(session)
+		}
+	}
+}
+
+// UseItemFrame puts a single item of the stack held by the player in the item frame at the position,
+// or rotates the item in the frame if it already holds one.
+// Returns false if the block at the position has a block entity other than an item frame.
+func (server *Server) UseItemFrame(session *net.MinecraftSession, position blocks.Position) bool {
+	var dimension = session.GetPlayer().GetDimension()
+	var manager = server.GetBlockEntityManager(dimension)
+	var blockEntity, ok = manager.Get(position)
+	if !ok {
+		blockEntity = blockentities.NewItemFrame(position)
+		manager.Add(blockEntity)
+	}
+	var frame, isFrame = blockEntity.(*blockentities.ItemFrame)
+	if !isFrame {
+		return false
+	}
+
+	if frame.Item != nil {
+		frame.Rotate()
+	} else if item := session.GetPlayer().GetHeldItem(); item != nil && item.Count > 0 {
+		var single = *item
+		single.Count = 1
+		frame.Item, frame.Rotation = &single, 0
+		if !server.IsCreative(session) {
+			server.consumeHeldItem(session)
+		}
+	}
+	server.UpdateBlockEntity(dimension, frame)
+	return true
+}
+
+// breakBlockEntity removes the block entity at the position in the dimension once its block gets broken.
+// The items held by a container or an item frame are dropped in the center of the block.
+func (server *Server) breakBlockEntity(dimension *worlds.Dimension, position blocks.Position) {
+	var manager = server.GetBlockEntityManager(dimension)
+	var blockEntity, ok = manager.Get(position)
+	if !ok {
+		return
+	}
+	manager.Remove(position)
+
+	var drops []*items.Stack
+	switch blockEntity := blockEntity.(type) {
+	case blockentities.Container:
+		for slot := 0; slot < blockEntity.GetSize(); slot++ {
+			if stack := blockEntity.GetItem(slot); stack != nil {
+				drops = append(drops, stack)
+				blockEntity.SetItem(slot, nil)
+			}
+		}
+	case *blockentities.ItemFrame:
+		if blockEntity.Item != nil {
+			drops = append(drops, blockEntity.Item)
+			blockEntity.Item = nil
+		}
+	}
+	var center = r3.Vector{X: float64(position.X) + 0.5, Y: float64(position.Y) + 0.5, Z: float64(position.Z) + 0.5}
+	for _, stack := range drops {
+		server.DropItem(dimension, center, stack)
+	}
+}
+
+// sameKind checks if two item stacks hold the same kind of item, regardless of their count.
+func sameKind(a *items.Stack, b *items.Stack) bool {
+	return a.Type.Equals(b.Type) && a.Durability == b.Durability && a.DisplayName == b.DisplayName && a.EqualsLore(b) && a.EqualsEnchantments(b)
+}
+
+// sameItem checks if two item stacks hold the same amount of the same item.
+// Empty stacks and nil are considered the same.
+func sameItem(a *items.Stack, b *items.Stack) bool {
+	var aEmpty, bEmpty = a == nil || a.Count <= 0, b == nil || b.Count <= 0
+	if aEmpty || bEmpty {
+		return aEmpty == bEmpty
+	}
+	return a.Equals(b)
+}
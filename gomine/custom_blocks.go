@@ -0,0 +1,104 @@
+package gomine
+
+import (
+	"errors"
+
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/info"
+	"github.com/BobbyShrd/gominetest/net/packets/types"
+	"github.com/BobbyShrd/gominetest/palette"
+	"github.com/BobbyShrd/gominetest/waterlog"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+	"github.com/irmine/worlds/chunks"
+	utils2 "github.com/irmine/worlds/utils"
+)
+
+var NoBlockPalette = errors.New("custom blocks require a block palette in the block_palettes directory")
+
+// RegisterCustomBlock registers a custom block, so that it can be placed with PlaceState.
+// Custom blocks must be registered before players join, since their definitions are sent when players join,
+// and the resource pack with their geometry and textures must be loaded as well.
+func (server *Server) RegisterCustomBlock(block palette.CustomBlock) error {
+	if server.BlockPalettes.IsEmpty() {
+		return NoBlockPalette
+	}
+	return server.BlockPalettes.RegisterCustomBlock(block)
+}
+
+// SetCustomBlockStore makes the states of custom blocks placed in the dimension persist in the given directory,
+// saving them with the chunk they are in.
+func (server *Server) SetCustomBlockStore(dimension *worlds.Dimension, directory string) {
+	server.GetDimensionWorld(dimension).custom = palette.NewLayer(directory)
+}
+
+// loadCustomStates loads the custom states of the chunk with the given coordinates into the dimension,
+// if the chunk was not loaded before.
+func (world *DimensionWorld) loadCustomStates(chunkX, chunkZ int32) error {
+	var states, err = world.custom.LoadChunk(chunkX, chunkZ, world.palettes)
+	var blockPalette, ok = world.palettes.Get(int32(info.LatestProtocol))
+	if !ok {
+		return err
+	}
+	for position, state := range states {
+		if runtimeId, ok := blockPalette.GetRuntimeId(state); ok {
+			world.dimension.SetBlockAt(utils2.PositionToVector(position), blocks.New(blocks.NewBlockState(state.Name, int32(runtimeId), 0, 0)))
+		}
+	}
+	return err
+}
+
+// sendChunkCustomStates sends the custom states in a chunk loaded by the session,
+// since the chunk itself only holds their legacy ID.
+func (server *Server) sendChunkCustomStates(session *net.MinecraftSession, chunk *chunks.Chunk) {
+	var world = server.GetDimensionWorld(session.GetPlayer().GetDimension())
+	var blockPalette, ok = world.palettes.Get(int32(info.LatestProtocol))
+	if !ok {
+		return
+	}
+	for position, state := range world.custom.GetInChunk(chunk.X, chunk.Z) {
+		if runtimeId, ok := blockPalette.GetRuntimeId(state); ok {
+			session.SendUpdateBlock(position, runtimeId, waterlog.LayerBlock)
+		}
+	}
+}
+
+// getBlockProperties returns the definitions of the custom blocks sent in the start game packet.
+func (server *Server) getBlockProperties() []types.BlockProperty {
+	var customBlocks = server.BlockPalettes.GetCustomBlocks()
+	var properties = make([]types.BlockProperty, len(customBlocks))
+	for i, block := range customBlocks {
+		properties[i] = types.BlockProperty{Name: block.Identifier, Properties: block.GetDefinition()}
+	}
+	return properties
+}
+
+// PlaceState places the block state at the given position, which may be the state of a custom block.
+// Returns false if the block palette of the latest protocol does not hold the state,
+// or if the position is outside of the height range of the world.
+func (world *DimensionWorld) PlaceState(position blocks.Position, state palette.State) bool {
+	if !world.isInRange(position) {
+		return false
+	}
+	var blockPalette, ok = world.palettes.Get(int32(info.LatestProtocol))
+	if !ok {
+		return false
+	}
+	runtimeId, ok := blockPalette.GetRuntimeId(state)
+	if !ok {
+		return false
+	}
+	var id, data = int32(state.LegacyId), int32(state.LegacyData)
+	if id < 0 {
+		// Chunks save custom states as air, so the state itself is kept in the custom layer.
+		id, data = 0, 0
+		world.custom.Set(position, state)
+	} else {
+		world.custom.Remove(position)
+	}
+	world.SetWaterlogged(position, waterlog.Place(state.Name, world.GetBlockName(position), world.GetBlockData(position), world.IsWaterlogged(position)))
+	world.dimension.SetBlockAt(utils2.PositionToVector(position), blocks.New(blocks.NewBlockState(state.Name, int32(runtimeId), id, data)))
+	world.MarkDirty(position)
+	world.broadcastUpdateBlock(position, runtimeId)
+	return true
+}
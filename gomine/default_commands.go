@@ -1,17 +1,31 @@
 package gomine
 
 import (
+	"github.com/BobbyShrd/gominetest/chat"
+	"github.com/BobbyShrd/gominetest/combat"
 	"github.com/BobbyShrd/gominetest/commands"
+	"github.com/BobbyShrd/gominetest/commands/arguments"
+	"github.com/BobbyShrd/gominetest/forms"
+	"github.com/BobbyShrd/gominetest/gamerules"
+	"github.com/BobbyShrd/gominetest/levels"
 	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/particles"
+	"github.com/BobbyShrd/gominetest/permissions"
+	"github.com/BobbyShrd/gominetest/selectors"
+	"github.com/BobbyShrd/gominetest/sounds"
 	"github.com/BobbyShrd/gominetest/text"
+	"github.com/BobbyShrd/gominetest/weather"
+	"github.com/golang/geo/r3"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func NewTest(_ *Server) *commands.Command {
-	cmd := commands.NewCommand("chunk", "Lists the current chunk", "none", []string{}, func(sender commands.Sender) {
+	cmd := commands.NewCommand("chunk", "Lists the current chunk", "none", []string{}, func(sender commands.Sender, output *commands.Output) {
 		if session, ok := sender.(*net.MinecraftSession); ok {
 			text.DefaultLogger.Debug(session.GetPlayer().GetChunk().X, session.GetPlayer().GetChunk().Z)
-			session.SendMessage(session.GetPlayer().GetChunk().X, session.GetPlayer().GetChunk().Z)
+			output.Print(session.GetPlayer().GetChunk().X, session.GetPlayer().GetChunk().Z)
 		}
 	})
 	cmd.ExemptFromPermissionCheck(true)
@@ -19,28 +33,29 @@ func NewTest(_ *Server) *commands.Command {
 }
 
 func NewList(server *Server) *commands.Command {
-	var list = commands.NewCommand("list", "Lists all players online", "gomine.list", []string{}, func(sender commands.Sender) {
+	var list = commands.NewCommand("list", "Lists all players online", "gomine.list", []string{}, func(sender commands.Sender, output *commands.Output) {
 		var s = "s"
 		if len(server.SessionManager.GetSessions()) == 1 {
 			s = ""
 		}
 
-		var playerList = text.BrightGreen + "-----" + text.White + " Player List (" + strconv.Itoa(len(server.SessionManager.GetSessions())) + " Player" + s + ") " + text.BrightGreen + "-----\n"
+		var locale = getLocale(sender)
+		var playerList = text.NewComponent().Color(text.BrightGreen).Text("-----").Color(text.White).Text(" Player List (" + locale.FormatInt(int64(len(server.SessionManager.GetSessions()))) + " Player" + s + ") ").Color(text.BrightGreen).Text("-----").Newline()
 		for name, player := range server.SessionManager.GetSessions() {
-			playerList += text.BrightGreen + name + ": " + text.Yellow + text.Bold + strconv.Itoa(int(player.GetPing())) + "ms" + text.Reset + "\n"
+			playerList.Color(text.BrightGreen).Text(name + ": ").Color(text.Yellow).Bold().Text(locale.FormatInt(int64(player.GetPing())) + "ms").Reset().Newline()
 		}
-		sender.SendMessage(playerList)
+		output.Print(playerList)
 	})
 	list.ExemptFromPermissionCheck(true)
 	return list
 }
 
 func NewPing() *commands.Command {
-	var ping = commands.NewCommand("ping", "Returns your latency", "gomine.ping", []string{}, func(sender commands.Sender) {
+	var ping = commands.NewCommand("ping", "Returns your latency", "gomine.ping", []string{}, func(sender commands.Sender, output *commands.Output) {
 		if session, ok := sender.(*net.MinecraftSession); ok {
-			session.SendMessage(text.Yellow+"Your current latency/ping is:", session.GetPing())
+			output.Print(text.Yellow+"Your current latency/ping is:", session.GetPing())
 		} else {
-			sender.SendMessage(text.Red + "Please run this command as a player.")
+			output.Error("Please run this command as a player.")
 		}
 	})
 	ping.ExemptFromPermissionCheck(true)
@@ -49,10 +64,594 @@ func NewPing() *commands.Command {
 
 func NewStop(server *Server) *commands.Command {
 	return commands.NewCommand("stop", "Stops the server", "gomine.stop", []string{"shutdown"}, func() {
-		for _, session := range server.SessionManager.GetSessions() {
-			session.Kick("Server Stopped", false, true)
+		server.Shutdown()
+	})
+}
+
+func NewRestart(server *Server) *commands.Command {
+	return commands.NewCommand("restart", "Restarts the server", "gomine.restart", []string{}, func(output *commands.Output) {
+		if err := server.Restart(); err != nil {
+			output.Error("Could not restart the server:", err)
 		}
+	})
+}
 
-		server.Shutdown()
+func NewSaveAll(server *Server) *commands.Command {
+	return commands.NewCommand("save-all", "Saves all worlds and player data", "gomine.save", []string{}, func(sender commands.Sender, output *commands.Output) {
+		if !server.SaveAll(func(failed int) {
+			if failed != 0 {
+				sender.SendMessage("Saving failed for", failed, "operations, see the log for details.")
+				return
+			}
+			sender.SendMessage("Saved the game.")
+		}) {
+			output.Error("A save is already in progress.")
+			return
+		}
+		output.Print("Saving...")
+	})
+}
+
+func NewChannel(server *Server) *commands.Command {
+	var channel = commands.NewCommand("channel", "Switches the chat channel you are chatting in", "gomine.channel", []string{"ch"}, func(sender commands.Sender, output *commands.Output, name string) {
+		if session, ok := sender.(*net.MinecraftSession); ok {
+			var err = server.ChatManager.SetChannel(session, name)
+			if err == chat.NoChannelPermission {
+				output.Error("You do not have permission to chat in this channel.")
+				return
+			}
+			if err != nil {
+				var names []string
+				for channelName := range server.ChatManager.GetChannels() {
+					names = append(names, channelName)
+				}
+				output.Error("Unknown channel. Available channels:", strings.Join(names, ", "))
+				return
+			}
+			output.Print(text.NewComponent().Color(text.Yellow).Text("You are now chatting in the ").Reset().Text(name).Color(text.Yellow).Text(" channel."))
+		} else {
+			output.Error("Please run this command as a player.")
+		}
+	})
+	channel.AppendArgument(arguments.NewString("channel", false))
+	channel.ExemptFromPermissionCheck(true)
+	return channel
+}
+
+func NewNick(server *Server) *commands.Command {
+	var nick = commands.NewCommand("nick", "Changes your display name", "gomine.nick", []string{"nickname"}, func(sender commands.Sender, output *commands.Output, nickname string) {
+		if session, ok := sender.(*net.MinecraftSession); ok {
+			if nickname == "off" {
+				server.ClearNickname(session)
+				output.Print(text.Yellow + "Your nickname has been cleared.")
+				return
+			}
+			if err := server.SetNickname(session, nickname); err != nil {
+				output.Error("Could not set nickname:", err.Error())
+				return
+			}
+			output.Print(text.NewComponent().Color(text.Yellow).Text("Your nickname is now ").Reset().Text(nickname).Color(text.Yellow).Text("."))
+		} else {
+			output.Error("Please run this command as a player.")
+		}
+	})
+	nick.AppendArgument(arguments.NewString("nickname", false))
+	nick.ExemptFromPermissionCheck(true)
+	return nick
+}
+
+func NewReload(server *Server) *commands.Command {
+	var reload = commands.NewCommand("reload", "Reloads a plugin, or all plugins if 'all' is given", "gomine.reload", []string{}, func(sender commands.Sender, output *commands.Output, name string) {
+		if name == "all" {
+			server.PluginManager.ReloadPlugins()
+			server.UpdateAvailableCommands()
+			output.Print(text.Yellow + "All plugins have been reloaded.")
+			return
+		}
+		if err := server.PluginManager.ReloadPlugin(name); err != nil {
+			output.Error("Could not reload plugin", name+":", err.Error())
+			return
+		}
+		server.UpdateAvailableCommands()
+		output.Print(text.Yellow+"Plugin", name, "has been reloaded.")
+	})
+	reload.AppendArgument(arguments.NewString("plugin", false))
+	return reload
+}
+
+func NewReloadPacks(server *Server) *commands.Command {
+	return commands.NewCommand("reloadpacks", "Reloads all resource and behavior packs", "gomine.reload", []string{}, func(output *commands.Output) {
+		var errs = server.PackManager.Reload()
+		for _, err := range errs {
+			output.Error("Failed to load pack:", err.Error())
+		}
+		output.Print(text.Yellow+"Packs have been reloaded.", "Players need to rejoin to get the new packs.")
 	})
 }
+
+func NewMute(server *Server) *commands.Command {
+	var mute = commands.NewCommand("mute", "Mutes a player in chat", "gomine.mute", []string{}, func(sender commands.Sender, output *commands.Output, name string) {
+		var session, ok = server.SessionManager.GetSessionByPrefix(name)
+		if !ok {
+			output.Error("Player", name, "is not online.")
+			return
+		}
+		server.ChatManager.Mute(session, 0)
+		text.DefaultLogger.LogError(server.ChatManager.SaveMutes())
+		session.SendMessage(text.NewComponent().Color(text.Red).Text("You have been muted."))
+		output.Print(text.Yellow+"Player", session.GetName(), "has been muted.")
+	})
+	mute.AppendArgument(arguments.NewString("player", false))
+	return mute
+}
+
+func NewTempMute(server *Server) *commands.Command {
+	var mute = commands.NewCommand("tempmute", "Mutes a player in chat for a number of minutes", "gomine.mute", []string{}, func(sender commands.Sender, output *commands.Output, name string, minutes int) {
+		var session, ok = server.SessionManager.GetSessionByPrefix(name)
+		if !ok {
+			output.Error("Player", name, "is not online.")
+			return
+		}
+		if minutes <= 0 {
+			output.Error("The amount of minutes must be positive.")
+			return
+		}
+		var duration = time.Duration(minutes) * time.Minute
+		server.ChatManager.Mute(session, duration)
+		text.DefaultLogger.LogError(server.ChatManager.SaveMutes())
+		session.SendMessage(text.NewComponent().Color(text.Red).Text("You have been muted until " + session.GetLocale().FormatDate(time.Now().Add(duration)) + "."))
+		output.Print(text.Yellow+"Player", session.GetName(), "has been muted for", getLocale(sender).FormatDuration(duration)+".")
+	})
+	mute.AppendArgument(arguments.NewString("player", false))
+	mute.AppendArgument(arguments.NewInt("minutes", false))
+	return mute
+}
+
+func NewUnmute(server *Server) *commands.Command {
+	var unmute = commands.NewCommand("unmute", "Unmutes a player in chat", "gomine.mute", []string{}, func(sender commands.Sender, output *commands.Output, name string) {
+		var session, ok = server.SessionManager.GetSessionByPrefix(name)
+		if !ok {
+			output.Error("Player", name, "is not online.")
+			return
+		}
+		if !server.ChatManager.Unmute(session) {
+			output.Error("Player", session.GetName(), "is not muted.")
+			return
+		}
+		text.DefaultLogger.LogError(server.ChatManager.SaveMutes())
+		session.SendMessage(text.NewComponent().Color(text.Yellow).Text("You have been unmuted."))
+		output.Print(text.Yellow+"Player", session.GetName(), "has been unmuted.")
+	})
+	unmute.AppendArgument(arguments.NewString("player", false))
+	return unmute
+}
+
+func NewTeleport(server *Server) *commands.Command {
+	var teleport = commands.NewCommand("tp", "Teleports players to a position", "gomine.teleport", []string{"teleport"}, func(sender commands.Sender, output *commands.Output, target *selectors.Selector, destination arguments.Position) {
+		var sessions = server.SelectSessions(sender, target)
+		if len(sessions) == 0 {
+			output.Error("No players matched the target " + target.String() + ".")
+			return
+		}
+		for _, session := range sessions {
+			var position = session.GetPlayer().Position
+			var x, y, z = destination.Resolve(position.X, position.Y, position.Z)
+			server.Teleport(session, r3.Vector{X: x, Y: y, Z: z}, nil)
+		}
+		output.Print(text.Yellow+"Teleported", len(sessions), "player(s).")
+		output.SetSuccessCount(len(sessions))
+	})
+	teleport.AppendArgument(arguments.NewTarget("target", false))
+	teleport.AppendArgument(arguments.NewPosition("destination", false))
+	return teleport
+}
+
+func NewTransfer(server *Server) *commands.Command {
+	var transfer = commands.NewCommand("transfer", "Transfers players to another server", "gomine.transfer", []string{}, func(sender commands.Sender, output *commands.Output, target *selectors.Selector, address string, port int) {
+		if port == 0 {
+			port = int(DefaultTransferPort)
+		}
+		if port < 1 || port > 65535 {
+			output.Error("Port", port, "is not a valid port.")
+			return
+		}
+		var sessions = server.SelectSessions(sender, target)
+		if len(sessions) == 0 {
+			output.Error("No players matched the target " + target.String() + ".")
+			return
+		}
+		var transferred = 0
+		for _, session := range sessions {
+			if server.Transfer(session, address, uint16(port)) {
+				transferred++
+			}
+		}
+		output.Print(text.Yellow+"Transferred", transferred, "player(s) to", address+":"+strconv.Itoa(port)+".")
+		output.SetSuccessCount(transferred)
+	})
+	transfer.AppendArgument(arguments.NewTarget("target", false))
+	transfer.AppendArgument(arguments.NewString("address", false))
+	transfer.AppendArgument(arguments.NewInt("port", true))
+	return transfer
+}
+
+func NewWorld(server *Server) *commands.Command {
+	var world = commands.NewCommand("world", "Transfers players to the spawn of a world", "gomine.world", []string{}, func(sender commands.Sender, output *commands.Output, target *selectors.Selector, name string) {
+		var level, err = server.LevelManager.GetLevel(name)
+		if err != nil {
+			if level, err = server.LoadWorld(name); err != nil {
+				output.Error("World", name, "could not be loaded:", err.Error())
+				return
+			}
+		}
+		var sessions = server.SelectSessions(sender, target)
+		if len(sessions) == 0 {
+			output.Error("No players matched the target " + target.String() + ".")
+			return
+		}
+		for _, session := range sessions {
+			server.TransferDimension(session, level.GetDefaultDimension(), server.GetWorldSpawn(level))
+		}
+		output.Print(text.Yellow+"Transferred", len(sessions), "player(s) to world", name+".")
+		output.SetSuccessCount(len(sessions))
+	})
+	world.AppendArgument(arguments.NewTarget("target", false))
+	world.AppendArgument(arguments.NewString("world", false))
+	return world
+}
+
+func NewKnockback(server *Server) *commands.Command {
+	var knockback = commands.NewCommand("knockback", "Changes the knockback of attacks, or of attacks with a weapon", "gomine.knockback", []string{"kb"}, func(sender commands.Sender, output *commands.Output, horizontal float64, vertical float64, weapon string) {
+		var value = combat.Knockback{Horizontal: horizontal, Vertical: vertical}
+		if weapon == "" {
+			server.KnockbackProfile.SetGlobal(value)
+			output.Print(text.Yellow+"Knockback set to", horizontal, "horizontal and", vertical, "vertical.")
+			return
+		}
+		var id = weaponId(weapon)
+		// Negative knockback makes the weapon use the global knockback again.
+		if horizontal < 0 || vertical < 0 {
+			server.KnockbackProfile.RemoveWeapon(id)
+			output.Print(text.Yellow+"Knockback of", id, "reset to the global knockback.")
+			return
+		}
+		server.KnockbackProfile.SetWeapon(id, value)
+		output.Print(text.Yellow+"Knockback of", id, "set to", horizontal, "horizontal and", vertical, "vertical.")
+	})
+	knockback.AppendArgument(arguments.NewFloat("horizontal", false))
+	knockback.AppendArgument(arguments.NewFloat("vertical", false))
+	knockback.AppendArgument(arguments.NewString("weapon", true))
+	return knockback
+}
+
+func NewOp(server *Server) *commands.Command {
+	var op = commands.NewCommand("op", "Makes a player an operator with an op level", "gomine.op", []string{}, func(sender commands.Sender, output *commands.Output, name string, level int) {
+		if level <= 0 {
+			level = permissions.DefaultOpLevel
+		}
+		if session, ok := sender.(*net.MinecraftSession); ok && level > session.GetOpLevel() {
+			output.Error("You cannot give a higher op level than your own.")
+			return
+		}
+		if online, ok := server.SessionManager.GetSessionByPrefix(name); ok {
+			name = online.GetName()
+		}
+		server.SetOpLevel(name, level)
+		output.Print(text.Yellow+"Player", name, "is now an operator with op level", strconv.Itoa(level)+".")
+	})
+	op.AppendArgument(arguments.NewString("player", false))
+	op.AppendArgument(arguments.NewInt("level", true))
+	return op
+}
+
+func NewDeop(server *Server) *commands.Command {
+	var deop = commands.NewCommand("deop", "Removes the op level of a player", "gomine.op", []string{}, func(sender commands.Sender, output *commands.Output, name string) {
+		if online, ok := server.SessionManager.GetSessionByPrefix(name); ok {
+			name = online.GetName()
+		}
+		if server.OpList.GetLevel(name) == 0 {
+			output.Error("Player", name, "is not an operator.")
+			return
+		}
+		server.SetOpLevel(name, 0)
+		output.Print(text.Yellow+"Player", name, "is no longer an operator.")
+	})
+	deop.AppendArgument(arguments.NewString("player", false))
+	return deop
+}
+
+// maxTitleWords is the maximum amount of words in the text of the title command.
+const maxTitleWords = 64
+
+func NewTitle(server *Server) *commands.Command {
+	var title = commands.NewCommand("title", "Shows titles, subtitles and action bar messages to players", "gomine.title", []string{}, func(sender commands.Sender, output *commands.Output, target *selectors.Selector, action string, message string) {
+		var times [3]int32
+		if action == "times" {
+			var values = strings.Fields(message)
+			if len(values) != len(times) {
+				output.Error("Times must be three tick amounts: fade in, stay and fade out.")
+				return
+			}
+			for i, value := range values {
+				var ticks, err = strconv.Atoi(value)
+				if err != nil || ticks < 0 {
+					output.Error("'" + value + "' is not a valid amount of ticks.")
+					return
+				}
+				times[i] = int32(ticks)
+			}
+		}
+		var sessions = server.SelectSessions(sender, target)
+		if len(sessions) == 0 {
+			output.Error("No players matched the target " + target.String() + ".")
+			return
+		}
+		for _, session := range sessions {
+			switch action {
+			case "clear":
+				session.ClearTitle()
+			case "reset":
+				session.ResetTitle()
+			case "title":
+				session.SendSetTitle(net.TitleTitle, message, 0, 0, 0)
+			case "subtitle":
+				session.SendSubtitle(message)
+			case "actionbar":
+				session.SendActionBar(message)
+			case "times":
+				session.SendTitleTimes(times[0], times[1], times[2])
+			}
+		}
+		output.Print(text.Yellow+"Sent", action, "to", len(sessions), "player(s).")
+		output.SetSuccessCount(len(sessions))
+	})
+	title.AppendArgument(arguments.NewTarget("target", false))
+	title.AppendArgument(arguments.NewEnum("action", false, "TitleAction", "clear", "reset", "title", "subtitle", "actionbar", "times"))
+	var message = arguments.NewString("text", true)
+	message.SetInputAmount(maxTitleWords)
+	message.SetShouldMerge(true)
+	title.AppendArgument(message)
+	return title
+}
+
+func NewPlaySound(server *Server) *commands.Command {
+	var playSound = commands.NewCommand("playsound", "Plays a sound to players", "gomine.playsound", []string{}, func(sender commands.Sender, output *commands.Output, sound string, target *selectors.Selector, volume float64, pitch float64) {
+		var sessions = server.SelectSessions(sender, target)
+		if len(sessions) == 0 {
+			output.Error("No players matched the target " + target.String() + ".")
+			return
+		}
+		var soundVolume, soundPitch = float32(volume), float32(pitch)
+		if soundVolume <= 0 {
+			soundVolume = sounds.DefaultVolume
+		}
+		if soundPitch <= 0 {
+			soundPitch = sounds.DefaultPitch
+		}
+		for _, session := range sessions {
+			session.PlaySound(sound, session.GetPlayer().Position, soundVolume, soundPitch)
+		}
+		output.Print(text.Yellow+"Played sound", sound, "to", len(sessions), "player(s).")
+		output.SetSuccessCount(len(sessions))
+	})
+	playSound.AppendArgument(arguments.NewString("sound", false))
+	playSound.AppendArgument(arguments.NewTarget("target", false))
+	playSound.AppendArgument(arguments.NewFloat("volume", true))
+	playSound.AppendArgument(arguments.NewFloat("pitch", true))
+	return playSound
+}
+
+func NewParticle(server *Server) *commands.Command {
+	var particle = commands.NewCommand("particle", "Spawns a particle at a position", "gomine.particle", []string{}, func(sender commands.Sender, output *commands.Output, name string, position arguments.Position) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			output.Error("Please run this command as a player.")
+			return
+		}
+		var particleId, found = particles.Get(name)
+		if !found {
+			output.Error("Unknown particle '" + name + "'. Available particles: " + strings.Join(particles.GetNames(), ", "))
+			return
+		}
+		var player = session.GetPlayer()
+		var x, y, z = position.Resolve(player.Position.X, player.Position.Y, player.Position.Z)
+		server.GetDimensionWorld(player.GetDimension()).SpawnParticle(particleId, r3.Vector{X: x, Y: y, Z: z}, 0)
+		output.Print(text.Yellow+"Spawned particle", name+".")
+		output.SetSuccessCount(1)
+	})
+	particle.AppendArgument(arguments.NewString("name", false))
+	particle.AppendArgument(arguments.NewPosition("position", false))
+	return particle
+}
+
+// timesOfDay holds the times of day that can be set by name with the time command.
+var timesOfDay = map[string]int64{
+	"day":      weather.Day,
+	"noon":     weather.Noon,
+	"sunset":   weather.Sunset,
+	"night":    weather.Night,
+	"midnight": weather.Midnight,
+	"sunrise":  weather.Sunrise,
+}
+
+func NewTime(server *Server) *commands.Command {
+	var timeCommand = commands.NewCommand("time", "Changes or queries the time of the world", "gomine.time", []string{}, func(sender commands.Sender, output *commands.Output, action string, value string) {
+		var level = server.getSenderLevel(sender)
+		var cycle = server.GetWeatherCycle(level)
+		if action == "query" {
+			output.Print(text.Yellow+"The time of day is", cycle.GetTimeOfDay(), "on day", cycle.GetTime()/weather.DayLength+1, "of", level.GetName()+".")
+			output.SetSuccessCount(1)
+			return
+		}
+		if value == "" {
+			output.Error("A time is required to " + action + " time.")
+			return
+		}
+		var ticks, ok = timesOfDay[strings.ToLower(value)]
+		if !ok || action == "add" {
+			var amount, err = strconv.ParseInt(value, 10, 64)
+			if err != nil || amount < 0 {
+				output.Error("'" + value + "' is not a valid amount of ticks.")
+				return
+			}
+			ticks = amount
+		}
+		if action == "add" {
+			server.AddTime(level, ticks)
+			output.Print(text.Yellow+"Added", ticks, "ticks to the time of", level.GetName()+".")
+		} else {
+			server.SetTimeOfDay(level, ticks%weather.DayLength)
+			output.Print(text.Yellow+"Set the time of", level.GetName(), "to", strconv.FormatInt(ticks%weather.DayLength, 10)+".")
+		}
+		output.SetSuccessCount(1)
+	})
+	timeCommand.AppendArgument(arguments.NewEnum("action", false, "TimeAction", "set", "add", "query"))
+	timeCommand.AppendArgument(arguments.NewString("value", true))
+	return timeCommand
+}
+
+func NewWeather(server *Server) *commands.Command {
+	var weatherCommand = commands.NewCommand("weather", "Changes the weather of the world", "gomine.weather", []string{}, func(sender commands.Sender, output *commands.Output, name string, duration int) {
+		if duration < 0 {
+			output.Error("The duration can not be negative.")
+			return
+		}
+		var level = server.getSenderLevel(sender)
+		var newWeather, _ = weather.FromName(name)
+		server.SetWeather(level, newWeather, int64(duration)*20)
+		output.Print(text.Yellow+"Changed the weather of", level.GetName(), "to", name+".")
+		output.SetSuccessCount(1)
+	})
+	weatherCommand.AppendArgument(arguments.NewEnum("type", false, "WeatherType", "clear", "rain", "thunder"))
+	weatherCommand.AppendArgument(arguments.NewInt("duration", true))
+	return weatherCommand
+}
+
+func NewGameRule(server *Server) *commands.Command {
+	var gameRule = commands.NewCommand("gamerule", "Changes or queries a game rule of the world", "gomine.gamerule", []string{}, func(sender commands.Sender, output *commands.Output, name string, value string) {
+		var rule, ok = gamerules.Get(name)
+		if !ok {
+			output.Error("Game rule", name, "does not exist. Game rules: "+strings.Join(gamerules.GetNames(), ", "))
+			return
+		}
+		var level = server.getSenderLevel(sender)
+		if value == "" {
+			var current, _ = server.GetGameRules(level).Get(rule.Name)
+			output.Print(text.Yellow+rule.Name, "=", gamerules.Format(current))
+			output.SetSuccessCount(1)
+			return
+		}
+		if err := server.SetGameRule(level, rule.Name, value); err != nil {
+			output.Error("Invalid value", value, "for game rule", rule.Name+".")
+			return
+		}
+		output.Print(text.Yellow+"Game rule", rule.Name, "of", level.GetName(), "has been set to", value+".")
+		output.SetSuccessCount(1)
+	})
+	gameRule.AppendArgument(arguments.NewString("rule", false))
+	gameRule.AppendArgument(arguments.NewString("value", true))
+	return gameRule
+}
+
+func NewDifficulty(server *Server) *commands.Command {
+	var difficulty = commands.NewCommand("difficulty", "Changes the difficulty of the world", "gomine.difficulty", []string{}, func(sender commands.Sender, output *commands.Output, name string) {
+		var level = server.getSenderLevel(sender)
+		for value, difficultyName := range levels.DifficultyNames {
+			if difficultyName == name {
+				server.SetDifficulty(level, value)
+			}
+		}
+		output.Print(text.Yellow+"Set the difficulty of", level.GetName(), "to", name+".")
+		output.SetSuccessCount(1)
+	})
+	difficulty.AppendArgument(arguments.NewEnum("difficulty", false, "Difficulty", "peaceful", "easy", "normal", "hard"))
+	return difficulty
+}
+
+func NewGameMode(server *Server) *commands.Command {
+	var gameMode = commands.NewCommand("gamemode", "Changes the game mode of players, or resets it to that of their world with 'default'", "gomine.gamemode", []string{"gm"}, func(sender commands.Sender, output *commands.Output, name string, target *selectors.Selector) {
+		var sessions []*net.MinecraftSession
+		if target != nil {
+			sessions = server.SelectSessions(sender, target)
+		} else if session, ok := sender.(*net.MinecraftSession); ok {
+			sessions = append(sessions, session)
+		} else {
+			output.Error("Please specify a player when running this command from the console.")
+			return
+		}
+		if len(sessions) == 0 {
+			output.Error("No players matched the target " + target.String() + ".")
+			return
+		}
+		for _, session := range sessions {
+			if name == "default" {
+				server.ClearGameMode(session)
+				continue
+			}
+			for value, gameModeName := range levels.GameModeNames {
+				if gameModeName == name {
+					server.SetGameMode(session, value)
+				}
+			}
+		}
+		output.Print(text.Yellow+"Set the game mode of", len(sessions), "player(s) to", name+".")
+		output.SetSuccessCount(len(sessions))
+	})
+	gameMode.AppendArgument(arguments.NewEnum("mode", false, "GameMode", "survival", "creative", "adventure", "default"))
+	gameMode.AppendArgument(arguments.NewTarget("player", true))
+	return gameMode
+}
+
+func NewMenu(server *Server) *commands.Command {
+	var menu = commands.NewCommand("menu", "Selects an option of the menu shown in chat", "gomine.menu", []string{}, func(sender commands.Sender, output *commands.Output, option int) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			output.Error("Please run this command as a player.")
+			return
+		}
+		switch server.FormManager.SelectOption(session, option) {
+		case forms.NoMenu:
+			output.Error("There is no menu to select an option of.")
+		case forms.InvalidOption:
+			output.Error("The menu has no option", strconv.Itoa(option)+".")
+		default:
+			output.SetSuccessCount(1)
+		}
+	})
+	menu.AppendArgument(arguments.NewInt("option", false))
+	return menu
+}
+
+func NewJoinInfo(server *Server) *commands.Command {
+	var joinInfo = commands.NewCommand("joininfo", "Shows where a player is in the join sequence", "gomine.joininfo", []string{}, func(output *commands.Output, name string) {
+		var session, ok = server.SessionManager.GetSessionByPrefix(name)
+		if !ok {
+			output.Error("Player", name, "is not online.")
+			return
+		}
+		var phase = session.GetJoinPhase()
+		if phase == net.PhaseSpawned {
+			output.Print(text.Yellow+session.GetName(), "spawned after", session.GetJoinDuration().Round(time.Millisecond).String()+".")
+		} else {
+			output.Print(text.Yellow+session.GetName(), "has been in join phase", phase.String(), "for", session.GetJoinPhaseDuration().Round(time.Millisecond), "and joining for", session.GetJoinDuration().Round(time.Millisecond).String()+".")
+		}
+		for _, progress := range session.GetPackProgress() {
+			var state = "downloading"
+			if progress.IsComplete() {
+				state = "downloaded"
+			}
+			output.Print(text.Yellow+"Pack", progress.UUID, state+":", progress.SentChunks, "/", progress.ChunkCount, "chunks,", progress.SentBytes, "/", progress.Size, "bytes in chunks of", progress.ChunkSize, "bytes")
+		}
+		output.SetSuccessCount(1)
+	})
+	joinInfo.AppendArgument(arguments.NewString("player", false))
+	return joinInfo
+}
+
+// getLocale returns the locale of the sender if it is a session, or the default locale otherwise.
+func getLocale(sender commands.Sender) text.Locale {
+	if session, ok := sender.(*net.MinecraftSession); ok {
+		return session.GetLocale()
+	}
+	return text.GetLocale(text.DefaultLocale)
+}
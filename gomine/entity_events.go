@@ -0,0 +1,79 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/combat"
+	"github.com/BobbyShrd/gominetest/net"
+)
+
+// Entity events are visual effects of entities, played for the viewers of an entity with an EntityEventPacket.
+// Most events do not use any data, in which case 0 is sent as data.
+const (
+	// EntityEventJump plays the jump animation of an entity.
+	EntityEventJump byte = 1
+	// EntityEventHurt plays the hurt animation of an entity, briefly coloring it red.
+	EntityEventHurt = combat.EntityEventHurt
+	// EntityEventDeath plays the death animation of an entity.
+	EntityEventDeath = combat.EntityEventDeath
+	// EntityEventArmSwing swings the arm of an entity.
+	EntityEventArmSwing byte = 4
+	// EntityEventTameFail shows smoke around a mob that did not get tamed.
+	EntityEventTameFail byte = 6
+	// EntityEventTameSuccess shows hearts around a mob that got tamed.
+	EntityEventTameSuccess byte = 7
+	// EntityEventShakeWet makes a wolf shake off water.
+	EntityEventShakeWet byte = 8
+	// EntityEventEatGrass plays the grass eating animation of a sheep.
+	EntityEventEatGrass byte = 10
+	// EntityEventSquidInk shows the ink cloud of a squid.
+	EntityEventSquidInk byte = 15
+	// EntityEventRespawn plays the respawn effect of an entity.
+	EntityEventRespawn byte = 18
+	// EntityEventLoveParticles shows hearts around a mob in love.
+	EntityEventLoveParticles byte = 21
+	// EntityEventVillagerAngry shows angry clouds around a villager.
+	EntityEventVillagerAngry byte = 22
+	// EntityEventVillagerHappy shows green sparkles around a villager.
+	EntityEventVillagerHappy byte = 23
+	// EntityEventWitchSpell shows the spell particles of a witch.
+	EntityEventWitchSpell byte = 24
+	// EntityEventFirework shows the explosion of a firework rocket.
+	EntityEventFirework byte = 25
+	// EntityEventSilverfishSpawn plays the animation of a silverfish leaving a block.
+	EntityEventSilverfishSpawn byte = 27
+	// EntityEventCreeperFuse plays the fuse animation of a creeper about to explode.
+	EntityEventCreeperFuse byte = 32
+	// EntityEventElderGuardianCurse shows the curse of an elder guardian.
+	EntityEventElderGuardianCurse byte = 35
+	// EntityEventDustParticles shows dust particles at an entity.
+	EntityEventDustParticles byte = 38
+	// EntityEventEatingItem shows particles of the item being eaten, given as data.
+	// The data is the legacy item ID shifted left by 16 bits, combined with the item data.
+	EntityEventEatingItem byte = 57
+	// EntityEventBabyFeed shows green sparkles around a baby that got fed.
+	EntityEventBabyFeed byte = 60
+	// EntityEventDeathSmoke shows the smoke cloud left behind by a dead entity.
+	EntityEventDeathSmoke byte = 61
+	// EntityEventConsumeTotem plays the animation and sound of a totem saving an entity from death.
+	EntityEventConsumeTotem = combat.EntityEventConsumeTotem
+)
+
+// BroadcastEvent plays the entity event of the entity for all its viewers, with the given event data.
+func (entity *PersistentEntity) BroadcastEvent(eventId byte, data int32) {
+	for _, viewer := range entity.GetViewers() {
+		if viewer, ok := viewer.(*net.MinecraftSession); ok {
+			viewer.SendEntityEvent(entity.GetRuntimeId(), eventId, data)
+		}
+	}
+}
+
+// BroadcastPlayerEvent plays the entity event of the player of the session, with the given event data,
+// for the player itself and for all its viewers.
+func (server *Server) BroadcastPlayerEvent(session *net.MinecraftSession, eventId byte, data int32) {
+	var player = session.GetPlayer()
+	session.SendEntityEvent(player.GetRuntimeId(), eventId, data)
+	for _, viewer := range player.GetViewers() {
+		if viewer, ok := viewer.(*net.MinecraftSession); ok {
+			viewer.SendEntityEvent(player.GetRuntimeId(), eventId, data)
+		}
+	}
+}
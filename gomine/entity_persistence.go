@@ -0,0 +1,163 @@
+package gomine
+
+import (
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/entitystore"
+	"github.com/BobbyShrd/gominetest/loot"
+	"github.com/BobbyShrd/gominetest/metadata"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/text"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/chunks"
+	"github.com/irmine/worlds/entities"
+)
+
+// PersistentEntity is an entity that is saved with the chunk it is in,
+// and spawned again when the chunk gets loaded.
+type PersistentEntity struct {
+	*entities.Entity
+	mutex      sync.RWMutex
+	persistent bool
+	metadata   *metadata.Store
+	saveFunc   func()
+	equipment  *loot.Equipment
+}
+
+// NewPersistentEntity returns a new persistent entity with the given entity type.
+func NewPersistentEntity(entityType uint32) *PersistentEntity {
+	return &PersistentEntity{Entity: entities.New(entityType), persistent: true, metadata: metadata.NewStore()}
+}
+
+// IsPersistent checks if the entity gets saved with its chunk.
+func (entity *PersistentEntity) IsPersistent() bool {
+	entity.mutex.RLock()
+	defer entity.mutex.RUnlock()
+	return entity.persistent
+}
+
+// SetPersistent sets if the entity gets saved with its chunk.
+// NPCs that are managed by plugins should not be persistent,
+// as the plugin spawns them again itself.
+func (entity *PersistentEntity) SetPersistent(value bool) {
+	entity.mutex.Lock()
+	entity.persistent = value
+	entity.mutex.Unlock()
+}
+
+// GetMetadata returns the values attached to the entity by plugins.
+// Persistent values are saved with the entity.
+func (entity *PersistentEntity) GetMetadata() *metadata.Store {
+	return entity.metadata
+}
+
+// SetSaveFunction sets the function called right before the entity gets saved,
+// which stores state kept outside of the entity in its metadata. Nil removes the function.
+func (entity *PersistentEntity) SetSaveFunction(function func()) {
+	entity.mutex.Lock()
+	entity.saveFunc = function
+	entity.mutex.Unlock()
+}
+
+// ToRecord returns the current state of the entity to persist.
+func (entity *PersistentEntity) ToRecord() entitystore.Record {
+	entity.mutex.RLock()
+	var saveFunc = entity.saveFunc
+	entity.mutex.RUnlock()
+	if saveFunc != nil {
+		saveFunc()
+	}
+	return entitystore.Record{
+		Type:       entity.GetEntityType(),
+		Position:   entity.Position,
+		Yaw:        entity.Rotation.Yaw,
+		Pitch:      entity.Rotation.Pitch,
+		Health:     entity.GetHealth(),
+		CustomData: entity.metadata.Encode(),
+	}
+}
+
+// SetEntityStore makes the entities of the dimension persist in the given directory.
+func (server *Server) SetEntityStore(dimension *worlds.Dimension, directory string) {
+	var manager = entitystore.NewManager(entitystore.NewStore(directory))
+	manager.SpawnFunction = func(record entitystore.Record) entitystore.Entity {
+		var entity = NewPersistentEntity(record.Type)
+		entity.metadata.Decode(record.CustomData)
+		entity.Rotation.Yaw, entity.Rotation.Pitch = record.Yaw, record.Pitch
+		entity.SetHealth(record.Health)
+		dimension.AddEntity(entity, record.Position)
+		server.addDefaultMob(dimension, entity)
+		server.addDefaultVehicle(dimension, entity)
+		return entity
+	}
+	server.entityManagerMutex.Lock()
+	server.entityManagers[dimension] = manager
+	server.entityManagerMutex.Unlock()
+}
+
+// GetEntityManager returns the manager of the persistent entities of the given dimension,
+// and a bool indicating if the dimension persists its entities.
+func (server *Server) GetEntityManager(dimension *worlds.Dimension) (*entitystore.Manager, bool) {
+	server.entityManagerMutex.Lock()
+	defer server.entityManagerMutex.Unlock()
+	var manager, ok = server.entityManagers[dimension]
+	return manager, ok
+}
+
+// SpawnPersistentEntity spawns a new persistent entity with the given entity type in the dimension.
+// The entity is saved with its chunk if the dimension persists its entities.
+// Mobs get the default AI of their entity type, and boats and minecarts can be ridden.
+func (server *Server) SpawnPersistentEntity(dimension *worlds.Dimension, entityType uint32, position r3.Vector) *PersistentEntity {
+	var entity = NewPersistentEntity(entityType)
+	dimension.AddEntity(entity, position)
+	server.addDefaultMob(dimension, entity)
+	server.addDefaultVehicle(dimension, entity)
+	if manager, ok := server.GetEntityManager(dimension); ok {
+		manager.Track(entity)
+	}
+	return entity
+}
+
+// loadChunkEntities spawns the persistent entities, loads the block entities, custom block states, water layer and crops,
+// and sends them and the mob equipment of a chunk loaded by a session.
+func (server *Server) loadChunkEntities(session *net.MinecraftSession, chunk *chunks.Chunk) {
+	if manager, ok := server.GetEntityManager(session.GetPlayer().GetDimension()); ok {
+		var _, err = manager.LoadChunk(chunk.X, chunk.Z)
+		text.DefaultLogger.LogError(err)
+	}
+	text.DefaultLogger.LogError(server.GetBlockEntityManager(session.GetPlayer().GetDimension()).LoadChunk(chunk.X, chunk.Z))
+	text.DefaultLogger.LogError(server.GetDimensionWorld(session.GetPlayer().GetDimension()).loadCustomStates(chunk.X, chunk.Z))
+	text.DefaultLogger.LogError(server.GetDimensionWorld(session.GetPlayer().GetDimension()).layer.LoadChunk(chunk.X, chunk.Z))
+	text.DefaultLogger.LogError(server.FarmManager.LoadChunk(server.GetDimensionWorld(session.GetPlayer().GetDimension()), chunk.X, chunk.Z))
+	server.sendChunkBlockEntities(session, chunk)
+	server.sendChunkEquipment(session, chunk)
+	server.sendChunkLayers(session, chunk)
+	server.sendChunkCustomStates(session, chunk)
+}
+
+// unloadChunkEntities saves and despawns the persistent entities, and saves and unloads the block entities,
+// custom block states, water layer and crops of a chunk
+// once it is no longer loaded by any session.
+func (server *Server) unloadChunkEntities(session *net.MinecraftSession, chunk *chunks.Chunk) {
+	if len(chunk.GetViewers()) != 0 {
+		return
+	}
+	var dimension = session.GetPlayer().GetDimension()
+	text.DefaultLogger.LogError(server.GetBlockEntityManager(dimension).UnloadChunk(chunk.X, chunk.Z))
+	text.DefaultLogger.LogError(server.GetDimensionWorld(dimension).custom.UnloadChunk(chunk.X, chunk.Z))
+	text.DefaultLogger.LogError(server.GetDimensionWorld(dimension).layer.UnloadChunk(chunk.X, chunk.Z))
+	text.DefaultLogger.LogError(server.FarmManager.UnloadChunk(server.GetDimensionWorld(dimension), chunk.X, chunk.Z))
+	if manager, ok := server.GetEntityManager(dimension); ok {
+		var unloaded, err = manager.UnloadChunk(chunk.X, chunk.Z)
+		text.DefaultLogger.LogError(err)
+		for _, entity := range unloaded {
+			if entity, ok := entity.(*PersistentEntity); ok {
+				server.RemoveMob(dimension, entity.GetRuntimeId())
+				server.GetVillagerManager(dimension).Remove(entity.GetRuntimeId())
+				server.GetVehicleManager(dimension).Remove(entity.GetRuntimeId())
+				entity.Close()
+			}
+		}
+	}
+}
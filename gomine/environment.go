@@ -0,0 +1,117 @@
+package gomine
+
+import (
+	"math"
+
+	"github.com/BobbyShrd/gominetest/combat"
+	"github.com/BobbyShrd/gominetest/effects"
+	"github.com/BobbyShrd/gominetest/environment"
+	"github.com/BobbyShrd/gominetest/gamerules"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/irmine/worlds/blocks"
+	data2 "github.com/irmine/worlds/entities/data"
+)
+
+// tickEnvironment checks the blocks at the head and feet of the player of the session,
+// and hurts it by drowning, suffocating, standing in fire or lava, being on fire, or falling into the void.
+// The air supply and on fire flag of the player are updated when they change.
+// Players in creative mode are not hurt by their surroundings, unless they fell into the void.
+func (server *Server) tickEnvironment(session *net.MinecraftSession) {
+	var player = session.GetPlayer()
+	if !session.HasSpawned() || player.GetDimension() == nil {
+		return
+	}
+	var world = server.GetDimensionWorld(player.GetDimension())
+	var inVoid = world.GetHeightRange().IsInVoid(player.Position.Y - playerEyeHeight)
+	if server.IsCreative(session) && !inVoid {
+		return
+	}
+	var state = player.GetEnvironment()
+	var air, onFire = state.GetAir(), state.IsOnFire()
+
+	var damages = state.Tick(environment.Surroundings{
+		Head:           blockNameAt(world, player.Position.X, player.Position.Y, player.Position.Z),
+		Feet:           blockNameAt(world, player.Position.X, player.Position.Y-playerEyeHeight, player.Position.Z),
+		WaterBreathing: player.GetEffects().Has(effects.WaterBreathing),
+		FireResistance: player.GetEffects().Has(effects.FireResistance),
+		InVoid:         inVoid,
+	})
+
+	if onFire != state.IsOnFire() {
+		player.SetEntityProperty(data2.EntityDataOnFire, state.IsOnFire())
+		player.BroadcastUpdatedEntityData()
+	}
+	if air != state.GetAir() {
+		player.SetEntityProperty(data2.EntityDataAir, int16(state.GetAir()))
+	}
+	if onFire != state.IsOnFire() || air != state.GetAir() {
+		session.SendSetEntityData(player.GetRuntimeId(), player.GetEntityData())
+	}
+	for _, damage := range damages {
+		if !server.hurtByEnvironment(session, damage) {
+			return
+		}
+	}
+}
+
+// hurtByEnvironment deals environmental damage to the player of the session.
+// The player dies with the death message of the cause if its health drops to 0, in which case false is returned.
+func (server *Server) hurtByEnvironment(session *net.MinecraftSession, damage environment.Damage) bool {
+	var player = session.GetPlayer()
+	if rule, ok := damageGameRule(damage.Cause); ok && !server.IsGameRuleEnabled(player.GetDimension().GetLevel(), rule) {
+		return true
+	}
+	var health = player.GetHealth() - server.protect(session, damage.Amount*player.GetEffects().GetDamageMultiplier(), damageSource(damage.Cause))
+	if health <= 0 {
+		server.die(session, damage.Cause.GetDeathMessage())
+		return false
+	}
+	player.SetHealth(health)
+	session.SendUpdateAttributes(player.GetRuntimeId(), player.GetAttributeMap())
+	server.broadcastHurt(session, combat.EntityEventHurt)
+	return true
+}
+
+// damageGameRule returns the game rule that disables environmental damage with the given cause,
+// and a bool indicating if there is one.
+func damageGameRule(cause environment.Cause) (string, bool) {
+	switch cause {
+	case environment.Drowning:
+		return gamerules.DrowningDamage, true
+	case environment.Fire, environment.Lava, environment.Burning:
+		return gamerules.FireDamage, true
+	}
+	return "", false
+}
+
+// damageSource returns the source of environmental damage with the given cause.
+// Armor protects against fire and lava, but not against drowning and suffocation.
+func damageSource(cause environment.Cause) combat.Source {
+	switch cause {
+	case environment.Fire, environment.Lava, environment.Burning:
+		return combat.SourceFire
+	}
+	return combat.SourceUnblockable
+}
+
+// blockNameAt returns the name of the block at the given coordinates in the world.
+// Air is returned for coordinates outside of the height range of the world.
+func blockNameAt(world *DimensionWorld, x, y, z float64) string {
+	if y < 0 || !world.GetHeightRange().Contains(int32(math.Floor(y))) {
+		return "air"
+	}
+	return world.GetBlockName(blocks.NewPosition(int32(math.Floor(x)), uint32(math.Floor(y)), int32(math.Floor(z))))
+}
+
+// resetEnvironment restores the air supply of the player of the session and extinguishes it, for example after dying.
+func (server *Server) resetEnvironment(session *net.MinecraftSession) {
+	var player = session.GetPlayer()
+	var onFire = player.GetEnvironment().IsOnFire()
+	player.GetEnvironment().Reset()
+	player.SetEntityProperty(data2.EntityDataAir, int16(environment.MaxAir))
+	player.SetEntityProperty(data2.EntityDataOnFire, false)
+	if onFire {
+		player.BroadcastUpdatedEntityData()
+	}
+	session.SendSetEntityData(player.GetRuntimeId(), player.GetEntityData())
+}
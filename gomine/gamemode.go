@@ -0,0 +1,56 @@
+package gomine
+
+import (
+	"errors"
+
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/BobbyShrd/gominetest/net"
+)
+
+var InvalidGameMode = errors.New("game mode does not exist")
+
+// GetGameMode returns the game mode of the player of the session.
+// This is the game mode set for the player, or the game mode of the world the player is in if none was set.
+func (server *Server) GetGameMode(session *net.MinecraftSession) int32 {
+	if gameMode := session.GetPlayer().GetData().GameMode; gameMode != nil {
+		return *gameMode
+	}
+	return server.GetWorldSettings(server.getSenderLevel(session)).Gamemode
+}
+
+// IsCreative checks if the player of the session is in creative mode.
+func (server *Server) IsCreative(session *net.MinecraftSession) bool {
+	return server.GetGameMode(session) == levels.Creative
+}
+
+// SetGameMode sets the game mode of the player of the session, which it keeps in all worlds,
+// saves it with the data of the player and sends it to the player.
+// InvalidGameMode is returned if the game mode is not one of the game modes in the levels package.
+func (server *Server) SetGameMode(session *net.MinecraftSession, gameMode int32) error {
+	if _, ok := levels.GameModeNames[gameMode]; !ok {
+		return InvalidGameMode
+	}
+	session.GetPlayer().GetData().GameMode = &gameMode
+	server.SavePlayerData(session)
+	server.sendGameMode(session)
+	return nil
+}
+
+// ClearGameMode clears the game mode set for the player of the session,
+// so that it gets the game mode of the world it is in again.
+func (server *Server) ClearGameMode(session *net.MinecraftSession) {
+	session.GetPlayer().GetData().GameMode = nil
+	server.SavePlayerData(session)
+	server.sendGameMode(session)
+}
+
+// sendGameMode sends the game mode of the player of the session to the player,
+// and resets its abilities to those of the game mode.
+func (server *Server) sendGameMode(session *net.MinecraftSession) {
+	if !session.HasSpawned() {
+		return
+	}
+	var gameMode = server.GetGameMode(session)
+	session.SendSetPlayerGameType(gameMode)
+	server.applyGameModeAbilities(session, gameMode)
+}
@@ -0,0 +1,69 @@
+package gomine
+
+import (
+	"strings"
+
+	"github.com/BobbyShrd/gominetest/gamerules"
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/BobbyShrd/gominetest/net/packets/types"
+	"github.com/irmine/worlds"
+)
+
+// GetGameRules returns the game rules of the level.
+// Levels that were not loaded by the server get game rules with default values.
+func (server *Server) GetGameRules(level *worlds.Level) *gamerules.Rules {
+	server.worldMutex.Lock()
+	defer server.worldMutex.Unlock()
+	if rules, ok := server.gameRules[level]; ok {
+		return rules
+	}
+	var rules = gamerules.New()
+	server.gameRules[level] = rules
+	return rules
+}
+
+// SetGameRule parses the value and sets the game rule with the given name of the level to it,
+// and sends the game rules to the players in the level.
+// gamerules.UnknownRule is returned if the game rule does not exist,
+// and gamerules.InvalidValue if the value does not fit the type of the game rule.
+func (server *Server) SetGameRule(level *worlds.Level, name string, value string) error {
+	var rules = server.GetGameRules(level)
+	if err := rules.Set(name, value); err != nil {
+		return err
+	}
+	var cycle = server.GetWeatherCycle(level)
+	cycle.SetDaylightCycle(rules.GetBool(gamerules.DoDaylightCycle))
+	cycle.SetWeatherCycle(rules.GetBool(gamerules.DoWeatherCycle))
+	server.broadcastGameRules(level)
+	return nil
+}
+
+// IsGameRuleEnabled checks if the bool game rule with the given name is enabled in the level.
+func (server *Server) IsGameRuleEnabled(level *worlds.Level, name string) bool {
+	return server.GetGameRules(level).GetBool(name)
+}
+
+// broadcastGameRules sends the game rules of the level to the players in the level.
+func (server *Server) broadcastGameRules(level *worlds.Level) {
+	var entries = getGameRuleEntries(server.GetGameRules(level))
+	for _, session := range server.getLevelSessions(level) {
+		session.SendGameRulesChanged(entries)
+	}
+}
+
+// getGameRuleEntries returns all game rules as sent to clients, which use lower case names.
+func getGameRuleEntries(rules *gamerules.Rules) map[string]types.GameRuleEntry {
+	var entries = map[string]types.GameRuleEntry{}
+	for name, value := range rules.GetAll() {
+		name = strings.ToLower(name)
+		entries[name] = types.GameRuleEntry{Name: name, Value: value}
+	}
+	return entries
+}
+
+// saveGameRules stores the game rules that were set in the level data.
+func saveGameRules(rules *gamerules.Rules, data *levels.Data) {
+	for name, value := range rules.ToStrings() {
+		data.SetGameRule(name, value)
+	}
+}
@@ -0,0 +1,23 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/geoip"
+	"github.com/BobbyShrd/gominetest/net"
+)
+
+// lookupCountry looks up the country the session connects from, if GeoIP lookups are enabled.
+// The language of the country is used if the client did not send its language.
+// The address of the session is only used for the lookup, and is never stored.
+func (server *Server) lookupCountry(session *net.MinecraftSession) {
+	if server.GeoIP == nil || session.GetAddress() == nil {
+		return
+	}
+	var country, _ = server.GeoIP.GetCountry(session.GetAddress().IP)
+	session.SetCountry(country)
+	if session.GetLanguage() == "" {
+		session.SetLanguage(geoip.GetLanguage(country))
+	}
+	if server.GeoIPFunction != nil {
+		server.GeoIPFunction(session, country)
+	}
+}
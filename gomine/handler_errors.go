@@ -0,0 +1,15 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/forms"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// showErrorForm shows the message of a handler error in a form with a single button to close it.
+func (server *Server) showErrorForm(session *net.MinecraftSession, message string) {
+	var form = forms.NewSimpleForm(text.Red+"Error", message)
+	form.AddButton("OK")
+	var _, err = server.FormManager.SendForm(session, form)
+	text.DefaultLogger.LogError(err)
+}
@@ -0,0 +1,242 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/ai"
+	"github.com/BobbyShrd/gominetest/combat"
+	"github.com/BobbyShrd/gominetest/gamerules"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+	data2 "github.com/irmine/worlds/entities/data"
+)
+
+const (
+	// LeashKnotEntityType is the legacy entity type ID of leash knots.
+	LeashKnotEntityType uint32 = 88
+	// LeadItemId is the string ID of the lead item, which leashes mobs.
+	LeadItemId = "minecraft:lead"
+	// noLeashHolder is the leash holder entity data of entities that are not leashed.
+	noLeashHolder int64 = -1
+)
+
+// leashableTypes are the entities players can leash with a lead, by legacy entity type ID.
+var leashableTypes = map[uint32]bool{
+	10: true,
+	11: true,
+	12: true,
+	13: true,
+	14: true,
+	16: true,
+	18: true,
+}
+
+// leashPosts are the blocks leashed mobs can be tied to with a leash knot.
+var leashPosts = map[string]bool{
+	"fence":              true,
+	"nether_brick_fence": true,
+	"cobblestone_wall":   true,
+}
+
+// interactEntity handles the player of the session interacting with the entity with the given runtime ID,
+// using the item the player holds. Entities out of reach of the player are ignored. Players leash mobs by using a lead on them and unleash mobs they lead by using anything on them,
+// and interacting with a leash knot removes it. Mobs are fed as handled by feedAnimal,
+// and tameable mobs are tamed and told to sit as handled by interactPet.
+// Returns false if nothing happened.
+func (server *Server) interactEntity(session *net.MinecraftSession, runtimeId uint64) bool {
+	var player = session.GetPlayer()
+	var dimension = player.GetDimension()
+	if dimension == nil {
+		return false
+	}
+	if knot, ok := server.getLeashKnot(dimension, runtimeId); ok {
+		if !combat.InReach(player.Position, knot.Position) {
+			return false
+		}
+		server.removeLeashKnot(dimension, knot)
+		return true
+	}
+	var mob, ok = server.GetMobManager(dimension).GetMob(runtimeId)
+	if !ok || !combat.InReach(player.Position, mob.GetBody().(mobBody).Position) {
+		return false
+	}
+	var item = player.GetHeldItem()
+	if holder, ok := mob.GetLeashHolder(); ok {
+		if holder.GetRuntimeId() != session.GetPlayer().GetRuntimeId() {
+			return false
+		}
+		server.Unleash(dimension, mob, true)
+		return true
+	}
+	if server.feedAnimal(session, dimension, mob, item) || server.interactPet(session, dimension, mob, item) || server.openTrade(session, dimension, mob) {
+		return true
+	}
+	var entity = mob.GetBody().(mobBody)
+	if item == nil || item.GetId() != LeadItemId || !leashableTypes[entity.GetEntityType()] {
+		return false
+	}
+	server.Leash(dimension, mob, session.GetPlayer())
+	if !server.IsCreative(session) {
+		server.consumeHeldItem(session)
+	}
+	return true
+}
+
+// consumeHeldItem removes one item from the stack held by the player of the session.
+func (server *Server) consumeHeldItem(session *net.MinecraftSession) {
+	var player = session.GetPlayer()
+	var inventory, slot = player.GetInventory(), player.GetHeldSlot()
+	var held = inventory.GetItem(slot)
+	if held == nil {
+		return
+	}
+	held.Count--
+	inventory.SetItem(slot, held)
+	session.SendInventorySlot(InventoryWindowId, uint32(slot), inventory.GetItem(slot))
+}
+
+// Leash leashes the mob in the dimension to the holder, which is a player or a leash knot,
+// and broadcasts the leash to the viewers of the mob.
+func (server *Server) Leash(dimension *worlds.Dimension, mob *ai.Mob, holder ai.Target) {
+	mob.Leash(holder)
+	broadcastLeashHolder(mob, int64(holder.GetRuntimeId()))
+}
+
+// Unleash removes the leash of the mob in the dimension, dropping a lead at the mob if drop is true.
+// Leash knots are removed once no mob is tied to them anymore. Returns false if the mob was not leashed.
+func (server *Server) Unleash(dimension *worlds.Dimension, mob *ai.Mob, drop bool) bool {
+	var holder, ok = mob.GetLeashHolder()
+	if !ok {
+		return false
+	}
+	mob.Unleash()
+	server.leashReleased(dimension, mob, holder, drop)
+	return true
+}
+
+// leashReleased broadcasts that the mob is no longer leashed to the holder,
+// drops a lead if drop is true and entity drops are enabled, and removes the leash knot the mob was tied to
+// if it is no longer used.
+func (server *Server) leashReleased(dimension *worlds.Dimension, mob *ai.Mob, holder ai.Target, drop bool) {
+	broadcastLeashHolder(mob, noLeashHolder)
+	if drop && server.IsGameRuleEnabled(dimension.GetLevel(), gamerules.DoEntityDrops) {
+		if lead, ok := items.DefaultManager.Get(LeadItemId, 1); ok {
+			server.DropItem(dimension, mob.GetBody().GetPosition(), lead)
+		}
+	}
+	if knot, ok := server.getLeashKnot(dimension, holder.GetRuntimeId()); ok && len(server.getLeashedMobs(dimension, knot.GetRuntimeId())) == 0 {
+		server.removeLeashKnot(dimension, knot)
+	}
+}
+
+// broadcastLeashHolder sets the leash holder entity data of the mob to the runtime ID of the holder,
+// or noLeashHolder if it is no longer leashed, and broadcasts it to the viewers of the mob.
+func broadcastLeashHolder(mob *ai.Mob, holderId int64) {
+	var entity = mob.GetBody().(mobBody)
+	entity.SetEntityProperty(data2.EntityDataLeashHolder, holderId)
+	entity.SetEntityProperty(data2.EntityDataLeashed, holderId != noLeashHolder)
+	entity.BroadcastUpdatedEntityData()
+}
+
+// getLeashedMobs returns the mobs in the dimension leashed to the holder with the given runtime ID.
+func (server *Server) getLeashedMobs(dimension *worlds.Dimension, holderId uint64) []*ai.Mob {
+	var leashed []*ai.Mob
+	for _, mob := range server.GetMobManager(dimension).GetMobs() {
+		if holder, ok := mob.GetLeashHolder(); ok && holder.GetRuntimeId() == holderId {
+			leashed = append(leashed, mob)
+		}
+	}
+	return leashed
+}
+
+// tieLeashes ties the mobs led by the player of the session to the leash post at the position,
+// using the leash knot on it or a new leash knot. Returns false if the block is no leash post within reach,
+// or if the player did not lead any mobs.
+func (server *Server) tieLeashes(session *net.MinecraftSession, position blocks.Position, blockName string) bool {
+	var player = session.GetPlayer()
+	var dimension = player.GetDimension()
+	var center = r3.Vector{X: float64(position.X) + 0.5, Y: float64(position.Y) + 0.5, Z: float64(position.Z) + 0.5}
+	if !leashPosts[blockName] || !combat.InReach(player.Position, center) {
+		return false
+	}
+	var leashed = server.getLeashedMobs(dimension, player.GetRuntimeId())
+	if len(leashed) == 0 {
+		return false
+	}
+	var knot = server.getLeashKnotAt(dimension, position)
+	for _, mob := range leashed {
+		server.Leash(dimension, mob, knot)
+	}
+	return true
+}
+
+// getLeashKnotAt returns the leash knot on the leash post at the position in the dimension.
+// A new leash knot is spawned if the post did not yet have one.
+// Leash knots are not persistent, as the leashes tied to them are not saved either.
+func (server *Server) getLeashKnotAt(dimension *worlds.Dimension, position blocks.Position) *PersistentEntity {
+	server.leashMutex.Lock()
+	defer server.leashMutex.Unlock()
+	var knots, ok = server.leashKnots[dimension]
+	if !ok {
+		knots = make(map[blocks.Position]*PersistentEntity)
+		server.leashKnots[dimension] = knots
+	}
+	if knot, ok := knots[position]; ok {
+		return knot
+	}
+	var knot = NewPersistentEntity(LeashKnotEntityType)
+	knot.SetPersistent(false)
+	dimension.AddEntity(knot, r3.Vector{X: float64(position.X) + 0.5, Y: float64(position.Y) + 0.5, Z: float64(position.Z) + 0.5})
+	knots[position] = knot
+	return knot
+}
+
+// getLeashKnot returns the leash knot with the given runtime ID in the dimension, and a bool indicating if it exists.
+func (server *Server) getLeashKnot(dimension *worlds.Dimension, runtimeId uint64) (*PersistentEntity, bool) {
+	server.leashMutex.Lock()
+	defer server.leashMutex.Unlock()
+	for _, knot := range server.leashKnots[dimension] {
+		if knot.GetRuntimeId() == runtimeId {
+			return knot, true
+		}
+	}
+	return nil, false
+}
+
+// removeLeashKnot despawns the leash knot in the dimension, dropping the leads of the mobs tied to it.
+func (server *Server) removeLeashKnot(dimension *worlds.Dimension, knot *PersistentEntity) {
+	server.leashMutex.Lock()
+	for position, other := range server.leashKnots[dimension] {
+		if other == knot {
+			delete(server.leashKnots[dimension], position)
+		}
+	}
+	server.leashMutex.Unlock()
+
+	for _, mob := range server.getLeashedMobs(dimension, knot.GetRuntimeId()) {
+		server.Unleash(dimension, mob, true)
+	}
+	knot.Close()
+}
+
+// dropLeashes unleashes the mobs led by the player of the session, dropping their leads.
+func (server *Server) dropLeashes(session *net.MinecraftSession) {
+	var dimension = session.GetPlayer().GetDimension()
+	if dimension == nil {
+		return
+	}
+	for _, mob := range server.getLeashedMobs(dimension, session.GetPlayer().GetRuntimeId()) {
+		server.Unleash(dimension, mob, true)
+	}
+}
+
+// breakLeashPost removes the leash knot on the leash post at the position in the dimension, if it had one.
+func (server *Server) breakLeashPost(dimension *worlds.Dimension, position blocks.Position) {
+	server.leashMutex.Lock()
+	var knot, ok = server.leashKnots[dimension][position]
+	server.leashMutex.Unlock()
+	if ok {
+		server.removeLeashKnot(dimension, knot)
+	}
+}
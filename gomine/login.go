@@ -0,0 +1,76 @@
+package gomine
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/BobbyShrd/gominetest/lang"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/packets/types"
+	"github.com/BobbyShrd/gominetest/utils"
+)
+
+// LoginResult is the result of an asynchronously verified login request.
+type LoginResult struct {
+	// Successful indicates if the login chains were valid.
+	Successful bool
+	// Authenticated indicates if the chains were signed by Mojang.
+	Authenticated bool
+	// ClientPublicKey is the public key of the client found in the chains.
+	ClientPublicKey *ecdsa.PublicKey
+	// EncryptionData is the encryption data with the shared secret already derived.
+	// EncryptionData is nil if encryption is not used.
+	EncryptionData *utils.EncryptionData
+	// EncryptionJwt is the signed JWT to send in the server handshake.
+	EncryptionJwt string
+}
+
+// VerifyLoginRequestAsync verifies the login chains and derives the encryption keys on the login worker pool.
+// The completion function gets called on the worker with the result once verification is done.
+// Returns an error if the login could not be queued, in which case the completion function is not called.
+func VerifyLoginRequestAsync(chains []types.Chain, server *Server, completion func(result LoginResult)) error {
+	return server.loginPool.Submit(func() {
+		var result = LoginResult{}
+		result.Successful, result.Authenticated, result.ClientPublicKey = VerifyLoginRequest(chains, server)
+
+		if result.Successful && server.Config.UseEncryption {
+			result.EncryptionData = &utils.EncryptionData{
+				ClientPublicKey:  result.ClientPublicKey,
+				ServerPrivateKey: server.GetPrivateKey(),
+				ServerToken:      server.GetServerToken(),
+			}
+			result.EncryptionData.ComputeSharedSecret()
+			result.EncryptionData.ComputeSecretKeyBytes()
+			result.EncryptionJwt = utils.ConstructEncryptionJwt(server.GetPrivateKey(), server.GetServerToken())
+		}
+		completion(result)
+	})
+}
+
+// resolveNameConflict resolves a login of the session with the name of a player that is already online,
+// ignoring the case of the name. It is called once the login was verified, so unverified logins can not kick anyone.
+// An existing player logged into XBOX Live may only be kicked by an authenticated login with the same XUID.
+// Otherwise a name conflict event decides if the existing player gets kicked, or if the new login gets refused.
+// Returns the kicked player, which the new login may replace in the session manager,
+// and false if the new login was refused.
+func (server *Server) resolveNameConflict(session *net.MinecraftSession, name string, xuid string, language string, authenticated bool) (*net.MinecraftSession, bool) {
+	var existing, ok = server.SessionManager.GetSessionIgnoreCase(name)
+	if !ok {
+		return nil, true
+	}
+	var sameAccount = authenticated && existing.IsXBOXLiveAuthenticated() && existing.GetXUID() == xuid
+	if existing.IsXBOXLiveAuthenticated() && !sameAccount {
+		session.Kick(lang.Translate(language, lang.KickNameTaken), false, false)
+		return nil, false
+	}
+	var event = NewNameConflictEvent(existing, session, name, server.Config.KickExistingOnNameConflict || sameAccount)
+	server.EventManager.Emit(event)
+	if event.IsCancelled() || !event.KickExisting {
+		session.Kick(lang.Translate(language, lang.KickNameTaken), false, false)
+		return nil, false
+	}
+	// The data of the existing player is saved before kicking,
+	// so the new login loads the latest data.
+	server.SavePlayerData(existing)
+	existing.Kick(server.Translate(existing, lang.KickOtherLocation), false, false)
+	return existing, true
+}
@@ -0,0 +1,112 @@
+package gomine
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/combat"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/loot"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/text"
+	"github.com/irmine/worlds/chunks"
+)
+
+// equipmentKey is the metadata key the equipment of mobs is saved under.
+const equipmentKey = "equipment"
+
+// GetEquipment returns the equipment of the entity, or nil if it has none.
+func (entity *PersistentEntity) GetEquipment() *loot.Equipment {
+	entity.mutex.RLock()
+	defer entity.mutex.RUnlock()
+	return entity.equipment
+}
+
+// SetEquipment sets the equipment of the entity, which is saved with the entity. Nil removes the equipment.
+// SetMobEquipment should be used to also show the equipment to the viewers of the entity.
+func (entity *PersistentEntity) SetEquipment(equipment *loot.Equipment) {
+	entity.mutex.Lock()
+	entity.equipment = equipment
+	entity.mutex.Unlock()
+	if equipment == nil {
+		entity.metadata.Remove(mobNamespace, equipmentKey)
+		return
+	}
+	entity.metadata.SetPersistent(mobNamespace, equipmentKey, string(equipment.Encode()))
+}
+
+// SetMobEquipment sets the equipment of the entity, and sends it to the viewers of the entity.
+// Weapons in the main hand increase the damage of the mob, and armor reduces the damage it takes.
+func (server *Server) SetMobEquipment(entity *PersistentEntity, equipment *loot.Equipment) {
+	entity.SetEquipment(equipment)
+	if equipment == nil {
+		equipment = loot.NewEquipment()
+	}
+	for _, viewer := range entity.GetViewers() {
+		if session, ok := viewer.(*net.MinecraftSession); ok {
+			sendEquipment(session, entity.GetRuntimeId(), equipment)
+		}
+	}
+}
+
+// loadEquipment gives the entity of the mob type the equipment saved with it.
+// Entities that did not roll their equipment yet roll it from the equipment table of their mob type, if it has one.
+// The rolled equipment is saved even if it is empty, so that it is only rolled once.
+func (server *Server) loadEquipment(entity *PersistentEntity, mobType mobType) {
+	if encoded, ok := entity.metadata.GetString(mobNamespace, equipmentKey); ok {
+		var equipment, err = loot.DecodeEquipment([]byte(encoded), items.DefaultManager)
+		if err != nil {
+			text.DefaultLogger.LogError(err)
+			return
+		}
+		entity.SetEquipment(equipment)
+		return
+	}
+	var table, ok = server.LootManager.GetEquipmentTable("entities/" + mobType.lootName)
+	if !ok {
+		return
+	}
+	var random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	server.SetMobEquipment(entity, table.Roll(random, items.DefaultManager))
+}
+
+// sendChunkEquipment sends the equipment of the mobs in a chunk loaded by the session.
+func (server *Server) sendChunkEquipment(session *net.MinecraftSession, chunk *chunks.Chunk) {
+	for _, mob := range server.GetMobManager(session.GetPlayer().GetDimension()).GetMobs() {
+		var entity = mob.GetBody().(mobBody)
+		var position = entity.GetPosition()
+		if int32(math.Floor(position.X))>>4 != chunk.X || int32(math.Floor(position.Z))>>4 != chunk.Z {
+			continue
+		}
+		if equipment := entity.GetEquipment(); equipment != nil && !equipment.IsEmpty() {
+			sendEquipment(session, entity.GetRuntimeId(), equipment)
+		}
+	}
+}
+
+// sendEquipment sends the item in the main hand and the armor of the entity with the given runtime ID to the session.
+func sendEquipment(session *net.MinecraftSession, runtimeId uint64, equipment *loot.Equipment) {
+	session.SendMobEquipment(runtimeId, equipment.Get(loot.SlotMainHand), 0, 0, byte(InventoryWindowId))
+	var armor [4]*items.Stack
+	copy(armor[:], equipment.GetArmor())
+	session.SendMobArmorEquipment(runtimeId, armor)
+}
+
+// getWeaponBonus returns the extra damage dealt by the entity with the weapon in its main hand.
+func getWeaponBonus(entity *PersistentEntity) float32 {
+	var equipment = entity.GetEquipment()
+	if equipment == nil {
+		return 0
+	}
+	return combat.GetDamage(equipment.Get(loot.SlotMainHand)) - combat.FistDamage
+}
+
+// getEquipmentArmor returns the pieces of armor worn by the entity.
+func getEquipmentArmor(entity *PersistentEntity) []*items.Stack {
+	var equipment = entity.GetEquipment()
+	if equipment == nil {
+		return nil
+	}
+	return equipment.GetArmor()
+}
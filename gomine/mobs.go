@@ -0,0 +1,249 @@
+package gomine
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/ai"
+	"github.com/BobbyShrd/gominetest/combat"
+	"github.com/BobbyShrd/gominetest/gamerules"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+)
+
+// mobType is the default AI of a type of mob.
+type mobType struct {
+	// name is the name of the mob in death messages.
+	name string
+	// speed is the amount of blocks the mob walks per tick.
+	speed float64
+	// damage is the damage dealt by attacks of the mob. Mobs without damage are passive.
+	damage float32
+	// lootName is the name of the loot table and equipment table of the mob, without the "entities/" prefix.
+	lootName string
+}
+
+// mobTypes are the mobs that get AI when spawned, by legacy entity type ID.
+var mobTypes = map[uint32]mobType{
+	10: {"a Chicken", 0.1, 0, "chicken"},
+	11: {"a Cow", 0.1, 0, "cow"},
+	12: {"a Pig", 0.1, 0, "pig"},
+	13: {"a Sheep", 0.1, 0, "sheep"},
+	14: {"a Wolf", 0.15, 0, "wolf"},
+	15: {"a Villager", 0.1, 0, "villager"},
+	16: {"a Mooshroom", 0.1, 0, "mooshroom"},
+	18: {"a Rabbit", 0.15, 0, "rabbit"},
+	22: {"an Ocelot", 0.15, 0, "ocelot"},
+	30: {"a Parrot", 0.1, 0, "parrot"},
+	32: {"a Zombie", 0.15, 3, "zombie"},
+	35: {"a Spider", 0.2, 2, "spider"},
+	44: {"a Zombie Villager", 0.15, 3, "zombie_villager"},
+	47: {"a Husk", 0.15, 3, "husk"},
+	75: {"a Cat", 0.15, 0, "cat"},
+}
+
+// mobBody lets a mob control a persistent entity.
+type mobBody struct {
+	*PersistentEntity
+}
+
+// Move moves the entity to the position, facing in the direction of the yaw and pitch.
+// The movement is sent to the viewers of the entity when its dimension ticks.
+func (body mobBody) Move(position r3.Vector, yaw float64, pitch float64) {
+	body.Position = position
+	body.Rotation.Yaw, body.Rotation.HeadYaw, body.Rotation.Pitch = yaw, yaw, pitch
+	body.HasMovementUpdate = true
+}
+
+// GetMobManager returns the mob manager of the given dimension.
+// A new manager gets created if the dimension did not yet have one.
+func (server *Server) GetMobManager(dimension *worlds.Dimension) *ai.Manager {
+	server.mobMutex.Lock()
+	defer server.mobMutex.Unlock()
+	var manager, ok = server.mobManagers[dimension]
+	if !ok {
+		manager = ai.NewManager()
+		manager.TickLimit = server.Config.MaxEntityTicks
+		manager.OwnerFunction = server.getOwnerFunction(dimension)
+		manager.PeacefulFunction = func() bool {
+			return server.GetWorldSettings(dimension.GetLevel()).Difficulty == levels.Peaceful
+		}
+		server.mobManagers[dimension] = manager
+	}
+	return manager
+}
+
+// AddMob makes the entity in the dimension a mob controlled by the given behaviors,
+// walking the given amount of blocks per tick. Mobs attack players in the dimension
+// with the name of their entity type, or "a Mob" if the entity type has no default AI.
+func (server *Server) AddMob(dimension *worlds.Dimension, entity *PersistentEntity, speed float64, behaviors ...ai.Behavior) *ai.Mob {
+	var mob = ai.NewMob(mobBody{entity}, speed, behaviors...)
+	var name = "a Mob"
+	if mobType, ok := mobTypes[entity.GetEntityType()]; ok {
+		name = mobType.name
+	}
+	mob.AttackFunction = func(mob *ai.Mob, target ai.Target, damage float32) {
+		server.handleMobAttack(dimension, mob, name, target, damage)
+	}
+	mob.LeashBreakFunction = func(mob *ai.Mob, holder ai.Target) {
+		server.leashReleased(dimension, mob, holder, true)
+	}
+	mob.Species = entity.GetEntityType()
+	mob.BreedFunction = func(mob *ai.Mob, partner *ai.Mob) {
+		server.breed(dimension, mob, partner)
+	}
+	mob.GrowUpFunction = server.growUp
+	server.GetMobManager(dimension).Add(mob)
+	return mob
+}
+
+// RemoveMob stops the AI of the entity with the given runtime ID in the dimension.
+// Returns false if the entity was not a mob.
+func (server *Server) RemoveMob(dimension *worlds.Dimension, runtimeId uint64) bool {
+	return server.GetMobManager(dimension).Remove(runtimeId)
+}
+
+// addDefaultMob gives the entity the default AI of its entity type, if it has any.
+// Passive mobs wander around, and hostile mobs attack players nearby.
+// Tameable mobs follow their owner once tamed, and get back the owner saved with their entity.
+// Babies that were saved before growing up continue growing up, and villagers get back their profession and trades.
+// Mobs get back their equipment, or roll it from the equipment table of their mob type when they first spawn.
+func (server *Server) addDefaultMob(dimension *worlds.Dimension, entity *PersistentEntity) {
+	var mobType, ok = mobTypes[entity.GetEntityType()]
+	if !ok {
+		return
+	}
+	server.loadEquipment(entity, mobType)
+	if entity.GetEntityType() == VillagerEntityType {
+		server.addVillager(dimension, entity, mobType.speed)
+		return
+	}
+	if IsTameable(entity.GetEntityType()) {
+		var mob = server.AddMob(dimension, entity, mobType.speed, ai.NewTameableBehaviors()...)
+		loadOwnership(mob)
+		server.loadAge(mob)
+		return
+	}
+	if mobType.damage == 0 {
+		server.loadAge(server.AddMob(dimension, entity, mobType.speed, ai.NewPassiveBehaviors()...))
+		return
+	}
+	server.AddMob(dimension, entity, mobType.speed, ai.NewHostileBehaviors(mobType.damage)...)
+}
+
+// tickMobs ticks the mobs of all dimensions, targeting the spawned players in their dimension.
+func (server *Server) tickMobs() {
+	server.mobMutex.Lock()
+	var managers = make(map[*worlds.Dimension]*ai.Manager, len(server.mobManagers))
+	for dimension, manager := range server.mobManagers {
+		managers[dimension] = manager
+	}
+	server.mobMutex.Unlock()
+
+	var targets = make(map[*worlds.Dimension][]ai.Target)
+	for _, session := range server.SessionManager.GetSessions() {
+		if dimension := session.GetPlayer().GetDimension(); session.HasSpawned() && dimension != nil {
+			targets[dimension] = append(targets[dimension], session.GetPlayer())
+		}
+	}
+	for dimension, manager := range managers {
+		if deferred := manager.Tick(server.GetDimensionWorld(dimension), targets[dimension]); deferred > 0 {
+			server.Metrics.DeferredEntityTicks.Add(uint64(deferred))
+			server.warnTickBudget("entity", dimension, deferred)
+		}
+	}
+}
+
+// handleMobAttack handles an attack of a mob with the given name on a player in the dimension.
+// The weapon in the main hand of the mob adds to its damage, which is scaled by the difficulty of the world.
+// Mobs do not hurt players in worlds with the peaceful difficulty.
+func (server *Server) handleMobAttack(dimension *worlds.Dimension, mob *ai.Mob, name string, target ai.Target, damage float32) {
+	var session, ok = server.GetSessionByRuntimeId(target.GetRuntimeId())
+	if !ok || !session.HasSpawned() {
+		return
+	}
+	var difficulty = server.GetWorldSettings(dimension.GetLevel()).Difficulty
+	if difficulty == levels.Peaceful {
+		return
+	}
+	if !server.CombatManager.TryHurt(target.GetRuntimeId()) {
+		return
+	}
+	if server.blockWithShield(session, mob.GetBody().GetPosition(), nil) {
+		return
+	}
+	var victim = session.GetPlayer()
+	damage = levels.ScaleDamage(difficulty, damage+getWeaponBonus(mob.GetBody().(mobBody).PersistentEntity))
+	damage = server.protect(session, damage*victim.GetEffects().GetDamageMultiplier(), combat.SourceAttack)
+
+	var health = victim.GetHealth() - damage
+	if health <= 0 {
+		server.kill(session, name)
+		return
+	}
+	victim.SetHealth(health)
+	session.SendUpdateAttributes(victim.GetRuntimeId(), victim.GetAttributeMap())
+	server.pushPlayer(session, server.KnockbackProfile.GetGlobal().GetMotion(mob.GetBody().GetPosition(), victim.Position))
+	server.broadcastHurt(session, combat.EntityEventHurt)
+}
+
+// attackMob handles an attack of the session on the mob, using the item the player holds.
+// The damage is reduced by the armor the mob wears, and the mob dies once its health drops to 0.
+// Returns false if the mob could not be reached or was hurt too recently.
+func (server *Server) attackMob(session *net.MinecraftSession, mob *ai.Mob) bool {
+	var attacker, entity = session.GetPlayer(), mob.GetBody().(mobBody).PersistentEntity
+	var item = attacker.GetHeldItem()
+	if !combat.InReach(attacker.Position, entity.Position) {
+		return false
+	}
+	var charge = server.CombatManager.Attack(attacker.GetRuntimeId(), combat.GetCooldown(item))
+	if !server.CombatManager.TryHurt(entity.GetRuntimeId()) {
+		return false
+	}
+	var result = server.DamageOptions.Calculate(item, attacker.GetEffects().GetAttackBonus(), charge, server.isFalling(session))
+	var damage = combat.ReduceDamage(result.Amount, getEquipmentArmor(entity), combat.SourceAttack, server.DamageOptions.Enchantments)
+
+	var health = entity.GetHealth() - damage
+	if health <= 0 {
+		server.killMob(attacker.GetDimension(), mob)
+		return true
+	}
+	entity.SetHealth(health)
+	entity.BroadcastEvent(EntityEventHurt, 0)
+	return true
+}
+
+// killMob kills the mob in the dimension, removing its entity.
+// The mob drops the items of the loot table of its mob type, and each piece of its equipment with its drop chance.
+func (server *Server) killMob(dimension *worlds.Dimension, mob *ai.Mob) {
+	var entity = mob.GetBody().(mobBody).PersistentEntity
+	entity.BroadcastEvent(EntityEventDeath, 0)
+	server.Unleash(dimension, mob, true)
+	server.RemoveMob(dimension, entity.GetRuntimeId())
+	server.GetVillagerManager(dimension).Remove(entity.GetRuntimeId())
+	if manager, ok := server.GetEntityManager(dimension); ok {
+		manager.Untrack(entity)
+	}
+
+	if !server.IsGameRuleEnabled(dimension.GetLevel(), gamerules.DoMobLoot) {
+		entity.Close()
+		return
+	}
+	var random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	var drops []*items.Stack
+	if mobType, ok := mobTypes[entity.GetEntityType()]; ok {
+		if table, ok := server.LootManager.GetTable("entities/" + mobType.lootName); ok {
+			drops = table.Roll(random, items.DefaultManager)
+		}
+	}
+	if equipment := entity.GetEquipment(); equipment != nil {
+		drops = append(drops, equipment.Drops(random)...)
+	}
+	for _, drop := range drops {
+		server.DropItem(dimension, entity.Position, drop)
+	}
+	entity.Close()
+}
@@ -0,0 +1,127 @@
+package gomine
+
+import (
+	"os"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/metadata"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/players"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// OfflinePlayer is a handle to a player that has played on the server before,
+// which may or may not currently be online.
+// Changes made to an online player apply to the online player directly.
+// Handles retrieved while the player was offline do not follow the player once it joins.
+type OfflinePlayer struct {
+	server   *Server
+	name     string
+	path     string
+	data     *players.Data
+	metadata *metadata.Store
+}
+
+// GetOfflinePlayer returns a handle to the player with the given name.
+// A bool is returned indicating if the player is online or has played on the server before.
+func (server *Server) GetOfflinePlayer(name string) (*OfflinePlayer, bool) {
+	if session, ok := server.SessionManager.GetSessionIgnoreCase(name); ok {
+		return &OfflinePlayer{server: server, name: session.GetName(), path: server.getSessionDataPath(session), data: session.GetPlayer().GetData(), metadata: session.GetPlayer().GetMetadata()}, true
+	}
+	if xuid, ok := server.playerNames.GetXUID(name); ok {
+		return server.loadOfflinePlayer(name, server.GetPlayerDataPathByXUID(xuid))
+	}
+	return server.loadOfflinePlayer(name, server.GetPlayerDataPath(name))
+}
+
+// GetOfflinePlayerByXUID returns a handle to the player with the given XUID.
+// Only players that were logged into XBOX Live can be found by XUID.
+// A bool is returned indicating success.
+func (server *Server) GetOfflinePlayerByXUID(xuid string) (*OfflinePlayer, bool) {
+	if xuid == "" {
+		return nil, false
+	}
+	if session, ok := server.SessionManager.GetSessionByXUID(xuid); ok {
+		return server.GetOfflinePlayer(session.GetName())
+	}
+	return server.loadOfflinePlayer("", server.GetPlayerDataPathByXUID(xuid))
+}
+
+// loadOfflinePlayer returns a handle to the player with the data file at the given path.
+// The name recorded in the data is preferred over the given name.
+// A bool is returned indicating if the data file exists.
+func (server *Server) loadOfflinePlayer(name string, path string) (*OfflinePlayer, bool) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, false
+	}
+	var playerData, err = players.LoadData(path)
+	if err != nil {
+		text.DefaultLogger.LogError(err)
+		return nil, false
+	}
+	if playerData.Name != "" {
+		name = playerData.Name
+	}
+	var store = metadata.NewStore()
+	store.Decode(playerData.Metadata)
+	return &OfflinePlayer{server: server, name: name, path: path, data: playerData, metadata: store}, true
+}
+
+// GetName returns the name of the player.
+func (player *OfflinePlayer) GetName() string {
+	return player.name
+}
+
+// GetXUID returns the XUID of the player, or an empty string if it is not known.
+func (player *OfflinePlayer) GetXUID() string {
+	if session, ok := player.GetSession(); ok {
+		return session.GetXUID()
+	}
+	return player.data.XUID
+}
+
+// GetLastPlayed returns the time the data of the player was last saved.
+// A zero time is returned if it is not known.
+func (player *OfflinePlayer) GetLastPlayed() time.Time {
+	if player.data.LastPlayed == 0 {
+		return time.Time{}
+	}
+	return time.Unix(player.data.LastPlayed, 0)
+}
+
+// GetData returns the persisted data of the player.
+func (player *OfflinePlayer) GetData() *players.Data {
+	return player.data
+}
+
+// GetMetadata returns the metadata store of the player.
+// Only persistent metadata is available for players that are not online.
+func (player *OfflinePlayer) GetMetadata() *metadata.Store {
+	return player.metadata
+}
+
+// IsOnline checks if the player is currently online.
+func (player *OfflinePlayer) IsOnline() bool {
+	var _, ok = player.GetSession()
+	return ok
+}
+
+// GetSession returns the session of the player if it is online.
+// A bool is returned indicating if the player is online.
+func (player *OfflinePlayer) GetSession() (*net.MinecraftSession, bool) {
+	var session, ok = player.server.SessionManager.GetSessionIgnoreCase(player.name)
+	if !ok || session.GetPlayer().GetData() != player.data {
+		return nil, false
+	}
+	return session, true
+}
+
+// Save saves the data of the player.
+func (player *OfflinePlayer) Save() error {
+	if session, ok := player.GetSession(); ok {
+		player.server.SavePlayerData(session)
+		return nil
+	}
+	player.data.Metadata = player.metadata.Encode()
+	return player.data.Save(player.path)
+}
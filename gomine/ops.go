@@ -0,0 +1,63 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// DefaultPermissionLevels holds the op levels required for the permissions of the default commands,
+// indexed by permission name. Permissions not registered require the highest op level.
+var DefaultPermissionLevels = map[string]int{
+	"gomine.list":         0,
+	"gomine.ping":         0,
+	"gomine.channel":      0,
+	"gomine.menu":         0,
+	"gomine.nick":         1,
+	"gomine.mute":         2,
+	"gomine.teleport":     2,
+	"gomine.world":        2,
+	"gomine.title":        2,
+	"gomine.playsound":    2,
+	"gomine.particle":     2,
+	"gomine.time":         2,
+	"gomine.weather":      2,
+	"gomine.gamerule":     2,
+	"gomine.difficulty":   2,
+	"gomine.gamemode":     2,
+	"gomine.commandblock": 2,
+	"gomine.joininfo":     2,
+	"gomine.transfer":     3,
+	"gomine.knockback":    3,
+	"gomine.op":           3,
+	"gomine.reload":       4,
+	"gomine.stop":         4,
+	"gomine.restart":      4,
+	"gomine.save":         4,
+}
+
+// getOpLevel returns the op level of the session, matching it by XUID if it is authenticated with XBOX Live.
+// The op list is saved if the XUID of the session was filled in, or if the operator was renamed.
+func (server *Server) getOpLevel(session *net.MinecraftSession) int {
+	var level, changed = server.OpList.GetPlayerLevel(session.GetName(), session.GetXUID(), session.IsXBOXLiveAuthenticated())
+	if changed {
+		text.DefaultLogger.LogError(server.OpList.Save())
+	}
+	return level
+}
+
+// SetOpLevel sets the op level of the player with the given name and saves the op list.
+// The player is removed from the op list if the level is 0.
+// If the player is online, the commands available to it and its operator badge are sent again.
+func (server *Server) SetOpLevel(name string, level int) {
+	server.OpList.SetLevel(name, level)
+	text.DefaultLogger.LogError(server.OpList.Save())
+	if session, ok := server.SessionManager.GetSessionIgnoreCase(name); ok {
+		session.SetOpLevel(server.getOpLevel(session))
+		server.SendAvailableCommands(session)
+		if session.HasSpawned() {
+			var abilities = session.GetPlayer().GetAbilities()
+			abilities.Operator = session.GetOpLevel() > 0
+			server.SetAbilities(session, abilities)
+		}
+	}
+}
@@ -5,21 +5,29 @@ import (
 	"crypto/sha512"
 	"crypto/x509"
 	"encoding/base64"
-	"github.com/golang/geo/r3"
+	"errors"
+	"github.com/BobbyShrd/gominetest/chat"
+	"github.com/BobbyShrd/gominetest/gamerules"
+	"github.com/BobbyShrd/gominetest/lang"
 	"github.com/BobbyShrd/gominetest/net"
 	"github.com/BobbyShrd/gominetest/net/info"
+	"github.com/BobbyShrd/gominetest/net/logincache"
 	"github.com/BobbyShrd/gominetest/net/packets"
 	"github.com/BobbyShrd/gominetest/net/packets/bedrock"
 	"github.com/BobbyShrd/gominetest/net/packets/data"
 	"github.com/BobbyShrd/gominetest/net/packets/types"
 	"github.com/BobbyShrd/gominetest/net/protocol"
 	"github.com/BobbyShrd/gominetest/players"
+	"github.com/BobbyShrd/gominetest/skins"
 	"github.com/BobbyShrd/gominetest/text"
-	"github.com/BobbyShrd/gominetest/utils"
+	"github.com/BobbyShrd/gominetest/vehicles"
+	"github.com/irmine/binutils"
+	"github.com/irmine/gonbt"
 	"github.com/irmine/worlds/blocks"
 	"github.com/irmine/worlds/chunks"
 	data2 "github.com/irmine/worlds/entities/data"
 	utils2 "github.com/irmine/worlds/utils"
+	"math"
 	"math/big"
 	"strings"
 	"time"
@@ -29,7 +37,8 @@ func NewClientHandshakeHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		if _, ok := packet.(*bedrock.ClientHandshakePacket); ok {
 			session.SendPlayStatus(data.StatusLoginSuccess)
-			session.SendResourcePackInfo(server.Config.ForceResourcePacks, server.PackManager.GetResourceStack(), server.PackManager.GetBehaviorStack())
+			server.setJoinPhase(session, net.PhasePacks)
+			session.SendResourcePackInfo(server.Config.ForceResourcePacks, server.PackManager.GetResourceStack(), server.PackManager.GetBehaviorStack(), server.PackManager.GetPackURLs())
 			return true
 		}
 		return false
@@ -39,24 +48,19 @@ func NewClientHandshakeHandler(server *Server) *net.PacketHandler {
 func NewCommandRequestHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		if pk, ok := packet.(*bedrock.CommandRequestPacket); ok {
-			var args = strings.Split(pk.CommandText, " ")
-			var commandName = strings.TrimLeft(args[0], "/")
-			var i = 1
-			for !server.CommandManager.IsCommandRegistered(commandName) {
-				if i == len(args) {
-					break
-				}
-				commandName += " " + args[i]
-				i++
-			}
-			if !server.CommandManager.IsCommandRegistered(commandName) {
-				session.SendMessage("Command could not be found.")
+			var command, args, ok = server.ParseCommand(pk.CommandText)
+			if !ok {
+				session.SendMessage(text.NewComponent().Color(text.Red).Translate("commands.generic.unknown", strings.TrimLeft(pk.CommandText, "/")))
+				server.suggestCommands(session, pk.CommandText)
 				return false
 			}
-			args = args[i:]
-			var command, _ = server.CommandManager.GetCommand(commandName)
-			command.Execute(session, args)
-
+			var output = command.Execute(session, args)
+			if output.HasErrors() || server.IsGameRuleEnabled(server.getSenderLevel(session), gamerules.SendCommandFeedback) {
+				session.SendCommandOutput(pk.CommandOrigin, output)
+			}
+			if output.HasErrors() {
+				server.suggestCommands(session, pk.CommandText)
+			}
 			return true
 		}
 
@@ -67,80 +71,124 @@ func NewCommandRequestHandler(server *Server) *net.PacketHandler {
 func NewLoginHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		if loginPacket, ok := packet.(*bedrock.LoginPacket); ok {
-			var _, ok = server.SessionManager.GetSession(loginPacket.Username)
-			if ok {
+			var username, err = text.SanitizeWithPolicy(loginPacket.Username, server.Config.SanitizePolicy)
+			if err != nil {
+				session.Kick(lang.Translate(loginPacket.Language, lang.KickInvalidUsername), false, false)
+				return false
+			}
+			loginPacket.Username = username
+
+			if err := text.ValidateUsername(loginPacket.Username); err != nil {
+				text.DefaultLogger.Debug(loginPacket.Username, "has tried to join with an invalid username:", err)
+				session.Kick(lang.Translate(loginPacket.Language, lang.KickInvalidUsername), false, false)
 				return false
 			}
 
 			if loginPacket.Protocol > info.LatestProtocol {
-				session.Kick("Outdated server.", false, true)
+				session.Kick(lang.Translate(loginPacket.Language, lang.KickOutdatedServer), false, true)
 				return false
 			}
 
 			if loginPacket.Protocol < info.LatestProtocol {
-				session.Kick("Outdated client.", false, true)
+				session.Kick(lang.Translate(loginPacket.Language, lang.KickOutdatedClient), false, true)
 				return false
 			}
 
-			var successful, authenticated, pubKey = VerifyLoginRequest(loginPacket.Chains, server)
-
-			if !successful {
-				text.DefaultLogger.Debug(loginPacket.Username, "has joined with invalid login data.")
-				return true
+			if !server.forwardAddress(session, loginPacket.Username, loginPacket.ClientData.WaterdogIP) {
+				return false
 			}
 
-			if authenticated {
-				text.DefaultLogger.Debug(loginPacket.Username, "has joined while being logged into XBOX Live.")
-			} else {
-				if server.Config.XBOXLiveAuth {
-					text.DefaultLogger.Debug(loginPacket.Username, "has tried to join while not being logged into XBOX Live.")
-					session.Kick("XBOX Live account required.", false, false)
-					return true
+			var err = VerifyLoginRequestAsync(loginPacket.Chains, server, func(result LoginResult) {
+				if !result.Successful {
+					text.DefaultLogger.Debug(loginPacket.Username, "has joined with invalid login data.")
+					session.ReportError(net.NewFatalError(lang.Translate(loginPacket.Language, lang.KickInvalidLogin), nil))
+					return
 				}
-				text.DefaultLogger.Debug(loginPacket.Username, "has joined while not being logged into XBOX Live.")
-			}
 
-			session.SetData(server.PermissionManager, types.SessionData{ClientUUID: loginPacket.ClientUUID, ClientXUID: loginPacket.ClientXUID, ClientId: loginPacket.ClientId, ProtocolNumber: loginPacket.Protocol, GameVersion: loginPacket.ClientData.GameVersion, Language: loginPacket.Language, DeviceOS: loginPacket.ClientData.DeviceOS})
-			session.SetPlayer(players.NewPlayer(loginPacket.ClientUUID, loginPacket.ClientXUID, int32(loginPacket.ClientData.DeviceOS), loginPacket.Username))
+				if result.Authenticated {
+					text.DefaultLogger.Debug(loginPacket.Username, "has joined while being logged into XBOX Live.")
+				} else {
+					if server.Config.XBOXLiveAuth {
+						text.DefaultLogger.Debug(loginPacket.Username, "has tried to join while not being logged into XBOX Live.")
+						session.Kick(lang.Translate(loginPacket.Language, lang.KickXBOXLiveRequired), false, false)
+						return
+					}
+					text.DefaultLogger.Debug(loginPacket.Username, "has joined while not being logged into XBOX Live.")
+				}
+				if !server.screenConnection(session, loginPacket.Username) {
+					return
+				}
+				var replaced, ok = server.resolveNameConflict(session, loginPacket.Username, loginPacket.ClientXUID, loginPacket.Language, result.Authenticated)
+				if !ok {
+					return
+				}
 
-			session.GetEncryptionHandler().Data = &utils.EncryptionData{
-				ClientPublicKey:  pubKey,
-				ServerPrivateKey: server.GetPrivateKey(),
-				ServerToken:      server.GetServerToken(),
-			}
+				session.SetData(server.PermissionManager, types.SessionData{ClientUUID: loginPacket.ClientUUID, ClientXUID: loginPacket.ClientXUID, ClientId: loginPacket.ClientId, ProtocolNumber: loginPacket.Protocol, GameVersion: loginPacket.ClientData.GameVersion, Language: loginPacket.Language, DeviceOS: loginPacket.ClientData.DeviceOS})
+				server.lookupCountry(session)
+				session.SetPlayer(players.NewPlayer(loginPacket.ClientUUID, loginPacket.ClientXUID, int32(loginPacket.ClientData.DeviceOS), loginPacket.Username))
 
-			session.GetPlayer().SetName(loginPacket.Username)
-			session.GetPlayer().SetDisplayName(loginPacket.Username)
-			session.GetPlayer().SetSkinId(loginPacket.SkinId)
-			session.GetPlayer().SetSkinData(loginPacket.SkinData)
-			session.GetPlayer().SetCapeData(loginPacket.CapeData)
-			session.GetPlayer().SetGeometryName(loginPacket.GeometryName)
-			session.GetPlayer().SetGeometryData(loginPacket.GeometryData)
-			session.SetXBOXLiveAuthenticated(authenticated)
+				session.GetPlayer().SetName(loginPacket.Username)
+				session.GetPlayer().SetPlatformChatId(loginPacket.ClientData.PlatformOnlineId)
+				session.GetPlayer().SetDisplayName(loginPacket.Username)
+				session.SetXBOXLiveAuthenticated(result.Authenticated)
+				session.SetOpLevel(server.getOpLevel(session))
 
-			if server.Config.UseEncryption {
-				var jwt = utils.ConstructEncryptionJwt(server.GetPrivateKey(), server.GetServerToken())
-				session.SendServerHandshake(jwt)
-				session.EnableEncryption()
-			} else {
-				session.SendPlayStatus(data.StatusLoginSuccess)
-				session.SendResourcePackInfo(server.Config.ForceResourcePacks, server.PackManager.GetResourceStack(), server.PackManager.GetBehaviorStack())
-			}
+				var skin, err = skins.FromLegacy(loginPacket.SkinId, loginPacket.SkinData, loginPacket.CapeData, loginPacket.GeometryName, loginPacket.GeometryData)
+				if err != nil {
+					session.Kick(server.Translate(session, lang.KickInvalidSkin), false, false)
+					return
+				}
+				skin.Trusted = result.Authenticated
+				text.DefaultLogger.LogError(server.SetSkin(session, skin))
+				server.LoadPlayerData(session)
+
+				// The session has to be added before the handshake continues,
+				// so packets sent in response are handled by this session.
+				// Another login with the same name may have been added since the conflict was resolved.
+				if !server.SessionManager.TryAddMinecraftSession(session, replaced) {
+					session.Kick(lang.Translate(loginPacket.Language, lang.KickNameTaken), false, false)
+					return
+				}
 
-			server.SessionManager.AddMinecraftSession(session)
+				if server.Config.UseEncryption {
+					session.GetEncryptionHandler().Data = result.EncryptionData
+					session.SendServerHandshake(result.EncryptionJwt)
+					session.EnableEncryption()
+				} else {
+					session.SendPlayStatus(data.StatusLoginSuccess)
+					server.setJoinPhase(session, net.PhasePacks)
+					session.SendResourcePackInfo(server.Config.ForceResourcePacks, server.PackManager.GetResourceStack(), server.PackManager.GetBehaviorStack(), server.PackManager.GetPackURLs())
+				}
+			})
+			if err != nil {
+				text.DefaultLogger.Debug(loginPacket.Username, "could not be verified:", err)
+				session.Kick(lang.Translate(loginPacket.Language, lang.KickServerBusy), false, false)
+			}
 			return true
 		}
 		return false
 	})
 }
 
-func NewMovePlayerHandler(_ *Server) *net.PacketHandler {
+func NewMovePlayerHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		if pk, ok := packet.(*bedrock.MovePlayerPacket); ok {
 			if session.GetPlayer().GetDimension() == nil {
 				return false
 			}
+			if server.isMovementOutdated(session, pk.Position) {
+				return true
+			}
+			if server.Config.ValidateMovement {
+				var position = session.GetPlayer().Position
+				if math.Hypot(pk.Position.X-position.X, pk.Position.Z-position.Z) > getMovementDistance(session) || !server.isValidRise(session, position.Y, pk.Position.Y, pk.OnGround) {
+					session.Teleport(position)
+					return true
+				}
+			}
 			session.SyncMove(pk.Position.X, pk.Position.Y, pk.Position.Z, pk.Rotation.Pitch, pk.Rotation.Yaw, pk.Rotation.HeadYaw, pk.OnGround)
+			server.stepOnBlocks(session, pk.Position.X, pk.Position.Y, pk.Position.Z, pk.OnGround)
+			server.handleFall(session, pk.Position.X, pk.Position.Y, pk.Position.Z, pk.OnGround)
 			return true
 		}
 		return false
@@ -154,35 +202,45 @@ func NewRequestChunkRadiusHandler(server *Server) *net.PacketHandler {
 			session.SetViewDistance(viewDistance)
 			session.SendChunkRadiusUpdated(viewDistance)
 
-			var sessions = server.SessionManager.GetSessions()
-			var viewers = make(map[string]protocol.PlayerListEntry)
-			for name, online := range sessions {
-				if online.HasSpawned() {
-					viewers[name] = online.GetPlayer()
-					online.SendPlayerList(data.ListTypeAdd, map[string]protocol.PlayerListEntry{session.GetName(): session.GetPlayer()})
+			if !server.PlayerList.IsCustomized() {
+				var sessions = server.SessionManager.GetSessions()
+				var viewers = make(map[string]protocol.PlayerListEntry)
+				for name, online := range sessions {
+					if online.HasSpawned() {
+						viewers[name] = online.GetPlayer()
+						online.SendPlayerList(data.ListTypeAdd, map[string]protocol.PlayerListEntry{session.GetName(): session.GetPlayer()})
+					}
 				}
-			}
 
-			session.SendPlayerList(data.ListTypeAdd, viewers)
+				session.SendPlayerList(data.ListTypeAdd, viewers)
+			}
 
 			for _, online := range server.SessionManager.GetSessions() {
-				if session.GetUUID() != online.GetUUID() {
+				if session.GetUUID() != online.GetUUID() && online.GetPlayer().GetDimension() == session.GetPlayer().GetDimension() {
+					online.SendSkin(session)
+					session.SendSkin(online)
+
 					online.GetPlayer().SpawnPlayerTo(session)
 					online.GetPlayer().AddViewer(session)
 
 					session.GetPlayer().SpawnPlayerTo(online)
 					session.GetPlayer().AddViewer(online)
-
-					online.SendSkin(session)
-					session.SendSkin(online)
 				}
 			}
 
 			session.SendSetEntityData(session.GetPlayer().GetRuntimeId(), session.GetPlayer().GetEntityData())
 			session.SendUpdateAttributes(session.GetPlayer().GetRuntimeId(), session.GetPlayer().GetAttributeMap())
 
-			server.BroadcastMessage(text.Yellow+session.GetDisplayName(), "has joined the server")
+			server.broadcastTranslation(text.Yellow, lang.PlayerJoined, session.GetDisplayName())
 			session.SendPlayStatus(data.StatusSpawn)
+			server.setJoinPhase(session, net.PhaseSpawned)
+			server.ApplyWorldSettings(session)
+			server.SendAvailableCommands(session)
+			server.addPlayerName(session)
+			server.SendWelcome(session)
+			server.sendScoreboard(session)
+			server.updatePlayerList()
+			server.applyDisguises(session)
 
 			session.Connected = true
 			return true
@@ -196,11 +254,10 @@ func NewResourcePackChunkRequestHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		if request, ok := packet.(*bedrock.ResourcePackChunkRequestPacket); ok {
 			if !server.PackManager.IsPackLoaded(request.PackUUID) {
-				// TODO: Kick the player. We can't kick yet.
-				return false
+				session.ReportError(net.NewFatalError(server.Translate(session, lang.ErrorUnknownPack), errors.New("unknown pack " + request.PackUUID)))
+				return true
 			}
-			var pack = server.PackManager.GetPack(request.PackUUID)
-			session.SendResourcePackChunkData(request.PackUUID, request.ChunkIndex, int64(data.ResourcePackChunkSize*request.ChunkIndex), pack.GetChunk(int(data.ResourcePackChunkSize*request.ChunkIndex), data.ResourcePackChunkSize))
+			server.sendPackChunk(session, server.PackManager.GetPack(request.PackUUID), request.ChunkIndex)
 			return true
 		}
 		return false
@@ -212,24 +269,36 @@ func NewResourcePackClientResponseHandler(server *Server) *net.PacketHandler {
 		if response, ok := packet.(*bedrock.ResourcePackClientResponsePacket); ok {
 			switch response.Status {
 			case data.StatusRefused:
-				// TODO: Kick the player. We can't kick yet.
-				return false
+				if server.Config.ForceResourcePacks {
+					session.ReportError(net.NewFatalError(server.Translate(session, lang.ErrorPacksRequired), nil))
+					return true
+				}
+				session.SendResourcePackStack(false, server.PackManager.GetResourceStack(), server.PackManager.GetBehaviorStack())
 			case data.StatusSendPacks:
+				var chunkSize = server.getPackChunkSize(session)
 				for _, packUUID := range response.PackUUIDs {
 					if !server.PackManager.IsPackLoaded(packUUID) {
-						// TODO: Kick the player. We can't kick yet.
-						return false
+						session.ReportError(net.NewFatalError(server.Translate(session, lang.ErrorUnknownPack), errors.New("unknown pack " + packUUID)))
+						return true
 					}
-					session.SendResourcePackDataInfo(server.PackManager.GetPack(packUUID))
+					server.startPackDownload(session, server.PackManager.GetPack(packUUID), chunkSize)
 				}
 			case data.StatusHaveAllPacks:
 				session.SendResourcePackStack(server.Config.ForceResourcePacks, server.PackManager.GetResourceStack(), server.PackManager.GetBehaviorStack())
 			case data.StatusCompleted:
-				server.LevelManager.GetDefaultLevel().GetDefaultDimension().LoadChunk(0, 0, func(chunk *chunks.Chunk) {
-					server.LevelManager.GetDefaultLevel().GetDefaultDimension().AddEntity(session.GetPlayer(), r3.Vector{X: 0, Y: 7, Z: 0})
-					server.LevelManager.GetDefaultLevel().GetDefaultDimension().AddViewer(session, r3.Vector{X: 0, Y: 7, Z: 0})
-					session.SendStartGame(session.GetPlayer(), blocks.GetRuntimeIdsTable())
+				server.setJoinPhase(session, net.PhaseChunks)
+				server.PackTransfers.Complete(session.GetXUID())
+				var dimension, position = server.GetSpawnDimension(session)
+				var chunkX, chunkZ = int32(math.Floor(position.X)) >> 4, int32(math.Floor(position.Z)) >> 4
+				server.GetDimensionWorld(dimension).RequestChunk(chunkX, chunkZ).Then(func(chunk *chunks.Chunk) {
+					dimension.AddEntity(session.GetPlayer(), position)
+					dimension.AddViewer(session, position)
+					var level = session.GetPlayer().GetDimension().GetLevel()
+					var spawn = server.GetWorldSpawn(level)
+					session.SendStartGame(session.GetPlayer(), server.GetLevelSeed(level), blocks.NewPosition(int32(math.Floor(spawn.X)), uint32(spawn.Y), int32(math.Floor(spawn.Z))), server.GetRuntimeIdsTable(session), server.getBlockProperties(), getGameRuleEntries(server.GetGameRules(level)), server.GetWorldSettings(level).Difficulty)
 					session.SendCraftingData()
+					server.SendInventory(session)
+					server.SpawnItemEntitiesTo(session)
 				})
 			}
 			return true
@@ -244,52 +313,285 @@ func NewTextHandler(server *Server) *net.PacketHandler {
 			if textPacket.TextType != data.TextChat {
 				return false
 			}
-			for _, receiver := range server.SessionManager.GetSessions() {
+			if session.GetPlayer().GetAbilities().Muted {
+				return true
+			}
+			if expiry, muted := server.ChatManager.GetMuteExpiry(session); muted {
+				if expiry.IsZero() {
+					session.SendMessage(text.NewComponent().Color(text.Red).Text("You are muted."))
+				} else {
+					session.SendMessage(text.NewComponent().Color(text.Red).Text("You are muted for another " + session.GetLocale().FormatDuration(time.Until(expiry)) + "."))
+				}
+				return true
+			}
+			var chatMessage, err = text.SanitizeWithPolicy(textPacket.Message, server.Config.SanitizePolicy)
+			if err == text.UnsanitizedText {
+				session.SendMessage(text.NewComponent().Color(text.Red).Text("Your message contains characters that are not allowed."))
+				return true
+			}
+			if err != nil {
+				session.ReportError(net.NewNotice("Your message could not be sent.", err))
+				return true
+			}
+			var channel = server.ChatManager.GetChannel(session)
+			if !channel.CanChat(session) {
+				channel, _ = server.ChatManager.GetChannelByName(chat.Global)
+			}
+
+			var event = NewChatEvent(session, channel, chatMessage, server.Config.ChatFormat, server.ChatManager.GetChannelReceivers(channel, session, server.SessionManager.GetSessions()))
+			server.EventManager.Emit(event)
+			if event.IsCancelled() {
+				return true
+			}
+
+			var message = event.GetFormattedMessage()
+			for _, receiver := range event.Receivers {
 				receiver.SendText(types.Text{
-					Message: "<" + session.GetDisplayName() + "> " + textPacket.Message,
+					Message: message,
 					PlatformChatId: textPacket.PlatformChatId,
 					SourceXUID: session.GetXUID(),
 					TextType: data.TextChat,
 				})
 			}
-			text.DefaultLogger.LogChat("<" + session.GetDisplayName() + "> " + textPacket.Message)
+			text.DefaultLogger.LogChat(message)
 			return true
 		}
 		return false
 	})
 }
 
-func NewInteractHandler(_ *Server) *net.PacketHandler {
+func NewModalFormResponseHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
-		if /*interactPacket*/ _, ok := packet.(*bedrock.InteractPacket); ok {
+		if response, ok := packet.(*bedrock.ModalFormResponsePacket); ok {
+			return server.FormManager.HandleResponse(session, response.FormId, response.FormData)
+		}
+		return false
+	})
+}
+
+func NewBlockEntityDataHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if blockEntityData, ok := packet.(*bedrock.BlockEntityDataPacket); ok {
+			if !session.HasSpawned() {
+				return false
+			}
+			var compound = gonbt.NewReader(blockEntityData.NamedTag, true, binutils.LittleEndian).ReadIntoCompound()
+			if compound == nil || compound.GetString("id", "") != "Sign" {
+				return false
+			}
+			server.editSign(session, blockEntityData.Position, compound.GetString("Text", ""))
+			return true
+		}
+		return false
+	})
+}
+
+func NewCommandBlockUpdateHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if update, ok := packet.(*bedrock.CommandBlockUpdatePacket); ok {
+			if !session.HasSpawned() || !update.IsBlock {
+				return false
+			}
+			server.editCommandBlock(session, update.Position, update.Command, update.ShouldTrackOutput)
+			return true
+		}
+		return false
+	})
+}
+
+func NewContainerCloseHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if containerClose, ok := packet.(*bedrock.ContainerClosePacket); ok {
+			if window, ok := server.WindowManager.Get(session.GetName()); ok && window.GetId() == containerClose.WindowId {
+				server.CloseWindow(session)
+			}
+			if containerClose.WindowId == TradeWindowId {
+				server.closeTrade(session)
+			}
+			return true
+		}
+		return false
+	})
+}
+
+func NewInteractHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if interactPacket, ok := packet.(*bedrock.InteractPacket); ok {
+			switch interactPacket.Action {
+			case bedrock.InteractLeftClick:
+				server.HandleAttack(session, interactPacket.RuntimeId, session.GetPlayer().GetHeldItem())
+			case bedrock.InteractRightClick:
+				server.Mount(session, interactPacket.RuntimeId)
+			case bedrock.InteractLeaveVehicle:
+				server.Dismount(session)
+			}
 		}
 		return true
 	})
 }
 
-func NewPlayerActionHandler(_ *Server) *net.PacketHandler {
+func NewMoveEntityHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if pk, ok := packet.(*bedrock.MoveEntityPacket); ok {
+			if session.GetPlayer().GetDimension() == nil {
+				return false
+			}
+			server.driveVehicle(session, pk.RuntimeId, pk.Position, pk.Rotation.Yaw)
+			return true
+		}
+		return false
+	})
+}
+
+func NewPlayerInputHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if pk, ok := packet.(*bedrock.PlayerInputPacket); ok {
+			var vehicle, riding = server.GetRiddenVehicle(session)
+			if !riding {
+				return false
+			}
+			if pk.Sneaking {
+				server.Dismount(session)
+				return true
+			}
+			vehicle.SetInput(vehicles.Input{Forward: float64(pk.MotionY), Strafe: float64(pk.MotionX), Yaw: session.GetPlayer().Rotation.Yaw})
+			return true
+		}
+		return false
+	})
+}
+
+func NewPlayerSkinHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if pk, ok := packet.(*bedrock.PlayerSkinPacket); ok {
+			if !session.HasSpawned() {
+				return false
+			}
+			var skin, err = skins.FromLegacy(pk.SkinId, pk.SkinData, pk.CapeData, pk.GeometryName, pk.GeometryData)
+			server.changeSkin(session, skin, err)
+			return true
+		}
+		return false
+	})
+}
+
+func NewNetworkStackLatencyHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if pk, ok := packet.(*bedrock.NetworkStackLatencyPacket); ok {
+			return session.HandleLatencyResponse(pk.Timestamp)
+		}
+		return false
+	})
+}
+
+func NewTickSyncHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if pk, ok := packet.(*bedrock.TickSyncPacket); ok {
+			session.SendTickSync(pk.ClientRequestTimestamp, server.GetCurrentTick())
+			return true
+		}
+		return false
+	})
+}
+
+func NewAdventureSettingsHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if pk, ok := packet.(*bedrock.AdventureSettingsPacket); ok {
+			if !session.HasSpawned() {
+				return false
+			}
+			server.handleFlightToggle(session, pk.Flags&players.FlagFlying != 0)
+			return true
+		}
+		return false
+	})
+}
+
+func NewSetDifficultyHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if pk, ok := packet.(*bedrock.SetDifficultyPacket); ok {
+			if !session.HasSpawned() {
+				return false
+			}
+			server.handleSetDifficulty(session, int32(pk.Difficulty))
+			return true
+		}
+		return false
+	})
+}
+
+func NewPlayerActionHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
-		//TODO: fix sending to others
 		if playerAction, ok := packet.(*bedrock.PlayerActionPacket); ok {
+			var player = session.GetPlayer()
+			if player.GetDimension() == nil {
+				return false
+			}
 			switch playerAction.Action {
 			case bedrock.PlayerStartSneak:
-				session.GetPlayer().SetEntityProperty(data2.EntityDataSneaking, true)
-				break
+				player.SetSneaking(true)
+				player.SetEntityProperty(data2.EntityDataSneaking, true)
+				player.BroadcastUpdatedEntityData()
+				server.updateBlocking(session)
 			case bedrock.PlayerStopSneak:
-				session.GetPlayer().SetEntityProperty(data2.EntityDataSneaking, false)
-				break
+				player.SetSneaking(false)
+				player.SetEntityProperty(data2.EntityDataSneaking, false)
+				player.BroadcastUpdatedEntityData()
+				server.updateBlocking(session)
 			case bedrock.PlayerStartSprint:
-				session.GetPlayer().SetEntityProperty(data2.EntityDataSprinting, true)
-				break
+				player.SetEntityProperty(data2.EntityDataSprinting, true)
+				player.BroadcastUpdatedEntityData()
 			case bedrock.PlayerStopSprint:
-				session.GetPlayer().SetEntityProperty(data2.EntityDataSprinting, false)
-				break
+				player.SetEntityProperty(data2.EntityDataSprinting, false)
+				player.BroadcastUpdatedEntityData()
+			case bedrock.PlayerJump:
+				for _, viewer := range player.GetViewers() {
+					if viewer, ok := viewer.(*net.MinecraftSession); ok {
+						viewer.SendPlayerAction(player.GetRuntimeId(), playerAction.Action, playerAction.Position, playerAction.Face)
+					}
+				}
+			case bedrock.PlayerStartBreak:
+				var position = utils2.PositionToVector(playerAction.Position)
+				var block, err = player.GetDimension().GetBlockAt(position)
+				if err != nil {
+					return false
+				}
+				server.StartBreak(session, playerAction.Position)
+				var breakTime = server.GetPlayerBreakTime(session, block.GetName(), player.GetHeldItem())
+				if breakTime <= 0 {
+					return true
+				}
+				for _, viewer := range player.GetViewers() {
+					if viewer, ok := viewer.(*net.MinecraftSession); ok {
+						viewer.SendLevelEvent(data.LevelEventBlockStartBreak, position, int32(65535/breakTime))
+					}
+				}
+			case bedrock.PlayerAbortBreak, bedrock.PlayerStopBreak:
+				if playerAction.Action == bedrock.PlayerAbortBreak {
+					server.AbortBreak(session)
+				}
+				var position = utils2.PositionToVector(playerAction.Position)
+				for _, viewer := range player.GetViewers() {
+					if viewer, ok := viewer.(*net.MinecraftSession); ok {
+						viewer.SendLevelEvent(data.LevelEventBlockStopBreak, position, 0)
+					}
+				}
 			}
 		}
 		return true
 	})
 }
 
+func NewMobEquipmentHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if equipment, ok := packet.(*bedrock.MobEquipmentPacket); ok && equipment.WindowId == byte(InventoryWindowId) {
+			session.GetPlayer().SetHeldSlot(int(equipment.HotbarSlot))
+			server.updateBlocking(session)
+		}
+		return true
+	})
+}
+
 func NewAnimateHandler(_ *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		if animate, ok := packet.(*bedrock.AnimatePacket); ok {
@@ -303,63 +605,126 @@ func NewAnimateHandler(_ *Server) *net.PacketHandler {
 	})
 }
 
-func NewInventoryTransactionHandler(_ *Server) *net.PacketHandler {
+func NewInventoryTransactionHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		if invTransaction, ok := packet.(*bedrock.InventoryTransactionPacket); ok {
 			var clickPos = invTransaction.BlockPosition
 			switch invTransaction.TransactionType {
+			case bedrock.Normal:
+				server.handleInventoryActions(session, invTransaction.Actions)
+				server.updateBlocking(session)
+				break
 			case bedrock.UseItem:
 				switch invTransaction.ActionType {
 				case bedrock.ItemBreakBlock:
-					runtimeId, ok := blocks.GetRuntimeId(0, 0)
+					var dimension = session.GetPlayer().GetDimension()
+					var broken, err = dimension.GetBlockAt(utils2.PositionToVector(clickPos))
+					if err != nil {
+						break
+					}
+					var held = session.GetPlayer().GetHeldItem()
+					if !server.canBreak(session, clickPos, broken.GetName(), held) {
+						if runtimeId, ok := server.GetDimensionWorld(dimension).GetBlockRuntimeId(clickPos); ok {
+							session.SendUpdateBlock(clickPos, runtimeId, 0)
+						}
+						break
+					}
+					runtimeId, ok := server.GetBlockRuntimeId(0, 0)
 					if ok {
+						server.dropBlockItems(session, clickPos, broken.GetName(), held)
+						server.FarmManager.Break(server.GetDimensionWorld(session.GetPlayer().GetDimension()), clickPos)
 						var block= blocks.New(blocks.NewBlockState("air", int32(runtimeId), 0, 0))
 						session.GetPlayer().GetDimension().SetBlockAt(utils2.PositionToVector(clickPos), block)
+						server.GetDimensionWorld(session.GetPlayer().GetDimension()).MarkDirty(clickPos)
+						server.GetDimensionWorld(session.GetPlayer().GetDimension()).custom.Remove(clickPos)
+						if world := server.GetDimensionWorld(session.GetPlayer().GetDimension()); world.IsWaterlogged(clickPos) {
+							world.BreakBlock(clickPos)
+						}
+						server.breakLeashPost(session.GetPlayer().GetDimension(), clickPos)
+						server.releaseWorkstation(session.GetPlayer().GetDimension(), clickPos)
+						server.breakBlockEntity(session.GetPlayer().GetDimension(), clickPos)
+						server.RedstoneEngine.Update(server.GetDimensionWorld(session.GetPlayer().GetDimension()), clickPos)
 					}
 					break
 				case bedrock.ItemClickBlock:
+					var block, err = session.GetPlayer().GetDimension().GetBlockAt(utils2.PositionToVector(clickPos))
+					if err == nil && block.GetName() == ItemFrameBlock && server.UseItemFrame(session, clickPos) {
+						break
+					}
+					if err == nil && server.tieLeashes(session, clickPos, block.GetName()) {
+						break
+					}
+					if err == nil && server.InteractionRegistry.HandleBlockInteraction(session, clickPos, block.GetName()) {
+						break
+					}
+					if server.InteractionRegistry.HandleItemUse(session, session.GetPlayer().GetHeldItem(), clickPos, byte(invTransaction.Face)) {
+						break
+					}
 					// TODO: do block placing
 					break
 				}
 				break
+			case bedrock.UseItemOnEntity:
+				switch invTransaction.ActionType {
+				case bedrock.ItemOnEntityAttack:
+					server.HandleAttack(session, invTransaction.RuntimeId, session.GetPlayer().GetHeldItem())
+				case bedrock.ItemOnEntityInteract:
+					server.interactEntity(session, invTransaction.RuntimeId)
+				}
+				break
 			}
 		}
 		return true
 	})
 }
 
-func VerifyLoginRequest(chains []types.Chain, _ *Server) (successful bool, authenticated bool, clientPublicKey *ecdsa.PublicKey) {
+func VerifyLoginRequest(chains []types.Chain, server *Server) (successful bool, authenticated bool, clientPublicKey *ecdsa.PublicKey) {
+	var cache = server.LoginCache
+	var certificates = make([]string, len(chains))
+	for i, chain := range chains {
+		certificates[i] = chain.Header.Raw + "." + chain.Payload.Raw + "." + chain.Signature
+	}
+	if result, ok := cache.GetChain(certificates); ok {
+		return true, result.Authenticated, result.ClientPublicKey
+	}
+
 	var publicKey *ecdsa.PublicKey
 	var publicKeyRaw string
-	for _, chain := range chains {
+	var keys []string
+	var expiration int64
+	for i, chain := range chains {
 		if publicKeyRaw == "" {
 			if chain.Header.X5u == "" {
 				return
 			}
 			publicKeyRaw = chain.Header.X5u
 		}
+		keys = append(keys, publicKeyRaw)
 
-		sig := []byte(chain.Signature)
-		d := []byte(chain.Header.Raw + "." + chain.Payload.Raw)
+		// Certificates verified before with the same key are not verified again.
+		if !cache.HasCertificate(publicKeyRaw, certificates[i]) {
+			sig := []byte(chain.Signature)
+			d := []byte(chain.Header.Raw + "." + chain.Payload.Raw)
 
-		var b64, errB64 = base64.RawStdEncoding.DecodeString(publicKeyRaw)
-		text.DefaultLogger.LogError(errB64)
+			var b64, errB64 = base64.RawStdEncoding.DecodeString(publicKeyRaw)
+			text.DefaultLogger.LogError(errB64)
 
-		key, err := x509.ParsePKIXPublicKey(b64)
-		if err != nil {
-			text.DefaultLogger.LogError(err)
-			return
-		}
+			key, err := x509.ParsePKIXPublicKey(b64)
+			if err != nil {
+				text.DefaultLogger.LogError(err)
+				return
+			}
 
-		hash := sha512.New384()
-		hash.Write(d)
+			hash := sha512.New384()
+			hash.Write(d)
 
-		publicKey = key.(*ecdsa.PublicKey)
-		r := new(big.Int).SetBytes(sig[:len(sig)/2])
-		s := new(big.Int).SetBytes(sig[len(sig)/2:])
+			publicKey = key.(*ecdsa.PublicKey)
+			r := new(big.Int).SetBytes(sig[:len(sig)/2])
+			s := new(big.Int).SetBytes(sig[len(sig)/2:])
 
-		if !ecdsa.Verify(publicKey, hash.Sum(nil), r, s) {
-			return
+			if !ecdsa.Verify(publicKey, hash.Sum(nil), r, s) {
+				return
+			}
 		}
 
 		if publicKeyRaw == data.MojangPublicKey {
@@ -370,6 +735,10 @@ func VerifyLoginRequest(chains []types.Chain, _ *Server) (successful bool, authe
 		if chain.Payload.ExpirationTime <= t && chain.Payload.ExpirationTime != 0 || chain.Payload.NotBefore > t || chain.Payload.IssuedAt > chain.Payload.ExpirationTime {
 			return
 		}
+		cache.AddCertificate(publicKeyRaw, certificates[i], chain.Payload.ExpirationTime)
+		if chain.Payload.ExpirationTime != 0 && (expiration == 0 || chain.Payload.ExpirationTime < expiration) {
+			expiration = chain.Payload.ExpirationTime
+		}
 
 		publicKeyRaw = chain.Payload.IdentityPublicKey
 	}
@@ -384,6 +753,7 @@ func VerifyLoginRequest(chains []types.Chain, _ *Server) (successful bool, authe
 	}
 
 	clientPublicKey = key.(*ecdsa.PublicKey)
+	cache.AddChain(certificates, keys, logincache.Result{Authenticated: authenticated, ClientPublicKey: clientPublicKey}, expiration)
 
 	successful = true
 	return
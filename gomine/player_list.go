@@ -0,0 +1,75 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/protocol"
+)
+
+// HidePlayer hides the player with the given name from the player list of every player.
+// The player stays visible in the world.
+func (server *Server) HidePlayer(name string) {
+	server.PlayerList.Hide(name)
+	server.RefreshPlayerList()
+}
+
+// ShowPlayer shows a player hidden with HidePlayer in the player list again.
+func (server *Server) ShowPlayer(name string) {
+	server.PlayerList.Show(name)
+	server.RefreshPlayerList()
+}
+
+// SetPlayerListPriority sets the priority of the player in the player list. Players with a higher priority are listed first.
+func (server *Server) SetPlayerListPriority(name string, priority int) {
+	server.PlayerList.SetPriority(name, priority)
+	server.RefreshPlayerList()
+}
+
+// RefreshPlayerList re-sends the player list to every player, in the order of the player list manager.
+// It should be called after changing the priority function of the player list manager.
+func (server *Server) RefreshPlayerList() {
+	for _, session := range server.SessionManager.GetSessions() {
+		if session.HasSpawned() {
+			server.sendPlayerList(session)
+		}
+	}
+}
+
+// updatePlayerList updates the player list of every player after an entry was added to it or removed from it,
+// if the player list is ordered or has hidden entries. Entries are added without the player list manager otherwise,
+// so it forgets what it listed, and the next update sends the complete player list again.
+func (server *Server) updatePlayerList() {
+	if server.PlayerList.IsCustomized() {
+		server.RefreshPlayerList()
+	} else {
+		server.PlayerList.Reset()
+	}
+}
+
+// sendPlayerList updates the player list of the session to list the visible entries in order.
+// Only the entries changed since the last update are removed and added, in a single batch.
+// All entries are removed and added again if the player list of the session was not updated before.
+func (server *Server) sendPlayerList(session *net.MinecraftSession) {
+	var sessions = make(map[string]*net.MinecraftSession)
+	var names []string
+	for name, online := range server.SessionManager.GetSessions() {
+		if online.HasSpawned() {
+			sessions[name] = online
+			names = append(names, name)
+		}
+	}
+	var removedNames, addedNames, listed = server.PlayerList.Update(session.GetName(), names)
+	if !listed {
+		removedNames = names
+	}
+	var removed = make(map[string]protocol.PlayerListEntry)
+	for _, name := range removedNames {
+		if online, ok := sessions[name]; ok {
+			removed[name] = online.GetPlayer()
+		}
+	}
+	var added = make(map[string]protocol.PlayerListEntry)
+	for _, name := range addedNames {
+		added[name] = server.getListEntry(sessions[name], session)
+	}
+	session.SendPlayerListChanges(removed, addedNames, added)
+}
@@ -1,5 +1,11 @@
 package gomine
 
+import (
+	"github.com/BobbyShrd/gominetest/commands"
+	"github.com/BobbyShrd/gominetest/events"
+	"github.com/BobbyShrd/gominetest/tasks"
+)
+
 type Manifest struct {
 	Name         string
 	Description  string
@@ -7,6 +13,9 @@ type Manifest struct {
 	APIVersion   string
 	Author       string
 	Organisation string
+	// Dependencies are the names of the plugins the plugin depends on.
+	// The plugin gets disabled before the plugins it depends on.
+	Dependencies []string
 }
 
 type IManifest interface {
@@ -21,23 +30,30 @@ type IManifest interface {
 type IPlugin interface {
 	GetServer() *Server
 	OnEnable()
+	OnDisable()
 
 	GetName() string
 	GetVersion() string
 	GetAuthor() string
 	GetOrganisation() string
 	GetAPIVersion() string
+	GetDependencies() []string
 	setManifest(IManifest)
+	cleanup()
 }
 
 type Plugin struct {
 	server *Server
 
 	manifest IManifest
+
+	commands []string
+	handlers []*events.Handler
+	tasks    []*tasks.Task
 }
 
 func NewPlugin(server *Server) *Plugin {
-	return &Plugin{server, Manifest{}}
+	return &Plugin{server, Manifest{}, []string{}, []*events.Handler{}, []*tasks.Task{}}
 }
 
 // GetName returns the name of the manifest.
@@ -70,6 +86,11 @@ func (manifest Manifest) GetDescription() string {
 	return manifest.Description
 }
 
+// GetDependencies returns the names of the plugins the plugin of the manifest depends on.
+func (manifest Manifest) GetDependencies() []string {
+	return manifest.Dependencies
+}
+
 // GetName returns the name of the plugin.
 func (plug *Plugin) GetName() string {
 	return plug.manifest.GetName()
@@ -100,6 +121,15 @@ func (plug *Plugin) GetDescription() string {
 	return plug.manifest.GetDescription()
 }
 
+// GetDependencies returns the names of the plugins the plugin depends on.
+// Manifests without a GetDependencies function have no dependencies.
+func (plug *Plugin) GetDependencies() []string {
+	if manifest, ok := plug.manifest.(interface{ GetDependencies() []string }); ok {
+		return manifest.GetDependencies()
+	}
+	return nil
+}
+
 // SetManifest sets the manifest of this plugin.
 func (plug *Plugin) setManifest(manifest IManifest) {
 	plug.manifest = manifest
@@ -109,3 +139,71 @@ func (plug *Plugin) setManifest(manifest IManifest) {
 func (plug *Plugin) GetServer() *Server {
 	return plug.server
 }
+
+// OnDisable gets called when the plugin gets unloaded.
+// Plugins may override this function to save their state.
+func (plug *Plugin) OnDisable() {}
+
+// RegisterCommand registers a command owned by this plugin.
+// Commands registered through the plugin get deregistered when the plugin gets unloaded.
+func (plug *Plugin) RegisterCommand(command *commands.Command) {
+	plug.server.CommandManager.RegisterCommand(command)
+	plug.commands = append(plug.commands, command.GetName())
+}
+
+// RegisterEventHandler registers an event handler owned by this plugin with the given priority.
+// Event handlers registered through the plugin get deregistered when the plugin gets unloaded.
+func (plug *Plugin) RegisterEventHandler(function interface{}, priority int) (*events.Handler, error) {
+	var handler, err = plug.server.EventManager.RegisterHandler(function, priority)
+	if err != nil {
+		return nil, err
+	}
+	plug.handlers = append(plug.handlers, handler)
+	return handler, nil
+}
+
+// ScheduleDelayed schedules a task owned by this plugin to be executed after the given amount of ticks.
+// Tasks owned by the plugin get cancelled when the plugin gets unloaded.
+func (plug *Plugin) ScheduleDelayed(function func(), delay int64) *tasks.Task {
+	return plug.addTask(plug.server.GetScheduler().ScheduleDelayed(function, delay))
+}
+
+// ScheduleRepeating schedules a task owned by this plugin to be executed after the given delay in ticks,
+// and every period ticks after that until cancelled.
+func (plug *Plugin) ScheduleRepeating(function func(), delay int64, period int64) *tasks.Task {
+	return plug.addTask(plug.server.GetScheduler().ScheduleRepeating(function, delay, period))
+}
+
+// ScheduleAsync schedules a task owned by this plugin to be executed on a worker as soon as possible.
+func (plug *Plugin) ScheduleAsync(function func()) *tasks.Task {
+	return plug.addTask(plug.server.GetScheduler().ScheduleAsync(function))
+}
+
+// addTask adds a task to the tasks owned by the plugin,
+// and forgets tasks that will not be executed anymore.
+func (plug *Plugin) addTask(task *tasks.Task) *tasks.Task {
+	var active = plug.tasks[:0]
+	for _, owned := range plug.tasks {
+		if owned.IsActive() {
+			active = append(active, owned)
+		}
+	}
+	plug.tasks = append(active, task)
+	return task
+}
+
+// cleanup deregisters all commands and event handlers of the plugin, and cancels all its tasks.
+func (plug *Plugin) cleanup() {
+	for _, command := range plug.commands {
+		plug.server.CommandManager.DeregisterCommand(command)
+	}
+	for _, handler := range plug.handlers {
+		plug.server.EventManager.DeregisterHandler(handler)
+	}
+	for _, task := range plug.tasks {
+		task.Cancel()
+	}
+	plug.commands = []string{}
+	plug.handlers = []*events.Handler{}
+	plug.tasks = []*tasks.Task{}
+}
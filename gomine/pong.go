@@ -0,0 +1,34 @@
+package gomine
+
+import (
+	"strconv"
+)
+
+// SetMOTD sets the MOTD of the server shown in the server list, and advertises it right away.
+// Placeholders in the MOTD, such as "{online}", are replaced every time the MOTD is advertised.
+func (server *Server) SetMOTD(motd string) {
+	server.Pong.SetMOTD(motd)
+	server.UpdatePongData()
+}
+
+// UpdatePongData advertises the current pong data right away, for example after plugins changed the pong builder of the server.
+// The pong data is otherwise rendered again every second, as GoRakLib answers pings with the data advertised last.
+// Updates are serialized, so plugins may call it from any goroutine.
+func (server *Server) UpdatePongData() {
+	server.pongMutex.Lock()
+	server.NetworkAdapter.GetRakLibManager().PongData = server.GeneratePongData()
+	server.pongMutex.Unlock()
+}
+
+// registerPongPlaceholders registers the default placeholders of the MOTD and sub-MOTD,
+// which are the same as the placeholders of the welcome, without the player specific placeholders.
+func (server *Server) registerPongPlaceholders() {
+	server.Pong.SetPlaceholder("{online}", func() string {
+		return strconv.Itoa(server.SessionManager.GetSessionCount())
+	})
+	server.Pong.SetPlaceholder("{max_players}", func() string {
+		return strconv.Itoa(int(server.GetMaximumPlayers()))
+	})
+	server.Pong.SetPlaceholder("{server}", server.GetName)
+	server.Pong.SetPlaceholder("{version}", server.GetMinecraftVersion)
+}
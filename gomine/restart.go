@@ -0,0 +1,18 @@
+//go:build !windows
+// +build !windows
+
+package gomine
+
+import (
+	"os"
+	"syscall"
+)
+
+// restartProcess replaces the process with a new process of the same executable, arguments and environment.
+func restartProcess() error {
+	var executable, err = os.Executable()
+	if err != nil {
+		return err
+	}
+	return syscall.Exec(executable, os.Args, os.Environ())
+}
@@ -0,0 +1,23 @@
+package gomine
+
+import (
+	"os"
+	"os/exec"
+)
+
+// restartProcess starts a new process of the same executable with the same arguments,
+// attached to the same console, and exits the current process.
+// Windows can not replace a running process.
+func restartProcess() error {
+	var executable, err = os.Executable()
+	if err != nil {
+		return err
+	}
+	var command = exec.Command(executable, os.Args[1:]...)
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := command.Start(); err != nil {
+		return err
+	}
+	os.Exit(0)
+	return nil
+}
@@ -0,0 +1,169 @@
+package gomine
+
+import (
+	"math"
+
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/packets/types"
+	"github.com/BobbyShrd/gominetest/scoreboard"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// Identity types of scoreboard entries.
+const (
+	scoreIdentityPlayer = 1
+	scoreIdentityEntity = 2
+	scoreIdentityFake   = 3
+)
+
+// Action types of the SetScore packet.
+const (
+	scoreActionChange = 0
+	scoreActionRemove = 1
+)
+
+// AddObjective adds a new objective to the scoreboard of the server.
+// Objectives with the health criteria have the health of every player as score, updated automatically.
+func (server *Server) AddObjective(name string, displayName string, criteria string) (*scoreboard.Objective, error) {
+	var objective, err = server.Scoreboard.AddObjective(name, displayName, criteria)
+	if err != nil {
+		return nil, err
+	}
+	if criteria == scoreboard.CriteriaHealth {
+		for _, session := range server.SessionManager.GetSessions() {
+			objective.SetScore(session.GetName(), int(math.Ceil(float64(session.GetPlayer().GetHealth()))))
+		}
+	}
+	return objective, nil
+}
+
+// RemoveObjective removes the objective with the given name from the scoreboard,
+// and removes it from the screen of every player if it was displayed.
+func (server *Server) RemoveObjective(name string) bool {
+	var _, slots, ok = server.Scoreboard.RemoveObjective(name)
+	if !ok {
+		return false
+	}
+	if len(slots) != 0 {
+		for _, session := range server.SessionManager.GetSessions() {
+			session.SendRemoveObjective(name)
+		}
+	}
+	return true
+}
+
+// DisplayObjective displays the objective in the display slot for every player:
+// the sidebar, below the name tags of players or in the player list. Nil clears the display slot.
+func (server *Server) DisplayObjective(slot string, objective *scoreboard.Objective) error {
+	var previous, err = server.Scoreboard.SetDisplay(slot, objective)
+	if err != nil {
+		return err
+	}
+	for _, session := range server.SessionManager.GetSessions() {
+		if previous != nil && !server.Scoreboard.IsDisplayed(previous) {
+			session.SendRemoveObjective(previous.GetName())
+		}
+		if objective != nil {
+			server.sendObjective(session, slot, objective)
+		}
+	}
+	return nil
+}
+
+// SetScore sets the score of the entry of the objective, and sends it to every player if the objective is displayed.
+// Entries that are the names of online players are shown as that player.
+func (server *Server) SetScore(objective *scoreboard.Objective, entry string, value int) {
+	var score, changed = objective.SetScore(entry, value)
+	if !changed || !server.Scoreboard.IsDisplayed(objective) {
+		return
+	}
+	var entries = []types.ScoreboardEntry{server.newScoreboardEntry(objective, score)}
+	for _, session := range server.SessionManager.GetSessions() {
+		session.SendSetScore(scoreActionChange, entries)
+	}
+}
+
+// RemoveScore removes the score of the entry of the objective, and removes it from the screen of every player if displayed.
+func (server *Server) RemoveScore(objective *scoreboard.Objective, entry string) {
+	var score, ok = objective.RemoveScore(entry)
+	if !ok || !server.Scoreboard.IsDisplayed(objective) {
+		return
+	}
+	var entries = []types.ScoreboardEntry{server.newScoreboardEntry(objective, score)}
+	for _, session := range server.SessionManager.GetSessions() {
+		session.SendSetScore(scoreActionRemove, entries)
+	}
+}
+
+// ResolveScores returns a copy of the component with its scores filled in from the scoreboard of the server,
+// with the entry * resolved as the name of the player of the session.
+func (server *Server) ResolveScores(session *net.MinecraftSession, component *text.Component) *text.Component {
+	return component.ResolveScores(session.GetName(), func(entry string, objectiveName string) (int, bool) {
+		var objective, ok = server.Scoreboard.GetObjective(objectiveName)
+		if !ok {
+			return 0, false
+		}
+		score, ok := objective.GetScore(entry)
+		return score.Value, ok
+	})
+}
+
+// sendScoreboard sends all displayed objectives to a session that just joined.
+func (server *Server) sendScoreboard(session *net.MinecraftSession) {
+	for slot, objective := range server.Scoreboard.GetDisplays() {
+		server.sendObjective(session, slot, objective)
+	}
+}
+
+// sendObjective displays the objective in the display slot of the session, and sends all its scores.
+func (server *Server) sendObjective(session *net.MinecraftSession, slot string, objective *scoreboard.Objective) {
+	session.SendSetDisplayObjective(slot, objective.GetName(), objective.GetDisplayName(), objective.GetCriteria(), int32(objective.GetSortOrder()))
+	var scores = objective.GetScores()
+	if len(scores) == 0 {
+		return
+	}
+	var entries = make([]types.ScoreboardEntry, len(scores))
+	for i, score := range scores {
+		entries[i] = server.newScoreboardEntry(objective, score)
+	}
+	session.SendSetScore(scoreActionChange, entries)
+}
+
+// newScoreboardEntry returns the scoreboard entry of the score of the objective.
+// Scores of online players are linked to the player, so that they can be shown below their name and in the player list.
+func (server *Server) newScoreboardEntry(objective *scoreboard.Objective, score scoreboard.Score) types.ScoreboardEntry {
+	var entry = types.ScoreboardEntry{EntryId: score.Id, ObjectiveName: objective.GetName(), Score: int32(score.Value)}
+	if session, ok := server.SessionManager.GetSession(score.Entry); ok && session.GetPlayer() != nil {
+		entry.IdentityType = scoreIdentityPlayer
+		entry.EntityUniqueId = session.GetPlayer().GetUniqueId()
+		return entry
+	}
+	entry.IdentityType = scoreIdentityFake
+	entry.CustomName = score.Entry
+	return entry
+}
+
+// updateHealthScores updates the scores of objectives with the health criteria to the health of every player.
+// Only scores that changed are sent.
+func (server *Server) updateHealthScores() {
+	var objectives = server.Scoreboard.GetObjectivesByCriteria(scoreboard.CriteriaHealth)
+	if len(objectives) == 0 {
+		return
+	}
+	for _, session := range server.SessionManager.GetSessions() {
+		if !session.Connected {
+			continue
+		}
+		var health = int(math.Ceil(float64(session.GetPlayer().GetHealth())))
+		for _, objective := range objectives {
+			server.SetScore(objective, session.GetName(), health)
+		}
+	}
+}
+
+// removeHealthScores removes the scores of a player leaving the server from the objectives with the health criteria.
+func (server *Server) removeHealthScores(session *net.MinecraftSession) {
+	for _, objective := range server.Scoreboard.GetObjectivesByCriteria(scoreboard.CriteriaHealth) {
+		server.RemoveScore(objective, session.GetName())
+	}
+}
@@ -0,0 +1,93 @@
+package gomine
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/metrics"
+	"github.com/irmine/worlds/chunks"
+)
+
+// ServerMetrics holds the metrics of the server, exposed on the metrics endpoint if it is enabled.
+type ServerMetrics struct {
+	*metrics.Registry
+
+	PacketsReceived   *metrics.Rate
+	PacketsSent       *metrics.Rate
+	BytesUncompressed *metrics.Counter
+	BytesCompressed   *metrics.Counter
+	CompressionRatio  *metrics.Gauge
+	Ticks             *metrics.Counter
+	TickDuration      *metrics.Gauge
+
+	DeferredEntityTicks      *metrics.Counter
+	DeferredBlockEntityTicks *metrics.Counter
+}
+
+// NewServerMetrics returns the metrics of the given server,
+// and registers the instrumentation hooks of its network adapter.
+func NewServerMetrics(server *Server) *ServerMetrics {
+	var m = &ServerMetrics{
+		Registry:          metrics.NewRegistry(),
+		PacketsReceived:   metrics.NewRate("gomine_packets_received", "Minecraft packets received"),
+		PacketsSent:       metrics.NewRate("gomine_packets_sent", "Minecraft packets sent"),
+		BytesUncompressed: metrics.NewCounter("gomine_batch_uncompressed_bytes_total", "Size of all batches sent before compression."),
+		BytesCompressed:   metrics.NewCounter("gomine_batch_compressed_bytes_total", "Size of all batches sent after compression."),
+		CompressionRatio:  metrics.NewGauge("gomine_batch_compression_ratio", "Compressed size of all batches sent divided by their uncompressed size."),
+		Ticks:             metrics.NewCounter("gomine_ticks_total", "Server ticks done."),
+		TickDuration:      metrics.NewGauge("gomine_tick_duration_seconds", "Duration of the last server tick."),
+
+		DeferredEntityTicks:      metrics.NewCounter("gomine_deferred_entity_ticks_total", "Entity ticks deferred to later ticks because of the entity tick limit."),
+		DeferredBlockEntityTicks: metrics.NewCounter("gomine_deferred_block_entity_ticks_total", "Block entity ticks deferred to later ticks because of the block entity tick limit."),
+	}
+	for _, metric := range []metrics.Metric{m.PacketsReceived, m.PacketsSent, m.BytesUncompressed, m.BytesCompressed, m.CompressionRatio, m.Ticks, m.TickDuration, m.DeferredEntityTicks, m.DeferredBlockEntityTicks} {
+		m.Register(metric)
+	}
+	m.Register(metrics.NewGaugeFunction("gomine_online_players", "Players online.", func() float64 {
+		return float64(server.SessionManager.GetSessionCount())
+	}))
+	m.Register(metrics.NewGaugeFunction("gomine_loaded_chunks", "Chunks loaded by players.", func() float64 {
+		return float64(server.countLoadedChunks())
+	}))
+	m.Register(metrics.NewGaugeFunction("gomine_goroutines", "Goroutines running.", func() float64 {
+		return float64(runtime.NumGoroutine())
+	}))
+
+	server.NetworkAdapter.PacketsReceivedFunction = func(count int) {
+		m.PacketsReceived.Add(uint64(count))
+	}
+	server.NetworkAdapter.PacketsSentFunction = func(count int) {
+		m.PacketsSent.Add(uint64(count))
+	}
+	server.NetworkAdapter.BatchCompressedFunction = func(uncompressed int, compressed int) {
+		m.BytesUncompressed.Add(uint64(uncompressed))
+		m.BytesCompressed.Add(uint64(compressed))
+		if total := m.BytesUncompressed.Get(); total != 0 {
+			m.CompressionRatio.Set(float64(m.BytesCompressed.Get()) / float64(total))
+		}
+	}
+	return m
+}
+
+// RecordTick records a server tick that took the given duration.
+func (m *ServerMetrics) RecordTick(duration time.Duration) {
+	m.Ticks.Inc()
+	m.TickDuration.Set(duration.Seconds())
+}
+
+// UpdateRates updates the per second rates of the metrics.
+func (m *ServerMetrics) UpdateRates() {
+	m.PacketsReceived.Update()
+	m.PacketsSent.Update()
+}
+
+// countLoadedChunks returns the amount of distinct chunks loaded by all sessions.
+func (server *Server) countLoadedChunks() int {
+	var loaded = make(map[*chunks.Chunk]bool)
+	for _, session := range server.SessionManager.GetSessions() {
+		for _, chunk := range session.GetChunkLoader().GetLoadedChunks() {
+			loaded[chunk] = true
+		}
+	}
+	return len(loaded)
+}
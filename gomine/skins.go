@@ -0,0 +1,89 @@
+package gomine
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/skins"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// GetSkinStore returns the store the skins of players are saved in.
+func (server *Server) GetSkinStore() *skins.Store {
+	return server.skinStore
+}
+
+// getSkinKey returns the key the skin of the player with the given name and XUID is stored with.
+// Like player data, skins of XBOX Live players are stored by XUID,
+// and names and XUIDs are reduced to their last path element so the key stays inside the skin store.
+func getSkinKey(name string, xuid string) string {
+	if xuid != "" {
+		return "xuid/" + filepath.Base(xuid)
+	}
+	return filepath.Base(strings.ToLower(name))
+}
+
+// getSessionSkinKey returns the key the skin of the player of the session is stored with.
+func getSessionSkinKey(session *net.MinecraftSession) string {
+	if session.IsXBOXLiveAuthenticated() {
+		return getSkinKey(session.GetName(), session.GetXUID())
+	}
+	return getSkinKey(session.GetName(), "")
+}
+
+// SetSkin validates the skin and sets it as the skin of the player of the session.
+// The skin is sent to all players viewing the player, and saved with the player.
+func (server *Server) SetSkin(session *net.MinecraftSession, skin *skins.Skin) error {
+	if err := skin.Validate(); err != nil {
+		return err
+	}
+	session.GetPlayer().SetSkin(skin)
+	if session.HasSpawned() {
+		server.broadcastSkin(session)
+	}
+	return server.skinStore.Save(getSessionSkinKey(session), skin)
+}
+
+// broadcastSkin sends the skin of the player of the session to all players in the same dimension,
+// except to players that see a disguise with another skin.
+func (server *Server) broadcastSkin(session *net.MinecraftSession) {
+	for _, online := range server.SessionManager.GetSessions() {
+		if online == session || !online.HasSpawned() || online.GetPlayer().GetDimension() != session.GetPlayer().GetDimension() {
+			continue
+		}
+		if disguise, ok := server.GetDisguise(session.GetPlayer(), online); ok && disguise.Skin != nil {
+			continue
+		}
+		session.SendSkin(online)
+	}
+}
+
+// changeSkin changes the skin of the player of the session to a skin changed in game.
+// The skin is trusted if the player is logged into XBOX Live.
+// If the skin is invalid or the change is cancelled, the current skin is sent back so the client reverts it.
+func (server *Server) changeSkin(session *net.MinecraftSession, skin *skins.Skin, err error) {
+	if err == nil {
+		skin.Trusted = session.IsXBOXLiveAuthenticated()
+		var event = NewSkinChangeEvent(session, skin)
+		server.EventManager.Emit(event)
+		if !event.IsCancelled() {
+			if err = server.SetSkin(session, event.Skin); err == nil {
+				return
+			}
+		}
+	}
+	if err != nil {
+		text.DefaultLogger.Debug(session.GetName(), "sent an invalid skin:", err)
+	}
+	session.SendSkin(session)
+}
+
+// GetSkin returns the skin of the player. The skin of online players is returned directly,
+// while the skin of offline players is loaded from the skin store.
+func (player *OfflinePlayer) GetSkin() (*skins.Skin, error) {
+	if session, ok := player.GetSession(); ok {
+		return session.GetPlayer().GetSkin(), nil
+	}
+	return player.server.skinStore.Load(getSkinKey(player.name, player.data.XUID))
+}
@@ -0,0 +1,44 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/golang/geo/r3"
+)
+
+// PlaySound plays the sound with the given name at the position for all viewers of the dimension.
+func (world *DimensionWorld) PlaySound(name string, position r3.Vector, volume float32, pitch float32) {
+	world.forEachSession(func(session *net.MinecraftSession) {
+		session.PlaySound(name, position, volume, pitch)
+	})
+}
+
+// StopSound stops the sound with the given name for all viewers of the dimension.
+// All sounds are stopped if the name is empty.
+func (world *DimensionWorld) StopSound(name string) {
+	world.forEachSession(func(session *net.MinecraftSession) {
+		session.StopSound(name)
+	})
+}
+
+// PlaySoundEvent plays the level sound event with the given ID at the position for all viewers of the dimension.
+func (world *DimensionWorld) PlaySoundEvent(soundId uint32, position r3.Vector) {
+	world.forEachSession(func(session *net.MinecraftSession) {
+		session.PlaySoundEvent(soundId, position)
+	})
+}
+
+// SpawnParticle spawns the particle with the given ID at the position for all viewers of the dimension.
+func (world *DimensionWorld) SpawnParticle(particleId int32, position r3.Vector, data int32) {
+	world.forEachSession(func(session *net.MinecraftSession) {
+		session.SpawnParticle(particleId, position, data)
+	})
+}
+
+// forEachSession calls the function with every session viewing the dimension.
+func (world *DimensionWorld) forEachSession(function func(session *net.MinecraftSession)) {
+	for _, viewer := range world.dimension.GetViewers() {
+		if session, ok := viewer.(*net.MinecraftSession); ok {
+			function(session)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package gomine
+
+import (
+	"strconv"
+
+	"github.com/BobbyShrd/gominetest/text"
+	"github.com/irmine/worlds"
+)
+
+// TickBudgetWarningInterval is the minimum amount of ticks between two warnings
+// about the tick limit of the same kind of entities being exceeded.
+const TickBudgetWarningInterval = 1200
+
+// warnTickBudget logs a warning that the given amount of entities of a kind in the dimension
+// were deferred to later ticks, unless a warning for that kind was logged recently.
+func (server *Server) warnTickBudget(kind string, dimension *worlds.Dimension, deferred int) {
+	if last, ok := server.budgetWarnings[kind]; ok && server.tick-last < TickBudgetWarningInterval {
+		return
+	}
+	server.budgetWarnings[kind] = server.tick
+	text.DefaultLogger.Warning("The "+kind+" tick limit was exceeded in "+dimension.GetLevel().GetName()+":"+dimension.GetName()+",", strconv.Itoa(deferred), kind+" ticks were deferred.")
+}
@@ -0,0 +1,20 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/lang"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// Translate returns the translation of the key in the language of the session, with the arguments formatted into it.
+// Sessions that did not yet send their language get the translation in lang.DefaultLanguage.
+func (server *Server) Translate(session *net.MinecraftSession, key string, args ...interface{}) string {
+	return lang.Translate(session.GetLanguage(), key, args...)
+}
+
+// broadcastTranslation broadcasts a message in the color that clients translate themselves to their own language,
+// such as lang.PlayerJoined, with the parameters formatted into it.
+// The console gets the message translated to lang.DefaultLanguage.
+func (server *Server) broadcastTranslation(color string, key string, parameters ...string) {
+	server.BroadcastComponent(text.NewComponent().Color(color).Translate(key, parameters...))
+}
@@ -0,0 +1,149 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/combat"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/vehicles"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+	data2 "github.com/irmine/worlds/entities/data"
+)
+
+// Types of entity links, as sent in set entity link packets.
+const (
+	LinkRemove byte = iota
+	LinkRider
+	LinkPassenger
+)
+
+// vehicleTypes are the entities players can ride, by legacy entity type ID.
+var vehicleTypes = map[uint32]vehicles.Kind{
+	vehicles.BoatEntityType:     vehicles.Boat,
+	vehicles.MinecartEntityType: vehicles.Minecart,
+}
+
+// GetVehicleManager returns the vehicle manager of the given dimension.
+// A new manager gets created if the dimension did not yet have one.
+func (server *Server) GetVehicleManager(dimension *worlds.Dimension) *vehicles.Manager {
+	server.vehicleMutex.Lock()
+	defer server.vehicleMutex.Unlock()
+	var manager, ok = server.vehicleManagers[dimension]
+	if !ok {
+		manager = vehicles.NewManager()
+		server.vehicleManagers[dimension] = manager
+	}
+	return manager
+}
+
+// addDefaultVehicle makes the entity a vehicle if players can ride its entity type.
+func (server *Server) addDefaultVehicle(dimension *worlds.Dimension, entity *PersistentEntity) {
+	var kind, ok = vehicleTypes[entity.GetEntityType()]
+	if !ok {
+		return
+	}
+	server.GetVehicleManager(dimension).Add(vehicles.NewVehicle(mobBody{entity}, kind))
+}
+
+// GetRiddenVehicle returns the vehicle the player of the session rides, and a bool indicating if it rides one.
+func (server *Server) GetRiddenVehicle(session *net.MinecraftSession) (*vehicles.Vehicle, bool) {
+	var dimension = session.GetPlayer().GetDimension()
+	if dimension == nil {
+		return nil, false
+	}
+	return server.GetVehicleManager(dimension).GetVehicleByRider(session.GetPlayer().GetRuntimeId())
+}
+
+// Mount makes the player of the session ride the vehicle with the given runtime ID in its dimension.
+// Players riding another vehicle dismount it first.
+// Returns false if there is no such vehicle in the dimension of the player, if it is out of reach,
+// or if somebody else rides it.
+func (server *Server) Mount(session *net.MinecraftSession, vehicleId uint64) bool {
+	var player = session.GetPlayer()
+	if player.GetDimension() == nil {
+		return false
+	}
+	var vehicle, ok = server.GetVehicleManager(player.GetDimension()).GetVehicle(vehicleId)
+	if !ok || vehicle.GetRider() == player.GetRuntimeId() {
+		return false
+	}
+	var body = vehicle.GetBody().(mobBody)
+	if body.GetDimension() != player.GetDimension() || !combat.InReach(player.Position, body.Position) {
+		return false
+	}
+	server.Dismount(session)
+	if !vehicle.SetRider(player.GetRuntimeId()) {
+		return false
+	}
+	player.SetRidingId(vehicleId)
+	server.broadcastLink(session, vehicleId, LinkRider)
+	return true
+}
+
+// Dismount makes the player of the session leave the vehicle it rides.
+// Returns false if the player did not ride a vehicle.
+func (server *Server) Dismount(session *net.MinecraftSession) bool {
+	var vehicle, ok = server.GetRiddenVehicle(session)
+	if !ok {
+		return false
+	}
+	vehicle.SetRider(0)
+	session.GetPlayer().SetRidingId(0)
+	server.broadcastLink(session, vehicle.GetBody().GetRuntimeId(), LinkRemove)
+	return true
+}
+
+// broadcastLink sends the link between the vehicle and the player of the session to the player and its viewers,
+// and broadcasts the riding flag of the player.
+func (server *Server) broadcastLink(session *net.MinecraftSession, vehicleId uint64, linkType byte) {
+	var player = session.GetPlayer()
+	player.SetEntityProperty(data2.EntityDataRiding, linkType != LinkRemove)
+	player.BroadcastUpdatedEntityData()
+	session.SendSetEntityData(player.GetRuntimeId(), player.GetEntityData())
+
+	session.SendSetEntityLink(vehicleId, player.GetRuntimeId(), linkType)
+	for _, viewer := range player.GetViewers() {
+		if viewer, ok := viewer.(*net.MinecraftSession); ok {
+			viewer.SendSetEntityLink(vehicleId, player.GetRuntimeId(), linkType)
+		}
+	}
+}
+
+// driveVehicle handles the movement of a boat sent by the player of the session riding it.
+// Invalid movement is corrected by teleporting the boat back for the rider,
+// while valid movement is broadcasted to the viewers of the boat when its dimension ticks.
+func (server *Server) driveVehicle(session *net.MinecraftSession, vehicleId uint64, position r3.Vector, yaw float64) {
+	var vehicle, ok = server.GetRiddenVehicle(session)
+	if !ok || vehicle.GetBody().GetRuntimeId() != vehicleId {
+		return
+	}
+	var body = vehicle.GetBody().(mobBody)
+	if !vehicle.Drive(server.GetDimensionWorld(session.GetPlayer().GetDimension()), position, yaw, server.tick) {
+		session.SendMoveEntity(vehicleId, body.Position, body.Rotation, 0, true)
+		return
+	}
+	server.moveRider(session, body)
+}
+
+// moveRider moves the player of the session along with the vehicle it rides.
+func (server *Server) moveRider(session *net.MinecraftSession, body mobBody) {
+	var player = session.GetPlayer()
+	session.SyncMove(body.Position.X, body.Position.Y, body.Position.Z, player.Rotation.Pitch, player.Rotation.Yaw, player.Rotation.HeadYaw, true)
+}
+
+// tickVehicles moves the minecarts of all dimensions along their rails, together with their riders.
+func (server *Server) tickVehicles() {
+	server.vehicleMutex.Lock()
+	var managers = make(map[*worlds.Dimension]*vehicles.Manager, len(server.vehicleManagers))
+	for dimension, manager := range server.vehicleManagers {
+		managers[dimension] = manager
+	}
+	server.vehicleMutex.Unlock()
+
+	for dimension, manager := range managers {
+		for _, vehicle := range manager.Tick(server.GetDimensionWorld(dimension)) {
+			if session, ok := server.GetSessionByRuntimeId(vehicle.GetRider()); ok && vehicle.GetRider() != 0 {
+				server.moveRider(session, vehicle.GetBody().(mobBody))
+			}
+		}
+	}
+}
@@ -0,0 +1,413 @@
+package gomine
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/BobbyShrd/gominetest/ai"
+	"github.com/BobbyShrd/gominetest/blockentities"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/packets/types"
+	"github.com/BobbyShrd/gominetest/text"
+	"github.com/BobbyShrd/gominetest/villagers"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+	data2 "github.com/irmine/worlds/entities/data"
+)
+
+const (
+	// VillagerEntityType is the legacy entity type ID of villagers.
+	VillagerEntityType = 15
+	// VillagerTickInterval is the amount of ticks between villagers looking for workstations and restocking.
+	VillagerTickInterval = 100
+	// WorkstationSearchRadius and WorkstationSearchHeight are the horizontal and vertical distance
+	// in blocks within which villagers look for workstations to claim.
+	WorkstationSearchRadius = 8
+	WorkstationSearchHeight = 4
+	// TradeDistance is the maximum distance between a player and the villager it trades with.
+	TradeDistance = 8.0
+
+	// TradeWindowId is the window ID of the trading window. It is above the IDs used for container windows.
+	TradeWindowId byte = 100
+	// TradeWindowType is the window type of the trading window.
+	TradeWindowType byte = 15
+)
+
+// Window IDs of inventory actions of the trading window, which are sent with the types.SourceTodo source.
+const (
+	TradeInputWindowId       int32 = -20
+	TradeSecondInputWindowId int32 = -21
+	TradeUseInputsWindowId   int32 = -22
+	TradeOutputWindowId      int32 = -23
+)
+
+// tradeWindow is the trading window a player opened with a villager.
+type tradeWindow struct {
+	// runtimeId is the runtime ID of the villager traded with.
+	runtimeId uint64
+	// inputs hold the items the player put in the first and second input slot of the trading window.
+	inputs [2]*blockentities.Inventory
+}
+
+// GetVillagerManager returns the villager manager of the given dimension.
+// A new manager gets created if the dimension did not yet have one.
+func (server *Server) GetVillagerManager(dimension *worlds.Dimension) *villagers.Manager {
+	server.villagerMutex.Lock()
+	defer server.villagerMutex.Unlock()
+	var manager, ok = server.villagerManagers[dimension]
+	if !ok {
+		manager = villagers.NewManager()
+		server.villagerManagers[dimension] = manager
+	}
+	return manager
+}
+
+// addVillager adds the villager AI of the entity in the dimension, loading the profession,
+// level and trades saved with the entity. Villagers walk to their workstation during work time,
+// and behave like passive mobs otherwise.
+func (server *Server) addVillager(dimension *worlds.Dimension, entity *PersistentEntity, speed float64) *ai.Mob {
+	var villager = villagers.New()
+	if encoded, ok := entity.GetMetadata().GetString(mobNamespace, "villager"); ok {
+		var decoded, err = villagers.Decode(encoded)
+		text.DefaultLogger.LogError(err)
+		if err == nil {
+			villager = decoded
+		}
+	}
+	var behaviors = append([]ai.Behavior{villagers.NewWork(villager, server.GetWorldTime)}, ai.NewPassiveBehaviors()...)
+	var mob = server.AddMob(dimension, entity, speed, behaviors...)
+	server.GetVillagerManager(dimension).Add(entity.GetRuntimeId(), villager)
+	setProfessionData(entity, villager)
+	return mob
+}
+
+// saveVillager saves the state of the villager with its entity.
+func saveVillager(entity *PersistentEntity, villager *villagers.Villager) {
+	entity.GetMetadata().SetPersistent(mobNamespace, "villager", villager.Encode())
+}
+
+// setProfessionData sets the variant of the entity to that of the profession of the villager,
+// which changes its clothing, and broadcasts it to its viewers.
+func setProfessionData(entity *PersistentEntity, villager *villagers.Villager) {
+	var variant int32
+	if villager.HasProfession() {
+		variant = villager.GetProfession().Variant
+	}
+	entity.SetEntityProperty(data2.EntityDataVariant, variant)
+	entity.BroadcastUpdatedEntityData()
+}
+
+// tickVillagers makes villagers without workstation claim one nearby, taking its profession if they may change it,
+// releases workstations that are no longer there, and restocks villagers at their workstation during work time.
+// Trading windows of players too far from their villager get closed.
+func (server *Server) tickVillagers() {
+	server.villagerMutex.Lock()
+	var managers = make(map[*worlds.Dimension]*villagers.Manager, len(server.villagerManagers))
+	for dimension, manager := range server.villagerManagers {
+		managers[dimension] = manager
+	}
+	server.villagerMutex.Unlock()
+
+	var time = server.GetWorldTime()
+	for dimension, manager := range managers {
+		var world = server.GetDimensionWorld(dimension)
+		for runtimeId, villager := range manager.GetVillagers() {
+			var mob, ok = server.GetMobManager(dimension).GetMob(runtimeId)
+			if !ok {
+				continue
+			}
+			var entity = mob.GetBody().(mobBody).PersistentEntity
+			var changed = server.updateWorkstation(world, manager, mob, villager)
+			if villager.HasProfession() && villager.NeedsRestock() && villager.CanRestock(time) && villager.IsAtWorkstation(entity.Position) {
+				villager.Restock(time)
+				changed = true
+			}
+			if changed {
+				saveVillager(entity, villager)
+			}
+		}
+	}
+	server.closeDistantTrades()
+}
+
+// updateWorkstation releases the workstation of the villager if it was broken, and makes villagers without
+// workstation claim the nearest unclaimed workstation. Villagers that may change their profession take the
+// profession of the workstation they claim, and lose their profession if no workstation is found.
+// Returns true if the villager changed.
+func (server *Server) updateWorkstation(world *DimensionWorld, manager *villagers.Manager, mob *ai.Mob, villager *villagers.Villager) bool {
+	var runtimeId = mob.GetBody().GetRuntimeId()
+	var changed bool
+	if position, ok := villager.GetWorkstation(); ok {
+		var profession, ok = villagers.GetProfessionByWorkstation(world.GetBlockName(position))
+		if ok && profession == villager.GetProfession() {
+			return false
+		}
+		manager.Release(position)
+		changed = true
+	}
+
+	var position, profession, found = findWorkstation(world, manager, mob.GetBlockPosition(), villager)
+	if !found {
+		if villager.HasProfession() && villager.CanChangeProfession() {
+			villager.SetProfession(nil, newVillagerRandom())
+			setProfessionData(mob.GetBody().(mobBody).PersistentEntity, villager)
+			return true
+		}
+		return changed
+	}
+	manager.Claim(runtimeId, position)
+	if profession != villager.GetProfession() {
+		villager.SetProfession(profession, newVillagerRandom())
+		setProfessionData(mob.GetBody().(mobBody).PersistentEntity, villager)
+	}
+	return true
+}
+
+// findWorkstation returns the position and profession of the nearest unclaimed workstation around the position
+// the villager may claim. Villagers that may not change their profession only claim workstations of their profession.
+// A bool is returned indicating if a workstation was found.
+func findWorkstation(world *DimensionWorld, manager *villagers.Manager, center blocks.Position, villager *villagers.Villager) (blocks.Position, *villagers.Profession, bool) {
+	var nearest blocks.Position
+	var nearestProfession *villagers.Profession
+	var nearestDistance = math.MaxInt32
+	for x := center.X - WorkstationSearchRadius; x <= center.X+WorkstationSearchRadius; x++ {
+		for z := center.Z - WorkstationSearchRadius; z <= center.Z+WorkstationSearchRadius; z++ {
+			for y := int64(center.Y) - WorkstationSearchHeight; y <= int64(center.Y)+WorkstationSearchHeight; y++ {
+				if y < 0 {
+					continue
+				}
+				var position = blocks.NewPosition(x, uint32(y), z)
+				var profession, ok = villagers.GetProfessionByWorkstation(world.GetBlockName(position))
+				if !ok || (!villager.CanChangeProfession() && profession != villager.GetProfession()) || manager.IsClaimed(position) {
+					continue
+				}
+				var dx, dy, dz = int(x - center.X), int(y - int64(center.Y)), int(z - center.Z)
+				if distance := dx*dx + dy*dy + dz*dz; distance < nearestDistance {
+					nearest, nearestProfession, nearestDistance = position, profession, distance
+				}
+			}
+		}
+	}
+	return nearest, nearestProfession, nearestProfession != nil
+}
+
+// releaseWorkstation releases the workstation at the position in the dimension once it gets broken.
+// The villager that claimed it looks for a new workstation the next time villagers tick.
+func (server *Server) releaseWorkstation(dimension *worlds.Dimension, position blocks.Position) {
+	var runtimeId, ok = server.GetVillagerManager(dimension).Release(position)
+	if !ok {
+		return
+	}
+	if mob, ok := server.GetMobManager(dimension).GetMob(runtimeId); ok {
+		if villager, ok := server.GetVillagerManager(dimension).Get(runtimeId); ok {
+			saveVillager(mob.GetBody().(mobBody).PersistentEntity, villager)
+		}
+	}
+}
+
+// newVillagerRandom returns a new random source to pick the trades of villagers with.
+func newVillagerRandom() *rand.Rand {
+	return rand.New(rand.NewSource(rand.Int63()))
+}
+
+// openTrade opens the trading window of the villager mob for the session.
+// The villager stops walking and looks at the player while trading.
+// Returns false if the mob is not a villager with a profession.
+func (server *Server) openTrade(session *net.MinecraftSession, dimension *worlds.Dimension, mob *ai.Mob) bool {
+	var villager, ok = server.GetVillagerManager(dimension).Get(mob.GetBody().GetRuntimeId())
+	if !ok || !villager.HasProfession() || mob.IsBaby() {
+		return false
+	}
+	server.CloseWindow(session)
+	server.tradeMutex.Lock()
+	server.trades[session.GetName()] = &tradeWindow{runtimeId: mob.GetBody().GetRuntimeId(), inputs: [2]*blockentities.Inventory{blockentities.NewInventory(1), blockentities.NewInventory(1)}}
+	server.tradeMutex.Unlock()
+
+	mob.Stop()
+	mob.LookAt(session.GetPlayer().Position)
+	server.sendTrade(session, mob, villager)
+	return true
+}
+
+// sendTrade sends the trading window with the offers of the villager to the session.
+func (server *Server) sendTrade(session *net.MinecraftSession, mob *ai.Mob, villager *villagers.Villager) {
+	var entity = mob.GetBody().(mobBody)
+	session.SendUpdateTrade(TradeWindowId, TradeWindowType, int32(villager.GetLevel()-1), entity.GetUniqueId(), session.GetPlayer().GetUniqueId(), villager.GetProfession().Name, encodeNBT(villager.ToNBT()))
+}
+
+// getTrade returns the villager mob the session is trading with, and a bool indicating if it is trading.
+func (server *Server) getTrade(session *net.MinecraftSession) (*ai.Mob, *villagers.Villager, bool) {
+	server.tradeMutex.Lock()
+	var trade, ok = server.trades[session.GetName()]
+	server.tradeMutex.Unlock()
+	var dimension = session.GetPlayer().GetDimension()
+	if !ok || dimension == nil {
+		return nil, nil, false
+	}
+	var mob, isMob = server.GetMobManager(dimension).GetMob(trade.runtimeId)
+	var villager, isVillager = server.GetVillagerManager(dimension).Get(trade.runtimeId)
+	return mob, villager, isMob && isVillager
+}
+
+// getTradeInput returns the input slot of the trading window of the session with the given window ID.
+// A bool is returned which is false if the session is not trading, or if the window is no trade input.
+func (server *Server) getTradeInput(session *net.MinecraftSession, windowId int32) (*blockentities.Inventory, bool) {
+	server.tradeMutex.Lock()
+	var trade, ok = server.trades[session.GetName()]
+	server.tradeMutex.Unlock()
+	if !ok {
+		return nil, false
+	}
+	switch windowId {
+	case TradeInputWindowId:
+		return trade.inputs[0], true
+	case TradeSecondInputWindowId:
+		return trade.inputs[1], true
+	}
+	return nil, false
+}
+
+// closeTrade closes the trading window of the session, if it has one opened.
+func (server *Server) closeTrade(session *net.MinecraftSession) {
+	if server.removeTrade(session) {
+		session.SendContainerClose(TradeWindowId)
+		session.SendInventoryContent(InventoryWindowId, session.GetPlayer().GetInventory().GetContents())
+	}
+}
+
+// removeTrade stops the trade of the session, returning the items left in the trade inputs to its inventory.
+// Items that do not fit in the inventory are dropped. Returns false if the session was not trading.
+func (server *Server) removeTrade(session *net.MinecraftSession) bool {
+	server.tradeMutex.Lock()
+	var trade, ok = server.trades[session.GetName()]
+	delete(server.trades, session.GetName())
+	server.tradeMutex.Unlock()
+	if !ok {
+		return false
+	}
+	var player = session.GetPlayer()
+	for _, input := range trade.inputs {
+		var stack = input.GetItem(0)
+		if stack == nil {
+			continue
+		}
+		input.SetItem(0, nil)
+		if player.GetInventory().AddItem(stack); stack.Count > 0 && player.GetDimension() != nil {
+			server.DropItem(player.GetDimension(), player.Position, stack)
+		}
+	}
+	return true
+}
+
+// closeDistantTrades closes the trading windows of players that moved too far from their villager,
+// or whose villager is gone.
+func (server *Server) closeDistantTrades() {
+	server.tradeMutex.Lock()
+	var names = make([]string, 0, len(server.trades))
+	for name := range server.trades {
+		names = append(names, name)
+	}
+	server.tradeMutex.Unlock()
+
+	for _, name := range names {
+		var session, ok = server.SessionManager.GetSession(name)
+		if !ok {
+			server.tradeMutex.Lock()
+			delete(server.trades, name)
+			server.tradeMutex.Unlock()
+			continue
+		}
+		var mob, _, trading = server.getTrade(session)
+		if !trading || mob.GetBody().GetPosition().Distance(session.GetPlayer().Position) > TradeDistance {
+			server.closeTrade(session)
+		}
+	}
+}
+
+// validateTradeOutput validates the output of the trading window of the session being taken in an inventory transaction.
+// The output has to be the item sold by an offer of the villager that is not exhausted, and the trade inputs,
+// after the slot changes of the transaction, have to hold the items the offer buys. The bought items are consumed
+// from the trade inputs by adding to the slot changes, and the sold item is counted as taken in the balance.
+// The index of the offer traded is returned, with a bool which is false if the output matches no offer that can be traded.
+func (server *Server) validateTradeOutput(session *net.MinecraftSession, output types.InventoryAction, changes *[]slotChange, balance *itemBalance) (int, bool) {
+	var _, villager, ok = server.getTrade(session)
+	if !ok || (output.NewItem != nil && output.NewItem.Count > 0) {
+		return -1, false
+	}
+	var index = findOffer(villager.GetOffers(), output.OldItem)
+	if index < 0 {
+		return -1, false
+	}
+	var offer = villager.GetOffers()[index]
+	var sold, known = items.DefaultManager.Get(offer.Sell.Id, offer.Sell.Count)
+	if !known || !sameItem(sold, output.OldItem) || !sameKind(sold, output.OldItem) {
+		return -1, false
+	}
+	for i, bought := range []villagers.Ingredient{offer.BuyA, offer.BuyB} {
+		if bought.IsEmpty() {
+			continue
+		}
+		var input, _ = server.getTradeInput(session, []int32{TradeInputWindowId, TradeSecondInputWindowId}[i])
+		if !consumeInput(input, bought, changes) {
+			return -1, false
+		}
+	}
+	balance.take(output.OldItem)
+	return index, true
+}
+
+// consumeInput consumes the bought ingredient from the trade input, as it is after the slot changes.
+// The slot change of the input is updated, or added if the input was not changed yet.
+// Returns false if the input does not hold enough items of the ingredient.
+func consumeInput(input *blockentities.Inventory, bought villagers.Ingredient, changes *[]slotChange) bool {
+	var change = -1
+	var stack = input.GetItem(0)
+	for i, existing := range *changes {
+		if existing.holder == slotHolder(input) {
+			change, stack = i, existing.item
+		}
+	}
+	if stack == nil || stack.GetId() != bought.Id || stack.Count < bought.Count {
+		return false
+	}
+	var rest = *stack
+	rest.Count -= bought.Count
+	if change < 0 {
+		*changes = append(*changes, slotChange{input, 0, &rest})
+	} else {
+		(*changes)[change].item = &rest
+	}
+	return true
+}
+
+// completeTrades trades the offers with the given indices of the villager the session trades with,
+// after the inventory transaction taking their output was applied. Villagers that level up unlock new offers,
+// which are sent to the player.
+func (server *Server) completeTrades(session *net.MinecraftSession, offers []int) {
+	var mob, villager, ok = server.getTrade(session)
+	if !ok || len(offers) == 0 {
+		return
+	}
+	var level = villager.GetLevel()
+	for _, index := range offers {
+		villager.Trade(index, newVillagerRandom())
+	}
+	saveVillager(mob.GetBody().(mobBody).PersistentEntity, villager)
+	if villager.GetLevel() != level {
+		server.sendTrade(session, mob, villager)
+	}
+}
+
+// findOffer returns the index of the first offer selling the item stack that is not exhausted, or -1 if there is none.
+func findOffer(offers []*villagers.Offer, sold *items.Stack) int {
+	if sold == nil {
+		return -1
+	}
+	for i, offer := range offers {
+		if !offer.IsExhausted() && offer.Sell.Id == sold.GetId() && offer.Sell.Count == sold.Count {
+			return i
+		}
+	}
+	return -1
+}
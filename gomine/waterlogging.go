@@ -0,0 +1,69 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/waterlog"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+	"github.com/irmine/worlds/chunks"
+)
+
+// SetWaterlogStore makes the water of the waterlogged blocks in the dimension persist in the given directory,
+// saving it with the chunk it is in.
+func (server *Server) SetWaterlogStore(dimension *worlds.Dimension, directory string) {
+	server.GetDimensionWorld(dimension).layer = waterlog.NewLayer(directory)
+}
+
+// IsWaterlogged checks if the block at the given position holds water in its second storage layer.
+func (world *DimensionWorld) IsWaterlogged(position blocks.Position) bool {
+	return world.layer.IsWaterlogged(position)
+}
+
+// SetWaterlogged sets or drains the water in the second storage layer at the given position.
+// The change is broadcasted to the viewers of the dimension if the position was (un)waterlogged.
+func (world *DimensionWorld) SetWaterlogged(position blocks.Position, waterlogged bool) {
+	var changed bool
+	if waterlogged {
+		changed = world.layer.Set(position)
+	} else {
+		changed = world.layer.Remove(position)
+	}
+	if !changed {
+		return
+	}
+	world.MarkDirty(position)
+	if runtimeId, ok := world.getLayerRuntimeId(waterlogged); ok {
+		world.broadcastUpdateLayer(position, runtimeId, waterlog.LayerLiquid)
+	}
+}
+
+// BreakBlock breaks the block at the given position.
+// The water of a waterlogged block is left behind in the first layer, while other blocks leave air.
+func (world *DimensionWorld) BreakBlock(position blocks.Position) {
+	if world.IsWaterlogged(position) {
+		world.PlaceBlock(position, waterlog.Break(true), waterlog.WaterId, 0)
+		return
+	}
+	world.PlaceBlock(position, waterlog.Break(false), 0, 0)
+}
+
+// getLayerRuntimeId returns the runtime ID of the block in the second layer,
+// which is water for waterlogged positions and air otherwise.
+func (world *DimensionWorld) getLayerRuntimeId(waterlogged bool) (uint32, bool) {
+	if waterlogged {
+		return getRuntimeId(world.palettes, waterlog.WaterId, 0)
+	}
+	return getRuntimeId(world.palettes, 0, 0)
+}
+
+// sendChunkLayers sends the water of the waterlogged blocks in a chunk loaded by the session.
+func (server *Server) sendChunkLayers(session *net.MinecraftSession, chunk *chunks.Chunk) {
+	var world = server.GetDimensionWorld(session.GetPlayer().GetDimension())
+	var runtimeId, ok = world.getLayerRuntimeId(true)
+	if !ok {
+		return
+	}
+	for _, position := range world.layer.GetInChunk(chunk.X, chunk.Z) {
+		session.SendUpdateBlock(position, runtimeId, waterlog.LayerLiquid)
+	}
+}
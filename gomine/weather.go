@@ -0,0 +1,229 @@
+package gomine
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/commands"
+	"github.com/BobbyShrd/gominetest/gamerules"
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/sounds"
+	"github.com/BobbyShrd/gominetest/weather"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	// TimeSyncInterval is the amount of ticks between sending the time to players,
+	// which corrects clients whose time drifted from the server.
+	TimeSyncInterval = 200
+	// LightningEntityType is the legacy entity type ID of lightning bolts.
+	LightningEntityType uint32 = 93
+	// LightningDuration is the amount of ticks a lightning bolt exists before it disappears.
+	LightningDuration = 20
+	// LightningRange is the horizontal distance from a player lightning strikes within during thunderstorms.
+	LightningRange = 32
+)
+
+// newWeatherCycle returns a new weather cycle starting at the time and weather of the level data,
+// with the daylight and weather cycle game rules applied.
+func newWeatherCycle(data *levels.Data, rules *gamerules.Rules) *weather.Cycle {
+	var cycle = weather.NewCycle(data.Time, rand.New(rand.NewSource(time.Now().UnixNano())))
+	if data.Thundering {
+		cycle.SetWeather(weather.Thunder, int64(data.WeatherTime))
+	} else if data.Raining {
+		cycle.SetWeather(weather.Rain, int64(data.WeatherTime))
+	} else if data.WeatherTime > 0 {
+		cycle.SetWeather(weather.Clear, int64(data.WeatherTime))
+	}
+	cycle.SetDaylightCycle(rules.GetBool(gamerules.DoDaylightCycle))
+	cycle.SetWeatherCycle(rules.GetBool(gamerules.DoWeatherCycle))
+	return cycle
+}
+
+// GetWeatherCycle returns the cycle keeping track of the time and weather of the level.
+// Levels that were not loaded by the server get a new cycle starting at time 0.
+func (server *Server) GetWeatherCycle(level *worlds.Level) *weather.Cycle {
+	server.worldMutex.Lock()
+	defer server.worldMutex.Unlock()
+	if cycle, ok := server.weatherCycles[level]; ok {
+		return cycle
+	}
+	var cycle = weather.NewCycle(0, rand.New(rand.NewSource(time.Now().UnixNano())))
+	server.weatherCycles[level] = cycle
+	return cycle
+}
+
+// GetWorldTime returns the time of the default world, which includes the ticks passed before the server started.
+func (server *Server) GetWorldTime() int64 {
+	return server.GetWeatherCycle(server.LevelManager.GetDefaultLevel()).GetTime()
+}
+
+// SetTimeOfDay sets the time within the current day of the level, and sends it to the players in the level.
+func (server *Server) SetTimeOfDay(level *worlds.Level, timeOfDay int64) {
+	server.GetWeatherCycle(level).SetTimeOfDay(timeOfDay)
+	server.broadcastTime(level)
+}
+
+// AddTime adds the given amount of ticks to the time of the level, and sends it to the players in the level.
+func (server *Server) AddTime(level *worlds.Level, ticks int64) {
+	server.GetWeatherCycle(level).AddTime(ticks)
+	server.broadcastTime(level)
+}
+
+// SetWeather sets the weather of the level for the given amount of ticks, and shows it to the players in the level.
+// A random duration is picked if the duration is 0 or less.
+func (server *Server) SetWeather(level *worlds.Level, newWeather weather.Weather, duration int64) {
+	var cycle = server.GetWeatherCycle(level)
+	var previous = cycle.GetWeather()
+	cycle.SetWeather(newWeather, duration)
+	server.broadcastWeather(level, previous, newWeather)
+}
+
+// SetDaylightCycle sets if the time of the level advances, and sends the game rules to the players in the level.
+func (server *Server) SetDaylightCycle(level *worlds.Level, value bool) {
+	server.SetGameRule(level, gamerules.DoDaylightCycle, strconv.FormatBool(value))
+}
+
+// SetWeatherCycle sets if the weather of the level changes by itself, and sends the game rules to the players in the level.
+func (server *Server) SetWeatherCycle(level *worlds.Level, value bool) {
+	server.SetGameRule(level, gamerules.DoWeatherCycle, strconv.FormatBool(value))
+}
+
+// StrikeLightning spawns a lightning bolt at the position in the dimension,
+// which disappears again after LightningDuration ticks.
+func (server *Server) StrikeLightning(dimension *worlds.Dimension, position r3.Vector) {
+	var bolt = NewPersistentEntity(LightningEntityType)
+	bolt.SetPersistent(false)
+	dimension.AddEntity(bolt, position)
+	if soundId, ok := sounds.GetEvent("thunder"); ok {
+		server.GetDimensionWorld(dimension).PlaySoundEvent(soundId, position)
+	}
+	server.scheduler.ScheduleDelayed(bolt.Close, LightningDuration)
+}
+
+// tickWeather advances the time and weather of all levels, showing weather changes to players
+// and striking lightning near players in the overworld during thunderstorms.
+func (server *Server) tickWeather() {
+	server.worldMutex.Lock()
+	var cycles = make(map[*worlds.Level]*weather.Cycle, len(server.weatherCycles))
+	for level, cycle := range server.weatherCycles {
+		cycles[level] = cycle
+	}
+	server.worldMutex.Unlock()
+
+	for level, cycle := range cycles {
+		if previous, changed := cycle.Tick(); changed {
+			server.broadcastWeather(level, previous, cycle.GetWeather())
+		}
+		if server.tick%TimeSyncInterval == 0 {
+			server.broadcastTime(level)
+		}
+		for _, session := range server.getLevelSessions(level) {
+			var dimension = session.GetPlayer().GetDimension()
+			if dimension.GetDimensionId() == worlds.OverworldId && cycle.ShouldStrikeLightning() {
+				server.StrikeLightning(dimension, server.getLightningPosition(dimension, session.GetPlayer().Position))
+			}
+		}
+	}
+}
+
+// getLightningPosition returns a random position on top of the highest block near the position.
+func (server *Server) getLightningPosition(dimension *worlds.Dimension, position r3.Vector) r3.Vector {
+	var x = int32(math.Floor(position.X)) + rand.Int31n(LightningRange*2+1) - LightningRange
+	var z = int32(math.Floor(position.Z)) + rand.Int31n(LightningRange*2+1) - LightningRange
+	var y = server.GetDimensionWorld(dimension).GetHighestBlock(x, z) + 1
+	return r3.Vector{X: float64(x) + 0.5, Y: float64(y), Z: float64(z) + 0.5}
+}
+
+// GetHighestBlock returns the height of the highest block that is not air at the given column,
+// or the lowest height blocks are stored at if the column only holds air.
+// Blocks below y=0 are not stored by chunks, so those are not checked.
+func (world *DimensionWorld) GetHighestBlock(x, z int32) int32 {
+	var lowest = int32(math.Max(0, float64(world.heights.Min)))
+	for y := world.heights.Max - 1; y > lowest; y-- {
+		if world.GetBlockName(blocks.NewPosition(x, uint32(y), z)) != "air" {
+			return y
+		}
+	}
+	return lowest
+}
+
+// sendTimeAndWeather sends the time, weather and game rules of the level
+// the player of the session is in to the session.
+// Weather is only shown in the overworld, as it does not rain in the nether and the end.
+func (server *Server) sendTimeAndWeather(session *net.MinecraftSession) {
+	var dimension = session.GetPlayer().GetDimension()
+	var cycle = server.GetWeatherCycle(dimension.GetLevel())
+	session.SendSetTime(int32(cycle.GetTime()))
+	session.SendGameRulesChanged(getGameRuleEntries(server.GetGameRules(dimension.GetLevel())))
+	// The weather of the dimension the player was in before is stopped first.
+	for _, event := range weather.GetStopEvents(weather.Thunder) {
+		session.SendLevelEvent(event, r3.Vector{}, 0)
+	}
+	if dimension.GetDimensionId() != worlds.OverworldId {
+		return
+	}
+	for _, event := range weather.GetStartEvents(cycle.GetWeather()) {
+		session.SendLevelEvent(event, r3.Vector{}, weather.Intensity)
+	}
+}
+
+// broadcastTime sends the time of the level to the players in the level.
+func (server *Server) broadcastTime(level *worlds.Level) {
+	var worldTime = int32(server.GetWeatherCycle(level).GetTime())
+	for _, session := range server.getLevelSessions(level) {
+		session.SendSetTime(worldTime)
+	}
+}
+
+// broadcastWeather stops the previous weather and starts the new weather for the players in the overworld of the level.
+func (server *Server) broadcastWeather(level *worlds.Level, previous weather.Weather, newWeather weather.Weather) {
+	for _, session := range server.getLevelSessions(level) {
+		if session.GetPlayer().GetDimension().GetDimensionId() != worlds.OverworldId {
+			continue
+		}
+		for _, event := range weather.GetStopEvents(previous) {
+			session.SendLevelEvent(event, r3.Vector{}, 0)
+		}
+		for _, event := range weather.GetStartEvents(newWeather) {
+			session.SendLevelEvent(event, r3.Vector{}, weather.Intensity)
+		}
+	}
+}
+
+// saveWeatherCycle stores the time and weather of the cycle in the level data.
+func saveWeatherCycle(cycle *weather.Cycle, data *levels.Data) {
+	data.Time = cycle.GetTime()
+	data.Raining = cycle.GetWeather() != weather.Clear
+	data.Thundering = cycle.GetWeather() == weather.Thunder
+	data.WeatherTime = int32(cycle.GetWeatherDuration())
+}
+
+// getLevelSessions returns the sessions of the spawned players in the level.
+func (server *Server) getLevelSessions(level *worlds.Level) []*net.MinecraftSession {
+	var sessions []*net.MinecraftSession
+	for _, session := range server.SessionManager.GetSessions() {
+		if dimension := session.GetPlayer().GetDimension(); session.HasSpawned() && dimension != nil && dimension.GetLevel() == level {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+// getSenderLevel returns the level the sender is in, which is the default level for senders that are not players or command blocks.
+func (server *Server) getSenderLevel(sender commands.Sender) *worlds.Level {
+	if session, ok := sender.(*net.MinecraftSession); ok {
+		if dimension := session.GetPlayer().GetDimension(); dimension != nil {
+			return dimension.GetLevel()
+		}
+	}
+	if commandBlock, ok := sender.(*CommandBlockSender); ok {
+		return commandBlock.GetDimension().GetLevel()
+	}
+	return server.LevelManager.GetDefaultLevel()
+}
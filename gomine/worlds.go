@@ -0,0 +1,271 @@
+package gomine
+
+import (
+	"errors"
+	"math"
+	"os"
+
+	"github.com/BobbyShrd/gominetest/gamerules"
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/packets/data"
+	"github.com/BobbyShrd/gominetest/text"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/chunks"
+	"github.com/irmine/worlds/generation/defaults"
+)
+
+var (
+	UnknownWorld         = errors.New("world does not exist")
+	WorldAlreadyExists   = errors.New("world already exists")
+	WorldAlreadyLoaded   = errors.New("world is already loaded")
+	WorldNotLoaded       = errors.New("world is not loaded")
+	DefaultWorldUnloaded = errors.New("the default world can not be unloaded")
+	UnknownDimension     = errors.New("dimension does not exist")
+)
+
+// GetWorldDirectory returns the directory of the world with the given name.
+func (server *Server) GetWorldDirectory(name string) string {
+	return server.ServerPath + "worlds/" + name + "/"
+}
+
+// GetWorldSettings returns the settings of the given level.
+// The default settings are returned if the level was not loaded by the server.
+func (server *Server) GetWorldSettings(level *worlds.Level) *levels.Settings {
+	server.worldMutex.Lock()
+	defer server.worldMutex.Unlock()
+	if settings, ok := server.worldSettings[level]; ok {
+		return settings
+	}
+	return levels.DefaultSettings()
+}
+
+// GetWorldSpawn returns the spawn position of the given level.
+// The spawn position of the default world is returned for levels that were not loaded by the server.
+func (server *Server) GetWorldSpawn(level *worlds.Level) r3.Vector {
+	server.worldMutex.Lock()
+	defer server.worldMutex.Unlock()
+	if spawn, ok := server.worldSpawns[level]; ok {
+		return spawn
+	}
+	return SpawnPosition
+}
+
+// SaveWorldSettings saves the settings of the given level to its world directory.
+func (server *Server) SaveWorldSettings(level *worlds.Level) error {
+	return server.GetWorldSettings(level).Save(server.GetWorldDirectory(level.GetName()) + "settings.yml")
+}
+
+// CreateWorld creates a new world with the given name and seed, and loads it.
+func (server *Server) CreateWorld(name string, seed int64) (*worlds.Level, error) {
+	var provider = levels.NewGoMine(server.GetWorldDirectory(name))
+	if _, err := provider.LoadData(); err != levels.NoLevelData {
+		return nil, WorldAlreadyExists
+	}
+	var levelData = levels.NewData(name, seed, int32(SpawnPosition.X), int32(SpawnPosition.Y), int32(SpawnPosition.Z))
+	if err := provider.SaveData(levelData); err != nil {
+		return nil, err
+	}
+	return server.LoadWorld(name)
+}
+
+// LoadWorld loads the world with the given name, with its overworld as default dimension.
+func (server *Server) LoadWorld(name string) (*worlds.Level, error) {
+	if server.LevelManager.IsLevelLoaded(name) {
+		return nil, WorldAlreadyLoaded
+	}
+	var directory = server.GetWorldDirectory(name)
+	var levelData, err = levels.NewGoMine(directory).LoadData()
+	if err != nil {
+		if err == levels.NoLevelData {
+			return nil, UnknownWorld
+		}
+		return nil, err
+	}
+	settings, err := levels.LoadSettings(directory + "settings.yml")
+	if err != nil {
+		return nil, err
+	}
+
+	var level = worlds.NewLevel(name, server.ServerPath)
+	server.worldMutex.Lock()
+	server.worldSettings[level] = settings
+	server.worldSpawns[level] = r3.Vector{X: float64(levelData.SpawnX), Y: float64(levelData.SpawnY), Z: float64(levelData.SpawnZ)}
+	server.gameRules[level] = gamerules.FromStrings(levelData.GameRules)
+	server.weatherCycles[level] = newWeatherCycle(levelData, server.gameRules[level])
+	server.levelData[level] = levelData
+	server.worldMutex.Unlock()
+
+	level.SetDefaultDimension(server.CreateDimension(level, levels.Overworld))
+	server.LevelManager.AddLevel(level)
+	return level, nil
+}
+
+// CreateDimension creates a dimension with the given name in the level and adds it to the level.
+// Chunks, entities and block entities of the dimension are stored in the world directory of the level.
+func (server *Server) CreateDimension(level *worlds.Level, name string) *worlds.Dimension {
+	var id = worlds.OverworldId
+	switch name {
+	case levels.Nether:
+		id = worlds.NetherId
+	case levels.End:
+		id = worlds.EndId
+	}
+	var directory = server.GetWorldDirectory(level.GetName()) + name + "/"
+	var dimension = worlds.NewDimension(name, level, id)
+	server.setAnvilProvider(dimension, directory+"region/")
+	server.SetEntityStore(dimension, directory+"entities/")
+	server.SetBlockEntityStore(dimension, directory+"blockentities/")
+	server.SetCustomBlockStore(dimension, directory+"customblocks/")
+	server.SetWaterlogStore(dimension, directory+"water/")
+	server.SetFarmStore(dimension, directory+"farming/")
+	server.SetGenerator(dimension, defaults.NewFlatGenerator())
+	level.AddDimension(dimension)
+	return dimension
+}
+
+// UnloadWorld unloads the world with the given name.
+// Players in the world are moved to the spawn of the default world,
+// and all dimensions and the level data of the world are saved.
+func (server *Server) UnloadWorld(name string) error {
+	var level, err = server.LevelManager.GetLevel(name)
+	if err != nil {
+		return WorldNotLoaded
+	}
+	var defaultLevel = server.LevelManager.GetDefaultLevel()
+	if level == defaultLevel {
+		return DefaultWorldUnloaded
+	}
+
+	for _, session := range server.SessionManager.GetSessions() {
+		if dimension := session.GetPlayer().GetDimension(); dimension != nil && dimension.GetLevel() == level {
+			server.TransferDimension(session, defaultLevel.GetDefaultDimension(), SpawnPosition)
+		}
+	}
+	for _, dimension := range level.GetDimensions() {
+		text.DefaultLogger.LogError(server.SaveDimension(dimension))
+		server.removeDimension(dimension)
+	}
+	text.DefaultLogger.LogError(server.SaveLevelData(level))
+	server.LevelManager.RemoveLevel(name)
+
+	server.worldMutex.Lock()
+	delete(server.worldSettings, level)
+	delete(server.worldSpawns, level)
+	delete(server.weatherCycles, level)
+	delete(server.gameRules, level)
+	delete(server.levelData, level)
+	server.worldMutex.Unlock()
+	return nil
+}
+
+// WorldExists checks if a world with the given name exists in the worlds directory.
+func (server *Server) WorldExists(name string) bool {
+	var _, err = os.Stat(server.GetWorldDirectory(name) + "level.dat")
+	return err == nil
+}
+
+// removeDimension removes the state the server keeps for the dimension.
+func (server *Server) removeDimension(dimension *worlds.Dimension) {
+	server.blockEntityMutex.Lock()
+	delete(server.blockEntityManagers, dimension)
+	server.blockEntityMutex.Unlock()
+	server.dimensionWorldMutex.Lock()
+	delete(server.dimensionWorlds, dimension)
+	server.dimensionWorldMutex.Unlock()
+	server.entityManagerMutex.Lock()
+	delete(server.entityManagers, dimension)
+	server.entityManagerMutex.Unlock()
+	server.itemEntityMutex.Lock()
+	delete(server.itemEntityManagers, dimension)
+	server.itemEntityMutex.Unlock()
+	server.mobMutex.Lock()
+	delete(server.mobManagers, dimension)
+	server.mobMutex.Unlock()
+	server.vehicleMutex.Lock()
+	delete(server.vehicleManagers, dimension)
+	server.vehicleMutex.Unlock()
+	server.leashMutex.Lock()
+	delete(server.leashKnots, dimension)
+	server.leashMutex.Unlock()
+	server.villagerMutex.Lock()
+	delete(server.villagerManagers, dimension)
+	server.villagerMutex.Unlock()
+	server.chunkProviderMutex.Lock()
+	delete(server.chunkProviders, dimension)
+	server.chunkProviderMutex.Unlock()
+}
+
+// TransferDimension transfers the player of the session to the given position in the dimension.
+// The player is teleported if it is already in the dimension.
+// Otherwise the player is despawned for the players in its old dimension, the client
+// is sent to the new dimension, and the player is spawned to the players in the new dimension
+// once the chunk at the position has been loaded.
+func (server *Server) TransferDimension(session *net.MinecraftSession, dimension *worlds.Dimension, position r3.Vector) {
+	var player = session.GetPlayer()
+	var old = player.GetDimension()
+	server.resetMovement(session, position)
+	if old == dimension {
+		session.Teleport(position)
+		return
+	}
+	server.CloseWindow(session)
+
+	for _, online := range server.SessionManager.GetSessions() {
+		if online == session || online.GetPlayer().GetDimension() != old {
+			continue
+		}
+		online.SendRemoveEntity(player.GetUniqueId())
+		player.RemoveViewer(online)
+		session.SendRemoveEntity(online.GetPlayer().GetUniqueId())
+		online.GetPlayer().RemoveViewer(session)
+	}
+
+	// The client ignores dimension changes to the dimension it is already in,
+	// so it is moved through another dimension when changing between levels.
+	if old != nil && old.GetDimensionId() == dimension.GetDimensionId() {
+		var other = worlds.NetherId
+		if dimension.GetDimensionId() == worlds.NetherId {
+			other = worlds.OverworldId
+		}
+		session.SendChangeDimension(int32(other), position, false)
+		session.SendPlayStatus(data.StatusSpawn)
+	}
+	session.SendChangeDimension(int32(dimension.GetDimensionId()), position, false)
+
+	var chunkX, chunkZ = int32(math.Floor(position.X)) >> 4, int32(math.Floor(position.Z)) >> 4
+	server.GetDimensionWorld(dimension).RequestChunk(chunkX, chunkZ).Then(func(chunk *chunks.Chunk) {
+		dimension.AddEntity(player, position)
+		dimension.AddViewer(session, position)
+		session.Teleport(position)
+
+		for _, online := range server.SessionManager.GetSessions() {
+			if online == session || !online.HasSpawned() || online.GetPlayer().GetDimension() != dimension {
+				continue
+			}
+			online.GetPlayer().SpawnPlayerTo(session)
+			online.GetPlayer().AddViewer(session)
+			player.SpawnPlayerTo(online)
+			player.AddViewer(online)
+		}
+		server.applyDisguises(session)
+		server.ApplyWorldSettings(session)
+		session.SendPlayStatus(data.StatusSpawn)
+	})
+}
+
+// ApplyWorldSettings sends the game mode, difficulty, time and weather of the world the player of the session is in,
+// and resets the abilities of the player to those of the game mode.
+// Players that have a game mode set keep it, instead of getting the game mode of the world.
+func (server *Server) ApplyWorldSettings(session *net.MinecraftSession) {
+	var dimension = session.GetPlayer().GetDimension()
+	if dimension == nil {
+		return
+	}
+	var gameMode = server.GetGameMode(session)
+	session.SendSetPlayerGameType(gameMode)
+	session.SendSetDifficulty(uint32(server.GetWorldSettings(dimension.GetLevel()).Difficulty))
+	server.applyGameModeAbilities(session, gameMode)
+	server.sendTimeAndWeather(session)
+}
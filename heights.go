@@ -0,0 +1,43 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/BobbyShrd/gominetest/resources"
+	"github.com/BobbyShrd/gominetest/text"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+)
+
+// newHeightRanges returns the height ranges of the dimensions in the configuration, indexed by dimension name.
+// Invalid ranges are left out, so that the dimension uses its vanilla height range.
+func newHeightRanges(config *resources.GoMineConfig) map[string]levels.HeightRange {
+	var heightRanges = make(map[string]levels.HeightRange)
+	for dimension, setting := range config.DimensionHeights {
+		var heights = levels.HeightRange{Min: setting.Min, Max: setting.Max}
+		if err := heights.Validate(); err != nil {
+			text.DefaultLogger.Warning("Invalid height range for dimension", dimension+":", err)
+			continue
+		}
+		heightRanges[dimension] = heights
+	}
+	return heightRanges
+}
+
+// GetHeightRange returns the range of heights blocks can be placed at in the given dimension.
+// The vanilla height range is returned for dimensions without a valid configured range.
+func (server *Server) GetHeightRange(dimension *worlds.Dimension) levels.HeightRange {
+	if heights, ok := server.heightRanges[dimension.GetName()]; ok {
+		return heights
+	}
+	return levels.GetDefaultHeightRange(dimension.GetName())
+}
+
+// GetHeightRange returns the range of heights blocks can be placed at in the world.
+func (world *DimensionWorld) GetHeightRange() levels.HeightRange {
+	return world.heights
+}
+
+// isInRange checks if blocks can be placed at the given position in the world.
+func (world *DimensionWorld) isInRange(position blocks.Position) bool {
+	return world.heights.Contains(int32(position.Y))
+}
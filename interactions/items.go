@@ -0,0 +1,58 @@
+package interactions
+
+import (
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/irmine/worlds/blocks"
+)
+
+// ItemBehavior is the behavior of an item when it gets used.
+type ItemBehavior interface {
+	// UseOnBlock gets called when a player uses the item on the block at the given position.
+	// The face is the face of the block that was clicked.
+	// UseOnBlock returns true if the item was used, which prevents other behaviors from being called.
+	UseOnBlock(session *net.MinecraftSession, item *items.Stack, position blocks.Position, face byte) bool
+}
+
+// ItemBehaviorFunction is a function implementing ItemBehavior.
+type ItemBehaviorFunction func(session *net.MinecraftSession, item *items.Stack, position blocks.Position, face byte) bool
+
+// UseOnBlock calls the function.
+func (function ItemBehaviorFunction) UseOnBlock(session *net.MinecraftSession, item *items.Stack, position blocks.Position, face byte) bool {
+	return function(session, item, position, face)
+}
+
+// RegisterItemBehavior registers a behavior for items with the given string IDs.
+func (registry *Registry) RegisterItemBehavior(behavior ItemBehavior, itemIds ...string) {
+	registry.mutex.Lock()
+	for _, id := range itemIds {
+		registry.itemBehaviors[id] = append(registry.itemBehaviors[id], behavior)
+	}
+	registry.mutex.Unlock()
+}
+
+// HasItemBehaviors checks if any behaviors are registered for items with the given string ID.
+func (registry *Registry) HasItemBehaviors(itemId string) bool {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	return len(registry.itemBehaviors[itemId]) != 0
+}
+
+// HandleItemUse calls the behaviors registered for the item used on a block,
+// until one of them uses the item.
+// Returns true if the item was used.
+func (registry *Registry) HandleItemUse(session *net.MinecraftSession, item *items.Stack, position blocks.Position, face byte) bool {
+	if item == nil {
+		return false
+	}
+	registry.mutex.RLock()
+	var behaviors = registry.itemBehaviors[item.GetId()]
+	registry.mutex.RUnlock()
+
+	for _, behavior := range behaviors {
+		if behavior.UseOnBlock(session, item, position, face) {
+			return true
+		}
+	}
+	return false
+}
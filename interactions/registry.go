@@ -0,0 +1,60 @@
+package interactions
+
+import (
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/irmine/worlds/blocks"
+)
+
+// BlockHandler handles a player clicking a block.
+// BlockHandler returns true if the interaction was handled,
+// which prevents further handlers from being called.
+type BlockHandler func(session *net.MinecraftSession, position blocks.Position, blockName string) bool
+
+// Registry is a struct managing block interactions and item behaviors.
+// Handlers get registered by block name, and get called
+// in order of registration when a block with that name gets clicked.
+// Item behaviors get registered by item string ID in a similar way.
+type Registry struct {
+	mutex         sync.RWMutex
+	handlers      map[string][]BlockHandler
+	itemBehaviors map[string][]ItemBehavior
+}
+
+// NewRegistry returns a new empty interaction registry.
+func NewRegistry() *Registry {
+	return &Registry{sync.RWMutex{}, make(map[string][]BlockHandler), make(map[string][]ItemBehavior)}
+}
+
+// RegisterBlockHandler registers a handler for blocks with the given names.
+func (registry *Registry) RegisterBlockHandler(handler BlockHandler, blockNames ...string) {
+	registry.mutex.Lock()
+	for _, name := range blockNames {
+		registry.handlers[name] = append(registry.handlers[name], handler)
+	}
+	registry.mutex.Unlock()
+}
+
+// HasBlockHandlers checks if any handlers are registered for blocks with the given name.
+func (registry *Registry) HasBlockHandlers(blockName string) bool {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	return len(registry.handlers[blockName]) != 0
+}
+
+// HandleBlockInteraction calls the handlers registered for the block clicked,
+// until one of them handles the interaction.
+// Returns true if the interaction was handled.
+func (registry *Registry) HandleBlockInteraction(session *net.MinecraftSession, position blocks.Position, blockName string) bool {
+	registry.mutex.RLock()
+	var handlers = registry.handlers[blockName]
+	registry.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		if handler(session, position, blockName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package gomine
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// GetListenAddress returns the address the RakNet listener of the server binds to, including its port.
+// With IPv6 enabled, the listener binds to the IPv6 address of the config instead of the IPv4 address,
+// which is put in brackets, such as "[::]:19132".
+// Binding to the unspecified IPv6 address "::" listens dual-stack: IPv4 clients connect to the same port,
+// and show up with their IPv4 address.
+func (server *Server) GetListenAddress() string {
+	var host = server.Config.ServerIp
+	if server.Config.EnableIPv6 {
+		host = server.Config.ServerIpv6
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(server.Config.ServerPort)))
+}
+
+// getListenHost returns the host of the listen address, keeping the brackets of IPv6 addresses.
+// GoRakLib appends the port to the host after a colon, which only works for IPv6 addresses in brackets.
+func (server *Server) getListenHost() string {
+	var address = server.GetListenAddress()
+	return address[:strings.LastIndex(address, ":")]
+}
+
+// IsDualStack checks if the server listens for both IPv4 and IPv6 clients.
+func (server *Server) IsDualStack() bool {
+	return server.Config.EnableIPv6 && isUnspecified(server.Config.ServerIpv6)
+}
+
+// GetIPv4Port returns the port IPv4 clients connect to, or 0 if the server only listens for IPv6 clients.
+func (server *Server) GetIPv4Port() uint16 {
+	if server.Config.EnableIPv6 && !server.IsDualStack() {
+		return 0
+	}
+	return server.Config.ServerPort
+}
+
+// GetIPv6Port returns the port IPv6 clients connect to, or 0 if IPv6 is not enabled.
+func (server *Server) GetIPv6Port() uint16 {
+	if !server.Config.EnableIPv6 {
+		return 0
+	}
+	return server.Config.ServerPort
+}
+
+// checkListenAddress logs a warning if the IPv6 listener can not be reached by clients on the configured IPv4 address.
+// Only one address can be bound to, so an IPv4 address is ignored unless the IPv6 address is "::".
+func (server *Server) checkListenAddress() {
+	if !server.Config.EnableIPv6 {
+		return
+	}
+	if net.ParseIP(server.Config.ServerIpv6) == nil && server.Config.ServerIpv6 != "" {
+		text.DefaultLogger.Warning("Invalid IPv6 address", server.Config.ServerIpv6+", listening on all addresses")
+		server.Config.ServerIpv6 = "::"
+	}
+	if !server.IsDualStack() && !isUnspecified(server.Config.ServerIp) {
+		text.DefaultLogger.Warning("IPv6 address", server.Config.ServerIpv6, "is not dual-stack, IPv4 clients can not connect to", server.Config.ServerIp)
+	}
+}
+
+// isUnspecified checks if the address is empty or an unspecified address, such as "0.0.0.0" or "::".
+func isUnspecified(address string) bool {
+	if address == "" {
+		return true
+	}
+	var ip = net.ParseIP(address)
+	return ip != nil && ip.IsUnspecified()
+}
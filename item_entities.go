@@ -0,0 +1,158 @@
+package gomine
+
+import (
+	"math/rand"
+
+	"github.com/BobbyShrd/gominetest/farming"
+	"github.com/BobbyShrd/gominetest/itementities"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+	"github.com/irmine/worlds/entities"
+)
+
+const (
+	// ItemEntityType is the legacy entity type ID of item entities.
+	ItemEntityType uint32 = 64
+	// InventoryWindowId is the window ID of the inventory of a player.
+	InventoryWindowId uint32 = 0
+)
+
+// GetItemEntityManager returns the item entity manager of the given dimension.
+// A new manager gets created if the dimension did not yet have one.
+func (server *Server) GetItemEntityManager(dimension *worlds.Dimension) *itementities.Manager {
+	server.itemEntityMutex.Lock()
+	defer server.itemEntityMutex.Unlock()
+	var manager, ok = server.itemEntityManagers[dimension]
+	if !ok {
+		manager = itementities.NewManager()
+		server.itemEntityManagers[dimension] = manager
+	}
+	return manager
+}
+
+// DropItem spawns an item entity holding the item stack at the given position in the dimension.
+// The item entity gets a small random motion, so that dropped items spread out.
+func (server *Server) DropItem(dimension *worlds.Dimension, position r3.Vector, stack *items.Stack) *itementities.ItemEntity {
+	var motion = r3.Vector{X: rand.Float64()*0.2 - 0.1, Y: 0.2, Z: rand.Float64()*0.2 - 0.1}
+	var entity = server.GetItemEntityManager(dimension).Spawn(entities.New(ItemEntityType).GetRuntimeId(), stack, position, motion)
+	for _, viewer := range dimension.GetViewers() {
+		if session, ok := viewer.(*net.MinecraftSession); ok {
+			session.SendAddItemEntity(entity.RuntimeId, entity.Item, entity.Position, entity.Motion)
+		}
+	}
+	return entity
+}
+
+// dropFarmItems drops the items of a broken crop or plant in the center of its block.
+func (server *Server) dropFarmItems(world farming.World, position blocks.Position, drops []*items.Stack) {
+	var dimensionWorld, ok = world.(*DimensionWorld)
+	if !ok {
+		return
+	}
+	var center = r3.Vector{X: float64(position.X) + 0.5, Y: float64(position.Y) + 0.5, Z: float64(position.Z) + 0.5}
+	for _, stack := range drops {
+		server.DropItem(dimensionWorld.GetDimension(), center, stack)
+	}
+}
+
+// SendInventory sends the creative inventory and the contents of the inventory of the player to the session.
+func (server *Server) SendInventory(session *net.MinecraftSession) {
+	session.SendCreativeContent(items.DefaultCreativeInventory.GetItems())
+	session.SendInventoryContent(InventoryWindowId, session.GetPlayer().GetInventory().GetContents())
+}
+
+// SpawnItemEntitiesTo sends all item entities in the dimension of the player of the session to the session.
+func (server *Server) SpawnItemEntitiesTo(session *net.MinecraftSession) {
+	for _, entity := range server.GetItemEntityManager(session.GetPlayer().GetDimension()).GetItemEntities() {
+		session.SendAddItemEntity(entity.RuntimeId, entity.Item, entity.Position, r3.Vector{})
+	}
+}
+
+// pickupItems adds the item entities near the player of the session to its inventory.
+// Item entities that fit completely are taken, and item entities that fit partially are respawned with the items left.
+func (server *Server) pickupItems(session *net.MinecraftSession) {
+	var player = session.GetPlayer()
+	if !session.HasSpawned() || player.GetDimension() == nil {
+		return
+	}
+	var dimension = player.GetDimension()
+	var manager = server.GetItemEntityManager(dimension)
+	var changed = false
+	for _, entity := range manager.GetPickups(player.Position) {
+		if player.GetInventory().AddItem(entity.Item) == 0 {
+			continue
+		}
+		changed = true
+		if entity.Item.Count > 0 {
+			server.broadcastRemoveEntity(dimension, entity.RuntimeId)
+			for _, viewer := range dimension.GetViewers() {
+				if viewer, ok := viewer.(*net.MinecraftSession); ok {
+					viewer.SendAddItemEntity(entity.RuntimeId, entity.Item, entity.Position, r3.Vector{})
+				}
+			}
+			continue
+		}
+		manager.Remove(entity.RuntimeId)
+		for _, viewer := range dimension.GetViewers() {
+			if viewer, ok := viewer.(*net.MinecraftSession); ok {
+				viewer.SendTakeItemEntity(entity.RuntimeId, player.GetRuntimeId())
+			}
+		}
+		server.broadcastRemoveEntity(dimension, entity.RuntimeId)
+	}
+	if changed {
+		session.SendInventoryContent(InventoryWindowId, player.GetInventory().GetContents())
+	}
+}
+
+// tickItemEntities ages the item entities of all dimensions, and despawns the item entities that reached their lifetime.
+func (server *Server) tickItemEntities() {
+	server.itemEntityMutex.Lock()
+	var managers = make(map[*worlds.Dimension]*itementities.Manager, len(server.itemEntityManagers))
+	for dimension, manager := range server.itemEntityManagers {
+		managers[dimension] = manager
+	}
+	server.itemEntityMutex.Unlock()
+
+	for dimension, manager := range managers {
+		for _, entity := range manager.Tick() {
+			server.broadcastRemoveEntity(dimension, entity.RuntimeId)
+		}
+	}
+}
+
+// collectItems passes the item stacks of the item entities inside of the block at the position to the collect function,
+// which returns true if it emptied the stack. Emptied item entities are removed,
+// and item entities that got smaller are spawned again so viewers see their new count.
+func (server *Server) collectItems(dimension *worlds.Dimension, position blocks.Position, collect func(stack *items.Stack) bool) {
+	var manager = server.GetItemEntityManager(dimension)
+	for _, entity := range manager.GetInBlock(position) {
+		var count = entity.Item.Count
+		if collect(entity.Item) {
+			manager.Remove(entity.RuntimeId)
+			server.broadcastRemoveEntity(dimension, entity.RuntimeId)
+			continue
+		}
+		if entity.Item.Count == count {
+			continue
+		}
+		server.broadcastRemoveEntity(dimension, entity.RuntimeId)
+		for _, viewer := range dimension.GetViewers() {
+			if viewer, ok := viewer.(*net.MinecraftSession); ok {
+				viewer.SendAddItemEntity(entity.RuntimeId, entity.Item, entity.Position, r3.Vector{})
+			}
+		}
+	}
+}
+
+// broadcastRemoveEntity removes the entity with the given runtime ID for all viewers of the dimension.
+func (server *Server) broadcastRemoveEntity(dimension *worlds.Dimension, runtimeId uint64) {
+	for _, viewer := range dimension.GetViewers() {
+		if session, ok := viewer.(*net.MinecraftSession); ok {
+			session.SendRemoveEntity(int64(runtimeId))
+		}
+	}
+}
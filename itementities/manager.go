@@ -0,0 +1,130 @@
+package itementities
+
+import (
+	"math"
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	// PickupDelay is the amount of ticks after spawning before an item entity can be picked up.
+	PickupDelay int64 = 10
+	// Lifetime is the amount of ticks after which item entities despawn.
+	Lifetime int64 = 6000
+	// PickupRadius is the distance from which players pick up item entities.
+	PickupRadius = 1.5
+)
+
+// ItemEntity is a dropped item stack lying in a dimension.
+type ItemEntity struct {
+	// RuntimeId is the entity runtime ID of the item entity.
+	RuntimeId uint64
+	// Item is the item stack of the item entity.
+	Item *items.Stack
+	// Position is the position of the item entity.
+	Position r3.Vector
+	// Motion is the motion the item entity spawned with.
+	Motion r3.Vector
+
+	spawnTick int64
+}
+
+// GetAge returns the amount of ticks the item entity has existed at the given tick.
+func (entity *ItemEntity) GetAge(tick int64) int64 {
+	return tick - entity.spawnTick
+}
+
+// Manager keeps track of the item entities of a dimension.
+type Manager struct {
+	mutex    sync.Mutex
+	entities map[uint64]*ItemEntity
+	tick     int64
+}
+
+// NewManager returns a new manager without item entities.
+func NewManager() *Manager {
+	return &Manager{entities: make(map[uint64]*ItemEntity)}
+}
+
+// Spawn adds a new item entity with the given runtime ID, item stack, position and motion.
+func (manager *Manager) Spawn(runtimeId uint64, item *items.Stack, position r3.Vector, motion r3.Vector) *ItemEntity {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var entity = &ItemEntity{RuntimeId: runtimeId, Item: item, Position: position, Motion: motion, spawnTick: manager.tick}
+	manager.entities[runtimeId] = entity
+	return entity
+}
+
+// Remove removes the item entity with the given runtime ID.
+// Returns false if there was no such item entity.
+func (manager *Manager) Remove(runtimeId uint64) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var _, ok = manager.entities[runtimeId]
+	delete(manager.entities, runtimeId)
+	return ok
+}
+
+// GetItemEntity returns the item entity with the given runtime ID, and a bool indicating if it exists.
+func (manager *Manager) GetItemEntity(runtimeId uint64) (*ItemEntity, bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var entity, ok = manager.entities[runtimeId]
+	return entity, ok
+}
+
+// GetItemEntities returns all item entities.
+func (manager *Manager) GetItemEntities() []*ItemEntity {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var entities = make([]*ItemEntity, 0, len(manager.entities))
+	for _, entity := range manager.entities {
+		entities = append(entities, entity)
+	}
+	return entities
+}
+
+// GetInBlock returns the item entities inside of the block at the given position.
+func (manager *Manager) GetInBlock(position blocks.Position) []*ItemEntity {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var inside []*ItemEntity
+	for _, entity := range manager.entities {
+		if int32(math.Floor(entity.Position.X)) == position.X && int32(math.Floor(entity.Position.Y)) == int32(position.Y) && int32(math.Floor(entity.Position.Z)) == position.Z {
+			inside = append(inside, entity)
+		}
+	}
+	return inside
+}
+
+// GetPickups returns the item entities that can be picked up by a player at the given position.
+func (manager *Manager) GetPickups(position r3.Vector) []*ItemEntity {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var pickups []*ItemEntity
+	for _, entity := range manager.entities {
+		if entity.GetAge(manager.tick) >= PickupDelay && entity.Position.Sub(position).Norm2() <= PickupRadius*PickupRadius {
+			pickups = append(pickups, entity)
+		}
+	}
+	return pickups
+}
+
+// Tick advances the age of all item entities, and removes the item entities that reached their lifetime.
+// The removed item entities are returned, so they can be despawned.
+func (manager *Manager) Tick() []*ItemEntity {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	manager.tick++
+	var despawned []*ItemEntity
+	for runtimeId, entity := range manager.entities {
+		if entity.GetAge(manager.tick) >= Lifetime {
+			delete(manager.entities, runtimeId)
+			despawned = append(despawned, entity)
+		}
+	}
+	return despawned
+}
@@ -0,0 +1,51 @@
+package itementities
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds/blocks"
+)
+
+func TestPickups(t *testing.T) {
+	var manager = NewManager()
+	manager.Spawn(1, nil, r3.Vector{X: 1}, r3.Vector{})
+	manager.Spawn(2, nil, r3.Vector{X: 10}, r3.Vector{})
+
+	if pickups := manager.GetPickups(r3.Vector{}); len(pickups) != 0 {
+		t.Errorf("expected no pickups during the pickup delay, got %v", len(pickups))
+	}
+	for i := int64(0); i < PickupDelay; i++ {
+		manager.Tick()
+	}
+	var pickups = manager.GetPickups(r3.Vector{})
+	if len(pickups) != 1 || pickups[0].RuntimeId != 1 {
+		t.Errorf("expected only the nearby item entity to be picked up, got %v", pickups)
+	}
+}
+
+func TestDespawn(t *testing.T) {
+	var manager = NewManager()
+	manager.Spawn(1, nil, r3.Vector{}, r3.Vector{})
+	var despawned []*ItemEntity
+	for i := int64(0); i < Lifetime; i++ {
+		despawned = append(despawned, manager.Tick()...)
+	}
+	if len(despawned) != 1 {
+		t.Fatalf("expected the item entity to despawn after its lifetime, got %v", len(despawned))
+	}
+	if _, ok := manager.GetItemEntity(1); ok {
+		t.Error("expected the despawned item entity to be removed")
+	}
+}
+
+func TestGetInBlock(t *testing.T) {
+	var manager = NewManager()
+	manager.Spawn(1, nil, r3.Vector{X: 0.5, Y: 1.2, Z: -0.5}, r3.Vector{})
+	manager.Spawn(2, nil, r3.Vector{X: 0.5, Y: 2.2, Z: -0.5}, r3.Vector{})
+
+	var inside = manager.GetInBlock(blocks.NewPosition(0, 1, -1))
+	if len(inside) != 1 || inside[0].RuntimeId != 1 {
+		t.Errorf("expected only the item entity inside of the block, got %v", inside)
+	}
+}
@@ -14,6 +14,29 @@ import (
 var IdToType = map[string]Type{
 	GetKey(0, 0): DefaultManager.stringIds["minecraft:air"],
 	GetKey(1, 0): DefaultManager.stringIds["minecraft:stone"],
+
+	GetKey(-163, 0): DefaultManager.stringIds["minecraft:bamboo"],
+	GetKey(6, 0):    DefaultManager.stringIds["minecraft:oak_sapling"],
+	GetKey(6, 1):    DefaultManager.stringIds["minecraft:spruce_sapling"],
+	GetKey(6, 2):    DefaultManager.stringIds["minecraft:birch_sapling"],
+	GetKey(6, 3):    DefaultManager.stringIds["minecraft:jungle_sapling"],
+	GetKey(6, 4):    DefaultManager.stringIds["minecraft:acacia_sapling"],
+	GetKey(6, 5):    DefaultManager.stringIds["minecraft:dark_oak_sapling"],
+	GetKey(81, 0):   DefaultManager.stringIds["minecraft:cactus"],
+	GetKey(290, 0):  DefaultManager.stringIds["minecraft:wooden_hoe"],
+	GetKey(291, 0):  DefaultManager.stringIds["minecraft:stone_hoe"],
+	GetKey(292, 0):  DefaultManager.stringIds["minecraft:iron_hoe"],
+	GetKey(293, 0):  DefaultManager.stringIds["minecraft:diamond_hoe"],
+	GetKey(294, 0):  DefaultManager.stringIds["minecraft:golden_hoe"],
+	GetKey(295, 0):  DefaultManager.stringIds["minecraft:wheat_seeds"],
+	GetKey(296, 0):  DefaultManager.stringIds["minecraft:wheat"],
+	GetKey(338, 0):  DefaultManager.stringIds["minecraft:sugar_cane"],
+	GetKey(351, 15): DefaultManager.stringIds["minecraft:bone_meal"],
+	GetKey(391, 0):  DefaultManager.stringIds["minecraft:carrot"],
+	GetKey(392, 0):  DefaultManager.stringIds["minecraft:potato"],
+	GetKey(394, 0):  DefaultManager.stringIds["minecraft:poisonous_potato"],
+	GetKey(457, 0):  DefaultManager.stringIds["minecraft:beetroot"],
+	GetKey(458, 0):  DefaultManager.stringIds["minecraft:beetroot_seeds"],
 }
 
 // TypeToId is a map used to convert
@@ -21,6 +44,29 @@ var IdToType = map[string]Type{
 var TypeToId = map[string]string{
 	fmt.Sprint(DefaultManager.stringIds["minecraft:air"]):   GetKey(0, 0),
 	fmt.Sprint(DefaultManager.stringIds["minecraft:stone"]): GetKey(1, 0),
+
+	fmt.Sprint(DefaultManager.stringIds["minecraft:bamboo"]):           GetKey(-163, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:oak_sapling"]):      GetKey(6, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:spruce_sapling"]):   GetKey(6, 1),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:birch_sapling"]):    GetKey(6, 2),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:jungle_sapling"]):   GetKey(6, 3),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:acacia_sapling"]):   GetKey(6, 4),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:dark_oak_sapling"]): GetKey(6, 5),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:cactus"]):           GetKey(81, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:wooden_hoe"]):       GetKey(290, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:stone_hoe"]):        GetKey(291, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:iron_hoe"]):         GetKey(292, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:diamond_hoe"]):      GetKey(293, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:golden_hoe"]):       GetKey(294, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:wheat_seeds"]):      GetKey(295, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:wheat"]):            GetKey(296, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:sugar_cane"]):       GetKey(338, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:bone_meal"]):        GetKey(351, 15),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:carrot"]):           GetKey(391, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:potato"]):           GetKey(392, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:poisonous_potato"]): GetKey(394, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:beetroot"]):         GetKey(457, 0),
+	fmt.Sprint(DefaultManager.stringIds["minecraft:beetroot_seeds"]):   GetKey(458, 0),
 }
 
 // getKey returns the key of an ID + data combination,
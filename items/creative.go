@@ -0,0 +1,79 @@
+package items
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// CreativeInventory is an ordered list of the item stacks shown in the creative inventory.
+// Clients show the items in the order they were added.
+type CreativeInventory struct {
+	mutex  sync.RWMutex
+	stacks []*Stack
+}
+
+// creativeEntry is an entry of a JSON creative item list.
+type creativeEntry struct {
+	Id    string `json:"id"`
+	Count int    `json:"count"`
+}
+
+// DefaultCreativeInventory is the default creative inventory,
+// holding the items in the embedded creative item list.
+// The list is loaded upon the init function, after the default items are registered.
+var DefaultCreativeInventory = NewCreativeInventory()
+
+// NewCreativeInventory returns a new empty creative inventory.
+func NewCreativeInventory() *CreativeInventory {
+	return &CreativeInventory{}
+}
+
+// LoadJSON adds the items in a JSON creative item list, such as [{"id": "minecraft:stone"}].
+// Items are created using the given item manager, and entries of unknown items are skipped.
+func (inventory *CreativeInventory) LoadJSON(data []byte, manager *Manager) error {
+	var entries []creativeEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Count <= 0 {
+			entry.Count = 1
+		}
+		if stack, ok := manager.Get(entry.Id, entry.Count); ok {
+			inventory.Add(stack)
+		}
+	}
+	return nil
+}
+
+// Add adds an item stack to the end of the creative inventory.
+func (inventory *CreativeInventory) Add(stack *Stack) {
+	inventory.mutex.Lock()
+	inventory.stacks = append(inventory.stacks, stack)
+	inventory.mutex.Unlock()
+}
+
+// Remove removes all item stacks with the given string ID.
+// Returns false if the creative inventory held no such item.
+func (inventory *CreativeInventory) Remove(stringId string) bool {
+	inventory.mutex.Lock()
+	defer inventory.mutex.Unlock()
+	var kept = inventory.stacks[:0]
+	for _, stack := range inventory.stacks {
+		if stack.GetId() != stringId {
+			kept = append(kept, stack)
+		}
+	}
+	var removed = len(kept) != len(inventory.stacks)
+	inventory.stacks = kept
+	return removed
+}
+
+// GetItems returns all item stacks in the creative inventory, in order.
+func (inventory *CreativeInventory) GetItems() []*Stack {
+	inventory.mutex.RLock()
+	defer inventory.mutex.RUnlock()
+	var stacks = make([]*Stack, len(inventory.stacks))
+	copy(stacks, inventory.stacks)
+	return stacks
+}
@@ -0,0 +1,28 @@
+package items
+
+// creativeItemsJSON is the embedded list of items shown in the creative inventory by default.
+const creativeItemsJSON = `[
+	{"id": "minecraft:stone"},
+	{"id": "minecraft:oak_sapling"},
+	{"id": "minecraft:spruce_sapling"},
+	{"id": "minecraft:birch_sapling"},
+	{"id": "minecraft:jungle_sapling"},
+	{"id": "minecraft:acacia_sapling"},
+	{"id": "minecraft:dark_oak_sapling"},
+	{"id": "minecraft:sugar_cane"},
+	{"id": "minecraft:cactus"},
+	{"id": "minecraft:bamboo"},
+	{"id": "minecraft:wooden_hoe"},
+	{"id": "minecraft:stone_hoe"},
+	{"id": "minecraft:iron_hoe"},
+	{"id": "minecraft:golden_hoe"},
+	{"id": "minecraft:diamond_hoe"},
+	{"id": "minecraft:wheat_seeds"},
+	{"id": "minecraft:beetroot_seeds"},
+	{"id": "minecraft:wheat"},
+	{"id": "minecraft:carrot"},
+	{"id": "minecraft:potato"},
+	{"id": "minecraft:poisonous_potato"},
+	{"id": "minecraft:beetroot"},
+	{"id": "minecraft:bone_meal"}
+]`
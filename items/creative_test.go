@@ -0,0 +1,22 @@
+package items
+
+import (
+	"testing"
+)
+
+func TestCreativeInventory(t *testing.T) {
+	var inventory = NewCreativeInventory()
+	if err := inventory.LoadJSON([]byte(`[{"id": "minecraft:stone"}, {"id": "minecraft:unknown"}, {"id": "minecraft:wheat", "count": 3}]`), DefaultManager); err != nil {
+		t.Fatal(err)
+	}
+	var stacks = inventory.GetItems()
+	if len(stacks) != 2 || stacks[0].GetId() != "minecraft:stone" || stacks[1].Count != 3 {
+		t.Fatalf("expected stone and three wheat in order, got %v", stacks)
+	}
+	if !inventory.Remove("minecraft:stone") || len(inventory.GetItems()) != 1 {
+		t.Error("expected stone to be removed")
+	}
+	if len(DefaultCreativeInventory.GetItems()) == 0 {
+		t.Error("expected the embedded creative items to be loaded")
+	}
+}
@@ -22,9 +22,10 @@ type Manager struct {
 var DefaultManager = NewManager()
 
 // init initializes all default item types,
-// of the default item manager.
+// of the default item manager, and the default creative inventory.
 func init() {
 	DefaultManager.RegisterDefaults()
+	DefaultCreativeInventory.LoadJSON([]byte(creativeItemsJSON), DefaultManager)
 }
 
 // NewManager returns a new item registry.
@@ -137,4 +138,108 @@ func (registry *Manager) GetTypes() map[string]Type {
 func (registry *Manager) RegisterDefaults() {
 	registry.Register(NewType("minecraft:air"), false)
 	registry.Register(NewType("minecraft:stone"), true)
+
+	registry.RegisterMultiple([]Type{
+		NewBreakable("minecraft:wooden_hoe"),
+		NewBreakable("minecraft:stone_hoe"),
+		NewBreakable("minecraft:iron_hoe"),
+		NewBreakable("minecraft:diamond_hoe"),
+		NewBreakable("minecraft:golden_hoe"),
+		NewType("minecraft:wheat_seeds"),
+		NewType("minecraft:wheat"),
+		NewType("minecraft:carrot"),
+		NewType("minecraft:potato"),
+		NewType("minecraft:poisonous_potato"),
+		NewType("minecraft:beetroot"),
+		NewType("minecraft:beetroot_seeds"),
+		NewType("minecraft:bone_meal"),
+		NewType("minecraft:oak_sapling"),
+		NewType("minecraft:spruce_sapling"),
+		NewType("minecraft:birch_sapling"),
+		NewType("minecraft:jungle_sapling"),
+		NewType("minecraft:acacia_sapling"),
+		NewType("minecraft:dark_oak_sapling"),
+		NewType("minecraft:sugar_cane"),
+		NewType("minecraft:cactus"),
+		NewType("minecraft:bamboo"),
+	}, true)
+
+	registry.RegisterMultiple([]Type{
+		NewType("minecraft:cobblestone"),
+		NewType("minecraft:sand"),
+		NewType("minecraft:glass"),
+		NewType("minecraft:iron_ore"),
+		NewType("minecraft:iron_ingot"),
+		NewType("minecraft:gold_ore"),
+		NewType("minecraft:gold_ingot"),
+		NewType("minecraft:coal"),
+		NewType("minecraft:coal_block"),
+		NewType("minecraft:charcoal"),
+		NewType("minecraft:oak_log"),
+		NewType("minecraft:oak_planks"),
+		NewType("minecraft:stick"),
+		NewType("minecraft:clay_ball"),
+		NewType("minecraft:brick"),
+		NewType("minecraft:beef"),
+		NewType("minecraft:cooked_beef"),
+		NewType("minecraft:porkchop"),
+		NewType("minecraft:cooked_porkchop"),
+		NewType("minecraft:chicken"),
+		NewType("minecraft:cooked_chicken"),
+		NewType("minecraft:baked_potato"),
+		NewType("minecraft:dirt"),
+		NewType("minecraft:gravel"),
+		NewType("minecraft:sandstone"),
+		NewType("minecraft:obsidian"),
+		NewType("minecraft:diamond"),
+		NewType("minecraft:emerald"),
+		NewType("minecraft:lapis_lazuli"),
+		NewType("minecraft:redstone"),
+		NewType("minecraft:quartz"),
+		NewType("minecraft:snowball"),
+		NewType("minecraft:glowstone_dust"),
+		NewType("minecraft:book"),
+		NewType("minecraft:lead"),
+		NewType("minecraft:bone"),
+		NewType("minecraft:cod"),
+		NewType("minecraft:rotten_flesh"),
+		NewType("minecraft:string"),
+		NewType("minecraft:spider_eye"),
+		NewType("minecraft:feather"),
+		NewType("minecraft:leather"),
+		NewType("minecraft:mutton"),
+	}, false)
+
+	registry.RegisterMultiple([]Type{
+		NewBreakable("minecraft:leather_helmet"),
+		NewBreakable("minecraft:leather_chestplate"),
+		NewBreakable("minecraft:leather_leggings"),
+		NewBreakable("minecraft:leather_boots"),
+		NewBreakable("minecraft:golden_helmet"),
+		NewBreakable("minecraft:golden_chestplate"),
+		NewBreakable("minecraft:golden_leggings"),
+		NewBreakable("minecraft:golden_boots"),
+		NewBreakable("minecraft:chainmail_helmet"),
+		NewBreakable("minecraft:chainmail_chestplate"),
+		NewBreakable("minecraft:chainmail_leggings"),
+		NewBreakable("minecraft:chainmail_boots"),
+		NewBreakable("minecraft:iron_helmet"),
+		NewBreakable("minecraft:iron_chestplate"),
+		NewBreakable("minecraft:iron_leggings"),
+		NewBreakable("minecraft:iron_boots"),
+		NewBreakable("minecraft:diamond_helmet"),
+		NewBreakable("minecraft:diamond_chestplate"),
+		NewBreakable("minecraft:diamond_leggings"),
+		NewBreakable("minecraft:diamond_boots"),
+		NewBreakable("minecraft:wooden_sword"),
+		NewBreakable("minecraft:stone_sword"),
+		NewBreakable("minecraft:golden_sword"),
+		NewBreakable("minecraft:iron_sword"),
+		NewBreakable("minecraft:diamond_sword"),
+		NewBreakable("minecraft:wooden_shovel"),
+		NewBreakable("minecraft:stone_shovel"),
+		NewBreakable("minecraft:golden_shovel"),
+		NewBreakable("minecraft:iron_shovel"),
+		NewBreakable("minecraft:diamond_shovel"),
+	}, true)
 }
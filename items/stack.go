@@ -125,6 +125,31 @@ func (stack Stack) EqualsLore(stack2 *Stack) bool {
 	return true
 }
 
+// GetEnchantment returns the enchantment instance with the given ID applied on the stack,
+// and a bool indicating if the stack has the enchantment.
+func (stack Stack) GetEnchantment(id string) (enchantments.Instance, bool) {
+	var instance, ok = stack.enchantments[id]
+	return instance, ok
+}
+
+// GetEnchantmentLevel returns the level of the enchantment with the given ID applied on the stack.
+// 0 is returned if the stack does not have the enchantment.
+func (stack Stack) GetEnchantmentLevel(id string) int {
+	if instance, ok := stack.enchantments[id]; ok {
+		return int(instance.Level)
+	}
+	return 0
+}
+
+// SetEnchantment applies the enchantment instance with the given ID on the stack,
+// overwriting any enchantment with the same ID.
+func (stack *Stack) SetEnchantment(id string, instance enchantments.Instance) {
+	if stack.enchantments == nil {
+		stack.enchantments = make(map[string]enchantments.Instance)
+	}
+	stack.enchantments[id] = instance
+}
+
 // EqualsEnchantments checks if enchantments of two
 // item stacks are equal to each other.
 func (stack Stack) EqualsEnchantments(stack2 *Stack) bool {
@@ -0,0 +1,94 @@
+package gomine
+
+import (
+	"math"
+
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/packets/data"
+	"github.com/BobbyShrd/gominetest/net/packtransfer"
+	"github.com/BobbyShrd/gominetest/packs"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// setJoinPhase advances the session to the phase of the join sequence,
+// emitting a JoinPhaseEvent if the session was not yet in the phase.
+func (server *Server) setJoinPhase(session *net.MinecraftSession, phase net.JoinPhase) {
+	var previous, changed = session.SetJoinPhase(phase)
+	if !changed {
+		return
+	}
+	text.DefaultLogger.Debug(session.GetName(), "advanced from join phase", previous.String(), "to", phase.String(), "after", session.GetJoinDuration())
+	server.EventManager.Emit(NewJoinPhaseEvent(session, previous, phase))
+}
+
+// startPackDownload sends the data info of the pack to the session, and records that the session is about to download it
+// in chunks of the given size, without any chunks sent yet.
+// If the previous download of the pack by the player was interrupted, the download resumes from the remembered chunk index
+// in the chunk size it was sent in before.
+func (server *Server) startPackDownload(session *net.MinecraftSession, pack packs.Pack, chunkSize int32) {
+	var progress = net.PackProgress{UUID: pack.GetUUID(), Size: pack.GetFileSize(), ChunkSize: chunkSize}
+	if transfer, ok := server.PackTransfers.Get(session.GetXUID()); ok {
+		if resumed, ok := transfer.Packs[pack.GetUUID()]; ok && resumed.ChunkSize > 0 {
+			progress.ChunkSize, progress.SentChunks = resumed.ChunkSize, resumed.SentChunks
+		}
+		text.DefaultLogger.Debug(session.GetName(), "resumes downloading pack", pack.GetUUID(), "from chunk", progress.SentChunks, "in chunks of", progress.ChunkSize, "bytes, after", transfer.Interruptions, "interruption(s)")
+	}
+	progress.ChunkCount = getPackChunkCount(pack, progress.ChunkSize)
+	progress.SentBytes = int64(math.Min(float64(progress.Size), float64(int64(progress.SentChunks)*int64(progress.ChunkSize))))
+	session.SendResourcePackDataInfo(pack, progress.ChunkSize)
+	session.SetPackProgress(progress)
+}
+
+// sendPackChunk sends the chunk with the index of the pack to the session, in the chunk size the download started with,
+// and emits a PackDownloadEvent with the download progress of the pack.
+func (server *Server) sendPackChunk(session *net.MinecraftSession, pack packs.Pack, chunkIndex int32) {
+	var progress, ok = session.GetPackProgressOf(pack.GetUUID())
+	if !ok {
+		var chunkSize = server.getPackChunkSize(session)
+		progress = net.PackProgress{UUID: pack.GetUUID(), ChunkCount: getPackChunkCount(pack, chunkSize), Size: pack.GetFileSize(), ChunkSize: chunkSize}
+	}
+	var offset = int64(progress.ChunkSize) * int64(chunkIndex)
+	session.SendResourcePackChunkData(pack.GetUUID(), chunkIndex, offset, pack.GetChunk(int(offset), int(progress.ChunkSize)))
+
+	progress.SentChunks = chunkIndex + 1
+	progress.SentBytes = int64(math.Min(float64(progress.Size), float64(offset+int64(progress.ChunkSize))))
+	session.SetPackProgress(progress)
+	server.EventManager.Emit(NewPackDownloadEvent(session, progress))
+}
+
+// getPackChunkSize returns the size of the chunks packs are sent to the session in.
+// This is the configured chunk size, tuned down for players whose previous transfer was interrupted.
+func (server *Server) getPackChunkSize(session *net.MinecraftSession) int32 {
+	var chunkSize = server.Config.ResourcePackChunkSize
+	if chunkSize <= 0 {
+		chunkSize = int32(data.ResourcePackChunkSize)
+	}
+	if transfer, ok := server.PackTransfers.Get(session.GetXUID()); ok {
+		chunkSize = packtransfer.TuneChunkSize(chunkSize, transfer.Interruptions)
+	}
+	return chunkSize
+}
+
+// interruptPackTransfer remembers the pack transfer of the session if it disconnected before all packs were downloaded,
+// so that the transfer resumes from the remembered chunk indexes if the player reconnects.
+func (server *Server) interruptPackTransfer(session *net.MinecraftSession) {
+	if session.GetJoinPhase() != net.PhasePacks {
+		return
+	}
+	var incomplete = make(map[string]packtransfer.Progress)
+	for _, progress := range session.GetPackProgress() {
+		if !progress.IsComplete() {
+			incomplete[progress.UUID] = packtransfer.Progress{ChunkSize: progress.ChunkSize, SentChunks: progress.SentChunks}
+		}
+	}
+	if len(incomplete) == 0 {
+		return
+	}
+	var transfer = server.PackTransfers.Interrupt(session.GetXUID(), incomplete)
+	text.DefaultLogger.Debug(session.GetName(), "disconnected while downloading packs, interruption", transfer.Interruptions)
+}
+
+// getPackChunkCount returns the amount of chunks the pack is sent to clients in, with the given chunk size.
+func getPackChunkCount(pack packs.Pack, chunkSize int32) int32 {
+	return int32(math.Ceil(float64(pack.GetFileSize()) / float64(chunkSize)))
+}
@@ -0,0 +1,32 @@
+package gomine
+
+import (
+	"time"
+
+	"github.com/BobbyShrd/gominetest/lang"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// tickKeepAlive measures the latency of connected players every latency interval,
+// and kicks sessions that did not send any packet for the idle timeout, such as connections of crashed clients.
+// Clients answer the latency requests, which keeps idle players that are still connected from timing out.
+// Latency requests that were not answered within the latency interval expire, and are replaced by a new request.
+// Latency is not measured if the latency interval is 0, and sessions are never kicked if the idle timeout is 0.
+// The measured latency is shown by the list and ping commands only: player list entries have no latency field,
+// so clients can not be sent the latency of other players, and the pause menu does not show it.
+func (server *Server) tickKeepAlive() {
+	var interval = int64(server.Config.LatencyIntervalSeconds) * 20
+	var measure = interval > 0 && server.tick%interval == 0
+	var timeout = time.Duration(server.Config.IdleTimeoutSeconds) * time.Second
+
+	for _, session := range server.SessionManager.GetSessions() {
+		if timeout > 0 && session.IsIdle(timeout) {
+			text.DefaultLogger.Debug(session.GetName(), "did not send packets for", timeout, "and timed out")
+			session.Kick(server.Translate(session, lang.KickTimedOut), false, false)
+			continue
+		}
+		if measure && session.Connected {
+			session.MeasureLatency(time.Duration(server.Config.LatencyIntervalSeconds) * time.Second)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package lang
+
+// Keys of the built-in messages of the server.
+const (
+	KickInvalidUsername   = "gomine.kick.invalidUsername"
+	KickNameTaken         = "gomine.kick.nameTaken"
+	KickOtherLocation     = "gomine.kick.otherLocation"
+	KickOutdatedServer    = "gomine.kick.outdatedServer"
+	KickOutdatedClient    = "gomine.kick.outdatedClient"
+	KickInvalidLogin      = "gomine.kick.invalidLogin"
+	KickXBOXLiveRequired  = "gomine.kick.xboxLiveRequired"
+	KickInvalidSkin       = "gomine.kick.invalidSkin"
+	KickTimedOut          = "gomine.kick.timedOut"
+	KickProxy             = "gomine.kick.proxy"
+	KickInvalidForwarding = "gomine.kick.invalidForwarding"
+	KickServerBusy        = "gomine.kick.serverBusy"
+	ErrorUnknownPack      = "gomine.error.unknownPack"
+	ErrorPacksRequired    = "gomine.error.packsRequired"
+	ErrorCommandBlock     = "gomine.error.commandBlock"
+)
+
+// Keys of messages clients translate themselves, which are sent as translation.
+// Their translations are only used for the console.
+const (
+	PlayerJoined = "multiplayer.player.joined"
+	PlayerLeft   = "multiplayer.player.left"
+)
+
+func init() {
+	Register("en_US", map[string]string{
+		KickInvalidUsername:   "Invalid username.",
+		KickNameTaken:         "A player with this name is already online.",
+		KickOtherLocation:     "Logged in from another location.",
+		KickOutdatedServer:    "Outdated server.",
+		KickOutdatedClient:    "Outdated client.",
+		KickInvalidLogin:      "Invalid login data.",
+		KickXBOXLiveRequired:  "XBOX Live account required.",
+		KickInvalidSkin:       "Invalid skin.",
+		KickTimedOut:          "Timed out.",
+		KickProxy:             "Joining through a VPN or proxy is not allowed.",
+		KickInvalidForwarding: "Invalid forwarded address.",
+		KickServerBusy:        "The server is busy, please try again later.",
+		ErrorUnknownPack:      "Requested an unknown resource pack.",
+		ErrorPacksRequired:    "You must accept the resource packs to join this server.",
+		ErrorCommandBlock:     "You can not edit this command block. Command blocks can only be edited in creative mode, with permission to edit them, and within reach.",
+		PlayerJoined:          "%s joined the game",
+		PlayerLeft:            "%s left the game",
+	})
+	Register("de_DE", map[string]string{
+		KickInvalidUsername:   "Ungültiger Benutzername.",
+		KickNameTaken:         "Ein Spieler mit diesem Namen ist bereits online.",
+		KickOtherLocation:     "Von einem anderen Ort aus angemeldet.",
+		KickOutdatedServer:    "Veralteter Server.",
+		KickOutdatedClient:    "Veralteter Client.",
+		KickInvalidLogin:      "Ungültige Anmeldedaten.",
+		KickXBOXLiveRequired:  "XBOX Live-Konto erforderlich.",
+		KickInvalidSkin:       "Ungültiger Skin.",
+		KickTimedOut:          "Zeitüberschreitung.",
+		KickProxy:             "Das Beitreten über ein VPN oder einen Proxy ist nicht erlaubt.",
+		KickInvalidForwarding: "Ungültige weitergeleitete Adresse.",
+		KickServerBusy:        "Der Server ist ausgelastet, bitte versuche es später erneut.",
+		ErrorUnknownPack:      "Ein unbekanntes Ressourcenpaket wurde angefordert.",
+		ErrorPacksRequired:    "Du musst die Ressourcenpakete akzeptieren, um diesem Server beizutreten.",
+		ErrorCommandBlock:     "Du kannst diesen Befehlsblock nicht bearbeiten. Befehlsblöcke können nur im Kreativmodus, mit der Berechtigung dazu und in Reichweite bearbeitet werden.",
+		PlayerJoined:          "%s hat das Spiel betreten",
+		PlayerLeft:            "%s hat das Spiel verlassen",
+	})
+}
@@ -0,0 +1,146 @@
+package lang
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLanguage is the language used for unknown languages, and for keys missing in a language.
+const DefaultLanguage = "en_US"
+
+// Extension is the extension of translation files, which are named after their language, such as en_US.lang.
+const Extension = ".lang"
+
+var (
+	mutex        sync.RWMutex
+	translations = make(map[string]map[string]string)
+	languages    = make(map[string]string)
+)
+
+// Register adds the translations, indexed by key, to the language with the given code, such as en_US.
+// Existing translations of the same keys are overwritten.
+// The first language registered of a language family is used for other languages of that family.
+func Register(code string, entries map[string]string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if _, ok := translations[code]; !ok {
+		translations[code] = make(map[string]string, len(entries))
+	}
+	for key, value := range entries {
+		translations[code][key] = value
+	}
+	var family = strings.SplitN(code, "_", 2)[0]
+	if _, ok := languages[family]; !ok {
+		languages[family] = code
+	}
+}
+
+// Parse parses translations in the format of Minecraft language files,
+// which hold a key=value pair on every line. Empty lines and lines starting with # are skipped.
+func Parse(reader io.Reader) (map[string]string, error) {
+	var entries = make(map[string]string)
+	var scanner = bufio.NewScanner(reader)
+	for scanner.Scan() {
+		var line = strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var pair = strings.SplitN(line, "=", 2)
+		if len(pair) != 2 {
+			continue
+		}
+		entries[strings.TrimSpace(pair[0])] = pair[1]
+	}
+	return entries, scanner.Err()
+}
+
+// LoadDirectory registers the translations of all language files in the directory.
+// Nothing is loaded if the directory does not exist.
+func LoadDirectory(directory string) error {
+	var files, err = ioutil.ReadDir(directory)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != Extension {
+			continue
+		}
+		reader, err := os.Open(filepath.Join(directory, file.Name()))
+		if err != nil {
+			return err
+		}
+		entries, err := Parse(reader)
+		reader.Close()
+		if err != nil {
+			return err
+		}
+		Register(strings.TrimSuffix(file.Name(), Extension), entries)
+	}
+	return nil
+}
+
+// Translate returns the translation of the key in the language with the given code,
+// with %s replaced by the arguments in order and %1$s by the argument at that position.
+// The translation of a language of the same family is used if the language is unknown,
+// and the translation of the default language if the key is missing. The key itself is used if neither has it.
+func Translate(code string, key string, args ...interface{}) string {
+	mutex.RLock()
+	var translation, ok = translations[code][key]
+	if !ok {
+		translation, ok = translations[languages[strings.SplitN(code, "_", 2)[0]]][key]
+	}
+	if !ok {
+		translation, ok = translations[DefaultLanguage][key]
+	}
+	mutex.RUnlock()
+	if !ok {
+		return key
+	}
+	return format(translation, args)
+}
+
+// format replaces the placeholders in the translation with the arguments.
+func format(translation string, args []interface{}) string {
+	var builder strings.Builder
+	var next = 0
+	for i := 0; i < len(translation); i++ {
+		if translation[i] != '%' || i+1 == len(translation) {
+			builder.WriteByte(translation[i])
+			continue
+		}
+		if translation[i+1] == '%' {
+			builder.WriteByte('%')
+			i++
+			continue
+		}
+		if translation[i+1] == 's' {
+			if next < len(args) {
+				builder.WriteString(fmt.Sprint(args[next]))
+			}
+			next++
+			i++
+			continue
+		}
+		if end := strings.Index(translation[i:], "$s"); end > 1 {
+			if index, err := strconv.Atoi(translation[i+1 : i+end]); err == nil {
+				if index >= 1 && index <= len(args) {
+					builder.WriteString(fmt.Sprint(args[index-1]))
+				}
+				i += end + 1
+				continue
+			}
+		}
+		builder.WriteByte('%')
+	}
+	return builder.String()
+}
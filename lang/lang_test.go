@@ -0,0 +1,30 @@
+package lang
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+	if s := Translate("de_AT", KickTimedOut); s != "Zeitüberschreitung." {
+		t.Errorf("expected German translation for de_AT, got %q", s)
+	}
+	if s := Translate("xx_XX", KickTimedOut); s != "Timed out." {
+		t.Errorf("expected default translation for unknown language, got %q", s)
+	}
+	if s := Translate("en_US", "unknown.key"); s != "unknown.key" {
+		t.Errorf("expected key for missing translation, got %q", s)
+	}
+
+	entries, err := Parse(strings.NewReader("# Comment\n\ntest.greeting=Hello %s, you have %s%% health\ntest.swap=%2$s before %1$s\ninvalid line\n"))
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %v, %v", entries, err)
+	}
+	Register("en_US", entries)
+	if s := Translate("en_US", "test.greeting", "Steve", 20); s != "Hello Steve, you have 20% health" {
+		t.Errorf("unexpected translation %q", s)
+	}
+	if s := Translate("de_DE", "test.swap", "a", "b"); s != "b before a" {
+		t.Errorf("expected positional arguments from default language, got %q", s)
+	}
+}
@@ -0,0 +1,68 @@
+package gomine
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/BobbyShrd/gominetest/text"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+)
+
+// loadLevelData loads the level data of the default world, importing the world
+// configured to be imported first if the world does not have level data yet.
+// New level data is created if the world has none, and the spawn position is applied.
+func (server *Server) loadLevelData() {
+	server.LevelProvider = levels.NewGoMine(server.ServerPath + "worlds/world/")
+
+	var data, err = server.LevelProvider.LoadData()
+	if err == levels.NoLevelData && server.Config.ImportWorld != "" {
+		var source = levels.Open(server.Config.ImportWorld)
+		if source, ok := source.(*levels.LevelDB); ok {
+			source.BlockLookup = server.GetLegacyBlock
+		}
+		text.DefaultLogger.Info("Importing", source.GetFormat(), "world from", server.Config.ImportWorld+"...")
+		if _, err := levels.Import(source, server.LevelProvider.GetDirectory()); err != nil {
+			text.DefaultLogger.Error("Failed to import world:", err)
+		}
+		data, err = server.LevelProvider.LoadData()
+	}
+
+	if err != nil {
+		if err != levels.NoLevelData {
+			text.DefaultLogger.Error("Failed to load level data, creating new level data:", err)
+		}
+		data = levels.NewData("world", rand.New(rand.NewSource(time.Now().UnixNano())).Int63(), int32(SpawnPosition.X), int32(SpawnPosition.Y), int32(SpawnPosition.Z))
+		text.DefaultLogger.LogError(server.LevelProvider.SaveData(data))
+	}
+	server.LevelData = data
+	SpawnPosition = r3.Vector{X: float64(data.SpawnX), Y: float64(data.SpawnY), Z: float64(data.SpawnZ)}
+}
+
+// GetLevelSeed returns the seed of the given level, as stored in its level data.
+// The seed of the default world is returned for levels that were not loaded by the server.
+func (server *Server) GetLevelSeed(level *worlds.Level) int64 {
+	server.worldMutex.Lock()
+	defer server.worldMutex.Unlock()
+	if data, ok := server.levelData[level]; ok {
+		return data.Seed
+	}
+	return server.LevelData.Seed
+}
+
+// SaveLevelData saves the level data of the level to its world directory,
+// along with the time, weather and game rules of the level.
+// Nothing is saved for levels that were not loaded by the server.
+func (server *Server) SaveLevelData(level *worlds.Level) error {
+	server.worldMutex.Lock()
+	var stored, ok = server.levelData[level]
+	server.worldMutex.Unlock()
+	if !ok {
+		return nil
+	}
+	var data = *stored
+	saveWeatherCycle(server.GetWeatherCycle(level), &data)
+	saveGameRules(server.GetGameRules(level), &data)
+	return levels.NewGoMine(server.GetWorldDirectory(level.GetName())).SaveData(&data)
+}
@@ -0,0 +1,62 @@
+package levels
+
+// Chunk is a chunk column of a world, holding its blocks by legacy ID and data value.
+type Chunk struct {
+	X, Z int32
+	// SubChunks holds the sub-chunks of the chunk from the bottom up. Sub-chunks that were never stored are nil.
+	SubChunks [16]*SubChunk
+	// HeightMap holds the height of the highest block of every column of the chunk, by z<<4 | x.
+	HeightMap [256]int16
+	// Biomes holds the biome ID of every column of the chunk, by z<<4 | x.
+	Biomes [256]byte
+}
+
+// NewChunk returns a new chunk without sub-chunks at the given chunk coordinates.
+func NewChunk(x, z int32) *Chunk {
+	return &Chunk{X: x, Z: z}
+}
+
+// SubChunk is a 16x16x16 section of a chunk.
+// Blocks and light are indexed by x<<8 | z<<4 | y, like in Bedrock worlds.
+// Data values and light levels take up half a byte each, with even indexes in the low half.
+type SubChunk struct {
+	Ids        [4096]byte
+	Data       [2048]byte
+	SkyLight   [2048]byte
+	BlockLight [2048]byte
+}
+
+// subChunkIndex returns the index of the block at the given coordinates in a sub-chunk.
+func subChunkIndex(x, y, z int) int {
+	return x<<8 | z<<4 | y
+}
+
+// GetBlock returns the legacy ID and data value of the block at the given coordinates in the sub-chunk.
+func (subChunk *SubChunk) GetBlock(x, y, z int) (byte, byte) {
+	var index = subChunkIndex(x, y, z)
+	return subChunk.Ids[index], getNibble(subChunk.Data[:], index)
+}
+
+// SetBlock sets the legacy ID and data value of the block at the given coordinates in the sub-chunk.
+func (subChunk *SubChunk) SetBlock(x, y, z int, id, data byte) {
+	var index = subChunkIndex(x, y, z)
+	subChunk.Ids[index] = id
+	setNibble(subChunk.Data[:], index, data)
+}
+
+// getNibble returns the half byte at the given index of the array.
+func getNibble(array []byte, index int) byte {
+	if index&1 == 0 {
+		return array[index>>1] & 0x0f
+	}
+	return array[index>>1] >> 4
+}
+
+// setNibble sets the half byte at the given index of the array.
+func setNibble(array []byte, index int, value byte) {
+	if index&1 == 0 {
+		array[index>>1] = array[index>>1]&0xf0 | value&0x0f
+	} else {
+		array[index>>1] = array[index>>1]&0x0f | value<<4
+	}
+}
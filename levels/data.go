@@ -0,0 +1,238 @@
+package levels
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/irmine/binutils"
+	"github.com/irmine/gonbt"
+)
+
+// StorageVersion is the storage version written in the header of Bedrock level.dat files.
+const StorageVersion int32 = 8
+
+var (
+	NoLevelData      = errors.New("world has no level.dat")
+	InvalidLevelData = errors.New("level.dat is corrupted")
+)
+
+// GameRules are the names of the game rules stored in level data.
+// Game rules are stored in lower case in Bedrock worlds, which is used for all formats.
+var GameRules = []string{
+	"commandblockoutput",
+	"dodaylightcycle",
+	"doentitydrops",
+	"dofiretick",
+	"domobloot",
+	"domobspawning",
+	"dotiledrops",
+	"doweathercycle",
+	"drowningdamage",
+	"falldamage",
+	"firedamage",
+	"keepinventory",
+	"mobgriefing",
+	"naturalregeneration",
+	"pvp",
+	"randomtickspeed",
+	"sendcommandfeedback",
+	"showcoordinates",
+	"tntexplodes",
+}
+
+// Data is the metadata of a level, as stored in its level.dat file.
+type Data struct {
+	Name   string
+	Seed   int64
+	SpawnX int32
+	SpawnY int32
+	SpawnZ int32
+	Time   int64
+	// Raining and Thundering hold the weather of the level,
+	// and WeatherTime the amount of ticks left before the weather changes.
+	Raining     bool
+	Thundering  bool
+	WeatherTime int32
+	// DataVersion holds the data version of Java worlds, which is 0 for other worlds,
+	// and for Java worlds saved before Java Edition 1.9.
+	DataVersion int32
+	// GameRules holds the game rules of the level by lower case name.
+	// Values are stored as strings, such as "true" or "3".
+	GameRules map[string]string
+
+	// tags holds all tags read from the level.dat file,
+	// so tags not known by the server are written back unchanged.
+	tags map[string]gonbt.INamedTag
+}
+
+// NewData returns new level data with the given name, seed and spawn position.
+func NewData(name string, seed int64, spawnX, spawnY, spawnZ int32) *Data {
+	return &Data{Name: name, Seed: seed, SpawnX: spawnX, SpawnY: spawnY, SpawnZ: spawnZ, GameRules: make(map[string]string), tags: make(map[string]gonbt.INamedTag)}
+}
+
+// GetGameRule returns the value of the game rule with the given name.
+// A bool is returned indicating if the game rule is set.
+func (data *Data) GetGameRule(name string) (string, bool) {
+	var value, ok = data.GameRules[strings.ToLower(name)]
+	return value, ok
+}
+
+// SetGameRule sets the value of the game rule with the given name.
+func (data *Data) SetGameRule(name string, value string) {
+	data.GameRules[strings.ToLower(name)] = value
+}
+
+// isGameRule checks if the tag with the given name is a game rule.
+func isGameRule(name string) bool {
+	for _, rule := range GameRules {
+		if rule == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadBedrockData reads Bedrock level data from the level.dat file at the given path.
+// Bedrock level.dat files hold a header of the storage version and length,
+// followed by a little endian NBT compound.
+func ReadBedrockData(path string) (*Data, error) {
+	var file, err = ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, NoLevelData
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(file) < 8 || int(binary.LittleEndian.Uint32(file[4:8])) > len(file)-8 {
+		return nil, InvalidLevelData
+	}
+	var compound = gonbt.NewReader(file[8:8+binary.LittleEndian.Uint32(file[4:8])], false, binutils.LittleEndian).ReadIntoCompound()
+	if compound == nil {
+		return nil, InvalidLevelData
+	}
+
+	var data = NewData(compound.GetString("LevelName", ""), compound.GetLong("RandomSeed", 0), compound.GetInt("SpawnX", 0), compound.GetInt("SpawnY", 0), compound.GetInt("SpawnZ", 0))
+	data.Time = compound.GetLong("Time", 0)
+	data.Raining = compound.GetFloat("rainLevel", 0) > 0
+	data.Thundering = compound.GetFloat("lightningLevel", 0) > 0
+	data.WeatherTime = compound.GetInt("rainTime", 0)
+	for name, tag := range compound.GetTags() {
+		data.tags[name] = tag
+		if !isGameRule(name) {
+			continue
+		}
+		switch tag.GetType() {
+		case gonbt.TAG_Byte:
+			data.GameRules[name] = strconv.FormatBool(tag.Interface().(byte) != 0)
+		case gonbt.TAG_Int:
+			data.GameRules[name] = strconv.Itoa(int(tag.Interface().(int32)))
+		case gonbt.TAG_Float:
+			data.GameRules[name] = strconv.FormatFloat(float64(tag.Interface().(float32)), 'g', -1, 32)
+		case gonbt.TAG_String:
+			data.GameRules[name] = tag.Interface().(string)
+		}
+	}
+	return data, nil
+}
+
+// WriteBedrock writes the level data to the Bedrock level.dat file at the given path.
+// The directory of the file gets created if it does not yet exist.
+func (data *Data) WriteBedrock(path string) error {
+	var tags = make(map[string]gonbt.INamedTag, len(data.tags))
+	for name, tag := range data.tags {
+		tags[name] = tag
+	}
+	tags["LevelName"] = gonbt.NewString("LevelName", data.Name)
+	tags["RandomSeed"] = gonbt.NewLong("RandomSeed", data.Seed)
+	tags["SpawnX"] = gonbt.NewInt("SpawnX", data.SpawnX)
+	tags["SpawnY"] = gonbt.NewInt("SpawnY", data.SpawnY)
+	tags["SpawnZ"] = gonbt.NewInt("SpawnZ", data.SpawnZ)
+	tags["Time"] = gonbt.NewLong("Time", data.Time)
+	tags["rainLevel"] = gonbt.NewFloat("rainLevel", weatherLevel(data.Raining))
+	tags["lightningLevel"] = gonbt.NewFloat("lightningLevel", weatherLevel(data.Thundering))
+	tags["rainTime"] = gonbt.NewInt("rainTime", data.WeatherTime)
+	tags["lightningTime"] = gonbt.NewInt("lightningTime", data.WeatherTime)
+	tags["StorageVersion"] = gonbt.NewInt("StorageVersion", StorageVersion)
+	for name, value := range data.GameRules {
+		if value == "true" || value == "false" {
+			var b byte
+			if value == "true" {
+				b = 1
+			}
+			tags[name] = gonbt.NewByte(name, b)
+		} else if i, err := strconv.Atoi(value); err == nil {
+			tags[name] = gonbt.NewInt(name, int32(i))
+		} else if f, err := strconv.ParseFloat(value, 32); err == nil {
+			tags[name] = gonbt.NewFloat(name, float32(f))
+		} else {
+			tags[name] = gonbt.NewString(name, value)
+		}
+	}
+
+	var writer = gonbt.NewWriter(false, binutils.LittleEndian)
+	writer.WriteUncompressedCompound(gonbt.NewCompound("", tags))
+	var header = make([]byte, 8)
+	binary.LittleEndian.PutUint32(header[0:4], uint32(StorageVersion))
+	binary.LittleEndian.PutUint32(header[4:8], uint32(len(writer.GetData())))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(header, writer.GetData()...), 0644)
+}
+
+// ReadJavaData reads Java Edition level data from the level.dat file at the given path.
+// Java level.dat files hold a gzip compressed big endian NBT compound,
+// with the level data in a nested Data compound.
+func ReadJavaData(path string) (*Data, error) {
+	var file, err = ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, NoLevelData
+	}
+	if err != nil {
+		return nil, err
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(file))
+	if err != nil {
+		return nil, InvalidLevelData
+	}
+	uncompressed, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, InvalidLevelData
+	}
+	var root = gonbt.NewReader(uncompressed, false, binutils.BigEndian).ReadIntoCompound()
+	if root == nil || root.GetCompound("Data") == nil {
+		return nil, InvalidLevelData
+	}
+	var compound = root.GetCompound("Data")
+
+	var data = NewData(compound.GetString("LevelName", ""), compound.GetLong("RandomSeed", 0), compound.GetInt("SpawnX", 0), compound.GetInt("SpawnY", 0), compound.GetInt("SpawnZ", 0))
+	data.Time = compound.GetLong("Time", 0)
+	data.Raining = compound.GetByte("raining", 0) != 0
+	data.Thundering = compound.GetByte("thundering", 0) != 0
+	data.WeatherTime = compound.GetInt("rainTime", 0)
+	data.DataVersion = compound.GetInt("DataVersion", 0)
+	if rules := compound.GetCompound("GameRules"); rules != nil {
+		for name, tag := range rules.GetTags() {
+			if tag.GetType() == gonbt.TAG_String && isGameRule(strings.ToLower(name)) {
+				data.GameRules[strings.ToLower(name)] = tag.Interface().(string)
+			}
+		}
+	}
+	return data, nil
+}
+
+// weatherLevel returns the strength of rain or lightning stored in Bedrock level.dat files for the weather being active.
+func weatherLevel(active bool) float32 {
+	if active {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,53 @@
+package levels
+
+import (
+	"errors"
+)
+
+// VoidDepth is the distance below the minimum height of a dimension at which entities start taking void damage.
+const VoidDepth = 64
+
+var InvalidHeightRange = errors.New("height range bounds must be multiples of 16, with the minimum at or above 0 and below the maximum")
+
+// HeightRange is the range of heights blocks can be placed at in a dimension.
+// The minimum is inclusive, and the maximum exclusive.
+type HeightRange struct {
+	Min int32
+	Max int32
+}
+
+// defaultHeightRanges are the height ranges of the vanilla dimensions supported by the chunk format, indexed by dimension name.
+var defaultHeightRanges = map[string]HeightRange{
+	Overworld: {Min: 0, Max: 256},
+	Nether:    {Min: 0, Max: 128},
+	End:       {Min: 0, Max: 256},
+}
+
+// GetDefaultHeightRange returns the vanilla height range of the dimension with the given name.
+// The range of the overworld is returned for dimensions that are not vanilla dimensions.
+func GetDefaultHeightRange(dimension string) HeightRange {
+	if heights, ok := defaultHeightRanges[dimension]; ok {
+		return heights
+	}
+	return defaultHeightRanges[Overworld]
+}
+
+// Validate checks if the range can be stored in sub-chunks,
+// which requires both bounds to be multiples of 16. Chunks hold no blocks below height 0,
+// so the minimum may not be negative.
+func (heights HeightRange) Validate() error {
+	if heights.Min < 0 || heights.Min >= heights.Max || heights.Min%16 != 0 || heights.Max%16 != 0 {
+		return InvalidHeightRange
+	}
+	return nil
+}
+
+// Contains checks if blocks can be placed at the given height.
+func (heights HeightRange) Contains(y int32) bool {
+	return y >= heights.Min && y < heights.Max
+}
+
+// IsInVoid checks if an entity at the given height is in the void, where it takes void damage.
+func (heights HeightRange) IsInVoid(y float64) bool {
+	return y < float64(heights.Min-VoidDepth)
+}
@@ -0,0 +1,26 @@
+package levels
+
+import (
+	"testing"
+)
+
+func TestHeightRange(t *testing.T) {
+	var overworld = GetDefaultHeightRange(Overworld)
+	if overworld.Validate() != nil {
+		t.Errorf("expected a valid overworld range, got %+v", overworld)
+	}
+	if !overworld.Contains(0) || !overworld.Contains(255) || overworld.Contains(256) || overworld.Contains(-1) {
+		t.Errorf("unexpected bounds of %+v", overworld)
+	}
+	if !overworld.IsInVoid(-64.5) || overworld.IsInVoid(-40) {
+		t.Error("expected the void to start 64 blocks below the minimum height")
+	}
+	if nether := GetDefaultHeightRange(Nether); nether.Validate() != nil || nether.Contains(128) {
+		t.Errorf("unexpected nether range %+v", nether)
+	}
+	for _, heights := range []HeightRange{{Min: 0, Max: 0}, {Min: -64, Max: 320}, {Min: 0, Max: 100}} {
+		if heights.Validate() != InvalidHeightRange {
+			t.Errorf("expected %+v to be invalid", heights)
+		}
+	}
+}
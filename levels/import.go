@@ -0,0 +1,123 @@
+package levels
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FlatteningDataVersion is the data version of the first Java worlds storing blocks as block states.
+// Chunks of these worlds can not be read in the GoMine format, which stores blocks by ID and data value.
+const FlatteningDataVersion = 1451
+
+var UnsupportedJavaVersion = errors.New("Java worlds saved by Java Edition 1.13 or later can not be imported, as their chunks store block states")
+
+// Import imports the world of the provider into a GoMine world in the destination directory.
+// The chunks of all dimensions and the level data, including the seed, spawn position
+// and game rules, are copied, replacing files of the same name in the destination.
+// Region files are copied as is, as GoMine worlds store chunks like Java worlds saved before Java Edition 1.13.
+// The chunks of LevelDB worlds are converted to region files. UnsupportedJavaVersion is returned for Java worlds with block states.
+func Import(source Provider, destination string) (*GoMine, error) {
+	var data, err = source.LoadData()
+	if err != nil {
+		return nil, err
+	}
+	if source.GetFormat() == FormatAnvil && data.DataVersion >= FlatteningDataVersion {
+		return nil, UnsupportedJavaVersion
+	}
+	var target = NewGoMine(destination)
+	if source, ok := source.(*LevelDB); ok {
+		if err := importChunks(source, target); err != nil {
+			return nil, err
+		}
+		return target, target.SaveData(data)
+	}
+	for _, dimension := range []string{Overworld, Nether, End} {
+		var sourceDirectory, err = source.GetRegionDirectory(dimension)
+		if err != nil {
+			return nil, err
+		}
+		var targetDirectory, _ = target.GetRegionDirectory(dimension)
+		if err := copyRegionFiles(sourceDirectory, targetDirectory); err != nil {
+			return nil, err
+		}
+	}
+	return target, target.SaveData(data)
+}
+
+// importChunks converts the chunks of all dimensions of the LevelDB world to region files of the GoMine world.
+// Chunks are read one region at a time, so that only the chunks of one region are held in memory.
+func importChunks(source *LevelDB, target *GoMine) error {
+	defer source.Close()
+	for _, dimension := range []string{Overworld, Nether, End} {
+		var positions, err = source.GetChunks(dimension)
+		if err != nil {
+			return err
+		}
+		var regions = make(map[[2]int32][][2]int32)
+		for _, position := range positions {
+			var region = [2]int32{position[0] >> 5, position[1] >> 5}
+			regions[region] = append(regions[region], position)
+		}
+		var directory, _ = target.GetRegionDirectory(dimension)
+		for region, positions := range regions {
+			var chunks = make([]*Chunk, 0, len(positions))
+			for _, position := range positions {
+				var chunk, err = source.LoadChunk(dimension, position[0], position[1])
+				if err != nil {
+					return err
+				}
+				chunks = append(chunks, chunk)
+			}
+			if err := writeRegionFile(getRegionPath(directory, region[0]<<5, region[1]<<5), chunks); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyRegionFiles copies all region files in the source directory to the destination directory.
+// Nothing is copied if the source directory does not exist.
+func copyRegionFiles(source, destination string) error {
+	var files, err = ioutil.ReadDir(source)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(destination, 0700); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".mca") {
+			continue
+		}
+		if err := copyFile(filepath.Join(source, file.Name()), filepath.Join(destination, file.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFile copies the file at the source path to the destination path.
+func copyFile(source, destination string) error {
+	var in, err = os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
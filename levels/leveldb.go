@@ -0,0 +1,387 @@
+package levels
+
+import (
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/palette"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/df-mc/goleveldb/leveldb/opt"
+	"github.com/irmine/binutils"
+	"github.com/irmine/gonbt"
+)
+
+// Keys of the records of a chunk in a LevelDB world.
+// Every key starts with the chunk coordinates and, outside the overworld, the dimension ID, followed by the tag.
+const (
+	// TagData3D holds the height map of the chunk, followed by its 3D biomes.
+	TagData3D byte = 0x2b
+	// TagVersion holds the chunk version, as saved by Bedrock Edition 1.16.100 and later.
+	TagVersion byte = 0x2c
+	// TagData2D holds the height map of the chunk, followed by its biomes.
+	TagData2D byte = 0x2d
+	// TagSubChunkPrefix holds a sub-chunk, with the index of the sub-chunk appended to the key.
+	TagSubChunkPrefix byte = 0x2f
+	// TagLegacyVersion holds the chunk version, as saved by Bedrock Edition before 1.16.100.
+	TagLegacyVersion byte = 0x76
+)
+
+// ChunkVersion is the chunk version saved with chunks written by the server.
+// Chunks are written with legacy sub-chunks, which Bedrock Edition upgrades when it loads them.
+const ChunkVersion = 7
+
+var (
+	NoChunk                    = errors.New("chunk does not exist")
+	InvalidChunk               = errors.New("chunk data is corrupted")
+	UnsupportedSubChunkVersion = errors.New("sub-chunk version is not supported")
+)
+
+// BlockLookup returns the legacy ID and data value of a block state of a paletted sub-chunk,
+// and a bool indicating if the block has a legacy ID. States saved before block states had properties
+// have no properties, and hold the data value saved with them in LegacyData.
+type BlockLookup func(state palette.State) (id byte, data byte, ok bool)
+
+// LevelDB is a provider of worlds created by Bedrock Edition, which store their chunks in a LevelDB database.
+// LevelDB worlds are imported to the GoMine format with Import, which reads their chunks with LoadChunk.
+type LevelDB struct {
+	directory string
+	mutex     sync.Mutex
+	database  *leveldb.DB
+
+	// BlockLookup is used to find the legacy ID and data value of blocks in paletted sub-chunks,
+	// as saved by Bedrock Edition 1.2.13 and later. Blocks are read as air if nil, or if the lookup fails.
+	BlockLookup BlockLookup
+}
+
+// NewLevelDB returns a new provider of the Bedrock world in the given directory.
+func NewLevelDB(directory string) *LevelDB {
+	return &LevelDB{directory: directory}
+}
+
+// GetFormat returns the format of the world.
+func (provider *LevelDB) GetFormat() string {
+	return FormatLevelDB
+}
+
+// GetDirectory returns the directory of the world.
+func (provider *LevelDB) GetDirectory() string {
+	return provider.directory
+}
+
+// LoadData loads the level data of the world from its level.dat.
+func (provider *LevelDB) LoadData() (*Data, error) {
+	return ReadBedrockData(filepath.Join(provider.directory, "level.dat"))
+}
+
+// SaveData saves the level data of the world to its level.dat.
+func (provider *LevelDB) SaveData(data *Data) error {
+	return data.WriteBedrock(filepath.Join(provider.directory, "level.dat"))
+}
+
+// GetRegionDirectory always returns UnsupportedChunkStorage,
+// as LevelDB worlds store their chunks in their database. LoadChunk and SaveChunk are used instead.
+func (provider *LevelDB) GetRegionDirectory(dimension string) (string, error) {
+	return "", UnsupportedChunkStorage
+}
+
+// getDatabase returns the database of the world, opening it if it was not yet opened.
+func (provider *LevelDB) getDatabase() (*leveldb.DB, error) {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	if provider.database != nil {
+		return provider.database, nil
+	}
+	var database, err = leveldb.OpenFile(filepath.Join(provider.directory, "db"), &opt.Options{Compression: opt.FlateCompression})
+	if err != nil {
+		return nil, err
+	}
+	provider.database = database
+	return database, nil
+}
+
+// Close closes the database of the world, if it was opened.
+func (provider *LevelDB) Close() error {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	if provider.database == nil {
+		return nil
+	}
+	var err = provider.database.Close()
+	provider.database = nil
+	return err
+}
+
+// getDimensionId returns the ID of the dimension with the given name in the keys of LevelDB worlds.
+func getDimensionId(dimension string) int32 {
+	switch dimension {
+	case Nether:
+		return 1
+	case End:
+		return 2
+	}
+	return 0
+}
+
+// chunkKey returns the key of the record with the given tag of the chunk at the chunk coordinates in the dimension.
+// The index of the sub-chunk is appended for sub-chunk records.
+func chunkKey(dimension string, x, z int32, tag byte, index ...byte) []byte {
+	var key = make([]byte, 8, 14)
+	binary.LittleEndian.PutUint32(key[0:4], uint32(x))
+	binary.LittleEndian.PutUint32(key[4:8], uint32(z))
+	if id := getDimensionId(dimension); id != 0 {
+		key = append(key, 0, 0, 0, 0)
+		binary.LittleEndian.PutUint32(key[8:12], uint32(id))
+	}
+	return append(append(key, tag), index...)
+}
+
+// GetChunks returns the chunk coordinates of all chunks stored in the dimension with the given name.
+func (provider *LevelDB) GetChunks(dimension string) ([][2]int32, error) {
+	var database, err = provider.getDatabase()
+	if err != nil {
+		return nil, err
+	}
+	var id = getDimensionId(dimension)
+	var positions [][2]int32
+	var iterator = database.NewIterator(nil, nil)
+	defer iterator.Release()
+	for iterator.Next() {
+		var key = iterator.Key()
+		if len(key) != 9 && len(key) != 13 || key[len(key)-1] != TagVersion && key[len(key)-1] != TagLegacyVersion {
+			continue
+		}
+		if len(key) == 13 && int32(binary.LittleEndian.Uint32(key[8:12])) != id || len(key) == 9 && id != 0 {
+			continue
+		}
+		positions = append(positions, [2]int32{int32(binary.LittleEndian.Uint32(key[0:4])), int32(binary.LittleEndian.Uint32(key[4:8]))})
+	}
+	return positions, iterator.Error()
+}
+
+// LoadChunk loads the chunk at the given chunk coordinates in the dimension with the given name.
+// NoChunk is returned if the chunk was never saved. Sub-chunks of all versions saved by Bedrock Edition
+// up to 1.17 can be read. Only the first block storage of paletted sub-chunks is read, so water of waterlogged blocks is left out.
+func (provider *LevelDB) LoadChunk(dimension string, x, z int32) (*Chunk, error) {
+	var database, err = provider.getDatabase()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := hasChunk(database, dimension, x, z); err != nil || !ok {
+		if err != nil {
+			return nil, err
+		}
+		return nil, NoChunk
+	}
+
+	var chunk = NewChunk(x, z)
+	data2D, err := database.Get(chunkKey(dimension, x, z, TagData2D), nil)
+	if err == leveldb.ErrNotFound {
+		data2D, err = database.Get(chunkKey(dimension, x, z, TagData3D), nil)
+	}
+	if err != nil && err != leveldb.ErrNotFound {
+		return nil, err
+	}
+	decodeData2D(chunk, data2D)
+
+	for index := range chunk.SubChunks {
+		var data, err = database.Get(chunkKey(dimension, x, z, TagSubChunkPrefix, byte(index)), nil)
+		if err == leveldb.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		subChunk, err := decodeSubChunk(data, provider.BlockLookup)
+		if err != nil {
+			return nil, err
+		}
+		chunk.SubChunks[index] = subChunk
+	}
+	return chunk, nil
+}
+
+// hasChunk checks if the database holds the chunk version of the chunk at the given chunk coordinates in the dimension,
+// which every saved chunk has.
+func hasChunk(database *leveldb.DB, dimension string, x, z int32) (bool, error) {
+	if ok, err := database.Has(chunkKey(dimension, x, z, TagVersion), nil); err != nil || ok {
+		return ok, err
+	}
+	return database.Has(chunkKey(dimension, x, z, TagLegacyVersion), nil)
+}
+
+// SaveChunk saves the chunk in the dimension with the given name, replacing the chunk saved at its coordinates.
+// Sub-chunks are saved in the legacy format, with their light.
+func (provider *LevelDB) SaveChunk(dimension string, chunk *Chunk) error {
+	var database, err = provider.getDatabase()
+	if err != nil {
+		return err
+	}
+	var batch = new(leveldb.Batch)
+	batch.Put(chunkKey(dimension, chunk.X, chunk.Z, TagLegacyVersion), []byte{ChunkVersion})
+	batch.Delete(chunkKey(dimension, chunk.X, chunk.Z, TagVersion))
+	batch.Delete(chunkKey(dimension, chunk.X, chunk.Z, TagData3D))
+	batch.Put(chunkKey(dimension, chunk.X, chunk.Z, TagData2D), encodeData2D(chunk))
+	for index, subChunk := range chunk.SubChunks {
+		var key = chunkKey(dimension, chunk.X, chunk.Z, TagSubChunkPrefix, byte(index))
+		if subChunk == nil {
+			batch.Delete(key)
+			continue
+		}
+		batch.Put(key, encodeSubChunk(subChunk))
+	}
+	return database.Write(batch, nil)
+}
+
+// decodeData2D reads the height map and biomes of the chunk from the 2D data record.
+// The biomes are only read from 2D data, as 3D data holds paletted biomes.
+func decodeData2D(chunk *Chunk, data []byte) {
+	if len(data) < 512 {
+		return
+	}
+	for i := range chunk.HeightMap {
+		chunk.HeightMap[i] = int16(binary.LittleEndian.Uint16(data[i*2:]))
+	}
+	if len(data) == 512+256 {
+		copy(chunk.Biomes[:], data[512:])
+	}
+}
+
+// encodeData2D returns the 2D data record of the chunk, holding its height map followed by its biomes.
+func encodeData2D(chunk *Chunk) []byte {
+	var data = make([]byte, 512+256)
+	for i, height := range chunk.HeightMap {
+		binary.LittleEndian.PutUint16(data[i*2:], uint16(height))
+	}
+	copy(data[512:], chunk.Biomes[:])
+	return data
+}
+
+// encodeSubChunk returns the sub-chunk in the legacy format of sub-chunk version 0:
+// the version, followed by the IDs, data values, sky light and block light.
+func encodeSubChunk(subChunk *SubChunk) []byte {
+	var data = make([]byte, 0, 1+4096+2048*3)
+	data = append(data, 0)
+	data = append(data, subChunk.Ids[:]...)
+	data = append(data, subChunk.Data[:]...)
+	data = append(data, subChunk.SkyLight[:]...)
+	return append(data, subChunk.BlockLight[:]...)
+}
+
+// decodeSubChunk reads a sub-chunk of any version up to 9.
+// Legacy sub-chunks hold IDs and data values, and paletted sub-chunks hold block storages with a palette of block states,
+// which are looked up with the block lookup.
+func decodeSubChunk(data []byte, lookup BlockLookup) (*SubChunk, error) {
+	if len(data) == 0 {
+		return nil, InvalidChunk
+	}
+	switch data[0] {
+	case 0, 2, 3, 4, 5, 6, 7:
+		if len(data) < 1+4096+2048 {
+			return nil, InvalidChunk
+		}
+		var subChunk = &SubChunk{}
+		copy(subChunk.Ids[:], data[1:])
+		copy(subChunk.Data[:], data[1+4096:])
+		if len(data) >= 1+4096+2048*3 {
+			copy(subChunk.SkyLight[:], data[1+4096+2048:])
+			copy(subChunk.BlockLight[:], data[1+4096+2048*2:])
+		}
+		return subChunk, nil
+	case 1:
+		return decodeBlockStorage(data[1:], lookup)
+	case 8:
+		if len(data) < 2 {
+			return nil, InvalidChunk
+		}
+		if data[1] == 0 {
+			return &SubChunk{}, nil
+		}
+		return decodeBlockStorage(data[2:], lookup)
+	case 9:
+		if len(data) < 3 {
+			return nil, InvalidChunk
+		}
+		if data[1] == 0 {
+			return &SubChunk{}, nil
+		}
+		return decodeBlockStorage(data[3:], lookup)
+	}
+	return nil, UnsupportedSubChunkVersion
+}
+
+// decodeBlockStorage reads the block storage at the start of the data into a new sub-chunk.
+// The storage starts with a header holding the bits used per block, followed by the palette indexes of all blocks
+// packed in little endian words, and the palette of block states as little endian NBT compounds.
+// Block storages do not hold light, so the sub-chunk gets full sky light.
+func decodeBlockStorage(data []byte, lookup BlockLookup) (*SubChunk, error) {
+	if len(data) == 0 || data[0]&1 != 0 {
+		return nil, InvalidChunk
+	}
+	var bits = int(data[0] >> 1)
+	var offset = 1
+	var indexes = make([]int, 4096)
+	switch bits {
+	case 0:
+	case 1, 2, 3, 4, 5, 6, 8, 16:
+		var perWord = 32 / bits
+		var words = (4096 + perWord - 1) / perWord
+		if len(data) < offset+words*4 {
+			return nil, InvalidChunk
+		}
+		var mask = uint32(1)<<uint(bits) - 1
+		for i := range indexes {
+			var word = binary.LittleEndian.Uint32(data[offset+i/perWord*4:])
+			indexes[i] = int(word >> uint(i%perWord*bits) & mask)
+		}
+		offset += words * 4
+	default:
+		return nil, InvalidChunk
+	}
+
+	var size = 1
+	if bits != 0 {
+		if len(data) < offset+4 {
+			return nil, InvalidChunk
+		}
+		size = int(int32(binary.LittleEndian.Uint32(data[offset:])))
+		offset += 4
+	}
+	if size <= 0 || size > 4096 {
+		return nil, InvalidChunk
+	}
+	var blocks = make([][2]byte, size)
+	var reader = gonbt.NewReader(data[offset:], false, binutils.LittleEndian)
+	for i := range blocks {
+		var compound = reader.ReadIntoCompound()
+		if compound == nil {
+			return nil, InvalidChunk
+		}
+		var state = palette.NewState(compound.GetString("name", ""), nil)
+		if properties := compound.GetCompound("states"); properties != nil {
+			for name, property := range properties.GetTags() {
+				state.Properties[name] = property.Interface()
+			}
+		} else {
+			state.LegacyData = compound.GetShort("val", 0)
+		}
+		if lookup != nil {
+			if id, data, ok := lookup(state); ok {
+				blocks[i] = [2]byte{id, data}
+			}
+		}
+	}
+
+	var subChunk = &SubChunk{}
+	for i := range subChunk.SkyLight {
+		subChunk.SkyLight[i] = 0xff
+	}
+	for i, index := range indexes {
+		if index < len(blocks) {
+			subChunk.Ids[i] = blocks[index][0]
+			setNibble(subChunk.Data[:], i, blocks[index][1])
+		}
+	}
+	return subChunk, nil
+}
@@ -0,0 +1,139 @@
+package levels
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BobbyShrd/gominetest/palette"
+	"github.com/irmine/binutils"
+	"github.com/irmine/gonbt"
+)
+
+func TestChunkKey(t *testing.T) {
+	if key := chunkKey(Overworld, 1, -1, TagLegacyVersion); !bytes.Equal(key, []byte{1, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0x76}) {
+		t.Errorf("unexpected overworld key %v", key)
+	}
+	if key := chunkKey(Nether, 0, 0, TagSubChunkPrefix, 3); !bytes.Equal(key, []byte{0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0x2f, 3}) {
+		t.Errorf("unexpected nether sub-chunk key %v", key)
+	}
+}
+
+func TestLegacySubChunk(t *testing.T) {
+	var subChunk = &SubChunk{}
+	subChunk.SetBlock(1, 2, 3, 35, 14)
+	subChunk.SetBlock(1, 3, 3, 1, 5)
+	setNibble(subChunk.SkyLight[:], subChunkIndex(1, 2, 3), 15)
+
+	var decoded, err = decodeSubChunk(encodeSubChunk(subChunk), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, data := decoded.GetBlock(1, 2, 3); id != 35 || data != 14 {
+		t.Errorf("expected red wool, got %v:%v", id, data)
+	}
+	if id, data := decoded.GetBlock(1, 3, 3); id != 1 || data != 5 {
+		t.Errorf("expected andesite, got %v:%v", id, data)
+	}
+	if light := getNibble(decoded.SkyLight[:], subChunkIndex(1, 2, 3)); light != 15 {
+		t.Errorf("expected the sky light to be kept, got %v", light)
+	}
+	if _, err := decodeSubChunk([]byte{10}, nil); err != UnsupportedSubChunkVersion {
+		t.Errorf("expected UnsupportedSubChunkVersion, got %v", err)
+	}
+}
+
+func TestPalettedSubChunk(t *testing.T) {
+	// A version 8 sub-chunk with one storage of one bit per block, holding stone at the first index and air elsewhere.
+	var data = []byte{8, 1, 1 << 1}
+	var words = make([]byte, 4096/32*4)
+	words[0] = 1
+	data = append(data, words...)
+	data = append(data, 2, 0, 0, 0)
+	var writer = gonbt.NewWriter(false, binutils.LittleEndian)
+	writer.WriteUncompressedCompound(gonbt.NewCompound("", map[string]gonbt.INamedTag{
+		"name":   gonbt.NewString("name", "minecraft:air"),
+		"states": gonbt.NewCompound("states", map[string]gonbt.INamedTag{}),
+	}))
+	writer.WriteUncompressedCompound(gonbt.NewCompound("", map[string]gonbt.INamedTag{
+		"name": gonbt.NewString("name", "minecraft:stone"),
+		"val":  gonbt.NewShort("val", 2),
+	}))
+	data = append(data, writer.GetData()...)
+
+	var subChunk, err = decodeSubChunk(data, func(state palette.State) (byte, byte, bool) {
+		if state.Name == "minecraft:stone" {
+			return 1, byte(state.LegacyData), true
+		}
+		return 0, 0, state.Name == "minecraft:air"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, data := subChunk.GetBlock(0, 0, 0); id != 1 || data != 2 {
+		t.Errorf("expected polished granite, got %v:%v", id, data)
+	}
+	if id, _ := subChunk.GetBlock(0, 1, 0); id != 0 {
+		t.Errorf("expected air, got %v", id)
+	}
+}
+
+func TestImportLevelDB(t *testing.T) {
+	var source, _ = ioutil.TempDir("", "leveldb")
+	var destination, _ = ioutil.TempDir("", "gomine")
+	defer os.RemoveAll(source)
+	defer os.RemoveAll(destination)
+
+	NewData("bedrock", 7, 0, 64, 0).WriteBedrock(filepath.Join(source, "level.dat"))
+	var provider = NewLevelDB(source)
+	var chunk = NewChunk(-1, 2)
+	chunk.SubChunks[4] = &SubChunk{}
+	chunk.SubChunks[4].SetBlock(0, 0, 0, 2, 0)
+	chunk.HeightMap[0] = 65
+	if err := provider.SaveChunk(Overworld, chunk); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, err := provider.LoadChunk(Overworld, -1, 2); err != nil || loaded.SubChunks[4] == nil || loaded.HeightMap[0] != 65 {
+		t.Fatalf("expected the saved chunk to be loaded, got %v", err)
+	}
+	if _, err := provider.LoadChunk(Nether, -1, 2); err != NoChunk {
+		t.Errorf("expected NoChunk in the nether, got %v", err)
+	}
+	provider.Close()
+
+	if Open(source).GetFormat() != FormatLevelDB {
+		t.Fatal("expected the world to be detected as LevelDB world")
+	}
+	if _, err := Import(Open(source), destination); err != nil {
+		t.Fatal(err)
+	}
+	var region, err = ioutil.ReadFile(filepath.Join(destination, Overworld, "region", "r.-1.0.mca"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var location = binary.BigEndian.Uint32(region[4*(31+2*32):])
+	if location>>8 != 2 || location&0xff == 0 {
+		t.Fatalf("expected the chunk in the first sector after the header, got %x", location)
+	}
+	var length = binary.BigEndian.Uint32(region[2*RegionSectorSize:])
+	reader, err := zlib.NewReader(bytes.NewReader(region[2*RegionSectorSize+5 : 2*RegionSectorSize+4+length]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var uncompressed, _ = ioutil.ReadAll(reader)
+	var level = gonbt.NewReader(uncompressed, false, binutils.BigEndian).ReadIntoCompound().GetCompound("Level")
+	if level.GetInt("xPos", 0) != -1 || level.GetInt("zPos", 0) != 2 {
+		t.Errorf("unexpected chunk position %v, %v", level.GetInt("xPos", 0), level.GetInt("zPos", 0))
+	}
+	var sections = level.GetList("Sections", gonbt.TAG_Compound).GetTags()
+	if len(sections) != 1 || sections[0].(*gonbt.Compound).GetByte("Y", 0) != 4 {
+		t.Fatalf("expected one section at Y 4, got %v", len(sections))
+	}
+	if data, _ := NewGoMine(destination).LoadData(); data == nil || data.Name != "bedrock" || data.Seed != 7 {
+		t.Errorf("expected the level data to be imported, got %+v", data)
+	}
+}
@@ -0,0 +1,156 @@
+package levels
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// World formats supported by providers.
+const (
+	// FormatGoMine is the format of worlds created by the server,
+	// with a Bedrock level.dat and chunks in Anvil region files per dimension.
+	FormatGoMine = "gomine"
+	// FormatLevelDB is the format of worlds created by Bedrock Edition,
+	// with a Bedrock level.dat and chunks in a LevelDB database.
+	FormatLevelDB = "leveldb"
+	// FormatAnvil is the format of worlds created by Java Edition,
+	// with a Java level.dat and chunks in Anvil region files.
+	FormatAnvil = "anvil"
+)
+
+// Dimension directory names, as used by GoMine worlds.
+const (
+	Overworld = "overworld"
+	Nether    = "nether"
+	End       = "end"
+)
+
+var (
+	ReadOnlyFormat          = errors.New("world format can only be imported, not written")
+	UnsupportedChunkStorage = errors.New("LevelDB worlds do not store chunks in region files, import the world to the GoMine format")
+)
+
+// Provider provides access to the level data and chunk storage of a world in a certain format.
+type Provider interface {
+	// GetFormat returns the format of the world.
+	GetFormat() string
+	// GetDirectory returns the directory of the world.
+	GetDirectory() string
+	// LoadData loads the level data of the world.
+	// NoLevelData is returned if the world does not have level data yet.
+	LoadData() (*Data, error)
+	// SaveData saves the level data of the world.
+	SaveData(data *Data) error
+	// GetRegionDirectory returns the directory of the Anvil region files of the dimension with the given name.
+	GetRegionDirectory(dimension string) (string, error)
+}
+
+// DetectFormat detects the format of the world in the given directory.
+// Directories that do not hold a Bedrock or Java world are assumed to hold a GoMine world,
+// which may not exist yet.
+func DetectFormat(directory string) string {
+	if isDirectory(filepath.Join(directory, "db")) {
+		return FormatLevelDB
+	}
+	if isDirectory(filepath.Join(directory, "region")) {
+		return FormatAnvil
+	}
+	return FormatGoMine
+}
+
+// Open returns a provider for the world in the given directory, detecting its format.
+func Open(directory string) Provider {
+	switch DetectFormat(directory) {
+	case FormatLevelDB:
+		return NewLevelDB(directory)
+	case FormatAnvil:
+		return NewAnvil(directory)
+	}
+	return NewGoMine(directory)
+}
+
+// isDirectory checks if the path exists and is a directory.
+func isDirectory(path string) bool {
+	var info, err = os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// GoMine is a provider of worlds in the GoMine format.
+type GoMine struct {
+	directory string
+}
+
+// NewGoMine returns a new provider of the GoMine world in the given directory.
+func NewGoMine(directory string) *GoMine {
+	return &GoMine{directory}
+}
+
+// GetFormat returns the format of the world.
+func (provider *GoMine) GetFormat() string {
+	return FormatGoMine
+}
+
+// GetDirectory returns the directory of the world.
+func (provider *GoMine) GetDirectory() string {
+	return provider.directory
+}
+
+// LoadData loads the level data of the world from its Bedrock level.dat.
+func (provider *GoMine) LoadData() (*Data, error) {
+	return ReadBedrockData(filepath.Join(provider.directory, "level.dat"))
+}
+
+// SaveData saves the level data of the world to its Bedrock level.dat.
+func (provider *GoMine) SaveData(data *Data) error {
+	return data.WriteBedrock(filepath.Join(provider.directory, "level.dat"))
+}
+
+// GetRegionDirectory returns the directory of the region files of the dimension with the given name.
+func (provider *GoMine) GetRegionDirectory(dimension string) (string, error) {
+	return filepath.Join(provider.directory, dimension, "region") + string(filepath.Separator), nil
+}
+
+// Anvil is a provider of worlds created by Java Edition.
+// Anvil worlds are read only, and should be imported to the GoMine format with Import.
+type Anvil struct {
+	directory string
+}
+
+// NewAnvil returns a new provider of the Java world in the given directory.
+func NewAnvil(directory string) *Anvil {
+	return &Anvil{directory}
+}
+
+// GetFormat returns the format of the world.
+func (provider *Anvil) GetFormat() string {
+	return FormatAnvil
+}
+
+// GetDirectory returns the directory of the world.
+func (provider *Anvil) GetDirectory() string {
+	return provider.directory
+}
+
+// LoadData loads the level data of the world from its Java level.dat.
+func (provider *Anvil) LoadData() (*Data, error) {
+	return ReadJavaData(filepath.Join(provider.directory, "level.dat"))
+}
+
+// SaveData always returns ReadOnlyFormat.
+func (provider *Anvil) SaveData(data *Data) error {
+	return ReadOnlyFormat
+}
+
+// GetRegionDirectory returns the directory of the region files of the dimension with the given name.
+// Java worlds store the nether and end in the DIM-1 and DIM1 directories.
+func (provider *Anvil) GetRegionDirectory(dimension string) (string, error) {
+	var directory = provider.directory
+	switch dimension {
+	case Nether:
+		directory = filepath.Join(directory, "DIM-1")
+	case End:
+		directory = filepath.Join(directory, "DIM1")
+	}
+	return filepath.Join(directory, "region") + string(filepath.Separator), nil
+}
@@ -0,0 +1,125 @@
+package levels
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/irmine/binutils"
+	"github.com/irmine/gonbt"
+)
+
+func TestBedrockData(t *testing.T) {
+	var directory, _ = ioutil.TempDir("", "levels")
+	defer os.RemoveAll(directory)
+
+	var provider = NewGoMine(directory)
+	if _, err := provider.LoadData(); err != NoLevelData {
+		t.Errorf("expected NoLevelData, got %v", err)
+	}
+
+	var data = NewData("world", 12345, 1, 70, -3)
+	data.SetGameRule("doDaylightCycle", "false")
+	data.SetGameRule("randomTickSpeed", "3")
+	data.Time, data.Raining, data.Thundering, data.WeatherTime = 6000, true, true, 1200
+	if err := provider.SaveData(data); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := provider.LoadData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Name != "world" || loaded.Seed != 12345 || loaded.SpawnX != 1 || loaded.SpawnY != 70 || loaded.SpawnZ != -3 {
+		t.Errorf("unexpected level data %+v", loaded)
+	}
+	if loaded.Time != 6000 || !loaded.Raining || !loaded.Thundering || loaded.WeatherTime != 1200 {
+		t.Errorf("expected the time and weather to be saved, got %+v", loaded)
+	}
+	if rule, _ := loaded.GetGameRule("dodaylightcycle"); rule != "false" {
+		t.Errorf("expected doDaylightCycle to be false, got %q", rule)
+	}
+	if rule, _ := loaded.GetGameRule("randomtickspeed"); rule != "3" {
+		t.Errorf("expected randomTickSpeed to be 3, got %q", rule)
+	}
+}
+
+func TestImportAnvil(t *testing.T) {
+	var source, _ = ioutil.TempDir("", "anvil")
+	var destination, _ = ioutil.TempDir("", "gomine")
+	defer os.RemoveAll(source)
+	defer os.RemoveAll(destination)
+
+	var writer = gonbt.NewWriter(false, binutils.BigEndian)
+	writer.WriteUncompressedCompound(gonbt.NewCompound("", map[string]gonbt.INamedTag{
+		"Data": gonbt.NewCompound("Data", map[string]gonbt.INamedTag{
+			"LevelName":  gonbt.NewString("LevelName", "java"),
+			"RandomSeed": gonbt.NewLong("RandomSeed", 42),
+			"SpawnY":     gonbt.NewInt("SpawnY", 64),
+			"GameRules": gonbt.NewCompound("GameRules", map[string]gonbt.INamedTag{
+				"keepInventory": gonbt.NewString("keepInventory", "true"),
+			}),
+		}),
+	}))
+	var buffer = bytes.NewBuffer(nil)
+	var compressor = gzip.NewWriter(buffer)
+	compressor.Write(writer.GetData())
+	compressor.Close()
+	ioutil.WriteFile(filepath.Join(source, "level.dat"), buffer.Bytes(), 0644)
+	os.MkdirAll(filepath.Join(source, "DIM-1", "region"), 0700)
+	os.MkdirAll(filepath.Join(source, "region"), 0700)
+	ioutil.WriteFile(filepath.Join(source, "region", "r.0.0.mca"), []byte("overworld"), 0644)
+	ioutil.WriteFile(filepath.Join(source, "DIM-1", "region", "r.0.0.mca"), []byte("nether"), 0644)
+
+	var provider = Open(source)
+	if provider.GetFormat() != FormatAnvil {
+		t.Fatalf("expected anvil format, got %v", provider.GetFormat())
+	}
+	target, err := Import(provider, destination)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := ioutil.ReadFile(filepath.Join(destination, Nether, "region", "r.0.0.mca")); string(content) != "nether" {
+		t.Errorf("expected nether region file to be imported, got %q", content)
+	}
+	data, err := target.LoadData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Name != "java" || data.Seed != 42 || data.SpawnY != 64 {
+		t.Errorf("unexpected level data %+v", data)
+	}
+	if rule, _ := data.GetGameRule("keepInventory"); rule != "true" {
+		t.Errorf("expected keepInventory to be true, got %q", rule)
+	}
+	if Open(destination).GetFormat() != FormatGoMine {
+		t.Error("expected imported world to be in GoMine format")
+	}
+}
+
+func TestImportUnsupported(t *testing.T) {
+	var source, _ = ioutil.TempDir("", "import")
+	var destination, _ = ioutil.TempDir("", "gomine")
+	defer os.RemoveAll(source)
+	defer os.RemoveAll(destination)
+
+	var writer = gonbt.NewWriter(false, binutils.BigEndian)
+	writer.WriteUncompressedCompound(gonbt.NewCompound("", map[string]gonbt.INamedTag{
+		"Data": gonbt.NewCompound("Data", map[string]gonbt.INamedTag{
+			"LevelName":   gonbt.NewString("LevelName", "java"),
+			"DataVersion": gonbt.NewInt("DataVersion", 1519),
+		}),
+	}))
+	var buffer = bytes.NewBuffer(nil)
+	var compressor = gzip.NewWriter(buffer)
+	compressor.Write(writer.GetData())
+	compressor.Close()
+	ioutil.WriteFile(filepath.Join(source, "level.dat"), buffer.Bytes(), 0644)
+	os.MkdirAll(filepath.Join(source, "region"), 0700)
+	if _, err := Import(Open(source), destination); err != UnsupportedJavaVersion {
+		t.Errorf("expected UnsupportedJavaVersion for a Java 1.13 world, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package levels
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/irmine/binutils"
+	"github.com/irmine/gonbt"
+)
+
+const (
+	// RegionSectorSize is the size of the sectors of region files, which hold the header and the chunks.
+	RegionSectorSize = 4096
+	// RegionCompressionZlib is the compression type of zlib compressed chunks in region files.
+	RegionCompressionZlib byte = 2
+)
+
+var ChunkTooLarge = errors.New("chunk is too large to be stored in a region file")
+
+// getRegionPath returns the path of the region file holding the chunk at the given chunk coordinates.
+func getRegionPath(directory string, x, z int32) string {
+	return filepath.Join(directory, fmt.Sprintf("r.%v.%v.mca", x>>5, z>>5))
+}
+
+// writeRegionFile writes the chunks to the region file at the given path, replacing the file if it exists.
+// All chunks must be in the region of the file.
+func writeRegionFile(path string, chunks []*Chunk) error {
+	var header = make([]byte, RegionSectorSize*2)
+	var body = bytes.NewBuffer(nil)
+	var sector = 2
+	for _, chunk := range chunks {
+		var data, err = encodeAnvilChunk(chunk)
+		if err != nil {
+			return err
+		}
+		var sectors = (len(data) + 5 + RegionSectorSize - 1) / RegionSectorSize
+		if sectors > 255 {
+			return ChunkTooLarge
+		}
+		var index = 4 * int((chunk.X&31)+(chunk.Z&31)*32)
+		binary.BigEndian.PutUint32(header[index:], uint32(sector<<8|sectors))
+		binary.BigEndian.PutUint32(header[RegionSectorSize+index:], uint32(time.Now().Unix()))
+
+		var length = make([]byte, 4)
+		binary.BigEndian.PutUint32(length, uint32(len(data)+1))
+		body.Write(length)
+		body.WriteByte(RegionCompressionZlib)
+		body.Write(data)
+		body.Write(make([]byte, sectors*RegionSectorSize-len(data)-5))
+		sector += sectors
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(header, body.Bytes()...), 0644)
+}
+
+// encodeAnvilChunk returns the zlib compressed NBT of the chunk, as stored in region files by Java Edition before 1.13.
+// Java sections index their blocks by y<<8 | z<<4 | x, so the blocks and light of sub-chunks are reordered.
+func encodeAnvilChunk(chunk *Chunk) ([]byte, error) {
+	var sections []gonbt.INamedTag
+	for y, subChunk := range chunk.SubChunks {
+		if subChunk == nil {
+			continue
+		}
+		var ids, data, skyLight, blockLight = make([]byte, 4096), make([]byte, 2048), make([]byte, 2048), make([]byte, 2048)
+		for x := 0; x < 16; x++ {
+			for z := 0; z < 16; z++ {
+				for sectionY := 0; sectionY < 16; sectionY++ {
+					var index, javaIndex = subChunkIndex(x, sectionY, z), sectionY<<8 | z<<4 | x
+					ids[javaIndex] = subChunk.Ids[index]
+					setNibble(data, javaIndex, getNibble(subChunk.Data[:], index))
+					setNibble(skyLight, javaIndex, getNibble(subChunk.SkyLight[:], index))
+					setNibble(blockLight, javaIndex, getNibble(subChunk.BlockLight[:], index))
+				}
+			}
+		}
+		sections = append(sections, gonbt.NewCompound("", map[string]gonbt.INamedTag{
+			"Y":          gonbt.NewByte("Y", byte(y)),
+			"Blocks":     gonbt.NewByteArray("Blocks", ids),
+			"Data":       gonbt.NewByteArray("Data", data),
+			"SkyLight":   gonbt.NewByteArray("SkyLight", skyLight),
+			"BlockLight": gonbt.NewByteArray("BlockLight", blockLight),
+		}))
+	}
+	var heightMap = make([]int32, len(chunk.HeightMap))
+	for i, height := range chunk.HeightMap {
+		heightMap[i] = int32(height)
+	}
+
+	var writer = gonbt.NewWriter(false, binutils.BigEndian)
+	writer.WriteUncompressedCompound(gonbt.NewCompound("", map[string]gonbt.INamedTag{
+		"Level": gonbt.NewCompound("Level", map[string]gonbt.INamedTag{
+			"xPos":             gonbt.NewInt("xPos", chunk.X),
+			"zPos":             gonbt.NewInt("zPos", chunk.Z),
+			"TerrainPopulated": gonbt.NewByte("TerrainPopulated", 1),
+			"LightPopulated":   gonbt.NewByte("LightPopulated", 1),
+			"Biomes":           gonbt.NewByteArray("Biomes", append([]byte{}, chunk.Biomes[:]...)),
+			"HeightMap":        gonbt.NewIntArray("HeightMap", heightMap),
+			"Sections":         gonbt.NewList("Sections", gonbt.TAG_Compound, sections),
+			"Entities":         gonbt.NewList("Entities", gonbt.TAG_Compound, []gonbt.INamedTag{}),
+			"TileEntities":     gonbt.NewList("TileEntities", gonbt.TAG_Compound, []gonbt.INamedTag{}),
+		}),
+	}))
+
+	var buffer = bytes.NewBuffer(nil)
+	var compressor = zlib.NewWriter(buffer)
+	if _, err := compressor.Write(writer.GetData()); err != nil {
+		return nil, err
+	}
+	if err := compressor.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,95 @@
+package levels
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Game modes of players in a world.
+const (
+	Survival int32 = iota
+	Creative
+	Adventure
+)
+
+// GameModeNames holds the names of the game modes, as used by the gamemode command.
+var GameModeNames = map[int32]string{
+	Survival:  "survival",
+	Creative:  "creative",
+	Adventure: "adventure",
+}
+
+// Difficulties of a world.
+const (
+	Peaceful int32 = iota
+	Easy
+	Normal
+	Hard
+)
+
+// DifficultyNames holds the names of the difficulties, as used by the difficulty command.
+var DifficultyNames = map[int32]string{
+	Peaceful: "peaceful",
+	Easy:     "easy",
+	Normal:   "normal",
+	Hard:     "hard",
+}
+
+// ScaleDamage returns the damage mobs deal to players in worlds with the difficulty.
+// Mobs deal no damage in peaceful worlds, about half the damage in easy worlds and one and a half times the damage in hard worlds.
+func ScaleDamage(difficulty int32, damage float32) float32 {
+	switch difficulty {
+	case Peaceful:
+		return 0
+	case Easy:
+		if scaled := damage/2 + 1; scaled < damage {
+			return scaled
+		}
+	case Hard:
+		return damage * 1.5
+	}
+	return damage
+}
+
+// Settings are the settings of a world, applied to players in the world.
+type Settings struct {
+	Gamemode   int32 `yaml:"Gamemode"`
+	Difficulty int32 `yaml:"Difficulty"`
+	PvP        bool  `yaml:"PvP"`
+}
+
+// DefaultSettings returns the settings of worlds that have no settings file.
+func DefaultSettings() *Settings {
+	return &Settings{Gamemode: Survival, Difficulty: Normal, PvP: true}
+}
+
+// LoadSettings loads world settings from the file at the given path.
+// The default settings are returned if the file does not exist.
+func LoadSettings(path string) (*Settings, error) {
+	var settings = DefaultSettings()
+	var file, err = ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return settings, nil
+		}
+		return settings, err
+	}
+	err = yaml.Unmarshal(file, settings)
+	return settings, err
+}
+
+// Save writes the settings to the file at the given path.
+// The directory of the file gets created if it does not yet exist.
+func (settings *Settings) Save(path string) error {
+	var encoded, err = yaml.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, encoded, 0644)
+}
@@ -0,0 +1,22 @@
+package levels
+
+import "testing"
+
+func TestScaleDamage(t *testing.T) {
+	var tests = []struct {
+		difficulty int32
+		damage     float32
+		expected   float32
+	}{
+		{Peaceful, 4, 0},
+		{Easy, 4, 3},
+		{Easy, 1, 1},
+		{Normal, 4, 4},
+		{Hard, 4, 6},
+	}
+	for _, test := range tests {
+		if damage := ScaleDamage(test.difficulty, test.damage); damage != test.expected {
+			t.Errorf("expected damage %v with difficulty %v, got %v", test.expected, DifficultyNames[test.difficulty], damage)
+		}
+	}
+}
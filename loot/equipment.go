@@ -0,0 +1,160 @@
+package loot
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand"
+
+	"github.com/BobbyShrd/gominetest/items"
+)
+
+// Equipment slots of mobs.
+const (
+	SlotMainHand = "mainhand"
+	SlotHead     = "head"
+	SlotChest    = "chest"
+	SlotLegs     = "legs"
+	SlotFeet     = "feet"
+
+	// DefaultDropChance is the chance equipment of mobs gets dropped when they die,
+	// if the equipment table does not specify one.
+	DefaultDropChance = 0.085
+)
+
+// ArmorSlots are the equipment slots holding armor, from head to feet.
+var ArmorSlots = [4]string{SlotHead, SlotChest, SlotLegs, SlotFeet}
+
+// EquipmentTable is a table deciding the equipment mobs spawn with.
+// Equipment tables use the format of loot tables, with a pool per slot.
+type EquipmentTable struct {
+	Slots map[string]EquipmentPool `json:"slots"`
+}
+
+// EquipmentPool decides the item in one equipment slot.
+// The slot gets equipped with the given chance, after which one of the entries is picked by weight.
+// DropChance is the chance the item gets dropped when the mob dies,
+// or nil to use the default drop chance. A drop chance of 0 means the item never drops.
+type EquipmentPool struct {
+	Chance     float64  `json:"chance"`
+	DropChance *float64 `json:"drop_chance"`
+	Entries    []Entry  `json:"entries"`
+}
+
+// LoadEquipmentTable reads an equipment table in JSON format from the given reader.
+func LoadEquipmentTable(reader io.Reader) (*EquipmentTable, error) {
+	var table = &EquipmentTable{}
+	if err := json.NewDecoder(reader).Decode(table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// Roll returns random equipment from the equipment table.
+// Items that are not registered in the item manager are left out.
+func (table *EquipmentTable) Roll(random *rand.Rand, manager *items.Manager) *Equipment {
+	var equipment = NewEquipment()
+	for slot, pool := range table.Slots {
+		if random.Float64() >= pool.Chance {
+			continue
+		}
+		var stacks = (&Table{[]Pool{{NewRange(1, 1), pool.Entries}}}).Roll(random, manager)
+		if len(stacks) == 0 {
+			continue
+		}
+		var dropChance = DefaultDropChance
+		if pool.DropChance != nil {
+			dropChance = *pool.DropChance
+		}
+		equipment.Set(slot, stacks[0], dropChance)
+	}
+	return equipment
+}
+
+// Equipment is the equipment of a mob: the items in its slots and the chance they get dropped on death.
+type Equipment struct {
+	Items       map[string]*items.Stack
+	DropChances map[string]float64
+}
+
+// NewEquipment returns new empty equipment.
+func NewEquipment() *Equipment {
+	return &Equipment{Items: make(map[string]*items.Stack), DropChances: make(map[string]float64)}
+}
+
+// Set sets the item in the slot, and the chance it gets dropped on death. Nil clears the slot.
+func (equipment *Equipment) Set(slot string, item *items.Stack, dropChance float64) {
+	if item == nil {
+		delete(equipment.Items, slot)
+		delete(equipment.DropChances, slot)
+		return
+	}
+	equipment.Items[slot] = item
+	equipment.DropChances[slot] = dropChance
+}
+
+// Get returns the item in the slot, or nil if the slot is empty.
+func (equipment *Equipment) Get(slot string) *items.Stack {
+	return equipment.Items[slot]
+}
+
+// GetArmor returns the pieces of armor in the armor slots, from head to feet.
+func (equipment *Equipment) GetArmor() []*items.Stack {
+	var pieces = make([]*items.Stack, len(ArmorSlots))
+	for i, slot := range ArmorSlots {
+		pieces[i] = equipment.Items[slot]
+	}
+	return pieces
+}
+
+// IsEmpty checks if none of the slots hold an item.
+func (equipment *Equipment) IsEmpty() bool {
+	return len(equipment.Items) == 0
+}
+
+// Drops returns the items dropped when the mob dies, every item having its own drop chance.
+func (equipment *Equipment) Drops(random *rand.Rand) []*items.Stack {
+	var drops []*items.Stack
+	for _, slot := range append([]string{SlotMainHand}, ArmorSlots[:]...) {
+		if item, ok := equipment.Items[slot]; ok && random.Float64() < equipment.DropChances[slot] {
+			drops = append(drops, item)
+		}
+	}
+	return drops
+}
+
+// equipmentSlot is an equipment slot encoded in JSON.
+type equipmentSlot struct {
+	Id         string  `json:"id"`
+	Count      int     `json:"count"`
+	Durability int16   `json:"durability"`
+	DropChance float64 `json:"drop_chance"`
+}
+
+// Encode encodes the equipment to JSON.
+func (equipment *Equipment) Encode() []byte {
+	var slots = make(map[string]equipmentSlot, len(equipment.Items))
+	for slot, item := range equipment.Items {
+		slots[slot] = equipmentSlot{item.GetId(), item.Count, item.Durability, equipment.DropChances[slot]}
+	}
+	var data, _ = json.Marshal(slots)
+	return data
+}
+
+// DecodeEquipment decodes equipment encoded with Encode,
+// getting its items from the item manager. Items that are no longer registered are left out.
+func DecodeEquipment(data []byte, manager *items.Manager) (*Equipment, error) {
+	var slots map[string]equipmentSlot
+	if err := json.Unmarshal(data, &slots); err != nil {
+		return nil, err
+	}
+	var equipment = NewEquipment()
+	for slot, encoded := range slots {
+		var item, ok = manager.Get(encoded.Id, encoded.Count)
+		if !ok {
+			continue
+		}
+		item.Durability = encoded.Durability
+		equipment.Set(slot, item, encoded.DropChance)
+	}
+	return equipment, nil
+}
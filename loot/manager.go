@@ -0,0 +1,166 @@
+package loot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Manager holds all loot tables and equipment tables, indexed by name.
+// Loot tables of blocks are named after the block, for example: "blocks/wheat",
+// and loot tables and equipment tables of mobs are named after the mob, for example: "entities/zombie".
+type Manager struct {
+	mutex     sync.RWMutex
+	tables    map[string]*Table
+	equipment map[string]*EquipmentTable
+}
+
+// NewManager returns a new loot table manager with the default loot tables registered.
+func NewManager() *Manager {
+	var manager = &Manager{tables: make(map[string]*Table), equipment: make(map[string]*EquipmentTable)}
+	manager.registerDefaults()
+	return manager
+}
+
+// RegisterTable registers a loot table with the given name, overwriting any existing table with it.
+func (manager *Manager) RegisterTable(name string, table *Table) {
+	manager.mutex.Lock()
+	manager.tables[name] = table
+	manager.mutex.Unlock()
+}
+
+// GetTable returns the loot table with the given name, and a bool indicating if it was found.
+func (manager *Manager) GetTable(name string) (*Table, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var table, ok = manager.tables[name]
+	return table, ok
+}
+
+// RegisterEquipmentTable registers an equipment table with the given name, overwriting any existing table with it.
+func (manager *Manager) RegisterEquipmentTable(name string, table *EquipmentTable) {
+	manager.mutex.Lock()
+	manager.equipment[name] = table
+	manager.mutex.Unlock()
+}
+
+// GetEquipmentTable returns the equipment table with the given name, and a bool indicating if it was found.
+func (manager *Manager) GetEquipmentTable(name string) (*EquipmentTable, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var table, ok = manager.equipment[name]
+	return table, ok
+}
+
+// LoadDirectory loads all loot tables in the given directory and its subdirectories.
+// Tables are named after their path relative to the directory, without the extension.
+// Tables in the "equipment" subdirectory are loaded as equipment tables, named without the "equipment/" prefix.
+// Nothing gets loaded if the directory does not exist.
+func (manager *Manager) LoadDirectory(path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(file) != ".json" {
+			return nil
+		}
+		var reader, openErr = os.Open(file)
+		if openErr != nil {
+			return openErr
+		}
+		defer reader.Close()
+
+		var name, _ = filepath.Rel(path, file)
+		name = strings.TrimSuffix(filepath.ToSlash(name), ".json")
+		if strings.HasPrefix(name, "equipment/") {
+			var table, loadErr = LoadEquipmentTable(reader)
+			if loadErr != nil {
+				return loadErr
+			}
+			manager.RegisterEquipmentTable(strings.TrimPrefix(name, "equipment/"), table)
+			return nil
+		}
+		var table, loadErr = LoadTable(reader)
+		if loadErr != nil {
+			return loadErr
+		}
+		manager.RegisterTable(name, table)
+		return nil
+	})
+}
+
+// registerDefaults registers the loot tables of fully grown crops and mobs, and the equipment tables of zombies.
+func (manager *Manager) registerDefaults() {
+	manager.RegisterTable("blocks/wheat", &Table{[]Pool{
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:wheat"}}},
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:wheat_seeds", Functions: []Function{{"set_count", NewRange(0, 3)}}}}},
+	}})
+	manager.RegisterTable("blocks/carrots", &Table{[]Pool{
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:carrot", Functions: []Function{{"set_count", NewRange(1, 4)}}}}},
+	}})
+	manager.RegisterTable("blocks/potatoes", &Table{[]Pool{
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:potato", Functions: []Function{{"set_count", NewRange(1, 4)}}}}},
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:poisonous_potato", Weight: 1}, {Type: "empty", Weight: 49}}},
+	}})
+	manager.RegisterTable("blocks/beetroot", &Table{[]Pool{
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:beetroot"}}},
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:beetroot_seeds", Functions: []Function{{"set_count", NewRange(0, 3)}}}}},
+	}})
+	manager.registerMobDefaults()
+}
+
+// registerMobDefaults registers the loot tables of mobs, and the equipment tables of zombies.
+func (manager *Manager) registerMobDefaults() {
+	var flesh = &Table{[]Pool{
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:rotten_flesh", Functions: []Function{{"set_count", NewRange(0, 2)}}}}},
+	}}
+	manager.RegisterTable("entities/zombie", flesh)
+	manager.RegisterTable("entities/husk", flesh)
+	manager.RegisterTable("entities/zombie_villager", flesh)
+	manager.RegisterTable("entities/spider", &Table{[]Pool{
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:string", Functions: []Function{{"set_count", NewRange(0, 2)}}}}},
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:spider_eye", Weight: 1}, {Type: "empty", Weight: 2}}},
+	}})
+	manager.RegisterTable("entities/chicken", &Table{[]Pool{
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:feather", Functions: []Function{{"set_count", NewRange(0, 2)}}}}},
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:chicken"}}},
+	}})
+	manager.RegisterTable("entities/cow", &Table{[]Pool{
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:leather", Functions: []Function{{"set_count", NewRange(0, 2)}}}}},
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:beef", Functions: []Function{{"set_count", NewRange(1, 3)}}}}},
+	}})
+	manager.RegisterTable("entities/pig", &Table{[]Pool{
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:porkchop", Functions: []Function{{"set_count", NewRange(1, 3)}}}}},
+	}})
+	manager.RegisterTable("entities/sheep", &Table{[]Pool{
+		{NewRange(1, 1), []Entry{{Type: "item", Name: "minecraft:mutton", Functions: []Function{{"set_count", NewRange(1, 2)}}}}},
+	}})
+
+	var zombie = &EquipmentTable{map[string]EquipmentPool{
+		SlotMainHand: {Chance: 0.05, Entries: []Entry{
+			{Type: "item", Name: "minecraft:iron_sword", Weight: 1},
+			{Type: "item", Name: "minecraft:iron_shovel", Weight: 2},
+		}},
+		SlotHead:  {Chance: 0.05, Entries: armorEntries("helmet")},
+		SlotChest: {Chance: 0.05, Entries: armorEntries("chestplate")},
+		SlotLegs:  {Chance: 0.05, Entries: armorEntries("leggings")},
+		SlotFeet:  {Chance: 0.05, Entries: armorEntries("boots")},
+	}}
+	manager.RegisterEquipmentTable("entities/zombie", zombie)
+	manager.RegisterEquipmentTable("entities/husk", zombie)
+	manager.RegisterEquipmentTable("entities/zombie_villager", zombie)
+}
+
+// armorEntries returns the entries of a piece of armor of every material, weighted like the armor of vanilla mobs.
+func armorEntries(piece string) []Entry {
+	return []Entry{
+		{Type: "item", Name: "minecraft:leather_" + piece, Weight: 37},
+		{Type: "item", Name: "minecraft:golden_" + piece, Weight: 49},
+		{Type: "item", Name: "minecraft:chainmail_" + piece, Weight: 13},
+		{Type: "item", Name: "minecraft:iron_" + piece, Weight: 1},
+	}
+}
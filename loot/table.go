@@ -0,0 +1,145 @@
+package loot
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand"
+
+	"github.com/BobbyShrd/gominetest/items"
+)
+
+// Table is a loot table, which decides the items dropped by for example blocks.
+// Loot tables use a simplified form of the vanilla loot table format.
+type Table struct {
+	Pools []Pool `json:"pools"`
+}
+
+// Pool is a pool of entries in a loot table.
+// Every roll one of the entries is picked, with a chance relative to its weight.
+type Pool struct {
+	Rolls   Range   `json:"rolls"`
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is an entry in a loot table pool.
+// Entries of the type "empty" do not drop anything.
+type Entry struct {
+	Type      string     `json:"type"`
+	Name      string     `json:"name"`
+	Weight    int        `json:"weight"`
+	Functions []Function `json:"functions"`
+}
+
+// Function is a function modifying the items dropped by an entry.
+// Only the "set_count" function is supported.
+type Function struct {
+	Function string `json:"function"`
+	Count    Range  `json:"count"`
+}
+
+// Range is an amount, which is either a constant number or a random number between a minimum and maximum.
+type Range struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// NewRange returns a new range between min and max, inclusive.
+func NewRange(min int, max int) Range {
+	return Range{min, max}
+}
+
+// UnmarshalJSON decodes a range from either a number or an object with a min and max.
+func (r *Range) UnmarshalJSON(data []byte) error {
+	var constant int
+	if err := json.Unmarshal(data, &constant); err == nil {
+		r.Min, r.Max = constant, constant
+		return nil
+	}
+	var object struct {
+		Min int `json:"min"`
+		Max int `json:"max"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	r.Min, r.Max = object.Min, object.Max
+	return nil
+}
+
+// Roll returns a random number in the range.
+func (r Range) Roll(random *rand.Rand) int {
+	if r.Max <= r.Min {
+		return r.Min
+	}
+	return r.Min + random.Intn(r.Max-r.Min+1)
+}
+
+// LoadTable reads a loot table in JSON format from the given reader.
+func LoadTable(reader io.Reader) (*Table, error) {
+	var table = &Table{}
+	if err := json.NewDecoder(reader).Decode(table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// Roll rolls all pools of the loot table, and returns the item stacks that should be dropped.
+// Items are created using the given item manager, and entries of unknown items are skipped.
+func (table *Table) Roll(random *rand.Rand, manager *items.Manager) []*items.Stack {
+	var drops []*items.Stack
+	for _, pool := range table.Pools {
+		for rolls := pool.Rolls.Roll(random); rolls > 0; rolls-- {
+			var entry, ok = pool.pick(random)
+			if !ok || entry.Type == "empty" {
+				continue
+			}
+			var count = entry.getCount(random)
+			if count <= 0 {
+				continue
+			}
+			if stack, ok := manager.Get(entry.Name, count); ok {
+				drops = append(drops, stack)
+			}
+		}
+	}
+	return drops
+}
+
+// pick picks a random entry of the pool, with a chance relative to its weight.
+// Entries without a weight have a weight of 1.
+func (pool Pool) pick(random *rand.Rand) (Entry, bool) {
+	var totalWeight = 0
+	for _, entry := range pool.Entries {
+		totalWeight += entry.getWeight()
+	}
+	if totalWeight == 0 {
+		return Entry{}, false
+	}
+	var roll = random.Intn(totalWeight)
+	for _, entry := range pool.Entries {
+		roll -= entry.getWeight()
+		if roll < 0 {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// getWeight returns the weight of the entry.
+func (entry Entry) getWeight() int {
+	if entry.Weight <= 0 {
+		return 1
+	}
+	return entry.Weight
+}
+
+// getCount returns the amount of items the entry drops.
+func (entry Entry) getCount(random *rand.Rand) int {
+	var count = 1
+	for _, function := range entry.Functions {
+		if function.Function == "set_count" || function.Function == "minecraft:set_count" {
+			count = function.Count.Roll(random)
+		}
+	}
+	return count
+}
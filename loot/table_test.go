@@ -0,0 +1,68 @@
+package loot
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/BobbyShrd/gominetest/items"
+)
+
+func TestLoadTable(t *testing.T) {
+	var table, err = LoadTable(strings.NewReader(`{"pools": [{"rolls": 1, "entries": [
+		{"type": "item", "name": "minecraft:wheat_seeds", "functions": [{"function": "set_count", "count": {"min": 2, "max": 4}}]}
+	]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var random = rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		var drops = table.Roll(random, items.DefaultManager)
+		if len(drops) != 1 || drops[0].GetId() != "minecraft:wheat_seeds" {
+			t.Fatalf("expected one stack of wheat seeds, got %v", drops)
+		}
+		if drops[0].Count < 2 || drops[0].Count > 4 {
+			t.Errorf("expected between 2 and 4 seeds, got %v", drops[0].Count)
+		}
+	}
+}
+
+func TestEmptyEntry(t *testing.T) {
+	var table = &Table{[]Pool{{NewRange(3, 3), []Entry{{Type: "empty"}}}}}
+	if drops := table.Roll(rand.New(rand.NewSource(1)), items.DefaultManager); len(drops) != 0 {
+		t.Errorf("expected no drops, got %v", drops)
+	}
+}
+
+func TestEquipmentTable(t *testing.T) {
+	var table, err = LoadEquipmentTable(strings.NewReader(`{"slots": {
+		"mainhand": {"chance": 1, "drop_chance": 1, "entries": [{"type": "item", "name": "minecraft:iron_sword"}]},
+		"chest": {"chance": 1, "drop_chance": 0, "entries": [{"type": "item", "name": "minecraft:iron_chestplate"}]},
+		"head": {"chance": 0, "entries": [{"type": "item", "name": "minecraft:iron_helmet"}]}
+	}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var random = rand.New(rand.NewSource(1))
+	var equipment = table.Roll(random, items.DefaultManager)
+	if item := equipment.Get(SlotMainHand); item == nil || item.GetId() != "minecraft:iron_sword" {
+		t.Fatalf("expected an iron sword in the main hand, got %v", item)
+	}
+	if item := equipment.Get(SlotChest); item == nil || equipment.DropChances[SlotChest] != 0 {
+		t.Fatalf("expected an iron chestplate that never drops, got %v", item)
+	}
+	if equipment.Get(SlotHead) != nil {
+		t.Errorf("expected no helmet with a chance of 0")
+	}
+	if drops := equipment.Drops(random); len(drops) != 1 {
+		t.Errorf("expected the sword to drop, got %v", drops)
+	}
+
+	var decoded, decodeErr = DecodeEquipment(equipment.Encode(), items.DefaultManager)
+	if decodeErr != nil {
+		t.Fatal(decodeErr)
+	}
+	if item := decoded.Get(SlotMainHand); item == nil || item.GetId() != "minecraft:iron_sword" || decoded.DropChances[SlotMainHand] != 1 {
+		t.Errorf("expected the decoded sword with a drop chance of 1, got %v", decoded.Items)
+	}
+}
@@ -0,0 +1,232 @@
+package metadata
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	InvalidNamespace = errors.New("metadata namespace must be a non-empty plugin name without colons")
+	InvalidKey       = errors.New("metadata key may not be empty")
+	UnsupportedType  = errors.New("persistent metadata must be a string, bool, int or float64")
+)
+
+// Value is a single value in a store.
+type Value struct {
+	// Value is the value set by the plugin.
+	Value interface{}
+	// Persistent specifies if the value is saved together with its holder.
+	Persistent bool
+}
+
+// Store holds values attached to an entity or player by plugins.
+// Values are namespaced by the name of the plugin setting them,
+// so that plugins can not overwrite each other's values.
+// Persistent values must be a string, bool, int or float64,
+// and are saved and loaded with the holder through Encode and Decode.
+type Store struct {
+	mutex  sync.RWMutex
+	values map[string]Value
+}
+
+// NewStore returns a new empty store.
+func NewStore() *Store {
+	return &Store{values: make(map[string]Value)}
+}
+
+// Set sets the value under the key in the namespace of the given plugin.
+// The value is only kept as long as its holder exists.
+func (store *Store) Set(plugin string, key string, value interface{}) error {
+	return store.set(plugin, key, Value{value, false})
+}
+
+// SetPersistent sets the value under the key in the namespace of the given plugin.
+// The value is saved with its holder, and returns UnsupportedType if it can not be saved.
+func (store *Store) SetPersistent(plugin string, key string, value interface{}) error {
+	if _, ok := encodeValue(value); !ok {
+		return UnsupportedType
+	}
+	return store.set(plugin, key, Value{value, true})
+}
+
+// set sets a value under the namespaced key.
+func (store *Store) set(plugin string, key string, value Value) error {
+	var namespaced, err = namespace(plugin, key)
+	if err != nil {
+		return err
+	}
+	store.mutex.Lock()
+	store.values[namespaced] = value
+	store.mutex.Unlock()
+	return nil
+}
+
+// Get returns the value under the key in the namespace of the given plugin,
+// and a bool indicating if the value was set.
+func (store *Store) Get(plugin string, key string) (interface{}, bool) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+	var value, ok = store.values[plugin+":"+key]
+	return value.Value, ok
+}
+
+// GetString returns the string under the key in the namespace of the given plugin,
+// and a bool which is false if no string was set under the key.
+func (store *Store) GetString(plugin string, key string) (string, bool) {
+	var value, _ = store.Get(plugin, key)
+	var s, ok = value.(string)
+	return s, ok
+}
+
+// GetBool returns the bool under the key in the namespace of the given plugin,
+// and a bool which is false if no bool was set under the key.
+func (store *Store) GetBool(plugin string, key string) (bool, bool) {
+	var value, _ = store.Get(plugin, key)
+	var b, ok = value.(bool)
+	return b, ok
+}
+
+// GetInt returns the int under the key in the namespace of the given plugin,
+// and a bool which is false if no int was set under the key.
+func (store *Store) GetInt(plugin string, key string) (int, bool) {
+	var value, _ = store.Get(plugin, key)
+	var i, ok = value.(int)
+	return i, ok
+}
+
+// GetFloat returns the float64 under the key in the namespace of the given plugin,
+// and a bool which is false if no float64 was set under the key.
+func (store *Store) GetFloat(plugin string, key string) (float64, bool) {
+	var value, _ = store.Get(plugin, key)
+	var f, ok = value.(float64)
+	return f, ok
+}
+
+// Has checks if a value is set under the key in the namespace of the given plugin.
+func (store *Store) Has(plugin string, key string) bool {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+	var _, ok = store.values[plugin+":"+key]
+	return ok
+}
+
+// IsPersistent checks if the value under the key in the namespace of the given plugin gets saved.
+func (store *Store) IsPersistent(plugin string, key string) bool {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+	return store.values[plugin+":"+key].Persistent
+}
+
+// Remove removes the value under the key in the namespace of the given plugin.
+func (store *Store) Remove(plugin string, key string) {
+	store.mutex.Lock()
+	delete(store.values, plugin+":"+key)
+	store.mutex.Unlock()
+}
+
+// RemoveNamespace removes all values in the namespace of the given plugin.
+// Persistent values are only removed if removePersistent is true.
+func (store *Store) RemoveNamespace(plugin string, removePersistent bool) {
+	var prefix = plugin + ":"
+	store.mutex.Lock()
+	for key, value := range store.values {
+		if strings.HasPrefix(key, prefix) && (removePersistent || !value.Persistent) {
+			delete(store.values, key)
+		}
+	}
+	store.mutex.Unlock()
+}
+
+// GetKeys returns the keys of all values in the namespace of the given plugin.
+func (store *Store) GetKeys(plugin string) []string {
+	var prefix = plugin + ":"
+	var keys []string
+	store.mutex.RLock()
+	for key := range store.values {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, strings.TrimPrefix(key, prefix))
+		}
+	}
+	store.mutex.RUnlock()
+	return keys
+}
+
+// Encode returns all persistent values encoded as strings, indexed by namespaced key.
+func (store *Store) Encode() map[string]string {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+	var encoded = make(map[string]string)
+	for key, value := range store.values {
+		if !value.Persistent {
+			continue
+		}
+		if s, ok := encodeValue(value.Value); ok {
+			encoded[key] = s
+		}
+	}
+	return encoded
+}
+
+// Decode sets all persistent values previously returned by Encode.
+// Values that could not be decoded are skipped.
+func (store *Store) Decode(encoded map[string]string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	for key, s := range encoded {
+		if value, ok := decodeValue(s); ok {
+			store.values[key] = Value{value, true}
+		}
+	}
+}
+
+// namespace returns the key prefixed with the plugin name.
+func namespace(plugin string, key string) (string, error) {
+	if plugin == "" || strings.Contains(plugin, ":") {
+		return "", InvalidNamespace
+	}
+	if key == "" {
+		return "", InvalidKey
+	}
+	return plugin + ":" + key, nil
+}
+
+// encodeValue encodes a value as a string prefixed with its type.
+// A bool is returned which is false if the value can not be encoded.
+func encodeValue(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return "s:" + value, true
+	case bool:
+		return "b:" + strconv.FormatBool(value), true
+	case int:
+		return "i:" + strconv.Itoa(value), true
+	case float64:
+		return "f:" + strconv.FormatFloat(value, 'g', -1, 64), true
+	}
+	return "", false
+}
+
+// decodeValue decodes a string returned by encodeValue.
+// A bool is returned which is false if the string could not be decoded.
+func decodeValue(s string) (interface{}, bool) {
+	if len(s) < 2 || s[1] != ':' {
+		return nil, false
+	}
+	var raw = s[2:]
+	switch s[0] {
+	case 's':
+		return raw, true
+	case 'b':
+		var value, err = strconv.ParseBool(raw)
+		return value, err == nil
+	case 'i':
+		var value, err = strconv.Atoi(raw)
+		return value, err == nil
+	case 'f':
+		var value, err = strconv.ParseFloat(raw, 64)
+		return value, err == nil
+	}
+	return nil, false
+}
@@ -0,0 +1,52 @@
+package metadata
+
+import (
+	"testing"
+)
+
+func TestNamespaces(t *testing.T) {
+	var store = NewStore()
+	store.Set("Teams", "team", "red")
+	store.Set("Economy", "team", 5)
+
+	if team, ok := store.GetString("Teams", "team"); !ok || team != "red" {
+		t.Errorf("expected team red, got %v", team)
+	}
+	if _, ok := store.GetString("Economy", "team"); ok {
+		t.Error("expected the value of the other plugin not to be a string")
+	}
+	if err := store.Set("", "team", "blue"); err != InvalidNamespace {
+		t.Errorf("expected InvalidNamespace, got %v", err)
+	}
+
+	store.RemoveNamespace("Teams", false)
+	if store.Has("Teams", "team") || !store.Has("Economy", "team") {
+		t.Error("expected only the values of the removed namespace to be removed")
+	}
+}
+
+func TestEncode(t *testing.T) {
+	var store = NewStore()
+	store.SetPersistent("Economy", "balance", 2.5)
+	store.SetPersistent("Economy", "visits", 3)
+	store.SetPersistent("Teams", "leader", true)
+	store.Set("Teams", "session", "not saved")
+	if err := store.SetPersistent("Teams", "members", []string{}); err != UnsupportedType {
+		t.Errorf("expected UnsupportedType, got %v", err)
+	}
+
+	var loaded = NewStore()
+	loaded.Decode(store.Encode())
+	if balance, ok := loaded.GetFloat("Economy", "balance"); !ok || balance != 2.5 {
+		t.Errorf("expected balance 2.5, got %v", balance)
+	}
+	if visits, ok := loaded.GetInt("Economy", "visits"); !ok || visits != 3 {
+		t.Errorf("expected 3 visits, got %v", visits)
+	}
+	if leader, ok := loaded.GetBool("Teams", "leader"); !ok || !leader {
+		t.Error("expected leader to be true")
+	}
+	if loaded.Has("Teams", "session") {
+		t.Error("expected values that are not persistent not to be encoded")
+	}
+}
@@ -0,0 +1,161 @@
+package metrics
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Types of metrics, as written in the exposition format.
+const (
+	TypeCounter = "counter"
+	TypeGauge   = "gauge"
+)
+
+// Metric is a single named value exposed by a registry.
+type Metric interface {
+	// GetName returns the name of the metric, for example "gomine_online_players".
+	GetName() string
+	// GetHelp returns the description of the metric.
+	GetHelp() string
+	// GetType returns the type of the metric, which is either TypeCounter or TypeGauge.
+	GetType() string
+	// GetValue returns the current value of the metric.
+	GetValue() float64
+}
+
+// description holds the name and help of a metric.
+type description struct {
+	name string
+	help string
+}
+
+// GetName returns the name of the metric.
+func (description description) GetName() string {
+	return description.name
+}
+
+// GetHelp returns the description of the metric.
+func (description description) GetHelp() string {
+	return description.help
+}
+
+// Counter is a metric that only ever increases, such as the amount of packets received.
+type Counter struct {
+	description
+	value uint64
+}
+
+// NewCounter returns a new counter with the given name and help.
+func NewCounter(name string, help string) *Counter {
+	return &Counter{description: description{name, help}}
+}
+
+// GetType returns TypeCounter.
+func (counter *Counter) GetType() string {
+	return TypeCounter
+}
+
+// Add increases the counter by n.
+func (counter *Counter) Add(n uint64) {
+	atomic.AddUint64(&counter.value, n)
+}
+
+// Inc increases the counter by one.
+func (counter *Counter) Inc() {
+	counter.Add(1)
+}
+
+// Get returns the current count.
+func (counter *Counter) Get() uint64 {
+	return atomic.LoadUint64(&counter.value)
+}
+
+// GetValue returns the current count.
+func (counter *Counter) GetValue() float64 {
+	return float64(counter.Get())
+}
+
+// Gauge is a metric that can go up and down, such as the duration of the last tick.
+type Gauge struct {
+	description
+	bits uint64
+}
+
+// NewGauge returns a new gauge with the given name and help.
+func NewGauge(name string, help string) *Gauge {
+	return &Gauge{description: description{name, help}}
+}
+
+// GetType returns TypeGauge.
+func (gauge *Gauge) GetType() string {
+	return TypeGauge
+}
+
+// Set sets the value of the gauge.
+func (gauge *Gauge) Set(value float64) {
+	atomic.StoreUint64(&gauge.bits, math.Float64bits(value))
+}
+
+// GetValue returns the value of the gauge.
+func (gauge *Gauge) GetValue() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&gauge.bits))
+}
+
+// GaugeFunction is a gauge of which the value is computed every time it is collected,
+// such as the amount of goroutines.
+type GaugeFunction struct {
+	description
+	function func() float64
+}
+
+// NewGaugeFunction returns a new gauge with the given name and help, collecting its value from the function.
+func NewGaugeFunction(name string, help string, function func() float64) *GaugeFunction {
+	return &GaugeFunction{description{name, help}, function}
+}
+
+// GetType returns TypeGauge.
+func (gauge *GaugeFunction) GetType() string {
+	return TypeGauge
+}
+
+// GetValue returns the value returned by the function of the gauge.
+func (gauge *GaugeFunction) GetValue() float64 {
+	return gauge.function()
+}
+
+// Rate counts events, and measures the amount of events per second.
+// The total is exposed as a counter, and the rate as a gauge updated every time Update is called.
+type Rate struct {
+	*Counter
+	PerSecond *Gauge
+
+	mutex      sync.Mutex
+	lastCount  uint64
+	lastUpdate time.Time
+}
+
+// NewRate returns a new rate with the given name and help.
+// The counter of the rate gets the suffix "_total", and the gauge the suffix "_per_second".
+func NewRate(name string, help string) *Rate {
+	return &Rate{
+		Counter:    NewCounter(name+"_total", help+" in total."),
+		PerSecond:  NewGauge(name+"_per_second", help+" per second."),
+		lastUpdate: time.Now(),
+	}
+}
+
+// Update sets the rate per second to the amount of events since the previous update.
+func (rate *Rate) Update() {
+	rate.mutex.Lock()
+	defer rate.mutex.Unlock()
+	var now = time.Now()
+	var count = rate.Get()
+	var elapsed = now.Sub(rate.lastUpdate).Seconds()
+	if elapsed > 0 {
+		rate.PerSecond.Set(float64(count-rate.lastCount) / elapsed)
+	}
+	rate.lastCount = count
+	rate.lastUpdate = now
+}
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	AlreadyRegistered = errors.New("a metric with the same name is already registered")
+	AlreadyListening  = errors.New("metrics endpoint is already listening")
+)
+
+// Registry holds metrics, and writes them in the Prometheus text exposition format.
+type Registry struct {
+	mutex   sync.RWMutex
+	metrics map[string]Metric
+}
+
+// NewRegistry returns a new empty registry.
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]Metric)}
+}
+
+// Register registers a metric. Rates register both their counter and gauge.
+// Returns AlreadyRegistered if a metric with the same name was registered before.
+func (registry *Registry) Register(metric Metric) error {
+	if rate, ok := metric.(*Rate); ok {
+		if err := registry.Register(rate.Counter); err != nil {
+			return err
+		}
+		return registry.Register(rate.PerSecond)
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if _, ok := registry.metrics[metric.GetName()]; ok {
+		return AlreadyRegistered
+	}
+	registry.metrics[metric.GetName()] = metric
+	return nil
+}
+
+// Deregister deregisters the metric with the given name.
+// Returns true if a metric was deregistered.
+func (registry *Registry) Deregister(name string) bool {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if _, ok := registry.metrics[name]; !ok {
+		return false
+	}
+	delete(registry.metrics, name)
+	return true
+}
+
+// GetMetric returns the metric with the given name, and a bool indicating if it exists.
+func (registry *Registry) GetMetric(name string) (Metric, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	var metric, ok = registry.metrics[name]
+	return metric, ok
+}
+
+// WriteTo writes all metrics sorted by name to the writer, in the Prometheus text exposition format.
+func (registry *Registry) WriteTo(writer io.Writer) (int64, error) {
+	registry.mutex.RLock()
+	var names = make([]string, 0, len(registry.metrics))
+	for name := range registry.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var metrics = make([]Metric, len(names))
+	for i, name := range names {
+		metrics[i] = registry.metrics[name]
+	}
+	registry.mutex.RUnlock()
+
+	var buffered = bufio.NewWriter(writer)
+	var written int64
+	for _, metric := range metrics {
+		var n, _ = buffered.WriteString("# HELP " + metric.GetName() + " " + escapeHelp(metric.GetHelp()) + "\n" +
+			"# TYPE " + metric.GetName() + " " + metric.GetType() + "\n" +
+			metric.GetName() + " " + strconv.FormatFloat(metric.GetValue(), 'g', -1, 64) + "\n")
+		written += int64(n)
+	}
+	return written, buffered.Flush()
+}
+
+// ServeHTTP writes all metrics as the response to a scrape.
+func (registry *Registry) ServeHTTP(writer http.ResponseWriter, _ *http.Request) {
+	writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	registry.WriteTo(writer)
+}
+
+// escapeHelp escapes backslashes and newlines in help texts.
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+// Endpoint serves the metrics of a registry over HTTP on the "/metrics" path.
+type Endpoint struct {
+	mutex    sync.Mutex
+	registry *Registry
+	server   *http.Server
+	listener net.Listener
+}
+
+// NewEndpoint returns a new endpoint serving the metrics of the registry.
+func NewEndpoint(registry *Registry) *Endpoint {
+	return &Endpoint{registry: registry}
+}
+
+// Listen starts serving metrics on the given address and port, on a separate goroutine.
+func (endpoint *Endpoint) Listen(address string, port uint16) error {
+	endpoint.mutex.Lock()
+	defer endpoint.mutex.Unlock()
+	if endpoint.server != nil {
+		return AlreadyListening
+	}
+	var listener, err = net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(int(port))))
+	if err != nil {
+		return err
+	}
+	var mux = http.NewServeMux()
+	mux.Handle("/metrics", endpoint.registry)
+	endpoint.server = &http.Server{Handler: mux}
+	endpoint.listener = listener
+	go endpoint.server.Serve(listener)
+	return nil
+}
+
+// GetAddress returns the local address of the endpoint, or nil if it is not listening.
+func (endpoint *Endpoint) GetAddress() net.Addr {
+	endpoint.mutex.Lock()
+	defer endpoint.mutex.Unlock()
+	if endpoint.listener == nil {
+		return nil
+	}
+	return endpoint.listener.Addr()
+}
+
+// Close stops serving metrics.
+func (endpoint *Endpoint) Close() error {
+	endpoint.mutex.Lock()
+	defer endpoint.mutex.Unlock()
+	if endpoint.server == nil {
+		return nil
+	}
+	var err = endpoint.server.Close()
+	endpoint.server = nil
+	endpoint.listener = nil
+	return err
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWriteTo(t *testing.T) {
+	var registry = NewRegistry()
+	var counter = NewCounter("test_packets_total", "Packets received.")
+	var gauge = NewGauge("test_tick_seconds", "Duration of the last tick.")
+	registry.Register(counter)
+	registry.Register(gauge)
+	registry.Register(NewGaugeFunction("test_goroutines", "Amount of goroutines.", func() float64 { return 12 }))
+	if err := registry.Register(NewCounter("test_packets_total", "")); err != AlreadyRegistered {
+		t.Errorf("expected AlreadyRegistered, got %v", err)
+	}
+
+	counter.Add(3)
+	counter.Inc()
+	gauge.Set(0.025)
+
+	var buffer = &bytes.Buffer{}
+	registry.WriteTo(buffer)
+	var expected = "# HELP test_goroutines Amount of goroutines.\n# TYPE test_goroutines gauge\ntest_goroutines 12\n" +
+		"# HELP test_packets_total Packets received.\n# TYPE test_packets_total counter\ntest_packets_total 4\n" +
+		"# HELP test_tick_seconds Duration of the last tick.\n# TYPE test_tick_seconds gauge\ntest_tick_seconds 0.025\n"
+	if buffer.String() != expected {
+		t.Errorf("expected:\n%v\ngot:\n%v", expected, buffer.String())
+	}
+}
+
+func TestRate(t *testing.T) {
+	var registry = NewRegistry()
+	var rate = NewRate("test_packets", "Packets received")
+	if err := registry.Register(rate); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := registry.GetMetric("test_packets_total"); !ok {
+		t.Error("expected the counter of the rate to be registered")
+	}
+	if _, ok := registry.GetMetric("test_packets_per_second"); !ok {
+		t.Error("expected the gauge of the rate to be registered")
+	}
+	rate.Add(10)
+	rate.Update()
+	if rate.PerSecond.GetValue() <= 0 {
+		t.Errorf("expected a positive rate, got %v", rate.PerSecond.GetValue())
+	}
+	rate.Update()
+	if rate.PerSecond.GetValue() != 0 {
+		t.Errorf("expected no events since the last update, got %v", rate.PerSecond.GetValue())
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	var registry = NewRegistry()
+	registry.Register(NewCounter("test_total", "Test."))
+	var endpoint = NewEndpoint(registry)
+	if err := endpoint.Listen("127.0.0.1", 0); err != nil {
+		t.Fatal(err)
+	}
+	defer endpoint.Close()
+
+	var response, err = http.Get("http://" + endpoint.GetAddress().String() + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	var body, _ = ioutil.ReadAll(response.Body)
+	if !strings.Contains(string(body), "test_total 0\n") {
+		t.Errorf("unexpected response %q", body)
+	}
+}
@@ -0,0 +1,175 @@
+package compression
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/golang/snappy"
+)
+
+// Algorithm is an algorithm batches can be compressed with, as negotiated with the client.
+type Algorithm uint16
+
+const (
+	// Flate compresses batches with raw deflate. Clients refer to it as zlib.
+	Flate Algorithm = 0
+	// Snappy compresses batches with snappy, which is faster but compresses less than flate.
+	Snappy Algorithm = 1
+	// None leaves batches uncompressed.
+	None Algorithm = 0xffff
+)
+
+// PrefixProtocol is the first protocol in which every batch is prefixed with the ID of the algorithm it is compressed with.
+// Clients using an older protocol that negotiate compression expect every batch to be compressed with the negotiated algorithm.
+const PrefixProtocol = 649
+
+// prefixNone is the prefix of batches that are not compressed.
+const prefixNone byte = 0xff
+
+// MaxDecompressedSize is the maximum size of a batch once decompressed.
+// Batches are decompressed before the client logged in, so clients can not make the server allocate more than this.
+const MaxDecompressedSize = 16 * 1024 * 1024
+
+var (
+	UnknownAlgorithm = errors.New("unknown compression algorithm")
+	BatchTooLarge    = errors.New("decompressed batch exceeds the maximum size")
+)
+
+// names holds all algorithms, indexed by their name in the configuration.
+var names = map[string]Algorithm{
+	"zlib":   Flate,
+	"flate":  Flate,
+	"snappy": Snappy,
+	"none":   None,
+}
+
+// FromName returns the algorithm with the given name, such as "zlib" or "snappy", case insensitively.
+func FromName(name string) (Algorithm, error) {
+	var algorithm, ok = names[strings.ToLower(name)]
+	if !ok {
+		return None, UnknownAlgorithm
+	}
+	return algorithm, nil
+}
+
+// FromPrefix returns the algorithm a batch with the given prefix is compressed with.
+func FromPrefix(prefix byte) (Algorithm, error) {
+	switch prefix {
+	case byte(Flate), byte(Snappy):
+		return Algorithm(prefix), nil
+	case prefixNone:
+		return None, nil
+	}
+	return None, UnknownAlgorithm
+}
+
+// GetPrefix returns the prefix of batches compressed with the algorithm.
+func (algorithm Algorithm) GetPrefix() byte {
+	if algorithm == None {
+		return prefixNone
+	}
+	return byte(algorithm)
+}
+
+// Settings are the compression settings of a network adapter.
+type Settings struct {
+	// Algorithm is the algorithm offered to clients negotiating compression.
+	Algorithm Algorithm
+	// Level is the flate and zlib compression level, ranging from -2 to 9. -1 is the default level.
+	Level int
+	// Threshold is the minimum size of a batch in bytes for it to be compressed.
+	// Smaller batches are sent uncompressed, or stored without compression for clients that do not negotiate compression.
+	Threshold int
+}
+
+// DefaultSettings returns the settings of clients that do not negotiate compression: zlib with the default level.
+func DefaultSettings() Settings {
+	return Settings{Algorithm: Flate, Level: zlib.DefaultCompression}
+}
+
+// Compress compresses the data with the algorithm, using the given level for flate.
+func Compress(algorithm Algorithm, data []byte, level int) ([]byte, error) {
+	switch algorithm {
+	case Flate:
+		var buffer = getBuffer()
+		defer putBuffer(buffer)
+		var writer, err = getFlateWriter(buffer, level)
+		if err != nil {
+			return nil, err
+		}
+		writer.Write(data)
+		writer.Close()
+		putFlateWriter(writer, level)
+		return copyBytes(buffer), nil
+	case Snappy:
+		return snappy.Encode(nil, data), nil
+	case None:
+		return data, nil
+	}
+	return nil, UnknownAlgorithm
+}
+
+// Decompress decompresses data compressed with the algorithm.
+// BatchTooLarge is returned if the data decompresses to more than MaxDecompressedSize bytes.
+func Decompress(algorithm Algorithm, data []byte) ([]byte, error) {
+	switch algorithm {
+	case Flate:
+		var reader = getFlateReader(bytes.NewReader(data))
+		defer flateReaders.Put(reader)
+		return readAll(reader)
+	case Snappy:
+		var length, err = snappy.DecodedLen(data)
+		if err != nil {
+			return nil, err
+		}
+		if length > MaxDecompressedSize {
+			return nil, BatchTooLarge
+		}
+		return snappy.Decode(nil, data)
+	case None:
+		return data, nil
+	}
+	return nil, UnknownAlgorithm
+}
+
+// CompressZlib compresses the data with zlib, which clients that do not negotiate compression use, at the given level.
+func CompressZlib(data []byte, level int) ([]byte, error) {
+	var buffer = getBuffer()
+	defer putBuffer(buffer)
+	var writer, err = getZlibWriter(buffer, level)
+	if err != nil {
+		return nil, err
+	}
+	writer.Write(data)
+	writer.Close()
+	putZlibWriter(writer, level)
+	return copyBytes(buffer), nil
+}
+
+// DecompressZlib decompresses zlib compressed data.
+// BatchTooLarge is returned if the data decompresses to more than MaxDecompressedSize bytes.
+func DecompressZlib(data []byte) ([]byte, error) {
+	var reader, err = getZlibReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zlibReaders.Put(reader)
+	return readAll(reader)
+}
+
+// readAll reads all decompressed data from the reader into a pooled buffer, and returns a copy of it.
+// BatchTooLarge is returned once more than MaxDecompressedSize bytes were read.
+func readAll(reader io.Reader) ([]byte, error) {
+	var buffer = getBuffer()
+	defer putBuffer(buffer)
+	if _, err := buffer.ReadFrom(io.LimitReader(reader, MaxDecompressedSize+1)); err != nil {
+		return nil, err
+	}
+	if buffer.Len() > MaxDecompressedSize {
+		return nil, BatchTooLarge
+	}
+	return copyBytes(buffer), nil
+}
@@ -0,0 +1,120 @@
+package compression
+
+import (
+	"bytes"
+	"compress/zlib"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	var data = bytes.Repeat([]byte("batch data "), 100)
+	for _, algorithm := range []Algorithm{Flate, Snappy, None} {
+		var compressed, err = Compress(algorithm, data, 6)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decompressed, err := Decompress(algorithm, compressed)
+		if err != nil || !bytes.Equal(decompressed, data) {
+			t.Errorf("expected algorithm %v to decompress to the original data, got error %v", algorithm, err)
+		}
+	}
+
+	var compressed, _ = CompressZlib(data, zlib.NoCompression)
+	if decompressed, err := DecompressZlib(compressed); err != nil || !bytes.Equal(decompressed, data) {
+		t.Errorf("expected stored zlib data to decompress to the original data, got error %v", err)
+	}
+}
+
+func TestMaxDecompressedSize(t *testing.T) {
+	var data = make([]byte, MaxDecompressedSize+1)
+	for _, algorithm := range []Algorithm{Flate, Snappy} {
+		var compressed, _ = Compress(algorithm, data, 6)
+		if _, err := Decompress(algorithm, compressed); err != BatchTooLarge {
+			t.Errorf("expected algorithm %v to reject the oversized batch, got %v", algorithm, err)
+		}
+	}
+	var compressed, _ = CompressZlib(data, 6)
+	if _, err := DecompressZlib(compressed); err != BatchTooLarge {
+		t.Errorf("expected zlib to reject the oversized batch, got %v", err)
+	}
+	compressed, _ = Compress(Flate, data[:MaxDecompressedSize], 6)
+	if decompressed, err := Decompress(Flate, compressed); err != nil || len(decompressed) != MaxDecompressedSize {
+		t.Errorf("expected a batch of the maximum size to be decompressed, got error %v", err)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	for _, algorithm := range []Algorithm{Flate, Snappy, None} {
+		if prefixed, err := FromPrefix(algorithm.GetPrefix()); err != nil || prefixed != algorithm {
+			t.Errorf("expected prefix of %v to return it, got %v", algorithm, prefixed)
+		}
+	}
+	if algorithm, err := FromName("Snappy"); err != nil || algorithm != Snappy {
+		t.Errorf("expected snappy, got %v", algorithm)
+	}
+}
+
+// benchmarkBatch returns batch data that compresses like a batch of movement and chunk packets.
+func benchmarkBatch() []byte {
+	var data = make([]byte, 0, 16384)
+	for i := 0; len(data) < 16384; i++ {
+		data = append(data, byte(i%7), byte(i%13), 0, 0, byte(i))
+	}
+	return data
+}
+
+func BenchmarkCompressZlib(b *testing.B) {
+	var data = benchmarkBatch()
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		CompressZlib(data, 6)
+	}
+}
+
+// BenchmarkCompressZlibUnpooled compresses like CompressZlib did before writers and buffers were pooled.
+func BenchmarkCompressZlibUnpooled(b *testing.B) {
+	var data = benchmarkBatch()
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		var buffer = bytes.Buffer{}
+		var writer, _ = zlib.NewWriterLevel(&buffer, 6)
+		writer.Write(data)
+		writer.Close()
+	}
+}
+
+func BenchmarkCompressFlate(b *testing.B) {
+	var data = benchmarkBatch()
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		Compress(Flate, data, 6)
+	}
+}
+
+func BenchmarkDecompressZlib(b *testing.B) {
+	var data, _ = CompressZlib(benchmarkBatch(), 6)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		DecompressZlib(data)
+	}
+}
+
+func TestPooledWriters(t *testing.T) {
+	var first = bytes.Repeat([]byte("first batch "), 50)
+	var second = bytes.Repeat([]byte("second batch "), 50)
+	for _, data := range [][]byte{first, second, first} {
+		var compressed, err = CompressZlib(data, 6)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decompressed, err := DecompressZlib(compressed); err != nil || !bytes.Equal(decompressed, data) {
+			t.Errorf("expected reused writer and reader to round trip, got error %v", err)
+		}
+	}
+	if _, err := CompressZlib(first, 42); err == nil {
+		t.Error("expected invalid compression level to fail")
+	}
+}
@@ -0,0 +1,113 @@
+package compression
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"io"
+	"sync"
+)
+
+// levels is the amount of compression levels of flate and zlib, ranging from -2 to 9.
+const levels = flate.BestCompression - flate.HuffmanOnly + 1
+
+// maxPooledBuffer is the maximum capacity of buffers put back in the pool.
+// Buffers that grew larger for a huge batch, such as a full chunk, are left to the garbage collector.
+const maxPooledBuffer = 1 << 20
+
+var (
+	// zlibWriters holds pools of zlib writers, indexed by compression level.
+	zlibWriters [levels]sync.Pool
+	// flateWriters holds pools of flate writers, indexed by compression level.
+	flateWriters [levels]sync.Pool
+	// zlibReaders holds zlib readers, which are reset for every batch.
+	zlibReaders sync.Pool
+	// flateReaders holds flate readers, which are reset for every batch.
+	flateReaders sync.Pool
+	// buffers holds the buffers batches are compressed and decompressed into.
+	buffers = sync.Pool{New: func() interface{} {
+		return bytes.NewBuffer(make([]byte, 0, 4096))
+	}}
+)
+
+// levelIndex returns the index of the pool of writers for the compression level,
+// and a bool indicating if the level is valid.
+func levelIndex(level int) (int, bool) {
+	return level - flate.HuffmanOnly, level >= flate.HuffmanOnly && level <= flate.BestCompression
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	var buffer = buffers.Get().(*bytes.Buffer)
+	buffer.Reset()
+	return buffer
+}
+
+// putBuffer puts the buffer back in the pool, unless it grew too large.
+func putBuffer(buffer *bytes.Buffer) {
+	if buffer.Cap() <= maxPooledBuffer {
+		buffers.Put(buffer)
+	}
+}
+
+// copyBytes returns a copy of the bytes in the buffer, so that the buffer can be put back in the pool.
+func copyBytes(buffer *bytes.Buffer) []byte {
+	return append(make([]byte, 0, buffer.Len()), buffer.Bytes()...)
+}
+
+// getZlibWriter returns a zlib writer with the compression level from the pool, writing to the writer.
+func getZlibWriter(writer io.Writer, level int) (*zlib.Writer, error) {
+	if index, ok := levelIndex(level); ok {
+		if pooled, ok := zlibWriters[index].Get().(*zlib.Writer); ok {
+			pooled.Reset(writer)
+			return pooled, nil
+		}
+	}
+	return zlib.NewWriterLevel(writer, level)
+}
+
+// putZlibWriter puts the closed zlib writer with the compression level back in the pool.
+func putZlibWriter(writer *zlib.Writer, level int) {
+	if index, ok := levelIndex(level); ok {
+		zlibWriters[index].Put(writer)
+	}
+}
+
+// getFlateWriter returns a flate writer with the compression level from the pool, writing to the writer.
+func getFlateWriter(writer io.Writer, level int) (*flate.Writer, error) {
+	if index, ok := levelIndex(level); ok {
+		if pooled, ok := flateWriters[index].Get().(*flate.Writer); ok {
+			pooled.Reset(writer)
+			return pooled, nil
+		}
+	}
+	return flate.NewWriter(writer, level)
+}
+
+// putFlateWriter puts the closed flate writer with the compression level back in the pool.
+func putFlateWriter(writer *flate.Writer, level int) {
+	if index, ok := levelIndex(level); ok {
+		flateWriters[index].Put(writer)
+	}
+}
+
+// getZlibReader returns a zlib reader from the pool, reading from the reader.
+func getZlibReader(reader io.Reader) (io.ReadCloser, error) {
+	if pooled, ok := zlibReaders.Get().(io.ReadCloser); ok {
+		if err := pooled.(zlib.Resetter).Reset(reader, nil); err != nil {
+			zlibReaders.Put(pooled)
+			return nil, err
+		}
+		return pooled, nil
+	}
+	return zlib.NewReader(reader)
+}
+
+// getFlateReader returns a flate reader from the pool, reading from the reader.
+func getFlateReader(reader io.Reader) io.ReadCloser {
+	if pooled, ok := flateReaders.Get().(io.ReadCloser); ok {
+		pooled.(flate.Resetter).Reset(reader, nil)
+		return pooled
+	}
+	return flate.NewReader(reader)
+}
@@ -0,0 +1,89 @@
+package forwarding
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TrustedProxies holds the addresses and CIDR networks of proxies that are trusted to forward the address of clients.
+// Clients connecting through any other address can not forward an address, so that they can not spoof it.
+type TrustedProxies struct {
+	mutex    sync.RWMutex
+	networks []*net.IPNet
+}
+
+// NewTrustedProxies returns new trusted proxies with the given addresses and CIDR networks.
+func NewTrustedProxies(entries ...string) (*TrustedProxies, error) {
+	var proxies = &TrustedProxies{}
+	for _, entry := range entries {
+		if err := proxies.Add(entry); err != nil {
+			return proxies, err
+		}
+	}
+	return proxies, nil
+}
+
+// Add adds an address or CIDR network to the trusted proxies.
+func (proxies *TrustedProxies) Add(entry string) error {
+	if !strings.Contains(entry, "/") {
+		var ip = net.ParseIP(entry)
+		if ip == nil {
+			return &net.ParseError{Type: "IP address", Text: entry}
+		}
+		if ip.To4() != nil {
+			entry += "/32"
+		} else {
+			entry += "/128"
+		}
+	}
+	var _, network, err = net.ParseCIDR(entry)
+	if err != nil {
+		return err
+	}
+	proxies.mutex.Lock()
+	proxies.networks = append(proxies.networks, network)
+	proxies.mutex.Unlock()
+	return nil
+}
+
+// IsEmpty checks if no proxies are trusted.
+func (proxies *TrustedProxies) IsEmpty() bool {
+	proxies.mutex.RLock()
+	defer proxies.mutex.RUnlock()
+	return len(proxies.networks) == 0
+}
+
+// IsTrusted checks if the IP address is the address of a trusted proxy.
+func (proxies *TrustedProxies) IsTrusted(ip net.IP) bool {
+	proxies.mutex.RLock()
+	defer proxies.mutex.RUnlock()
+	for _, network := range proxies.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseAddress parses an address forwarded by a proxy, which is an IP address with an optional port,
+// such as "203.0.113.5", "203.0.113.5:19132" or "[2001:db8::1]:19132". The default port is used if it has no port.
+func ParseAddress(address string, defaultPort int) (*net.UDPAddr, error) {
+	if ip := net.ParseIP(strings.Trim(address, "[]")); ip != nil {
+		return &net.UDPAddr{IP: ip, Port: defaultPort}, nil
+	}
+	var host, portString, err = net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	var ip = net.ParseIP(host)
+	if ip == nil {
+		return nil, &net.ParseError{Type: "IP address", Text: host}
+	}
+	var port, portErr = strconv.Atoi(portString)
+	if portErr != nil || port < 0 || port > 65535 {
+		return nil, &net.ParseError{Type: "port", Text: portString}
+	}
+	return &net.UDPAddr{IP: ip, Port: port}, nil
+}
@@ -0,0 +1,45 @@
+package forwarding
+
+import (
+	"net"
+	"testing"
+)
+
+func TestTrustedProxies(t *testing.T) {
+	var proxies, err = NewTrustedProxies("10.0.0.0/8", "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for ip, trusted := range map[string]bool{"10.1.2.3": true, "192.0.2.1": true, "192.0.2.2": false, "203.0.113.5": false} {
+		if proxies.IsTrusted(net.ParseIP(ip)) != trusted {
+			t.Errorf("expected %v to be trusted: %v", ip, trusted)
+		}
+	}
+	if _, err := NewTrustedProxies("not an address"); err == nil {
+		t.Error("expected an invalid entry to fail")
+	}
+}
+
+func TestParseAddress(t *testing.T) {
+	var tests = map[string]string{
+		"203.0.113.5":        "203.0.113.5:19132",
+		"203.0.113.5:1234":   "203.0.113.5:1234",
+		"2001:db8::1":        "[2001:db8::1]:19132",
+		"[2001:db8::1]:1234": "[2001:db8::1]:1234",
+	}
+	for address, expected := range tests {
+		var parsed, err = ParseAddress(address, 19132)
+		if err != nil {
+			t.Errorf("failed to parse %v: %v", address, err)
+			continue
+		}
+		if parsed.String() != expected {
+			t.Errorf("expected %v, got %v", expected, parsed)
+		}
+	}
+	for _, address := range []string{"", "proxy.example.com", "203.0.113.5:port", "203.0.113.5:70000"} {
+		if _, err := ParseAddress(address, 19132); err == nil {
+			t.Errorf("expected %v to be invalid", address)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package gamespy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// TypeStatistics is the packet type of a basic or full stat request.
+	TypeStatistics = 0x00
+	// TypeHandshake is the packet type of a handshake requesting a challenge token.
+	TypeHandshake = 0x09
+
+	// GameId is the game id sent in the full stat response.
+	GameId = "MINECRAFTPE"
+)
+
+// Header is the magic header every query request starts with.
+var Header = []byte{0xfe, 0xfd}
+
+// TokenLifetime is the duration after which challenge tokens get rotated.
+// Tokens handed out remain valid for up to twice this duration.
+var TokenLifetime = time.Second * 30
+
+// Info is the server information returned to query clients.
+type Info struct {
+	MOTD           string
+	GameMode       string
+	WorldName      string
+	Version        string
+	ServerEngine   string
+	ListPlugins    bool
+	PluginNames    []string
+	PlayerNames    []string
+	OnlinePlayers  int
+	MaximumPlayers int
+	Whitelist      string
+	Address        string
+	Port           uint16
+}
+
+// Handler handles GameSpy4 (UT3) query requests and produces responses.
+// It is independent of the transport, so it can be used by the Listener
+// as well as by any other socket that receives query packets.
+type Handler struct {
+	mutex     sync.RWMutex
+	info      Info
+	secret    uint32
+	previous  uint32
+	rotatedAt time.Time
+}
+
+// NewHandler returns a new query handler with empty server information.
+func NewHandler() *Handler {
+	return &Handler{secret: rand.Uint32(), previous: rand.Uint32(), rotatedAt: time.Now()}
+}
+
+// SetInfo sets the server information returned to query clients.
+func (handler *Handler) SetInfo(info Info) {
+	handler.mutex.Lock()
+	handler.info = info
+	handler.mutex.Unlock()
+}
+
+// GetInfo returns the server information returned to query clients.
+func (handler *Handler) GetInfo() Info {
+	handler.mutex.RLock()
+	defer handler.mutex.RUnlock()
+	return handler.info
+}
+
+// Handle handles a query request from the given address.
+// Returns the response to write back, or nil if the request
+// was malformed or carried an invalid challenge token.
+func (handler *Handler) Handle(packet []byte, addr *net.UDPAddr) []byte {
+	if len(packet) < 7 || !bytes.Equal(packet[:2], Header) {
+		return nil
+	}
+	var packetType = packet[2]
+	var sessionId = packet[3:7]
+
+	switch packetType {
+	case TypeHandshake:
+		var buffer = bytes.NewBuffer([]byte{TypeHandshake})
+		buffer.Write(sessionId)
+		buffer.WriteString(strconv.Itoa(int(handler.token(addr))))
+		buffer.WriteByte(0)
+		return buffer.Bytes()
+	case TypeStatistics:
+		if len(packet) < 11 || !handler.validToken(int32(binary.BigEndian.Uint32(packet[7:11])), addr) {
+			return nil
+		}
+		var buffer = bytes.NewBuffer([]byte{TypeStatistics})
+		buffer.Write(sessionId)
+
+		var info = handler.GetInfo()
+		if len(packet) >= 15 {
+			writeFullStatistics(buffer, info)
+		} else {
+			writeBasicStatistics(buffer, info)
+		}
+		return buffer.Bytes()
+	}
+	return nil
+}
+
+// token returns the current challenge token for the given address.
+func (handler *Handler) token(addr *net.UDPAddr) int32 {
+	handler.mutex.Lock()
+	if time.Since(handler.rotatedAt) >= TokenLifetime {
+		handler.previous = handler.secret
+		handler.secret = rand.Uint32()
+		handler.rotatedAt = time.Now()
+	}
+	var secret = handler.secret
+	handler.mutex.Unlock()
+
+	return generateToken(secret, addr)
+}
+
+// validToken checks if the token is the current or previous challenge token of the address.
+func (handler *Handler) validToken(token int32, addr *net.UDPAddr) bool {
+	handler.mutex.RLock()
+	defer handler.mutex.RUnlock()
+	return token == generateToken(handler.secret, addr) || token == generateToken(handler.previous, addr)
+}
+
+// generateToken generates a challenge token for an address using the given secret.
+// Tokens are kept positive, as clients parse them as signed integers.
+func generateToken(secret uint32, addr *net.UDPAddr) int32 {
+	var data = make([]byte, 4)
+	binary.BigEndian.PutUint32(data, secret)
+	data = append(data, addr.IP...)
+	data = append(data, strconv.Itoa(addr.Port)...)
+	return int32(crc32.ChecksumIEEE(data) & 0x7fffffff)
+}
+
+// writeBasicStatistics writes the basic stat response of the server information.
+func writeBasicStatistics(buffer *bytes.Buffer, info Info) {
+	writeString(buffer, info.MOTD)
+	writeString(buffer, info.GameMode)
+	writeString(buffer, info.WorldName)
+	writeString(buffer, strconv.Itoa(info.OnlinePlayers))
+	writeString(buffer, strconv.Itoa(info.MaximumPlayers))
+	binary.Write(buffer, binary.LittleEndian, info.Port)
+	writeString(buffer, info.Address)
+}
+
+// writeFullStatistics writes the full stat response of the server information,
+// which consists of key/value pairs followed by the names of all online players.
+func writeFullStatistics(buffer *bytes.Buffer, info Info) {
+	buffer.Write([]byte("splitnum\x00\x80\x00"))
+
+	var plugins = info.ServerEngine
+	if info.ListPlugins && len(info.PluginNames) > 0 {
+		plugins += ": " + strings.Join(info.PluginNames, "; ")
+	}
+	var pairs = [][2]string{
+		{"hostname", info.MOTD},
+		{"gametype", info.GameMode},
+		{"game_id", GameId},
+		{"version", info.Version},
+		{"server_engine", info.ServerEngine},
+		{"plugins", plugins},
+		{"map", info.WorldName},
+		{"numplayers", strconv.Itoa(info.OnlinePlayers)},
+		{"maxplayers", strconv.Itoa(info.MaximumPlayers)},
+		{"whitelist", info.Whitelist},
+		{"hostip", info.Address},
+		{"hostport", strconv.Itoa(int(info.Port))},
+	}
+	for _, pair := range pairs {
+		writeString(buffer, pair[0])
+		writeString(buffer, pair[1])
+	}
+	buffer.WriteByte(0)
+
+	buffer.Write([]byte("\x01player_\x00\x00"))
+	for _, name := range info.PlayerNames {
+		writeString(buffer, name)
+	}
+	buffer.WriteByte(0)
+}
+
+// writeString writes a null terminated string to the buffer.
+func writeString(buffer *bytes.Buffer, value string) {
+	buffer.WriteString(value)
+	buffer.WriteByte(0)
+}
@@ -0,0 +1,128 @@
+package gamespy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var testInfo = Info{
+	MOTD:           "Test Server",
+	GameMode:       "SMP",
+	WorldName:      "world",
+	Version:        "v1.2.10",
+	ServerEngine:   "GoMine 0.0.1",
+	ListPlugins:    true,
+	PluginNames:    []string{"Essentials v1.0"},
+	PlayerNames:    []string{"Steve", "Alex"},
+	OnlinePlayers:  2,
+	MaximumPlayers: 20,
+	Whitelist:      "off",
+	Address:        "127.0.0.1",
+	Port:           19132,
+}
+
+// request builds a query request of the given type with the payload appended.
+func request(packetType byte, payload ...byte) []byte {
+	return append(append(append([]byte{}, Header...), packetType, 0, 0, 0, 1), payload...)
+}
+
+// handshake performs a handshake and returns the challenge token payload.
+func handshake(t *testing.T, respond func([]byte) []byte) []byte {
+	var response = respond(request(TypeHandshake))
+	if len(response) < 6 || response[0] != TypeHandshake || response[len(response)-1] != 0 {
+		t.Fatalf("invalid handshake response %q", response)
+	}
+	var token, err = strconv.Atoi(string(response[5 : len(response)-1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload = make([]byte, 4)
+	binary.BigEndian.PutUint32(payload, uint32(token))
+	return payload
+}
+
+func TestBasicStatistics(t *testing.T) {
+	var handler = NewHandler()
+	handler.SetInfo(testInfo)
+	var addr = &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5000}
+	var respond = func(packet []byte) []byte { return handler.Handle(packet, addr) }
+
+	var response = respond(request(TypeStatistics, handshake(t, respond)...))
+	var expected = []byte("\x00\x00\x00\x00\x01Test Server\x00SMP\x00world\x002\x0020\x00\xbc\x4a127.0.0.1\x00")
+	if !bytes.Equal(response, expected) {
+		t.Errorf("expected %q, got %q", expected, response)
+	}
+}
+
+func TestFullStatistics(t *testing.T) {
+	var handler = NewHandler()
+	handler.SetInfo(testInfo)
+	var addr = &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5000}
+	var respond = func(packet []byte) []byte { return handler.Handle(packet, addr) }
+
+	var response = respond(request(TypeStatistics, append(handshake(t, respond), 0, 0, 0, 0)...))
+	for _, part := range []string{
+		"splitnum\x00\x80\x00",
+		"\x00hostname\x00Test Server\x00",
+		"\x00game_id\x00MINECRAFTPE\x00",
+		"\x00plugins\x00GoMine 0.0.1: Essentials v1.0\x00",
+		"\x00numplayers\x002\x00",
+		"\x00hostport\x0019132\x00\x00",
+		"\x01player_\x00\x00Steve\x00Alex\x00\x00",
+	} {
+		if !bytes.Contains(response, []byte(part)) {
+			t.Errorf("expected response to contain %q, got %q", part, response)
+		}
+	}
+}
+
+func TestInvalidToken(t *testing.T) {
+	var handler = NewHandler()
+	var addr = &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5000}
+	var other = &net.UDPAddr{IP: net.IPv4(127, 0, 0, 2), Port: 5000}
+	var token = handshake(t, func(packet []byte) []byte { return handler.Handle(packet, addr) })
+
+	if response := handler.Handle(request(TypeStatistics, token...), other); response != nil {
+		t.Errorf("expected no response for a token of another address, got %q", response)
+	}
+	if response := handler.Handle(request(TypeStatistics, 0, 0, 0, 0), addr); response != nil {
+		t.Errorf("expected no response for an invalid token, got %q", response)
+	}
+}
+
+func TestListener(t *testing.T) {
+	var handler = NewHandler()
+	handler.SetInfo(testInfo)
+	var listener = NewListener(handler)
+	if err := listener.Listen("127.0.0.1", 0); err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	var connection, err = net.DialUDP("udp", nil, listener.GetAddress())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer connection.Close()
+
+	var respond = func(packet []byte) []byte {
+		connection.SetDeadline(time.Now().Add(time.Second * 2))
+		if _, err := connection.Write(packet); err != nil {
+			t.Fatal(err)
+		}
+		var buffer = make([]byte, 1500)
+		var n, err = connection.Read(buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return buffer[:n]
+	}
+	var response = respond(request(TypeStatistics, handshake(t, respond)...))
+	if !bytes.HasPrefix(response, []byte("\x00\x00\x00\x00\x01Test Server\x00")) {
+		t.Errorf("unexpected basic stat response %q", response)
+	}
+}
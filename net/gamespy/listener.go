@@ -0,0 +1,82 @@
+package gamespy
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+)
+
+var (
+	AlreadyListening = errors.New("query listener is already listening")
+)
+
+// Listener listens for query requests on a dedicated UDP port,
+// for server list sites and launchers that query a separate port.
+type Listener struct {
+	*Handler
+	mutex      sync.Mutex
+	connection *net.UDPConn
+}
+
+// NewListener returns a new query listener handling requests with the handler.
+func NewListener(handler *Handler) *Listener {
+	return &Listener{Handler: handler}
+}
+
+// Listen starts listening on the given address and port.
+// Requests are handled on a separate goroutine until the listener is closed.
+func (listener *Listener) Listen(address string, port uint16) error {
+	listener.mutex.Lock()
+	defer listener.mutex.Unlock()
+	if listener.connection != nil {
+		return AlreadyListening
+	}
+
+	var addr, err = net.ResolveUDPAddr("udp", net.JoinHostPort(address, strconv.Itoa(int(port))))
+	if err != nil {
+		return err
+	}
+	listener.connection, err = net.ListenUDP("udp", addr)
+	if err != nil {
+		return err
+	}
+	go listener.read(listener.connection)
+	return nil
+}
+
+// GetAddress returns the local address of the listener, or nil if it is not listening.
+func (listener *Listener) GetAddress() *net.UDPAddr {
+	listener.mutex.Lock()
+	defer listener.mutex.Unlock()
+	if listener.connection == nil {
+		return nil
+	}
+	return listener.connection.LocalAddr().(*net.UDPAddr)
+}
+
+// Close stops the listener from listening.
+func (listener *Listener) Close() error {
+	listener.mutex.Lock()
+	defer listener.mutex.Unlock()
+	if listener.connection == nil {
+		return nil
+	}
+	var err = listener.connection.Close()
+	listener.connection = nil
+	return err
+}
+
+// read reads and answers requests until the connection gets closed.
+func (listener *Listener) read(connection *net.UDPConn) {
+	var buffer = make([]byte, 1500)
+	for {
+		var n, addr, err = connection.ReadFromUDP(buffer)
+		if err != nil {
+			return
+		}
+		if response := listener.Handle(buffer[:n], addr); response != nil {
+			connection.WriteToUDP(response, addr)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package net
+
+import (
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// Severity is the severity of an error of a packet handler, which determines how it is reported to the client.
+type Severity int
+
+const (
+	// SeverityNotice errors are reported in a chat message, after which the session continues as usual.
+	SeverityNotice Severity = iota
+	// SeverityForm errors are reported in a form, for errors players should not overlook in chat.
+	SeverityForm
+	// SeverityFatal errors disconnect the session, showing the message on the disconnection screen.
+	SeverityFatal
+)
+
+// HandlerError is an error of a packet handler, holding a message that is safe to show to the client.
+// The underlying error, if any, is only logged.
+type HandlerError struct {
+	Severity Severity
+	Message  string
+	Err      error
+}
+
+// NewNotice returns a new handler error reported in a chat message.
+func NewNotice(message string, err error) *HandlerError {
+	return &HandlerError{Severity: SeverityNotice, Message: message, Err: err}
+}
+
+// NewFormError returns a new handler error reported in a form.
+func NewFormError(message string, err error) *HandlerError {
+	return &HandlerError{Severity: SeverityForm, Message: message, Err: err}
+}
+
+// NewFatalError returns a new handler error that disconnects the session.
+func NewFatalError(message string, err error) *HandlerError {
+	return &HandlerError{Severity: SeverityFatal, Message: message, Err: err}
+}
+
+// Error returns the message of the error, followed by the underlying error if it has one.
+func (err *HandlerError) Error() string {
+	if err.Err == nil {
+		return err.Message
+	}
+	return err.Message + " " + err.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *HandlerError) Unwrap() error {
+	return err.Err
+}
+
+// ReportError reports the handler error to the client of the session, depending on its severity.
+// Form errors are reported in a chat message if the adapter has no ErrorFormFunction.
+// Packet handlers that report an error should still return true, as the packet was handled.
+func (session *MinecraftSession) ReportError(err *HandlerError) {
+	text.DefaultLogger.Debug(session.GetName(), "got a handler error:", err.Error())
+	switch err.Severity {
+	case SeverityFatal:
+		session.Kick(err.Message, false, false)
+	case SeverityForm:
+		if session.adapter.ErrorFormFunction != nil {
+			session.adapter.ErrorFormFunction(session, err.Message)
+			return
+		}
+		fallthrough
+	default:
+		session.SendMessage(text.Red + err.Message)
+	}
+}
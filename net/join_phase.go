@@ -0,0 +1,143 @@
+package net
+
+import (
+	"sync"
+	"time"
+)
+
+// JoinPhase is a phase of the join sequence of a session.
+// Sessions go through the phases in order, and never go back to an earlier phase.
+type JoinPhase int
+
+const (
+	// PhaseHandshake is the phase of logging in and the encryption handshake.
+	PhaseHandshake JoinPhase = iota
+	// PhasePacks is the phase of negotiating and downloading resource and behavior packs.
+	PhasePacks
+	// PhaseChunks is the phase after the game started, in which the chunks around the spawn are sent.
+	PhaseChunks
+	// PhaseSpawned is the phase of sessions of which the player has spawned.
+	PhaseSpawned
+)
+
+// joinPhaseNames holds the names of the join phases.
+var joinPhaseNames = map[JoinPhase]string{
+	PhaseHandshake: "handshake",
+	PhasePacks:     "packs",
+	PhaseChunks:    "chunks",
+	PhaseSpawned:   "spawned",
+}
+
+// String returns the name of the join phase, such as "packs".
+func (phase JoinPhase) String() string {
+	return joinPhaseNames[phase]
+}
+
+// PackProgress is the progress of a session downloading a pack from the server.
+type PackProgress struct {
+	// UUID is the UUID of the pack.
+	UUID string
+	// SentChunks is the amount of chunks of the pack sent so far, out of ChunkCount.
+	SentChunks int32
+	ChunkCount int32
+	// SentBytes is the amount of bytes of the pack sent so far, out of Size.
+	SentBytes int64
+	Size      int64
+	// ChunkSize is the size of the chunks the pack is sent in.
+	ChunkSize int32
+}
+
+// IsComplete checks if all chunks of the pack have been sent.
+func (progress PackProgress) IsComplete() bool {
+	return progress.SentChunks >= progress.ChunkCount
+}
+
+// joinTracker keeps track of the join sequence of a session.
+type joinTracker struct {
+	mutex        sync.Mutex
+	phase        JoinPhase
+	started      time.Time
+	phaseStarted time.Time
+	packs        []PackProgress
+}
+
+// newJoinTracker returns a new join tracker of a session that just connected.
+func newJoinTracker() *joinTracker {
+	var now = time.Now()
+	return &joinTracker{started: now, phaseStarted: now}
+}
+
+// GetJoinPhase returns the phase of the join sequence the session is in.
+func (session *MinecraftSession) GetJoinPhase() JoinPhase {
+	session.join.mutex.Lock()
+	defer session.join.mutex.Unlock()
+	return session.join.phase
+}
+
+// SetJoinPhase advances the session to the given phase of the join sequence.
+// It returns the previous phase, and a bool indicating if the phase changed.
+// The phase does not change if the session is already in the phase or a later one.
+func (session *MinecraftSession) SetJoinPhase(phase JoinPhase) (JoinPhase, bool) {
+	session.join.mutex.Lock()
+	defer session.join.mutex.Unlock()
+	var previous = session.join.phase
+	if phase <= previous {
+		return previous, false
+	}
+	session.join.phase = phase
+	session.join.phaseStarted = time.Now()
+	return previous, true
+}
+
+// GetJoinPhaseDuration returns how long the session has been in its current join phase.
+// For spawned sessions, this is the time since the player spawned.
+func (session *MinecraftSession) GetJoinPhaseDuration() time.Duration {
+	session.join.mutex.Lock()
+	defer session.join.mutex.Unlock()
+	return time.Since(session.join.phaseStarted)
+}
+
+// GetJoinDuration returns how long the session took to spawn,
+// or how long it has been joining if the player has not yet spawned.
+func (session *MinecraftSession) GetJoinDuration() time.Duration {
+	session.join.mutex.Lock()
+	defer session.join.mutex.Unlock()
+	if session.join.phase == PhaseSpawned {
+		return session.join.phaseStarted.Sub(session.join.started)
+	}
+	return time.Since(session.join.started)
+}
+
+// SetPackProgress sets the download progress of the pack with the UUID of the progress.
+func (session *MinecraftSession) SetPackProgress(progress PackProgress) {
+	session.join.mutex.Lock()
+	defer session.join.mutex.Unlock()
+	for i, pack := range session.join.packs {
+		if pack.UUID == progress.UUID {
+			session.join.packs[i] = progress
+			return
+		}
+	}
+	session.join.packs = append(session.join.packs, progress)
+}
+
+// GetPackProgressOf returns the download progress of the pack with the UUID,
+// and a bool indicating if the session requested the pack.
+func (session *MinecraftSession) GetPackProgressOf(uuid string) (PackProgress, bool) {
+	session.join.mutex.Lock()
+	defer session.join.mutex.Unlock()
+	for _, pack := range session.join.packs {
+		if pack.UUID == uuid {
+			return pack, true
+		}
+	}
+	return PackProgress{}, false
+}
+
+// GetPackProgress returns the download progress of all packs the session requested,
+// in the order the packs were first requested.
+func (session *MinecraftSession) GetPackProgress() []PackProgress {
+	session.join.mutex.Lock()
+	defer session.join.mutex.Unlock()
+	return append([]PackProgress{}, session.join.packs...)
+}
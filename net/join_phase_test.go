@@ -0,0 +1,27 @@
+package net
+
+import "testing"
+
+func TestJoinPhase(t *testing.T) {
+	var session = &MinecraftSession{join: newJoinTracker()}
+	if session.GetJoinPhase() != PhaseHandshake {
+		t.Fatalf("expected new sessions to be in the handshake phase, got %v", session.GetJoinPhase())
+	}
+	if previous, changed := session.SetJoinPhase(PhaseChunks); !changed || previous != PhaseHandshake {
+		t.Errorf("expected the phase to advance from the handshake phase, got %v", previous)
+	}
+	if _, changed := session.SetJoinPhase(PhasePacks); changed || session.GetJoinPhase() != PhaseChunks {
+		t.Error("expected the phase not to go back to an earlier phase")
+	}
+
+	session.SetPackProgress(PackProgress{UUID: "a", ChunkCount: 2, Size: 100})
+	session.SetPackProgress(PackProgress{UUID: "b", ChunkCount: 1, Size: 10})
+	session.SetPackProgress(PackProgress{UUID: "a", SentChunks: 2, ChunkCount: 2, SentBytes: 100, Size: 100})
+	var progress = session.GetPackProgress()
+	if len(progress) != 2 || progress[0].UUID != "a" || !progress[0].IsComplete() || progress[1].IsComplete() {
+		t.Errorf("unexpected pack progress %v", progress)
+	}
+	if pack, ok := session.GetPackProgressOf("b"); !ok || pack.Size != 10 {
+		t.Errorf("expected progress of pack b, got %v", pack)
+	}
+}
@@ -0,0 +1,171 @@
+package logincache
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// Result is the cached result of verifying a complete login chain.
+type Result struct {
+	// Authenticated indicates if the chain was signed by Mojang.
+	Authenticated bool
+	// ClientPublicKey is the public key of the client found in the chain.
+	ClientPublicKey *ecdsa.PublicKey
+}
+
+// certificateEntry is a cached, verified certificate of a login chain.
+type certificateEntry struct {
+	issuer  string
+	expires time.Time
+}
+
+// chainEntry is a cached result of a verified login chain, with the keys used to verify it.
+type chainEntry struct {
+	result  Result
+	keys    []string
+	expires time.Time
+}
+
+// Cache caches login chain verification in two tiers, so reconnecting clients skip ECDSA verification.
+// The first tier holds complete chains, and is checked before anything gets verified.
+// The second tier holds single certificates with the key that signed them,
+// so chains that only differ in their last certificate, as sent by clients that generated a new key,
+// only need the changed certificates verified.
+// Only successfully verified chains and certificates are cached.
+type Cache struct {
+	mutex        sync.Mutex
+	ttl          time.Duration
+	chains       map[[sha256.Size]byte]chainEntry
+	certificates map[[sha256.Size]byte]certificateEntry
+}
+
+// New returns a new cache keeping verified chains and certificates for the given duration.
+// Nothing gets cached if the duration is 0.
+func New(ttl time.Duration) *Cache {
+	return &Cache{ttl: ttl, chains: make(map[[sha256.Size]byte]chainEntry), certificates: make(map[[sha256.Size]byte]certificateEntry)}
+}
+
+// hash returns the key of the given strings in the cache.
+func hash(values ...string) [sha256.Size]byte {
+	var digest = sha256.New()
+	for _, value := range values {
+		digest.Write([]byte(value))
+		digest.Write([]byte{0})
+	}
+	var key [sha256.Size]byte
+	copy(key[:], digest.Sum(nil))
+	return key
+}
+
+// getExpiry returns the time an entry added now expires,
+// which is after the TTL or at the expiration time of the certificates, whichever is earlier.
+// An expiration time of 0 means the certificates do not expire.
+func (cache *Cache) getExpiry(expiration int64) time.Time {
+	var expires = time.Now().Add(cache.ttl)
+	if expiration != 0 && time.Unix(expiration, 0).Before(expires) {
+		return time.Unix(expiration, 0)
+	}
+	return expires
+}
+
+// GetChain returns the cached result of the chain with the given certificates.
+// A bool is returned indicating if the chain was found.
+func (cache *Cache) GetChain(certificates []string) (Result, bool) {
+	var key = hash(certificates...)
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	var entry, ok = cache.chains[key]
+	if !ok {
+		return Result{}, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(cache.chains, key)
+		return Result{}, false
+	}
+	return entry.result, true
+}
+
+// AddChain adds the result of a verified chain with the given certificates, which were verified with the given keys.
+// The expiration is the earliest Unix expiration time of the certificates.
+func (cache *Cache) AddChain(certificates []string, keys []string, result Result, expiration int64) {
+	if cache.ttl <= 0 {
+		return
+	}
+	cache.mutex.Lock()
+	cache.chains[hash(certificates...)] = chainEntry{result, keys, cache.getExpiry(expiration)}
+	cache.mutex.Unlock()
+}
+
+// HasCertificate checks if the certificate was verified to be signed by the issuer key.
+func (cache *Cache) HasCertificate(issuer string, certificate string) bool {
+	var key = hash(issuer, certificate)
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	var entry, ok = cache.certificates[key]
+	if !ok {
+		return false
+	}
+	if time.Now().After(entry.expires) {
+		delete(cache.certificates, key)
+		return false
+	}
+	return true
+}
+
+// AddCertificate adds a certificate that was verified to be signed by the issuer key,
+// expiring at the given Unix expiration time.
+func (cache *Cache) AddCertificate(issuer string, certificate string, expiration int64) {
+	if cache.ttl <= 0 {
+		return
+	}
+	cache.mutex.Lock()
+	cache.certificates[hash(issuer, certificate)] = certificateEntry{issuer, cache.getExpiry(expiration)}
+	cache.mutex.Unlock()
+}
+
+// InvalidateKey removes all chains and certificates verified with the given key.
+// InvalidateKey should be called when a key gets rotated or is no longer trusted.
+func (cache *Cache) InvalidateKey(key string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	for id, entry := range cache.certificates {
+		if entry.issuer == key {
+			delete(cache.certificates, id)
+		}
+	}
+	for id, entry := range cache.chains {
+		for _, chainKey := range entry.keys {
+			if chainKey == key {
+				delete(cache.chains, id)
+				break
+			}
+		}
+	}
+}
+
+// Clear removes all chains and certificates from the cache.
+func (cache *Cache) Clear() {
+	cache.mutex.Lock()
+	cache.chains = make(map[[sha256.Size]byte]chainEntry)
+	cache.certificates = make(map[[sha256.Size]byte]certificateEntry)
+	cache.mutex.Unlock()
+}
+
+// Prune removes all chains and certificates that have expired.
+func (cache *Cache) Prune() {
+	var now = time.Now()
+	cache.mutex.Lock()
+	for id, entry := range cache.chains {
+		if now.After(entry.expires) {
+			delete(cache.chains, id)
+		}
+	}
+	for id, entry := range cache.certificates {
+		if now.After(entry.expires) {
+			delete(cache.certificates, id)
+		}
+	}
+	cache.mutex.Unlock()
+}
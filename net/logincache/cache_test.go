@@ -0,0 +1,63 @@
+package logincache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChains(t *testing.T) {
+	var cache = New(time.Minute)
+	var certificates = []string{"a.b.c", "d.e.f"}
+	if _, ok := cache.GetChain(certificates); ok {
+		t.Fatal("expected empty cache")
+	}
+	cache.AddChain(certificates, []string{"root", "intermediate"}, Result{Authenticated: true}, 0)
+	if result, ok := cache.GetChain(certificates); !ok || !result.Authenticated {
+		t.Fatalf("expected cached authenticated chain, got %+v, %v", result, ok)
+	}
+	if _, ok := cache.GetChain([]string{"a.b.c", "d.e.g"}); ok {
+		t.Error("expected different chain not to be cached")
+	}
+	if _, ok := cache.GetChain([]string{"a.b.cd.e.f"}); ok {
+		t.Error("expected concatenated certificates not to match")
+	}
+
+	cache.InvalidateKey("intermediate")
+	if _, ok := cache.GetChain(certificates); ok {
+		t.Error("expected chain to be invalidated with its key")
+	}
+}
+
+func TestCertificates(t *testing.T) {
+	var cache = New(time.Minute)
+	cache.AddCertificate("root", "a.b.c", 0)
+	cache.AddCertificate("other", "d.e.f", 0)
+	if !cache.HasCertificate("root", "a.b.c") {
+		t.Fatal("expected certificate to be cached")
+	}
+	if cache.HasCertificate("other", "a.b.c") {
+		t.Error("expected certificate with a different issuer not to be cached")
+	}
+
+	cache.InvalidateKey("root")
+	if cache.HasCertificate("root", "a.b.c") {
+		t.Error("expected certificate to be invalidated")
+	}
+	if !cache.HasCertificate("other", "d.e.f") {
+		t.Error("expected certificate of other key to stay cached")
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	var cache = New(time.Minute)
+	cache.AddCertificate("root", "a.b.c", time.Now().Add(-time.Second).Unix())
+	if cache.HasCertificate("root", "a.b.c") {
+		t.Error("expected expired certificate not to be returned")
+	}
+
+	var disabled = New(0)
+	disabled.AddChain([]string{"a.b.c"}, nil, Result{}, 0)
+	if _, ok := disabled.GetChain([]string{"a.b.c"}); ok {
+		t.Error("expected nothing to be cached with a TTL of 0")
+	}
+}
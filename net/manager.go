@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/irmine/goraklib/server"
+	"strings"
 	"sync"
 )
 
@@ -12,6 +13,7 @@ import (
 type SessionManager struct {
 	mutex      sync.RWMutex
 	nameMap    map[string]*MinecraftSession
+	lowerMap   map[string]*MinecraftSession
 	uuidMap    map[uuid.UUID]*MinecraftSession
 	xuidMap    map[string]*MinecraftSession
 	sessionMap map[string]*MinecraftSession
@@ -19,7 +21,7 @@ type SessionManager struct {
 
 // NewSessionManager returns a new session manager.
 func NewSessionManager() *SessionManager {
-	return &SessionManager{sync.RWMutex{}, make(map[string]*MinecraftSession), make(map[uuid.UUID]*MinecraftSession), make(map[string]*MinecraftSession), make(map[string]*MinecraftSession)}
+	return &SessionManager{sync.RWMutex{}, make(map[string]*MinecraftSession), make(map[string]*MinecraftSession), make(map[uuid.UUID]*MinecraftSession), make(map[string]*MinecraftSession), make(map[string]*MinecraftSession)}
 }
 
 // GetSessions returns the name => session map of the manager.
@@ -30,20 +32,56 @@ func (manager *SessionManager) GetSessions() map[string]*MinecraftSession {
 // AddMinecraftSession adds the given Minecraft session to the manager.
 func (manager *SessionManager) AddMinecraftSession(session *MinecraftSession) {
 	manager.mutex.Lock()
+	manager.add(session)
+	manager.mutex.Unlock()
+}
+
+// TryAddMinecraftSession adds the given Minecraft session to the manager,
+// unless the manager holds another session with the same name, ignoring case.
+// The replaced session may be nil, or a session kicked to make room for the new one,
+// which gets replaced if it was not yet removed, even if its name has a different case.
+// Returns false if the session was not added.
+func (manager *SessionManager) TryAddMinecraftSession(session *MinecraftSession, replaced *MinecraftSession) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var existing, ok = manager.lowerMap[strings.ToLower(session.GetName())]
+	if ok && existing != replaced {
+		return false
+	}
+	if ok && manager.nameMap[existing.GetName()] == existing {
+		delete(manager.nameMap, existing.GetName())
+	}
+	manager.add(session)
+	return true
+}
+
+// add adds the given Minecraft session to all maps of the manager.
+// The mutex of the manager must be locked while calling add.
+func (manager *SessionManager) add(session *MinecraftSession) {
 	manager.nameMap[session.GetName()] = session
+	manager.lowerMap[strings.ToLower(session.GetName())] = session
 	manager.uuidMap[session.GetUUID()] = session
 	manager.xuidMap[session.GetXUID()] = session
 	manager.sessionMap[fmt.Sprint(session.GetSession())] = session
-	manager.mutex.Unlock()
 }
 
 // RemoveMinecraftSession removes a Minecraft session from the manager.
 func (manager *SessionManager) RemoveMinecraftSession(session *MinecraftSession) {
 	if session != nil {
 		manager.mutex.Lock()
-		delete(manager.nameMap, session.GetPlayer().GetName())
-		delete(manager.uuidMap, session.GetUUID())
-		delete(manager.xuidMap, session.GetXUID())
+		// Sessions kicked because of a name conflict may already have been replaced by the new login.
+		if manager.nameMap[session.GetPlayer().GetName()] == session {
+			delete(manager.nameMap, session.GetPlayer().GetName())
+		}
+		if manager.lowerMap[strings.ToLower(session.GetPlayer().GetName())] == session {
+			delete(manager.lowerMap, strings.ToLower(session.GetPlayer().GetName()))
+		}
+		if manager.uuidMap[session.GetUUID()] == session {
+			delete(manager.uuidMap, session.GetUUID())
+		}
+		if manager.xuidMap[session.GetXUID()] == session {
+			delete(manager.xuidMap, session.GetXUID())
+		}
 		delete(manager.sessionMap, fmt.Sprint(session.GetSession()))
 		manager.mutex.Unlock()
 	}
@@ -71,6 +109,46 @@ func (manager *SessionManager) GetSession(name string) (*MinecraftSession, bool)
 	return session, ok
 }
 
+// GetSessionIgnoreCase attempts to retrieve a session by its name, ignoring the case of the name.
+// A bool is returned indicating success.
+func (manager *SessionManager) GetSessionIgnoreCase(name string) (*MinecraftSession, bool) {
+	manager.mutex.RLock()
+	var session, ok = manager.lowerMap[strings.ToLower(name)]
+	manager.mutex.RUnlock()
+	return session, ok
+}
+
+// GetSessionByPrefix attempts to retrieve a session by a partial name, ignoring case.
+// A session with exactly the given name is preferred. Otherwise the session
+// with the shortest name starting with the prefix is returned, unless
+// multiple names of that length match. A bool is returned indicating success.
+func (manager *SessionManager) GetSessionByPrefix(prefix string) (*MinecraftSession, bool) {
+	prefix = strings.ToLower(prefix)
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+
+	if session, ok := manager.lowerMap[prefix]; ok {
+		return session, true
+	}
+	var match *MinecraftSession
+	var matchLength = -1
+	var ambiguous = false
+	for name, session := range manager.lowerMap {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if matchLength == -1 || len(name) < matchLength {
+			match, matchLength, ambiguous = session, len(name), false
+		} else if len(name) == matchLength {
+			ambiguous = true
+		}
+	}
+	if match == nil || ambiguous {
+		return nil, false
+	}
+	return match, true
+}
+
 // HasSessionWithRakNetSession checks if the session manager has a session with the given RakNet session.
 func (manager *SessionManager) HasSessionWithRakNetSession(rakNetSession *server.Session) bool {
 	manager.mutex.RLock()
@@ -1,26 +1,40 @@
 package net
 
 import (
-	"bytes"
 	"compress/zlib"
-	"crypto/cipher"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
-	"io/ioutil"
+	"sync"
 
 	"github.com/irmine/binutils"
+	"github.com/BobbyShrd/gominetest/net/compression"
 	"github.com/BobbyShrd/gominetest/net/packets"
 	"github.com/BobbyShrd/gominetest/text"
 )
 
 const McpeFlag = 0xFE
 
+var TruncatedBatch = errors.New("batch holds a packet longer than the remaining data")
+
+// maxPooledStream is the maximum capacity of packet streams put back in the pool.
+const maxPooledStream = 1 << 20
+
+// streams holds the streams the packets of batches are encoded into before they get compressed.
+var streams = sync.Pool{New: func() interface{} {
+	var stream = binutils.NewStream()
+	stream.Buffer = make([]byte, 0, 4096)
+	return stream
+}}
+
 type MinecraftPacketBatch struct {
 	*binutils.Stream
 	raw             []byte
 	packets         []packets.IPacket
 	session         *MinecraftSession
 	needsEncryption bool
+	payloads        [][]byte
+	uncompressed    bool
 }
 
 // NewMinecraftPacketBatch returns a new Minecraft Packet Batch used to decode/encode batches from Encapsulated Packets.
@@ -46,47 +60,88 @@ func (batch *MinecraftPacketBatch) Decode() {
 		}
 	}()
 
-	var mcpeFlag = batch.GetByte()
-	if mcpeFlag != McpeFlag {
+	var packetData, err = batch.decodePayloads()
+	if err != nil {
+		text.DefaultLogger.LogError(err)
 		return
 	}
+	batch.fetchPackets(packetData)
+}
+
+// decodePayloads decrypts and decompresses the batch, and returns the payloads of the packets in it.
+// No payloads are returned if the batch is not a Minecraft batch, or if it was a network settings request.
+func (batch *MinecraftPacketBatch) decodePayloads() ([][]byte, error) {
+	if len(batch.Buffer) <= batch.Offset || batch.GetByte() != McpeFlag {
+		return nil, nil
+	}
 	batch.raw = batch.Buffer[batch.Offset:]
 
 	if batch.needsEncryption {
-		batch.decrypt()
+		if err := batch.decrypt(); err != nil {
+			return nil, err
+		}
 	}
-	var err = batch.decompress()
-	if err != nil {
-		text.DefaultLogger.LogError(err)
-		return
+	if batch.session != nil && batch.session.handleNetworkSettingsRequest(batch.raw) {
+		return nil, nil
+	}
+	if err := batch.decompress(); err != nil {
+		return nil, err
 	}
 
 	batch.ResetStream()
 	batch.SetBuffer(batch.raw)
+	return splitPayloads(batch.raw)
+}
 
-	var packetData [][]byte
-
-	for !batch.Feof() {
-		packetData = append(packetData, batch.GetLengthPrefixedBytes())
+// splitPayloads splits decompressed batch data into the length prefixed payloads of its packets.
+// The payloads found before the data turned out to be truncated are returned along with TruncatedBatch.
+func splitPayloads(data []byte) ([][]byte, error) {
+	var payloads [][]byte
+	for len(data) > 0 {
+		var length, n = binary.Uvarint(data)
+		if n <= 0 || length > uint64(len(data)-n) {
+			return payloads, TruncatedBatch
+		}
+		data = data[n:]
+		payloads = append(payloads, data[:length:length])
+		data = data[length:]
 	}
-
-	batch.fetchPackets(packetData)
+	return payloads, nil
 }
 
-// Encode encodes all packets in the batch and zlib encodes them.
+// Encode encodes all packets in the batch and compresses them with the compression of the session.
+// Packets are encoded into a pooled stream, and the buffer of the batch is allocated once with the final size.
 func (batch *MinecraftPacketBatch) Encode() {
-	batch.ResetStream()
-	batch.PutByte(McpeFlag)
-
-	var stream = binutils.NewStream()
+	var stream = streams.Get().(*binutils.Stream)
+	stream.Offset, stream.Buffer = 0, stream.Buffer[:0]
+	defer func() {
+		if cap(stream.Buffer) <= maxPooledStream {
+			streams.Put(stream)
+		}
+	}()
 	batch.putPackets(stream)
 
-	var zlibData = batch.compress(stream)
-	var data = zlibData
+	var compressed = stream.Buffer
+	if !batch.uncompressed {
+		compressed = batch.compress(stream)
+	}
+	if batch.session != nil {
+		var adapter = batch.session.adapter
+		if adapter.PacketsSentFunction != nil {
+			adapter.PacketsSentFunction(len(batch.packets) + len(batch.payloads))
+		}
+		if adapter.BatchCompressedFunction != nil {
+			adapter.BatchCompressedFunction(len(stream.Buffer), len(compressed))
+		}
+	}
+	var data = compressed
 	if batch.needsEncryption {
 		data = batch.encrypt(data)
 	}
 
+	batch.ResetStream()
+	batch.Buffer = make([]byte, 0, len(data)+1)
+	batch.PutByte(McpeFlag)
 	batch.PutBytes(data)
 }
 
@@ -125,28 +180,18 @@ func (batch *MinecraftPacketBatch) peekProtocol(packetData []byte) int32 {
 	return protocol
 }
 
-// encrypt encrypts the data passed to the function.
+// encrypt appends the checksum to the data passed to the function and encrypts it
+// with the encryption mode of the session.
 func (batch *MinecraftPacketBatch) encrypt(d []byte) []byte {
-	var data = batch.session.GetEncryptionHandler().Data
-	d = append(d, batch.session.GetEncryptionHandler().ComputeSendChecksum(d)...)
-
-	for i := range d {
-		var cfb = cipher.NewCFBEncrypter(data.EncryptCipher, data.EncryptIV)
-		cfb.XORKeyStream(d[i:i+1], d[i:i+1])
-		data.EncryptIV = append(data.EncryptIV[1:], d[i])
-	}
-
-	return d
+	return batch.session.GetEncryptionHandler().Encrypt(d)
 }
 
-// decrypt decrypts the buffer of the packet.
-func (batch *MinecraftPacketBatch) decrypt() {
-	var data = batch.session.GetEncryptionHandler().Data
-	for i, b := range batch.raw {
-		var cfb = cipher.NewCFBDecrypter(data.DecryptCipher, data.DecryptIV)
-		cfb.XORKeyStream(batch.raw[i:i+1], batch.raw[i:i+1])
-		data.DecryptIV = append(data.DecryptIV[1:], b)
-	}
+// decrypt decrypts the buffer of the packet and strips its checksum.
+// An error is returned if the checksum does not match.
+func (batch *MinecraftPacketBatch) decrypt() error {
+	var payload, err = batch.session.GetEncryptionHandler().Decrypt(batch.raw)
+	batch.raw = payload
+	return err
 }
 
 // putPackets puts all packets of the batch inside of the stream.
@@ -156,35 +201,66 @@ func (batch *MinecraftPacketBatch) putPackets(stream *binutils.Stream) {
 		packet.Encode()
 		stream.PutLengthPrefixedBytes(packet.GetBuffer())
 	}
+	for _, payload := range batch.payloads {
+		stream.PutLengthPrefixedBytes(payload)
+	}
 }
 
-// compress zlib compresses the data in the stream and returns it.
+// compress compresses the data in the stream with the compression of the session and returns it.
+// Batches smaller than the compression threshold are not compressed. Sessions that did not negotiate
+// compression always get zlib, in which case small batches are stored in zlib without compression.
 func (batch *MinecraftPacketBatch) compress(stream *binutils.Stream) []byte {
-	var buff = bytes.Buffer{}
-	var writer = zlib.NewWriter(&buff)
-	writer.Write(stream.Buffer)
-	writer.Close()
+	var settings = compression.DefaultSettings()
+	if batch.session != nil {
+		settings = batch.session.adapter.Compression
+	}
+	var data = stream.Buffer
+	if batch.session == nil || !batch.session.compressionNegotiated {
+		var level = settings.Level
+		if len(data) < settings.Threshold {
+			level = zlib.NoCompression
+		}
+		var compressed, err = compression.CompressZlib(data, level)
+		text.DefaultLogger.LogError(err)
+		return compressed
+	}
 
-	return buff.Bytes()
+	var algorithm = batch.session.compressionAlgorithm
+	if batch.session.compressionPrefixed && len(data) < settings.Threshold {
+		algorithm = compression.None
+	}
+	var compressed, err = compression.Compress(algorithm, data, settings.Level)
+	text.DefaultLogger.LogError(err)
+	if batch.session.compressionPrefixed {
+		return append([]byte{algorithm.GetPrefix()}, compressed...)
+	}
+	return compressed
 }
 
-// decompress decompresses the zlib compressed buffer.
+// decompress decompresses the compressed buffer with the compression of the session.
+// Batches of sessions using compression prefixes are decompressed with the algorithm of their prefix.
 func (batch *MinecraftPacketBatch) decompress() error {
-	var reader = bytes.NewReader(batch.raw)
-	zlibReader, err := zlib.NewReader(reader)
-	text.DefaultLogger.LogError(err)
+	var data, err = batch.raw, error(nil)
+	if batch.session == nil || !batch.session.compressionNegotiated {
+		batch.raw, err = compression.DecompressZlib(data)
+	} else {
+		var algorithm = batch.session.compressionAlgorithm
+		if batch.session.compressionPrefixed {
+			if len(data) == 0 {
+				return errors.New("batch is missing its compression prefix")
+			}
+			if algorithm, err = compression.FromPrefix(data[0]); err != nil {
+				return err
+			}
+			data = data[1:]
+		}
+		batch.raw, err = compression.Decompress(algorithm, data)
+	}
 
 	if err != nil {
-		text.DefaultLogger.Debug(hex.EncodeToString(batch.raw))
-		return err
-	}
-	if zlibReader == nil {
-		return errors.New("an error occurred when decompressing zlib")
+		text.DefaultLogger.LogError(err)
+		text.DefaultLogger.Debug(hex.EncodeToString(data))
 	}
-	zlibReader.Close()
-
-	batch.raw, err = ioutil.ReadAll(zlibReader)
-
 	return err
 }
 
@@ -0,0 +1,236 @@
+package net
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BobbyShrd/gominetest/net/compression"
+	"github.com/BobbyShrd/gominetest/utils"
+)
+
+// batchVector is a batch in the format sent by a client with the given settings, along with the payloads of the packets in it.
+// The vectors in testdata/batches were encoded by this package, not captured from real clients, so they only catch
+// changes to the format. They are stored as JSON, so captures from real clients can be added without code changes.
+type batchVector struct {
+	Description string   `json:"description"`
+	Protocol    int32    `json:"protocol"`
+	Negotiated  bool     `json:"negotiated"`
+	Algorithm   string   `json:"algorithm"`
+	Encrypted   bool     `json:"encrypted"`
+	Batch       string   `json:"batch"`
+	Packets     []string `json:"packets"`
+}
+
+// vectorSettings are the compression settings the vectors were encoded with.
+var vectorSettings = compression.Settings{Algorithm: compression.Flate, Level: zlib.DefaultCompression, Threshold: 256}
+
+// vectorKey returns the shared secret encrypted vectors were encrypted with.
+// Encrypted vectors are the first batch encrypted with the key, so their checksum counter is 0.
+func vectorKey() [32]byte {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i * 7)
+	}
+	return key
+}
+
+// loadVectors loads all vectors in testdata/batches, indexed by their file name.
+func loadVectors(tb testing.TB) map[string]batchVector {
+	var files, err = filepath.Glob(filepath.Join("testdata", "batches", "*.json"))
+	if err != nil || len(files) == 0 {
+		tb.Fatalf("no batch vectors found: %v", err)
+	}
+	var vectors = make(map[string]batchVector)
+	for _, file := range files {
+		var data, err = ioutil.ReadFile(file)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		var vector batchVector
+		if err := json.Unmarshal(data, &vector); err != nil {
+			tb.Fatalf("%v: %v", file, err)
+		}
+		vectors[strings.TrimSuffix(filepath.Base(file), ".json")] = vector
+	}
+	return vectors
+}
+
+// newVectorSession returns a session with the protocol, compression and encryption of the vector.
+func newVectorSession(vector batchVector) *MinecraftSession {
+	var session = &MinecraftSession{adapter: &NetworkAdapter{Compression: vectorSettings}, encryptionHandler: utils.NewEncryptionHandler(), protocolNumber: vector.Protocol}
+	if vector.Negotiated {
+		session.compressionNegotiated = true
+		session.compressionAlgorithm, _ = compression.FromName(vector.Algorithm)
+		session.compressionPrefixed = vector.Protocol >= compression.PrefixProtocol
+	}
+	if vector.Encrypted {
+		var data = session.encryptionHandler.Data
+		data.EncryptSecretKeyBytes, data.DecryptSecretKeyBytes = vectorKey(), vectorKey()
+		data.StartStreams(utils.EncryptionModeFor(vector.Protocol))
+		session.usesEncryption = true
+	}
+	return session
+}
+
+// decodeHex decodes the hexadecimal strings of a vector.
+func decodeHex(tb testing.TB, values ...string) [][]byte {
+	var decoded = make([][]byte, len(values))
+	for i, value := range values {
+		var err error
+		if decoded[i], err = hex.DecodeString(value); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return decoded
+}
+
+// decodeBatch decodes the batch data with the session and returns the payloads of its packets.
+func decodeBatch(session *MinecraftSession, data []byte) ([][]byte, error) {
+	var batch = NewMinecraftPacketBatch(session)
+	batch.SetBuffer(data)
+	return batch.decodePayloads()
+}
+
+// encodeBatch encodes the payloads into a batch with the session and returns the batch data.
+func encodeBatch(session *MinecraftSession, payloads [][]byte) []byte {
+	var batch = NewMinecraftPacketBatch(session)
+	batch.payloads = payloads
+	batch.Encode()
+	return batch.Buffer
+}
+
+func equalPayloads(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDecodeVectors(t *testing.T) {
+	for name, vector := range loadVectors(t) {
+		var payloads, err = decodeBatch(newVectorSession(vector), decodeHex(t, vector.Batch)[0])
+		if err != nil {
+			t.Errorf("%v: %v", name, err)
+			continue
+		}
+		if expected := decodeHex(t, vector.Packets...); !equalPayloads(payloads, expected) {
+			t.Errorf("%v: expected packets %x, got %x", name, expected, payloads)
+		}
+	}
+}
+
+// TestEncodeVectors checks that the packets of every vector survive encoding and decoding with its settings.
+// The encoded bytes are not compared with the vector, as compressors may compress the same data differently,
+// apart from the header and the compression prefix, which show if the batch was compressed.
+func TestEncodeVectors(t *testing.T) {
+	for name, vector := range loadVectors(t) {
+		var packets = decodeHex(t, vector.Packets...)
+		var encoded = encodeBatch(newVectorSession(vector), packets)
+		var header = 1
+		if vector.Negotiated && vector.Protocol >= compression.PrefixProtocol && !vector.Encrypted {
+			header = 2
+		}
+		if expected := decodeHex(t, vector.Batch)[0]; len(encoded) < header || !bytes.Equal(encoded[:header], expected[:header]) {
+			t.Errorf("%v: expected batch header %x, got %x", name, expected[:header], encoded)
+		}
+		if payloads, err := decodeBatch(newVectorSession(vector), encoded); err != nil || !equalPayloads(payloads, packets) {
+			t.Errorf("%v: expected packets %x, got %x with error %v", name, packets, payloads, err)
+		}
+	}
+}
+
+func TestDecodeTamperedVector(t *testing.T) {
+	for name, vector := range loadVectors(t) {
+		if !vector.Encrypted {
+			continue
+		}
+		var data = decodeHex(t, vector.Batch)[0]
+		data[len(data)/2] ^= 0x01
+		if _, err := decodeBatch(newVectorSession(vector), data); err != utils.InvalidChecksum {
+			t.Errorf("%v: expected InvalidChecksum for a tampered batch, got %v", name, err)
+		}
+	}
+}
+
+func TestSplitPayloads(t *testing.T) {
+	var payloads, err = splitPayloads([]byte{0x02, 0x45, 0x10, 0x00, 0x01, 0x71})
+	if err != nil || !equalPayloads(payloads, [][]byte{{0x45, 0x10}, {}, {0x71}}) {
+		t.Errorf("unexpected payloads %x with error %v", payloads, err)
+	}
+	payloads, err = splitPayloads([]byte{0x02, 0x45, 0x10, 0x05, 0x71})
+	if err != TruncatedBatch || len(payloads) != 1 {
+		t.Errorf("expected TruncatedBatch after one payload, got %x with error %v", payloads, err)
+	}
+}
+
+// FuzzSplitPayloads checks that splitting arbitrary data never panics,
+// and that the payloads of valid data frame back into the same data.
+func FuzzSplitPayloads(f *testing.F) {
+	for _, vector := range loadVectors(f) {
+		var data []byte
+		for _, payload := range decodeHex(f, vector.Packets...) {
+			data = binary.AppendUvarint(data, uint64(len(payload)))
+			data = append(data, payload...)
+		}
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var payloads, err = splitPayloads(data)
+		if err != nil {
+			return
+		}
+		var framed []byte
+		for _, payload := range payloads {
+			framed = binary.AppendUvarint(framed, uint64(len(payload)))
+			framed = append(framed, payload...)
+		}
+		// Lengths may be encoded with redundant bytes, so only the payloads are compared.
+		if again, err := splitPayloads(framed); err != nil || !equalPayloads(payloads, again) {
+			t.Errorf("payloads %x changed after framing them again", payloads)
+		}
+	})
+}
+
+// FuzzDecode checks that decoding arbitrary batches with the settings of every vector never panics.
+func FuzzDecode(f *testing.F) {
+	var vectors = loadVectors(f)
+	for _, vector := range vectors {
+		f.Add(decodeHex(f, vector.Batch)[0])
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, vector := range vectors {
+			decodeBatch(newVectorSession(vector), append([]byte(nil), data...))
+		}
+	})
+}
+
+// FuzzRoundTrip checks that arbitrary packets survive encoding and decoding with the settings of every vector.
+func FuzzRoundTrip(f *testing.F) {
+	var vectors = loadVectors(f)
+	for _, vector := range vectors {
+		for _, packet := range decodeHex(f, vector.Packets...) {
+			f.Add(packet, packet)
+		}
+	}
+	f.Fuzz(func(t *testing.T, first []byte, second []byte) {
+		var payloads = [][]byte{first, second}
+		for name, vector := range vectors {
+			var decoded, err = decodeBatch(newVectorSession(vector), encodeBatch(newVectorSession(vector), payloads))
+			if err != nil || !equalPayloads(decoded, payloads) {
+				t.Errorf("%v: expected packets %x, got %x with error %v", name, payloads, decoded, err)
+			}
+		}
+	})
+}
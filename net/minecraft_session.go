@@ -2,9 +2,14 @@ package net
 
 import (
 	"fmt"
+	"github.com/golang/geo/r3"
 	"github.com/google/uuid"
+	"github.com/BobbyShrd/gominetest/effects"
+	"github.com/BobbyShrd/gominetest/net/compression"
 	"github.com/BobbyShrd/gominetest/net/packets"
+	"github.com/BobbyShrd/gominetest/net/packets/data"
 	"github.com/BobbyShrd/gominetest/net/packets/types"
+	protocol2 "github.com/BobbyShrd/gominetest/net/protocol"
 	"github.com/BobbyShrd/gominetest/permissions"
 	"github.com/BobbyShrd/gominetest/players"
 	"github.com/BobbyShrd/gominetest/text"
@@ -14,8 +19,12 @@ import (
 	"github.com/irmine/worlds"
 	"github.com/irmine/worlds/blocks"
 	"github.com/irmine/worlds/chunks"
+	"github.com/irmine/worlds/entities"
 	"math"
+	"net"
 	"strings"
+	"sync"
+	"time"
 )
 
 type MinecraftSession struct {
@@ -32,6 +41,9 @@ type MinecraftSession struct {
 	minecraftVersion string
 
 	language string
+	country  string
+
+	forwardedAddress *net.UDPAddr
 
 	clientPlatform int32
 
@@ -39,24 +51,39 @@ type MinecraftSession struct {
 	usesEncryption        bool
 	xboxLiveAuthenticated bool
 
+	compressionAlgorithm  compression.Algorithm
+	compressionNegotiated bool
+	compressionPrefixed   bool
+
 	viewDistance int32
 	chunkLoader  *worlds.Loader
 
-	permissions     map[string]*permissions.Permission
-	permissionGroup *permissions.Group
+	permissions       map[string]*permissions.Permission
+	permissionGroup   *permissions.Group
+	permissionManager *permissions.Manager
+	opLevel           int
+
+	activityMutex    sync.Mutex
+	lastActivity     time.Time
+	latency          time.Duration
+	latencyMeasured  bool
+	latencyTimestamp uint64
+
+	join *joinTracker
 
 	Connected         bool
 }
 
 // NewMinecraftSession returns a new Minecraft session with the given RakNet session.
 func NewMinecraftSession(adapter *NetworkAdapter, session *server.Session) *MinecraftSession {
-	return &MinecraftSession{adapter, session, nil, uuid.New(), "", 0, 0, "", "", 0, utils.NewEncryptionHandler(), false, false, 0, nil, nil, nil, false}
+	return &MinecraftSession{adapter, session, nil, uuid.New(), "", 0, 0, "", "", "", nil, 0, utils.NewEncryptionHandler(), false, false, compression.Flate, false, false, 0, nil, nil, nil, nil, 0, sync.Mutex{}, time.Now(), 0, false, 0, newJoinTracker(), false}
 }
 
 // SetData sets the basic session data of the Minecraft Session
 func (session *MinecraftSession) SetData(permissionManager *permissions.Manager, data types.SessionData) {
 	session.permissions = make(map[string]*permissions.Permission)
 	session.permissionGroup = permissionManager.GetDefaultGroup()
+	session.permissionManager = permissionManager
 
 	session.uuid = data.ClientUUID
 	session.xuid = data.ClientXUID
@@ -75,10 +102,16 @@ func (session *MinecraftSession) SetData(permissionManager *permissions.Manager,
 		session.SendFullChunkData(chunk)
 		chunk.AddViewer(session)
 		chunk.AddEntity(session.player)
+		if session.adapter.ChunkLoadedFunction != nil {
+			session.adapter.ChunkLoadedFunction(session, chunk)
+		}
 	}
 	session.chunkLoader.UnloadFunction = func(chunk *chunks.Chunk) {
 		chunk.RemoveViewer(session)
 		chunk.RemoveEntity(session.player.GetRuntimeId())
+		if session.adapter.ChunkUnloadedFunction != nil {
+			session.adapter.ChunkUnloadedFunction(session, chunk)
+		}
 	}
 }
 
@@ -92,6 +125,17 @@ func (session *MinecraftSession) GetPlayer() *players.Player {
 // Network actions will be executed on this player.
 func (session *MinecraftSession) SetPlayer(player *players.Player) {
 	session.player = player
+	player.EffectExpiredFunction = func(player *players.Player, effect *effects.Effect) {
+		session.SendMobEffect(player.GetRuntimeId(), effects.MobEffectRemove, effect.Type.Id, 0, false, 0)
+	}
+	player.AttributesChangedFunction = func(player *players.Player) {
+		session.SendUpdateAttributes(player.GetRuntimeId(), player.GetAttributeMap())
+	}
+	player.SpawnedFunction = func(player *players.Player, viewer entities.Viewer) {
+		if viewer, ok := viewer.(*MinecraftSession); ok && session.adapter.PlayerSpawnedFunction != nil {
+			session.adapter.PlayerSpawnedFunction(session, viewer)
+		}
+	}
 }
 
 // GetName returns the name of the player under the session.
@@ -151,10 +195,66 @@ func (session *MinecraftSession) GetSession() *server.Session {
 }
 
 // GetPing returns the ping of the session in milliseconds.
+// The latency measured with MeasureLatency is returned once measured, which includes the time the client takes to process packets.
+// The RakNet ping is returned until then.
 func (session *MinecraftSession) GetPing() int64 {
+	session.activityMutex.Lock()
+	defer session.activityMutex.Unlock()
+	if session.latencyMeasured {
+		return session.latency.Nanoseconds() / int64(time.Millisecond)
+	}
 	return session.session.CurrentPing
 }
 
+// GetLatency returns the latency last measured with MeasureLatency, and a bool indicating if it was measured yet.
+func (session *MinecraftSession) GetLatency() (time.Duration, bool) {
+	session.activityMutex.Lock()
+	defer session.activityMutex.Unlock()
+	return session.latency, session.latencyMeasured
+}
+
+// MeasureLatency sends a latency request, which the client answers with the same timestamp.
+// Nothing is sent if the previous request was not answered yet, unless it was sent longer than the timeout ago,
+// in which case the previous request expires and answers to it are ignored.
+func (session *MinecraftSession) MeasureLatency(timeout time.Duration) {
+	session.activityMutex.Lock()
+	var timestamp = uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	if session.latencyTimestamp != 0 && time.Duration(timestamp-session.latencyTimestamp)*time.Millisecond < timeout {
+		session.activityMutex.Unlock()
+		return
+	}
+	session.latencyTimestamp = timestamp
+	session.activityMutex.Unlock()
+
+	session.SendNetworkStackLatency(timestamp, true)
+}
+
+// HandleLatencyResponse updates the latency of the session with the answer to a latency request.
+// Returns false if the timestamp does not belong to the pending request.
+func (session *MinecraftSession) HandleLatencyResponse(timestamp uint64) bool {
+	session.activityMutex.Lock()
+	defer session.activityMutex.Unlock()
+	if session.latencyTimestamp == 0 || timestamp != session.latencyTimestamp {
+		return false
+	}
+	session.latency = time.Duration(uint64(time.Now().UnixNano()/int64(time.Millisecond))-timestamp) * time.Millisecond
+	session.latencyMeasured = true
+	session.latencyTimestamp = 0
+	return true
+}
+
+// GetLastActivity returns the time the session last sent a packet.
+func (session *MinecraftSession) GetLastActivity() time.Time {
+	session.activityMutex.Lock()
+	defer session.activityMutex.Unlock()
+	return session.lastActivity
+}
+
+// IsIdle checks if the session did not send any packet for the duration of the timeout.
+func (session *MinecraftSession) IsIdle(timeout time.Duration) bool {
+	return time.Since(session.GetLastActivity()) > timeout
+}
+
 // GetUUID returns the UUID of this session.
 func (session *MinecraftSession) GetUUID() uuid.UUID {
 	return session.uuid
@@ -175,6 +275,47 @@ func (session *MinecraftSession) GetLanguage() string {
 	return session.language
 }
 
+// SetCountry sets the ISO code of the country the client of this session connects from.
+func (session *MinecraftSession) SetCountry(country string) {
+	session.country = country
+}
+
+// GetCountry returns the ISO code of the country the client of this session connects from.
+// The country is only known if GeoIP lookups are enabled, and is empty otherwise.
+func (session *MinecraftSession) GetCountry() string {
+	return session.country
+}
+
+// GetAddress returns the address of the client of this session.
+// The address forwarded by a trusted proxy is returned if the client connects through one.
+func (session *MinecraftSession) GetAddress() *net.UDPAddr {
+	if session.forwardedAddress != nil {
+		return session.forwardedAddress
+	}
+	return session.session.GetAddress()
+}
+
+// GetConnectionAddress returns the address the session is connected from,
+// which is the address of the proxy if the client connects through one.
+func (session *MinecraftSession) GetConnectionAddress() *net.UDPAddr {
+	return session.session.GetAddress()
+}
+
+// SetForwardedAddress sets the address of the client forwarded by the proxy the session is connected from.
+func (session *MinecraftSession) SetForwardedAddress(address *net.UDPAddr) {
+	session.forwardedAddress = address
+}
+
+// IsForwarded checks if the address of the client was forwarded by a proxy.
+func (session *MinecraftSession) IsForwarded() bool {
+	return session.forwardedAddress != nil
+}
+
+// GetLocale returns the locale used to format numbers, durations and dates for this session.
+func (session *MinecraftSession) GetLocale() text.Locale {
+	return text.GetLocale(session.language)
+}
+
 // GetClientId returns the client ID of this session.
 func (session *MinecraftSession) GetClientId() int {
 	return session.clientId
@@ -191,10 +332,15 @@ func (session *MinecraftSession) UsesEncryption() bool {
 }
 
 // EnableEncryption enables encryption for this session and computes secret key bytes.
+// The secret key bytes are not computed again if the shared secret was already derived.
+// Batches are encrypted in counter mode or in the legacy cipher feedback mode, depending on the protocol of the client.
 func (session *MinecraftSession) EnableEncryption() {
 	session.usesEncryption = true
-	session.encryptionHandler.Data.ComputeSharedSecret()
-	session.encryptionHandler.Data.ComputeSecretKeyBytes()
+	if session.encryptionHandler.Data.SharedSecret == nil {
+		session.encryptionHandler.Data.ComputeSharedSecret()
+		session.encryptionHandler.Data.ComputeSecretKeyBytes()
+	}
+	session.encryptionHandler.Data.StartStreams(utils.EncryptionModeFor(session.protocolNumber))
 }
 
 // IsXBOXLiveAuthenticated checks if the session logged in while being logged into XBOX Live.
@@ -208,10 +354,62 @@ func (session *MinecraftSession) SetXBOXLiveAuthenticated(value bool) {
 }
 
 // SendMessage sends a text message to the Minecraft session.
+// A single text component is sent using SendComponent, so that its translation keys get translated.
 func (session *MinecraftSession) SendMessage(message ...interface{}) {
+	if len(message) == 1 {
+		if component, ok := message[0].(*text.Component); ok {
+			session.SendComponent(component)
+			return
+		}
+	}
 	session.SendText(types.Text{Message: strings.Trim(fmt.Sprint(message), "[]")})
 }
 
+// SendJSON sends a rawtext JSON message to the Minecraft session, such as {"rawtext":[{"text":"Hello"}]}.
+// Clients that do not support rawtext JSON messages get the message as plain text.
+func (session *MinecraftSession) SendJSON(json string) {
+	if !session.SupportsRawText() {
+		session.SendText(types.Text{Message: json})
+		return
+	}
+	session.SendText(types.Text{Message: json, TextType: data.TextJson})
+}
+
+// SendWhisper sends a whispered message from the source to the Minecraft session.
+// The XUID of the source may be left empty if the source is no player.
+func (session *MinecraftSession) SendWhisper(source string, sourceXUID string, message string) {
+	session.SendText(types.Text{Message: message, TextType: data.TextWhisper, SourceName: source, SourceXUID: sourceXUID})
+}
+
+// SendAnnouncement sends an announcement from the source to the Minecraft session.
+// The XUID of the source may be left empty if the source is no player.
+func (session *MinecraftSession) SendAnnouncement(source string, sourceXUID string, message string) {
+	session.SendText(types.Text{Message: message, TextType: data.TextAnnouncement, SourceName: source, SourceXUID: sourceXUID})
+}
+
+// MinimumRawTextProtocol is the first protocol supporting rawtext JSON messages.
+const MinimumRawTextProtocol int32 = 332
+
+// SupportsRawText checks if the client of the session supports rawtext JSON messages.
+func (session *MinecraftSession) SupportsRawText() bool {
+	return session.protocolNumber >= MinimumRawTextProtocol
+}
+
+// SendComponent sends a text component to the Minecraft session.
+// Components are sent as rawtext JSON if the client supports it,
+// and as legacy translation with the translation parameters of the component otherwise.
+func (session *MinecraftSession) SendComponent(component *text.Component) {
+	if session.SupportsRawText() {
+		session.SendText(types.Text{Message: component.RawText(), TextType: data.TextJson})
+		return
+	}
+	if component.IsTranslated() {
+		session.SendText(types.Text{Message: component.String(), TextType: data.TextTranslation, IsTranslation: true, TranslationParameters: component.GetTranslationParameters()})
+		return
+	}
+	session.SendText(types.Text{Message: component.String()})
+}
+
 // GetPermissionGroup returns the permission group this session is in.
 func (session *MinecraftSession) GetPermissionGroup() *permissions.Group {
 	return session.permissionGroup
@@ -222,9 +420,24 @@ func (session *MinecraftSession) SetPermissionGroup(group *permissions.Group) {
 	session.permissionGroup = group
 }
 
+// GetOpLevel returns the op level of this session, which is 0 if the session is not an operator.
+func (session *MinecraftSession) GetOpLevel() int {
+	return session.opLevel
+}
+
+// SetOpLevel sets the op level of this session.
+func (session *MinecraftSession) SetOpLevel(level int) {
+	session.opLevel = level
+}
+
 // HasPermission checks if this session has a permission.
+// Permissions are granted by the group of the session, by the session itself,
+// or by an op level at least as high as the level required for the permission.
 func (session *MinecraftSession) HasPermission(permission string) bool {
-	if session.GetPermissionGroup().HasPermission(permission) {
+	if group := session.GetPermissionGroup(); group != nil && group.HasPermission(permission) {
+		return true
+	}
+	if session.permissionManager != nil && session.opLevel >= session.permissionManager.GetRequiredLevel(permission) {
 		return true
 	}
 	var _, exists = session.permissions[permission]
@@ -257,6 +470,24 @@ func (session *MinecraftSession) SendSkin(target *MinecraftSession) {
 	target.SendPlayerSkin(player.GetUUID(), player.GetSkinId(), player.GetGeometryName(), player.GetGeometryData(), player.GetSkinData(), player.GetCapeData())
 }
 
+// SendPlayerListChanges removes the removed entries from the player list of the session,
+// and adds the added entries in the order of the names, in a single batch.
+// Every added entry gets its own packet, as the client lists entries in the order they were added.
+func (session *MinecraftSession) SendPlayerListChanges(removed map[string]protocol2.PlayerListEntry, names []string, added map[string]protocol2.PlayerListEntry) {
+	var batch = NewMinecraftPacketBatch(session)
+	if len(removed) != 0 {
+		batch.AddPacket(session.adapter.packetManager.GetPlayerList(data.ListTypeRemove, removed))
+	}
+	for _, name := range names {
+		if entry, ok := added[name]; ok {
+			batch.AddPacket(session.adapter.packetManager.GetPlayerList(data.ListTypeAdd, map[string]protocol2.PlayerListEntry{name: entry}))
+		}
+	}
+	if len(batch.GetPackets()) != 0 {
+		session.SendBatch(batch)
+	}
+}
+
 // SendPacket sends a packet to this session.
 func (session *MinecraftSession) SendPacket(packet packets.IPacket) {
 	if session.session == nil {
@@ -278,6 +509,10 @@ func (session *MinecraftSession) SendBatch(batch *MinecraftPacketBatch) {
 
 // HandlePacket handles packets of this session.
 func (session *MinecraftSession) HandlePacket(packet packets.IPacket) {
+	session.activityMutex.Lock()
+	session.lastActivity = time.Now()
+	session.activityMutex.Unlock()
+
 	priorityHandlers := session.adapter.packetManager.GetHandlersById(packet.GetId())
 
 	var handled = false
@@ -336,6 +571,74 @@ func (session *MinecraftSession) SyncMove(x, y, z float64, pitch, yaw, headYaw f
 	session.player.SyncMove(x, y, z, pitch, yaw, headYaw, onGround)
 }
 
+// MoveTeleport is the mode of move player packets teleporting the player.
+const MoveTeleport byte = 2
+
+// Teleport teleports the player of the session to the given position in its current dimension.
+func (session *MinecraftSession) Teleport(position r3.Vector) {
+	var player = session.GetPlayer()
+	player.SyncMove(position.X, position.Y, position.Z, player.Rotation.Pitch, player.Rotation.Yaw, player.Rotation.HeadYaw, false)
+	session.SendMovePlayer(player.GetRuntimeId(), player.Position, player.Rotation, MoveTeleport, false, player.GetRidingId())
+}
+
+// AddEffect adds the effect to the player of the session, and sends it to the client.
+// Instant effects change the health of the player right away instead.
+// Returns false if the effect was not added, because a stronger effect of the same type is applied.
+func (session *MinecraftSession) AddEffect(effect *effects.Effect) bool {
+	var player = session.GetPlayer()
+	if effect.Type.IsInstant() {
+		var health = player.GetHealth() + effect.GetInstantHealth()
+		if health > players.MaximumHealth {
+			health = players.MaximumHealth
+		}
+		if health < 1 {
+			health = 1
+		}
+		player.SetHealth(health)
+		session.SendUpdateAttributes(player.GetRuntimeId(), player.GetAttributeMap())
+		return true
+	}
+
+	var eventId = effects.MobEffectAdd
+	if player.GetEffects().Has(effect.Type.Id) {
+		eventId = effects.MobEffectModify
+	}
+	if !player.GetEffects().Add(effect) {
+		return false
+	}
+	session.SendMobEffect(player.GetRuntimeId(), eventId, effect.Type.Id, effect.Amplifier, effect.Particles, effect.Duration)
+	session.updateMovementSpeed()
+	return true
+}
+
+// RemoveEffect removes the effect with the given ID from the player of the session.
+// Returns false if the player did not have the effect.
+func (session *MinecraftSession) RemoveEffect(id int32) bool {
+	if _, ok := session.GetPlayer().GetEffects().Remove(id); !ok {
+		return false
+	}
+	session.SendMobEffect(session.GetPlayer().GetRuntimeId(), effects.MobEffectRemove, id, 0, false, 0)
+	session.updateMovementSpeed()
+	return true
+}
+
+// ClearEffects removes all effects from the player of the session.
+func (session *MinecraftSession) ClearEffects() {
+	for _, effect := range session.GetPlayer().GetEffects().Clear() {
+		session.SendMobEffect(session.GetPlayer().GetRuntimeId(), effects.MobEffectRemove, effect.Type.Id, 0, false, 0)
+	}
+	session.updateMovementSpeed()
+}
+
+// updateMovementSpeed updates the movement speed of the player of the session to that of its effects,
+// and sends the attributes of the player to the client if the speed changed.
+func (session *MinecraftSession) updateMovementSpeed() {
+	var player = session.GetPlayer()
+	if player.UpdateMovementSpeed() {
+		session.SendUpdateAttributes(player.GetRuntimeId(), player.GetAttributeMap())
+	}
+}
+
 func (session *MinecraftSession) Tick() {
 	if session.Connected {
 		session.GetChunkLoader().Warp(session.GetPlayer().GetDimension(), int32(math.Floor(session.player.Position.X))>>4, int32(math.Floor(session.player.Position.Z))>>4)
@@ -1,11 +1,13 @@
 package net
 
 import (
+	"github.com/BobbyShrd/gominetest/net/compression"
 	"github.com/BobbyShrd/gominetest/net/packets"
 	protocol2 "github.com/BobbyShrd/gominetest/net/protocol"
 	"github.com/BobbyShrd/gominetest/text"
 	"github.com/irmine/goraklib/protocol"
 	"github.com/irmine/goraklib/server"
+	"github.com/irmine/worlds/chunks"
 	"net"
 )
 
@@ -13,12 +15,38 @@ type NetworkAdapter struct {
 	rakLibManager   *server.Manager
 	packetManager   protocol2.IPacketManager
 	sessionManager  *SessionManager
+
+	// Compression holds the compression settings of batches sent to all sessions.
+	// The algorithm is offered to sessions negotiating compression, while other sessions always use zlib.
+	Compression compression.Settings
+
+	// PacketsReceivedFunction gets called with the amount of packets in every batch received.
+	// Nothing is done if nil.
+	PacketsReceivedFunction func(count int)
+	// PacketsSentFunction gets called with the amount of packets in every batch sent.
+	// Nothing is done if nil.
+	PacketsSentFunction func(count int)
+	// BatchCompressedFunction gets called with the size of every batch sent before and after compression.
+	// Nothing is done if nil.
+	BatchCompressedFunction func(uncompressed int, compressed int)
+	// ChunkLoadedFunction gets called when a chunk gets loaded by a session.
+	// Nothing is done if nil.
+	ChunkLoadedFunction func(session *MinecraftSession, chunk *chunks.Chunk)
+	// ChunkUnloadedFunction gets called when a chunk gets unloaded by a session.
+	// Nothing is done if nil.
+	ChunkUnloadedFunction func(session *MinecraftSession, chunk *chunks.Chunk)
+	// ErrorFormFunction gets called to show the message of a handler error with SeverityForm in a form.
+	// The message is sent in chat instead if nil.
+	ErrorFormFunction func(session *MinecraftSession, message string)
+	// PlayerSpawnedFunction gets called after the player of a session is spawned to the player of another session.
+	// Nothing is done if nil.
+	PlayerSpawnedFunction func(session *MinecraftSession, viewer *MinecraftSession)
 }
 
 // NewNetworkAdapter returns a new Network adapter to adapt to the RakNet server.
 func NewNetworkAdapter(packetManager protocol2.IPacketManager, sessionManager *SessionManager) *NetworkAdapter {
 	var manager = server.NewManager()
-	var adapter = &NetworkAdapter{manager, packetManager, sessionManager}
+	var adapter = &NetworkAdapter{rakLibManager: manager, packetManager: packetManager, sessionManager: sessionManager, Compression: compression.DefaultSettings()}
 
 	manager.PacketFunction = func(packet []byte, session *server.Session) {
 		var minecraftSession *MinecraftSession
@@ -49,6 +77,9 @@ func (adapter *NetworkAdapter) HandlePacket(session *MinecraftSession, buffer []
 	batch.Buffer = buffer
 	batch.Decode()
 
+	if adapter.PacketsReceivedFunction != nil {
+		adapter.PacketsReceivedFunction(len(batch.GetPackets()))
+	}
 	for _, packet := range batch.GetPackets() {
 		if session.GetProtocolNumber() < 120 {
 			packet.DecodeId()
@@ -0,0 +1,62 @@
+package net
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/BobbyShrd/gominetest/net/compression"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+const (
+	// requestNetworkSettingsId is the ID of the packet newer clients send to negotiate compression before logging in.
+	requestNetworkSettingsId = 0xc1
+	// networkSettingsId is the ID of the packet holding the compression settings the server chose for a session.
+	networkSettingsId = 0x8f
+)
+
+// IsCompressionNegotiated checks if the session negotiated the compression of its batches.
+// Sessions that did not negotiate compression use zlib.
+func (session *MinecraftSession) IsCompressionNegotiated() bool {
+	return session.compressionNegotiated
+}
+
+// GetCompressionAlgorithm returns the compression algorithm selected for the session during the handshake.
+func (session *MinecraftSession) GetCompressionAlgorithm() compression.Algorithm {
+	return session.compressionAlgorithm
+}
+
+// handleNetworkSettingsRequest handles the uncompressed network settings request sent by newer clients before logging in.
+// The compression algorithm of the adapter is selected for the session and sent back uncompressed,
+// after which all batches are compressed with it. Returns false if the batch was not a network settings request.
+func (session *MinecraftSession) handleNetworkSettingsRequest(raw []byte) bool {
+	// The request holds a single packet: a length of 6, the 2 byte packet ID and the big endian protocol.
+	if session.compressionNegotiated || len(raw) < 7 || raw[0] != 6 || raw[1] != requestNetworkSettingsId || raw[2] != 0x01 {
+		return false
+	}
+	var protocol = int32(binary.BigEndian.Uint32(raw[3:7]))
+	var settings = session.adapter.Compression
+
+	var threshold = settings.Threshold
+	if protocol < compression.PrefixProtocol || threshold < 1 {
+		// Clients without prefixes can not tell compressed batches apart, so every batch is compressed.
+		threshold = 1
+	}
+	if threshold > math.MaxUint16 {
+		threshold = math.MaxUint16
+	}
+	var payload = []byte{networkSettingsId, 0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	binary.LittleEndian.PutUint16(payload[2:4], uint16(threshold))
+	binary.LittleEndian.PutUint16(payload[4:6], uint16(settings.Algorithm))
+
+	var batch = NewMinecraftPacketBatch(session)
+	batch.payloads = [][]byte{payload}
+	batch.uncompressed = true
+	session.SendBatch(batch)
+
+	session.compressionAlgorithm = settings.Algorithm
+	session.compressionPrefixed = protocol >= compression.PrefixProtocol
+	session.compressionNegotiated = true
+	text.DefaultLogger.Debug(session.GetAddress(), "negotiated compression", settings.Algorithm, "with protocol", protocol)
+	return true
+}
@@ -0,0 +1,94 @@
+package packtransfer
+
+import (
+	"sync"
+	"time"
+)
+
+// MinimumChunkSize is the smallest size chunks of packs are tuned down to.
+const MinimumChunkSize int32 = 64 * 1024
+
+// Progress is the progress of the download of a pack, remembered when the download was interrupted.
+type Progress struct {
+	// ChunkSize is the size of the chunks the pack was sent in.
+	ChunkSize int32
+	// SentChunks is the amount of chunks of the pack sent before the interruption.
+	SentChunks int32
+}
+
+// Transfer is a pack download of a player, remembered after the player disconnected during the download.
+type Transfer struct {
+	// Packs holds the progress of the packs that were not completely downloaded, by pack UUID.
+	Packs map[string]Progress
+	// Interruptions is the amount of times the transfer was interrupted by a disconnect.
+	Interruptions int
+}
+
+// entry is a remembered transfer with the time it is forgotten.
+type entry struct {
+	transfer Transfer
+	expires  time.Time
+}
+
+// Cache remembers interrupted pack transfers by XUID, so players reconnecting shortly after
+// resume their download where it stopped. Packs that were partially sent resume from the remembered chunk index,
+// in the chunk size they were sent in so the chunk indexes stay valid. Packs that were not started yet are sent
+// in smaller chunks, which get through lossy connections more easily.
+type Cache struct {
+	mutex     sync.Mutex
+	ttl       time.Duration
+	transfers map[string]entry
+}
+
+// New returns a new cache remembering interrupted transfers for the given duration.
+// Nothing is remembered if the duration is 0.
+func New(ttl time.Duration) *Cache {
+	return &Cache{ttl: ttl, transfers: make(map[string]entry)}
+}
+
+// Get returns the interrupted transfer of the player with the XUID, and a bool indicating if one was found.
+func (cache *Cache) Get(xuid string) (Transfer, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	var entry, ok = cache.transfers[xuid]
+	if !ok {
+		return Transfer{}, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(cache.transfers, xuid)
+		return Transfer{}, false
+	}
+	return entry.transfer, true
+}
+
+// Interrupt remembers that the transfer of the player with the XUID was interrupted, with the progress of its
+// incomplete packs by pack UUID. The interrupted transfer is returned.
+// Players without XUID are not remembered, as they can not be recognised when reconnecting.
+func (cache *Cache) Interrupt(xuid string, packs map[string]Progress) Transfer {
+	var transfer, _ = cache.Get(xuid)
+	transfer.Packs = packs
+	transfer.Interruptions++
+	if cache.ttl <= 0 || xuid == "" {
+		return transfer
+	}
+	cache.mutex.Lock()
+	cache.transfers[xuid] = entry{transfer, time.Now().Add(cache.ttl)}
+	cache.mutex.Unlock()
+	return transfer
+}
+
+// Complete forgets the transfer of the player with the XUID, after all packs were downloaded.
+func (cache *Cache) Complete(xuid string) {
+	cache.mutex.Lock()
+	delete(cache.transfers, xuid)
+	cache.mutex.Unlock()
+}
+
+// TuneChunkSize returns the chunk size to use for a transfer that was interrupted the given amount of times.
+// The chunk size is halved for every interruption, down to MinimumChunkSize.
+func TuneChunkSize(chunkSize int32, interruptions int) int32 {
+	for i := 0; i < interruptions && chunkSize/2 >= MinimumChunkSize; i++ {
+		chunkSize /= 2
+	}
+	return chunkSize
+}
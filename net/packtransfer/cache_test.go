@@ -0,0 +1,54 @@
+package packtransfer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	var cache = New(time.Minute)
+	if _, ok := cache.Get("123"); ok {
+		t.Fatal("expected empty cache")
+	}
+	cache.Interrupt("123", nil)
+	var transfer = cache.Interrupt("123", nil)
+	if transfer.Interruptions != 2 {
+		t.Fatalf("expected second interruption, got %+v", transfer)
+	}
+	if remembered, ok := cache.Get("123"); !ok || remembered.Interruptions != 2 {
+		t.Errorf("expected transfer to be remembered, got %+v", remembered)
+	}
+	cache.Interrupt("123", map[string]Progress{"pack": {ChunkSize: 65536, SentChunks: 3}})
+	if remembered, _ := cache.Get("123"); remembered.Packs["pack"].SentChunks != 3 || remembered.Packs["pack"].ChunkSize != 65536 {
+		t.Errorf("expected the pack progress to be remembered, got %+v", remembered)
+	}
+	cache.Complete("123")
+	if _, ok := cache.Get("123"); ok {
+		t.Error("expected completed transfer to be forgotten")
+	}
+
+	cache.Interrupt("", nil)
+	if _, ok := cache.Get(""); ok {
+		t.Error("expected players without XUID not to be remembered")
+	}
+	var disabled = New(0)
+	disabled.Interrupt("123", nil)
+	if _, ok := disabled.Get("123"); ok {
+		t.Error("expected nothing to be remembered without TTL")
+	}
+}
+
+func TestTuneChunkSize(t *testing.T) {
+	if size := TuneChunkSize(1048576, 0); size != 1048576 {
+		t.Errorf("expected chunk size not to change, got %v", size)
+	}
+	if size := TuneChunkSize(1048576, 2); size != 262144 {
+		t.Errorf("expected chunk size to be halved twice, got %v", size)
+	}
+	if size := TuneChunkSize(1048576, 10); size != MinimumChunkSize {
+		t.Errorf("expected minimum chunk size, got %v", size)
+	}
+	if size := TuneChunkSize(1000, 1); size != 1000 {
+		t.Errorf("expected small chunk size not to be halved, got %v", size)
+	}
+}
@@ -3,6 +3,8 @@ package net
 import (
 	"github.com/golang/geo/r3"
 	"github.com/google/uuid"
+	"github.com/BobbyShrd/gominetest/commands"
+	"github.com/BobbyShrd/gominetest/items"
 	"github.com/BobbyShrd/gominetest/net/packets/types"
 	"github.com/BobbyShrd/gominetest/net/protocol"
 	"github.com/BobbyShrd/gominetest/packs"
@@ -55,12 +57,12 @@ func (session *MinecraftSession) SendResourcePackChunkData(packUUID string, chun
 	session.SendPacket(session.adapter.packetManager.GetResourcePackChunkData(packUUID, chunkIndex, progress, data))
 }
 
-func (session *MinecraftSession) SendResourcePackDataInfo(pack packs.Pack) {
-	session.SendPacket(session.adapter.packetManager.GetResourcePackDataInfo(pack))
+func (session *MinecraftSession) SendResourcePackDataInfo(pack packs.Pack, chunkSize int32) {
+	session.SendPacket(session.adapter.packetManager.GetResourcePackDataInfo(pack, chunkSize))
 }
 
-func (session *MinecraftSession) SendResourcePackInfo(mustAccept bool, resourcePacks *packs.Stack, behaviorPacks *packs.Stack) {
-	session.SendPacket(session.adapter.packetManager.GetResourcePackInfo(mustAccept, resourcePacks, behaviorPacks))
+func (session *MinecraftSession) SendResourcePackInfo(mustAccept bool, resourcePacks *packs.Stack, behaviorPacks *packs.Stack, packURLs map[string]string) {
+	session.SendPacket(session.adapter.packetManager.GetResourcePackInfo(mustAccept, resourcePacks, behaviorPacks, packURLs))
 }
 
 func (session *MinecraftSession) SendResourcePackStack(mustAccept bool, resourcePacks *packs.Stack, behaviorPacks *packs.Stack) {
@@ -75,8 +77,8 @@ func (session *MinecraftSession) SendSetEntityData(runtimeId uint64, data map[ui
 	session.SendPacket(session.adapter.packetManager.GetSetEntityData(runtimeId, data))
 }
 
-func (session *MinecraftSession) SendStartGame(player protocol.StartGameEntry, runtimeIdsTable []byte) {
-	session.SendPacket(session.adapter.packetManager.GetStartGame(player, runtimeIdsTable))
+func (session *MinecraftSession) SendStartGame(player protocol.StartGameEntry, seed int64, spawn blocks.Position, runtimeIdsTable []byte, blockProperties []types.BlockProperty, gameRules map[string]types.GameRuleEntry, difficulty int32) {
+	session.SendPacket(session.adapter.packetManager.GetStartGame(player, seed, spawn, runtimeIdsTable, blockProperties, gameRules, difficulty))
 }
 
 func (session *MinecraftSession) SendText(text types.Text) {
@@ -113,4 +115,152 @@ func (session *MinecraftSession) SendAnimate(action int32, runtimeId uint64, flo
 
 func (session *MinecraftSession) SendUpdateBlock(position blocks.Position, blockRuntimeId, dataLayerId uint32) {
 	session.SendPacket(session.adapter.packetManager.GetUpdateBlock(position, blockRuntimeId, dataLayerId))
-}
\ No newline at end of file
+}
+
+func (session *MinecraftSession) SendLevelEvent(eventId int32, position r3.Vector, data int32) {
+	session.SendPacket(session.adapter.packetManager.GetLevelEvent(eventId, position, data))
+}
+
+func (session *MinecraftSession) SendModalFormRequest(formId uint32, formData string) {
+	session.SendPacket(session.adapter.packetManager.GetModalFormRequest(formId, formData))
+}
+
+func (session *MinecraftSession) SendBlockEvent(position blocks.Position, eventType int32, eventData int32) {
+	session.SendPacket(session.adapter.packetManager.GetBlockEvent(position, eventType, eventData))
+}
+
+func (session *MinecraftSession) SendEntityEvent(runtimeId uint64, eventId byte, eventData int32) {
+	session.SendPacket(session.adapter.packetManager.GetEntityEvent(runtimeId, eventId, eventData))
+}
+
+func (session *MinecraftSession) SendSetEntityMotion(runtimeId uint64, motion r3.Vector) {
+	session.SendPacket(session.adapter.packetManager.GetSetEntityMotion(runtimeId, motion))
+}
+
+func (session *MinecraftSession) SendLevelSoundEvent(soundId uint32, position r3.Vector, extraData int32) {
+	session.SendPacket(session.adapter.packetManager.GetLevelSoundEvent(soundId, position, extraData))
+}
+
+func (session *MinecraftSession) SendCreativeContent(stacks []*items.Stack) {
+	session.SendPacket(session.adapter.packetManager.GetCreativeContent(stacks))
+}
+
+func (session *MinecraftSession) SendInventoryContent(windowId uint32, stacks []*items.Stack) {
+	session.SendPacket(session.adapter.packetManager.GetInventoryContent(windowId, stacks))
+}
+
+func (session *MinecraftSession) SendAddItemEntity(runtimeId uint64, item *items.Stack, position r3.Vector, motion r3.Vector) {
+	session.SendPacket(session.adapter.packetManager.GetAddItemEntity(runtimeId, item, position, motion))
+}
+
+func (session *MinecraftSession) SendTakeItemEntity(itemRuntimeId uint64, takerRuntimeId uint64) {
+	session.SendPacket(session.adapter.packetManager.GetTakeItemEntity(itemRuntimeId, takerRuntimeId))
+}
+
+func (session *MinecraftSession) SendMobEffect(runtimeId uint64, eventId byte, effectId int32, amplifier int32, particles bool, duration int32) {
+	session.SendPacket(session.adapter.packetManager.GetMobEffect(runtimeId, eventId, effectId, amplifier, particles, duration))
+}
+
+func (session *MinecraftSession) SendChangeDimension(dimension int32, position r3.Vector, respawn bool) {
+	session.SendPacket(session.adapter.packetManager.GetChangeDimension(dimension, position, respawn))
+}
+
+func (session *MinecraftSession) SendSetPlayerGameType(gameMode int32) {
+	session.SendPacket(session.adapter.packetManager.GetSetPlayerGameType(gameMode))
+}
+
+func (session *MinecraftSession) SendSetDifficulty(difficulty uint32) {
+	session.SendPacket(session.adapter.packetManager.GetSetDifficulty(difficulty))
+}
+
+func (session *MinecraftSession) SendBlockEntityData(position blocks.Position, namedTag []byte) {
+	session.SendPacket(session.adapter.packetManager.GetBlockEntityData(position, namedTag))
+}
+
+func (session *MinecraftSession) SendContainerOpen(windowId byte, windowType byte, position blocks.Position) {
+	session.SendPacket(session.adapter.packetManager.GetContainerOpen(windowId, windowType, position))
+}
+
+func (session *MinecraftSession) SendContainerClose(windowId byte) {
+	session.SendPacket(session.adapter.packetManager.GetContainerClose(windowId))
+}
+
+func (session *MinecraftSession) SendInventorySlot(windowId uint32, slot uint32, item *items.Stack) {
+	session.SendPacket(session.adapter.packetManager.GetInventorySlot(windowId, slot, item))
+}
+
+func (session *MinecraftSession) SendCommandOutput(origin types.CommandOrigin, output *commands.Output) {
+	session.SendPacket(session.adapter.packetManager.GetCommandOutput(origin, output))
+}
+
+func (session *MinecraftSession) SendSetEntityLink(vehicleId uint64, riderId uint64, linkType byte) {
+	session.SendPacket(session.adapter.packetManager.GetSetEntityLink(vehicleId, riderId, linkType))
+}
+
+func (session *MinecraftSession) SendUpdateTrade(windowId byte, windowType byte, tier int32, traderUniqueId int64, playerUniqueId int64, displayName string, offers []byte) {
+	session.SendPacket(session.adapter.packetManager.GetUpdateTrade(windowId, windowType, tier, traderUniqueId, playerUniqueId, displayName, offers))
+}
+
+func (session *MinecraftSession) SendMobEquipment(runtimeId uint64, item *items.Stack, inventorySlot byte, hotbarSlot byte, windowId byte) {
+	session.SendPacket(session.adapter.packetManager.GetMobEquipment(runtimeId, item, inventorySlot, hotbarSlot, windowId))
+}
+
+func (session *MinecraftSession) SendMobArmorEquipment(runtimeId uint64, armor [4]*items.Stack) {
+	session.SendPacket(session.adapter.packetManager.GetMobArmorEquipment(runtimeId, armor))
+}
+
+func (session *MinecraftSession) SendSetDisplayObjective(displaySlot string, objectiveName string, displayName string, criteria string, sortOrder int32) {
+	session.SendPacket(session.adapter.packetManager.GetSetDisplayObjective(displaySlot, objectiveName, displayName, criteria, sortOrder))
+}
+
+func (session *MinecraftSession) SendRemoveObjective(objectiveName string) {
+	session.SendPacket(session.adapter.packetManager.GetRemoveObjective(objectiveName))
+}
+
+func (session *MinecraftSession) SendSetScore(actionType byte, entries []types.ScoreboardEntry) {
+	session.SendPacket(session.adapter.packetManager.GetSetScore(actionType, entries))
+}
+
+func (session *MinecraftSession) SendNetworkStackLatency(timestamp uint64, needsResponse bool) {
+	session.SendPacket(session.adapter.packetManager.GetNetworkStackLatency(timestamp, needsResponse))
+}
+
+func (session *MinecraftSession) SendTickSync(clientRequestTimestamp int64, serverReceptionTimestamp int64) {
+	session.SendPacket(session.adapter.packetManager.GetTickSync(clientRequestTimestamp, serverReceptionTimestamp))
+}
+
+func (session *MinecraftSession) SendAdventureSettings(flags uint32, commandPermissionLevel uint32, actionPermissions uint32, permissionLevel uint32, uniqueId int64) {
+	session.SendPacket(session.adapter.packetManager.GetAdventureSettings(flags, commandPermissionLevel, actionPermissions, permissionLevel, uniqueId))
+}
+
+func (session *MinecraftSession) SendSetTitle(titleType int32, text string, fadeInTime int32, stayTime int32, fadeOutTime int32) {
+	session.SendPacket(session.adapter.packetManager.GetSetTitle(titleType, text, fadeInTime, stayTime, fadeOutTime))
+}
+
+func (session *MinecraftSession) SendToastRequest(title string, message string) {
+	session.SendPacket(session.adapter.packetManager.GetToastRequest(title, message))
+}
+
+func (session *MinecraftSession) SendPlaySound(soundName string, position r3.Vector, volume float32, pitch float32) {
+	session.SendPacket(session.adapter.packetManager.GetPlaySound(soundName, position, volume, pitch))
+}
+
+func (session *MinecraftSession) SendStopSound(soundName string, stopAll bool) {
+	session.SendPacket(session.adapter.packetManager.GetStopSound(soundName, stopAll))
+}
+
+func (session *MinecraftSession) SendSetTime(time int32) {
+	session.SendPacket(session.adapter.packetManager.GetSetTime(time))
+}
+
+func (session *MinecraftSession) SendGameRulesChanged(gameRules map[string]types.GameRuleEntry) {
+	session.SendPacket(session.adapter.packetManager.GetGameRulesChanged(gameRules))
+}
+
+func (session *MinecraftSession) SendAvailableCommands(commandList []*commands.Command) {
+	session.SendPacket(session.adapter.packetManager.GetAvailableCommands(commandList))
+}
+
+func (session *MinecraftSession) SendUpdateSoftEnum(enumName string, values []string, action byte) {
+	session.SendPacket(session.adapter.packetManager.GetUpdateSoftEnum(enumName, values, action))
+}
@@ -0,0 +1,253 @@
+package rcon
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/BobbyShrd/gominetest/commands"
+)
+
+// UnknownCommand is the output returned for commands that could not be found.
+const UnknownCommand = "Command could not be found."
+
+var (
+	EmptyPassword    = errors.New("rcon password may not be empty")
+	AlreadyListening = errors.New("rcon listener is already listening")
+)
+
+// ExecuteFunction executes the command in the command text as the given sender.
+// It returns false if no command could be found in the command text.
+type ExecuteFunction func(sender commands.Sender, commandText string) bool
+
+// Listener accepts RCON connections, and executes the commands of authenticated clients.
+type Listener struct {
+	mutex       sync.Mutex
+	waitGroup   sync.WaitGroup
+	password    string
+	execute     ExecuteFunction
+	listener    net.Listener
+	connections map[net.Conn]bool
+	closed      chan struct{}
+
+	// ConnectFunction gets called with the address of a client that authenticated successfully.
+	// Nothing is done if nil.
+	ConnectFunction func(addr net.Addr)
+	// FailFunction gets called with the address of a client that used a wrong password.
+	// Nothing is done if nil.
+	FailFunction func(addr net.Addr)
+	// QueueFunction queues a function to be run on the goroutine commands are executed on,
+	// such as the goroutine ticking the server. Commands are executed on the goroutine of the connection if nil.
+	QueueFunction func(function func())
+}
+
+// NewListener returns a new RCON listener authenticating clients with the password,
+// and executing their commands with the execute function.
+func NewListener(password string, execute ExecuteFunction) *Listener {
+	return &Listener{password: password, execute: execute, connections: make(map[net.Conn]bool)}
+}
+
+// Listen starts accepting connections on the given address and port.
+// Connections are handled on separate goroutines until the listener is closed.
+// Returns EmptyPassword if the listener has no password, as anyone could execute commands otherwise.
+func (listener *Listener) Listen(address string, port uint16) error {
+	if listener.password == "" {
+		return EmptyPassword
+	}
+	listener.mutex.Lock()
+	defer listener.mutex.Unlock()
+	if listener.listener != nil {
+		return AlreadyListening
+	}
+	var l, err = net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(int(port))))
+	if err != nil {
+		return err
+	}
+	listener.listener = l
+	listener.closed = make(chan struct{})
+	listener.waitGroup.Add(1)
+	go listener.accept(l)
+	return nil
+}
+
+// GetAddress returns the local address of the listener, or nil if it is not listening.
+func (listener *Listener) GetAddress() net.Addr {
+	listener.mutex.Lock()
+	defer listener.mutex.Unlock()
+	if listener.listener == nil {
+		return nil
+	}
+	return listener.listener.Addr()
+}
+
+// Close stops accepting connections, closes all open connections and waits for them to finish.
+func (listener *Listener) Close() error {
+	listener.mutex.Lock()
+	if listener.listener == nil {
+		listener.mutex.Unlock()
+		return nil
+	}
+	var err = listener.listener.Close()
+	listener.listener = nil
+	close(listener.closed)
+	for connection := range listener.connections {
+		connection.Close()
+	}
+	listener.mutex.Unlock()
+
+	listener.waitGroup.Wait()
+	return err
+}
+
+// accept accepts connections until the listener gets closed.
+func (listener *Listener) accept(l net.Listener) {
+	defer listener.waitGroup.Done()
+	for {
+		var connection, err = l.Accept()
+		if err != nil {
+			return
+		}
+		listener.mutex.Lock()
+		if listener.listener != l {
+			listener.mutex.Unlock()
+			connection.Close()
+			return
+		}
+		listener.connections[connection] = true
+		listener.waitGroup.Add(1)
+		listener.mutex.Unlock()
+
+		go listener.handle(connection)
+	}
+}
+
+// client is a connection of an RCON client.
+// Writes are serialized, so that the packets of a response split over multiple packets are never interleaved.
+type client struct {
+	net.Conn
+	mutex sync.Mutex
+}
+
+// write writes the packets to the connection of the client, without other packets in between.
+func (client *client) write(packets ...Packet) error {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	for _, packet := range packets {
+		if err := WritePacket(client.Conn, packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handle reads and answers packets of a connection until it gets closed.
+// Connections are closed after a failed authentication attempt, or a command sent without authenticating.
+func (listener *Listener) handle(conn net.Conn) {
+	defer listener.waitGroup.Done()
+	defer func() {
+		listener.mutex.Lock()
+		delete(listener.connections, conn)
+		listener.mutex.Unlock()
+		conn.Close()
+	}()
+
+	var connection = &client{Conn: conn}
+	var authenticated = false
+	for {
+		var packet, err = ReadPacket(connection)
+		if err != nil {
+			return
+		}
+		switch packet.Type {
+		case TypeAuth:
+			if subtle.ConstantTimeCompare([]byte(packet.Body), []byte(listener.password)) != 1 {
+				connection.write(Packet{Id: -1, Type: TypeAuthResponse})
+				if listener.FailFunction != nil {
+					listener.FailFunction(connection.RemoteAddr())
+				}
+				return
+			}
+			authenticated = true
+			if err := connection.write(Packet{Id: packet.Id, Type: TypeAuthResponse}); err != nil {
+				return
+			}
+			if listener.ConnectFunction != nil {
+				listener.ConnectFunction(connection.RemoteAddr())
+			}
+		case TypeExecCommand:
+			if !authenticated {
+				connection.write(Packet{Id: -1, Type: TypeAuthResponse})
+				return
+			}
+			if err := listener.respond(connection, packet.Id, listener.Execute(packet.Body)); err != nil {
+				return
+			}
+		default:
+			if err := connection.write(Packet{Id: packet.Id, Type: TypeResponseValue, Body: "Unknown request " + strconv.Itoa(int(packet.Type))}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Execute executes the command text as an RCON client, and returns the captured output.
+// Commands are queued with the queue function if set, in which case Execute waits for the command to be executed.
+// The output captured so far is returned if the listener gets closed before the command finished,
+// such as when the command shuts down the server.
+func (listener *Listener) Execute(commandText string) string {
+	var sender = &Sender{}
+	if listener.QueueFunction == nil {
+		if !listener.execute(sender, commandText) {
+			return UnknownCommand
+		}
+		return sender.GetOutput()
+	}
+
+	listener.mutex.Lock()
+	var closed = listener.closed
+	listener.mutex.Unlock()
+	var found = make(chan bool, 1)
+	listener.QueueFunction(func() {
+		found <- listener.execute(sender, commandText)
+	})
+	select {
+	case ok := <-found:
+		if !ok {
+			return UnknownCommand
+		}
+	case <-closed:
+	}
+	return sender.GetOutput()
+}
+
+// respond writes the output of a command, split over multiple packets if it is too long.
+func (listener *Listener) respond(connection *client, id int32, output string) error {
+	return connection.write(SplitResponse(id, output)...)
+}
+
+// SplitResponse returns the response packets holding the output of a command with the given request ID.
+// Output longer than MaxResponseBody is split over multiple packets between runes,
+// so that every packet holds valid UTF-8.
+func SplitResponse(id int32, output string) []Packet {
+	var packets []Packet
+	for {
+		var length = len(output)
+		if length > MaxResponseBody {
+			length = MaxResponseBody
+			for length > 0 && !utf8.RuneStart(output[length]) {
+				length--
+			}
+			if length == 0 {
+				length = MaxResponseBody
+			}
+		}
+		packets = append(packets, Packet{Id: id, Type: TypeResponseValue, Body: output[:length]})
+		output = output[length:]
+		if output == "" {
+			return packets
+		}
+	}
+}
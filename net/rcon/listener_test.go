@@ -0,0 +1,160 @@
+package rcon
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/BobbyShrd/gominetest/commands"
+)
+
+func TestPacket(t *testing.T) {
+	var buffer = &bytes.Buffer{}
+	var packet = Packet{Id: 42, Type: TypeExecCommand, Body: "list"}
+	if err := WritePacket(buffer, packet); err != nil {
+		t.Fatal(err)
+	}
+	if buffer.Len() != 4+minimumSize+len("list") {
+		t.Errorf("unexpected packet length %v", buffer.Len())
+	}
+	var read, err = ReadPacket(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != packet {
+		t.Errorf("expected %v, got %v", packet, read)
+	}
+
+	if _, err := ReadPacket(bytes.NewReader([]byte{0xff, 0xff, 0, 0})); err != InvalidPacketSize {
+		t.Errorf("expected InvalidPacketSize for an oversized packet, got %v", err)
+	}
+}
+
+// listen starts a listener executing the echo command, and returns a connection to it.
+func listen(t *testing.T) (*Listener, net.Conn) {
+	var listener = NewListener("secret", func(sender commands.Sender, commandText string) bool {
+		if !strings.HasPrefix(commandText, "echo ") {
+			return false
+		}
+		sender.SendMessage(strings.TrimPrefix(commandText, "echo "))
+		sender.SendMessage("§adone")
+		return true
+	})
+	if err := listener.Listen("127.0.0.1", 0); err != nil {
+		t.Fatal(err)
+	}
+	var connection, err = net.Dial("tcp", listener.GetAddress().String())
+	if err != nil {
+		listener.Close()
+		t.Fatal(err)
+	}
+	connection.SetDeadline(time.Now().Add(time.Second * 2))
+	return listener, connection
+}
+
+// request writes a packet and reads the response.
+func request(t *testing.T, connection net.Conn, packet Packet) Packet {
+	if err := WritePacket(connection, packet); err != nil {
+		t.Fatal(err)
+	}
+	var response, err = ReadPacket(connection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response
+}
+
+func TestExecute(t *testing.T) {
+	var listener, connection = listen(t)
+	defer listener.Close()
+
+	if response := request(t, connection, Packet{Id: 1, Type: TypeAuth, Body: "secret"}); response.Id != 1 || response.Type != TypeAuthResponse {
+		t.Fatalf("expected successful authentication, got %v", response)
+	}
+	if response := request(t, connection, Packet{Id: 2, Type: TypeExecCommand, Body: "echo hello"}); response.Id != 2 || response.Body != "hello\ndone" {
+		t.Errorf("expected captured output without colours, got %v", response)
+	}
+	if response := request(t, connection, Packet{Id: 3, Type: TypeExecCommand, Body: "unknown"}); response.Body != UnknownCommand {
+		t.Errorf("expected unknown command output, got %v", response)
+	}
+}
+
+func TestQueue(t *testing.T) {
+	var listener, connection = listen(t)
+	var queue = make(chan func(), 1)
+	listener.QueueFunction = func(function func()) {
+		queue <- function
+	}
+	request(t, connection, Packet{Id: 1, Type: TypeAuth, Body: "secret"})
+	go func() {
+		(<-queue)()
+	}()
+	if response := request(t, connection, Packet{Id: 2, Type: TypeExecCommand, Body: "echo queued"}); response.Body != "queued\ndone" {
+		t.Errorf("expected the output of the queued command, got %v", response)
+	}
+
+	// A command that is never run must not keep the listener from closing.
+	WritePacket(connection, Packet{Id: 3, Type: TypeExecCommand, Body: "echo never"})
+	<-queue
+	var closed = make(chan struct{})
+	go func() {
+		listener.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Error("expected the listener to close while a queued command is waiting")
+	}
+}
+
+func TestWrongPassword(t *testing.T) {
+	var listener, connection = listen(t)
+	defer listener.Close()
+
+	if response := request(t, connection, Packet{Id: 1, Type: TypeAuth, Body: "wrong"}); response.Id != -1 {
+		t.Errorf("expected failed authentication, got %v", response)
+	}
+	if _, err := ReadPacket(connection); err == nil {
+		t.Error("expected the connection to be closed after a failed authentication")
+	}
+}
+
+func TestUnauthenticated(t *testing.T) {
+	var listener, connection = listen(t)
+	defer listener.Close()
+
+	if response := request(t, connection, Packet{Id: 1, Type: TypeExecCommand, Body: "echo hello"}); response.Id != -1 {
+		t.Errorf("expected commands to be refused without authentication, got %v", response)
+	}
+}
+
+func TestEmptyPassword(t *testing.T) {
+	if err := NewListener("", nil).Listen("127.0.0.1", 0); err != EmptyPassword {
+		t.Errorf("expected EmptyPassword, got %v", err)
+	}
+}
+
+func TestSplitResponse(t *testing.T) {
+	var output = "a" + strings.Repeat("ä", MaxResponseBody)
+	var packets = SplitResponse(7, output)
+	if len(packets) != 3 {
+		t.Fatalf("expected the output to be split over 3 packets, got %v", len(packets))
+	}
+	var joined string
+	for _, packet := range packets {
+		if len(packet.Body) > MaxResponseBody || !utf8.ValidString(packet.Body) || packet.Id != 7 {
+			t.Errorf("expected packets of valid UTF-8 within the maximum body length, got %v bytes", len(packet.Body))
+		}
+		joined += packet.Body
+	}
+	if joined != output {
+		t.Error("expected the packets to hold the complete output")
+	}
+	if packets := SplitResponse(7, ""); len(packets) != 1 || packets[0].Body != "" {
+		t.Errorf("expected empty output to be answered with one empty packet, got %v", packets)
+	}
+}
@@ -0,0 +1,79 @@
+package rcon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	// TypeResponseValue is the type of packets holding the output of a command.
+	TypeResponseValue int32 = 0
+	// TypeExecCommand is the type of packets requesting a command to be executed.
+	TypeExecCommand int32 = 2
+	// TypeAuthResponse is the type of packets answering an authentication request.
+	TypeAuthResponse int32 = 2
+	// TypeAuth is the type of packets requesting authentication with a password.
+	TypeAuth int32 = 3
+
+	// MaxRequestSize is the maximum size of packets sent by clients.
+	MaxRequestSize = 1460
+	// MaxResponseBody is the maximum body length of a single response packet.
+	// Longer responses are split over multiple packets.
+	MaxResponseBody = 4096
+	// minimumSize is the size of a packet with an empty body, excluding the size field.
+	minimumSize = 10
+)
+
+var (
+	InvalidPacketSize = errors.New("invalid rcon packet size")
+)
+
+// Packet is a packet of the Source RCON protocol.
+type Packet struct {
+	// Id is chosen by the client, and is copied into the response to a request.
+	Id int32
+	// Type is the type of the packet.
+	Type int32
+	// Body is the password, command or command output held by the packet.
+	Body string
+}
+
+// ReadPacket reads a packet from the reader.
+// Returns InvalidPacketSize if the packet is too small or larger than MaxRequestSize.
+func ReadPacket(reader io.Reader) (Packet, error) {
+	var size int32
+	if err := binary.Read(reader, binary.LittleEndian, &size); err != nil {
+		return Packet{}, err
+	}
+	if size < minimumSize || size > MaxRequestSize {
+		return Packet{}, InvalidPacketSize
+	}
+	var data = make([]byte, size)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return Packet{}, err
+	}
+	var packet = Packet{
+		Id:   int32(binary.LittleEndian.Uint32(data[0:4])),
+		Type: int32(binary.LittleEndian.Uint32(data[4:8])),
+	}
+	var body = data[8:]
+	if i := bytes.IndexByte(body, 0); i >= 0 {
+		body = body[:i]
+	}
+	packet.Body = string(body)
+	return packet, nil
+}
+
+// WritePacket writes a packet to the writer.
+func WritePacket(writer io.Writer, packet Packet) error {
+	var buffer = bytes.NewBuffer(make([]byte, 0, len(packet.Body)+minimumSize+4))
+	binary.Write(buffer, binary.LittleEndian, int32(len(packet.Body)+minimumSize))
+	binary.Write(buffer, binary.LittleEndian, packet.Id)
+	binary.Write(buffer, binary.LittleEndian, packet.Type)
+	buffer.WriteString(packet.Body)
+	buffer.Write([]byte{0, 0})
+	var _, err = writer.Write(buffer.Bytes())
+	return err
+}
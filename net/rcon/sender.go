@@ -0,0 +1,36 @@
+package rcon
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// Sender is the command sender of commands executed over RCON.
+// It captures all messages sent to it, so they can be returned as the command output.
+// RCON clients have all permissions, as they authenticated with the RCON password.
+type Sender struct {
+	mutex  sync.Mutex
+	output []string
+}
+
+// HasPermission returns true, as RCON clients have all permissions.
+func (sender *Sender) HasPermission(string) bool {
+	return true
+}
+
+// SendMessage captures a message, with all colours stripped.
+func (sender *Sender) SendMessage(message ...interface{}) {
+	sender.mutex.Lock()
+	sender.output = append(sender.output, text.ColoredString(strings.TrimSuffix(fmt.Sprintln(message...), "\n")).StripAll())
+	sender.mutex.Unlock()
+}
+
+// GetOutput returns all messages captured, separated by newlines.
+func (sender *Sender) GetOutput() string {
+	sender.mutex.Lock()
+	defer sender.mutex.Unlock()
+	return strings.Join(sender.output, "\n")
+}
@@ -0,0 +1,77 @@
+package screening
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AddressPlaceholder is replaced with the IP address in the URL of HTTP providers.
+const AddressPlaceholder = "{ip}"
+
+var InvalidResponse = errors.New("invalid screening API response")
+
+// HTTPProvider screens IP addresses with a web API returning JSON.
+// The address is flagged if the configured field of the response is true,
+// or is a string equal to "yes" or "true", or a non-zero number.
+type HTTPProvider struct {
+	url    string
+	field  string
+	client *http.Client
+}
+
+// NewHTTPProvider returns a new HTTP provider requesting the given URL, in which AddressPlaceholder
+// is replaced with the screened address, and checking the given field of the response.
+// Fields of nested objects are separated by dots, for example "result.proxy".
+func NewHTTPProvider(url string, field string, timeout time.Duration) *HTTPProvider {
+	return &HTTPProvider{url, field, &http.Client{Timeout: timeout}}
+}
+
+// GetName returns the name of the provider.
+func (provider *HTTPProvider) GetName() string {
+	return "http"
+}
+
+// Screen requests the API for the IP address.
+func (provider *HTTPProvider) Screen(ip net.IP) (Result, error) {
+	var response, err = provider.client.Get(strings.Replace(provider.url, AddressPlaceholder, ip.String(), -1))
+	if err != nil {
+		return Result{}, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return Result{}, fmt.Errorf("screening API returned status %v", response.Status)
+	}
+
+	var body interface{}
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return Result{}, InvalidResponse
+	}
+	for _, key := range strings.Split(provider.field, ".") {
+		var object, ok = body.(map[string]interface{})
+		if !ok {
+			return Result{}, InvalidResponse
+		}
+		body = object[key]
+	}
+
+	var flagged bool
+	switch value := body.(type) {
+	case bool:
+		flagged = value
+	case string:
+		flagged = strings.EqualFold(value, "yes") || strings.EqualFold(value, "true")
+	case float64:
+		flagged = value != 0
+	case nil:
+		return Result{}, InvalidResponse
+	}
+	if !flagged {
+		return Result{}, nil
+	}
+	return Result{Flagged: true, Reason: provider.field}, nil
+}
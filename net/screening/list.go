@@ -0,0 +1,87 @@
+package screening
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ListProvider flags IP addresses that are in a local list of addresses and CIDR networks.
+type ListProvider struct {
+	mutex    sync.RWMutex
+	networks []*net.IPNet
+}
+
+// NewListProvider returns a new list provider flagging the given networks.
+func NewListProvider(networks ...*net.IPNet) *ListProvider {
+	return &ListProvider{networks: networks}
+}
+
+// LoadListProvider loads a list provider from the file at the given path.
+// The file contains one address or CIDR network per line. Empty lines and lines starting with # are ignored.
+// An empty list is returned if the file does not exist.
+func LoadListProvider(path string) (*ListProvider, error) {
+	var provider = NewListProvider()
+	var file, err = os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return provider, nil
+		}
+		return provider, err
+	}
+	defer file.Close()
+
+	var scanner = bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line = strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := provider.Add(line); err != nil {
+			return provider, err
+		}
+	}
+	return provider, scanner.Err()
+}
+
+// Add adds an address or CIDR network to the list.
+func (provider *ListProvider) Add(entry string) error {
+	if !strings.Contains(entry, "/") {
+		var ip = net.ParseIP(entry)
+		if ip == nil {
+			return &net.ParseError{Type: "IP address", Text: entry}
+		}
+		if ip.To4() != nil {
+			entry += "/32"
+		} else {
+			entry += "/128"
+		}
+	}
+	var _, network, err = net.ParseCIDR(entry)
+	if err != nil {
+		return err
+	}
+	provider.mutex.Lock()
+	provider.networks = append(provider.networks, network)
+	provider.mutex.Unlock()
+	return nil
+}
+
+// GetName returns the name of the provider.
+func (provider *ListProvider) GetName() string {
+	return "list"
+}
+
+// Screen flags the IP address if it is in any of the networks in the list.
+func (provider *ListProvider) Screen(ip net.IP) (Result, error) {
+	provider.mutex.RLock()
+	defer provider.mutex.RUnlock()
+	for _, network := range provider.networks {
+		if network.Contains(ip) {
+			return Result{Flagged: true, Reason: "listed in " + network.String()}, nil
+		}
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,114 @@
+package screening
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// Result is the result of screening an IP address.
+type Result struct {
+	// Flagged indicates if the address belongs to a VPN, proxy or datacenter.
+	Flagged bool
+	// Provider is the name of the provider that flagged the address.
+	Provider string
+	// Reason is a description of why the address was flagged, if known.
+	Reason string
+}
+
+// Provider screens IP addresses, for example by looking them up in a list or with a web API.
+type Provider interface {
+	// GetName returns the name of the provider.
+	GetName() string
+	// Screen screens the IP address.
+	Screen(ip net.IP) (Result, error)
+}
+
+// cacheEntry is a cached result with the time it expires.
+type cacheEntry struct {
+	result  Result
+	expires time.Time
+}
+
+// Screener screens IP addresses with multiple providers, and caches the results.
+// Providers are consulted in order, until one of them flags the address.
+type Screener struct {
+	mutex     sync.Mutex
+	providers []Provider
+	ttl       time.Duration
+	cache     map[string]cacheEntry
+}
+
+// NewScreener returns a new screener caching results for the given duration.
+func NewScreener(ttl time.Duration, providers ...Provider) *Screener {
+	return &Screener{providers: providers, ttl: ttl, cache: make(map[string]cacheEntry)}
+}
+
+// AddProvider adds a provider to the screener, consulted after all providers added before.
+func (screener *Screener) AddProvider(provider Provider) {
+	screener.mutex.Lock()
+	screener.providers = append(screener.providers, provider)
+	screener.mutex.Unlock()
+}
+
+// GetProviders returns all providers of the screener.
+func (screener *Screener) GetProviders() []Provider {
+	screener.mutex.Lock()
+	defer screener.mutex.Unlock()
+	return append([]Provider{}, screener.providers...)
+}
+
+// Screen screens the IP address with all providers, returning a cached result if there is one.
+// Results of providers that fail are not cached, and the error of the last failing provider is returned
+// if no provider flagged the address.
+func (screener *Screener) Screen(ip net.IP) (Result, error) {
+	var key = ip.String()
+	screener.mutex.Lock()
+	if entry, ok := screener.cache[key]; ok && time.Now().Before(entry.expires) {
+		screener.mutex.Unlock()
+		return entry.result, nil
+	}
+	screener.mutex.Unlock()
+
+	var result Result
+	var lastErr error
+	for _, provider := range screener.GetProviders() {
+		var providerResult, err = provider.Screen(ip)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if providerResult.Flagged {
+			providerResult.Provider = provider.GetName()
+			result, lastErr = providerResult, nil
+			break
+		}
+	}
+	if lastErr != nil {
+		return result, lastErr
+	}
+
+	screener.mutex.Lock()
+	screener.cache[key] = cacheEntry{result, time.Now().Add(screener.ttl)}
+	screener.mutex.Unlock()
+	return result, nil
+}
+
+// ClearCache removes all cached results.
+func (screener *Screener) ClearCache() {
+	screener.mutex.Lock()
+	screener.cache = make(map[string]cacheEntry)
+	screener.mutex.Unlock()
+}
+
+// Prune removes all cached results that have expired.
+func (screener *Screener) Prune() {
+	var now = time.Now()
+	screener.mutex.Lock()
+	for key, entry := range screener.cache {
+		if now.After(entry.expires) {
+			delete(screener.cache, key)
+		}
+	}
+	screener.mutex.Unlock()
+}
@@ -0,0 +1,106 @@
+package screening
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type countingProvider struct {
+	calls  int
+	result Result
+	err    error
+}
+
+func (provider *countingProvider) GetName() string {
+	return "counting"
+}
+
+func (provider *countingProvider) Screen(ip net.IP) (Result, error) {
+	provider.calls++
+	return provider.result, provider.err
+}
+
+func TestListProvider(t *testing.T) {
+	var directory, err = ioutil.TempDir("", "screening")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+	var path = filepath.Join(directory, "list.txt")
+	ioutil.WriteFile(path, []byte("# datacenters\n10.0.0.0/8\n\n192.168.1.5\n"), 0644)
+
+	provider, err := LoadListProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for ip, flagged := range map[string]bool{"10.1.2.3": true, "192.168.1.5": true, "192.168.1.6": false, "8.8.8.8": false} {
+		var result, _ = provider.Screen(net.ParseIP(ip))
+		if result.Flagged != flagged {
+			t.Errorf("%v: expected flagged %v, got %v", ip, flagged, result.Flagged)
+		}
+	}
+	if err := provider.Add("not an address"); err == nil {
+		t.Error("expected invalid entry to fail")
+	}
+}
+
+func TestScreenerCache(t *testing.T) {
+	var provider = &countingProvider{result: Result{Flagged: true}}
+	var screener = NewScreener(time.Minute, provider)
+	var ip = net.ParseIP("1.2.3.4")
+
+	for i := 0; i < 3; i++ {
+		var result, err = screener.Screen(ip)
+		if err != nil || !result.Flagged || result.Provider != "counting" {
+			t.Fatalf("unexpected result %+v, %v", result, err)
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("expected 1 provider call, got %v", provider.calls)
+	}
+	screener.ClearCache()
+	screener.Screen(ip)
+	if provider.calls != 2 {
+		t.Errorf("expected 2 provider calls after clearing, got %v", provider.calls)
+	}
+}
+
+func TestScreenerErrorsNotCached(t *testing.T) {
+	var provider = &countingProvider{err: errors.New("unavailable")}
+	var screener = NewScreener(time.Minute, provider)
+	var ip = net.ParseIP("1.2.3.4")
+
+	if _, err := screener.Screen(ip); err == nil {
+		t.Fatal("expected error")
+	}
+	screener.Screen(ip)
+	if provider.calls != 2 {
+		t.Errorf("expected failed results not to be cached, got %v calls", provider.calls)
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Query().Get("ip") == "5.6.7.8" {
+			writer.Write([]byte(`{"result": {"proxy": "yes"}}`))
+			return
+		}
+		writer.Write([]byte(`{"result": {"proxy": "no"}}`))
+	}))
+	defer server.Close()
+
+	var provider = NewHTTPProvider(server.URL+"/?ip="+AddressPlaceholder, "result.proxy", time.Second)
+	if result, err := provider.Screen(net.ParseIP("5.6.7.8")); err != nil || !result.Flagged {
+		t.Errorf("expected address to be flagged, got %+v, %v", result, err)
+	}
+	if result, err := provider.Screen(net.ParseIP("1.1.1.1")); err != nil || result.Flagged {
+		t.Errorf("expected address not to be flagged, got %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,31 @@
+package net
+
+import (
+	"github.com/BobbyShrd/gominetest/particles"
+	"github.com/golang/geo/r3"
+)
+
+// PlaySound plays the sound with the given name, as defined in the sound definitions of resource packs,
+// at the position for the session.
+func (session *MinecraftSession) PlaySound(name string, position r3.Vector, volume float32, pitch float32) {
+	session.SendPlaySound(name, position, volume, pitch)
+}
+
+// StopSound stops the sound with the given name for the session.
+// All sounds are stopped if the name is empty.
+func (session *MinecraftSession) StopSound(name string) {
+	session.SendStopSound(name, name == "")
+}
+
+// PlaySoundEvent plays the level sound event with the given ID at the position for the session.
+// IDs of sound events can be looked up by name in the sounds package.
+func (session *MinecraftSession) PlaySoundEvent(soundId uint32, position r3.Vector) {
+	session.SendLevelSoundEvent(soundId, position, -1)
+}
+
+// SpawnParticle spawns the particle with the given ID at the position for the session.
+// The data of the particle is used by some particles, such as the color of redstone particles.
+// IDs of particles can be looked up by name in the particles package.
+func (session *MinecraftSession) SpawnParticle(particleId int32, position r3.Vector, data int32) {
+	session.SendLevelEvent(particles.GetEventId(particleId), position, data)
+}
@@ -0,0 +1,110 @@
+package net
+
+import (
+	"github.com/BobbyShrd/gominetest/net/packets/data"
+	"github.com/BobbyShrd/gominetest/net/packets/types"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+// Title types of the SetTitle packet.
+const (
+	TitleClear int32 = iota
+	TitleReset
+	TitleTitle
+	TitleSubtitle
+	TitleActionBar
+	TitleTimes
+	TitleTitleJSON
+	TitleSubtitleJSON
+	TitleActionBarJSON
+)
+
+// Default timings of titles in ticks, as used by vanilla.
+const (
+	DefaultTitleFadeIn  int32 = 10
+	DefaultTitleStay    int32 = 70
+	DefaultTitleFadeOut int32 = 20
+)
+
+// MinimumJSONTitleProtocol is the first protocol supporting rawtext JSON titles.
+const MinimumJSONTitleProtocol int32 = 448
+
+// MinimumToastProtocol is the first protocol supporting toast notifications.
+const MinimumToastProtocol int32 = 527
+
+// SendTitle shows a title in the center of the screen of the session,
+// fading in, staying and fading out for the given amount of ticks.
+// A subtitle sent with SendSubtitle before the title is shown below it.
+func (session *MinecraftSession) SendTitle(title string, fadeIn int32, stay int32, fadeOut int32) {
+	session.SendTitleTimes(fadeIn, stay, fadeOut)
+	session.SendSetTitle(TitleTitle, title, fadeIn, stay, fadeOut)
+}
+
+// SendSubtitle sets the subtitle shown below the next title sent to the session,
+// or below the title currently shown.
+func (session *MinecraftSession) SendSubtitle(subtitle string) {
+	session.SendSetTitle(TitleSubtitle, subtitle, 0, 0, 0)
+}
+
+// SendActionBar shows a message above the hotbar of the session, using the current title timings.
+func (session *MinecraftSession) SendActionBar(message string) {
+	session.SendSetTitle(TitleActionBar, message, 0, 0, 0)
+}
+
+// SendTitleComponent shows a text component as title in the center of the screen of the session,
+// sent as rawtext JSON if the client supports it and as legacy text otherwise.
+func (session *MinecraftSession) SendTitleComponent(title *text.Component, fadeIn int32, stay int32, fadeOut int32) {
+	session.SendTitleTimes(fadeIn, stay, fadeOut)
+	session.sendTitleComponent(TitleTitle, TitleTitleJSON, title, fadeIn, stay, fadeOut)
+}
+
+// SendSubtitleComponent sets a text component as subtitle shown below the next or current title of the session.
+func (session *MinecraftSession) SendSubtitleComponent(subtitle *text.Component) {
+	session.sendTitleComponent(TitleSubtitle, TitleSubtitleJSON, subtitle, 0, 0, 0)
+}
+
+// SendActionBarComponent shows a text component above the hotbar of the session, using the current title timings.
+func (session *MinecraftSession) SendActionBarComponent(message *text.Component) {
+	session.sendTitleComponent(TitleActionBar, TitleActionBarJSON, message, 0, 0, 0)
+}
+
+// sendTitleComponent sends the component with the JSON title type if the client supports it,
+// and with the legacy title type otherwise.
+func (session *MinecraftSession) sendTitleComponent(legacyType int32, jsonType int32, component *text.Component, fadeIn int32, stay int32, fadeOut int32) {
+	if session.protocolNumber >= MinimumJSONTitleProtocol {
+		session.SendSetTitle(jsonType, component.RawText(), fadeIn, stay, fadeOut)
+		return
+	}
+	session.SendSetTitle(legacyType, component.String(), fadeIn, stay, fadeOut)
+}
+
+// SendTitleTimes sets the amount of ticks titles and action bar messages fade in, stay and fade out.
+func (session *MinecraftSession) SendTitleTimes(fadeIn int32, stay int32, fadeOut int32) {
+	session.SendSetTitle(TitleTimes, "", fadeIn, stay, fadeOut)
+}
+
+// ClearTitle removes the title currently shown to the session.
+func (session *MinecraftSession) ClearTitle() {
+	session.SendSetTitle(TitleClear, "", 0, 0, 0)
+}
+
+// ResetTitle removes the title currently shown to the session,
+// and resets its subtitle and title timings.
+func (session *MinecraftSession) ResetTitle() {
+	session.SendSetTitle(TitleReset, "", 0, 0, 0)
+}
+
+// SendPopup shows a popup message above the hotbar of the session.
+func (session *MinecraftSession) SendPopup(message string) {
+	session.SendText(types.Text{Message: message, TextType: data.TextPopup})
+}
+
+// SendToast shows a toast notification with a title and message at the top of the screen of the session.
+// Clients that do not support toasts get the title and message as popup.
+func (session *MinecraftSession) SendToast(title string, message string) {
+	if session.protocolNumber < MinimumToastProtocol {
+		session.SendPopup(title + "\n" + message)
+		return
+	}
+	session.SendToastRequest(title, message)
+}
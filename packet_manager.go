@@ -3,6 +3,9 @@ package gomine
 import (
 	"github.com/golang/geo/r3"
 	"github.com/google/uuid"
+	"github.com/BobbyShrd/gominetest/commands"
+	"github.com/BobbyShrd/gominetest/commands/arguments"
+	"github.com/BobbyShrd/gominetest/items"
 	"github.com/BobbyShrd/gominetest/net/info"
 	"github.com/BobbyShrd/gominetest/net/packets"
 	"github.com/BobbyShrd/gominetest/net/packets/bedrock"
@@ -21,6 +24,12 @@ type PacketManager struct {
 	*protocol.PacketManagerBase
 }
 
+// platformChatEntry is a player list entry of a player with a platform chat ID.
+// Players on platforms with platform chat show their platform chat ID in the player list.
+type platformChatEntry interface {
+	GetPlatformChatId() string
+}
+
 func NewPacketManager(server *Server) *PacketManager {
 	var ids = info.PacketIds
 	var proto = &PacketManager{protocol.NewPacketManagerBase(info.PacketIds, map[int]func() packets.IPacket{
@@ -38,6 +47,16 @@ func NewPacketManager(server *Server) *PacketManager {
 		ids[info.PlayerActionPacket]:               func() packets.IPacket { return bedrock.NewPlayerActionPacket() },
 		ids[info.AnimatePacket]:                    func() packets.IPacket { return bedrock.NewAnimatePacket() },
 		ids[info.InventoryTransactionPacket]:       func() packets.IPacket { return bedrock.NewInventoryTransactionPacket() },
+		ids[info.ModalFormResponsePacket]:          func() packets.IPacket { return bedrock.NewModalFormResponsePacket() },
+		ids[info.BlockEntityDataPacket]:            func() packets.IPacket { return bedrock.NewBlockEntityDataPacket() },
+		ids[info.ContainerClosePacket]:             func() packets.IPacket { return bedrock.NewContainerClosePacket() },
+		ids[info.MoveEntityPacket]:                 func() packets.IPacket { return bedrock.NewMoveEntityPacket() },
+		ids[info.PlayerInputPacket]:                func() packets.IPacket { return bedrock.NewPlayerInputPacket() },
+		ids[info.PlayerSkinPacket]:                 func() packets.IPacket { return bedrock.NewPlayerSkinPacket() },
+		ids[info.NetworkStackLatencyPacket]:        func() packets.IPacket { return bedrock.NewNetworkStackLatencyPacket() },
+		ids[info.TickSyncPacket]:                   func() packets.IPacket { return bedrock.NewTickSyncPacket() },
+		ids[info.AdventureSettingsPacket]:          func() packets.IPacket { return bedrock.NewAdventureSettingsPacket() },
+		ids[info.CommandBlockUpdatePacket]:         func() packets.IPacket { return bedrock.NewCommandBlockUpdatePacket() },
 	}, map[int][][]protocol.Handler{})}
 	proto.initHandlers(server)
 
@@ -56,7 +75,19 @@ func (protocol *PacketManager) initHandlers(server *Server) {
 	protocol.RegisterHandler(info.InteractPacket, NewInteractHandler(server))
 	protocol.RegisterHandler(info.PlayerActionPacket, NewPlayerActionHandler(server))
 	protocol.RegisterHandler(info.AnimatePacket, NewAnimateHandler(server))
+	protocol.RegisterHandler(info.MobEquipmentPacket, NewMobEquipmentHandler(server))
 	protocol.RegisterHandler(info.InventoryTransactionPacket, NewInventoryTransactionHandler(server))
+	protocol.RegisterHandler(info.ModalFormResponsePacket, NewModalFormResponseHandler(server))
+	protocol.RegisterHandler(info.BlockEntityDataPacket, NewBlockEntityDataHandler(server))
+	protocol.RegisterHandler(info.ContainerClosePacket, NewContainerCloseHandler(server))
+	protocol.RegisterHandler(info.MoveEntityPacket, NewMoveEntityHandler(server))
+	protocol.RegisterHandler(info.PlayerInputPacket, NewPlayerInputHandler(server))
+	protocol.RegisterHandler(info.PlayerSkinPacket, NewPlayerSkinHandler(server))
+	protocol.RegisterHandler(info.NetworkStackLatencyPacket, NewNetworkStackLatencyHandler(server))
+	protocol.RegisterHandler(info.TickSyncPacket, NewTickSyncHandler(server))
+	protocol.RegisterHandler(info.AdventureSettingsPacket, NewAdventureSettingsHandler(server))
+	protocol.RegisterHandler(info.SetDifficultyPacket, NewSetDifficultyHandler(server))
+	protocol.RegisterHandler(info.CommandBlockUpdatePacket, NewCommandBlockUpdateHandler(server))
 }
 
 func (protocol *PacketManager) GetAddEntity(entity protocol.AddEntityEntry) packets.IPacket {
@@ -126,6 +157,10 @@ func (protocol *PacketManager) GetMovePlayer(runtimeId uint64, position r3.Vecto
 	return pk
 }
 
+// GetPlayerList returns a player list packet adding or removing the entries.
+// Entries have no latency field, so the latency of players is not sent to clients.
+// Entries are not refreshed on the latency interval either: nothing in them changes with the latency,
+// and every entry carries the skin of the player, which would be re-sent to every player each interval.
 func (protocol *PacketManager) GetPlayerList(listType byte, players map[string]protocol.PlayerListEntry) packets.IPacket {
 	var pk = bedrock.NewPlayerListPacket()
 	pk.ListType = listType
@@ -144,6 +179,11 @@ func (protocol *PacketManager) GetPlayerList(listType byte, players map[string]p
 			GeometryName:   player.GetGeometryName(),
 			GeometryData:   player.GetGeometryData(),
 		}
+		if chatPlayer, ok := player.(platformChatEntry); ok {
+			var entry = entries[name]
+			entry.PlatformChatId = chatPlayer.GetPlatformChatId()
+			entries[name] = entry
+		}
 	}
 	pk.Entries = entries
 
@@ -174,18 +214,18 @@ func (protocol *PacketManager) GetResourcePackChunkData(packUUID string, chunkIn
 	return pk
 }
 
-func (protocol *PacketManager) GetResourcePackDataInfo(pack packs.Pack) packets.IPacket {
+func (protocol *PacketManager) GetResourcePackDataInfo(pack packs.Pack, chunkSize int32) packets.IPacket {
 	var pk = bedrock.NewResourcePackDataInfoPacket()
 	pk.PackUUID = pack.GetUUID()
-	pk.MaxChunkSize = data.ResourcePackChunkSize
-	pk.ChunkCount = int32(math.Ceil(float64(pack.GetFileSize()) / float64(data.ResourcePackChunkSize)))
+	pk.MaxChunkSize = chunkSize
+	pk.ChunkCount = int32(math.Ceil(float64(pack.GetFileSize()) / float64(chunkSize)))
 	pk.CompressedPackSize = pack.GetFileSize()
 	pk.Sha256 = pack.GetSha256()
 
 	return pk
 }
 
-func (protocol *PacketManager) GetResourcePackInfo(mustAccept bool, resourcePacks *packs.Stack, behaviorPacks *packs.Stack) packets.IPacket {
+func (protocol *PacketManager) GetResourcePackInfo(mustAccept bool, resourcePacks *packs.Stack, behaviorPacks *packs.Stack, packURLs map[string]string) packets.IPacket {
 	var pk = bedrock.NewResourcePackInfoPacket()
 	pk.MustAccept = mustAccept
 
@@ -209,6 +249,14 @@ func (protocol *PacketManager) GetResourcePackInfo(mustAccept bool, resourcePack
 	pk.ResourcePacks = resourceEntries
 	pk.BehaviorPacks = behaviorEntries
 
+	var urls []types.PackURL
+	for _, pack := range *resourcePacks {
+		if packURL, ok := packURLs[pack.GetUUID()]; ok {
+			urls = append(urls, types.PackURL{UUIDVersion: pack.GetUUID() + "_" + pack.GetVersion(), URL: packURL})
+		}
+	}
+	pk.PackURLs = urls
+
 	return pk
 }
 
@@ -251,32 +299,27 @@ func (protocol *PacketManager) GetSetEntityData(runtimeId uint64, data map[uint3
 	return pk
 }
 
-func (protocol *PacketManager) GetStartGame(player protocol.StartGameEntry, runtimeIdsTable []byte) packets.IPacket {
+func (protocol *PacketManager) GetStartGame(player protocol.StartGameEntry, seed int64, spawn blocks.Position, runtimeIdsTable []byte, blockProperties []types.BlockProperty, gameRules map[string]types.GameRuleEntry, difficulty int32) packets.IPacket {
 	var pk = bedrock.NewStartGamePacket()
 	pk.Generator = 1
-	pk.LevelSeed = 312402
+	pk.LevelSeed = int32(seed)
 	pk.DefaultPermissionLevel = permissions.LevelMember
 	pk.EntityRuntimeId = player.GetRuntimeId()
 	pk.EntityUniqueId = player.GetUniqueId()
 	pk.PlayerGameMode = 1
 	pk.PlayerPosition = player.GetPosition()
 	pk.LevelGameMode = 1
-	pk.LevelSpawnPosition = blocks.NewPosition(0, 7, 0)
+	pk.Difficulty = difficulty
+	pk.LevelSpawnPosition = spawn
 	pk.CommandsEnabled = true
-
-	var gameRules = player.GetDimension().GetLevel().GetGameRules()
-	var gameRuleEntries = map[string]types.GameRuleEntry{}
-	for name, gameRule := range gameRules {
-		gameRuleEntries[string(name)] = types.GameRuleEntry{Name: string(gameRule.GetName()), Value: gameRule.GetValue()}
-	}
-
-	pk.GameRules = gameRuleEntries
+	pk.GameRules = gameRules
 	pk.LevelName = player.GetDimension().GetLevel().GetName()
 	pk.CurrentTick = player.GetDimension().GetLevel().GetCurrentTick()
 	pk.Time = 0
 	pk.AchievementsDisabled = true
 	pk.BroadcastToLan = true
 	pk.RuntimeIdsTable = runtimeIdsTable
+	pk.BlockProperties = blockProperties
 
 	pk.PlatformBroadcastIntent = bedrock.GameBroadcastSettingPublic
 	pk.XBOXBroadcastIntent = bedrock.GameBroadcastSettingPublic
@@ -377,4 +420,399 @@ func (protocol *PacketManager) GetUpdateBlock(position blocks.Position, blockRun
 	pk.DataLayerId = dataLayerId
 
 	return pk
-}
\ No newline at end of file
+}
+
+func (protocol *PacketManager) GetLevelEvent(eventId int32, position r3.Vector, data int32) packets.IPacket {
+	var pk = bedrock.NewLevelEventPacket()
+
+	pk.EventId = eventId
+	pk.Position = position
+	pk.Data = data
+
+	return pk
+}
+
+func (protocol *PacketManager) GetModalFormRequest(formId uint32, formData string) packets.IPacket {
+	var pk = bedrock.NewModalFormRequestPacket()
+
+	pk.FormId = formId
+	pk.FormData = formData
+
+	return pk
+}
+
+func (protocol *PacketManager) GetBlockEvent(position blocks.Position, eventType int32, eventData int32) packets.IPacket {
+	var pk = bedrock.NewBlockEventPacket()
+
+	pk.Position = position
+	pk.EventType = eventType
+	pk.EventData = eventData
+
+	return pk
+}
+
+func (protocol *PacketManager) GetEntityEvent(runtimeId uint64, eventId byte, eventData int32) packets.IPacket {
+	var pk = bedrock.NewEntityEventPacket()
+
+	pk.RuntimeId = runtimeId
+	pk.EventId = eventId
+	pk.EventData = eventData
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetEntityMotion(runtimeId uint64, motion r3.Vector) packets.IPacket {
+	var pk = bedrock.NewSetEntityMotionPacket()
+
+	pk.RuntimeId = runtimeId
+	pk.Motion = motion
+
+	return pk
+}
+
+func (protocol *PacketManager) GetLevelSoundEvent(soundId uint32, position r3.Vector, extraData int32) packets.IPacket {
+	var pk = bedrock.NewLevelSoundEventPacket()
+
+	pk.SoundId = soundId
+	pk.Position = position
+	pk.ExtraData = extraData
+	pk.EntityType = ":"
+
+	return pk
+}
+
+func (protocol *PacketManager) GetCreativeContent(stacks []*items.Stack) packets.IPacket {
+	var pk = bedrock.NewCreativeContentPacket()
+
+	pk.Items = stacks
+
+	return pk
+}
+
+func (protocol *PacketManager) GetInventoryContent(windowId uint32, stacks []*items.Stack) packets.IPacket {
+	var pk = bedrock.NewInventoryContentPacket()
+
+	pk.WindowId = windowId
+	pk.Items = stacks
+
+	return pk
+}
+
+func (protocol *PacketManager) GetAddItemEntity(runtimeId uint64, item *items.Stack, position r3.Vector, motion r3.Vector) packets.IPacket {
+	var pk = bedrock.NewAddItemEntityPacket()
+
+	pk.EntityUniqueId = int64(runtimeId)
+	pk.EntityRuntimeId = runtimeId
+	pk.Item = item
+	pk.Position = position
+	pk.Motion = motion
+
+	return pk
+}
+
+func (protocol *PacketManager) GetTakeItemEntity(itemRuntimeId uint64, takerRuntimeId uint64) packets.IPacket {
+	var pk = bedrock.NewTakeItemEntityPacket()
+
+	pk.ItemRuntimeId = itemRuntimeId
+	pk.TakerRuntimeId = takerRuntimeId
+
+	return pk
+}
+
+func (protocol *PacketManager) GetMobEffect(runtimeId uint64, eventId byte, effectId int32, amplifier int32, particles bool, duration int32) packets.IPacket {
+	var pk = bedrock.NewMobEffectPacket()
+
+	pk.RuntimeId = runtimeId
+	pk.EventId = eventId
+	pk.EffectId = effectId
+	pk.Amplifier = amplifier
+	pk.Particles = particles
+	pk.Duration = duration
+
+	return pk
+}
+
+func (protocol *PacketManager) GetChangeDimension(dimension int32, position r3.Vector, respawn bool) packets.IPacket {
+	var pk = bedrock.NewChangeDimensionPacket()
+
+	pk.Dimension = dimension
+	pk.Position = position
+	pk.Respawn = respawn
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetPlayerGameType(gameMode int32) packets.IPacket {
+	var pk = bedrock.NewSetPlayerGameTypePacket()
+
+	pk.GameMode = gameMode
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetDifficulty(difficulty uint32) packets.IPacket {
+	var pk = bedrock.NewSetDifficultyPacket()
+
+	pk.Difficulty = difficulty
+
+	return pk
+}
+
+func (protocol *PacketManager) GetBlockEntityData(position blocks.Position, namedTag []byte) packets.IPacket {
+	var pk = bedrock.NewBlockEntityDataPacket()
+
+	pk.Position = position
+	pk.NamedTag = namedTag
+
+	return pk
+}
+
+func (protocol *PacketManager) GetContainerOpen(windowId byte, windowType byte, position blocks.Position) packets.IPacket {
+	var pk = bedrock.NewContainerOpenPacket()
+
+	pk.WindowId = windowId
+	pk.ContainerType = windowType
+	pk.Position = position
+	pk.EntityUniqueId = -1
+
+	return pk
+}
+
+func (protocol *PacketManager) GetContainerClose(windowId byte) packets.IPacket {
+	var pk = bedrock.NewContainerClosePacket()
+
+	pk.WindowId = windowId
+
+	return pk
+}
+
+func (protocol *PacketManager) GetInventorySlot(windowId uint32, slot uint32, item *items.Stack) packets.IPacket {
+	var pk = bedrock.NewInventorySlotPacket()
+
+	pk.ContainerId = windowId
+	pk.Slot = slot
+	pk.Item = item
+
+	return pk
+}
+
+func (protocol *PacketManager) GetCommandOutput(origin types.CommandOrigin, output *commands.Output) packets.IPacket {
+	var pk = bedrock.NewCommandOutputPacket()
+
+	pk.CommandOrigin = origin
+	pk.OutputType = data.CommandOutputAll
+	pk.SuccessCount = uint32(output.GetSuccessCount())
+	for _, message := range output.GetMessages() {
+		pk.OutputMessages = append(pk.OutputMessages, types.CommandOutputMessage{IsInternal: message.Translated, MessageId: message.Text, Parameters: message.Parameters})
+	}
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetEntityLink(vehicleId uint64, riderId uint64, linkType byte) packets.IPacket {
+	var pk = bedrock.NewSetEntityLinkPacket()
+
+	pk.FromRuntimeId = vehicleId
+	pk.ToRuntimeId = riderId
+	pk.Type = linkType
+
+	return pk
+}
+
+func (protocol *PacketManager) GetUpdateTrade(windowId byte, windowType byte, tier int32, traderUniqueId int64, playerUniqueId int64, displayName string, offers []byte) packets.IPacket {
+	var pk = bedrock.NewUpdateTradePacket()
+
+	pk.WindowId = windowId
+	pk.WindowType = windowType
+	pk.TradeTier = tier
+	pk.TraderUniqueId = traderUniqueId
+	pk.PlayerUniqueId = playerUniqueId
+	pk.DisplayName = displayName
+	pk.NewTradeUI = true
+	pk.NamedTag = offers
+
+	return pk
+}
+
+func (protocol *PacketManager) GetMobEquipment(runtimeId uint64, item *items.Stack, inventorySlot byte, hotbarSlot byte, windowId byte) packets.IPacket {
+	var pk = bedrock.NewMobEquipmentPacket()
+
+	pk.RuntimeId = runtimeId
+	pk.Item = item
+	pk.InventorySlot = inventorySlot
+	pk.HotbarSlot = hotbarSlot
+	pk.WindowId = windowId
+
+	return pk
+}
+
+func (protocol *PacketManager) GetMobArmorEquipment(runtimeId uint64, armor [4]*items.Stack) packets.IPacket {
+	var pk = bedrock.NewMobArmorEquipmentPacket()
+
+	pk.RuntimeId = runtimeId
+	pk.Slots = armor
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetDisplayObjective(displaySlot string, objectiveName string, displayName string, criteria string, sortOrder int32) packets.IPacket {
+	var pk = bedrock.NewSetDisplayObjectivePacket()
+
+	pk.DisplaySlot = displaySlot
+	pk.ObjectiveName = objectiveName
+	pk.DisplayName = displayName
+	pk.CriteriaName = criteria
+	pk.SortOrder = sortOrder
+
+	return pk
+}
+
+func (protocol *PacketManager) GetRemoveObjective(objectiveName string) packets.IPacket {
+	var pk = bedrock.NewRemoveObjectivePacket()
+
+	pk.ObjectiveName = objectiveName
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetScore(actionType byte, entries []types.ScoreboardEntry) packets.IPacket {
+	var pk = bedrock.NewSetScorePacket()
+
+	pk.ActionType = actionType
+	pk.Entries = entries
+
+	return pk
+}
+
+func (protocol *PacketManager) GetNetworkStackLatency(timestamp uint64, needsResponse bool) packets.IPacket {
+	var pk = bedrock.NewNetworkStackLatencyPacket()
+
+	pk.Timestamp = timestamp
+	pk.NeedsResponse = needsResponse
+
+	return pk
+}
+
+func (protocol *PacketManager) GetTickSync(clientRequestTimestamp int64, serverReceptionTimestamp int64) packets.IPacket {
+	var pk = bedrock.NewTickSyncPacket()
+
+	pk.ClientRequestTimestamp = clientRequestTimestamp
+	pk.ServerReceptionTimestamp = serverReceptionTimestamp
+
+	return pk
+}
+
+func (protocol *PacketManager) GetAdventureSettings(flags uint32, commandPermissionLevel uint32, actionPermissions uint32, permissionLevel uint32, uniqueId int64) packets.IPacket {
+	var pk = bedrock.NewAdventureSettingsPacket()
+
+	pk.Flags = flags
+	pk.CommandPermissionLevel = commandPermissionLevel
+	pk.ActionPermissions = actionPermissions
+	pk.PermissionLevel = permissionLevel
+	pk.EntityUniqueId = uniqueId
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetTitle(titleType int32, text string, fadeInTime int32, stayTime int32, fadeOutTime int32) packets.IPacket {
+	var pk = bedrock.NewSetTitlePacket()
+
+	pk.TitleType = titleType
+	pk.Text = text
+	pk.FadeInTime = fadeInTime
+	pk.StayTime = stayTime
+	pk.FadeOutTime = fadeOutTime
+
+	return pk
+}
+
+func (protocol *PacketManager) GetToastRequest(title string, message string) packets.IPacket {
+	var pk = bedrock.NewToastRequestPacket()
+
+	pk.Title = title
+	pk.Message = message
+
+	return pk
+}
+
+func (protocol *PacketManager) GetPlaySound(soundName string, position r3.Vector, volume float32, pitch float32) packets.IPacket {
+	var pk = bedrock.NewPlaySoundPacket()
+
+	pk.SoundName = soundName
+	pk.Position = position
+	pk.Volume = volume
+	pk.Pitch = pitch
+
+	return pk
+}
+
+func (protocol *PacketManager) GetStopSound(soundName string, stopAll bool) packets.IPacket {
+	var pk = bedrock.NewStopSoundPacket()
+
+	pk.SoundName = soundName
+	pk.StopAll = stopAll
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetTime(time int32) packets.IPacket {
+	var pk = bedrock.NewSetTimePacket()
+
+	pk.Time = time
+
+	return pk
+}
+
+func (protocol *PacketManager) GetGameRulesChanged(gameRules map[string]types.GameRuleEntry) packets.IPacket {
+	var pk = bedrock.NewGameRulesChangedPacket()
+
+	pk.GameRules = gameRules
+
+	return pk
+}
+
+func (protocol *PacketManager) GetAvailableCommands(commandList []*commands.Command) packets.IPacket {
+	var pk = bedrock.NewAvailableCommandsPacket()
+
+	var valueIndices = make(map[string]uint32)
+	var enumIndices = make(map[string]uint32)
+	var addEnum = func(name string, values []string) uint32 {
+		if index, ok := enumIndices[name]; ok {
+			return index
+		}
+		var enum = types.CommandEnum{Name: name}
+		for _, value := range values {
+			var index, ok = valueIndices[value]
+			if !ok {
+				index = uint32(len(pk.EnumValues))
+				valueIndices[value] = index
+				pk.EnumValues = append(pk.EnumValues, value)
+			}
+			enum.ValueIndices = append(enum.ValueIndices, index)
+		}
+		enumIndices[name] = uint32(len(pk.Enums))
+		pk.Enums = append(pk.Enums, enum)
+		return enumIndices[name]
+	}
+
+	for _, command := range commandList {
+		var commandData = types.CommandData{Name: command.GetName(), Description: command.GetDescription(), AliasesEnum: -1}
+		if len(command.GetAliases()) != 0 {
+			commandData.AliasesEnum = int32(addEnum(command.GetName()+"Aliases", append([]string{command.GetName()}, command.GetAliases()...)))
+		}
+
+		var overload = make([]types.CommandParameter, 0, len(command.GetArguments()))
+		for _, argument := range command.GetArguments() {
+			var parameterType = arguments.FlagValid | argument.GetParameterType()
+			if argument.IsEnum() {
+				parameterType = arguments.FlagValid | arguments.FlagEnum | addEnum(argument.GetEnumName(), argument.GetEnumValues())
+			}
+			overload = append(overload, types.CommandParameter{Name: argument.GetName(), Type: parameterType, Optional: argument.IsOptional()})
+		}
+		commandData.Overloads = [][]types.CommandParameter{overload}
+
+		pk.Commands = append(pk.Commands, commandData)
+	}
+
+	return pk
+}
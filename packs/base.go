@@ -4,11 +4,9 @@ import (
 	"archive/zip"
 	"crypto/sha256"
 	"encoding/json"
-	"errors"
 	"io/ioutil"
 	"os"
 	"strconv"
-	"strings"
 )
 
 const (
@@ -76,29 +74,36 @@ func newBase(path string, packType PackType) *Base {
 	return &Base{path, &Manifest{}, content, int64(len(content)), shaBytes, packType}
 }
 
-// Load loads the pack, and returns an error if any.
+// Load loads the manifest of the pack, and returns an error if any.
 func (pack *Base) Load() error {
 	var zipFile, err = zip.OpenReader(pack.packPath)
 	if err != nil {
-		panic(err)
+		return newLoadError(pack.packPath, err, "")
 	}
+	defer zipFile.Close()
 
 	for _, file := range zipFile.File {
 		if file.Name != "manifest.json" && file.Name != "pack_manifest.json" {
 			continue
 		}
-		reader, _ := file.Open()
-		bytes, _ := ioutil.ReadAll(reader)
+		reader, err := file.Open()
+		if err != nil {
+			return newLoadError(pack.packPath, err, file.Name)
+		}
+		bytes, err := ioutil.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return newLoadError(pack.packPath, err, file.Name)
+		}
 
 		manifest := &Manifest{}
-		err := json.Unmarshal(bytes, manifest)
+		if err := json.Unmarshal(bytes, manifest); err != nil {
+			return newLoadError(pack.packPath, InvalidManifest, err.Error())
+		}
 		pack.manifest = manifest
-
-		reader.Close()
-
-		return err
+		return nil
 	}
-	return errors.New("No manifest.json or pack_manifest.json could be found in zip: " + pack.packPath)
+	return newLoadError(pack.packPath, NoManifest, "")
 }
 
 // GetPath returns the path of the pack.
@@ -136,25 +141,34 @@ func (pack *Base) GetContent() []byte {
 	return pack.content
 }
 
-// ValidateManifest validates the manifest, and returns an error if any.
+// moduleTypes are the types modules of packs may have.
+var moduleTypes = map[string]bool{
+	"resources":      true,
+	"data":           true,
+	"client_data":    true,
+	"interface":      true,
+	"world_template": true,
+	"script":         true,
+	"javascript":     true,
+	"skin_pack":      true,
+}
+
+// ValidateManifest validates the header and modules of the manifest, and returns an error if any.
 func (pack *Base) ValidateManifest() error {
 	var manifest = pack.manifest
 	if manifest.Header.Description == "" {
-		return errors.New("Pack at " + pack.packPath + " is missing a description.")
+		return newLoadError(pack.packPath, MissingDescription, "header")
 	}
 	if manifest.Header.Name == "" {
-		return errors.New("Pack at " + pack.packPath + " is missing a name.")
+		return newLoadError(pack.packPath, MissingName, "header")
 	}
-
-	if len(manifest.Header.Version) < 2 {
-		return errors.New("Pack at " + pack.packPath + " is missing a valid version.")
+	if !isValidUUID(manifest.Header.UUID) {
+		return newLoadError(pack.packPath, InvalidUUID, "header: "+manifest.Header.UUID)
 	}
-
-	var versionStrings []string
-	for _, versionNumber := range manifest.Header.Version {
-		versionStrings = append(versionStrings, strconv.Itoa(int(versionNumber)))
+	if !isValidVersion(manifest.Header.Version) {
+		return newLoadError(pack.packPath, InvalidVersion, "header")
 	}
-	manifest.Header.VersionString = strings.Join(versionStrings, ".")
+	manifest.Header.VersionString = versionString(manifest.Header.Version)
 
 	return pack.ValidateModules()
 }
@@ -163,26 +177,54 @@ func (pack *Base) ValidateManifest() error {
 func (pack *Base) ValidateModules() error {
 	var modules = pack.manifest.Modules
 	if len(modules) == 0 {
-		return errors.New("Pack at " + pack.packPath + " doesn't have any modules.")
+		return newLoadError(pack.packPath, MissingModule, "manifest has no modules")
 	}
 
+	var uuids = make(map[string]bool, len(modules))
 	for index, module := range modules {
-		if module.Description == "" {
-			return errors.New("Module " + strconv.Itoa(index) + " in pack at " + pack.packPath + " is missing a description.")
+		var detail = "module " + strconv.Itoa(index)
+		if !isValidUUID(module.UUID) {
+			return newLoadError(pack.packPath, InvalidUUID, detail+": "+module.UUID)
 		}
-
-		if len(module.Version) < 2 {
-			return errors.New("Module " + strconv.Itoa(index) + " in pack at " + pack.packPath + " is missing a valid version.")
+		if module.UUID == pack.manifest.Header.UUID {
+			return newLoadError(pack.packPath, InvalidUUID, detail+": module UUID equals the header UUID")
 		}
-
-		if module.Type == "" {
-			return errors.New("Module " + strconv.Itoa(index) + " in pack at " + pack.packPath + " is missing a valid type.")
+		if uuids[module.UUID] {
+			return newLoadError(pack.packPath, DuplicateUUID, detail+": "+module.UUID+" is also used by another module")
+		}
+		uuids[module.UUID] = true
+		if !isValidVersion(module.Version) {
+			return newLoadError(pack.packPath, InvalidVersion, detail)
 		}
+		if !moduleTypes[module.Type] {
+			return newLoadError(pack.packPath, InvalidModuleType, detail+": '"+module.Type+"'")
+		}
+	}
+	if !pack.hasModule(packModules[pack.packType]...) {
+		return newLoadError(pack.packPath, MissingModule, "no module of type '"+string(pack.packType)+"'")
 	}
 
 	return nil
 }
 
+// packModules are the module types of which packs of a pack type need at least one.
+var packModules = map[PackType][]string{
+	Resource: {"resources"},
+	Behavior: {"data", "script", "javascript"},
+}
+
+// hasModule checks if the pack has a module of any of the module types.
+func (pack *Base) hasModule(moduleTypes ...string) bool {
+	for _, module := range pack.manifest.Modules {
+		for _, moduleType := range moduleTypes {
+			if module.Type == moduleType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetChunk returns a chunk of the pack at the given offset with the given length.
 func (pack *Base) GetChunk(offset int, length int) []byte {
 	if offset > len(pack.content) || offset < 0 || length < 1 {
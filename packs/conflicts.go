@@ -0,0 +1,43 @@
+package packs
+
+import "strconv"
+
+// getUUIDs returns the header UUID of the pack, followed by the UUIDs of its modules.
+func (pack *Base) getUUIDs() []string {
+	var uuids = []string{pack.manifest.Header.UUID}
+	for _, module := range pack.manifest.Modules {
+		uuids = append(uuids, module.UUID)
+	}
+	return uuids
+}
+
+// uuidOwner returns the pack of the packs that has the UUID as header or module UUID,
+// and a bool indicating if any pack has.
+func uuidOwner(uuid string, packs []*Base) (*Base, bool) {
+	for _, pack := range packs {
+		for _, packUUID := range pack.getUUIDs() {
+			if packUUID == uuid {
+				return pack, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// findConflict returns an error if the header or a module of the pack has a UUID
+// that is already used by the header or a module of any of the packs, or nil if none is.
+// Clients refuse pack stacks with conflicting UUIDs, so the pack that conflicts is left out.
+func (pack *Base) findConflict(packs []*Base) error {
+	for index, uuid := range pack.getUUIDs() {
+		var owner, ok = uuidOwner(uuid, packs)
+		if !ok {
+			continue
+		}
+		var detail = "header"
+		if index > 0 {
+			detail = "module " + strconv.Itoa(index-1)
+		}
+		return newLoadError(pack.packPath, DuplicateUUID, detail+": "+uuid+" is also used by "+owner.packPath)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package packs
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// uuidPattern matches UUIDs in their canonical textual form.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// isValidUUID checks if the string is a UUID in its canonical textual form.
+func isValidUUID(uuid string) bool {
+	return uuidPattern.MatchString(uuid)
+}
+
+// isValidVersion checks if the version has a major, minor and patch number that are not negative.
+// Older manifests may leave out the patch number.
+func isValidVersion(version []float64) bool {
+	if len(version) < 2 || len(version) > 3 {
+		return false
+	}
+	for _, number := range version {
+		if number < 0 || number != float64(int(number)) {
+			return false
+		}
+	}
+	return true
+}
+
+// versionString returns the version joined by dots, such as "1.2.0".
+func versionString(version []float64) string {
+	var numbers = make([]string, len(version))
+	for i, number := range version {
+		numbers[i] = strconv.Itoa(int(number))
+	}
+	return strings.Join(numbers, ".")
+}
+
+// compareVersions returns -1 if version a is lower than b, 1 if it is higher, and 0 if they are equal.
+// Missing numbers count as 0.
+func compareVersions(a []float64, b []float64) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y float64
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x < y {
+			return -1
+		}
+		if x > y {
+			return 1
+		}
+	}
+	return 0
+}
+
+// isCompatible checks if a pack with the loaded version satisfies a dependency on the required version:
+// the major versions are equal, and the loaded version is at least the required version.
+func isCompatible(loaded []float64, required []float64) bool {
+	return len(loaded) != 0 && len(required) != 0 && loaded[0] == required[0] && compareVersions(loaded, required) >= 0
+}
+
+// GetDependencies returns the UUIDs of the packs the pack depends on.
+func (pack *Base) GetDependencies() []string {
+	var dependencies = make([]string, len(pack.manifest.Dependencies))
+	for i, dependency := range pack.manifest.Dependencies {
+		dependencies[i] = dependency.UUID
+	}
+	return dependencies
+}
+
+// ValidateDependencies validates all dependencies of the pack, and returns an error if any.
+// Every dependency needs to be a loaded pack of any type with a compatible version.
+func (pack *Base) ValidateDependencies(manager *Manager) error {
+	for index, dependency := range pack.manifest.Dependencies {
+		var detail = "dependency " + strconv.Itoa(index)
+		if !isValidUUID(dependency.UUID) {
+			return newLoadError(pack.packPath, InvalidUUID, detail+": "+dependency.UUID)
+		}
+		if !isValidVersion(dependency.Version) {
+			return newLoadError(pack.packPath, InvalidVersion, detail)
+		}
+		var loaded, ok = manager.getBase(dependency.UUID)
+		if !ok {
+			detail += ": " + dependency.UUID + " " + versionString(dependency.Version)
+			if path, failed := manager.failed[dependency.UUID]; failed {
+				detail += ", which failed to load from " + path
+			}
+			return newLoadError(pack.packPath, MissingDependency, detail)
+		}
+		if !isCompatible(loaded.manifest.Header.Version, dependency.Version) {
+			return newLoadError(pack.packPath, IncompatibleDependency, detail+": "+dependency.UUID+" requires "+versionString(dependency.Version)+", loaded "+loaded.GetVersion())
+		}
+	}
+	return nil
+}
+
+// findCycle returns the UUIDs of packs that depend on themselves through their dependencies,
+// starting at the pack with the given UUID. Nil is returned if there is no cycle.
+func (manager *Manager) findCycle(uuid string, path []string, visited map[string]bool) []string {
+	for i, visiting := range path {
+		if visiting == uuid {
+			return append(path[i:], uuid)
+		}
+	}
+	if visited[uuid] {
+		return nil
+	}
+	visited[uuid] = true
+	var pack, ok = manager.getBase(uuid)
+	if !ok {
+		return nil
+	}
+	path = append(path, uuid)
+	for _, dependency := range pack.GetDependencies() {
+		if cycle := manager.findCycle(dependency, path, visited); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// orderStack returns the packs ordered so that every pack comes before the packs it depends on,
+// putting packs on top of their dependencies. Packs keep their load order otherwise.
+// Dependencies on packs that are not in the list, such as packs of another type, are ignored.
+func orderStack(packs []*Base) []*Base {
+	var byUUID = make(map[string]*Base, len(packs))
+	for _, pack := range packs {
+		byUUID[pack.GetUUID()] = pack
+	}
+	var visited = make(map[string]bool, len(packs))
+	var ordered = make([]*Base, 0, len(packs))
+	var visit func(pack *Base)
+	visit = func(pack *Base) {
+		if visited[pack.GetUUID()] {
+			return
+		}
+		visited[pack.GetUUID()] = true
+		for _, dependency := range pack.GetDependencies() {
+			if dependencyPack, ok := byUUID[dependency]; ok {
+				visit(dependencyPack)
+			}
+		}
+		ordered = append(ordered, pack)
+	}
+	for _, pack := range packs {
+		visit(pack)
+	}
+	// Dependencies were added first, so reverse the order to put packs above their dependencies.
+	for i, j := 0, len(ordered)-1; i < j; i, j = i+1, j-1 {
+		ordered[i], ordered[j] = ordered[j], ordered[i]
+	}
+	return ordered
+}
@@ -0,0 +1,46 @@
+package packs
+
+import "errors"
+
+var (
+	NoManifest             = errors.New("no manifest.json or pack_manifest.json found")
+	InvalidManifest        = errors.New("manifest is not valid JSON")
+	MissingName            = errors.New("missing a name")
+	MissingDescription     = errors.New("missing a description")
+	InvalidVersion         = errors.New("missing a valid version")
+	InvalidUUID            = errors.New("invalid UUID")
+	MissingModule          = errors.New("missing a module")
+	InvalidModuleType      = errors.New("invalid module type")
+	DuplicateUUID          = errors.New("UUID is already used by another pack")
+	MissingDependency      = errors.New("dependency is not loaded")
+	IncompatibleDependency = errors.New("dependency has an incompatible version")
+	DependencyCycle        = errors.New("dependencies form a cycle")
+	InvalidURL             = errors.New("URL is not a valid HTTP or HTTPS URL")
+)
+
+// LoadError is an error that occurred while loading the pack at a path.
+// Err is one of the errors of this package, and Detail describes where in the manifest it occurred.
+type LoadError struct {
+	Path   string
+	Err    error
+	Detail string
+}
+
+// newLoadError returns a new load error of the pack at the path.
+func newLoadError(path string, err error, detail string) *LoadError {
+	return &LoadError{Path: path, Err: err, Detail: detail}
+}
+
+// Error returns the error message, such as "pack at path: invalid UUID: module 0: abc".
+func (err *LoadError) Error() string {
+	var message = "pack at " + err.Path + ": " + err.Err.Error()
+	if err.Detail != "" {
+		message += ": " + err.Detail
+	}
+	return message
+}
+
+// Unwrap returns the error of this package that occurred.
+func (err *LoadError) Unwrap() error {
+	return err.Err
+}
@@ -1,92 +1,174 @@
 package packs
 
 import (
-	"github.com/BobbyShrd/gominetest/text"
 	"io/ioutil"
+	"net/url"
 	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/text"
 )
 
 // Manager manages the loading of packs.
 // It provides helper functions for both types of packs.
+// Packs can be reloaded at runtime with Reload, and resource packs can be hosted on an HTTP(S) URL,
+// which clients download the pack from instead of requesting it in chunks.
 type Manager struct {
+	mutex      sync.RWMutex
 	serverPath string
+	urls       map[string]string
+	failed     map[string]string
 
 	resourcePacks map[string]*ResourcePack
+	resourceOrder []string
 	resourceStack *Stack
 
 	behaviorPacks map[string]*BehaviorPack
+	behaviorOrder []string
 	behaviorStack *Stack
 }
 
 // NewManager returns a new pack manager with the given path.
 func NewManager(serverPath string) *Manager {
-	return &Manager{serverPath, make(map[string]*ResourcePack), NewStack(), make(map[string]*BehaviorPack), NewStack()}
+	return &Manager{serverPath: serverPath, urls: make(map[string]string), failed: make(map[string]string), resourcePacks: make(map[string]*ResourcePack), resourceStack: NewStack(), behaviorPacks: make(map[string]*BehaviorPack), behaviorStack: NewStack()}
 }
 
 // GetResourcePacks returns all resource maps in a UUID => pack map.
 func (manager *Manager) GetResourcePacks() map[string]*ResourcePack {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
 	return manager.resourcePacks
 }
 
 // GetBehaviorPacks returns all behavior packs in a UUID => pack map.
 func (manager *Manager) GetBehaviorPacks() map[string]*BehaviorPack {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
 	return manager.behaviorPacks
 }
 
 // GetResourceStack returns the resource pack stack.
+// Packs are ordered above the packs they depend on once dependencies are resolved.
 func (manager *Manager) GetResourceStack() *Stack {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
 	return manager.resourceStack
 }
 
 // GetBehaviorStack returns the behavior pack stack.
+// Packs are ordered above the packs they depend on once dependencies are resolved.
 func (manager *Manager) GetBehaviorStack() *Stack {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
 	return manager.behaviorStack
 }
 
-// LoadResourcePacks loads all resource packs in the `serverPath/extensions/resource_packs/` folder.
-// It returns an array of errors that occurred during the loading of all resource packs.
-func (manager *Manager) LoadResourcePacks() []error {
-	var path = manager.serverPath + "extensions/resource_packs/"
-	var files, _ = ioutil.ReadDir(path)
-	var errors []error
-	for _, file := range files {
-		if file.IsDir() {
-			continue
-		}
+// LoadPacks loads all resource and behavior packs, and resolves their dependencies.
+// Packs with invalid manifests or unsatisfied dependencies are not loaded.
+// It returns an array of errors that occurred, which are *LoadError if they occurred in a pack.
+func (manager *Manager) LoadPacks() []error {
+	var errors = manager.LoadResourcePacks()
+	errors = append(errors, manager.LoadBehaviorPacks()...)
+	errors = append(errors, manager.ResolveDependencies()...)
+	for _, pack := range *manager.resourceStack {
+		text.DefaultLogger.Info("Loaded resource pack:", text.Yellow+manager.resourcePacks[pack.GetUUID()].manifest.Header.Name, pack.GetVersion())
+	}
+	for _, pack := range *manager.behaviorStack {
+		text.DefaultLogger.Info("Loaded behavior pack:", text.Yellow+manager.behaviorPacks[pack.GetUUID()].manifest.Header.Name, pack.GetVersion())
+	}
+	return errors
+}
 
-		extension := filepath.Ext(file.Name())
-		if extension != ".mcpack" && extension != ".zip" {
-			continue
-		}
+// Reload rescans the pack directories, loading new and changed packs and unloading removed packs.
+// The packs are loaded separately and replace the current packs at once, so that sessions joining
+// during the reload get either the old or the new packs. Sessions that already joined keep their packs until they rejoin.
+// URLs of packs are kept. It returns an array of errors that occurred, like LoadPacks.
+func (manager *Manager) Reload() []error {
+	var reloaded = NewManager(manager.serverPath)
+	var errors = reloaded.LoadPacks()
 
-		filePath := path + file.Name()
+	manager.mutex.Lock()
+	manager.resourcePacks, manager.resourceOrder, manager.resourceStack = reloaded.resourcePacks, reloaded.resourceOrder, reloaded.resourceStack
+	manager.behaviorPacks, manager.behaviorOrder, manager.behaviorStack = reloaded.behaviorPacks, reloaded.behaviorOrder, reloaded.behaviorStack
+	manager.mutex.Unlock()
+	return errors
+}
 
-		resourcePack := NewResourcePack(filePath)
-		err := resourcePack.Load()
-		if err != nil {
-			errors = append(errors, err)
-			continue
-		}
+// SetPackURL sets the HTTP(S) URL the resource pack with the given UUID is hosted on.
+// Clients download the pack from the URL instead of from the server, which is faster for large packs.
+// The pack does not need to be loaded yet, so that URLs can be set before packs are loaded.
+func (manager *Manager) SetPackURL(uuid string, packURL string) error {
+	var parsed, err = url.Parse(packURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return InvalidURL
+	}
+	manager.mutex.Lock()
+	manager.urls[uuid] = packURL
+	manager.mutex.Unlock()
+	return nil
+}
 
-		err = resourcePack.ValidateManifest()
-		if err != nil {
-			errors = append(errors, err)
-			continue
+// RemovePackURL removes the URL of the resource pack with the given UUID,
+// after which clients download the pack from the server again.
+func (manager *Manager) RemovePackURL(uuid string) {
+	manager.mutex.Lock()
+	delete(manager.urls, uuid)
+	manager.mutex.Unlock()
+}
+
+// GetPackURL returns the URL of the resource pack with the given UUID, and a bool indicating if it has one.
+func (manager *Manager) GetPackURL(uuid string) (string, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var packURL, ok = manager.urls[uuid]
+	return packURL, ok
+}
+
+// GetPackURLs returns the URLs of the loaded resource packs that are hosted on a URL, in a UUID => URL map.
+func (manager *Manager) GetPackURLs() map[string]string {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var urls = make(map[string]string)
+	for uuid, packURL := range manager.urls {
+		if _, ok := manager.resourcePacks[uuid]; ok {
+			urls[uuid] = packURL
 		}
+	}
+	return urls
+}
 
-		manager.resourcePacks[resourcePack.manifest.Header.UUID] = resourcePack
-		text.DefaultLogger.Info("Loaded resource pack:", text.Yellow+resourcePack.manifest.Header.Name)
-		manager.GetResourceStack().Push(resourcePack)
+// LoadResourcePacks loads all resource packs in the `serverPath/extensions/resource_packs/` folder.
+// Dependencies of the packs are not validated until ResolveDependencies is called.
+// It returns an array of errors that occurred during the loading of all resource packs.
+func (manager *Manager) LoadResourcePacks() []error {
+	var bases, errors = manager.loadDirectory(manager.serverPath+"extensions/resource_packs/", Resource)
+	for _, base := range bases {
+		manager.resourcePacks[base.GetUUID()] = &ResourcePack{base}
+		manager.resourceOrder = append(manager.resourceOrder, base.GetUUID())
 	}
 	return errors
 }
 
 // LoadBehaviorPacks loads all behavior packs in the `serverPath/extensions/behavior_packs/` folder.
+// Dependencies of the packs are not validated until ResolveDependencies is called.
 // It returns an array of errors that occurred during the loading of all behavior packs.
 func (manager *Manager) LoadBehaviorPacks() []error {
-	var path = manager.serverPath + "extensions/behavior_packs/"
+	var bases, errors = manager.loadDirectory(manager.serverPath+"extensions/behavior_packs/", Behavior)
+	for _, base := range bases {
+		manager.behaviorPacks[base.GetUUID()] = &BehaviorPack{base}
+		manager.behaviorOrder = append(manager.behaviorOrder, base.GetUUID())
+	}
+	return errors
+}
+
+// loadDirectory loads and validates the manifests of all packs with the pack type in the directory.
+// Packs with a header or module UUID that is already used by a loaded pack are left out.
+func (manager *Manager) loadDirectory(path string, packType PackType) ([]*Base, []error) {
 	var files, _ = ioutil.ReadDir(path)
+	var bases []*Base
 	var errors []error
+	var loaded = manager.getBases(manager.getLoadOrder())
 	for _, file := range files {
 		if file.IsDir() {
 			continue
@@ -97,41 +179,130 @@ func (manager *Manager) LoadBehaviorPacks() []error {
 			continue
 		}
 
-		filePath := path + file.Name()
-
-		behaviorPack := NewBehaviorPack(filePath)
-		err := behaviorPack.Load()
-		if err != nil {
+		base := newBase(path+file.Name(), packType)
+		if err := base.Load(); err != nil {
 			errors = append(errors, err)
 			continue
 		}
-
-		err = behaviorPack.ValidateManifest()
-		if err != nil {
+		if err := base.ValidateManifest(); err != nil {
+			if isValidUUID(base.GetUUID()) {
+				manager.failed[base.GetUUID()] = base.packPath
+			}
 			errors = append(errors, err)
 			continue
 		}
-
-		err = behaviorPack.ValidateDependencies(manager)
-		if err != nil {
+		if err := base.findConflict(append(loaded, bases...)); err != nil {
+			manager.failed[base.GetUUID()] = base.packPath
 			errors = append(errors, err)
 			continue
 		}
+		bases = append(bases, base)
+	}
+	return bases, errors
+}
 
-		manager.behaviorPacks[behaviorPack.manifest.Header.UUID] = behaviorPack
-		manager.GetBehaviorStack().Push(behaviorPack)
+// ResolveDependencies validates the dependencies of all loaded packs, and orders the pack stacks
+// so that packs are above the packs they depend on. Packs with a missing or incompatible dependency,
+// or with dependencies that depend on the pack again, are unloaded, which may in turn unload packs depending on them.
+// It returns an array of errors of the packs that got unloaded.
+func (manager *Manager) ResolveDependencies() []error {
+	var errors []error
+	for changed := true; changed; {
+		changed = false
+		for _, uuid := range manager.getLoadOrder() {
+			var pack, ok = manager.getBase(uuid)
+			if !ok {
+				continue
+			}
+			if err := pack.ValidateDependencies(manager); err != nil {
+				errors = append(errors, err)
+				manager.unload(uuid)
+				changed = true
+				continue
+			}
+			if cycle := manager.findCycle(uuid, nil, make(map[string]bool)); cycle != nil {
+				var cyclePack, _ = manager.getBase(cycle[0])
+				errors = append(errors, newLoadError(cyclePack.packPath, DependencyCycle, strings.Join(cycle, " -> ")))
+				for _, cycleUUID := range cycle {
+					manager.unload(cycleUUID)
+				}
+				changed = true
+			}
+		}
+	}
+
+	*manager.resourceStack = (*manager.resourceStack)[:0]
+	for _, base := range orderStack(manager.getBases(manager.resourceOrder)) {
+		*manager.resourceStack = append(*manager.resourceStack, manager.resourcePacks[base.GetUUID()])
+	}
+	*manager.behaviorStack = (*manager.behaviorStack)[:0]
+	for _, base := range orderStack(manager.getBases(manager.behaviorOrder)) {
+		*manager.behaviorStack = append(*manager.behaviorStack, manager.behaviorPacks[base.GetUUID()])
 	}
 	return errors
 }
 
+// getLoadOrder returns the UUIDs of all loaded packs in the order they were loaded, resource packs first.
+func (manager *Manager) getLoadOrder() []string {
+	return append(append([]string{}, manager.resourceOrder...), manager.behaviorOrder...)
+}
+
+// getBase returns the base of the pack of any type with the given UUID, and a bool indicating if it is loaded.
+func (manager *Manager) getBase(uuid string) (*Base, bool) {
+	if pack, ok := manager.resourcePacks[uuid]; ok {
+		return pack.Base, true
+	}
+	if pack, ok := manager.behaviorPacks[uuid]; ok {
+		return pack.Base, true
+	}
+	return nil, false
+}
+
+// getBases returns the bases of the loaded packs with the given UUIDs.
+func (manager *Manager) getBases(uuids []string) []*Base {
+	var bases = make([]*Base, 0, len(uuids))
+	for _, uuid := range uuids {
+		if base, ok := manager.getBase(uuid); ok {
+			bases = append(bases, base)
+		}
+	}
+	return bases
+}
+
+// unload unloads the pack with the given UUID.
+// The pack is remembered as failed, so that errors of packs depending on it can point to it.
+func (manager *Manager) unload(uuid string) {
+	if base, ok := manager.getBase(uuid); ok {
+		manager.failed[uuid] = base.packPath
+	}
+	delete(manager.resourcePacks, uuid)
+	delete(manager.behaviorPacks, uuid)
+	manager.resourceOrder = removeUUID(manager.resourceOrder, uuid)
+	manager.behaviorOrder = removeUUID(manager.behaviorOrder, uuid)
+}
+
+// removeUUID returns the UUIDs without the given UUID.
+func removeUUID(uuids []string, uuid string) []string {
+	for i, u := range uuids {
+		if u == uuid {
+			return append(uuids[:i:i], uuids[i+1:]...)
+		}
+	}
+	return uuids
+}
+
 // IsResourcePackLoaded checks if a resource pack with the given UUID is loaded.
 func (manager *Manager) IsResourcePackLoaded(uuid string) bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
 	var _, exists = manager.resourcePacks[uuid]
 	return exists
 }
 
 // IsBehaviorPackLoaded checks if a behavior pack with the given UUID is loaded.
 func (manager *Manager) IsBehaviorPackLoaded(uuid string) bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
 	var _, exists = manager.behaviorPacks[uuid]
 	return exists
 }
@@ -143,17 +314,15 @@ func (manager *Manager) IsPackLoaded(uuid string) bool {
 
 // GetResourcePack returns a resource pack by its UUID, or nil of none was found.
 func (manager *Manager) GetResourcePack(uuid string) *ResourcePack {
-	if !manager.IsResourcePackLoaded(uuid) {
-		return nil
-	}
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
 	return manager.resourcePacks[uuid]
 }
 
 // GetBehaviorPack returns a behavior pack by its UUID, or nil if none was found.
 func (manager *Manager) GetBehaviorPack(uuid string) *BehaviorPack {
-	if !manager.IsBehaviorPackLoaded(uuid) {
-		return nil
-	}
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
 	return manager.behaviorPacks[uuid]
 }
 
@@ -0,0 +1,173 @@
+package packs
+
+import (
+	"archive/zip"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	resourceUUID = "0c56cbfa-1a7a-4c72-a1a2-1c2d6a41e0c1"
+	behaviorUUID = "7b1d2b0e-3c1e-4f2a-9a3c-5a6d1e2f3b4c"
+	addonUUID    = "e3a1f6b2-8d4c-4b7e-9f1a-2c3d4e5f6a7b"
+)
+
+// writePack writes a pack with the given manifest to the directory of the pack type in the server path.
+func writePack(t *testing.T, serverPath string, packType PackType, name string, manifest string) {
+	var directory = filepath.Join(serverPath, "extensions", "resource_packs")
+	if packType == Behavior {
+		directory = filepath.Join(serverPath, "extensions", "behavior_packs")
+	}
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		t.Fatal(err)
+	}
+	var file, err = os.Create(filepath.Join(directory, name+".mcpack"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var writer = zip.NewWriter(file)
+	var entry, _ = writer.Create("manifest.json")
+	entry.Write([]byte(manifest))
+	writer.Close()
+}
+
+// manifest returns a manifest with a module of the module type and the given dependencies.
+func manifest(uuid string, moduleUUID string, moduleType string, version string, dependencies string) string {
+	return `{"header": {"name": "Test", "description": "Test pack", "uuid": "` + uuid + `", "version": ` + version + `},
+		"modules": [{"description": "Module", "type": "` + moduleType + `", "uuid": "` + moduleUUID + `", "version": [1, 0, 0]}],
+		"dependencies": [` + dependencies + `]}`
+}
+
+func TestDependencies(t *testing.T) {
+	var serverPath, _ = ioutil.TempDir("", "packs")
+	defer os.RemoveAll(serverPath)
+	serverPath += "/"
+
+	writePack(t, serverPath, Resource, "base", manifest(resourceUUID, "11111111-1111-4111-8111-111111111111", "resources", "[1, 2, 0]", ""))
+	writePack(t, serverPath, Resource, "addon", manifest(addonUUID, "22222222-2222-4222-8222-222222222222", "resources", "[1, 0, 0]",
+		`{"uuid": "`+resourceUUID+`", "version": [1, 1, 0]}`))
+	writePack(t, serverPath, Behavior, "behavior", manifest(behaviorUUID, "33333333-3333-4333-8333-333333333333", "data", "[1, 0, 0]",
+		`{"uuid": "`+addonUUID+`", "version": [1, 0, 0]}`))
+
+	var manager = NewManager(serverPath)
+	if errs := manager.LoadPacks(); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	var stack = manager.GetResourceStack()
+	if stack.Len() != 2 || stack.GetPackAtOffset(0).GetUUID() != addonUUID || stack.GetPackAtOffset(1).GetUUID() != resourceUUID {
+		t.Errorf("expected addon to be above its dependency")
+	}
+	if !manager.IsBehaviorPackLoaded(behaviorUUID) || manager.GetBehaviorStack().Len() != 1 {
+		t.Errorf("expected behavior pack to be loaded")
+	}
+}
+
+func TestUnsatisfiedDependencies(t *testing.T) {
+	var serverPath, _ = ioutil.TempDir("", "packs")
+	defer os.RemoveAll(serverPath)
+	serverPath += "/"
+
+	writePack(t, serverPath, Resource, "base", manifest(resourceUUID, "11111111-1111-4111-8111-111111111111", "resources", "[2, 0, 0]", ""))
+	writePack(t, serverPath, Resource, "addon", manifest(addonUUID, "22222222-2222-4222-8222-222222222222", "resources", "[1, 0, 0]",
+		`{"uuid": "`+resourceUUID+`", "version": [1, 0, 0]}`))
+	writePack(t, serverPath, Behavior, "behavior", manifest(behaviorUUID, "33333333-3333-4333-8333-333333333333", "data", "[1, 0, 0]",
+		`{"uuid": "`+addonUUID+`", "version": [1, 0, 0]}`))
+
+	var manager = NewManager(serverPath)
+	var errs = manager.LoadPacks()
+	if len(errs) != 2 || !errors.Is(errs[0], IncompatibleDependency) || !errors.Is(errs[1], MissingDependency) {
+		t.Fatalf("expected an incompatible and a missing dependency, got %v", errs)
+	}
+	if manager.IsPackLoaded(addonUUID) || manager.IsPackLoaded(behaviorUUID) || manager.GetResourceStack().Len() != 1 {
+		t.Error("expected packs with unsatisfied dependencies to be unloaded")
+	}
+}
+
+func TestInvalidManifests(t *testing.T) {
+	var serverPath, _ = ioutil.TempDir("", "packs")
+	defer os.RemoveAll(serverPath)
+	serverPath += "/"
+
+	writePack(t, serverPath, Resource, "a", manifest("not-a-uuid", "11111111-1111-4111-8111-111111111111", "resources", "[1, 0, 0]", ""))
+	writePack(t, serverPath, Resource, "b", manifest(resourceUUID, "11111111-1111-4111-8111-111111111111", "data", "[1, 0, 0]", ""))
+	writePack(t, serverPath, Resource, "c", manifest(addonUUID, "22222222-2222-4222-8222-222222222222", "resources", "[1]", ""))
+	writePack(t, serverPath, Behavior, "d", manifest(behaviorUUID, "33333333-3333-4333-8333-333333333333", "data", "[1, 0, 0]",
+		`{"uuid": "`+behaviorUUID+`", "version": [1, 0, 0]}`))
+
+	var manager = NewManager(serverPath)
+	var errs = manager.LoadPacks()
+	var expected = []error{InvalidUUID, MissingModule, InvalidVersion, DependencyCycle}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %v errors, got %v", len(expected), errs)
+	}
+	for i, err := range expected {
+		if !errors.Is(errs[i], err) {
+			t.Errorf("expected %v, got %v", err, errs[i])
+		}
+	}
+}
+
+func TestReload(t *testing.T) {
+	var serverPath, _ = ioutil.TempDir("", "packs")
+	defer os.RemoveAll(serverPath)
+	serverPath += "/"
+
+	writePack(t, serverPath, Resource, "base", manifest(resourceUUID, "11111111-1111-4111-8111-111111111111", "resources", "[1, 0, 0]", ""))
+	var manager = NewManager(serverPath)
+	if errs := manager.LoadPacks(); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if err := manager.SetPackURL(resourceUUID, "ftp://example.com/base.mcpack"); !errors.Is(err, InvalidURL) {
+		t.Errorf("expected an invalid URL, got %v", err)
+	}
+	if err := manager.SetPackURL(resourceUUID, "https://example.com/base.mcpack"); err != nil {
+		t.Fatal(err)
+	}
+
+	writePack(t, serverPath, Resource, "addon", manifest(addonUUID, "22222222-2222-4222-8222-222222222222", "resources", "[1, 0, 0]",
+		`{"uuid": "`+resourceUUID+`", "version": [1, 0, 0]}`))
+	if errs := manager.Reload(); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if !manager.IsResourcePackLoaded(addonUUID) || manager.GetResourceStack().Len() != 2 {
+		t.Error("expected the new pack to be loaded after reloading")
+	}
+	if urls := manager.GetPackURLs(); urls[resourceUUID] != "https://example.com/base.mcpack" || len(urls) != 1 {
+		t.Errorf("expected the URL to be kept after reloading, got %v", urls)
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	var serverPath, _ = ioutil.TempDir("", "packs")
+	defer os.RemoveAll(serverPath)
+	serverPath += "/"
+
+	writePack(t, serverPath, Resource, "a", manifest(resourceUUID, "11111111-1111-4111-8111-111111111111", "resources", "[1, 0, 0]", ""))
+	writePack(t, serverPath, Resource, "b", manifest(addonUUID, "11111111-1111-4111-8111-111111111111", "resources", "[1, 0, 0]", ""))
+	writePack(t, serverPath, Behavior, "c", manifest(resourceUUID, "33333333-3333-4333-8333-333333333333", "data", "[1, 0, 0]", ""))
+	writePack(t, serverPath, Behavior, "d", manifest(behaviorUUID, "44444444-4444-4444-8444-444444444444", "data", "[1, 0, 0]",
+		`{"uuid": "`+addonUUID+`", "version": [1, 0, 0]}`))
+
+	var manager = NewManager(serverPath)
+	var errs = manager.LoadPacks()
+	var expected = []error{DuplicateUUID, DuplicateUUID, MissingDependency}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %v errors, got %v", len(expected), errs)
+	}
+	for i, err := range expected {
+		if !errors.Is(errs[i], err) {
+			t.Errorf("expected %v, got %v", err, errs[i])
+		}
+	}
+	if !strings.HasSuffix(errs[2].Error(), "which failed to load from "+serverPath+"extensions/resource_packs/b.mcpack") {
+		t.Errorf("expected the missing dependency to point to the pack that failed to load, got %v", errs[2])
+	}
+	if !manager.IsResourcePackLoaded(resourceUUID) || manager.IsPackLoaded(addonUUID) || manager.IsPackLoaded(behaviorUUID) {
+		t.Error("expected only the first pack using a UUID to be loaded")
+	}
+}
@@ -0,0 +1,105 @@
+package palette
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+var (
+	InvalidIdentifier   = errors.New("custom block identifier must have a namespace, such as \"plugin:block\"")
+	VanillaIdentifier   = errors.New("custom blocks can not use the minecraft namespace")
+	CustomBlockExists   = errors.New("a custom block with this identifier is already registered")
+	EmptyPropertyValues = errors.New("custom block property has no values")
+)
+
+// CustomBlock is a block added by a plugin, paired with a resource pack holding its geometry and textures.
+type CustomBlock struct {
+	// Identifier is the namespaced identifier of the block, such as "plugin:crate".
+	Identifier string
+	// Properties are the block state properties of the block, and the values they may have.
+	// A block state is added to the palette for every combination of values.
+	Properties map[string][]interface{}
+	// Components are the components of the block, such as "minecraft:geometry" and "minecraft:material_instances".
+	Components map[string]interface{}
+	// Permutations change the components of the block for the block states matching their condition.
+	Permutations []Permutation
+}
+
+// Permutation holds the components of a custom block applied to block states matching a Molang condition,
+// such as "query.block_property('plugin:open') == true".
+type Permutation struct {
+	Condition  string
+	Components map[string]interface{}
+}
+
+// Validate checks if the custom block has a namespaced identifier outside the minecraft namespace,
+// and if all of its properties have values.
+func (block CustomBlock) Validate() error {
+	var namespace = strings.SplitN(block.Identifier, ":", 2)
+	if len(namespace) != 2 || namespace[0] == "" || namespace[1] == "" {
+		return InvalidIdentifier
+	}
+	if namespace[0] == "minecraft" {
+		return VanillaIdentifier
+	}
+	for _, values := range block.Properties {
+		if len(values) == 0 {
+			return EmptyPropertyValues
+		}
+	}
+	return nil
+}
+
+// GetStates returns all block states of the custom block: one for every combination of property values.
+// Properties are iterated in alphabetical order and values in the order they were given, so the order of the states is stable.
+func (block CustomBlock) GetStates() []State {
+	var names = block.getPropertyNames()
+	var states = []State{NewState(block.Identifier, nil)}
+	for _, name := range names {
+		var combined = make([]State, 0, len(states)*len(block.Properties[name]))
+		for _, state := range states {
+			for _, value := range block.Properties[name] {
+				var properties = make(map[string]interface{}, len(state.Properties)+1)
+				for key, existing := range state.Properties {
+					properties[key] = existing
+				}
+				properties[name] = value
+				combined = append(combined, NewState(block.Identifier, properties))
+			}
+		}
+		states = combined
+	}
+	return states
+}
+
+// GetDefinition returns the definition of the custom block sent to clients in the block properties of the start game packet.
+func (block CustomBlock) GetDefinition() map[string]interface{} {
+	var properties = make([]interface{}, 0, len(block.Properties))
+	for _, name := range block.getPropertyNames() {
+		properties = append(properties, map[string]interface{}{"name": name, "enum": block.Properties[name]})
+	}
+	var permutations = make([]interface{}, len(block.Permutations))
+	for i, permutation := range block.Permutations {
+		permutations[i] = map[string]interface{}{"condition": permutation.Condition, "components": permutation.Components}
+	}
+	var components = block.Components
+	if components == nil {
+		components = make(map[string]interface{})
+	}
+	return map[string]interface{}{
+		"components":   components,
+		"permutations": permutations,
+		"properties":   properties,
+	}
+}
+
+// getPropertyNames returns the names of the properties of the block in alphabetical order.
+func (block CustomBlock) getPropertyNames() []string {
+	var names = make([]string, 0, len(block.Properties))
+	for name := range block.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,157 @@
+package palette
+
+import (
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/chunkstore"
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+// TagStates is the name of the list holding the custom block states of a chunk file.
+const TagStates = "States"
+
+// Layer holds the states of custom blocks placed in a dimension, by position.
+// Chunks only save the legacy ID and data value of their blocks, which custom states do not have,
+// so the layer keeps them instead, with one NBT file for every chunk holding custom states.
+type Layer struct {
+	mutex  sync.RWMutex
+	store  *chunkstore.Store
+	states map[blocks.Position]State
+	loaded map[[2]int32]bool
+}
+
+// NewLayer returns a new layer keeping its files in the given directory.
+// The states of the layer are not saved if the directory is empty.
+func NewLayer(directory string) *Layer {
+	return &Layer{store: chunkstore.New(directory, TagStates), states: make(map[blocks.Position]State), loaded: make(map[[2]int32]bool)}
+}
+
+// Set sets the custom state at the given position.
+func (layer *Layer) Set(position blocks.Position, state State) {
+	layer.mutex.Lock()
+	layer.states[position] = state
+	layer.loaded[[2]int32{position.X >> 4, position.Z >> 4}] = true
+	layer.mutex.Unlock()
+}
+
+// Remove removes the custom state at the given position.
+// Returns true if the position held a custom state.
+func (layer *Layer) Remove(position blocks.Position) bool {
+	layer.mutex.Lock()
+	defer layer.mutex.Unlock()
+	if _, ok := layer.states[position]; !ok {
+		return false
+	}
+	delete(layer.states, position)
+	return true
+}
+
+// Get returns the custom state at the given position, and a bool indicating if the position holds one.
+func (layer *Layer) Get(position blocks.Position) (State, bool) {
+	layer.mutex.RLock()
+	defer layer.mutex.RUnlock()
+	var state, ok = layer.states[position]
+	return state, ok
+}
+
+// GetInChunk returns all custom states in the chunk with the given coordinates, by position.
+func (layer *Layer) GetInChunk(chunkX, chunkZ int32) map[blocks.Position]State {
+	layer.mutex.RLock()
+	defer layer.mutex.RUnlock()
+	var states = make(map[blocks.Position]State)
+	for position, state := range layer.states {
+		if position.X>>4 == chunkX && position.Z>>4 == chunkZ {
+			states[position] = state
+		}
+	}
+	return states
+}
+
+// LoadChunk loads the custom states saved in the chunk at the given chunk coordinates, and returns them by position.
+// States are saved in their canonical form, and looked up in the custom blocks registered to the manager,
+// so that they keep their block when the runtime IDs of custom states change. States of custom blocks
+// that are no longer registered are left out. Nothing is returned if the chunk was loaded before.
+func (layer *Layer) LoadChunk(x, z int32, manager *Manager) (map[blocks.Position]State, error) {
+	layer.mutex.Lock()
+	defer layer.mutex.Unlock()
+	if !layer.store.IsPersistent() || layer.loaded[[2]int32{x, z}] {
+		return nil, nil
+	}
+	layer.loaded[[2]int32{x, z}] = true
+	var compounds, err = layer.store.Load(x, z)
+	if err != nil {
+		return nil, err
+	}
+
+	var states = make(map[blocks.Position]State)
+	for _, entry := range compounds {
+		var block, registered = manager.GetCustomBlock(entry.GetString("Name", ""))
+		if !registered {
+			continue
+		}
+		var canonical = entry.GetString("State", "")
+		for _, state := range block.GetStates() {
+			if state.String() == canonical {
+				var position = chunkstore.PositionFromNBT(entry)
+				layer.states[position] = state
+				states[position] = state
+				break
+			}
+		}
+	}
+	return states, nil
+}
+
+// SaveChunk saves the custom states in the chunk at the given chunk coordinates,
+// replacing the states saved before. The file of the chunk is removed if it holds no custom states.
+func (layer *Layer) SaveChunk(x, z int32) error {
+	var states = layer.GetInChunk(x, z)
+	var tags = make([]gonbt.INamedTag, 0, len(states))
+	for position, state := range states {
+		var compound = chunkstore.PositionToNBT(position)
+		compound.SetString("Name", state.Name)
+		compound.SetString("State", state.String())
+		tags = append(tags, compound)
+	}
+	return layer.store.Save(x, z, tags)
+}
+
+// SaveAll saves the custom states of all chunks loaded or changed since the layer was created.
+// The first error that occurred is returned, after all other chunks were saved.
+func (layer *Layer) SaveAll() error {
+	layer.mutex.RLock()
+	var chunks = make([][2]int32, 0, len(layer.loaded))
+	for chunk := range layer.loaded {
+		chunks = append(chunks, chunk)
+	}
+	layer.mutex.RUnlock()
+
+	var first error
+	for _, chunk := range chunks {
+		if err := layer.SaveChunk(chunk[0], chunk[1]); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// UnloadChunk saves the custom states in the chunk at the given chunk coordinates, and removes them from the layer.
+// The states are kept if the layer does not save its states.
+func (layer *Layer) UnloadChunk(x, z int32) error {
+	if !layer.store.IsPersistent() {
+		return nil
+	}
+	if err := layer.SaveChunk(x, z); err != nil {
+		return err
+	}
+	layer.mutex.Lock()
+	for position := range layer.states {
+		if position.X>>4 == x && position.Z>>4 == z {
+			delete(layer.states, position)
+		}
+	}
+	delete(layer.loaded, [2]int32{x, z})
+	layer.mutex.Unlock()
+	return nil
+}
@@ -0,0 +1,152 @@
+package palette
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Manager manages the block palettes of the protocol versions the server supports.
+// Clients get the palette of their protocol, or of the newest protocol before it if their protocol has none.
+// The states of custom blocks are appended to every palette, sorted by block identifier like the client sorts them.
+type Manager struct {
+	mutex        sync.RWMutex
+	palettes     map[int32]*Palette
+	vanilla      map[int32]int
+	protocols    []int32
+	customBlocks []CustomBlock
+}
+
+// NewManager returns a new manager without palettes.
+func NewManager() *Manager {
+	return &Manager{palettes: make(map[int32]*Palette), vanilla: make(map[int32]int)}
+}
+
+// LoadDirectory loads the palettes in the directory, which are canonical block state dumps named by protocol,
+// such as "361.nbt". The directory is created if it does not exist.
+// Files that fail to load are skipped, and their errors are returned.
+func (manager *Manager) LoadDirectory(path string) []error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return []error{os.MkdirAll(path, 0700)}
+	}
+	var files, err = ioutil.ReadDir(path)
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".nbt" {
+			continue
+		}
+		var protocol, err = strconv.Atoi(strings.TrimSuffix(file.Name(), ".nbt"))
+		if err != nil {
+			continue
+		}
+		var data, readErr = ioutil.ReadFile(filepath.Join(path, file.Name()))
+		if readErr != nil {
+			errs = append(errs, readErr)
+			continue
+		}
+		var palette, loadErr = Load(data, false)
+		if loadErr != nil {
+			errs = append(errs, &os.PathError{Op: "load palette", Path: file.Name(), Err: loadErr})
+			continue
+		}
+		manager.Register(int32(protocol), palette)
+	}
+	return errs
+}
+
+// Register registers the palette as palette of the protocol, replacing the previous palette of the protocol.
+// The states of custom blocks registered before are appended to the palette.
+func (manager *Manager) Register(protocol int32, palette *Palette) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	manager.vanilla[protocol] = palette.Len()
+	manager.appendCustomStates(palette)
+	if _, ok := manager.palettes[protocol]; !ok {
+		manager.protocols = append(manager.protocols, protocol)
+		sort.Slice(manager.protocols, func(i, j int) bool {
+			return manager.protocols[i] < manager.protocols[j]
+		})
+	}
+	manager.palettes[protocol] = palette
+}
+
+// Get returns the palette of the protocol, or of the newest protocol before it.
+// Returns false if no palette of the protocol or an older protocol is registered.
+func (manager *Manager) Get(protocol int32) (*Palette, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	for i := len(manager.protocols) - 1; i >= 0; i-- {
+		if manager.protocols[i] <= protocol {
+			return manager.palettes[manager.protocols[i]], true
+		}
+	}
+	return nil, false
+}
+
+// IsEmpty checks if no palettes are registered.
+func (manager *Manager) IsEmpty() bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return len(manager.palettes) == 0
+}
+
+// RegisterCustomBlock registers a custom block, adding its states to the palettes of all protocols.
+// The client orders the states of custom blocks by block identifier after the vanilla states,
+// so the custom states of every palette are ordered again, and the runtime IDs of custom states
+// may change with every custom block registered. Custom blocks must therefore be registered before players join.
+func (manager *Manager) RegisterCustomBlock(block CustomBlock) error {
+	if err := block.Validate(); err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	for _, registered := range manager.customBlocks {
+		if registered.Identifier == block.Identifier {
+			return CustomBlockExists
+		}
+	}
+	manager.customBlocks = append(manager.customBlocks, block)
+	sort.Slice(manager.customBlocks, func(i, j int) bool {
+		return manager.customBlocks[i].Identifier < manager.customBlocks[j].Identifier
+	})
+	for protocol, palette := range manager.palettes {
+		palette.truncate(manager.vanilla[protocol])
+		manager.appendCustomStates(palette)
+	}
+	return nil
+}
+
+// appendCustomStates appends the states of all custom blocks to the palette, in the order of the client.
+func (manager *Manager) appendCustomStates(palette *Palette) {
+	for _, block := range manager.customBlocks {
+		for _, state := range block.GetStates() {
+			palette.Add(state)
+		}
+	}
+}
+
+// GetCustomBlock returns the custom block with the given identifier, and a bool indicating if it is registered.
+func (manager *Manager) GetCustomBlock(identifier string) (CustomBlock, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	for _, block := range manager.customBlocks {
+		if block.Identifier == identifier {
+			return block, true
+		}
+	}
+	return CustomBlock{}, false
+}
+
+// GetCustomBlocks returns all registered custom blocks, sorted by identifier.
+func (manager *Manager) GetCustomBlocks() []CustomBlock {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return append([]CustomBlock(nil), manager.customBlocks...)
+}
@@ -0,0 +1,190 @@
+package palette
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"github.com/irmine/binutils"
+	"github.com/irmine/gonbt"
+)
+
+var (
+	InvalidDump    = errors.New("block state dump has no block states")
+	DuplicateState = errors.New("block state is already in the palette")
+)
+
+// Palette is a block palette: the block states a client knows, by runtime ID.
+// The runtime ID of a state is its index in the palette, so the order of the states must match the order of the client.
+type Palette struct {
+	mutex  sync.RWMutex
+	states []State
+	keys   map[string]uint32
+	legacy map[[2]int16]uint32
+	names  map[string]int16
+}
+
+// New returns a new empty palette.
+func New() *Palette {
+	return &Palette{keys: make(map[string]uint32), legacy: make(map[[2]int16]uint32), names: make(map[string]int16)}
+}
+
+// Load loads a palette from a canonical block states dump in little endian NBT.
+// The dump is a compound holding a list of block states named "blocks",
+// every state being a compound with the name, the "states" compound of properties and the version of the state.
+// States that also hold the legacy "id" and "data" fields can be looked up by their legacy ID and data value.
+func Load(data []byte, network bool) (*Palette, error) {
+	var root = gonbt.NewReader(data, network, binutils.LittleEndian).ReadIntoCompound()
+	if root == nil {
+		return nil, InvalidDump
+	}
+	var list = root.GetList("blocks", gonbt.TAG_Compound)
+	if len(list.GetTags()) == 0 {
+		return nil, InvalidDump
+	}
+	var palette = New()
+	for _, tag := range list.GetTags() {
+		var compound, ok = tag.(*gonbt.Compound)
+		if !ok {
+			continue
+		}
+		var state = NewState(compound.GetString("name", ""), nil)
+		state.Version = compound.GetInt("version", 0)
+		if properties := compound.GetCompound("states"); properties != nil {
+			for name, property := range properties.GetTags() {
+				state.Properties[name] = property.Interface()
+			}
+		}
+		if compound.HasTagWithType("id", gonbt.TAG_Short) {
+			state.LegacyId = compound.GetShort("id", -1)
+			state.LegacyData = compound.GetShort("data", 0)
+		}
+		// Dumps may hold the same state more than once for different legacy data values,
+		// which still occupy a runtime ID each.
+		palette.add(state)
+	}
+	return palette, nil
+}
+
+// Add appends a custom block state to the palette, and returns its runtime ID.
+// Custom states must be appended in the same order as the client, as done by the manager for the states of custom blocks.
+func (palette *Palette) Add(state State) (uint32, error) {
+	palette.mutex.Lock()
+	defer palette.mutex.Unlock()
+	if _, ok := palette.keys[state.String()]; ok {
+		return 0, DuplicateState
+	}
+	return palette.addState(state), nil
+}
+
+// truncate removes all states after the first length states from the palette, such as the states of custom blocks.
+func (palette *Palette) truncate(length int) {
+	palette.mutex.Lock()
+	defer palette.mutex.Unlock()
+	if length >= len(palette.states) {
+		return
+	}
+	var states = palette.states[:length]
+	palette.states = nil
+	palette.keys = make(map[string]uint32)
+	palette.legacy = make(map[[2]int16]uint32)
+	palette.names = make(map[string]int16)
+	for _, state := range states {
+		palette.addState(state)
+	}
+}
+
+// add appends a block state loaded from a dump to the palette.
+func (palette *Palette) add(state State) {
+	palette.mutex.Lock()
+	palette.addState(state)
+	palette.mutex.Unlock()
+}
+
+// addState appends the state and indexes it by its canonical form and legacy ID, and its name by legacy ID.
+// The first state with a canonical form or legacy ID is kept in the index.
+func (palette *Palette) addState(state State) uint32 {
+	var runtimeId = uint32(len(palette.states))
+	palette.states = append(palette.states, state)
+	var key = state.String()
+	if _, ok := palette.keys[key]; !ok {
+		palette.keys[key] = runtimeId
+	}
+	if state.LegacyId >= 0 {
+		var key = [2]int16{state.LegacyId, state.LegacyData}
+		if _, ok := palette.legacy[key]; !ok {
+			palette.legacy[key] = runtimeId
+		}
+		if _, ok := palette.names[state.Name]; !ok {
+			palette.names[state.Name] = state.LegacyId
+		}
+	}
+	return runtimeId
+}
+
+// GetRuntimeId returns the runtime ID of the block state, and a bool indicating if the palette holds the state.
+// States are looked up by their canonical form, so the order of their properties does not matter.
+func (palette *Palette) GetRuntimeId(state State) (uint32, bool) {
+	var key = state.String()
+	palette.mutex.RLock()
+	defer palette.mutex.RUnlock()
+	var runtimeId, ok = palette.keys[key]
+	return runtimeId, ok
+}
+
+// GetLegacyRuntimeId returns the runtime ID of the block state with the given legacy ID and data value.
+// The state with data value 0 is returned if the data value has no state, like the legacy runtime ID table does.
+func (palette *Palette) GetLegacyRuntimeId(id int, data int) (uint32, bool) {
+	palette.mutex.RLock()
+	defer palette.mutex.RUnlock()
+	if runtimeId, ok := palette.legacy[[2]int16{int16(id), int16(data)}]; ok {
+		return runtimeId, true
+	}
+	var runtimeId, ok = palette.legacy[[2]int16{int16(id), 0}]
+	return runtimeId, ok
+}
+
+// GetLegacyId returns the legacy ID of the block with the given name, and a bool indicating if the palette holds
+// a state of the block with a legacy ID.
+func (palette *Palette) GetLegacyId(name string) (int16, bool) {
+	palette.mutex.RLock()
+	defer palette.mutex.RUnlock()
+	var id, ok = palette.names[name]
+	return id, ok
+}
+
+// GetState returns the block state with the given runtime ID, and a bool indicating if it exists.
+func (palette *Palette) GetState(runtimeId uint32) (State, bool) {
+	palette.mutex.RLock()
+	defer palette.mutex.RUnlock()
+	if runtimeId >= uint32(len(palette.states)) {
+		return State{}, false
+	}
+	return palette.states[runtimeId], true
+}
+
+// Len returns the amount of block states in the palette.
+func (palette *Palette) Len() int {
+	palette.mutex.RLock()
+	defer palette.mutex.RUnlock()
+	return len(palette.states)
+}
+
+// EncodeTable encodes the palette as runtime ID table of the start game packet:
+// the amount of states, followed by the name, legacy data value and legacy ID of every state.
+func (palette *Palette) EncodeTable() []byte {
+	palette.mutex.RLock()
+	defer palette.mutex.RUnlock()
+	var buffer = make([]byte, binary.MaxVarintLen32)
+	var table = append([]byte(nil), buffer[:binary.PutUvarint(buffer, uint64(len(palette.states)))]...)
+	for _, state := range palette.states {
+		table = append(table, buffer[:binary.PutUvarint(buffer, uint64(len(state.Name)))]...)
+		table = append(table, state.Name...)
+		var legacyId, legacyData = state.LegacyId, state.LegacyData
+		if legacyId < 0 {
+			legacyId, legacyData = 0, 0
+		}
+		table = append(table, byte(legacyData), byte(uint16(legacyData)>>8), byte(legacyId), byte(uint16(legacyId)>>8))
+	}
+	return table
+}
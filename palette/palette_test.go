@@ -0,0 +1,151 @@
+package palette
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/irmine/binutils"
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+func TestString(t *testing.T) {
+	var a = NewState("minecraft:wool", map[string]interface{}{"color": "red", "age": int32(1)})
+	var b = NewState("minecraft:wool", map[string]interface{}{"age": int32(1), "color": "red"})
+	if a.String() != "minecraft:wool[age=1,color=red]" {
+		t.Errorf("unexpected canonical form %v", a.String())
+	}
+	if a.String() != b.String() {
+		t.Error("expected the canonical form not to depend on the order of the properties")
+	}
+	if a.String() == NewState("minecraft:wool", nil).String() {
+		t.Error("expected states with different properties to have different canonical forms")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	var writer = gonbt.NewWriter(false, binutils.LittleEndian)
+	writer.WriteUncompressedCompound(gonbt.NewCompound("", map[string]gonbt.INamedTag{
+		"blocks": gonbt.NewList("blocks", gonbt.TAG_Compound, []gonbt.INamedTag{
+			gonbt.NewCompound("", map[string]gonbt.INamedTag{
+				"name":   gonbt.NewString("name", "minecraft:air"),
+				"states": gonbt.NewCompound("states", map[string]gonbt.INamedTag{}),
+				"id":     gonbt.NewShort("id", 0),
+				"data":   gonbt.NewShort("data", 0),
+			}),
+			gonbt.NewCompound("", map[string]gonbt.INamedTag{
+				"name":   gonbt.NewString("name", "minecraft:stone"),
+				"states": gonbt.NewCompound("states", map[string]gonbt.INamedTag{"stone_type": gonbt.NewString("stone_type", "granite")}),
+				"id":     gonbt.NewShort("id", 1),
+				"data":   gonbt.NewShort("data", 1),
+			}),
+		}),
+	}))
+	var palette, err = Load(writer.GetData(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtimeId, ok := palette.GetRuntimeId(NewState("minecraft:stone", map[string]interface{}{"stone_type": "granite"})); !ok || runtimeId != 1 {
+		t.Errorf("expected granite to have runtime ID 1, got %v", runtimeId)
+	}
+	if runtimeId, ok := palette.GetLegacyRuntimeId(1, 1); !ok || runtimeId != 1 {
+		t.Errorf("expected legacy ID 1:1 to have runtime ID 1, got %v", runtimeId)
+	}
+	if id, ok := palette.GetLegacyId("minecraft:stone"); !ok || id != 1 {
+		t.Errorf("expected stone to have legacy ID 1, got %v", id)
+	}
+
+	var custom = NewState("custom:block", nil)
+	if runtimeId, err := palette.Add(custom); err != nil || runtimeId != 2 {
+		t.Errorf("expected the custom state to be appended, got %v", runtimeId)
+	}
+	if _, err := palette.Add(custom); err != DuplicateState {
+		t.Errorf("expected adding the state twice to fail, got %v", err)
+	}
+
+	var manager = NewManager()
+	manager.Register(361, palette)
+	if found, ok := manager.Get(388); !ok || found != palette {
+		t.Error("expected newer protocols to use the palette of the newest older protocol")
+	}
+	if _, ok := manager.Get(340); ok {
+		t.Error("expected older protocols not to have a palette")
+	}
+}
+
+func TestCustomBlock(t *testing.T) {
+	var block = CustomBlock{
+		Identifier: "plugin:crate",
+		Properties: map[string][]interface{}{"plugin:open": {false, true}, "plugin:facing": {int32(0), int32(1), int32(2)}},
+		Components: map[string]interface{}{"minecraft:geometry": "geometry.crate"},
+	}
+	if err := (CustomBlock{Identifier: "minecraft:crate"}).Validate(); err != VanillaIdentifier {
+		t.Errorf("expected the minecraft namespace to be rejected, got %v", err)
+	}
+	if states := block.GetStates(); len(states) != 6 || states[0].Properties["plugin:facing"] != int32(0) || states[1].Properties["plugin:open"] != true {
+		t.Errorf("expected a state for every combination of property values, got %v", states)
+	}
+
+	var manager = NewManager()
+	var palette = New()
+	palette.Add(NewState("minecraft:air", nil))
+	manager.Register(361, palette)
+	if err := manager.RegisterCustomBlock(block); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.RegisterCustomBlock(block); err != CustomBlockExists {
+		t.Errorf("expected registering the block twice to fail, got %v", err)
+	}
+	if runtimeId, ok := palette.GetRuntimeId(block.GetStates()[5]); !ok || runtimeId != 6 {
+		t.Errorf("expected the states of the block to be appended to the palette, got %v", runtimeId)
+	}
+
+	var earlier = CustomBlock{Identifier: "plugin:barrel"}
+	if err := manager.RegisterCustomBlock(earlier); err != nil {
+		t.Fatal(err)
+	}
+	if runtimeId, ok := palette.GetRuntimeId(earlier.GetStates()[0]); !ok || runtimeId != 1 {
+		t.Errorf("expected custom states to be sorted by identifier, got %v", runtimeId)
+	}
+	if runtimeId, ok := palette.GetRuntimeId(block.GetStates()[5]); !ok || runtimeId != 7 {
+		t.Errorf("expected the states of the later block to move back, got %v", runtimeId)
+	}
+
+	var later = New()
+	manager.Register(388, later)
+	if later.Len() != 7 {
+		t.Errorf("expected palettes registered later to get the custom states, got %v states", later.Len())
+	}
+}
+
+func TestLayerChunks(t *testing.T) {
+	var directory, err = ioutil.TempDir("", "palette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	var manager = NewManager()
+	var block = CustomBlock{Identifier: "plugin:crate", Properties: map[string][]interface{}{"plugin:open": {false, true}}}
+	if err := manager.RegisterCustomBlock(block); err != nil {
+		t.Fatal(err)
+	}
+	var layer = NewLayer(directory)
+	var position = blocks.NewPosition(3, 64, 5)
+	layer.Set(position, block.GetStates()[1])
+	if err := layer.UnloadChunk(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := layer.Get(position); ok {
+		t.Fatal("expected the state to be removed when its chunk was unloaded")
+	}
+
+	var states, loadErr = layer.LoadChunk(0, 0, manager)
+	if loadErr != nil {
+		t.Fatal(loadErr)
+	}
+	if state, ok := states[position]; !ok || state.Properties["plugin:open"] != true {
+		t.Errorf("expected the open crate to be loaded again, got %v", states)
+	}
+}
@@ -0,0 +1,50 @@
+package palette
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// State is a block state of the canonical block states: a block name and the values of its properties.
+// Properties hold bytes, ints and strings, like the NBT the states are loaded from.
+type State struct {
+	Name       string
+	Properties map[string]interface{}
+	// Version is the block version the state was added in, as stored in the dump.
+	Version int32
+	// LegacyId and LegacyData are the legacy ID and data value of the state.
+	// States without legacy ID and data value have a LegacyId of -1.
+	LegacyId   int16
+	LegacyData int16
+}
+
+// NewState returns a new block state with the given name and properties, without legacy ID.
+func NewState(name string, properties map[string]interface{}) State {
+	if properties == nil {
+		properties = make(map[string]interface{})
+	}
+	return State{Name: name, Properties: properties, LegacyId: -1}
+}
+
+// String returns the block state in a canonical form: the name, followed by the properties sorted by name.
+// Two states with the same name and properties always have the same string, regardless of the order of their properties.
+func (state State) String() string {
+	var names = make([]string, 0, len(state.Properties))
+	for name := range state.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var builder strings.Builder
+	builder.WriteString(state.Name)
+	builder.WriteByte('[')
+	for i, name := range names {
+		if i != 0 {
+			builder.WriteByte(',')
+		}
+		fmt.Fprintf(&builder, "%v=%v", name, state.Properties[name])
+	}
+	builder.WriteByte(']')
+	return builder.String()
+}
@@ -0,0 +1,88 @@
+package particles
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AddParticleMask is combined with the ID of a particle to get the ID of the level event spawning it.
+const AddParticleMask int32 = 0x4000
+
+var (
+	particleMutex sync.RWMutex
+	// particleIds holds the IDs of particles, indexed by their name.
+	particleIds = map[string]int32{
+		"bubble":                  1,
+		"critical":                3,
+		"block_force_field":       4,
+		"smoke":                   5,
+		"explode":                 6,
+		"evaporation":             7,
+		"flame":                   8,
+		"lava":                    9,
+		"large_smoke":             10,
+		"redstone":                11,
+		"rising_red_dust":         12,
+		"item_break":              13,
+		"snowball_poof":           14,
+		"huge_explode":            15,
+		"huge_explode_seed":       16,
+		"mob_flame":               17,
+		"heart":                   18,
+		"terrain":                 19,
+		"town_aura":               20,
+		"portal":                  21,
+		"water_splash":            23,
+		"water_wake":              24,
+		"drip_water":              25,
+		"drip_lava":               26,
+		"falling_dust":            28,
+		"mob_spell":               29,
+		"mob_spell_ambient":       30,
+		"mob_spell_instantaneous": 31,
+		"ink":                     32,
+		"slime":                   33,
+		"rain_splash":             34,
+		"villager_angry":          35,
+		"villager_happy":          36,
+		"enchantment_table":       37,
+		"note":                    39,
+		"witch_spell":             40,
+		"carrot":                  41,
+	}
+)
+
+// Register registers the ID of a particle with the given name,
+// or overwrites the ID of the particle with the same name.
+// Names are case insensitive.
+func Register(name string, id int32) {
+	particleMutex.Lock()
+	particleIds[strings.ToLower(name)] = id
+	particleMutex.Unlock()
+}
+
+// Get returns the ID of the particle with the given name, and a bool indicating if it exists.
+func Get(name string) (int32, bool) {
+	particleMutex.RLock()
+	defer particleMutex.RUnlock()
+	var id, ok = particleIds[strings.ToLower(name)]
+	return id, ok
+}
+
+// GetNames returns the names of all registered particles, sorted alphabetically.
+func GetNames() []string {
+	particleMutex.RLock()
+	var names = make([]string, 0, len(particleIds))
+	for name := range particleIds {
+		names = append(names, name)
+	}
+	particleMutex.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// GetEventId returns the ID of the level event spawning the particle with the given ID.
+func GetEventId(id int32) int32 {
+	return AddParticleMask | id
+}
@@ -0,0 +1,18 @@
+package particles
+
+import (
+	"testing"
+)
+
+func TestParticles(t *testing.T) {
+	if id, ok := Get("HEART"); !ok || id != 18 {
+		t.Errorf("expected heart to have ID 18, got %v", id)
+	}
+	if eventId := GetEventId(18); eventId != 0x4012 {
+		t.Errorf("expected level event 0x4012, got %#x", eventId)
+	}
+	Register("sparkle", 99)
+	if id, ok := Get("sparkle"); !ok || id != 99 {
+		t.Errorf("expected sparkle to have ID 99, got %v", id)
+	}
+}
@@ -74,3 +74,12 @@ func (manager *Manager) IsPermissionRegistered(name string) bool {
 func (manager *Manager) RegisterPermission(permission *Permission) {
 	manager.permissions[permission.GetName()] = permission
 }
+
+// GetRequiredLevel returns the op level required to be granted the permission with the given name.
+// Permissions that are not registered require the highest op level.
+func (manager *Manager) GetRequiredLevel(name string) int {
+	if permission, ok := manager.permissions[name]; ok {
+		return permission.GetDefaultLevel()
+	}
+	return MaxOpLevel
+}
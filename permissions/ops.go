@@ -0,0 +1,146 @@
+package permissions
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// MaxOpLevel is the highest op level, granting every permission.
+	MaxOpLevel = 4
+	// DefaultOpLevel is the op level given to operators when no level is specified.
+	DefaultOpLevel = MaxOpLevel
+)
+
+// Op is an operator in the op list, stored in ops.json.
+// Xuid is the XUID of the operator, which is filled in when the operator first joins authenticated with XBOX Live.
+type Op struct {
+	Name  string `json:"name"`
+	Xuid  string `json:"xuid,omitempty"`
+	Level int    `json:"level"`
+}
+
+// OpList holds the op levels of operators, indexed by lowercase player name.
+// Operators with an XUID are matched by XUID when they join, see GetPlayerLevel.
+// Players not in the list have op level 0.
+type OpList struct {
+	mutex sync.RWMutex
+	path  string
+	ops   map[string]Op
+}
+
+// LoadOpList loads the op list from the JSON file at the given path.
+// An empty list is returned if the file does not exist.
+func LoadOpList(path string) (*OpList, error) {
+	var list = &OpList{path: path, ops: make(map[string]Op)}
+	var file, err = ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return list, nil
+		}
+		return list, err
+	}
+	var ops []Op
+	if err := json.Unmarshal(file, &ops); err != nil {
+		return list, err
+	}
+	for _, op := range ops {
+		list.ops[strings.ToLower(op.Name)] = Op{Name: op.Name, Xuid: op.Xuid, Level: clampLevel(op.Level)}
+	}
+	return list, nil
+}
+
+// GetLevel returns the op level of the operator with the given name, ignoring case.
+// Joining players should be looked up with GetPlayerLevel instead, which also matches their XUID.
+func (list *OpList) GetLevel(name string) int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+	return list.ops[strings.ToLower(name)].Level
+}
+
+// GetPlayerLevel returns the op level of a player joining with the given name and XUID,
+// and a bool indicating if the list changed and should be saved.
+// Players authenticated with XBOX Live are matched by XUID, so that they keep their op level after changing their name,
+// in which case the operator is renamed. Operators without XUID, such as operators added before they ever joined,
+// are matched by name and get the XUID of the first authenticated player using the name.
+// Operators with an XUID are never matched by name, so players that are not authenticated can not take their level.
+func (list *OpList) GetPlayerLevel(name, xuid string, authenticated bool) (int, bool) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+	var key = strings.ToLower(name)
+	if authenticated && xuid != "" {
+		for k, op := range list.ops {
+			if op.Xuid != xuid {
+				continue
+			}
+			if op.Name == name {
+				return op.Level, false
+			}
+			delete(list.ops, k)
+			op.Name = name
+			list.ops[key] = op
+			return op.Level, true
+		}
+	}
+	var op, ok = list.ops[key]
+	if !ok || op.Xuid != "" {
+		return 0, false
+	}
+	if !authenticated || xuid == "" {
+		return op.Level, false
+	}
+	op.Xuid = xuid
+	list.ops[key] = op
+	return op.Level, true
+}
+
+// SetLevel sets the op level of the player with the given name, keeping the XUID of the operator if it has one.
+// The player gets removed from the list if the level is 0.
+func (list *OpList) SetLevel(name string, level int) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+	var key = strings.ToLower(name)
+	if level = clampLevel(level); level == 0 {
+		delete(list.ops, key)
+		return
+	}
+	list.ops[key] = Op{Name: name, Xuid: list.ops[key].Xuid, Level: level}
+}
+
+// GetOps returns all operators in the list, sorted by name.
+func (list *OpList) GetOps() []Op {
+	list.mutex.RLock()
+	var ops = make([]Op, 0, len(list.ops))
+	for _, op := range list.ops {
+		ops = append(ops, op)
+	}
+	list.mutex.RUnlock()
+	sort.Slice(ops, func(i, j int) bool {
+		return strings.ToLower(ops[i].Name) < strings.ToLower(ops[j].Name)
+	})
+	return ops
+}
+
+// Save writes the op list to its file.
+func (list *OpList) Save() error {
+	var encoded, err = json.MarshalIndent(list.GetOps(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(list.path, encoded, 0644)
+}
+
+// clampLevel returns the level limited to the range of op levels.
+func clampLevel(level int) int {
+	if level < 0 {
+		return 0
+	}
+	if level > MaxOpLevel {
+		return MaxOpLevel
+	}
+	return level
+}
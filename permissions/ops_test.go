@@ -0,0 +1,81 @@
+package permissions
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpList(t *testing.T) {
+	var directory, err = ioutil.TempDir("", "ops")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+	var path = filepath.Join(directory, "ops.json")
+
+	var list, _ = LoadOpList(path)
+	list.SetLevel("Steve", 7)
+	list.SetLevel("Alex", 2)
+	if level := list.GetLevel("steve"); level != MaxOpLevel {
+		t.Errorf("expected op level to be clamped to %v, got %v", MaxOpLevel, level)
+	}
+	if err := list.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err = LoadOpList(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level := list.GetLevel("ALEX"); level != 2 {
+		t.Errorf("expected op level 2 after loading, got %v", level)
+	}
+	list.SetLevel("Alex", 0)
+	if ops := list.GetOps(); len(ops) != 1 || ops[0].Name != "Steve" {
+		t.Errorf("expected only Steve to be an operator, got %v", ops)
+	}
+}
+
+func TestPlayerLevel(t *testing.T) {
+	var list, _ = LoadOpList(filepath.Join(os.TempDir(), "ops-unsaved.json"))
+	list.SetLevel("Steve", 3)
+	if level, changed := list.GetPlayerLevel("Steve", "1234", false); level != 3 || changed {
+		t.Errorf("expected unauthenticated players to match operators without XUID by name, got %v", level)
+	}
+	if level, changed := list.GetPlayerLevel("steve", "1234", true); level != 3 || !changed {
+		t.Errorf("expected the first authenticated join to fill in the XUID, got %v", level)
+	}
+	if level, _ := list.GetPlayerLevel("Steve", "", false); level != 0 {
+		t.Errorf("expected unauthenticated players not to match an operator with an XUID, got %v", level)
+	}
+	if level, _ := list.GetPlayerLevel("Steve", "5678", true); level != 0 {
+		t.Errorf("expected players with another XUID not to match the operator, got %v", level)
+	}
+	if level, changed := list.GetPlayerLevel("Herobrine", "1234", true); level != 3 || !changed {
+		t.Errorf("expected the operator to be matched by XUID after changing its name, got %v", level)
+	}
+	if ops := list.GetOps(); len(ops) != 1 || ops[0].Name != "Herobrine" || ops[0].Xuid != "1234" {
+		t.Errorf("expected the operator to be renamed, got %v", ops)
+	}
+	list.SetLevel("herobrine", 1)
+	if ops := list.GetOps(); ops[0].Xuid != "1234" || ops[0].Level != 1 {
+		t.Errorf("expected setting the level to keep the XUID, got %v", ops)
+	}
+}
+
+func TestRequiredLevel(t *testing.T) {
+	var manager = NewManager()
+	manager.RegisterPermission(NewPermission("gomine.list", 0))
+	manager.RegisterPermission(NewPermission("gomine.teleport", 2))
+	if level := manager.GetRequiredLevel("gomine.teleport"); level != 2 {
+		t.Errorf("expected required level 2, got %v", level)
+	}
+	if level := manager.GetRequiredLevel("gomine.list"); level != 0 {
+		t.Errorf("expected required level 0, got %v", level)
+	}
+	if level := manager.GetRequiredLevel("plugin.unknown"); level != MaxOpLevel {
+		t.Errorf("expected unregistered permissions to require level %v, got %v", MaxOpLevel, level)
+	}
+}
@@ -10,7 +10,7 @@ type Permission struct {
 
 // NewPermission returns a new permission with the given name and default level.
 func NewPermission(name string, defaultLevel int) *Permission {
-	return &Permission{name, defaultLevel & 0x04, make(map[string]*Permission)}
+	return &Permission{name, clampLevel(defaultLevel), make(map[string]*Permission)}
 }
 
 // GetName returns the name of the permission.
@@ -18,14 +18,14 @@ func (permission *Permission) GetName() string {
 	return permission.name
 }
 
-// GetDefaultLevel returns the default level of required to be granted the permission.
+// GetDefaultLevel returns the op level required to be granted the permission.
 func (permission *Permission) GetDefaultLevel() int {
 	return permission.defaultLevel
 }
 
 // SetDefaultLevel sets the default level of the the permission.
 func (permission *Permission) SetDefaultLevel(level int) {
-	permission.defaultLevel = level & 0x04
+	permission.defaultLevel = clampLevel(level)
 }
 
 // GetChildren returns a name => permission child permission map of all children.
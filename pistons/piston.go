@@ -0,0 +1,225 @@
+package pistons
+
+import (
+	"github.com/BobbyShrd/gominetest/tasks"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	// DefaultPushLimit is the maximum amount of blocks a vanilla piston can push.
+	DefaultPushLimit = 12
+	// MoveDelay is the amount of ticks between powering a piston and it moving.
+	MoveDelay = 2
+
+	EventExtend  int32 = 0
+	EventRetract int32 = 1
+
+	Piston           = "piston"
+	StickyPiston     = "sticky_piston"
+	PistonHead       = "piston_arm_collision"
+	StickyPistonHead = "sticky_piston_arm_collision"
+	Air              = "air"
+
+	PistonHeadId       int32 = 34
+	StickyPistonHeadId int32 = 472
+)
+
+// immovableBlocks are blocks that can not be pushed or pulled by pistons.
+var immovableBlocks = map[string]bool{
+	"bedrock":          true,
+	"obsidian":         true,
+	"end_portal_frame": true,
+	"end_portal":       true,
+	"portal":           true,
+	"enchanting_table": true,
+	"ender_chest":      true,
+	"chest":            true,
+	"trapped_chest":    true,
+	"furnace":          true,
+	"lit_furnace":      true,
+	"hopper":           true,
+	"beacon":           true,
+	"mob_spawner":      true,
+	"standing_sign":    true,
+	"wall_sign":        true,
+	"command_block":    true,
+	PistonHead:         true,
+	StickyPistonHead:   true,
+	"moving_block":     true,
+	"barrier":          true,
+}
+
+// replaceableBlocks are blocks that get destroyed when pushed by a piston,
+// and which pistons can push blocks into.
+var replaceableBlocks = map[string]bool{
+	Air:                    true,
+	"water":                true,
+	"flowing_water":        true,
+	"lava":                 true,
+	"flowing_lava":         true,
+	"tallgrass":            true,
+	"double_plant":         true,
+	"yellow_flower":        true,
+	"red_flower":           true,
+	"deadbush":             true,
+	"sapling":              true,
+	"torch":                true,
+	"redstone_wire":        true,
+	"snow_layer":           true,
+	"fire":                 true,
+	"web":                  true,
+	"vine":                 true,
+	"waterlily":            true,
+	"wheat":                true,
+	"reeds":                true,
+	"brown_mushroom":       true,
+	"red_mushroom":         true,
+	"redstone_torch":       true,
+	"unlit_redstone_torch": true,
+}
+
+// IsPiston checks if the block with the given name is a piston or sticky piston.
+func IsPiston(name string) bool {
+	return name == Piston || name == StickyPiston
+}
+
+// IsMovable checks if the block with the given name can be moved by pistons.
+// Pistons are only movable if they are retracted, which is checked when pushing.
+func IsMovable(name string) bool {
+	return !immovableBlocks[name]
+}
+
+// IsReplaceable checks if the block with the given name gets destroyed when pushed.
+func IsReplaceable(name string) bool {
+	return replaceableBlocks[name]
+}
+
+// SetMovable sets whether the block with the given name can be moved by pistons.
+func SetMovable(name string, movable bool) {
+	if movable {
+		delete(immovableBlocks, name)
+	} else {
+		immovableBlocks[name] = true
+	}
+}
+
+// Manager moves blocks using pistons.
+// Pistons move a couple of ticks after their power changed,
+// using the scheduler of the server.
+type Manager struct {
+	scheduler *tasks.Scheduler
+	enabled   bool
+
+	// PushLimit is the maximum amount of blocks a piston can push.
+	PushLimit int
+}
+
+// NewManager returns a new piston manager scheduling moves with the given scheduler.
+// Pistons do not move at all if the manager is not enabled.
+func NewManager(scheduler *tasks.Scheduler, enabled bool) *Manager {
+	return &Manager{scheduler, enabled, DefaultPushLimit}
+}
+
+// IsEnabled checks if pistons are enabled.
+func (manager *Manager) IsEnabled() bool {
+	return manager.enabled
+}
+
+// IsExtended checks if the piston at the given position is extended.
+func (manager *Manager) IsExtended(world World, position blocks.Position) bool {
+	var head, ok = offsetIn(world, position, world.GetBlockData(position)&7)
+	if !ok {
+		return false
+	}
+	var name = world.GetBlockName(head)
+	return (name == PistonHead || name == StickyPistonHead) && world.GetBlockData(head)&7 == world.GetBlockData(position)&7
+}
+
+// SetPowered updates the piston at the given position with the new power state.
+// A powered retracted piston extends, and an unpowered extended piston retracts,
+// after the move delay has passed.
+func (manager *Manager) SetPowered(world World, position blocks.Position, powered bool) {
+	if !manager.enabled || !IsPiston(world.GetBlockName(position)) {
+		return
+	}
+	manager.scheduler.ScheduleDelayed(func() {
+		if !IsPiston(world.GetBlockName(position)) {
+			return
+		}
+		var extended = manager.IsExtended(world, position)
+		if powered && !extended {
+			manager.Extend(world, position)
+		} else if !powered && extended {
+			manager.Retract(world, position)
+		}
+	}, MoveDelay)
+}
+
+// Extend immediately extends the piston at the given position, pushing the blocks in front of it.
+// Returns false if the piston could not extend, because too many blocks
+// or an immovable block were in the way.
+func (manager *Manager) Extend(world World, position blocks.Position) bool {
+	var facing = world.GetBlockData(position) & 7
+	var head, ok = offsetIn(world, position, facing)
+	if !ok {
+		return false
+	}
+
+	var line []blocks.Position
+	var current = head
+	for {
+		var name = world.GetBlockName(current)
+		if IsReplaceable(name) {
+			break
+		}
+		if !IsMovable(name) || len(line) == manager.PushLimit {
+			return false
+		}
+		if IsPiston(name) && manager.IsExtended(world, current) {
+			return false
+		}
+		line = append(line, current)
+		if current, ok = offsetIn(world, current, facing); !ok {
+			return false
+		}
+	}
+
+	if world.GetBlockName(current) != Air {
+		world.PlaceBlock(current, Air, 0, 0)
+	}
+	for i := len(line) - 1; i >= 0; i-- {
+		var next, _ = Offset(line[i], facing)
+		world.MoveBlock(line[i], next)
+	}
+
+	if world.GetBlockName(position) == StickyPiston {
+		world.PlaceBlock(head, StickyPistonHead, StickyPistonHeadId, facing)
+	} else {
+		world.PlaceBlock(head, PistonHead, PistonHeadId, facing)
+	}
+	world.BroadcastBlockEvent(position, EventExtend, int32(facing))
+	return true
+}
+
+// Retract immediately retracts the piston at the given position.
+// Sticky pistons pull the block in front of their head back with them.
+// Returns false if the piston was not extended.
+func (manager *Manager) Retract(world World, position blocks.Position) bool {
+	if !manager.IsExtended(world, position) {
+		return false
+	}
+	var facing = world.GetBlockData(position) & 7
+	var head, _ = offsetIn(world, position, facing)
+	world.PlaceBlock(head, Air, 0, 0)
+
+	if world.GetBlockName(position) == StickyPiston {
+		if pulled, ok := offsetIn(world, head, facing); ok {
+			var name = world.GetBlockName(pulled)
+			if !IsReplaceable(name) && IsMovable(name) && !(IsPiston(name) && manager.IsExtended(world, pulled)) {
+				world.MoveBlock(pulled, head)
+			}
+		}
+	}
+	world.BroadcastBlockEvent(position, EventRetract, int32(facing))
+	return true
+}
@@ -0,0 +1,69 @@
+package pistons
+
+import (
+	"testing"
+
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/irmine/worlds/blocks"
+)
+
+type testBlock struct {
+	name string
+	data byte
+}
+
+type testWorld map[blocks.Position]testBlock
+
+func (world testWorld) GetBlockName(position blocks.Position) string {
+	if block, ok := world[position]; ok {
+		return block.name
+	}
+	return Air
+}
+
+func (world testWorld) GetBlockData(position blocks.Position) byte {
+	return world[position].data
+}
+
+func (world testWorld) MoveBlock(from blocks.Position, to blocks.Position) {
+	world[to] = world[from]
+	delete(world, from)
+}
+
+func (world testWorld) PlaceBlock(position blocks.Position, name string, _ int32, data byte) {
+	world[position] = testBlock{name, data}
+}
+
+func (world testWorld) BroadcastBlockEvent(blocks.Position, int32, int32) {}
+
+func (world testWorld) GetHeightRange() levels.HeightRange {
+	return levels.HeightRange{Min: 0, Max: 256}
+}
+
+func TestPiston(t *testing.T) {
+	var world = testWorld{}
+	var manager = NewManager(nil, true)
+	var piston = blocks.NewPosition(0, 10, 0)
+	world[piston] = testBlock{StickyPiston, FaceEast}
+	world[blocks.NewPosition(1, 10, 0)] = testBlock{"stone", 0}
+	world[blocks.NewPosition(2, 10, 0)] = testBlock{"dirt", 0}
+
+	if !manager.Extend(world, piston) {
+		t.Fatal("expected piston to extend")
+	}
+	if world.GetBlockName(blocks.NewPosition(1, 10, 0)) != StickyPistonHead || world.GetBlockName(blocks.NewPosition(2, 10, 0)) != "stone" || world.GetBlockName(blocks.NewPosition(3, 10, 0)) != "dirt" {
+		t.Error("expected blocks to be pushed one block east")
+	}
+
+	if !manager.Retract(world, piston) {
+		t.Fatal("expected piston to retract")
+	}
+	if world.GetBlockName(blocks.NewPosition(1, 10, 0)) != "stone" || world.GetBlockName(blocks.NewPosition(2, 10, 0)) != Air {
+		t.Error("expected sticky piston to pull the stone back")
+	}
+
+	world[blocks.NewPosition(2, 10, 0)] = testBlock{"obsidian", 0}
+	if manager.Extend(world, piston) {
+		t.Error("expected piston not to push obsidian")
+	}
+}
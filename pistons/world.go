@@ -0,0 +1,65 @@
+package pistons
+
+import (
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/irmine/worlds/blocks"
+)
+
+// World is the world pistons move blocks in.
+// It is implemented by the server for every dimension.
+type World interface {
+	// GetBlockName returns the name of the block at the given position.
+	GetBlockName(position blocks.Position) string
+	// GetBlockData returns the data value of the block at the given position.
+	GetBlockData(position blocks.Position) byte
+	// MoveBlock moves the block at one position to another, leaving air behind.
+	MoveBlock(from blocks.Position, to blocks.Position)
+	// PlaceBlock places a block with the given name, legacy ID and data value at the given position.
+	PlaceBlock(position blocks.Position, name string, id int32, data byte)
+	// BroadcastBlockEvent sends a block event to all viewers of the given position.
+	BroadcastBlockEvent(position blocks.Position, eventType int32, eventData int32)
+	// GetHeightRange returns the range of heights blocks can be placed at in the world.
+	GetHeightRange() levels.HeightRange
+}
+
+// Faces of blocks, as stored in the lowest three bits of the data value of pistons.
+const (
+	FaceDown byte = iota
+	FaceUp
+	FaceNorth
+	FaceSouth
+	FaceWest
+	FaceEast
+)
+
+// Offset returns the position next to the given position in the direction of the face.
+// A bool is returned which is false if the position would be below height 0, or if the face is invalid.
+func Offset(position blocks.Position, face byte) (blocks.Position, bool) {
+	switch face {
+	case FaceDown:
+		if position.Y == 0 {
+			return position, false
+		}
+		position.Y--
+	case FaceUp:
+		position.Y++
+	case FaceNorth:
+		position.Z--
+	case FaceSouth:
+		position.Z++
+	case FaceWest:
+		position.X--
+	case FaceEast:
+		position.X++
+	default:
+		return position, false
+	}
+	return position, true
+}
+
+// offsetIn returns the position next to the given position in the direction of the face,
+// and a bool which is false if the position would be outside of the height range of the world.
+func offsetIn(world World, position blocks.Position, face byte) (blocks.Position, bool) {
+	var next, ok = Offset(position, face)
+	return next, ok && world.GetHeightRange().Contains(int32(next.Y))
+}
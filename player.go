@@ -1,17 +1,32 @@
 package players
 
 import (
+	"github.com/BobbyShrd/gominetest/effects"
+	"github.com/BobbyShrd/gominetest/environment"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/metadata"
+	"github.com/BobbyShrd/gominetest/skins"
 	"github.com/google/uuid"
 	"github.com/irmine/worlds/entities"
 	"math"
 )
 
+// MaximumHealth is the maximum health of a player.
+const MaximumHealth float32 = 20
+
+// BaseMovementDistance is the maximum horizontal distance in blocks a player
+// may move between two movement updates without any effects applied.
+// It is lenient to allow for sprint jumping and delayed packets.
+const BaseMovementDistance = 1.0
+
 type Player struct {
 	*entities.Entity
 	uuid     uuid.UUID
 	xuid     string
 	platform int32
 
+	platformChatId string
+
 	playerName  string
 	displayName string
 
@@ -20,6 +35,26 @@ type Player struct {
 	capeData     []byte
 	geometryName string
 	geometryData string
+	skin         *skins.Skin
+
+	data      *Data
+	metadata  *metadata.Store
+	inventory *Inventory
+	armor     *Inventory
+	offhand   *Inventory
+	heldSlot  int
+	sneaking  bool
+	blocking  bool
+	effects   *effects.Container
+	environment *environment.State
+	abilities   Abilities
+
+	// EffectExpiredFunction gets called when an effect of the player expires.
+	// Nothing is done if nil.
+	EffectExpiredFunction func(player *Player, effect *effects.Effect)
+	// HealthChangedFunction gets called when the health of the player is changed by effects.
+	// Nothing is done if nil.
+	HealthChangedFunction func(player *Player)
 }
 
 // NewPlayer returns a new player with the given name.
@@ -32,6 +67,13 @@ func NewPlayer(uuid uuid.UUID, xuid string, platform int32, name string) *Player
 
 	player.playerName = name
 	player.displayName = name
+	player.data = NewData()
+	player.metadata = metadata.NewStore()
+	player.inventory = NewInventory()
+	player.armor = NewArmorInventory()
+	player.offhand = NewOffhandInventory()
+	player.effects = effects.NewContainer()
+	player.environment = environment.NewState()
 
 	return player
 }
@@ -57,6 +99,91 @@ func (player *Player) SetDisplayName(name string) {
 	player.displayName = name
 }
 
+// GetData returns the persisted data of the player.
+func (player *Player) GetData() *Data {
+	return player.data
+}
+
+// GetInventory returns the inventory of the player.
+func (player *Player) GetInventory() *Inventory {
+	return player.inventory
+}
+
+// GetArmor returns the armor inventory of the player.
+func (player *Player) GetArmor() *Inventory {
+	return player.armor
+}
+
+// GetOffhand returns the offhand inventory of the player.
+func (player *Player) GetOffhand() *Inventory {
+	return player.offhand
+}
+
+// GetHeldSlot returns the hotbar slot the player is holding.
+func (player *Player) GetHeldSlot() int {
+	return player.heldSlot
+}
+
+// SetHeldSlot sets the hotbar slot the player is holding.
+// Slots outside of the hotbar are ignored.
+func (player *Player) SetHeldSlot(slot int) {
+	if slot >= 0 && slot < HotbarSize {
+		player.heldSlot = slot
+	}
+}
+
+// GetHeldItem returns the item in the hotbar slot the player is holding, or nil if the slot is empty.
+func (player *Player) GetHeldItem() *items.Stack {
+	return player.inventory.GetItem(player.heldSlot)
+}
+
+// IsSneaking checks if the player is sneaking.
+func (player *Player) IsSneaking() bool {
+	return player.sneaking
+}
+
+// SetSneaking sets the player sneaking or not sneaking.
+func (player *Player) SetSneaking(value bool) {
+	player.sneaking = value
+}
+
+// IsBlocking checks if the player is blocking with a shield.
+func (player *Player) IsBlocking() bool {
+	return player.blocking
+}
+
+// SetBlocking sets the player blocking or not blocking with a shield.
+func (player *Player) SetBlocking(value bool) {
+	player.blocking = value
+}
+
+// GetEffects returns the effects applied to the player.
+func (player *Player) GetEffects() *effects.Container {
+	return player.effects
+}
+
+// GetEnvironment returns the environmental state of the player, holding its air supply and the ticks it is on fire.
+func (player *Player) GetEnvironment() *environment.State {
+	return player.environment
+}
+
+// GetMaximumMovementDistance returns the maximum horizontal distance the player
+// may move between two movement updates, taking speed and slowness effects into account.
+func (player *Player) GetMaximumMovementDistance() float64 {
+	return BaseMovementDistance * player.effects.GetSpeedMultiplier()
+}
+
+// GetMetadata returns the values attached to the player by plugins.
+// Persistent values are saved with the data of the player.
+func (player *Player) GetMetadata() *metadata.Store {
+	return player.metadata
+}
+
+// SetData sets the persisted data of the player.
+func (player *Player) SetData(data *Data) {
+	player.data = data
+}
+
 // GetUUID returns the UUID of the player.
 func (player *Player) GetUUID() uuid.UUID {
 	return player.uuid
@@ -72,6 +199,17 @@ func (player *Player) GetPlatform() int32 {
 	return player.platform
 }
 
+// GetPlatformChatId returns the ID of the player on the chat of its platform, such as its Nintendo Switch ID.
+// The ID is empty on platforms without platform chat.
+func (player *Player) GetPlatformChatId() string {
+	return player.platformChatId
+}
+
+// SetPlatformChatId sets the ID of the player on the chat of its platform.
+func (player *Player) SetPlatformChatId(id string) {
+	player.platformChatId = id
+}
+
 // SpawnPlayerTo spawns this player to the given other player.
 func (player *Player) SpawnPlayerTo(viewer entities.Viewer) {
 	viewer.SendAddPlayer(player.GetUUID(), player)
@@ -89,6 +227,23 @@ func (player *Player) SpawnPlayerToAll() {
 	}
 }
 
+// GetSkin returns the full skin of the player.
+// Nil is returned if the player has no skin set yet.
+func (player *Player) GetSkin() *skins.Skin {
+	return player.skin
+}
+
+// SetSkin sets the full skin of the player,
+// and sets the legacy skin fields to those of the skin.
+func (player *Player) SetSkin(skin *skins.Skin) {
+	player.skin = skin
+	player.skinId = skin.Id
+	player.skinData = skin.Image.Data
+	player.capeData = skin.Cape.Data
+	player.geometryName = skin.GetGeometryName()
+	player.geometryData = skin.Geometry
+}
+
 // SetSkinId sets the skin ID/name of the player.
 func (player *Player) SetSkinId(id string) {
 	player.skinId = id
@@ -167,6 +322,7 @@ func (player *Player) BroadcastMovement() {
 
 // Tick ticks the player, this overrides the base entity tick.
 func (player Player) Tick() {
+	player.tickEffects()
 	if player.HasEntityDataUpdate {
 		player.BroadcastUpdatedEntityData()
 		player.HasEntityDataUpdate = false
@@ -175,4 +331,30 @@ func (player Player) Tick() {
 		player.HasMovementUpdate = false
 	}
 	player.BroadcastMovement()
+}
+
+// tickEffects ticks the effects of the player, and applies the health changes of the effects.
+// Effects never reduce the health of the player below 1.
+func (player *Player) tickEffects() {
+	var health, expired = player.effects.Tick()
+	if health != 0 {
+		var newHealth = player.GetHealth() + health
+		if newHealth > MaximumHealth {
+			newHealth = MaximumHealth
+		}
+		if newHealth < 1 {
+			newHealth = 1
+		}
+		if newHealth != player.GetHealth() {
+			player.SetHealth(newHealth)
+			if player.HealthChangedFunction != nil {
+				player.HealthChangedFunction(player)
+			}
+		}
+	}
+	if player.EffectExpiredFunction != nil {
+		for _, effect := range expired {
+			player.EffectExpiredFunction(player, effect)
+		}
+	}
 }
\ No newline at end of file
@@ -0,0 +1,201 @@
+package gomine
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/packets/data"
+	"github.com/BobbyShrd/gominetest/net/protocol"
+	"github.com/BobbyShrd/gominetest/players"
+	"github.com/BobbyShrd/gominetest/text"
+	data2 "github.com/irmine/worlds/entities/data"
+)
+
+const MaximumNicknameLength = 16
+
+var (
+	InvalidNickname = errors.New("nickname must be between 1 and 16 characters")
+	NicknameTaken   = errors.New("nickname is already in use by another player")
+)
+
+// GetPlayerDataPath returns the path of the data file of the player with the given name.
+// Only players that are not logged into XBOX Live have their data stored by name.
+// The name is reduced to its last path element, so names given in commands can not point outside the players directory.
+func (server *Server) GetPlayerDataPath(name string) string {
+	return server.ServerPath + "players/" + filepath.Base(strings.ToLower(name)) + ".yml"
+}
+
+// GetPlayerDataPathByXUID returns the path of the data file of the player with the given XUID.
+// The XUID is reduced to its last path element, like names.
+func (server *Server) GetPlayerDataPathByXUID(xuid string) string {
+	return server.ServerPath + "players/xuid/" + filepath.Base(xuid) + ".yml"
+}
+
+// getSessionDataPath returns the path of the data file of the player of the session.
+// Data is stored by XUID if the player is logged into XBOX Live, so it is kept when the player changes its name.
+func (server *Server) getSessionDataPath(session *net.MinecraftSession) string {
+	if session.IsXBOXLiveAuthenticated() && session.GetXUID() != "" {
+		return server.GetPlayerDataPathByXUID(session.GetXUID())
+	}
+	return server.GetPlayerDataPath(session.GetName())
+}
+
+// HasPlayerData checks if a player with the given name has played on the server before.
+func (server *Server) HasPlayerData(name string) bool {
+	if _, ok := server.playerNames.GetXUID(name); ok {
+		return true
+	}
+	var _, err = os.Stat(server.GetPlayerDataPath(name))
+	return err == nil
+}
+
+// LoadPlayerData loads the persisted data of the player of the session,
+// and applies the nickname and persistent metadata of the player.
+// Data of XBOX Live players that is still stored by name is migrated to be stored by XUID.
+func (server *Server) LoadPlayerData(session *net.MinecraftSession) {
+	if session.IsXBOXLiveAuthenticated() && session.GetXUID() != "" {
+		text.DefaultLogger.LogError(server.migratePlayerData(session.GetName(), session.GetXUID()))
+	}
+	var playerData, err = players.LoadData(server.getSessionDataPath(session))
+	text.DefaultLogger.LogError(err)
+
+	session.GetPlayer().SetData(playerData)
+	session.GetPlayer().GetMetadata().Decode(playerData.Metadata)
+	if playerData.Nickname != "" {
+		session.GetPlayer().SetDisplayName(playerData.Nickname)
+		session.GetPlayer().SetEntityProperty(data2.EntityDataNameTag, playerData.Nickname)
+	}
+}
+
+// SavePlayerData saves the persisted data of the player of the session.
+func (server *Server) SavePlayerData(session *net.MinecraftSession) {
+	var playerData = session.GetPlayer().GetData()
+	playerData.Name = session.GetName()
+	playerData.LastPlayed = time.Now().Unix()
+	playerData.Metadata = session.GetPlayer().GetMetadata().Encode()
+	if session.IsXBOXLiveAuthenticated() && session.GetXUID() != "" {
+		playerData.XUID = session.GetXUID()
+		server.playerNames.Set(session.GetName(), session.GetXUID())
+		text.DefaultLogger.LogError(server.playerNames.Save())
+	}
+	text.DefaultLogger.LogError(playerData.Save(server.getSessionDataPath(session)))
+}
+
+// migratePlayerData moves the data of the player with the given name from its name keyed file
+// to the file of the given XUID. Nothing is done if data is already stored for the XUID,
+// or if the name keyed data belongs to a different XUID.
+func (server *Server) migratePlayerData(name string, xuid string) error {
+	var path = server.GetPlayerDataPathByXUID(xuid)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	var legacyPath = server.GetPlayerDataPath(name)
+	if _, err := os.Stat(legacyPath); err != nil {
+		return nil
+	}
+	var playerData, err = players.LoadData(legacyPath)
+	if err != nil {
+		return err
+	}
+	if playerData.XUID != "" && playerData.XUID != xuid {
+		return nil
+	}
+	playerData.Name = name
+	playerData.XUID = xuid
+	if err := playerData.Save(path); err != nil {
+		return err
+	}
+	server.playerNames.Set(name, xuid)
+	if err := server.playerNames.Save(); err != nil {
+		return err
+	}
+	text.DefaultLogger.Debug("Migrated player data of", name, "to XUID", xuid)
+	return os.Remove(legacyPath)
+}
+
+// MigratePlayerData migrates all name keyed player data files that record an XUID
+// to be stored by XUID. Files without an XUID are migrated once their player joins while logged into XBOX Live.
+func (server *Server) MigratePlayerData() {
+	var files, err = ioutil.ReadDir(server.ServerPath + "players/")
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".yml") {
+			continue
+		}
+		var playerData, err = players.LoadData(server.ServerPath + "players/" + file.Name())
+		if err != nil || playerData.XUID == "" {
+			continue
+		}
+		var name = playerData.Name
+		if name == "" {
+			name = strings.TrimSuffix(file.Name(), ".yml")
+		}
+		text.DefaultLogger.LogError(server.migratePlayerData(name, playerData.XUID))
+	}
+}
+
+// SetNickname sets the nickname of the player of the session.
+// An error is returned if the nickname is invalid,
+// or if it could be used to impersonate another player.
+func (server *Server) SetNickname(session *net.MinecraftSession, nickname string) error {
+	var stripped = strings.TrimSpace(text.ColoredString(nickname).StripAll())
+	if len(stripped) == 0 || len(stripped) > MaximumNicknameLength {
+		return InvalidNickname
+	}
+
+	for name, online := range server.SessionManager.GetSessions() {
+		if online == session {
+			continue
+		}
+		if strings.EqualFold(name, stripped) || strings.EqualFold(text.ColoredString(online.GetDisplayName()).StripAll(), stripped) {
+			return NicknameTaken
+		}
+	}
+	if !strings.EqualFold(session.GetName(), stripped) && server.HasPlayerData(stripped) {
+		return NicknameTaken
+	}
+
+	session.GetPlayer().GetData().Nickname = nickname
+	server.updateDisplayName(session, nickname)
+	server.SavePlayerData(session)
+	return nil
+}
+
+// ClearNickname clears the nickname of the player of the session,
+// restoring the display name to the name of the player.
+func (server *Server) ClearNickname(session *net.MinecraftSession) {
+	session.GetPlayer().GetData().Nickname = ""
+	server.updateDisplayName(session, session.GetName())
+	server.SavePlayerData(session)
+}
+
+// updateDisplayName sets the display name of the player of the session,
+// and updates the name tag and player list entry for all players.
+func (server *Server) updateDisplayName(session *net.MinecraftSession, displayName string) {
+	var player = session.GetPlayer()
+	player.SetDisplayName(displayName)
+	if !session.HasSpawned() {
+		return
+	}
+
+	player.SetEntityProperty(data2.EntityDataNameTag, displayName)
+	player.BroadcastUpdatedEntityData()
+
+	var entry = map[string]protocol.PlayerListEntry{session.GetName(): player}
+	var customized = server.PlayerList.IsCustomized()
+	for _, online := range server.SessionManager.GetSessions() {
+		online.SendPlayerList(data.ListTypeRemove, entry)
+		if !customized {
+			online.SendPlayerList(data.ListTypeAdd, entry)
+		}
+	}
+	server.PlayerList.Remove(session.GetName())
+	server.updatePlayerList()
+}
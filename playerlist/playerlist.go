@@ -0,0 +1,147 @@
+package playerlist
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Manager controls the order of the entries in the player list, and the entries hidden from it.
+// Entries with a higher priority are listed first, for example to list staff before other players.
+// Entries with the same priority are listed alphabetically.
+type Manager struct {
+	mutex      sync.RWMutex
+	priorities map[string]int
+	hidden     map[string]bool
+	listed     map[string][]string
+
+	// PriorityFunction returns the priority of players without a priority set, for example based on their permissions.
+	// Players without a priority set have priority 0 if PriorityFunction is nil.
+	PriorityFunction func(name string) int
+}
+
+// NewManager returns a new player list manager listing all players alphabetically.
+func NewManager() *Manager {
+	return &Manager{priorities: make(map[string]int), hidden: make(map[string]bool), listed: make(map[string][]string)}
+}
+
+// SetPriority sets the priority of the player with the given name.
+func (manager *Manager) SetPriority(name string, priority int) {
+	manager.mutex.Lock()
+	manager.priorities[name] = priority
+	manager.mutex.Unlock()
+}
+
+// RemovePriority removes the priority set for the player with the given name.
+func (manager *Manager) RemovePriority(name string) {
+	manager.mutex.Lock()
+	delete(manager.priorities, name)
+	manager.mutex.Unlock()
+}
+
+// GetPriority returns the priority of the player with the given name.
+func (manager *Manager) GetPriority(name string) int {
+	manager.mutex.RLock()
+	var priority, ok = manager.priorities[name]
+	manager.mutex.RUnlock()
+	if !ok && manager.PriorityFunction != nil {
+		return manager.PriorityFunction(name)
+	}
+	return priority
+}
+
+// Hide hides the player with the given name from the player list.
+func (manager *Manager) Hide(name string) {
+	manager.mutex.Lock()
+	manager.hidden[name] = true
+	manager.mutex.Unlock()
+}
+
+// Show shows the player with the given name in the player list again.
+func (manager *Manager) Show(name string) {
+	manager.mutex.Lock()
+	delete(manager.hidden, name)
+	manager.mutex.Unlock()
+}
+
+// IsHidden checks if the player with the given name is hidden from the player list.
+func (manager *Manager) IsHidden(name string) bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return manager.hidden[name]
+}
+
+// IsCustomized checks if the player list differs from the default list,
+// which is the case if priorities are set or players are hidden.
+func (manager *Manager) IsCustomized() bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return len(manager.priorities) != 0 || len(manager.hidden) != 0 || manager.PriorityFunction != nil
+}
+
+// Sort returns the names of the players that are not hidden, in the order they are listed.
+func (manager *Manager) Sort(names []string) []string {
+	var visible = make([]string, 0, len(names))
+	var priorities = make(map[string]int, len(names))
+	for _, name := range names {
+		if !manager.IsHidden(name) {
+			visible = append(visible, name)
+			priorities[name] = manager.GetPriority(name)
+		}
+	}
+	sort.Slice(visible, func(i, j int) bool {
+		if priorities[visible[i]] != priorities[visible[j]] {
+			return priorities[visible[i]] > priorities[visible[j]]
+		}
+		return strings.ToLower(visible[i]) < strings.ToLower(visible[j])
+	})
+	return visible
+}
+
+// Update returns the changes to the player list of the viewer that list the players with the given names in order,
+// and remembers the names as listed to the viewer. The client lists entries in the order they were added,
+// so the names listed after the first name out of place are removed, and the names from there on are added in order.
+// Listed is false if nothing was listed to the viewer before, in which case all names are added.
+func (manager *Manager) Update(viewer string, names []string) (removed []string, added []string, listed bool) {
+	var sorted = manager.Sort(names)
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var previous, ok = manager.listed[viewer]
+	manager.listed[viewer] = sorted
+	var i = 0
+	for i < len(previous) && i < len(sorted) && previous[i] == sorted[i] {
+		i++
+	}
+	return previous[i:], sorted[i:], ok
+}
+
+// Remove forgets the player with the given name was listed, after its entry was removed from all player lists.
+// Removing an entry does not change the order of the other entries, so they are not listed again.
+func (manager *Manager) Remove(name string) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	for viewer, names := range manager.listed {
+		var remaining = make([]string, 0, len(names))
+		for _, listed := range names {
+			if listed != name {
+				remaining = append(remaining, listed)
+			}
+		}
+		manager.listed[viewer] = remaining
+	}
+}
+
+// Forget forgets the names listed to the viewer with the given name, for when the viewer leaves.
+func (manager *Manager) Forget(viewer string) {
+	manager.mutex.Lock()
+	delete(manager.listed, viewer)
+	manager.mutex.Unlock()
+}
+
+// Reset forgets the names listed to all viewers, for when entries get added to player lists without the manager.
+// The next update of every viewer adds all names again.
+func (manager *Manager) Reset() {
+	manager.mutex.Lock()
+	manager.listed = make(map[string][]string)
+	manager.mutex.Unlock()
+}
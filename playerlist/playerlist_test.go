@@ -0,0 +1,61 @@
+package playerlist
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	var manager = NewManager()
+	var names = []string{"steve", "Alex", "Admin", "Vanished"}
+	if manager.IsCustomized() {
+		t.Error("expected a new manager not to be customized")
+	}
+	if sorted := manager.Sort(names); !reflect.DeepEqual(sorted, []string{"Admin", "Alex", "steve", "Vanished"}) {
+		t.Errorf("expected names in alphabetical order, got %v", sorted)
+	}
+
+	manager.SetPriority("steve", 10)
+	manager.Hide("Vanished")
+	manager.PriorityFunction = func(name string) int {
+		if name == "Admin" {
+			return 20
+		}
+		return 0
+	}
+	if sorted := manager.Sort(names); !reflect.DeepEqual(sorted, []string{"Admin", "steve", "Alex"}) {
+		t.Errorf("expected names by priority without hidden names, got %v", sorted)
+	}
+
+	manager.Show("Vanished")
+	manager.RemovePriority("steve")
+	if sorted := manager.Sort(names); !reflect.DeepEqual(sorted, []string{"Admin", "Alex", "steve", "Vanished"}) {
+		t.Errorf("expected the default order after resetting, got %v", sorted)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var manager = NewManager()
+	if removed, added, listed := manager.Update("Alex", []string{"steve", "Alex"}); listed || len(removed) != 0 || !reflect.DeepEqual(added, []string{"Alex", "steve"}) {
+		t.Errorf("expected all names to be added to a new viewer, got %v, %v, %v", removed, added, listed)
+	}
+	if removed, added, _ := manager.Update("Alex", []string{"steve", "Alex", "Zed"}); len(removed) != 0 || !reflect.DeepEqual(added, []string{"Zed"}) {
+		t.Errorf("expected only the name listed last to be added, got %v, %v", removed, added)
+	}
+	if removed, added, _ := manager.Update("Alex", []string{"steve", "Alex", "Zed", "Bob"}); !reflect.DeepEqual(removed, []string{"steve", "Zed"}) || !reflect.DeepEqual(added, []string{"Bob", "steve", "Zed"}) {
+		t.Errorf("expected the names after the new name to be listed again, got %v, %v", removed, added)
+	}
+
+	manager.Remove("Bob")
+	if removed, added, listed := manager.Update("Alex", []string{"steve", "Alex", "Zed"}); !listed || len(removed) != 0 || len(added) != 0 {
+		t.Errorf("expected no changes after removing a name, got %v, %v, %v", removed, added, listed)
+	}
+	manager.Forget("Alex")
+	if _, _, listed := manager.Update("Alex", []string{"Alex"}); listed {
+		t.Error("expected nothing to be listed to a forgotten viewer")
+	}
+	manager.Reset()
+	if _, _, listed := manager.Update("Alex", []string{"Alex"}); listed {
+		t.Error("expected nothing to be listed after resetting")
+	}
+}
@@ -0,0 +1,130 @@
+package players
+
+// Flags of the adventure settings packet.
+const (
+	FlagWorldImmutable = 0x01
+	FlagNoPvP          = 0x02
+	FlagAutoJump       = 0x20
+	FlagAllowFlight    = 0x40
+	FlagNoClip         = 0x80
+	FlagWorldBuilder   = 0x100
+	FlagFlying         = 0x200
+	FlagMuted          = 0x400
+)
+
+// Action permissions of the adventure settings packet.
+const (
+	ActionBuildAndMine      = 0x01
+	ActionDoorsAndSwitches  = 0x02
+	ActionOpenContainers    = 0x04
+	ActionAttackPlayers     = 0x08
+	ActionAttackMobs        = 0x10
+	ActionOperator          = 0x20
+	ActionTeleport          = 0x80
+	defaultActionPermission = ActionBuildAndMine | ActionDoorsAndSwitches | ActionOpenContainers | ActionAttackPlayers | ActionAttackMobs
+)
+
+// Permission levels of players, which decide the badge shown next to their name in the player list.
+const (
+	PermissionVisitor  = 0
+	PermissionMember   = 1
+	PermissionOperator = 2
+)
+
+// Abilities are the abilities of a player, which are sent to the client with the adventure settings packet.
+type Abilities struct {
+	// AllowFlight allows the player to fly. Flying players stop flying if it gets disabled.
+	AllowFlight bool
+	// Flying is true while the player flies.
+	Flying bool
+	// NoClip lets the player move through blocks, like spectators.
+	NoClip bool
+	// Muted hides the chat input of the client. Messages of muted players are not sent either.
+	Muted bool
+	// WorldBuilder lets the player build in immutable worlds.
+	WorldBuilder bool
+	// WorldImmutable prevents the player from changing blocks.
+	WorldImmutable bool
+	// Operator shows the operator badge next to the name of the player in the player list.
+	Operator bool
+}
+
+// GetFlags returns the flags of the adventure settings packet for the abilities.
+func (abilities Abilities) GetFlags() uint32 {
+	var flags uint32 = FlagAutoJump
+	var values = map[uint32]bool{
+		FlagWorldImmutable: abilities.WorldImmutable,
+		FlagAllowFlight:    abilities.AllowFlight,
+		FlagNoClip:         abilities.NoClip,
+		FlagWorldBuilder:   abilities.WorldBuilder,
+		FlagFlying:         abilities.Flying && abilities.AllowFlight,
+		FlagMuted:          abilities.Muted,
+	}
+	for flag, value := range values {
+		if value {
+			flags |= flag
+		}
+	}
+	return flags
+}
+
+// GetActionPermissions returns the action permissions of the adventure settings packet for the abilities.
+func (abilities Abilities) GetActionPermissions() uint32 {
+	if abilities.Operator {
+		return defaultActionPermission | ActionOperator | ActionTeleport
+	}
+	return defaultActionPermission
+}
+
+// GetCommandPermissionLevel returns the command permission level of the player with the abilities,
+// which is 1 for operators, allowing them to use commands like /tp from the client.
+func (abilities Abilities) GetCommandPermissionLevel() uint32 {
+	if abilities.Operator {
+		return 1
+	}
+	return 0
+}
+
+// GetPermissionLevel returns the permission level of the player with the abilities.
+func (abilities Abilities) GetPermissionLevel() uint32 {
+	if abilities.Operator {
+		return PermissionOperator
+	}
+	return PermissionMember
+}
+
+// GetAbilities returns the abilities of the player.
+func (player *Player) GetAbilities() Abilities {
+	return player.abilities
+}
+
+// SetAbilities sets the abilities of the player.
+// The abilities still have to be sent to the client for them to take effect.
+func (player *Player) SetAbilities(abilities Abilities) {
+	if !abilities.AllowFlight {
+		abilities.Flying = false
+	}
+	player.abilities = abilities
+}
+
+// SetAllowFlight sets if the player is allowed to fly. Flying players stop flying if flight gets disallowed.
+func (player *Player) SetAllowFlight(allow bool) {
+	var abilities = player.abilities
+	abilities.AllowFlight = allow
+	player.SetAbilities(abilities)
+}
+
+// CanFly checks if the player is allowed to fly.
+func (player *Player) CanFly() bool {
+	return player.abilities.AllowFlight
+}
+
+// IsFlying checks if the player is flying.
+func (player *Player) IsFlying() bool {
+	return player.abilities.Flying
+}
+
+// SetFlying sets if the player is flying. Players that are not allowed to fly can not fly.
+func (player *Player) SetFlying(flying bool) {
+	player.abilities.Flying = flying && player.abilities.AllowFlight
+}
@@ -0,0 +1,55 @@
+package players
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Data is the persisted data of a player.
+// Data is stored in a YAML file per player,
+// and gets loaded when the player joins.
+// GameMode is nil if the player gets the game mode of the world it is in.
+type Data struct {
+	Name       string            `yaml:"Name,omitempty"`
+	XUID       string            `yaml:"XUID,omitempty"`
+	LastPlayed int64             `yaml:"Last Played,omitempty"`
+	Nickname   string            `yaml:"Nickname"`
+	GameMode   *int32            `yaml:"Gamemode,omitempty"`
+	Metadata   map[string]string `yaml:"Metadata,omitempty"`
+}
+
+// NewData returns new empty player data.
+func NewData() *Data {
+	return &Data{}
+}
+
+// LoadData loads player data from the file at the given path.
+// Empty player data is returned if the file does not exist.
+func LoadData(path string) (*Data, error) {
+	var data = NewData()
+	var file, err = ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return data, nil
+		}
+		return data, err
+	}
+	err = yaml.Unmarshal(file, data)
+	return data, err
+}
+
+// Save writes the player data to the file at the given path.
+// The directory of the file gets created if it does not yet exist.
+func (data *Data) Save(path string) error {
+	var encoded, err = yaml.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, encoded, 0644)
+}
@@ -0,0 +1,96 @@
+package players
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// NameIndex maps the names of players to their XUIDs,
+// so data of players stored by XUID can be found by name.
+// Every XUID is mapped to by at most one name, which is the last name the player used.
+type NameIndex struct {
+	mutex     sync.RWMutex
+	saveMutex sync.Mutex
+	path      string
+	names     map[string]string
+	changed   bool
+}
+
+// LoadNameIndex loads the name index from the file at the given path.
+// An empty index is returned if the file does not exist.
+func LoadNameIndex(path string) (*NameIndex, error) {
+	var index = &NameIndex{path: path, names: make(map[string]string)}
+	var file, err = ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return index, nil
+		}
+		return index, err
+	}
+	err = yaml.Unmarshal(file, &index.names)
+	if index.names == nil {
+		index.names = make(map[string]string)
+	}
+	return index, err
+}
+
+// GetXUID returns the XUID of the player with the given name, ignoring case.
+// A bool is returned indicating if the name is in the index.
+func (index *NameIndex) GetXUID(name string) (string, bool) {
+	index.mutex.RLock()
+	var xuid, ok = index.names[strings.ToLower(name)]
+	index.mutex.RUnlock()
+	return xuid, ok
+}
+
+// Set maps the name to the XUID, removing any other name mapped to the XUID.
+// The index is only marked as changed if the name was not yet mapped to the XUID.
+func (index *NameIndex) Set(name string, xuid string) {
+	var key = strings.ToLower(name)
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+	if index.names[key] == xuid {
+		return
+	}
+	for other, otherXUID := range index.names {
+		if otherXUID == xuid {
+			delete(index.names, other)
+		}
+	}
+	index.names[key] = xuid
+	index.changed = true
+}
+
+// Save writes the name index to its file, if it changed since it was loaded or last saved.
+// The directory of the file gets created if it does not yet exist.
+// Saves are done one at a time, so that the file always holds the index of the last save.
+func (index *NameIndex) Save() error {
+	index.saveMutex.Lock()
+	defer index.saveMutex.Unlock()
+
+	index.mutex.Lock()
+	if !index.changed {
+		index.mutex.Unlock()
+		return nil
+	}
+	var encoded, err = yaml.Marshal(index.names)
+	index.changed = err != nil
+	index.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(index.path), 0700); err == nil {
+		err = ioutil.WriteFile(index.path, encoded, 0644)
+	}
+	if err != nil {
+		index.mutex.Lock()
+		index.changed = true
+		index.mutex.Unlock()
+	}
+	return err
+}
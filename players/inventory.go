@@ -0,0 +1,139 @@
+package players
+
+import (
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/items"
+)
+
+// InventorySize is the amount of slots of a player inventory, including the hotbar.
+const InventorySize = 36
+
+// ArmorSize is the amount of slots of the armor inventory of a player:
+// the helmet, chestplate, leggings and boots.
+const ArmorSize = 4
+
+// OffhandSize is the amount of slots of the offhand inventory of a player.
+const OffhandSize = 1
+
+// CursorSize is the amount of slots of the cursor of a player, which holds the item picked up in an opened window.
+const CursorSize = 1
+
+// HotbarSize is the amount of slots of the hotbar, which are the first slots of the inventory.
+const HotbarSize = 9
+
+// Inventory is the inventory of a player.
+// Empty slots are represented by nil item stacks.
+type Inventory struct {
+	mutex sync.RWMutex
+	slots []*items.Stack
+}
+
+// NewInventory returns a new empty player inventory.
+func NewInventory() *Inventory {
+	return &Inventory{slots: make([]*items.Stack, InventorySize)}
+}
+
+// NewArmorInventory returns a new empty armor inventory.
+func NewArmorInventory() *Inventory {
+	return &Inventory{slots: make([]*items.Stack, ArmorSize)}
+}
+
+// NewOffhandInventory returns a new empty offhand inventory.
+func NewOffhandInventory() *Inventory {
+	return &Inventory{slots: make([]*items.Stack, OffhandSize)}
+}
+
+// NewCursorInventory returns a new empty cursor inventory.
+func NewCursorInventory() *Inventory {
+	return &Inventory{slots: make([]*items.Stack, CursorSize)}
+}
+
+// GetSize returns the amount of slots of the inventory.
+func (inventory *Inventory) GetSize() int {
+	return len(inventory.slots)
+}
+
+// GetItem returns the item stack in the given slot, or nil if the slot is empty or out of range.
+func (inventory *Inventory) GetItem(slot int) *items.Stack {
+	inventory.mutex.RLock()
+	defer inventory.mutex.RUnlock()
+	if slot < 0 || slot >= len(inventory.slots) {
+		return nil
+	}
+	return inventory.slots[slot]
+}
+
+// SetItem sets the item stack in the given slot.
+// Stacks with a count of 0 or less clear the slot.
+func (inventory *Inventory) SetItem(slot int, stack *items.Stack) {
+	inventory.mutex.Lock()
+	defer inventory.mutex.Unlock()
+	if slot < 0 || slot >= len(inventory.slots) {
+		return
+	}
+	if stack != nil && stack.Count <= 0 {
+		stack = nil
+	}
+	inventory.slots[slot] = stack
+}
+
+// GetContents returns the item stacks in all slots of the inventory.
+func (inventory *Inventory) GetContents() []*items.Stack {
+	inventory.mutex.RLock()
+	defer inventory.mutex.RUnlock()
+	var contents = make([]*items.Stack, len(inventory.slots))
+	copy(contents, inventory.slots)
+	return contents
+}
+
+// AddItem adds as many items of the stack to the inventory as possible,
+// stacking them on existing stacks first and filling empty slots after.
+// The count of the stack is decreased by the amount of items added,
+// and the amount of items added is returned.
+func (inventory *Inventory) AddItem(stack *items.Stack) int {
+	inventory.mutex.Lock()
+	defer inventory.mutex.Unlock()
+	var added = 0
+	for _, existing := range inventory.slots {
+		if stack.Count <= 0 {
+			return added
+		}
+		if existing == nil {
+			continue
+		}
+		if _, _, count := stack.StackOn(existing); count > 0 {
+			added += count
+		}
+	}
+	for slot, existing := range inventory.slots {
+		if stack.Count <= 0 {
+			return added
+		}
+		if existing != nil {
+			continue
+		}
+		var moved = *stack
+		if moved.Count > stack.GetMaximumStackSize() {
+			moved.Count = stack.GetMaximumStackSize()
+		}
+		stack.Count -= moved.Count
+		added += moved.Count
+		inventory.slots[slot] = &moved
+	}
+	return added
+}
+
+// Clear empties all slots of the inventory, returning the item stacks that were in it.
+func (inventory *Inventory) Clear() []*items.Stack {
+	inventory.mutex.Lock()
+	defer inventory.mutex.Unlock()
+	var cleared []*items.Stack
+	for slot, stack := range inventory.slots {
+		if stack != nil {
+			cleared = append(cleared, stack)
+		}
+		inventory.slots[slot] = nil
+	}
+	return cleared
+}
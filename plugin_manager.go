@@ -18,15 +18,22 @@ const (
 
 	OutdatedPlugin     = "plugin.Open: plugin was built with a different version of package"
 	NoPluginsSupported = "plugin: not implemented"
+	AlreadyLoaded      = "plugin already loaded"
 )
 
+// UnknownPlugin gets returned when unloading or reloading a plugin that is not loaded.
+var UnknownPlugin = errors.New("plugin is not loaded")
+
 type PluginManager struct {
 	server  *Server
 	plugins map[string]IPlugin
+	paths   map[string]string
+	// order holds the names of all loaded plugins in the order they were enabled.
+	order []string
 }
 
 func NewPluginManager(server *Server) *PluginManager {
-	return &PluginManager{server, make(map[string]IPlugin)}
+	return &PluginManager{server, make(map[string]IPlugin), make(map[string]string), nil}
 }
 
 // GetPlugins returns all plugins currently loaded on the server.
@@ -114,7 +121,11 @@ func (manager *PluginManager) RecompilePlugin(filePath string) (*plugin.Plugin,
 // LoadPlugin loads a plugin at the given file path and returns an error if applicable.
 func (manager *PluginManager) LoadPlugin(filePath string) error {
 	var plug, err = plugin.Open(filePath)
+	return manager.enablePlugin(plug, err, filePath)
+}
 
+// enablePlugin validates the opened plugin, creates a new instance of it and enables it.
+func (manager *PluginManager) enablePlugin(plug *plugin.Plugin, err error, filePath string) error {
 	if err != nil {
 		if strings.Contains(err.Error(), OutdatedPlugin) {
 			text.DefaultLogger.Notice("Outdated plugin. Recompiling plugin... This might take a bit.")
@@ -157,11 +168,134 @@ func (manager *PluginManager) LoadPlugin(filePath string) error {
 	finalPlugin.setManifest(manifest)
 
 	manager.plugins[finalPlugin.GetName()] = finalPlugin
+	manager.paths[finalPlugin.GetName()] = filePath
+	manager.order = append(manager.order, finalPlugin.GetName())
 	finalPlugin.OnEnable()
 
 	return nil
 }
 
+// UnloadPlugin disables the plugin with the given name,
+// and deregisters everything the plugin registered through its plugin API.
+// Go can not close shared objects, so the code of the plugin stays in memory.
+func (manager *PluginManager) UnloadPlugin(name string) error {
+	var plug = manager.GetPlugin(name)
+	if plug == nil {
+		return UnknownPlugin
+	}
+	plug.OnDisable()
+	plug.cleanup()
+	for _, session := range manager.server.SessionManager.GetSessions() {
+		session.GetPlayer().GetMetadata().RemoveNamespace(name, false)
+	}
+
+	delete(manager.plugins, name)
+	delete(manager.paths, name)
+	for i, loaded := range manager.order {
+		if loaded == name {
+			manager.order = append(manager.order[:i], manager.order[i+1:]...)
+			break
+		}
+	}
+	text.DefaultLogger.Info("Unloaded plugin", name)
+	return nil
+}
+
+// ReloadPlugin unloads the plugin with the given name and opens its shared object again.
+// Go caches shared objects by path, so the shared object is opened from a uniquely named copy,
+// which picks up a rebuilt plugin. The cached shared object is reused if it did not change.
+func (manager *PluginManager) ReloadPlugin(name string) error {
+	var filePath, ok = manager.paths[name]
+	if !ok {
+		return UnknownPlugin
+	}
+	if err := manager.UnloadPlugin(name); err != nil {
+		return err
+	}
+	if err := manager.reopenPlugin(filePath); err != nil {
+		return err
+	}
+	text.DefaultLogger.Info("Reloaded plugin", name)
+	return nil
+}
+
+// reopenPlugin opens the shared object of an unloaded plugin again from a uniquely named copy, and enables it.
+func (manager *PluginManager) reopenPlugin(filePath string) error {
+	var content, err = ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	var copyPath = filepath.Join(os.TempDir(), "gomine-plugin~"+uuid.Must(uuid.NewRandom()).String()+".so")
+	if err := ioutil.WriteFile(copyPath, content, 0700); err != nil {
+		return err
+	}
+
+	plug, err := plugin.Open(copyPath)
+	os.Remove(copyPath)
+	if err != nil && strings.Contains(err.Error(), AlreadyLoaded) {
+		plug, err = plugin.Open(filePath)
+	}
+	return manager.enablePlugin(plug, err, filePath)
+}
+
+// ReloadPlugins reloads all plugins currently loaded.
+// All plugins are unloaded first, in the order of UnloadPlugins, and then opened again in reverse order,
+// so that plugins are enabled after the plugins they depend on.
+// An error is logged for every plugin that could not be reloaded.
+func (manager *PluginManager) ReloadPlugins() {
+	var unloaded []string
+	var paths = make(map[string]string)
+	for _, name := range manager.getUnloadOrder() {
+		paths[name] = manager.paths[name]
+		if err := manager.UnloadPlugin(name); err != nil {
+			text.DefaultLogger.LogError(err)
+			continue
+		}
+		unloaded = append(unloaded, name)
+	}
+	for i := len(unloaded) - 1; i >= 0; i-- {
+		if err := manager.reopenPlugin(paths[unloaded[i]]); err != nil {
+			text.DefaultLogger.LogError(err)
+			continue
+		}
+		text.DefaultLogger.Info("Reloaded plugin", unloaded[i])
+	}
+}
+
+// UnloadPlugins unloads all plugins, in reverse dependency order:
+// plugins are unloaded before the plugins they depend on, and otherwise in reverse order of loading.
+// An error is logged for every plugin that could not be unloaded.
+func (manager *PluginManager) UnloadPlugins() {
+	for _, name := range manager.getUnloadOrder() {
+		text.DefaultLogger.LogError(manager.UnloadPlugin(name))
+	}
+}
+
+// getUnloadOrder returns the names of all loaded plugins, ordered so that
+// every plugin comes before the plugins it depends on.
+func (manager *PluginManager) getUnloadOrder() []string {
+	var visited = make(map[string]bool)
+	var order []string
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] || !manager.IsPluginLoaded(name) {
+			return
+		}
+		visited[name] = true
+		for _, dependency := range manager.plugins[name].GetDependencies() {
+			visit(dependency)
+		}
+		order = append(order, name)
+	}
+	for _, name := range manager.order {
+		visit(name)
+	}
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
+
 // ValidateManifest validates the plugin manifest and checks for duplicated plugins.
 func (manager *PluginManager) ValidateManifest(manifest IManifest, path string) error {
 	if manifest.GetName() == "" {
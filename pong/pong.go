@@ -0,0 +1,182 @@
+package pong
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Data is the data advertised in the unconnected pong, which clients show in their server list.
+type Data struct {
+	MOTD           string
+	Protocol       int32
+	Version        string
+	OnlinePlayers  int
+	MaximumPlayers int
+	ServerId       int64
+	SubMOTD        string
+	GameMode       string
+	// GameModeId is the ID of the game mode players get when joining, such as levels.Creative.
+	GameModeId int32
+	// Port and PortV6 are the ports the server listens on for IPv4 and IPv6 clients.
+	// Clients use them to connect to servers found on the LAN. A port of 0 means the server does not listen on it.
+	Port   uint16
+	PortV6 uint16
+}
+
+// String returns the data in the format of the unconnected pong.
+// Semicolons separate the fields, so they are removed from the MOTD, sub-MOTD and game mode.
+// The game mode ID and ports are only advertised if at least one of the ports is set.
+func (data Data) String() string {
+	var pong = fmt.Sprint("MCPE;", clean(data.MOTD), ";", data.Protocol, ";", data.Version, ";", data.OnlinePlayers, ";", data.MaximumPlayers, ";", data.ServerId, ";", clean(data.SubMOTD), ";", clean(data.GameMode), ";")
+	if data.Port == 0 && data.PortV6 == 0 {
+		return pong
+	}
+	return fmt.Sprint(pong, data.GameModeId, ";", data.Port, ";", data.PortV6, ";")
+}
+
+// clean removes the semicolons and line breaks from a field of the pong data.
+func clean(field string) string {
+	return strings.NewReplacer(";", "", "\n", " ").Replace(field)
+}
+
+// Builder builds the pong data of the server, letting the MOTD, sub-MOTD, player counts and game mode be changed at runtime.
+// Placeholders in the MOTD and sub-MOTD, such as "{online}", are replaced every time the data is built.
+type Builder struct {
+	mutex          sync.RWMutex
+	motd           string
+	subMotd        string
+	gameMode       string
+	onlinePlayers  int
+	maximumPlayers int
+	placeholders   map[string]func() string
+}
+
+// NewBuilder returns a new builder advertising the MOTD, sub-MOTD and game mode, and the real player counts.
+// The real game mode is advertised if the game mode is empty.
+func NewBuilder(motd string, subMotd string, gameMode string) *Builder {
+	return &Builder{motd: motd, subMotd: subMotd, gameMode: gameMode, onlinePlayers: -1, maximumPlayers: -1, placeholders: make(map[string]func() string)}
+}
+
+// SetMOTD sets the MOTD shown in the server list.
+func (builder *Builder) SetMOTD(motd string) {
+	builder.mutex.Lock()
+	builder.motd = motd
+	builder.mutex.Unlock()
+}
+
+// GetMOTD returns the MOTD shown in the server list, without its placeholders replaced.
+func (builder *Builder) GetMOTD() string {
+	builder.mutex.RLock()
+	defer builder.mutex.RUnlock()
+	return builder.motd
+}
+
+// SetSubMOTD sets the sub-MOTD, which clients show as the world name of LAN games.
+func (builder *Builder) SetSubMOTD(subMotd string) {
+	builder.mutex.Lock()
+	builder.subMotd = subMotd
+	builder.mutex.Unlock()
+}
+
+// GetSubMOTD returns the sub-MOTD, without its placeholders replaced.
+func (builder *Builder) GetSubMOTD() string {
+	builder.mutex.RLock()
+	defer builder.mutex.RUnlock()
+	return builder.subMotd
+}
+
+// SetGameMode sets the name of the game mode shown in the server list, such as "Survival".
+// An empty name shows the real game mode again.
+func (builder *Builder) SetGameMode(gameMode string) {
+	builder.mutex.Lock()
+	builder.gameMode = gameMode
+	builder.mutex.Unlock()
+}
+
+// GetGameMode returns the name of the game mode shown in the server list.
+func (builder *Builder) GetGameMode() string {
+	builder.mutex.RLock()
+	defer builder.mutex.RUnlock()
+	return builder.gameMode
+}
+
+// SetOnlinePlayers sets the amount of online players shown in the server list.
+// A negative count shows the real amount of online players again.
+func (builder *Builder) SetOnlinePlayers(count int) {
+	builder.mutex.Lock()
+	builder.onlinePlayers = count
+	builder.mutex.Unlock()
+}
+
+// SetMaximumPlayers sets the maximum amount of players shown in the server list,
+// which does not change the amount of players that can actually join.
+// A negative count shows the real maximum amount of players again.
+func (builder *Builder) SetMaximumPlayers(count int) {
+	builder.mutex.Lock()
+	builder.maximumPlayers = count
+	builder.mutex.Unlock()
+}
+
+// SetPlaceholder sets the function returning the value of the placeholder with the given name, such as "{online}".
+// The function is called every time the data is built, if the MOTD or sub-MOTD contains the placeholder.
+func (builder *Builder) SetPlaceholder(name string, function func() string) {
+	builder.mutex.Lock()
+	builder.placeholders[name] = function
+	builder.mutex.Unlock()
+}
+
+// RemovePlaceholder removes the placeholder with the given name.
+func (builder *Builder) RemovePlaceholder(name string) {
+	builder.mutex.Lock()
+	delete(builder.placeholders, name)
+	builder.mutex.Unlock()
+}
+
+// Build returns the pong data with the real values of the server in the given data,
+// replaced with the values set in the builder. Placeholders in the MOTD and sub-MOTD get replaced.
+// Placeholder functions are called without holding the lock of the builder, so they may use the builder themselves.
+func (builder *Builder) Build(data Data) Data {
+	builder.mutex.RLock()
+	var motd, subMotd = builder.motd, builder.subMotd
+	if builder.gameMode != "" {
+		data.GameMode = builder.gameMode
+	}
+	if builder.onlinePlayers >= 0 {
+		data.OnlinePlayers = builder.onlinePlayers
+	}
+	if builder.maximumPlayers >= 0 {
+		data.MaximumPlayers = builder.maximumPlayers
+	}
+	builder.mutex.RUnlock()
+
+	var placeholders = builder.getPlaceholders()
+	data.MOTD, data.SubMOTD = replace(motd, placeholders), replace(subMotd, placeholders)
+	return data
+}
+
+// RenderMOTD returns the MOTD shown in the server list, with its placeholders replaced.
+func (builder *Builder) RenderMOTD() string {
+	return replace(builder.GetMOTD(), builder.getPlaceholders())
+}
+
+// getPlaceholders returns a copy of the placeholders of the builder, by name.
+func (builder *Builder) getPlaceholders() map[string]func() string {
+	builder.mutex.RLock()
+	defer builder.mutex.RUnlock()
+	var placeholders = make(map[string]func() string, len(builder.placeholders))
+	for name, function := range builder.placeholders {
+		placeholders[name] = function
+	}
+	return placeholders
+}
+
+// replace replaces the placeholders in the text. Only placeholders found in the text are evaluated.
+func replace(text string, placeholders map[string]func() string) string {
+	for name, function := range placeholders {
+		if strings.Contains(text, name) {
+			text = strings.Replace(text, name, function(), -1)
+		}
+	}
+	return text
+}
@@ -0,0 +1,61 @@
+package pong
+
+import (
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	var builder = NewBuilder("Welcome; {online} online", "GoMine", "Survival")
+	var evaluated = 0
+	builder.SetPlaceholder("{online}", func() string {
+		evaluated++
+		return "3"
+	})
+	builder.SetPlaceholder("{unused}", func() string {
+		t.Error("expected placeholders not in the MOTD not to be evaluated")
+		return ""
+	})
+
+	var data = builder.Build(Data{Protocol: 354, Version: "1.11.0", OnlinePlayers: 3, MaximumPlayers: 20, ServerId: 1})
+	if expected := "MCPE;Welcome 3 online;354;1.11.0;3;20;1;GoMine;Survival;"; data.String() != expected {
+		t.Errorf("expected %v, got %v", expected, data.String())
+	}
+
+	data.Port, data.PortV6, data.GameModeId = 19132, 19132, 1
+	if expected := "MCPE;Welcome 3 online;354;1.11.0;3;20;1;GoMine;Survival;1;19132;19132;"; data.String() != expected {
+		t.Errorf("expected %v, got %v", expected, data.String())
+	}
+
+	builder.SetMaximumPlayers(100)
+	builder.SetOnlinePlayers(99)
+	builder.SetMOTD("Full")
+	data = builder.Build(Data{OnlinePlayers: 3, MaximumPlayers: 20})
+	if data.MOTD != "Full" || data.OnlinePlayers != 99 || data.MaximumPlayers != 100 {
+		t.Errorf("expected the values of the builder, got %+v", data)
+	}
+	builder.SetMaximumPlayers(-1)
+	if data = builder.Build(Data{MaximumPlayers: 20}); data.MaximumPlayers != 20 {
+		t.Errorf("expected the real maximum players after resetting, got %v", data.MaximumPlayers)
+	}
+	builder.SetGameMode("")
+	if data = builder.Build(Data{GameMode: "Creative"}); data.GameMode != "Creative" {
+		t.Errorf("expected the real game mode without a game mode set, got %v", data.GameMode)
+	}
+	if evaluated != 1 {
+		t.Errorf("expected the placeholder to be evaluated once, got %v", evaluated)
+	}
+}
+
+func TestPlaceholderUsesBuilder(t *testing.T) {
+	var builder = NewBuilder("{status}", "", "Survival")
+	builder.SetPlaceholder("{status}", func() string {
+		builder.SetSubMOTD("rendered")
+		return "open"
+	})
+	if data := builder.Build(Data{}); data.MOTD != "open" || builder.GetSubMOTD() != "rendered" {
+		t.Errorf("expected the placeholder to be able to change the builder, got %+v", data)
+	}
+	if motd := builder.RenderMOTD(); motd != "open" {
+		t.Errorf("expected the rendered MOTD, got %v", motd)
+	}
+}
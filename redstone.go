@@ -0,0 +1,46 @@
+package gomine
+
+import (
+	"math"
+
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/pistons"
+	"github.com/BobbyShrd/gominetest/redstone"
+	"github.com/irmine/worlds/blocks"
+)
+
+// playerEyeHeight is the height of the eyes of players above their feet.
+const playerEyeHeight = 1.62
+
+// registerRedstone registers the interactions of levers and buttons,
+// and lets pistons be powered by redstone.
+func (server *Server) registerRedstone() {
+	server.InteractionRegistry.RegisterBlockHandler(func(session *net.MinecraftSession, position blocks.Position, _ string) bool {
+		server.RedstoneEngine.ToggleLever(server.GetDimensionWorld(session.GetPlayer().GetDimension()), position)
+		return true
+	}, redstone.Lever)
+
+	server.InteractionRegistry.RegisterBlockHandler(func(session *net.MinecraftSession, position blocks.Position, _ string) bool {
+		server.RedstoneEngine.PressButton(server.GetDimensionWorld(session.GetPlayer().GetDimension()), position)
+		return true
+	}, redstone.StoneButton, redstone.WoodenButton)
+
+	server.RedstoneEngine.RegisterBehavior(func(world redstone.World, position blocks.Position, powered bool) {
+		if world, ok := world.(pistons.World); ok {
+			server.PistonManager.SetPowered(world, position, powered)
+		}
+	}, pistons.Piston, pistons.StickyPiston)
+}
+
+// stepOnBlocks presses the pressure plate the player of the session is standing in, if any.
+func (server *Server) stepOnBlocks(session *net.MinecraftSession, x, y, z float64, onGround bool) {
+	var feet = math.Floor(y - playerEyeHeight)
+	if !onGround || feet < 0 {
+		return
+	}
+	var position = blocks.NewPosition(int32(math.Floor(x)), uint32(feet), int32(math.Floor(z)))
+	var world = server.GetDimensionWorld(session.GetPlayer().GetDimension())
+	if redstone.IsPressurePlate(world.GetBlockName(position)) {
+		server.RedstoneEngine.StepOn(world, position)
+	}
+}
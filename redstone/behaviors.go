@@ -0,0 +1,82 @@
+package redstone
+
+import (
+	"github.com/irmine/worlds/blocks"
+)
+
+// Behavior is a function reacting to the power of a block changing.
+// Behaviors get called when a redstone update reaches the block,
+// with whether the block is powered or not.
+type Behavior func(world World, position blocks.Position, powered bool)
+
+const (
+	RedstoneLamp    = "redstone_lamp"
+	LitRedstoneLamp = "lit_redstone_lamp"
+)
+
+// doorIds holds the legacy block IDs of all doors opened by redstone.
+var doorIds = map[string]int32{
+	"wooden_door":   64,
+	"iron_door":     71,
+	"spruce_door":   193,
+	"birch_door":    194,
+	"jungle_door":   195,
+	"acacia_door":   196,
+	"dark_oak_door": 197,
+}
+
+// trapdoorIds holds the legacy block IDs of all trapdoors opened by redstone.
+var trapdoorIds = map[string]int32{
+	"trapdoor":      96,
+	"iron_trapdoor": 167,
+}
+
+// registerDefaultBehaviors registers the behaviors of vanilla blocks powered by redstone.
+func (engine *Engine) registerDefaultBehaviors() {
+	for name := range doorIds {
+		engine.RegisterBehavior(DoorBehavior, name)
+	}
+	for name := range trapdoorIds {
+		engine.RegisterBehavior(TrapdoorBehavior, name)
+	}
+	engine.RegisterBehavior(LampBehavior, RedstoneLamp, LitRedstoneLamp)
+}
+
+// DoorBehavior opens doors when powered, and closes them when no longer powered.
+// The open state is stored in the lower half of the door.
+func DoorBehavior(world World, position blocks.Position, powered bool) {
+	if world.GetBlockData(position)&0x08 != 0 {
+		if position.Y == 0 {
+			return
+		}
+		position.Y--
+	}
+	var name = world.GetBlockName(position)
+	var id, ok = doorIds[name]
+	if !ok {
+		return
+	}
+	var data = world.GetBlockData(position)
+	if (data&0x04 != 0) != powered {
+		world.PlaceBlock(position, name, id, data^0x04)
+	}
+}
+
+// TrapdoorBehavior opens trapdoors when powered, and closes them when no longer powered.
+func TrapdoorBehavior(world World, position blocks.Position, powered bool) {
+	var name = world.GetBlockName(position)
+	var data = world.GetBlockData(position)
+	if (data&0x08 != 0) != powered {
+		world.PlaceBlock(position, name, trapdoorIds[name], data^0x08)
+	}
+}
+
+// LampBehavior lights redstone lamps when powered.
+func LampBehavior(world World, position blocks.Position, powered bool) {
+	var lit = world.GetBlockName(position) == LitRedstoneLamp
+	if powered && !lit {
+		world.PlaceBlock(position, LitRedstoneLamp, 124, 0)
+	} else if !powered && lit {
+		world.PlaceBlock(position, RedstoneLamp, 123, 0)
+	}
+}
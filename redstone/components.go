@@ -0,0 +1,205 @@
+package redstone
+
+import (
+	"github.com/BobbyShrd/gominetest/pistons"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	Wire                = "redstone_wire"
+	Torch               = "redstone_torch"
+	UnlitTorch          = "unlit_redstone_torch"
+	Lever               = "lever"
+	StoneButton         = "stone_button"
+	WoodenButton        = "wooden_button"
+	StonePressurePlate  = "stone_pressure_plate"
+	WoodenPressurePlate = "wooden_pressure_plate"
+	Repeater            = "unpowered_repeater"
+	PoweredRepeater     = "powered_repeater"
+	Block               = "redstone_block"
+	Air                 = "air"
+
+	// MaxPower is the power level of redstone sources.
+	MaxPower = 15
+	// PoweredBit is the data bit set for powered levers and pressed buttons.
+	PoweredBit byte = 0x08
+)
+
+// componentIds holds the legacy block IDs of all redstone components.
+var componentIds = map[string]int32{
+	Wire:                55,
+	Torch:               76,
+	UnlitTorch:          75,
+	Lever:               69,
+	StoneButton:         77,
+	WoodenButton:        143,
+	StonePressurePlate:  70,
+	WoodenPressurePlate: 72,
+	Repeater:            93,
+	PoweredRepeater:     94,
+	Block:               152,
+}
+
+// toggledComponents maps components that switch blocks when turned on or off.
+var toggledComponents = map[string]string{
+	Torch:           UnlitTorch,
+	UnlitTorch:      Torch,
+	Repeater:        PoweredRepeater,
+	PoweredRepeater: Repeater,
+}
+
+// nonConductors are blocks that are not redstone components but can not conduct power either.
+var nonConductors = map[string]bool{
+	Air:                      true,
+	"glass":                  true,
+	"glass_pane":             true,
+	"stained_glass":          true,
+	"leaves":                 true,
+	"leaves2":                true,
+	"glowstone":              true,
+	"ice":                    true,
+	"water":                  true,
+	"flowing_water":          true,
+	"lava":                   true,
+	"flowing_lava":           true,
+	"tallgrass":              true,
+	"yellow_flower":          true,
+	"red_flower":             true,
+	"torch":                  true,
+	"ladder":                 true,
+	"snow_layer":             true,
+	"standing_sign":          true,
+	"wall_sign":              true,
+	"stone_slab":             true,
+	"wooden_slab":            true,
+	"fence":                  true,
+	"hopper":                 true,
+	"tnt":                    true,
+	pistons.Piston:           true,
+	pistons.StickyPiston:     true,
+	pistons.PistonHead:       true,
+	pistons.StickyPistonHead: true,
+}
+
+// IsComponent checks if the block with the given name is a redstone component.
+func IsComponent(name string) bool {
+	var _, ok = componentIds[name]
+	return ok
+}
+
+// SetConductor sets whether the block with the given name conducts redstone power.
+func SetConductor(name string, conductor bool) {
+	if conductor {
+		delete(nonConductors, name)
+	} else {
+		nonConductors[name] = true
+	}
+}
+
+// IsButton checks if the block with the given name is a button.
+func IsButton(name string) bool {
+	return name == StoneButton || name == WoodenButton
+}
+
+// IsPressurePlate checks if the block with the given name is a pressure plate.
+func IsPressurePlate(name string) bool {
+	return name == StonePressurePlate || name == WoodenPressurePlate
+}
+
+// opposite returns the face opposite to the given face.
+func opposite(face byte) byte {
+	return face ^ 1
+}
+
+// attachment returns the face of a torch or lever pointing to the block it is attached to.
+func attachment(data byte) byte {
+	switch data & 7 {
+	case 1:
+		return pistons.FaceWest
+	case 2:
+		return pistons.FaceEast
+	case 3:
+		return pistons.FaceNorth
+	case 4:
+		return pistons.FaceSouth
+	case 0, 7:
+		return pistons.FaceUp
+	}
+	return pistons.FaceDown
+}
+
+// buttonAttachment returns the face of a button pointing to the block it is attached to.
+func buttonAttachment(data byte) byte {
+	return opposite(data & 7)
+}
+
+// repeaterOutput returns the face a repeater with the given data value outputs power to.
+func repeaterOutput(data byte) byte {
+	return [4]byte{pistons.FaceNorth, pistons.FaceEast, pistons.FaceSouth, pistons.FaceWest}[data&3]
+}
+
+// repeaterDelay returns the delay in ticks of a repeater with the given data value.
+func repeaterDelay(data byte) int64 {
+	return (int64(data>>2&3) + 1) * TickDelay
+}
+
+// emittedPower returns the power the source component at the given position
+// gives to a component or wire directly next to it, in the direction of the face.
+func emittedPower(world World, source blocks.Position, name string, face byte) int {
+	var data = world.GetBlockData(source)
+	switch name {
+	case Block:
+		return MaxPower
+	case Torch:
+		if face != attachment(data) {
+			return MaxPower
+		}
+	case Lever, StoneButton, WoodenButton:
+		if data&PoweredBit != 0 {
+			return MaxPower
+		}
+	case StonePressurePlate, WoodenPressurePlate:
+		if data != 0 {
+			return MaxPower
+		}
+	case PoweredRepeater:
+		if face == repeaterOutput(data) {
+			return MaxPower
+		}
+	case Wire:
+		if face != pistons.FaceUp {
+			return int(data & 15)
+		}
+	}
+	return 0
+}
+
+// strongPower returns the power the source component at the given position
+// gives to a conducting block next to it, in the direction of the face.
+// Strongly powered blocks power wires next to them.
+func strongPower(world World, source blocks.Position, name string, face byte) int {
+	var data = world.GetBlockData(source)
+	switch name {
+	case Torch:
+		if face == pistons.FaceUp {
+			return MaxPower
+		}
+	case Lever:
+		if data&PoweredBit != 0 && face == attachment(data) {
+			return MaxPower
+		}
+	case StoneButton, WoodenButton:
+		if data&PoweredBit != 0 && face == buttonAttachment(data) {
+			return MaxPower
+		}
+	case StonePressurePlate, WoodenPressurePlate:
+		if data != 0 && face == pistons.FaceDown {
+			return MaxPower
+		}
+	case PoweredRepeater:
+		if face == repeaterOutput(data) {
+			return MaxPower
+		}
+	}
+	return 0
+}
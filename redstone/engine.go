@@ -0,0 +1,435 @@
+package redstone
+
+import (
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/pistons"
+	"github.com/BobbyShrd/gominetest/tasks"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	// TickDelay is the amount of ticks in one redstone tick.
+	TickDelay = 2
+	// StoneButtonDelay is the amount of ticks a stone button stays pressed.
+	StoneButtonDelay = 20
+	// WoodenButtonDelay is the amount of ticks a wooden button stays pressed.
+	WoodenButtonDelay = 30
+	// PressurePlateDelay is the amount of ticks a pressure plate stays pressed after being stepped on.
+	PressurePlateDelay = 20
+	// MaxWireNetwork is the maximum amount of connected wires updated at once.
+	MaxWireNetwork = 4096
+)
+
+// horizontalFaces are the faces wires connect to other wires on.
+var horizontalFaces = []byte{pistons.FaceNorth, pistons.FaceSouth, pistons.FaceWest, pistons.FaceEast}
+
+// key identifies a position in a world.
+type key struct {
+	world    World
+	position blocks.Position
+}
+
+// Engine propagates redstone power through worlds.
+// Every change is made in a task of the scheduler,
+// so that all redstone updates run on the server tick.
+type Engine struct {
+	scheduler *tasks.Scheduler
+
+	mutex     sync.RWMutex
+	behaviors map[string][]Behavior
+
+	pending   map[key]bool
+	steppedOn map[key]int64
+}
+
+// NewEngine returns a new redstone engine scheduling updates with the given scheduler.
+// The behaviors of doors, trapdoors and redstone lamps are registered by default.
+func NewEngine(scheduler *tasks.Scheduler) *Engine {
+	var engine = &Engine{
+		scheduler: scheduler,
+		behaviors: make(map[string][]Behavior),
+		pending:   make(map[key]bool),
+		steppedOn: make(map[key]int64),
+	}
+	engine.registerDefaultBehaviors()
+	return engine
+}
+
+// RegisterBehavior registers a behavior for blocks with the given names.
+// Blocks with behaviors no longer conduct redstone power.
+func (engine *Engine) RegisterBehavior(behavior Behavior, blockNames ...string) {
+	engine.mutex.Lock()
+	for _, name := range blockNames {
+		engine.behaviors[name] = append(engine.behaviors[name], behavior)
+	}
+	engine.mutex.Unlock()
+}
+
+// HasBehaviors checks if any behaviors are registered for blocks with the given name.
+func (engine *Engine) HasBehaviors(blockName string) bool {
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+	return len(engine.behaviors[blockName]) != 0
+}
+
+// IsConductor checks if the block with the given name conducts redstone power.
+func (engine *Engine) IsConductor(name string) bool {
+	return !nonConductors[name] && !IsComponent(name) && !engine.HasBehaviors(name)
+}
+
+// GetPower returns the power level a block at the given position receives,
+// from the components and conducting blocks next to it.
+func (engine *Engine) GetPower(world World, position blocks.Position) int {
+	var power = 0
+	for face := pistons.FaceDown; face <= pistons.FaceEast; face++ {
+		var neighbour, ok = pistons.Offset(position, face)
+		if !ok {
+			continue
+		}
+		var name = world.GetBlockName(neighbour)
+		if IsComponent(name) {
+			power = maxPower(power, emittedPower(world, neighbour, name, opposite(face)))
+		} else if engine.IsConductor(name) {
+			power = maxPower(power, engine.getConductorPower(world, neighbour, true))
+		}
+	}
+	return power
+}
+
+// IsPowered checks if a block at the given position receives any power.
+func (engine *Engine) IsPowered(world World, position blocks.Position) bool {
+	return engine.GetPower(world, position) > 0
+}
+
+// Update schedules a redstone update around the given position.
+// Update should be called every time a block gets placed or broken.
+func (engine *Engine) Update(world World, position blocks.Position) {
+	engine.scheduler.Schedule(func() {
+		engine.update(world, position)
+	})
+}
+
+// ToggleLever schedules switching the lever at the given position on or off.
+func (engine *Engine) ToggleLever(world World, position blocks.Position) {
+	engine.scheduler.Schedule(func() {
+		if world.GetBlockName(position) != Lever {
+			return
+		}
+		world.PlaceBlock(position, Lever, componentIds[Lever], world.GetBlockData(position)^PoweredBit)
+		engine.update(world, position)
+	})
+}
+
+// PressButton schedules pressing the button at the given position.
+// The button gets released again after the delay of its type has passed.
+func (engine *Engine) PressButton(world World, position blocks.Position) {
+	engine.scheduler.Schedule(func() {
+		var name = world.GetBlockName(position)
+		var data = world.GetBlockData(position)
+		if !IsButton(name) || data&PoweredBit != 0 {
+			return
+		}
+		world.PlaceBlock(position, name, componentIds[name], data|PoweredBit)
+		engine.update(world, position)
+
+		var delay int64 = StoneButtonDelay
+		if name == WoodenButton {
+			delay = WoodenButtonDelay
+		}
+		engine.scheduler.ScheduleDelayed(func() {
+			var data = world.GetBlockData(position)
+			if world.GetBlockName(position) != name || data&PoweredBit == 0 {
+				return
+			}
+			world.PlaceBlock(position, name, componentIds[name], data&^PoweredBit)
+			engine.update(world, position)
+		}, delay)
+	})
+}
+
+// StepOn schedules pressing the pressure plate at the given position.
+// The pressure plate gets released once it has not been stepped on for the pressure plate delay.
+func (engine *Engine) StepOn(world World, position blocks.Position) {
+	engine.scheduler.Schedule(func() {
+		var name = world.GetBlockName(position)
+		if !IsPressurePlate(name) {
+			return
+		}
+		var k = key{world, position}
+		var _, pressed = engine.steppedOn[k]
+		engine.steppedOn[k] = engine.scheduler.GetCurrentTick()
+		if pressed {
+			return
+		}
+		world.PlaceBlock(position, name, componentIds[name], 1)
+		engine.update(world, position)
+		engine.scheduleRelease(world, position, PressurePlateDelay)
+	})
+}
+
+// scheduleRelease schedules releasing the pressure plate at the given position,
+// unless it got stepped on again in the meantime.
+func (engine *Engine) scheduleRelease(world World, position blocks.Position, delay int64) {
+	engine.scheduler.ScheduleDelayed(func() {
+		var k = key{world, position}
+		var elapsed = engine.scheduler.GetCurrentTick() - engine.steppedOn[k]
+		if elapsed < PressurePlateDelay {
+			engine.scheduleRelease(world, position, PressurePlateDelay-elapsed)
+			return
+		}
+		delete(engine.steppedOn, k)
+
+		var name = world.GetBlockName(position)
+		if IsPressurePlate(name) && world.GetBlockData(position) != 0 {
+			world.PlaceBlock(position, name, componentIds[name], 0)
+			engine.update(world, position)
+		}
+	}, delay)
+}
+
+// update updates all wires and components that may have been affected by a change at the given position.
+func (engine *Engine) update(world World, position blocks.Position) {
+	var handledWires = make(map[blocks.Position]bool)
+	var changedWires []blocks.Position
+	for _, affected := range engine.getAffectedPositions(world, position) {
+		if world.GetBlockName(affected) == Wire {
+			if !handledWires[affected] {
+				changedWires = append(changedWires, engine.updateWires(world, affected, handledWires)...)
+			}
+			continue
+		}
+		engine.updateComponent(world, affected)
+	}
+
+	var updated = make(map[blocks.Position]bool)
+	for _, wire := range changedWires {
+		for _, affected := range engine.getAffectedPositions(world, wire) {
+			if !updated[affected] && world.GetBlockName(affected) != Wire {
+				updated[affected] = true
+				engine.updateComponent(world, affected)
+			}
+		}
+	}
+}
+
+// getAffectedPositions returns the given position, the positions next to it,
+// and the positions next to conducting blocks next to it.
+func (engine *Engine) getAffectedPositions(world World, position blocks.Position) []blocks.Position {
+	var positions = []blocks.Position{position}
+	var found = map[blocks.Position]bool{position: true}
+	for face := pistons.FaceDown; face <= pistons.FaceEast; face++ {
+		var neighbour, ok = pistons.Offset(position, face)
+		if !ok || found[neighbour] {
+			continue
+		}
+		found[neighbour] = true
+		positions = append(positions, neighbour)
+
+		if !engine.IsConductor(world.GetBlockName(neighbour)) {
+			continue
+		}
+		for face := pistons.FaceDown; face <= pistons.FaceEast; face++ {
+			var next, ok = pistons.Offset(neighbour, face)
+			if ok && !found[next] {
+				found[next] = true
+				positions = append(positions, next)
+			}
+		}
+	}
+	return positions
+}
+
+// updateComponent schedules torches and repeaters to switch if their input changed,
+// and calls the behaviors of the block at the given position.
+func (engine *Engine) updateComponent(world World, position blocks.Position) {
+	var name = world.GetBlockName(position)
+	switch name {
+	case Torch, UnlitTorch:
+		if engine.shouldBeOn(world, position, name) != (name == Torch) {
+			engine.scheduleToggle(world, position, TickDelay)
+		}
+	case Repeater, PoweredRepeater:
+		if engine.shouldBeOn(world, position, name) != (name == PoweredRepeater) {
+			engine.scheduleToggle(world, position, repeaterDelay(world.GetBlockData(position)))
+		}
+	default:
+		engine.mutex.RLock()
+		var behaviors = engine.behaviors[name]
+		engine.mutex.RUnlock()
+		if len(behaviors) == 0 {
+			return
+		}
+		var powered = engine.IsPowered(world, position)
+		for _, behavior := range behaviors {
+			behavior(world, position, powered)
+		}
+	}
+}
+
+// shouldBeOn checks if the torch or repeater at the given position should be turned on.
+// Torches turn off when the block they are attached to is powered,
+// and repeaters turn on when the block behind them is powered.
+func (engine *Engine) shouldBeOn(world World, position blocks.Position, name string) bool {
+	var data = world.GetBlockData(position)
+	if name == Torch || name == UnlitTorch {
+		var attached, ok = pistons.Offset(position, attachment(data))
+		return !ok || engine.getConductorPower(world, attached, true) == 0
+	}
+	var output = repeaterOutput(data)
+	var input, ok = pistons.Offset(position, opposite(output))
+	if !ok {
+		return false
+	}
+	var inputName = world.GetBlockName(input)
+	if IsComponent(inputName) {
+		return emittedPower(world, input, inputName, output) > 0
+	}
+	return engine.IsConductor(inputName) && engine.getConductorPower(world, input, true) > 0
+}
+
+// scheduleToggle schedules the torch or repeater at the given position to switch after the delay,
+// if it should still switch by then.
+func (engine *Engine) scheduleToggle(world World, position blocks.Position, delay int64) {
+	var k = key{world, position}
+	if engine.pending[k] {
+		return
+	}
+	engine.pending[k] = true
+	engine.scheduler.ScheduleDelayed(func() {
+		delete(engine.pending, k)
+		var name = world.GetBlockName(position)
+		var toggled, ok = toggledComponents[name]
+		if !ok {
+			return
+		}
+		var on = name == Torch || name == PoweredRepeater
+		if engine.shouldBeOn(world, position, name) == on {
+			return
+		}
+		world.PlaceBlock(position, toggled, componentIds[toggled], world.GetBlockData(position))
+		engine.update(world, position)
+	}, delay)
+}
+
+// getConductorPower returns the power of the conducting block at the given position.
+// Weak power from wires on top of the block is only included if weak is true,
+// as weakly powered blocks do not power wires next to them.
+func (engine *Engine) getConductorPower(world World, position blocks.Position, weak bool) int {
+	var power = 0
+	for face := pistons.FaceDown; face <= pistons.FaceEast; face++ {
+		var neighbour, ok = pistons.Offset(position, face)
+		if !ok {
+			continue
+		}
+		var name = world.GetBlockName(neighbour)
+		if name == Wire {
+			if weak && face == pistons.FaceUp {
+				power = maxPower(power, int(world.GetBlockData(neighbour)&15))
+			}
+		} else if IsComponent(name) {
+			power = maxPower(power, strongPower(world, neighbour, name, opposite(face)))
+		}
+	}
+	return power
+}
+
+// updateWires recalculates the power of all wires connected to the wire at the given position.
+// The wires handled are added to the handled map, and the wires that changed power are returned.
+func (engine *Engine) updateWires(world World, start blocks.Position, handled map[blocks.Position]bool) []blocks.Position {
+	var network = []blocks.Position{start}
+	var connections = make(map[blocks.Position][]blocks.Position)
+	handled[start] = true
+	for i := 0; i < len(network); i++ {
+		var connected = engine.getWireConnections(world, network[i])
+		connections[network[i]] = connected
+		for _, wire := range connected {
+			if !handled[wire] && len(network) < MaxWireNetwork {
+				handled[wire] = true
+				network = append(network, wire)
+			}
+		}
+	}
+
+	var power = make(map[blocks.Position]int, len(network))
+	var queue []blocks.Position
+	for _, wire := range network {
+		power[wire] = engine.getWireSourcePower(world, wire)
+		if power[wire] > 1 {
+			queue = append(queue, wire)
+		}
+	}
+	for len(queue) > 0 {
+		var wire = queue[0]
+		queue = queue[1:]
+		for _, connected := range connections[wire] {
+			if current, ok := power[connected]; ok && power[wire]-1 > current {
+				power[connected] = power[wire] - 1
+				queue = append(queue, connected)
+			}
+		}
+	}
+
+	var changed []blocks.Position
+	for _, wire := range network {
+		if int(world.GetBlockData(wire)&15) != power[wire] {
+			world.PlaceBlock(wire, Wire, componentIds[Wire], byte(power[wire]))
+			changed = append(changed, wire)
+		}
+	}
+	return changed
+}
+
+// getWireConnections returns the positions of the wires connected to the wire at the given position.
+// Wires connect horizontally, and step up or down conducting blocks.
+func (engine *Engine) getWireConnections(world World, position blocks.Position) []blocks.Position {
+	var connections []blocks.Position
+	var above, canStepUp = pistons.Offset(position, pistons.FaceUp)
+	canStepUp = canStepUp && !engine.IsConductor(world.GetBlockName(above))
+
+	for _, face := range horizontalFaces {
+		var neighbour, _ = pistons.Offset(position, face)
+		var name = world.GetBlockName(neighbour)
+		if name == Wire {
+			connections = append(connections, neighbour)
+			continue
+		}
+		if engine.IsConductor(name) {
+			if up, ok := pistons.Offset(neighbour, pistons.FaceUp); ok && canStepUp && world.GetBlockName(up) == Wire {
+				connections = append(connections, up)
+			}
+		} else if down, ok := pistons.Offset(neighbour, pistons.FaceDown); ok && world.GetBlockName(down) == Wire {
+			connections = append(connections, down)
+		}
+	}
+	return connections
+}
+
+// getWireSourcePower returns the power the wire at the given position receives from anything but other wires.
+func (engine *Engine) getWireSourcePower(world World, position blocks.Position) int {
+	var power = 0
+	for face := pistons.FaceDown; face <= pistons.FaceEast; face++ {
+		var neighbour, ok = pistons.Offset(position, face)
+		if !ok {
+			continue
+		}
+		var name = world.GetBlockName(neighbour)
+		if name == Wire {
+			continue
+		}
+		if IsComponent(name) {
+			power = maxPower(power, emittedPower(world, neighbour, name, opposite(face)))
+		} else if engine.IsConductor(name) {
+			power = maxPower(power, engine.getConductorPower(world, neighbour, false))
+		}
+	}
+	return power
+}
+
+// maxPower returns the highest of the two power levels.
+func maxPower(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,131 @@
+package redstone
+
+import (
+	"testing"
+
+	"github.com/BobbyShrd/gominetest/tasks"
+	"github.com/irmine/worlds/blocks"
+)
+
+type testBlock struct {
+	name string
+	data byte
+}
+
+type testWorld struct {
+	blocks map[blocks.Position]testBlock
+}
+
+func newTestWorld() *testWorld {
+	return &testWorld{make(map[blocks.Position]testBlock)}
+}
+
+func (world *testWorld) GetBlockName(position blocks.Position) string {
+	if block, ok := world.blocks[position]; ok {
+		return block.name
+	}
+	return Air
+}
+
+func (world *testWorld) GetBlockData(position blocks.Position) byte {
+	return world.blocks[position].data
+}
+
+func (world *testWorld) PlaceBlock(position blocks.Position, name string, _ int32, data byte) {
+	world.blocks[position] = testBlock{name, data}
+}
+
+func tick(scheduler *tasks.Scheduler, ticks int) {
+	for i := 0; i < ticks; i++ {
+		scheduler.Tick()
+	}
+}
+
+func TestWire(t *testing.T) {
+	var scheduler = tasks.NewScheduler(1)
+	defer scheduler.Close()
+	var engine = NewEngine(scheduler)
+	var world = newTestWorld()
+
+	var lever = blocks.NewPosition(0, 10, 0)
+	world.blocks[blocks.NewPosition(0, 9, 0)] = testBlock{"stone", 0}
+	world.blocks[lever] = testBlock{Lever, 5}
+	for x := int32(1); x <= 16; x++ {
+		world.blocks[blocks.NewPosition(x, 9, 0)] = testBlock{"stone", 0}
+		world.blocks[blocks.NewPosition(x, 10, 0)] = testBlock{Wire, 0}
+	}
+	world.blocks[blocks.NewPosition(17, 10, 0)] = testBlock{RedstoneLamp, 0}
+
+	engine.ToggleLever(world, lever)
+	tick(scheduler, 1)
+	if world.blocks[blocks.NewPosition(1, 10, 0)].data != MaxPower || world.blocks[blocks.NewPosition(16, 10, 0)].data != 0 {
+		t.Error("expected wire power to decrease by one every block")
+	}
+	if world.blocks[blocks.NewPosition(15, 10, 0)].data != 1 {
+		t.Error("expected last powered wire to have power 1")
+	}
+	if world.GetBlockName(blocks.NewPosition(17, 10, 0)) != RedstoneLamp {
+		t.Error("expected lamp out of reach to stay unlit")
+	}
+
+	world.blocks[blocks.NewPosition(16, 10, 0)] = testBlock{RedstoneLamp, 0}
+	engine.Update(world, blocks.NewPosition(16, 10, 0))
+	tick(scheduler, 1)
+	if world.GetBlockName(blocks.NewPosition(16, 10, 0)) != LitRedstoneLamp {
+		t.Error("expected lamp next to powered wire to light")
+	}
+
+	engine.ToggleLever(world, lever)
+	tick(scheduler, 1)
+	for x := int32(1); x <= 15; x++ {
+		if world.blocks[blocks.NewPosition(x, 10, 0)].data != 0 {
+			t.Fatal("expected wires to lose power")
+		}
+	}
+	if world.GetBlockName(blocks.NewPosition(16, 10, 0)) != RedstoneLamp {
+		t.Error("expected lamp to turn off")
+	}
+}
+
+func TestTorchAndRepeater(t *testing.T) {
+	var scheduler = tasks.NewScheduler(1)
+	defer scheduler.Close()
+	var engine = NewEngine(scheduler)
+	var world = newTestWorld()
+
+	// Button on the west side of a stone block, with a torch on the east side of it,
+	// and a repeater outputting east to a door.
+	var button = blocks.NewPosition(0, 10, 0)
+	var torch = blocks.NewPosition(2, 10, 0)
+	var repeater = blocks.NewPosition(3, 10, 0)
+	var door = blocks.NewPosition(4, 10, 0)
+	world.blocks[button] = testBlock{StoneButton, 4}
+	world.blocks[blocks.NewPosition(1, 10, 0)] = testBlock{"stone", 0}
+	world.blocks[torch] = testBlock{Torch, 1}
+	world.blocks[repeater] = testBlock{Repeater, 1}
+	world.blocks[door] = testBlock{"wooden_door", 0}
+
+	engine.Update(world, torch)
+	tick(scheduler, 2+TickDelay)
+	if world.GetBlockName(repeater) != PoweredRepeater {
+		t.Fatal("expected repeater to be powered by the torch")
+	}
+	if world.blocks[door].data&0x04 == 0 {
+		t.Error("expected door to open")
+	}
+
+	engine.PressButton(world, button)
+	tick(scheduler, 2+TickDelay)
+	if world.GetBlockName(torch) != UnlitTorch {
+		t.Error("expected torch to turn off")
+	}
+	tick(scheduler, 1+TickDelay)
+	if world.GetBlockName(repeater) != Repeater || world.blocks[door].data&0x04 != 0 {
+		t.Error("expected repeater to turn off and door to close")
+	}
+
+	tick(scheduler, StoneButtonDelay)
+	if world.GetBlockName(torch) != Torch {
+		t.Error("expected torch to turn on again after button release")
+	}
+}
@@ -0,0 +1,17 @@
+package redstone
+
+import (
+	"github.com/irmine/worlds/blocks"
+)
+
+// World is the world redstone power propagates in.
+// It is implemented by the server for every dimension.
+// Implementations must be comparable, as worlds are used to identify scheduled updates.
+type World interface {
+	// GetBlockName returns the name of the block at the given position.
+	GetBlockName(position blocks.Position) string
+	// GetBlockData returns the data value of the block at the given position.
+	GetBlockData(position blocks.Position) byte
+	// PlaceBlock places a block with the given name, legacy ID and data value at the given position.
+	PlaceBlock(position blocks.Position, name string, id int32, data byte)
+}
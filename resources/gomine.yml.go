@@ -12,6 +12,8 @@ type GoMineConfig struct {
 	ServerMotd string `yaml:"Server MOTD"`
 	ServerIp   string `yaml:"Server IP"`
 	ServerPort uint16 `yaml:"Server Port"`
+	EnableIPv6 bool   `yaml:"Enable IPv6"`
+	ServerIpv6 string `yaml:"Server IPv6"`
 
 	MaximumPlayers  uint `yaml:"Maximum Players"`
 	DefaultGameMode byte `yaml:"Default Gamemode"`
@@ -21,16 +23,128 @@ type GoMineConfig struct {
 	DefaultLevel     string `yaml:"Default Level"`
 	DefaultGenerator string `yaml:"Default Generator"`
 
-	ForceResourcePacks   bool   `yaml:"Forced Resource Packs"`
-	SelectedResourcePack string `yaml:"Selected Resource Pack"`
+	ForceResourcePacks   bool              `yaml:"Forced Resource Packs"`
+	SelectedResourcePack string            `yaml:"Selected Resource Pack"`
+	ResourcePackURLs     map[string]string `yaml:"Resource Pack URLs"`
+
+	ResourcePackChunkSize int32 `yaml:"Resource Pack Chunk Size"`
+	PackResumeSeconds     int   `yaml:"Pack Resume Seconds"`
 
 	XBOXLiveAuth  bool `yaml:"XBOX Live Auth"`
 	UseEncryption bool `yaml:"Use Encryption"`
 
-	AllowQuery       bool `yaml:"Allow Query"`
-	AllowPluginQuery bool `yaml:"Allow Plugin Query"`
+	CompressionAlgorithm string `yaml:"Compression Algorithm"`
+	CompressionLevel     int    `yaml:"Compression Level"`
+	CompressionThreshold int    `yaml:"Compression Threshold"`
+
+	AllowQuery       bool   `yaml:"Allow Query"`
+	AllowPluginQuery bool   `yaml:"Allow Plugin Query"`
+	QueryPort        uint16 `yaml:"Query Port"`
+
+	EnableRcon   bool   `yaml:"Enable RCON"`
+	RconAddress  string `yaml:"RCON Address"`
+	RconPort     uint16 `yaml:"RCON Port"`
+	RconPassword string `yaml:"RCON Password"`
+
+	EnableMetrics  bool   `yaml:"Enable Metrics"`
+	MetricsAddress string `yaml:"Metrics Address"`
+	MetricsPort    uint16 `yaml:"Metrics Port"`
 
 	MaxViewDistance int32 `yaml:"Max View Distance"`
+
+	EnableGeoIP   bool   `yaml:"Enable GeoIP"`
+	GeoIPDatabase string `yaml:"GeoIP Database"`
+
+	EnableScreening       bool   `yaml:"Enable Screening"`
+	ScreeningAction       string `yaml:"Screening Action"`
+	ScreeningList         string `yaml:"Screening List"`
+	ScreeningAPI          string `yaml:"Screening API"`
+	ScreeningAPIField     string `yaml:"Screening API Field"`
+	ScreeningCacheMinutes int    `yaml:"Screening Cache Minutes"`
+
+	TrustedProxies []string `yaml:"Trusted Proxies"`
+
+	IdleTimeoutSeconds     int `yaml:"Idle Timeout Seconds"`
+	LatencyIntervalSeconds int `yaml:"Latency Interval Seconds"`
+
+	ImportWorld string            `yaml:"Import World"`
+	Worlds      []string          `yaml:"Worlds"`
+	SpawnWorld  string            `yaml:"Spawn World"`
+	SpawnWorlds map[string]string `yaml:"Spawn Worlds"`
+
+	DimensionHeights map[string]HeightSetting `yaml:"Dimension Heights"`
+
+	LoginWorkers      int `yaml:"Login Workers"`
+	LoginCacheSeconds int `yaml:"Login Cache Seconds"`
+
+	GenerationWorkers int `yaml:"Generation Workers"`
+	MaxPendingChunks  int `yaml:"Max Pending Chunks"`
+
+	LocalChatRadius float64 `yaml:"Local Chat Radius"`
+	ChatFormat      string  `yaml:"Chat Format"`
+	SanitizePolicy  string  `yaml:"Sanitize Policy"`
+
+	KickExistingOnNameConflict bool `yaml:"Kick Existing On Name Conflict"`
+
+	WelcomeType    string          `yaml:"Welcome Type"`
+	WelcomeTitle   string          `yaml:"Welcome Title"`
+	WelcomePages   []string        `yaml:"Welcome Pages"`
+	WelcomeButtons []WelcomeButton `yaml:"Welcome Buttons"`
+
+	CommandSignPattern string `yaml:"Command Sign Pattern"`
+
+	HopperTransferCooldown int `yaml:"Hopper Transfer Cooldown"`
+
+	EnablePistons bool `yaml:"Enable Pistons"`
+
+	AllowPvP bool `yaml:"Allow PvP"`
+
+	ValidateMovement bool `yaml:"Validate Movement"`
+
+	ValidateHits bool    `yaml:"Validate Hits"`
+	HitTolerance float64 `yaml:"Hit Tolerance"`
+
+	KnockbackHorizontal float64                     `yaml:"Knockback Horizontal"`
+	KnockbackVertical   float64                     `yaml:"Knockback Vertical"`
+	WeaponKnockback     map[string]KnockbackSetting `yaml:"Weapon Knockback"`
+
+	CriticalHits      bool `yaml:"Critical Hits"`
+	SweepingAttacks   bool `yaml:"Sweeping Attacks"`
+	AttackCooldown    bool `yaml:"Attack Cooldown"`
+	EnchantmentDamage bool `yaml:"Enchantment Damage"`
+
+	ValidateBreaking bool    `yaml:"Validate Breaking"`
+	BreakTolerance   float64 `yaml:"Break Tolerance"`
+
+	MaxEntityTicks      int `yaml:"Max Entity Ticks"`
+	MaxBlockEntityTicks int `yaml:"Max Block Entity Ticks"`
+	MobCap              int `yaml:"Mob Cap"`
+
+	ShutdownMessage string `yaml:"Shutdown Message"`
+	RestartMessage  string `yaml:"Restart Message"`
+
+	AutosaveInterval int `yaml:"Autosave Interval"`
+}
+
+// WelcomeButton is a button shown in the welcome form,
+// which executes a command as the player clicking it.
+type WelcomeButton struct {
+	Text    string `yaml:"Text"`
+	Command string `yaml:"Command"`
+}
+
+// HeightSetting is the range of heights blocks can be placed at in a dimension.
+// Both bounds must be multiples of 16.
+type HeightSetting struct {
+	Min int32 `yaml:"Min"`
+	Max int32 `yaml:"Max"`
+}
+
+// KnockbackSetting is the knockback of attacks with a specific weapon.
+// Values that are left out default to the global knockback.
+type KnockbackSetting struct {
+	Horizontal *float64 `yaml:"Horizontal"`
+	Vertical   *float64 `yaml:"Vertical"`
 }
 
 // NewGoMineConfig returns a new configuration struct.
@@ -46,43 +160,154 @@ func initializeConfig(serverPath string) {
 	var _, err = os.Stat(path)
 
 	if os.IsNotExist(err) {
-		var data, _ = yaml.Marshal(GoMineConfig{
-			ServerName: "GoMine Server",
-			ServerMotd: "GoMine Testing Server",
-			ServerIp:   "0.0.0.0",
-			ServerPort: 19132,
+		var data, _ = yaml.Marshal(defaultGoMineConfig())
+		var file, _ = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		file.WriteString(string(data))
+		file.Sync()
+	}
+}
 
-			MaximumPlayers:  20,
-			DefaultGameMode: 1,
+// defaultGoMineConfig returns the default configuration.
+// Options missing from an existing configuration file keep these defaults.
+func defaultGoMineConfig() GoMineConfig {
+	return GoMineConfig{
+		ServerName: "GoMine Server",
+		ServerMotd: "GoMine Testing Server",
+		ServerIp:   "0.0.0.0",
+		ServerPort: 19132,
+		EnableIPv6: false,
+		ServerIpv6: "::",
 
-			DebugMode: true,
+		MaximumPlayers:  20,
+		DefaultGameMode: 1,
 
-			DefaultLevel:     "world",
-			DefaultGenerator: "Flat",
+		DebugMode: true,
 
-			ForceResourcePacks:   false,
-			SelectedResourcePack: "",
+		DefaultLevel:     "world",
+		DefaultGenerator: "Flat",
 
-			XBOXLiveAuth:  true,
-			UseEncryption: false,
+		ForceResourcePacks:   false,
+		SelectedResourcePack: "",
+		ResourcePackURLs:     map[string]string{},
 
-			AllowQuery:       true,
-			AllowPluginQuery: true,
+		ResourcePackChunkSize: 1048576,
+		PackResumeSeconds:     60,
 
-			MaxViewDistance: 8,
-		})
-		var file, _ = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-		file.WriteString(string(data))
-		file.Sync()
+		XBOXLiveAuth:  true,
+		UseEncryption: false,
+
+		CompressionAlgorithm: "zlib",
+		CompressionLevel:     6,
+		CompressionThreshold: 256,
+
+		AllowQuery:       true,
+		AllowPluginQuery: true,
+		QueryPort:        0,
+
+		EnableRcon:   false,
+		RconAddress:  "127.0.0.1",
+		RconPort:     25575,
+		RconPassword: "",
+
+		EnableMetrics:  false,
+		MetricsAddress: "127.0.0.1",
+		MetricsPort:    9100,
+
+		MaxViewDistance: 8,
+
+		EnableGeoIP:   false,
+		GeoIPDatabase: "GeoLite2-Country.mmdb",
+
+		EnableScreening:       false,
+		ScreeningAction:       "flag",
+		ScreeningList:         "screening.txt",
+		ScreeningAPI:          "",
+		ScreeningAPIField:     "proxy",
+		ScreeningCacheMinutes: 60,
+
+		TrustedProxies: []string{},
+
+		IdleTimeoutSeconds:     30,
+		LatencyIntervalSeconds: 5,
+
+		ImportWorld: "",
+		Worlds:      []string{},
+		SpawnWorld:  "",
+		SpawnWorlds: map[string]string{},
+
+		DimensionHeights: map[string]HeightSetting{
+			"overworld": {Min: 0, Max: 256},
+			"nether":    {Min: 0, Max: 128},
+			"end":       {Min: 0, Max: 256},
+		},
+
+		LoginWorkers:      0,
+		LoginCacheSeconds: 30,
+
+		GenerationWorkers: 0,
+		MaxPendingChunks:  1024,
+
+		LocalChatRadius: 32,
+		ChatFormat:      "<{display_name}> {message}",
+		SanitizePolicy:  "strip",
+
+		KickExistingOnNameConflict: false,
+
+		WelcomeType:  "none",
+		WelcomeTitle: "Welcome",
+		WelcomePages: []string{
+			"Welcome to {server}, {display_name}!\nThere are {online}/{max_players} players online.",
+		},
+		WelcomeButtons: []WelcomeButton{
+			{Text: "Player List", Command: "/list"},
+		},
+
+		CommandSignPattern: `^\[(?i)command\]$`,
+
+		HopperTransferCooldown: 8,
+
+		EnablePistons: false,
+
+		AllowPvP: true,
+
+		ValidateMovement: false,
+
+		ValidateHits: true,
+		HitTolerance: 0.5,
+
+		KnockbackHorizontal: 0.4,
+		KnockbackVertical:   0.4,
+		WeaponKnockback:     map[string]KnockbackSetting{},
+
+		CriticalHits:      true,
+		SweepingAttacks:   false,
+		AttackCooldown:    false,
+		EnchantmentDamage: true,
+
+		ValidateBreaking: true,
+		BreakTolerance:   0.2,
+
+		MaxEntityTicks:      400,
+		MaxBlockEntityTicks: 1000,
+		MobCap:              100,
+
+		ShutdownMessage: "Server stopped",
+		RestartMessage:  "Server restarting",
+
+		AutosaveInterval: 300,
 	}
 }
 
 // getGoMineConfig parses the configuration file into a struct.
+// The query port defaults to the server port if it is 0.
 func getGoMineConfig(serverPath string) *GoMineConfig {
 	var yamlFile, _ = ioutil.ReadFile(serverPath + "gomine.yml")
 
-	var config = &GoMineConfig{}
-	yaml.Unmarshal(yamlFile, config)
+	var config = defaultGoMineConfig()
+	yaml.Unmarshal(yamlFile, &config)
+	if config.QueryPort == 0 {
+		config.QueryPort = config.ServerPort
+	}
 
-	return config
+	return &config
 }
@@ -0,0 +1,160 @@
+package gomine
+
+import (
+	"time"
+
+	"github.com/BobbyShrd/gominetest/autosave"
+	"github.com/BobbyShrd/gominetest/text"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/providers"
+)
+
+const (
+	// SaveBatchSize is the amount of save operations run before the saver pauses.
+	SaveBatchSize = 16
+	// SaveBatchPause is the time the saver pauses between batches of save operations.
+	SaveBatchPause = time.Millisecond * 5
+)
+
+// ChunkSaver is a chunk provider that can write single chunks to disk.
+// Dirty chunks of dimensions whose provider does not implement ChunkSaver
+// are left to the provider, which writes chunks when they get unloaded.
+type ChunkSaver interface {
+	SaveChunk(x, z int32) error
+}
+
+// The anvil provider set by setAnvilProvider saves dirty chunks itself.
+var _ ChunkSaver = (*providers.Anvil)(nil)
+
+// setAnvilProvider makes the dimension load and save its chunks in the anvil format in the given region directory.
+func (server *Server) setAnvilProvider(dimension *worlds.Dimension, directory string) {
+	var provider = providers.NewAnvil(directory)
+	dimension.SetChunkProvider(provider)
+	server.chunkProviderMutex.Lock()
+	server.chunkProviders[dimension] = provider
+	server.chunkProviderMutex.Unlock()
+}
+
+// getChunkSaver returns the chunk provider of the dimension, and a bool indicating if it can save single chunks.
+func (server *Server) getChunkSaver(dimension *worlds.Dimension) (ChunkSaver, bool) {
+	server.chunkProviderMutex.Lock()
+	defer server.chunkProviderMutex.Unlock()
+	var saver, ok = server.chunkProviders[dimension].(ChunkSaver)
+	return saver, ok
+}
+
+// SaveDimension saves the dirty chunks, the persistent entities, the block entities, the custom block states
+// and the water layer of the dimension.
+// The first error that occurred is returned, after all other saves were attempted.
+func (server *Server) SaveDimension(dimension *worlds.Dimension) error {
+	return runOperations(server.dimensionOperations(dimension))
+}
+
+// SaveWorld saves all dimensions of the level, its world settings and its level data.
+// The first error that occurred is returned, after all other saves were attempted.
+func (server *Server) SaveWorld(level *worlds.Level) error {
+	return runOperations(server.worldOperations(level))
+}
+
+// SaveAll saves all worlds with their level data, and the data of all online players.
+// The operations are collected on the calling goroutine and run in batches in an asynchronous task,
+// so that saving does not stall the server tick. Done, if not nil, is called with the amount of
+// operations that failed once the save finished.
+// Returns false if a save is still in progress, in which case nothing is saved.
+func (server *Server) SaveAll(done func(failed int)) bool {
+	if server.saver.IsSaving() {
+		return false
+	}
+	var operations []autosave.Operation
+	for _, level := range server.LevelManager.GetLevels() {
+		operations = append(operations, server.worldOperations(level)...)
+	}
+	for _, session := range server.SessionManager.GetSessions() {
+		var session = session
+		operations = append(operations, func() error {
+			server.SavePlayerData(session)
+			return nil
+		})
+	}
+
+	server.scheduler.ScheduleAsync(func() {
+		var errs, ok = server.saver.Save(operations)
+		if !ok {
+			return
+		}
+		for _, err := range errs {
+			text.DefaultLogger.LogError(err)
+		}
+		if done != nil {
+			done(len(errs))
+		}
+	})
+	return true
+}
+
+// scheduleAutosave schedules saving everything every autosave interval of the configuration.
+// Autosaves are skipped while an earlier save is still running. Autosaving is disabled if the interval is 0.
+func (server *Server) scheduleAutosave() {
+	if server.Config.AutosaveInterval <= 0 {
+		return
+	}
+	var period = int64(server.Config.AutosaveInterval) * 20
+	server.scheduler.ScheduleRepeating(func() {
+		server.SaveAll(func(failed int) {
+			text.DefaultLogger.Debug("Autosave finished,", failed, "operations failed.")
+		})
+	}, period, period)
+}
+
+// worldOperations returns the operations saving all dimensions of the level, its world settings and its level data.
+func (server *Server) worldOperations(level *worlds.Level) []autosave.Operation {
+	var operations []autosave.Operation
+	for _, dimension := range level.GetDimensions() {
+		operations = append(operations, server.dimensionOperations(dimension)...)
+	}
+	return append(operations, func() error {
+		return server.SaveWorldSettings(level)
+	}, func() error {
+		return server.SaveLevelData(level)
+	})
+}
+
+// dimensionOperations returns the operations saving the dirty chunks, the persistent entities, the block entities,
+// the custom block states and the water layer of the dimension.
+// Chunks that fail to save are marked dirty again, so that the next save retries them.
+// Dirty chunks are left marked if the chunk provider of the dimension can not save single chunks.
+func (server *Server) dimensionOperations(dimension *worlds.Dimension) []autosave.Operation {
+	var operations []autosave.Operation
+	if saver, ok := server.getChunkSaver(dimension); ok {
+		var dirty = server.GetDimensionWorld(dimension).GetDirtyChunks()
+		for _, position := range dirty.Take() {
+			var position = position
+			operations = append(operations, func() error {
+				if err := saver.SaveChunk(position.X, position.Z); err != nil {
+					dirty.Mark(position.X, position.Z)
+					return err
+				}
+				return nil
+			})
+		}
+	}
+	if manager, ok := server.GetEntityManager(dimension); ok {
+		operations = append(operations, manager.SaveAll)
+	}
+	operations = append(operations, server.GetBlockEntityManager(dimension).SaveAll, server.GetDimensionWorld(dimension).custom.SaveAll, server.GetDimensionWorld(dimension).layer.SaveAll, func() error {
+		return server.FarmManager.SaveAll(server.GetDimensionWorld(dimension))
+	})
+	return operations
+}
+
+// runOperations runs the save operations on the calling goroutine,
+// and returns the first error that occurred after running all operations.
+func runOperations(operations []autosave.Operation) error {
+	var first error
+	for _, operation := range operations {
+		if err := operation(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
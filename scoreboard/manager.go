@@ -0,0 +1,160 @@
+package scoreboard
+
+import (
+	"errors"
+	"sync"
+)
+
+// Display slots objectives can be displayed in.
+const (
+	// DisplaySidebar shows the scores of the objective at the side of the screen.
+	DisplaySidebar = "sidebar"
+	// DisplayBelowName shows the scores of players below their name tag.
+	DisplayBelowName = "belowname"
+	// DisplayList shows the scores of players next to their name in the player list.
+	DisplayList = "list"
+)
+
+var (
+	ObjectiveExists    = errors.New("an objective with this name already exists")
+	InvalidCriteria    = errors.New("invalid criteria")
+	InvalidDisplaySlot = errors.New("invalid display slot")
+)
+
+// criteria holds all supported criteria.
+var criteria = map[string]bool{CriteriaDummy: true, CriteriaHealth: true}
+
+// displaySlots holds all display slots.
+var displaySlots = map[string]bool{DisplaySidebar: true, DisplayBelowName: true, DisplayList: true}
+
+// IsDisplaySlot checks if the name is the name of a display slot.
+func IsDisplaySlot(slot string) bool {
+	return displaySlots[slot]
+}
+
+// Manager manages the objectives of the scoreboard, and the objectives displayed in the display slots.
+type Manager struct {
+	mutex      sync.RWMutex
+	objectives map[string]*Objective
+	displays   map[string]*Objective
+}
+
+// NewManager returns a new scoreboard manager without objectives.
+func NewManager() *Manager {
+	return &Manager{objectives: make(map[string]*Objective), displays: make(map[string]*Objective)}
+}
+
+// AddObjective adds a new objective with the given name, display name and criteria.
+func (manager *Manager) AddObjective(name string, displayName string, objectiveCriteria string) (*Objective, error) {
+	if !criteria[objectiveCriteria] {
+		return nil, InvalidCriteria
+	}
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if _, ok := manager.objectives[name]; ok {
+		return nil, ObjectiveExists
+	}
+	var objective = NewObjective(name, displayName, objectiveCriteria)
+	manager.objectives[name] = objective
+	return objective, nil
+}
+
+// RemoveObjective removes the objective with the given name, and clears the display slots it was displayed in.
+// The removed objective and the slots it was displayed in are returned, or false if the objective did not exist.
+func (manager *Manager) RemoveObjective(name string) (*Objective, []string, bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var objective, ok = manager.objectives[name]
+	if !ok {
+		return nil, nil, false
+	}
+	delete(manager.objectives, name)
+	var slots []string
+	for slot, displayed := range manager.displays {
+		if displayed == objective {
+			delete(manager.displays, slot)
+			slots = append(slots, slot)
+		}
+	}
+	return objective, slots, true
+}
+
+// GetObjective returns the objective with the given name, and a bool indicating if it exists.
+func (manager *Manager) GetObjective(name string) (*Objective, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var objective, ok = manager.objectives[name]
+	return objective, ok
+}
+
+// GetObjectives returns all objectives, indexed by name.
+func (manager *Manager) GetObjectives() map[string]*Objective {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var objectives = make(map[string]*Objective, len(manager.objectives))
+	for name, objective := range manager.objectives {
+		objectives[name] = objective
+	}
+	return objectives
+}
+
+// GetObjectivesByCriteria returns all objectives with the given criteria.
+func (manager *Manager) GetObjectivesByCriteria(objectiveCriteria string) []*Objective {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var objectives []*Objective
+	for _, objective := range manager.objectives {
+		if objective.criteria == objectiveCriteria {
+			objectives = append(objectives, objective)
+		}
+	}
+	return objectives
+}
+
+// SetDisplay displays the objective in the display slot. Nil clears the display slot.
+// The objective previously displayed in the slot is returned, or nil if the slot was empty.
+func (manager *Manager) SetDisplay(slot string, objective *Objective) (*Objective, error) {
+	if !IsDisplaySlot(slot) {
+		return nil, InvalidDisplaySlot
+	}
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var previous = manager.displays[slot]
+	if objective == nil {
+		delete(manager.displays, slot)
+	} else {
+		manager.displays[slot] = objective
+	}
+	return previous, nil
+}
+
+// GetDisplay returns the objective displayed in the display slot, and a bool indicating if the slot displays one.
+func (manager *Manager) GetDisplay(slot string) (*Objective, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var objective, ok = manager.displays[slot]
+	return objective, ok
+}
+
+// GetDisplays returns the objectives displayed, indexed by display slot.
+func (manager *Manager) GetDisplays() map[string]*Objective {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var displays = make(map[string]*Objective, len(manager.displays))
+	for slot, objective := range manager.displays {
+		displays[slot] = objective
+	}
+	return displays
+}
+
+// IsDisplayed checks if the objective is displayed in any display slot.
+func (manager *Manager) IsDisplayed(objective *Objective) bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	for _, displayed := range manager.displays {
+		if displayed == objective {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package scoreboard
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Criteria decide how the scores of an objective change.
+const (
+	// CriteriaDummy objectives only change when their scores are set.
+	CriteriaDummy = "dummy"
+	// CriteriaHealth objectives hold the health of players, and are updated automatically.
+	CriteriaHealth = "health"
+)
+
+// Sort orders of the scores of an objective in the sidebar.
+const (
+	SortAscending  = 0
+	SortDescending = 1
+)
+
+// lastScoreId is the ID of the last score created. Every score gets a unique ID, which clients use to update it.
+var lastScoreId int64
+
+// Score is the score of an entry of an objective.
+// Entries are either the names of players, or any other text shown in the sidebar.
+type Score struct {
+	Id    int64
+	Entry string
+	Value int
+}
+
+// Objective is an objective of a scoreboard, holding the scores of entries.
+type Objective struct {
+	mutex       sync.RWMutex
+	name        string
+	displayName string
+	criteria    string
+	sortOrder   int
+	scores      map[string]*Score
+}
+
+// NewObjective returns a new objective with the given name, the name shown to players and the criteria.
+// Scores are sorted in descending order by default.
+func NewObjective(name string, displayName string, criteria string) *Objective {
+	return &Objective{name: name, displayName: displayName, criteria: criteria, sortOrder: SortDescending, scores: make(map[string]*Score)}
+}
+
+// GetName returns the name of the objective.
+func (objective *Objective) GetName() string {
+	return objective.name
+}
+
+// GetDisplayName returns the name of the objective shown to players.
+func (objective *Objective) GetDisplayName() string {
+	objective.mutex.RLock()
+	defer objective.mutex.RUnlock()
+	return objective.displayName
+}
+
+// SetDisplayName sets the name of the objective shown to players.
+func (objective *Objective) SetDisplayName(displayName string) {
+	objective.mutex.Lock()
+	objective.displayName = displayName
+	objective.mutex.Unlock()
+}
+
+// GetCriteria returns the criteria of the objective.
+func (objective *Objective) GetCriteria() string {
+	return objective.criteria
+}
+
+// GetSortOrder returns the order the scores are sorted in, either SortAscending or SortDescending.
+func (objective *Objective) GetSortOrder() int {
+	objective.mutex.RLock()
+	defer objective.mutex.RUnlock()
+	return objective.sortOrder
+}
+
+// SetSortOrder sets the order the scores are sorted in, either SortAscending or SortDescending.
+func (objective *Objective) SetSortOrder(order int) {
+	objective.mutex.Lock()
+	objective.sortOrder = order
+	objective.mutex.Unlock()
+}
+
+// SetScore sets the score of the entry, and returns the score.
+// Returns false if the score did not change.
+func (objective *Objective) SetScore(entry string, value int) (Score, bool) {
+	objective.mutex.Lock()
+	defer objective.mutex.Unlock()
+	var score, ok = objective.scores[entry]
+	if !ok {
+		score = &Score{Id: atomic.AddInt64(&lastScoreId, 1), Entry: entry}
+		objective.scores[entry] = score
+	} else if score.Value == value {
+		return *score, false
+	}
+	score.Value = value
+	return *score, true
+}
+
+// AddScore adds the amount to the score of the entry, and returns the score.
+// Entries without a score start at 0.
+func (objective *Objective) AddScore(entry string, amount int) Score {
+	objective.mutex.Lock()
+	defer objective.mutex.Unlock()
+	var score, ok = objective.scores[entry]
+	if !ok {
+		score = &Score{Id: atomic.AddInt64(&lastScoreId, 1), Entry: entry}
+		objective.scores[entry] = score
+	}
+	score.Value += amount
+	return *score
+}
+
+// GetScore returns the score of the entry, and a bool indicating if the entry has a score.
+func (objective *Objective) GetScore(entry string) (Score, bool) {
+	objective.mutex.RLock()
+	defer objective.mutex.RUnlock()
+	var score, ok = objective.scores[entry]
+	if !ok {
+		return Score{}, false
+	}
+	return *score, true
+}
+
+// RemoveScore removes the score of the entry, and returns the removed score.
+// Returns false if the entry had no score.
+func (objective *Objective) RemoveScore(entry string) (Score, bool) {
+	objective.mutex.Lock()
+	defer objective.mutex.Unlock()
+	var score, ok = objective.scores[entry]
+	if !ok {
+		return Score{}, false
+	}
+	delete(objective.scores, entry)
+	return *score, true
+}
+
+// GetScores returns all scores of the objective, sorted in the sort order of the objective.
+func (objective *Objective) GetScores() []Score {
+	objective.mutex.RLock()
+	defer objective.mutex.RUnlock()
+	var scores = make([]Score, 0, len(objective.scores))
+	for _, score := range objective.scores {
+		scores = append(scores, *score)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Value == scores[j].Value {
+			return scores[i].Entry < scores[j].Entry
+		}
+		if objective.sortOrder == SortAscending {
+			return scores[i].Value < scores[j].Value
+		}
+		return scores[i].Value > scores[j].Value
+	})
+	return scores
+}
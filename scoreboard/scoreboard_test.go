@@ -0,0 +1,51 @@
+package scoreboard
+
+import (
+	"testing"
+)
+
+func TestObjective(t *testing.T) {
+	var objective = NewObjective("kills", "Kills", CriteriaDummy)
+	objective.SetScore("b", 3)
+	objective.SetScore("a", 5)
+	if _, changed := objective.SetScore("a", 5); changed {
+		t.Error("expected setting the same score not to change it")
+	}
+	objective.AddScore("c", 4)
+
+	var scores = objective.GetScores()
+	if len(scores) != 3 || scores[0].Entry != "a" || scores[1].Entry != "c" || scores[2].Entry != "b" {
+		t.Errorf("expected scores in descending order, got %v", scores)
+	}
+	objective.SetSortOrder(SortAscending)
+	if scores = objective.GetScores(); scores[0].Entry != "b" {
+		t.Errorf("expected scores in ascending order, got %v", scores)
+	}
+	if scores[0].Id == scores[1].Id {
+		t.Error("expected every score to have a unique ID")
+	}
+}
+
+func TestDisplays(t *testing.T) {
+	var manager = NewManager()
+	if _, err := manager.AddObjective("health", "Health", "unknown"); err != InvalidCriteria {
+		t.Errorf("expected invalid criteria, got %v", err)
+	}
+	var health, _ = manager.AddObjective("health", "Health", CriteriaHealth)
+	if _, err := manager.AddObjective("health", "Health", CriteriaHealth); err != ObjectiveExists {
+		t.Errorf("expected the objective to exist, got %v", err)
+	}
+	if _, err := manager.SetDisplay("head", health); err != InvalidDisplaySlot {
+		t.Errorf("expected an invalid display slot, got %v", err)
+	}
+	manager.SetDisplay(DisplayBelowName, health)
+	manager.SetDisplay(DisplayList, health)
+	if objectives := manager.GetObjectivesByCriteria(CriteriaHealth); len(objectives) != 1 || !manager.IsDisplayed(health) {
+		t.Error("expected the health objective to be displayed")
+	}
+
+	var _, slots, ok = manager.RemoveObjective("health")
+	if !ok || len(slots) != 2 || len(manager.GetDisplays()) != 0 {
+		t.Errorf("expected removing the objective to clear both display slots, got %v", slots)
+	}
+}
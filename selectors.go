@@ -0,0 +1,118 @@
+package gomine
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/BobbyShrd/gominetest/commands"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/selectors"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+)
+
+// SessionTarget is the target of a session selected by target selectors.
+type SessionTarget struct {
+	*net.MinecraftSession
+}
+
+// GetEntityType returns the entity type of players.
+func (target SessionTarget) GetEntityType() string {
+	return selectors.PlayerType
+}
+
+// GetPosition returns the position of the player of the session.
+func (target SessionTarget) GetPosition() r3.Vector {
+	return target.GetPlayer().Position
+}
+
+// GetDimension returns the dimension the player of the session is in.
+func (target SessionTarget) GetDimension() *worlds.Dimension {
+	return target.GetPlayer().GetDimension()
+}
+
+// MobTarget is the target of a mob selected by "@e" target selectors.
+type MobTarget struct {
+	*PersistentEntity
+	dimension *worlds.Dimension
+}
+
+// GetName returns the name of the mob type, such as "Zombie".
+func (target MobTarget) GetName() string {
+	var mobType, ok = mobTypes[target.PersistentEntity.GetEntityType()]
+	if !ok {
+		return "Mob"
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(mobType.name, "an "), "a ")
+}
+
+// GetEntityType returns the entity type of the mob, such as "zombie".
+func (target MobTarget) GetEntityType() string {
+	return mobTypes[target.PersistentEntity.GetEntityType()].lootName
+}
+
+// GetPosition returns the position of the mob.
+func (target MobTarget) GetPosition() r3.Vector {
+	return target.Position
+}
+
+// GetDimension returns the dimension the mob is in.
+func (target MobTarget) GetDimension() *worlds.Dimension {
+	return target.dimension
+}
+
+// getMobTargets returns the mobs in the dimension as targets of "@e" target selectors, ordered by runtime ID.
+func (server *Server) getMobTargets(dimension *worlds.Dimension) []selectors.Target {
+	var mobs = server.GetMobManager(dimension).GetMobs()
+	var targets = make([]selectors.Target, 0, len(mobs))
+	for _, mob := range mobs {
+		if body, ok := mob.GetBody().(mobBody); ok {
+			targets = append(targets, MobTarget{body.PersistentEntity, dimension})
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].(MobTarget).GetRuntimeId() < targets[j].(MobTarget).GetRuntimeId()
+	})
+	return targets
+}
+
+// SelectTargets returns the targets selected by the selector, executed by the given sender.
+// Players are selected from all online sessions, and entities from the dimension of the sender
+// if the server has an entities function.
+func (server *Server) SelectTargets(sender commands.Sender, selector *selectors.Selector) []selectors.Target {
+	var source selectors.Target
+	if session, ok := sender.(*net.MinecraftSession); ok {
+		source = SessionTarget{session}
+	}
+
+	var sessions = make([]*net.MinecraftSession, 0, server.SessionManager.GetSessionCount())
+	for _, session := range server.SessionManager.GetSessions() {
+		if session.GetPlayer().GetDimension() != nil {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].GetName() < sessions[j].GetName()
+	})
+	var players = make([]selectors.Target, len(sessions))
+	for i, session := range sessions {
+		players[i] = SessionTarget{session}
+	}
+
+	var entities []selectors.Target
+	if selector.SelectsEntities() && source != nil && server.EntitiesFunction != nil {
+		entities = server.EntitiesFunction(source.GetDimension())
+	}
+	return selector.Select(source, players, entities)
+}
+
+// SelectSessions returns the sessions of the players selected by the selector, executed by the given sender.
+func (server *Server) SelectSessions(sender commands.Sender, selector *selectors.Selector) []*net.MinecraftSession {
+	var sessions []*net.MinecraftSession
+	for _, target := range server.SelectTargets(sender, selector) {
+		if target, ok := target.(SessionTarget); ok {
+			sessions = append(sessions, target.MinecraftSession)
+		}
+	}
+	return sessions
+}
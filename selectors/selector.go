@@ -0,0 +1,249 @@
+package selectors
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+)
+
+const (
+	// PlayerType is the entity type of players.
+	PlayerType = "player"
+
+	AllPlayers    byte = 'a'
+	NearestPlayer byte = 'p'
+	RandomPlayer  byte = 'r'
+	Self          byte = 's'
+	AllEntities   byte = 'e'
+)
+
+// Target is an entity that can be selected by target selectors.
+type Target interface {
+	// GetName returns the name of a player, or the name tag of an entity.
+	GetName() string
+	// GetEntityType returns the type of the entity, for example "player" or "zombie".
+	GetEntityType() string
+	// GetPosition returns the position of the entity.
+	GetPosition() r3.Vector
+	// GetDimension returns the dimension the entity is in.
+	GetDimension() *worlds.Dimension
+}
+
+var (
+	InvalidSelector   = errors.New("invalid target selector")
+	UnknownVariable   = errors.New("unknown target selector variable")
+	UnknownArgument   = errors.New("unknown target selector argument")
+	InvalidArgument   = errors.New("invalid target selector argument value")
+	DuplicateArgument = errors.New("duplicate target selector argument")
+)
+
+// numericArguments are the arguments that must have a number as value.
+var numericArguments = map[string]bool{
+	"r":     true,
+	"rm":    true,
+	"limit": true,
+	"c":     true,
+}
+
+// textArguments are the arguments that may have any value.
+var textArguments = map[string]bool{
+	"type": true,
+	"name": true,
+}
+
+// Selector is a parsed target selector, such as "@a[r=10]".
+// Selectors may also be a plain player name, which selects the player with that name.
+type Selector struct {
+	text      string
+	variable  byte
+	arguments map[string]string
+}
+
+// Parse parses the given text into a target selector.
+// Text that does not start with an '@' is treated as a player name.
+func Parse(text string) (*Selector, error) {
+	if text == "" {
+		return nil, InvalidSelector
+	}
+	var selector = &Selector{text: text, arguments: make(map[string]string)}
+	if text[0] != '@' {
+		return selector, nil
+	}
+	if len(text) < 2 {
+		return nil, InvalidSelector
+	}
+	switch text[1] {
+	case AllPlayers, NearestPlayer, RandomPlayer, Self, AllEntities:
+		selector.variable = text[1]
+	default:
+		return nil, UnknownVariable
+	}
+
+	var rest = text[2:]
+	if rest == "" {
+		return selector, nil
+	}
+	if rest[0] != '[' || rest[len(rest)-1] != ']' {
+		return nil, InvalidSelector
+	}
+	rest = strings.TrimSpace(rest[1 : len(rest)-1])
+	if rest == "" {
+		return selector, nil
+	}
+	for _, argument := range strings.Split(rest, ",") {
+		var fragments = strings.SplitN(argument, "=", 2)
+		if len(fragments) != 2 {
+			return nil, InvalidSelector
+		}
+		var key, value = strings.TrimSpace(fragments[0]), strings.TrimSpace(fragments[1])
+		if !numericArguments[key] && !textArguments[key] {
+			return nil, UnknownArgument
+		}
+		if _, ok := selector.arguments[key]; ok {
+			return nil, DuplicateArgument
+		}
+		if numericArguments[key] {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, InvalidArgument
+			}
+		}
+		selector.arguments[key] = value
+	}
+	return selector, nil
+}
+
+// String returns the text the selector was parsed from.
+func (selector *Selector) String() string {
+	return selector.text
+}
+
+// IsPlayerName checks if the selector is a plain player name, rather than a selector starting with an '@'.
+func (selector *Selector) IsPlayerName() bool {
+	return selector.variable == 0
+}
+
+// GetVariable returns the variable of the selector, for example 'a' for "@a".
+// Zero is returned if the selector is a plain player name.
+func (selector *Selector) GetVariable() byte {
+	return selector.variable
+}
+
+// GetArgument returns the value of the argument with the given name,
+// and a bool indicating if the argument was specified.
+func (selector *Selector) GetArgument(name string) (string, bool) {
+	var value, ok = selector.arguments[name]
+	return value, ok
+}
+
+// SelectsEntities checks if the selector may select entities other than players.
+func (selector *Selector) SelectsEntities() bool {
+	return selector.variable == AllEntities
+}
+
+// getNumber returns the numeric value of an argument.
+func (selector *Selector) getNumber(name string) (float64, bool) {
+	var value, ok = selector.arguments[name]
+	if !ok {
+		return 0, false
+	}
+	var number, _ = strconv.ParseFloat(value, 64)
+	return number, true
+}
+
+// getLimit returns the maximum amount of targets the selector selects, or -1 if it is unlimited.
+func (selector *Selector) getLimit() int {
+	if limit, ok := selector.getNumber("limit"); ok {
+		return int(limit)
+	}
+	if limit, ok := selector.getNumber("c"); ok {
+		return int(limit)
+	}
+	if selector.variable == NearestPlayer || selector.variable == RandomPlayer {
+		return 1
+	}
+	return -1
+}
+
+// Select returns the targets matching the selector.
+// The source is the target executing the selector, which may be nil if it is no entity, such as the console.
+// Selectors with a radius only select targets in the same dimension as the source,
+// and select nothing without a source. Entities are only selected by "@e".
+func (selector *Selector) Select(source Target, players []Target, entities []Target) []Target {
+	var candidates []Target
+	switch selector.variable {
+	case 0:
+		for _, player := range players {
+			if strings.EqualFold(player.GetName(), selector.text) {
+				return []Target{player}
+			}
+		}
+		return nil
+	case Self:
+		if source != nil {
+			candidates = []Target{source}
+		}
+	case AllEntities:
+		candidates = append(append(candidates, players...), entities...)
+	default:
+		candidates = append(candidates, players...)
+	}
+
+	var selected []Target
+	for _, candidate := range candidates {
+		if selector.matches(source, candidate) {
+			selected = append(selected, candidate)
+		}
+	}
+
+	switch selector.variable {
+	case NearestPlayer:
+		if source != nil {
+			var origin = source.GetPosition()
+			sort.SliceStable(selected, func(i, j int) bool {
+				return selected[i].GetPosition().Sub(origin).Norm2() < selected[j].GetPosition().Sub(origin).Norm2()
+			})
+		}
+	case RandomPlayer:
+		rand.Shuffle(len(selected), func(i, j int) {
+			selected[i], selected[j] = selected[j], selected[i]
+		})
+	}
+
+	if limit := selector.getLimit(); limit >= 0 && len(selected) > limit {
+		selected = selected[:limit]
+	}
+	return selected
+}
+
+// matches checks if the candidate matches all arguments of the selector.
+func (selector *Selector) matches(source Target, candidate Target) bool {
+	if entityType, ok := selector.arguments["type"]; ok {
+		var negated = strings.HasPrefix(entityType, "!")
+		entityType = strings.TrimPrefix(strings.TrimPrefix(entityType, "!"), "minecraft:")
+		if (candidate.GetEntityType() == entityType) == negated {
+			return false
+		}
+	}
+	if name, ok := selector.arguments["name"]; ok {
+		var negated = strings.HasPrefix(name, "!")
+		if (candidate.GetName() == strings.TrimPrefix(name, "!")) == negated {
+			return false
+		}
+	}
+
+	var maxRadius, hasMax = selector.getNumber("r")
+	var minRadius, hasMin = selector.getNumber("rm")
+	if !hasMax && !hasMin {
+		return true
+	}
+	if source == nil || source.GetDimension() != candidate.GetDimension() {
+		return false
+	}
+	var distance = candidate.GetPosition().Distance(source.GetPosition())
+	return (!hasMax || distance <= maxRadius) && (!hasMin || distance >= minRadius)
+}
@@ -0,0 +1,80 @@
+package selectors
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+)
+
+type testTarget struct {
+	name       string
+	entityType string
+	position   r3.Vector
+}
+
+func (target testTarget) GetName() string {
+	return target.name
+}
+
+func (target testTarget) GetEntityType() string {
+	return target.entityType
+}
+
+func (target testTarget) GetPosition() r3.Vector {
+	return target.position
+}
+
+func (target testTarget) GetDimension() *worlds.Dimension {
+	return nil
+}
+
+func TestParse(t *testing.T) {
+	for _, text := range []string{"Steve", "@a", "@p[r=10]", "@e[type=!player,limit=2]", "@r[]"} {
+		if _, err := Parse(text); err != nil {
+			t.Errorf("expected %v to parse, got %v", text, err)
+		}
+	}
+	for _, text := range []string{"", "@", "@x", "@a[r=ten]", "@a[foo=1]", "@a[r=1", "@a[r=1,r=2]"} {
+		if _, err := Parse(text); err == nil {
+			t.Errorf("expected %v not to parse", text)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	var steve = testTarget{"Steve", PlayerType, r3.Vector{X: 5}}
+	var alex = testTarget{"Alex", PlayerType, r3.Vector{X: 20}}
+	var zombie = testTarget{"", "zombie", r3.Vector{X: 1}}
+	var players = []Target{steve, alex}
+	var entities = []Target{zombie}
+
+	var tests = map[string]int{
+		"alex":                       1,
+		"@a":                         2,
+		"@a[r=10]":                   1,
+		"@a[rm=10]":                  1,
+		"@a[name=!Steve]":            1,
+		"@e":                         3,
+		"@e[type=zombie]":            1,
+		"@e[type=!minecraft:zombie]": 2,
+		"@e[limit=2]":                2,
+		"@r":                         1,
+		"@s":                         1,
+	}
+	for text, expected := range tests {
+		var selector, _ = Parse(text)
+		if selected := selector.Select(steve, players, entities); len(selected) != expected {
+			t.Errorf("expected %v to select %v targets, got %v", text, expected, len(selected))
+		}
+	}
+
+	var selector, _ = Parse("@p")
+	if selected := selector.Select(testTarget{"", PlayerType, r3.Vector{X: 18}}, players, entities); len(selected) != 1 || selected[0].GetName() != "Alex" {
+		t.Errorf("expected @p to select the nearest player, got %v", selected)
+	}
+	selector, _ = Parse("@a[r=100]")
+	if selected := selector.Select(nil, players, entities); len(selected) != 0 {
+		t.Error("expected selectors with a radius to select nothing without a source")
+	}
+}
@@ -5,26 +5,74 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"github.com/irmine/worlds/generation/defaults"
-	"github.com/irmine/worlds/providers"
 
 	"encoding/hex"
 	"errors"
-	"fmt"
+	"github.com/BobbyShrd/gominetest/ai"
+	"github.com/BobbyShrd/gominetest/autosave"
+	"github.com/BobbyShrd/gominetest/blockentities"
+	"github.com/BobbyShrd/gominetest/chat"
+	"github.com/BobbyShrd/gominetest/chunkgen"
+	"github.com/BobbyShrd/gominetest/combat"
 	"github.com/BobbyShrd/gominetest/commands"
+	"github.com/BobbyShrd/gominetest/console"
+	"github.com/BobbyShrd/gominetest/disguises"
+	"github.com/BobbyShrd/gominetest/entitystore"
+	"github.com/BobbyShrd/gominetest/events"
+	"github.com/BobbyShrd/gominetest/farming"
+	"github.com/BobbyShrd/gominetest/forms"
+	"github.com/BobbyShrd/gominetest/gamerules"
+	"github.com/BobbyShrd/gominetest/geoip"
+	"github.com/BobbyShrd/gominetest/interactions"
+	"github.com/BobbyShrd/gominetest/itementities"
+	"github.com/BobbyShrd/gominetest/lang"
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/BobbyShrd/gominetest/loot"
+	"github.com/BobbyShrd/gominetest/metrics"
 	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/net/compression"
+	"github.com/BobbyShrd/gominetest/net/forwarding"
+	"github.com/BobbyShrd/gominetest/net/gamespy"
 	"github.com/BobbyShrd/gominetest/net/info"
+	"github.com/BobbyShrd/gominetest/net/logincache"
 	"github.com/BobbyShrd/gominetest/net/packets/data"
+	"github.com/BobbyShrd/gominetest/net/packtransfer"
 	"github.com/BobbyShrd/gominetest/net/protocol"
+	"github.com/BobbyShrd/gominetest/net/rcon"
+	"github.com/BobbyShrd/gominetest/net/screening"
 	"github.com/BobbyShrd/gominetest/packs"
+	"github.com/BobbyShrd/gominetest/palette"
 	"github.com/BobbyShrd/gominetest/permissions"
+	"github.com/BobbyShrd/gominetest/pistons"
+	"github.com/BobbyShrd/gominetest/playerlist"
+	"github.com/BobbyShrd/gominetest/players"
+	"github.com/BobbyShrd/gominetest/pong"
+	"github.com/BobbyShrd/gominetest/redstone"
 	"github.com/BobbyShrd/gominetest/resources"
+	"github.com/BobbyShrd/gominetest/scoreboard"
+	"github.com/BobbyShrd/gominetest/selectors"
+	"github.com/BobbyShrd/gominetest/skins"
+	"github.com/BobbyShrd/gominetest/tasks"
 	"github.com/BobbyShrd/gominetest/text"
+	"github.com/BobbyShrd/gominetest/utils"
+	"github.com/BobbyShrd/gominetest/vehicles"
+	"github.com/BobbyShrd/gominetest/villagers"
+	"github.com/BobbyShrd/gominetest/weather"
+	"github.com/BobbyShrd/gominetest/windows"
+	"github.com/golang/geo/r3"
 	"github.com/irmine/goraklib/server"
 	"github.com/irmine/query"
 	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
 	net2 "net"
 	"os"
+	"os/signal"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 )
 
 const (
@@ -33,35 +81,145 @@ const (
 )
 
 type Server struct {
-	isRunning         bool
-	tick              int64
-	privateKey        *ecdsa.PrivateKey
-	token             []byte
-	ServerPath        string
-	Config            *resources.GoMineConfig
-	CommandReader     *text.CommandReader
-	CommandManager    *commands.Manager
-	PackManager       *packs.Manager
-	PermissionManager *permissions.Manager
-	LevelManager      *worlds.Manager
-	SessionManager    *net.SessionManager
-	NetworkAdapter    *net.NetworkAdapter
-	PluginManager     *PluginManager
-	QueryManager      query.Manager
+	lifecycleMutex      sync.Mutex
+	isRunning           int32
+	logFile             *os.File
+	tick                int64
+	budgetWarnings      map[string]int64
+	privateKey          *ecdsa.PrivateKey
+	token               []byte
+	loginPool           *utils.WorkerPool
+	scheduler           *tasks.Scheduler
+	blockEntityMutex    sync.Mutex
+	blockEntityManagers map[*worlds.Dimension]*blockentities.Manager
+	dimensionWorldMutex sync.Mutex
+	dimensionWorlds     map[*worlds.Dimension]*DimensionWorld
+	chunkProviderMutex  sync.Mutex
+	chunkProviders      map[*worlds.Dimension]interface{}
+	saver               *autosave.Saver
+	entityManagerMutex  sync.Mutex
+	entityManagers      map[*worlds.Dimension]*entitystore.Manager
+	itemEntityMutex     sync.Mutex
+	itemEntityManagers  map[*worlds.Dimension]*itementities.Manager
+	mobMutex            sync.Mutex
+	mobManagers         map[*worlds.Dimension]*ai.Manager
+	vehicleMutex        sync.Mutex
+	vehicleManagers     map[*worlds.Dimension]*vehicles.Manager
+	leashMutex          sync.Mutex
+	leashKnots          map[*worlds.Dimension]map[blocks.Position]*PersistentEntity
+	villagerMutex       sync.Mutex
+	villagerManagers    map[*worlds.Dimension]*villagers.Manager
+	tradeMutex          sync.Mutex
+	trades              map[string]*tradeWindow
+	fallMutex           sync.Mutex
+	fallHeights         map[string]float64
+	riseMutex           sync.Mutex
+	rises               map[string]riseState
+	teleportMutex       sync.Mutex
+	teleports           map[string]pendingTeleport
+	breakMutex          sync.Mutex
+	breakStarts         map[string]breakStart
+	playerNames         *players.NameIndex
+	skinStore           *skins.Store
+	disguiseMutex       sync.Mutex
+	disguiseTargets     map[string]DisguiseTarget
+	worldMutex          sync.Mutex
+	worldSettings       map[*worlds.Level]*levels.Settings
+	heightRanges        map[string]levels.HeightRange
+	worldSpawns         map[*worlds.Level]r3.Vector
+	weatherCycles       map[*worlds.Level]*weather.Cycle
+	levelData           map[*worlds.Level]*levels.Data
+	gameRules           map[*worlds.Level]*gamerules.Rules
+	pongMutex           sync.Mutex
+	ServerPath          string
+	Config              *resources.GoMineConfig
+	Console             *console.Console
+	ConsoleSender       *console.Sender
+	CommandManager      *commands.Manager
+	PackManager         *packs.Manager
+	PermissionManager   *permissions.Manager
+	OpList              *permissions.OpList
+	ChatManager         *chat.Manager
+	EventManager        *events.Manager
+	FormManager         *forms.Manager
+	InteractionRegistry *interactions.Registry
+	CommandSignManager  *CommandSignManager
+	PistonManager       *pistons.Manager
+	RedstoneEngine      *redstone.Engine
+	LootManager         *loot.Manager
+	FarmManager         *farming.Manager
+	CombatManager       *combat.Manager
+	KnockbackProfile    *combat.KnockbackProfile
+	DamageOptions       combat.DamageOptions
+	WindowManager       *windows.Manager
+	LevelManager        *worlds.Manager
+	LevelProvider       levels.Provider
+	LevelData           *levels.Data
+	SessionManager      *net.SessionManager
+	NetworkAdapter      *net.NetworkAdapter
+	PluginManager       *PluginManager
+	QueryManager        query.Manager
+	QueryListener       *gamespy.Listener
+	ChunkGenerationPool *chunkgen.Pool
+	RconListener        *rcon.Listener
+	Metrics             *ServerMetrics
+	MetricsEndpoint     *metrics.Endpoint
+	GeoIP               *geoip.Reader
+	Screener            *screening.Screener
+	TrustedProxies      *forwarding.TrustedProxies
+	LoginCache          *logincache.Cache
+	PackTransfers       *packtransfer.Cache
+	Pong                *pong.Builder
+	Scoreboard          *scoreboard.Manager
+	PlayerList          *playerlist.Manager
+	Disguises           *disguises.Manager
+	BlockPalettes       *palette.Manager
+
+	// EntitiesFunction returns the entities in a dimension that can be selected by "@e" target selectors.
+	// Only players are selected if EntitiesFunction is nil. By default, the mobs of the dimension are selected.
+	EntitiesFunction func(dimension *worlds.Dimension) []selectors.Target
+	// GeoIPFunction gets called with the country of every joining player, if GeoIP lookups are enabled.
+	// The country is empty if it could not be found. Nothing is done if nil.
+	GeoIPFunction func(session *net.MinecraftSession, country string)
 }
 
 // AlreadyStarted gets returned during server startup,
 // if the server has already been started.
 var AlreadyStarted = errors.New("server is already started")
 
+// NotRunning gets returned when restarting a server that is not running.
+var NotRunning = errors.New("server is not running")
+
 // NewServer returns a new server with the given server path.
 func NewServer(serverPath string, config *resources.GoMineConfig) *Server {
 	var s = &Server{}
 
 	s.ServerPath = serverPath
 	s.Config = config
+	s.blockEntityManagers = make(map[*worlds.Dimension]*blockentities.Manager)
+	s.budgetWarnings = make(map[string]int64)
+	s.dimensionWorlds = make(map[*worlds.Dimension]*DimensionWorld)
+	s.chunkProviders = make(map[*worlds.Dimension]interface{})
+	s.saver = autosave.NewSaver(SaveBatchSize, SaveBatchPause)
+	s.entityManagers = make(map[*worlds.Dimension]*entitystore.Manager)
+	s.itemEntityManagers = make(map[*worlds.Dimension]*itementities.Manager)
+	s.mobManagers = make(map[*worlds.Dimension]*ai.Manager)
+	s.vehicleManagers = make(map[*worlds.Dimension]*vehicles.Manager)
+	s.leashKnots = make(map[*worlds.Dimension]map[blocks.Position]*PersistentEntity)
+	s.villagerManagers = make(map[*worlds.Dimension]*villagers.Manager)
+	s.trades = make(map[string]*tradeWindow)
+	s.fallHeights = make(map[string]float64)
+	s.rises = make(map[string]riseState)
+	s.teleports = make(map[string]pendingTeleport)
+	s.breakStarts = make(map[string]breakStart)
+	s.worldSettings = make(map[*worlds.Level]*levels.Settings)
+	s.worldSpawns = make(map[*worlds.Level]r3.Vector)
+	s.weatherCycles = make(map[*worlds.Level]*weather.Cycle)
+	s.levelData = make(map[*worlds.Level]*levels.Data)
+	s.gameRules = make(map[*worlds.Level]*gamerules.Rules)
 	text.DefaultLogger.DebugMode = config.DebugMode
 	file, _ := os.OpenFile(serverPath+"gomine.log", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0700)
+	s.logFile = file
 	text.DefaultLogger.AddOutput(func(message []byte) {
 		_, err := file.WriteString(text.ColoredString(message).StripAll())
 		if err != nil {
@@ -69,22 +227,103 @@ func NewServer(serverPath string, config *resources.GoMineConfig) *Server {
 		}
 	})
 
-	s.LevelManager = worlds.NewManager(serverPath)
-	s.CommandReader = text.NewCommandReader(os.Stdin)
-	s.CommandReader.AddReadFunc(s.attemptReadCommand)
+	var playerNames, err = players.LoadNameIndex(serverPath + "players/xuid/index.yml")
+	text.DefaultLogger.LogError(err)
+	s.playerNames = playerNames
+	s.skinStore = skins.NewStore(serverPath + "players/skins/")
 
+	s.LevelManager = worlds.NewManager(serverPath)
 	s.CommandManager = commands.NewManager()
+	s.ConsoleSender = console.NewSender()
+	s.Console = console.New(os.Stdin, os.Stdout, s.completeConsoleLine)
+	s.Console.ReadFunction = s.attemptReadCommand
+	s.Console.InterruptFunction = s.Shutdown
 
 	s.SessionManager = net.NewSessionManager()
 	s.NetworkAdapter = net.NewNetworkAdapter(NewPacketManager(s), s.SessionManager)
+	s.NetworkAdapter.Compression = newCompressionSettings(config)
+	s.Scoreboard = scoreboard.NewManager()
+	s.PlayerList = playerlist.NewManager()
+	s.Disguises = disguises.NewManager()
+	s.disguiseTargets = make(map[string]DisguiseTarget)
+	s.Pong = pong.NewBuilder(config.ServerMotd, GoMineName, "")
+	s.registerPongPlaceholders()
 	s.NetworkAdapter.GetRakLibManager().PongData = s.GeneratePongData()
 	s.NetworkAdapter.GetRakLibManager().RawPacketFunction = s.HandleRaw
 	s.NetworkAdapter.GetRakLibManager().DisconnectFunction = s.HandleDisconnect
+	s.NetworkAdapter.ChunkLoadedFunction = s.loadChunkEntities
+	s.NetworkAdapter.ChunkUnloadedFunction = s.unloadChunkEntities
+	s.NetworkAdapter.ErrorFormFunction = s.showErrorForm
+	s.NetworkAdapter.PlayerSpawnedFunction = s.sendSpawnedDisguise
+	s.Metrics = NewServerMetrics(s)
+	s.MetricsEndpoint = metrics.NewEndpoint(s.Metrics.Registry)
 
 	s.PackManager = packs.NewManager(serverPath)
 	s.PermissionManager = permissions.NewManager()
+	s.OpList, err = permissions.LoadOpList(serverPath + "ops.json")
+	text.DefaultLogger.LogError(err)
+	s.ChatManager = chat.NewManager(config.LocalChatRadius)
+	text.DefaultLogger.LogError(s.ChatManager.LoadMutes(serverPath + "mutes.json"))
+	s.EventManager = events.NewManager()
+	s.FormManager = forms.NewManager()
+	s.InteractionRegistry = interactions.NewRegistry()
+	s.CommandSignManager = NewCommandSignManager(s, config.CommandSignPattern)
+	s.LootManager = loot.NewManager()
+	text.DefaultLogger.LogError(lang.LoadDirectory(serverPath + "lang/"))
+	text.DefaultLogger.LogError(s.LootManager.LoadDirectory(serverPath + "loot_tables/"))
+	s.BlockPalettes = palette.NewManager()
+	for _, err := range s.BlockPalettes.LoadDirectory(serverPath + "block_palettes/") {
+		text.DefaultLogger.LogError(err)
+	}
+	s.FarmManager = farming.NewManager(s.LootManager, farming.DefaultRandomTickSpeed)
+	s.FarmManager.DropItemsFunction = s.dropFarmItems
+	s.FarmManager.RandomTickSpeedFunction = s.getRandomTickSpeed
+	s.registerFarming()
+	s.CombatManager = combat.NewManager(combat.DefaultCooldown)
+	s.EntitiesFunction = s.getMobTargets
+	s.KnockbackProfile = newKnockbackProfile(config)
+	s.heightRanges = newHeightRanges(config)
+	s.DamageOptions = combat.DamageOptions{
+		Criticals:    config.CriticalHits,
+		Sweeping:     config.SweepingAttacks,
+		Cooldown:     config.AttackCooldown,
+		Enchantments: config.EnchantmentDamage,
+	}
+	s.WindowManager = windows.NewManager()
+	s.registerContainers()
 	s.PluginManager = NewPluginManager(s)
 	s.QueryManager = query.NewManager()
+	s.QueryListener = gamespy.NewListener(gamespy.NewHandler())
+	s.RconListener = rcon.NewListener(config.RconPassword, s.DispatchCommand)
+	s.RconListener.ConnectFunction = func(addr net2.Addr) {
+		text.DefaultLogger.Info("RCON client", addr, "authenticated.")
+	}
+	s.RconListener.FailFunction = func(addr net2.Addr) {
+		text.DefaultLogger.Notice("RCON client", addr, "used a wrong password.")
+	}
+	// RCON commands are executed during the next tick like console commands, rather than on the goroutine of the connection.
+	s.RconListener.QueueFunction = func(function func()) {
+		s.scheduler.Schedule(function)
+	}
+
+	var loginWorkers = config.LoginWorkers
+	if loginWorkers <= 0 {
+		loginWorkers = runtime.NumCPU()
+	}
+	s.loginPool = utils.NewWorkerPool(loginWorkers, 64)
+	s.LoginCache = logincache.New(time.Duration(config.LoginCacheSeconds) * time.Second)
+	s.PackTransfers = packtransfer.New(time.Duration(config.PackResumeSeconds) * time.Second)
+	s.scheduler = tasks.NewScheduler(runtime.NumCPU())
+
+	var generationWorkers = config.GenerationWorkers
+	if generationWorkers <= 0 {
+		generationWorkers = runtime.NumCPU()
+	}
+	s.ChunkGenerationPool = chunkgen.NewPool(generationWorkers, config.MaxPendingChunks)
+	s.PistonManager = pistons.NewManager(s.scheduler, config.EnablePistons)
+	s.RedstoneEngine = redstone.NewEngine(s.scheduler)
+	s.registerRedstone()
+	s.registerCommandBlocks()
 
 	if config.UseEncryption {
 		var curve = elliptic.P384()
@@ -111,52 +350,213 @@ func NewServer(serverPath string, config *resources.GoMineConfig) *Server {
 // RegisterDefaultCommands registers all default commands of the server.
 func (server *Server) RegisterDefaultCommands() {
 	server.CommandManager.RegisterCommand(NewStop(server))
+	server.CommandManager.RegisterCommand(NewRestart(server))
+	server.CommandManager.RegisterCommand(NewSaveAll(server))
 	server.CommandManager.RegisterCommand(NewList(server))
 	server.CommandManager.RegisterCommand(NewPing())
 	server.CommandManager.RegisterCommand(NewTest(server))
+	server.CommandManager.RegisterCommand(NewChannel(server))
+	server.CommandManager.RegisterCommand(NewNick(server))
+	server.CommandManager.RegisterCommand(NewReload(server))
+	server.CommandManager.RegisterCommand(NewReloadPacks(server))
+	server.CommandManager.RegisterCommand(NewMute(server))
+	server.CommandManager.RegisterCommand(NewTempMute(server))
+	server.CommandManager.RegisterCommand(NewUnmute(server))
+	server.CommandManager.RegisterCommand(NewTeleport(server))
+	server.CommandManager.RegisterCommand(NewWorld(server))
+	server.CommandManager.RegisterCommand(NewTransfer(server))
+	server.CommandManager.RegisterCommand(NewKnockback(server))
+	server.CommandManager.RegisterCommand(NewOp(server))
+	server.CommandManager.RegisterCommand(NewDeop(server))
+	server.CommandManager.RegisterCommand(NewTitle(server))
+	server.CommandManager.RegisterCommand(NewPlaySound(server))
+	server.CommandManager.RegisterCommand(NewParticle(server))
+	server.CommandManager.RegisterCommand(NewTime(server))
+	server.CommandManager.RegisterCommand(NewWeather(server))
+	server.CommandManager.RegisterCommand(NewGameRule(server))
+	server.CommandManager.RegisterCommand(NewJoinInfo(server))
+	server.CommandManager.RegisterCommand(NewDifficulty(server))
+	server.CommandManager.RegisterCommand(NewGameMode(server))
+	server.CommandManager.RegisterCommand(NewMenu(server))
+
+	for name, level := range DefaultPermissionLevels {
+		server.PermissionManager.RegisterPermission(permissions.NewPermission(name, level))
+	}
 }
 
 // IsRunning checks if the server is running.
 func (server *Server) IsRunning() bool {
-	return server.isRunning
+	return atomic.LoadInt32(&server.isRunning) == 1
 }
 
 // Start starts the server and loads levels, plugins, resource packs etc.
 // Start returns an error if one occurred during starting.
 func (server *Server) Start() error {
-	if server.isRunning {
+	if server.IsRunning() {
 		return AlreadyStarted
 	}
+	if server.Console.Start() {
+		// The default output of the logger writes to Stdout, which would draw over the line being typed.
+		text.DefaultLogger.OutputFunctions[0] = func(message []byte) {
+			server.Console.Write(message)
+		}
+	}
 	text.DefaultLogger.Info("GoMine "+GoMineVersion+" is now starting...", "("+server.ServerPath+")")
 
+	server.loadLevelData()
+	server.MigratePlayerData()
 	server.LevelManager.SetDefaultLevel(worlds.NewLevel("world", server.ServerPath))
-	var dimension = worlds.NewDimension("overworld", server.LevelManager.GetDefaultLevel(), worlds.OverworldId)
-	dimension.SetChunkProvider(providers.NewAnvil(server.ServerPath + "worlds/world/overworld/region/"))
+	var dimension = worlds.NewDimension(levels.Overworld, server.LevelManager.GetDefaultLevel(), worlds.OverworldId)
+	var regionDirectory, _ = server.LevelProvider.GetRegionDirectory(levels.Overworld)
+	server.setAnvilProvider(dimension, regionDirectory)
+	server.SetEntityStore(dimension, server.ServerPath+"worlds/world/overworld/entities/")
+	server.SetBlockEntityStore(dimension, server.ServerPath+"worlds/world/overworld/blockentities/")
+	server.SetCustomBlockStore(dimension, server.ServerPath+"worlds/world/overworld/customblocks/")
+	server.SetWaterlogStore(dimension, server.ServerPath+"worlds/world/overworld/water/")
+	server.SetFarmStore(dimension, server.ServerPath+"worlds/world/overworld/farming/")
 	server.LevelManager.GetDefaultLevel().SetDefaultDimension(dimension)
-	dimension.SetGenerator(defaults.NewFlatGenerator())
+	server.SetGenerator(dimension, defaults.NewFlatGenerator())
+
+	var settings, err = levels.LoadSettings(server.GetWorldDirectory("world") + "settings.yml")
+	text.DefaultLogger.LogError(err)
+	server.worldSettings[server.LevelManager.GetDefaultLevel()] = settings
+	server.gameRules[server.LevelManager.GetDefaultLevel()] = gamerules.FromStrings(server.LevelData.GameRules)
+	server.weatherCycles[server.LevelManager.GetDefaultLevel()] = newWeatherCycle(server.LevelData, server.gameRules[server.LevelManager.GetDefaultLevel()])
+	server.levelData[server.LevelManager.GetDefaultLevel()] = server.LevelData
+	for _, name := range server.Config.Worlds {
+		if _, err := server.LoadWorld(name); err != nil {
+			text.DefaultLogger.Error("Failed to load world", name+":", err)
+		}
+	}
 
 	server.RegisterDefaultCommands()
 
-	server.PackManager.LoadResourcePacks() // Behavior packs may depend on resource packs, so always load resource packs first.
-	server.PackManager.LoadBehaviorPacks()
+	for uuid, packURL := range server.Config.ResourcePackURLs {
+		if err := server.PackManager.SetPackURL(uuid, packURL); err != nil {
+			text.DefaultLogger.Error("Failed to set URL of pack", uuid+":", err)
+		}
+	}
+	var packErrors = server.PackManager.LoadPacks()
+	for _, err := range packErrors {
+		text.DefaultLogger.Error("Failed to load pack:", err)
+	}
+	if len(packErrors) != 0 {
+		text.DefaultLogger.Warning(len(packErrors), "pack(s) failed to load and were left out of the pack stacks sent to players.")
+	}
+	for uuid := range server.Config.ResourcePackURLs {
+		if !server.PackManager.IsResourcePackLoaded(uuid) {
+			text.DefaultLogger.Warning("A URL is set for pack", uuid+", which is not a loaded resource pack.")
+		}
+	}
 
 	server.PluginManager.LoadPlugins()
 
-	server.isRunning = true
-	return server.NetworkAdapter.GetRakLibManager().Start(server.Config.ServerIp, int(server.Config.ServerPort))
+	if server.Config.AllowQuery && server.Config.QueryPort != server.Config.ServerPort {
+		server.QueryListener.SetInfo(server.GenerateQueryInfo())
+		if err := server.QueryListener.Listen(server.Config.ServerIp, server.Config.QueryPort); err != nil {
+			text.DefaultLogger.Error("Failed to start query listener:", err)
+		}
+	}
+
+	if server.Config.EnableMetrics {
+		if err := server.MetricsEndpoint.Listen(server.Config.MetricsAddress, server.Config.MetricsPort); err != nil {
+			text.DefaultLogger.Error("Failed to start metrics endpoint:", err)
+		}
+	}
+	if server.Config.EnableGeoIP {
+		var reader, err = geoip.Open(server.ServerPath + server.Config.GeoIPDatabase)
+		if err != nil {
+			text.DefaultLogger.Error("Failed to load GeoIP database:", err)
+		} else {
+			server.GeoIP = reader
+		}
+	}
+	if server.Config.EnableScreening {
+		server.Screener = server.newScreener()
+	}
+	server.TrustedProxies = server.newTrustedProxies()
+	if server.Config.EnableRcon {
+		if err := server.RconListener.Listen(server.Config.RconAddress, server.Config.RconPort); err != nil {
+			text.DefaultLogger.Error("Failed to start RCON:", err)
+		}
+	}
+
+	server.checkListenAddress()
+	atomic.StoreInt32(&server.isRunning, 1)
+	server.scheduleAutosave()
+	server.trapSignals()
+	return server.NetworkAdapter.GetRakLibManager().Start(server.getListenHost(), int(server.Config.ServerPort))
 }
 
-// Shutdown shuts down the server, saving and disabling everything.
+// Shutdown shuts down the server, kicking all players with the shutdown message of the configuration.
+// All worlds and player data are saved, plugins are disabled in reverse dependency order,
+// the log is flushed and the network listener is closed.
+// Shutting down a server that is not running does nothing.
+// Shutdown must be called on the goroutine ticking the server, like commands and scheduled tasks are,
+// so that no tick runs while the server is being shut down.
 func (server *Server) Shutdown() {
-	if !server.isRunning {
-		return
+	server.shutdown(server.Config.ShutdownMessage)
+}
+
+// Restart shuts down the server, kicking all players with the restart message of the configuration,
+// and starts the server again by replacing the process with a new process of the same executable and arguments.
+// An error is returned if the new process could not be started.
+// Like Shutdown, Restart must be called on the goroutine ticking the server.
+func (server *Server) Restart() error {
+	if !server.shutdown(server.Config.RestartMessage) {
+		return NotRunning
+	}
+	text.DefaultLogger.Notice("Restarting server...")
+	text.DefaultLogger.Wait()
+	return restartProcess()
+}
+
+// shutdown shuts down the server, kicking all players with the given message.
+// Returns false if the server was not running.
+func (server *Server) shutdown(message string) bool {
+	server.lifecycleMutex.Lock()
+	defer server.lifecycleMutex.Unlock()
+	if !server.IsRunning() {
+		return false
 	}
+	// The server stops ticking right away, so that the rest of the tick the server is shut down in is skipped.
+	atomic.StoreInt32(&server.isRunning, 0)
 	text.DefaultLogger.Info("Server is shutting down.")
+	for _, session := range server.SessionManager.GetSessions() {
+		server.SavePlayerData(session)
+		session.Kick(message, false, false)
+	}
+	server.loginPool.Close()
+	for _, level := range server.LevelManager.GetLevels() {
+		text.DefaultLogger.LogError(server.SaveWorld(level))
+	}
+	server.PluginManager.UnloadPlugins()
+	server.scheduler.Close()
+	server.ChunkGenerationPool.Close()
+	server.QueryListener.Close()
+	server.RconListener.Close()
+	server.MetricsEndpoint.Close()
+	server.NetworkAdapter.GetRakLibManager().Stop()
 
 	text.DefaultLogger.Notice("Server stopped.")
 	text.DefaultLogger.Wait()
+	if server.logFile != nil {
+		server.logFile.Sync()
+	}
+	server.Console.Close()
+	return true
+}
 
-	server.isRunning = false
+// trapSignals shuts down the server when the process receives an interrupt or termination signal.
+// The shutdown is queued to run during the next tick, on the goroutine ticking the server.
+func (server *Server) trapSignals() {
+	var signals = make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		signal.Stop(signals)
+		server.scheduler.Schedule(server.Shutdown)
+	}()
 }
 
 // GetMinecraftVersion returns the latest Minecraft game version.
@@ -207,9 +607,10 @@ func (server *Server) GetMaximumPlayers() uint {
 	return server.Config.MaximumPlayers
 }
 
-// Returns the Message Of The Day of the server.
+// Returns the Message Of The Day of the server, with its placeholders replaced.
+// The MOTD is the one set in the configuration, unless it was changed with SetMOTD.
 func (server *Server) GetMotd() string {
-	return server.Config.ServerMotd
+	return server.Pong.RenderMOTD()
 }
 
 // Returns the max view distance allowed by the server
@@ -228,6 +629,12 @@ func (server *Server) GetAllowedViewDistance(distance int32) int32 {
 	return maxViewDistance
 }
 
+// GetScheduler returns the scheduler of the server, which is ticked every server tick.
+// Plugins should schedule their tasks through their plugin, so tasks get cancelled when the plugin is unloaded.
+func (server *Server) GetScheduler() *tasks.Scheduler {
+	return server.scheduler
+}
+
 // GetCurrentTick returns the current tick the server is on.
 func (server *Server) GetCurrentTick() int64 {
 	return server.tick
@@ -236,7 +643,7 @@ func (server *Server) GetCurrentTick() int64 {
 // BroadcastMessageTo broadcasts a message to all receivers.
 func (server *Server) BroadcastMessageTo(receivers []*net.MinecraftSession, message ...interface{}) {
 	for _, session := range receivers {
-		session.SendMessage(message)
+		session.SendMessage(message...)
 	}
 	text.DefaultLogger.LogChat(message)
 }
@@ -244,11 +651,62 @@ func (server *Server) BroadcastMessageTo(receivers []*net.MinecraftSession, mess
 // Broadcast broadcasts a message to all players and the console in the server.
 func (server *Server) BroadcastMessage(message ...interface{}) {
 	for _, session := range server.SessionManager.GetSessions() {
-		session.SendMessage(message)
+		session.SendMessage(message...)
 	}
 	text.DefaultLogger.LogChat(message)
 }
 
+// BroadcastComponent broadcasts a text component to all players and the console in the server,
+// with its scores resolved for every player. The console gets translation keys translated to lang.DefaultLanguage.
+func (server *Server) BroadcastComponent(component *text.Component) {
+	for _, session := range server.SessionManager.GetSessions() {
+		session.SendComponent(server.ResolveScores(session, component))
+	}
+	text.DefaultLogger.LogChat(component.Translated(translateDefault))
+}
+
+// translateDefault translates the key with the given parameters to the default language,
+// for text that is not sent to a player, such as log messages.
+func translateDefault(key string, parameters []string) string {
+	var args = make([]interface{}, len(parameters))
+	for i, parameter := range parameters {
+		args[i] = parameter
+	}
+	return lang.Translate(lang.DefaultLanguage, key, args...)
+}
+
+// BroadcastMessageToWorld broadcasts a message to all players in any dimension of the world, and to the console.
+func (server *Server) BroadcastMessageToWorld(level *worlds.Level, message ...interface{}) {
+	server.BroadcastMessageTo(server.GetSessionsInWorld(level), message...)
+}
+
+// BroadcastMessageToDimension broadcasts a message to all players in the dimension, and to the console.
+func (server *Server) BroadcastMessageToDimension(dimension *worlds.Dimension, message ...interface{}) {
+	server.BroadcastMessageTo(server.GetSessionsInDimension(dimension), message...)
+}
+
+// GetSessionsInWorld returns the sessions of all spawned players in any dimension of the world.
+func (server *Server) GetSessionsInWorld(level *worlds.Level) []*net.MinecraftSession {
+	var sessions []*net.MinecraftSession
+	for _, session := range server.SessionManager.GetSessions() {
+		if dimension := session.GetPlayer().GetDimension(); session.HasSpawned() && dimension != nil && dimension.GetLevel() == level {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+// GetSessionsInDimension returns the sessions of all spawned players in the dimension.
+func (server *Server) GetSessionsInDimension(dimension *worlds.Dimension) []*net.MinecraftSession {
+	var sessions []*net.MinecraftSession
+	for _, session := range server.SessionManager.GetSessions() {
+		if session.HasSpawned() && session.GetPlayer().GetDimension() == dimension {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
 // GetPrivateKey returns the ECDSA private key of the server.
 func (server *Server) GetPrivateKey() *ecdsa.PrivateKey {
 	return server.privateKey
@@ -295,6 +753,27 @@ func (server *Server) GenerateQueryResult() query.Result {
 	return result
 }
 
+// GenerateQueryInfo returns the query data of the server,
+// as served by the dedicated query listener.
+func (server *Server) GenerateQueryInfo() gamespy.Info {
+	var result = server.GenerateQueryResult()
+	return gamespy.Info{
+		MOTD:           result.MOTD,
+		GameMode:       result.GameMode,
+		WorldName:      result.WorldName,
+		Version:        result.Version,
+		ServerEngine:   result.ServerEngine,
+		ListPlugins:    result.ListPlugins,
+		PluginNames:    result.PluginNames,
+		PlayerNames:    result.PlayerNames,
+		OnlinePlayers:  result.OnlinePlayers,
+		MaximumPlayers: result.MaximumPlayers,
+		Whitelist:      result.Whitelist,
+		Address:        result.Address,
+		Port:           result.Port,
+	}
+}
+
 // HandleRaw handles a raw packet, for instance a query packet.
 func (server *Server) HandleRaw(packet []byte, addr *net2.UDPAddr) {
 	if string(packet[0:2]) == string(query.Header) {
@@ -320,50 +799,163 @@ func (server *Server) HandleDisconnect(s *server.Session) {
 	if !ok {
 		return
 	}
+	server.interruptPackTransfer(session)
+	server.ChatManager.RemoveSession(session)
+	server.FormManager.RemoveSession(session)
+	server.removeTrade(session)
+	server.SavePlayerData(session)
+
+	server.fallMutex.Lock()
+	delete(server.fallHeights, session.GetName())
+	server.fallMutex.Unlock()
+	server.riseMutex.Lock()
+	delete(server.rises, session.GetName())
+	server.riseMutex.Unlock()
+	server.AbortBreak(session)
+	server.CombatManager.Remove(session.GetPlayer().GetRuntimeId())
+	server.WindowManager.Remove(session.GetName())
+	server.Dismount(session)
+	server.dropLeashes(session)
+	server.removeHealthScores(session)
+	server.removeDisguise(session)
+	server.removeTeleport(session)
 
 	if session.GetPlayer().Dimension != nil {
 		for _, online := range server.SessionManager.GetSessions() {
 			online.SendPlayerList(data.ListTypeRemove, map[string]protocol.PlayerListEntry{session.GetPlayer().GetName(): session.GetPlayer()})
 		}
+		server.PlayerList.Remove(session.GetName())
+		server.PlayerList.Forget(session.GetName())
+		server.removePlayerName(session)
 
 		session.GetPlayer().Close()
 		session.Connected = false
 
-		server.BroadcastMessage(text.Yellow+session.GetDisplayName(), "has left the server")
+		server.broadcastTranslation(text.Yellow, lang.PlayerLeft, session.GetDisplayName())
 	}
 }
 
 // GeneratePongData generates the GoRakLib pong data for the UnconnectedPong RakNet packet.
+// The MOTD, sub-MOTD, player counts and game mode may be changed by plugins through the pong builder of the server.
 func (server *Server) GeneratePongData() string {
-	return fmt.Sprint("MCPE;", server.GetMotd(), ";", info.LatestProtocol, ";", server.GetMinecraftNetworkVersion(), ";", server.SessionManager.GetSessionCount(), ";", server.Config.MaximumPlayers, ";", server.NetworkAdapter.GetRakLibManager().ServerId, ";", server.GetEngineName(), ";Creative;")
+	var gameMode = server.GetWorldSettings(server.LevelManager.GetDefaultLevel()).Gamemode
+	return server.Pong.Build(pong.Data{
+		GameMode:       strings.Title(levels.GameModeNames[gameMode]),
+		GameModeId:     gameMode,
+		Protocol:       int32(info.LatestProtocol),
+		Version:        server.GetMinecraftNetworkVersion(),
+		OnlinePlayers:  server.SessionManager.GetSessionCount(),
+		MaximumPlayers: int(server.Config.MaximumPlayers),
+		ServerId:       int64(server.NetworkAdapter.GetRakLibManager().ServerId),
+		Port:           server.GetIPv4Port(),
+		PortV6:         server.GetIPv6Port(),
+	}).String()
 }
 
 // Tick ticks the entire server. (Levels, scheduler, GoRakLib server etc.)
 // Internal. Not to be used by plugins.
 func (server *Server) Tick() {
-	if !server.isRunning {
+	if !server.IsRunning() {
 		return
 	}
+	var start = time.Now()
 	if server.tick%20 == 0 {
+		server.Metrics.UpdateRates()
 		server.QueryManager.SetQueryResult(server.GenerateQueryResult())
-		server.NetworkAdapter.GetRakLibManager().PongData = server.GeneratePongData()
+		server.QueryListener.SetInfo(server.GenerateQueryInfo())
+		server.ChunkGenerationPool.Reprioritize()
+		server.UpdatePongData()
+		server.tickKeepAlive()
+	}
+	if server.tick%10 == 0 {
+		server.closeDistantWindows()
+		server.updateHealthScores()
+	}
+	if server.tick%1200 == 0 {
+		server.LoginCache.Prune()
+		if server.Screener != nil {
+			server.Screener.Prune()
+		}
+	}
+	if server.tick%VillagerTickInterval == 0 {
+		server.tickVillagers()
+	}
+
+	server.scheduler.Tick()
+	if !server.IsRunning() {
+		// A task or command shut down the server.
+		return
 	}
 
 	for _, session := range server.SessionManager.GetSessions() {
 		session.Tick()
+		server.pickupItems(session)
+		server.tickEnvironment(session)
 	}
 
+	// Mobs and vehicles are ticked before their dimensions, so their movement is sent in the same tick.
+	server.tickMobs()
+	server.tickVehicles()
 	for _, level := range server.LevelManager.GetLevels() {
 		level.Tick()
 	}
+	server.tickWeather()
+	server.tickBlockEntities()
+	server.tickItemEntities()
+	server.FarmManager.Tick()
 
 	server.tick++
+	server.Metrics.RecordTick(time.Since(start))
 }
 
+// attemptReadCommand queues a command typed in the console, to be executed as the console sender during the next tick.
+// Commands are executed on the goroutine ticking the server, rather than on the goroutine reading the console.
 func (server *Server) attemptReadCommand(commandText string) {
-	args := strings.Split(commandText, " ")
-	commandName := args[0]
-	i := 1
+	server.scheduler.Schedule(func() {
+		if !server.DispatchCommand(server.ConsoleSender, commandText) {
+			text.DefaultLogger.Error("Command could not be found.")
+		}
+	})
+}
+
+// SendAvailableCommands sends all commands the session is allowed to execute to the session,
+// so that the client can autocomplete them, along with the names of the online players.
+func (server *Server) SendAvailableCommands(session *net.MinecraftSession) {
+	session.SendAvailableCommands(server.CommandManager.GetAvailableCommands(session))
+	server.sendPlayerNames(session)
+}
+
+// UpdateAvailableCommands sends the available commands to all sessions,
+// which should be done after commands have been registered or deregistered.
+func (server *Server) UpdateAvailableCommands() {
+	for _, session := range server.SessionManager.GetSessions() {
+		server.SendAvailableCommands(session)
+	}
+}
+
+// DispatchCommand executes the command in the given command text as the given sender,
+// and sends the output of the command to the sender as messages.
+// The command text may be prefixed with a slash.
+// Returns false if no command could be found in the command text.
+func (server *Server) DispatchCommand(sender commands.Sender, commandText string) bool {
+	var command, args, ok = server.ParseCommand(commandText)
+	if !ok {
+		return false
+	}
+	commands.SendOutput(sender, command.Execute(sender, args))
+	return true
+}
+
+// ParseCommand returns the command in the given command text and the arguments following it.
+// The command text may be prefixed with a slash.
+// A bool is returned indicating if a command could be found in the command text.
+func (server *Server) ParseCommand(commandText string) (*commands.Command, []string, bool) {
+	var args = commands.SplitArguments(commandText)
+	if len(args) == 0 {
+		return nil, nil, false
+	}
+	var commandName = strings.TrimLeft(args[0], "/")
+	var i = 1
 	for !server.CommandManager.IsCommandRegistered(commandName) {
 		if i == len(args) {
 			break
@@ -371,14 +963,22 @@ func (server *Server) attemptReadCommand(commandText string) {
 		commandName += " " + args[i]
 		i++
 	}
-	manager := server.CommandManager
-
-	if !manager.IsCommandRegistered(commandName) {
-		text.DefaultLogger.Error("Command could not be found.")
-		return
+	if !server.CommandManager.IsCommandRegistered(commandName) {
+		return nil, nil, false
 	}
-	args = args[i:]
+	var command, _ = server.CommandManager.GetCommand(commandName)
+	return command, args[i:], true
+}
 
-	command, _ := manager.GetCommand(commandName)
-	command.Execute(server, args)
+// newCompressionSettings returns the compression settings of the configuration.
+// Zlib is used if the configured algorithm is unknown.
+func newCompressionSettings(config *resources.GoMineConfig) compression.Settings {
+	var settings = compression.Settings{Level: config.CompressionLevel, Threshold: config.CompressionThreshold}
+	var algorithm, err = compression.FromName(config.CompressionAlgorithm)
+	if err != nil {
+		text.DefaultLogger.Error("Unknown compression algorithm", config.CompressionAlgorithm+", using zlib instead.")
+		algorithm = compression.Flate
+	}
+	settings.Algorithm = algorithm
+	return settings
 }
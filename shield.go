@@ -0,0 +1,51 @@
+package gomine
+
+import (
+	"time"
+
+	"github.com/BobbyShrd/gominetest/combat"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/golang/geo/r3"
+	data2 "github.com/irmine/worlds/entities/data"
+)
+
+// updateBlocking raises or lowers the shield of the player of the session.
+// Players block while sneaking with a shield in their main hand or offhand, unless their shield was disabled by an axe.
+// The blocking flag is broadcasted to the viewers of the player when it changes.
+func (server *Server) updateBlocking(session *net.MinecraftSession) {
+	var player = session.GetPlayer()
+	var blocking = player.IsSneaking() && server.holdsShield(session) && !server.CombatManager.IsShieldDisabled(player.GetRuntimeId())
+	if blocking == player.IsBlocking() {
+		return
+	}
+	player.SetBlocking(blocking)
+	player.SetEntityProperty(data2.EntityDataBlocking, blocking)
+	player.BroadcastUpdatedEntityData()
+	session.SendSetEntityData(player.GetRuntimeId(), player.GetEntityData())
+}
+
+// holdsShield checks if the player of the session holds a shield in its main hand or offhand.
+func (server *Server) holdsShield(session *net.MinecraftSession) bool {
+	var player = session.GetPlayer()
+	return combat.IsShield(player.GetHeldItem()) || combat.IsShield(player.GetOffhand().GetItem(0))
+}
+
+// blockWithShield checks if the player of the target session blocks an attack from the attacker position
+// with its shield, in which case the attack deals no damage. Attacks are only blocked from the front.
+// An axe disables the shield for the shield cooldown, after blocking the attack.
+func (server *Server) blockWithShield(target *net.MinecraftSession, attacker r3.Vector, item *items.Stack) bool {
+	var player = target.GetPlayer()
+	if !player.IsBlocking() || !combat.IsFacing(player.Position, player.Rotation.Yaw, attacker) {
+		return false
+	}
+	if combat.IsAxe(item) {
+		server.CombatManager.DisableShield(player.GetRuntimeId())
+		server.updateBlocking(target)
+		var ticks = int64(combat.ShieldCooldown/time.Second)*20 + 1
+		server.scheduler.ScheduleDelayed(func() {
+			server.updateBlocking(target)
+		}, ticks)
+	}
+	return true
+}
@@ -0,0 +1,201 @@
+package skins
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Sizes of skin images as RGBA bytes.
+const (
+	// BytesPerPixel is the amount of bytes of a pixel in skin and cape images.
+	BytesPerPixel = 4
+	// MaxAnimations is the maximum amount of animations of a skin.
+	MaxAnimations = 16
+	// MaxPersonaPieces is the maximum amount of persona pieces of a skin.
+	MaxPersonaPieces = 64
+	// DefaultGeometryName is the geometry of skins without resource patch.
+	DefaultGeometryName = "geometry.humanoid.custom"
+)
+
+var (
+	InvalidImageSize     = errors.New("skin image has an unsupported size")
+	InvalidImageLength   = errors.New("skin image data does not match its size")
+	InvalidCapeSize      = errors.New("cape image has an unsupported size")
+	InvalidAnimation     = errors.New("skin animation is invalid")
+	TooManyAnimations    = errors.New("skin has too many animations")
+	TooManyPersonaPieces = errors.New("skin has too many persona pieces")
+	InvalidSkinLength    = errors.New("legacy skin data has an unsupported length")
+)
+
+// skinSizes are the sizes in pixels skin images may have, as width and height.
+var skinSizes = map[[2]int]bool{
+	{64, 32}:   true,
+	{64, 64}:   true,
+	{128, 64}:  true,
+	{128, 128}: true,
+	{256, 128}: true,
+	{256, 256}: true,
+	{512, 512}: true,
+}
+
+// capeSizes are the sizes in pixels cape images may have. Skins without a cape have an empty cape image.
+var capeSizes = map[[2]int]bool{
+	{0, 0}:   true,
+	{64, 32}: true,
+}
+
+// AnimationType is the part of the skin an animation is played on.
+type AnimationType int
+
+const (
+	AnimationHead AnimationType = iota + 1
+	AnimationBody32
+	AnimationBody128
+)
+
+// Image is an RGBA image of a skin, cape or animation.
+type Image struct {
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Data   []byte `json:"data"`
+}
+
+// Validate checks if the image data holds exactly the pixels of the image size.
+func (image Image) Validate() error {
+	if image.Width < 0 || image.Height < 0 || len(image.Data) != image.Width*image.Height*BytesPerPixel {
+		return InvalidImageLength
+	}
+	return nil
+}
+
+// Animation is an animation of a skin, such as blinking eyes.
+type Animation struct {
+	Image Image         `json:"image"`
+	Type  AnimationType `json:"type"`
+	// Frames is the amount of frames of the animation, which are stacked vertically in the image.
+	Frames float32 `json:"frames"`
+	// Expression is the expression the animation is played for.
+	Expression int32 `json:"expression"`
+}
+
+// PersonaPiece is a piece of a skin made in the character creator, such as a hair style.
+type PersonaPiece struct {
+	Id        string `json:"id"`
+	Type      string `json:"type"`
+	PackId    string `json:"packId"`
+	ProductId string `json:"productId"`
+	Default   bool   `json:"default"`
+}
+
+// TintColor holds the colors of the persona pieces with the given type.
+type TintColor struct {
+	PieceType string   `json:"pieceType"`
+	Colors    []string `json:"colors"`
+}
+
+// Skin is the full skin of a player, as sent in the login and in player skin packets.
+type Skin struct {
+	Id        string `json:"id"`
+	PlayFabId string `json:"playFabId,omitempty"`
+	// ResourcePatch is the JSON mapping the default geometry of the skin to a geometry name.
+	ResourcePatch string `json:"resourcePatch"`
+	Image         Image  `json:"image"`
+	// Geometry is the JSON geometry data of the skin.
+	Geometry        string      `json:"geometry"`
+	GeometryVersion string      `json:"geometryVersion,omitempty"`
+	AnimationData   string      `json:"animationData,omitempty"`
+	Animations      []Animation `json:"animations,omitempty"`
+
+	CapeId        string `json:"capeId,omitempty"`
+	Cape          Image  `json:"cape"`
+	CapeOnClassic bool   `json:"capeOnClassic,omitempty"`
+
+	Premium bool `json:"premium,omitempty"`
+	Persona bool `json:"persona,omitempty"`
+	// Trusted is set by the server for skins of players logged into XBOX Live, which clients show without restrictions.
+	Trusted bool `json:"trusted,omitempty"`
+
+	ArmSize       string         `json:"armSize,omitempty"`
+	Color         string         `json:"color,omitempty"`
+	PersonaPieces []PersonaPiece `json:"personaPieces,omitempty"`
+	TintColors    []TintColor    `json:"tintColors,omitempty"`
+}
+
+// Validate checks if the skin and cape images have supported sizes and hold all their pixels,
+// and if the animations and persona pieces of the skin are valid.
+func (skin *Skin) Validate() error {
+	if !skinSizes[[2]int{skin.Image.Width, skin.Image.Height}] {
+		return InvalidImageSize
+	}
+	if err := skin.Image.Validate(); err != nil {
+		return err
+	}
+	if !capeSizes[[2]int{skin.Cape.Width, skin.Cape.Height}] {
+		return InvalidCapeSize
+	}
+	if err := skin.Cape.Validate(); err != nil {
+		return err
+	}
+	if len(skin.Animations) > MaxAnimations {
+		return TooManyAnimations
+	}
+	for _, animation := range skin.Animations {
+		if animation.Type < AnimationHead || animation.Type > AnimationBody128 || animation.Frames < 1 {
+			return InvalidAnimation
+		}
+		if err := animation.Image.Validate(); err != nil {
+			return err
+		}
+	}
+	if len(skin.PersonaPieces) > MaxPersonaPieces {
+		return TooManyPersonaPieces
+	}
+	return nil
+}
+
+// GetGeometryName returns the name of the geometry of the skin from its resource patch,
+// or the default geometry if the resource patch does not name one.
+func (skin *Skin) GetGeometryName() string {
+	var patch struct {
+		Geometry struct {
+			Default string `json:"default"`
+		} `json:"geometry"`
+	}
+	if err := json.Unmarshal([]byte(skin.ResourcePatch), &patch); err != nil || patch.Geometry.Default == "" {
+		return DefaultGeometryName
+	}
+	return patch.Geometry.Default
+}
+
+// FromLegacy returns the skin with the legacy skin fields: the skin and cape data as RGBA bytes,
+// the geometry name and the geometry data. The image sizes are derived from the length of the data.
+func FromLegacy(id string, skinData []byte, capeData []byte, geometryName string, geometryData string) (*Skin, error) {
+	var width, height, ok = legacySize(len(skinData))
+	if !ok {
+		return nil, InvalidSkinLength
+	}
+	var skin = &Skin{
+		Id:       id,
+		Image:    Image{Width: width, Height: height, Data: skinData},
+		Geometry: geometryData,
+	}
+	if geometryName != "" {
+		var patch, _ = json.Marshal(map[string]map[string]string{"geometry": {"default": geometryName}})
+		skin.ResourcePatch = string(patch)
+	}
+	if len(capeData) != 0 {
+		skin.Cape = Image{Width: 64, Height: 32, Data: capeData}
+	}
+	return skin, skin.Validate()
+}
+
+// legacySize returns the width and height of legacy skin data with the given length.
+// Every supported skin size has a different amount of pixels, so the size follows from the length.
+func legacySize(length int) (int, int, bool) {
+	for size := range skinSizes {
+		if size[0]*size[1]*BytesPerPixel == length {
+			return size[0], size[1], true
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,84 @@
+package skins
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestFromLegacy(t *testing.T) {
+	var skin, err = FromLegacy("Standard_Custom", make([]byte, 64*64*4), make([]byte, 64*32*4), "geometry.humanoid.customSlim", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skin.Image.Width != 64 || skin.Image.Height != 64 {
+		t.Errorf("expected 64x64 skin, got %vx%v", skin.Image.Width, skin.Image.Height)
+	}
+	if skin.GetGeometryName() != "geometry.humanoid.customSlim" {
+		t.Errorf("unexpected geometry name %v", skin.GetGeometryName())
+	}
+	if _, err := FromLegacy("", make([]byte, 100), nil, "", ""); err != InvalidSkinLength {
+		t.Errorf("expected invalid skin length, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var skin = &Skin{Image: Image{Width: 128, Height: 128, Data: make([]byte, 128*128*4)}}
+	if err := skin.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if skin.GetGeometryName() != DefaultGeometryName {
+		t.Errorf("expected default geometry, got %v", skin.GetGeometryName())
+	}
+
+	skin.Image.Data = skin.Image.Data[1:]
+	if err := skin.Validate(); err != InvalidImageLength {
+		t.Errorf("expected invalid image length, got %v", err)
+	}
+	skin.Image = Image{Width: 100, Height: 100, Data: make([]byte, 100*100*4)}
+	if err := skin.Validate(); err != InvalidImageSize {
+		t.Errorf("expected invalid image size, got %v", err)
+	}
+	skin.Image = Image{Width: 64, Height: 32, Data: make([]byte, 64*32*4)}
+	skin.Cape = Image{Width: 32, Height: 32, Data: make([]byte, 32*32*4)}
+	if err := skin.Validate(); err != InvalidCapeSize {
+		t.Errorf("expected invalid cape size, got %v", err)
+	}
+	skin.Cape = Image{}
+	skin.Animations = []Animation{{Image: Image{Width: 32, Height: 64, Data: make([]byte, 32*64*4)}, Type: AnimationHead, Frames: 0}}
+	if err := skin.Validate(); err != InvalidAnimation {
+		t.Errorf("expected invalid animation, got %v", err)
+	}
+}
+
+func TestStore(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "skins")
+	defer os.RemoveAll(dir)
+
+	var store = NewStore(dir)
+	var skin, _ = FromLegacy("Standard_Custom", make([]byte, 64*32*4), nil, "geometry.humanoid.custom", "{}")
+	skin.Trusted = true
+	if err := store.Save("steve", skin); err != nil {
+		t.Fatal(err)
+	}
+	var loaded, err = store.Load("steve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Id != skin.Id || len(loaded.Image.Data) != len(skin.Image.Data) || !loaded.Trusted {
+		t.Errorf("loaded skin does not match saved skin")
+	}
+
+	var past = time.Now().Add(-time.Hour).Truncate(time.Second)
+	os.Chtimes(store.GetPath("steve"), past, past)
+	if err := store.Save("steve", skin); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(store.GetPath("steve")); err != nil || !info.ModTime().Equal(past) {
+		t.Errorf("unchanged skin was written again")
+	}
+	if err := store.Remove("steve"); err != nil || store.Has("steve") {
+		t.Errorf("skin was not removed")
+	}
+}
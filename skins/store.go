@@ -0,0 +1,72 @@
+package skins
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Store persists skins as JSON files in a directory, keyed by player.
+// Skins are kept out of the player data, as their images are too large for YAML.
+type Store struct {
+	path string
+}
+
+// NewStore returns a new skin store storing skins in the directory at the given path.
+func NewStore(path string) *Store {
+	return &Store{path}
+}
+
+// GetPath returns the path of the skin file with the given key.
+func (store *Store) GetPath(key string) string {
+	return filepath.Join(store.path, key+".json")
+}
+
+// Has checks if a skin is stored with the given key.
+func (store *Store) Has(key string) bool {
+	var _, err = os.Stat(store.GetPath(key))
+	return err == nil
+}
+
+// Load loads the skin stored with the given key.
+// The skin is validated, so stored skins can always be sent to clients.
+func (store *Store) Load(key string) (*Skin, error) {
+	var file, err = ioutil.ReadFile(store.GetPath(key))
+	if err != nil {
+		return nil, err
+	}
+	var skin = &Skin{}
+	if err := json.Unmarshal(file, skin); err != nil {
+		return nil, err
+	}
+	return skin, skin.Validate()
+}
+
+// Save stores the skin with the given key, replacing any skin stored before.
+// Keys may contain slashes to store skins in sub directories.
+// The file is not written if it already holds the same skin.
+func (store *Store) Save(key string, skin *Skin) error {
+	var encoded, err = json.Marshal(skin)
+	if err != nil {
+		return err
+	}
+	var path = store.GetPath(key)
+	if stored, err := ioutil.ReadFile(path); err == nil && bytes.Equal(stored, encoded) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, encoded, 0644)
+}
+
+// Remove removes the skin stored with the given key.
+func (store *Store) Remove(key string) error {
+	var err = os.Remove(store.GetPath(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
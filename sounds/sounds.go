@@ -0,0 +1,143 @@
+package sounds
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Default volume and pitch of sounds played with a PlaySound packet.
+const (
+	DefaultVolume float32 = 1
+	DefaultPitch  float32 = 1
+)
+
+var (
+	soundMutex sync.RWMutex
+	// soundIds holds the IDs of level sound events, indexed by their name.
+	soundIds = map[string]uint32{
+		"item_use_on":        0,
+		"hit":                1,
+		"step":               2,
+		"fly":                3,
+		"jump":               4,
+		"break":              5,
+		"place":              6,
+		"heavy_step":         7,
+		"gallop":             8,
+		"fall":               9,
+		"ambient":            10,
+		"ambient_baby":       11,
+		"ambient_in_water":   12,
+		"breathe":            13,
+		"death":              14,
+		"death_in_water":     15,
+		"death_to_zombie":    16,
+		"hurt":               17,
+		"hurt_in_water":      18,
+		"mad":                19,
+		"boost":              20,
+		"bow":                21,
+		"squish_big":         22,
+		"squish_small":       23,
+		"fall_big":           24,
+		"fall_small":         25,
+		"splash":             26,
+		"fizz":               27,
+		"flap":               28,
+		"swim":               29,
+		"drink":              30,
+		"eat":                31,
+		"takeoff":            32,
+		"shake":              33,
+		"plop":               34,
+		"land":               35,
+		"saddle":             36,
+		"armor":              37,
+		"armor_stand_place":  38,
+		"add_chest":          39,
+		"throw":              40,
+		"attack":             41,
+		"attack_nodamage":    42,
+		"attack_strong":      43,
+		"warn":               44,
+		"shear":              45,
+		"milk":               46,
+		"thunder":            47,
+		"explode":            48,
+		"fire":               49,
+		"ignite":             50,
+		"fuse":               51,
+		"stare":              52,
+		"spawn":              53,
+		"shoot":              54,
+		"break_block":        55,
+		"launch":             56,
+		"blast":              57,
+		"large_blast":        58,
+		"twinkle":            59,
+		"remedy":             60,
+		"unfect":             61,
+		"levelup":            62,
+		"bow_hit":            63,
+		"bullet_hit":         64,
+		"extinguish_fire":    65,
+		"item_fizz":          66,
+		"chest_open":         67,
+		"chest_closed":       68,
+		"shulkerbox_open":    69,
+		"shulkerbox_closed":  70,
+		"enderchest_open":    71,
+		"enderchest_closed":  72,
+		"power_on":           73,
+		"power_off":          74,
+		"attach":             75,
+		"detach":             76,
+		"deny":               77,
+		"tripod":             78,
+		"pop":                79,
+		"drop_slot":          80,
+		"note":               81,
+		"thorns":             82,
+		"piston_in":          83,
+		"piston_out":         84,
+		"portal":             85,
+		"water":              86,
+		"lava_pop":           87,
+		"lava":               88,
+		"burp":               89,
+		"bucket_fill_water":  90,
+		"bucket_fill_lava":   91,
+		"bucket_empty_water": 92,
+		"bucket_empty_lava":  93,
+	}
+)
+
+// RegisterEvent registers the ID of a level sound event with the given name,
+// or overwrites the ID of the sound event with the same name.
+// Names are case insensitive.
+func RegisterEvent(name string, id uint32) {
+	soundMutex.Lock()
+	soundIds[strings.ToLower(name)] = id
+	soundMutex.Unlock()
+}
+
+// GetEvent returns the ID of the level sound event with the given name, and a bool indicating if it exists.
+func GetEvent(name string) (uint32, bool) {
+	soundMutex.RLock()
+	defer soundMutex.RUnlock()
+	var id, ok = soundIds[strings.ToLower(name)]
+	return id, ok
+}
+
+// GetEventNames returns the names of all registered level sound events, sorted alphabetically.
+func GetEventNames() []string {
+	soundMutex.RLock()
+	var names = make([]string, 0, len(soundIds))
+	for name := range soundIds {
+		names = append(names, name)
+	}
+	soundMutex.RUnlock()
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,24 @@
+package sounds
+
+import (
+	"testing"
+)
+
+func TestEvents(t *testing.T) {
+	if id, ok := GetEvent("Attack_Strong"); !ok || id != 43 {
+		t.Errorf("expected attack_strong to have ID 43, got %v", id)
+	}
+	if _, ok := GetEvent("custom"); ok {
+		t.Error("expected custom to not be registered")
+	}
+	RegisterEvent("Custom", 500)
+	if id, ok := GetEvent("custom"); !ok || id != 500 {
+		t.Errorf("expected custom to have ID 500, got %v", id)
+	}
+	var names = GetEventNames()
+	for i := 1; i < len(names); i++ {
+		if names[i-1] > names[i] {
+			t.Fatalf("expected sorted names, got %v before %v", names[i-1], names[i])
+		}
+	}
+}
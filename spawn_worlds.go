@@ -0,0 +1,62 @@
+package gomine
+
+import (
+	"strings"
+
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/text"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+)
+
+// GetSpawnWorld returns the world the player of the session spawns in when joining.
+// This is the spawn world of the permission group of the session, or the spawn world set in the config
+// if the group has none. Spawn worlds are written as "world", or "world:dimension" to spawn in
+// another dimension than the default dimension of the world.
+// An empty string is returned if the player spawns in the default world.
+func (server *Server) GetSpawnWorld(session *net.MinecraftSession) string {
+	if group := session.GetPermissionGroup(); group != nil {
+		if world, ok := server.Config.SpawnWorlds[group.GetName()]; ok && world != "" {
+			return world
+		}
+	}
+	return server.Config.SpawnWorld
+}
+
+// GetSpawnDimension returns the dimension and position the player of the session spawns at when joining.
+// Worlds that are not yet loaded get loaded. The spawn of the default world is returned
+// if the spawn world of the session could not be found.
+func (server *Server) GetSpawnDimension(session *net.MinecraftSession) (*worlds.Dimension, r3.Vector) {
+	var defaultLevel = server.LevelManager.GetDefaultLevel()
+	var spawnWorld = server.GetSpawnWorld(session)
+	if spawnWorld == "" {
+		return defaultLevel.GetDefaultDimension(), SpawnPosition
+	}
+	var dimension, err = server.resolveSpawnWorld(spawnWorld)
+	if err != nil {
+		text.DefaultLogger.Warning("Failed to find spawn world", spawnWorld+":", err)
+		return defaultLevel.GetDefaultDimension(), SpawnPosition
+	}
+	return dimension, server.GetWorldSpawn(dimension.GetLevel())
+}
+
+// resolveSpawnWorld returns the dimension of a spawn world written as "world" or "world:dimension".
+// The world gets loaded if it is not yet loaded.
+func (server *Server) resolveSpawnWorld(spawnWorld string) (*worlds.Dimension, error) {
+	var fragments = strings.SplitN(spawnWorld, ":", 2)
+	var level, err = server.LevelManager.GetLevel(fragments[0])
+	if err != nil {
+		if level, err = server.LoadWorld(fragments[0]); err != nil {
+			return nil, err
+		}
+	}
+	if len(fragments) == 1 {
+		return level.GetDefaultDimension(), nil
+	}
+	for _, dimension := range level.GetDimensions() {
+		if dimension.GetName() == fragments[1] {
+			return dimension, nil
+		}
+	}
+	return nil, UnknownDimension
+}
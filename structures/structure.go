@@ -0,0 +1,175 @@
+package structures
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/irmine/worlds/blocks"
+)
+
+// World is the world structures get placed in.
+// It is implemented by the server for every dimension.
+type World interface {
+	// GetBlockName returns the name of the block at the given position.
+	GetBlockName(position blocks.Position) string
+	// PlaceBlock places a block with the given name, legacy ID and data value at the given position.
+	PlaceBlock(position blocks.Position, name string, id int32, data byte)
+	// GetHeightRange returns the range of heights blocks can be placed at in the world.
+	GetHeightRange() levels.HeightRange
+}
+
+// Replaceable are the blocks structures may be placed over.
+var Replaceable = map[string]bool{
+	"air":        true,
+	"sapling":    true,
+	"leaves":     true,
+	"leaves2":    true,
+	"tallgrass":  true,
+	"deadbush":   true,
+	"vine":       true,
+	"snow_layer": true,
+}
+
+// Block is a block in a structure.
+type Block struct {
+	// Name is the name of the block.
+	Name string
+	// Id is the legacy block ID of the block.
+	Id int32
+	// Data is the data value of the block.
+	Data byte
+}
+
+// Offset is the position of a block in a structure, relative to the origin of the structure.
+type Offset struct {
+	X, Y, Z int32
+}
+
+// part is a block at an offset in a structure.
+type part struct {
+	offset   Offset
+	block    Block
+	optional bool
+}
+
+// Structure is a set of blocks that can be placed in a world at once.
+type Structure struct {
+	name  string
+	parts []part
+	index map[Offset]int
+}
+
+// New returns a new empty structure with the given name.
+func New(name string) *Structure {
+	return &Structure{name: name, index: make(map[Offset]int)}
+}
+
+// GetName returns the name of the structure.
+func (structure *Structure) GetName() string {
+	return structure.name
+}
+
+// Set sets a required block at the given offset, overwriting any block set there before.
+// A structure can only be placed if the world has room for all its required blocks.
+func (structure *Structure) Set(x, y, z int32, block Block) {
+	structure.set(Offset{x, y, z}, block, false)
+}
+
+// SetOptional sets an optional block at the given offset, unless a block was set there before.
+// Optional blocks, such as leaves, are only placed where the world has room for them,
+// and do not prevent the structure from being placed.
+func (structure *Structure) SetOptional(x, y, z int32, block Block) {
+	if _, ok := structure.index[Offset{x, y, z}]; ok {
+		return
+	}
+	structure.set(Offset{x, y, z}, block, true)
+}
+
+// set sets a block at the given offset.
+func (structure *Structure) set(offset Offset, block Block, optional bool) {
+	if i, ok := structure.index[offset]; ok {
+		structure.parts[i] = part{offset, block, optional}
+		return
+	}
+	structure.index[offset] = len(structure.parts)
+	structure.parts = append(structure.parts, part{offset, block, optional})
+}
+
+// Get returns the block at the given offset, and a bool indicating if any block was set there.
+func (structure *Structure) Get(x, y, z int32) (Block, bool) {
+	var i, ok = structure.index[Offset{x, y, z}]
+	if !ok {
+		return Block{}, false
+	}
+	return structure.parts[i].block, true
+}
+
+// GetBlockCount returns the amount of blocks in the structure.
+func (structure *Structure) GetBlockCount() int {
+	return len(structure.parts)
+}
+
+// CanPlace checks if the world has room for all required blocks of the structure at the given origin.
+func (structure *Structure) CanPlace(world World, origin blocks.Position) bool {
+	for _, part := range structure.parts {
+		if part.optional {
+			continue
+		}
+		var position, ok = offset(origin, part.offset, world.GetHeightRange())
+		if !ok || !Replaceable[world.GetBlockName(position)] {
+			return false
+		}
+	}
+	return true
+}
+
+// Place places the structure at the given origin.
+// Returns false and places nothing if the world has no room for the structure.
+func (structure *Structure) Place(world World, origin blocks.Position) bool {
+	if !structure.CanPlace(world, origin) {
+		return false
+	}
+	for _, part := range structure.parts {
+		var position, ok = offset(origin, part.offset, world.GetHeightRange())
+		if !ok || (part.optional && !Replaceable[world.GetBlockName(position)]) {
+			continue
+		}
+		world.PlaceBlock(position, part.block.Name, part.block.Id, part.block.Data)
+	}
+	return true
+}
+
+// offset returns the position of an offset relative to the origin.
+// A bool is returned which is false if the position would be outside of the height range of the world.
+func offset(origin blocks.Position, offset Offset, heights levels.HeightRange) (blocks.Position, bool) {
+	var y = int64(origin.Y) + int64(offset.Y)
+	if y < 0 || y < int64(heights.Min) || y >= int64(heights.Max) {
+		return origin, false
+	}
+	return blocks.NewPosition(origin.X+offset.X, uint32(y), origin.Z+offset.Z), true
+}
+
+// Template generates a structure, which may differ every time it is generated.
+type Template func(random *rand.Rand) *Structure
+
+var (
+	templateMutex sync.RWMutex
+	templates     = make(map[string]Template)
+)
+
+// RegisterTemplate registers a template with the given name,
+// or overwrites the template with the same name.
+func RegisterTemplate(name string, template Template) {
+	templateMutex.Lock()
+	templates[name] = template
+	templateMutex.Unlock()
+}
+
+// GetTemplate returns the template with the given name, and a bool indicating if it exists.
+func GetTemplate(name string) (Template, bool) {
+	templateMutex.RLock()
+	defer templateMutex.RUnlock()
+	var template, ok = templates[name]
+	return template, ok
+}
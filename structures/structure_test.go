@@ -0,0 +1,70 @@
+package structures
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/BobbyShrd/gominetest/levels"
+	"github.com/irmine/worlds/blocks"
+)
+
+type testWorld map[blocks.Position]string
+
+func (world testWorld) GetBlockName(position blocks.Position) string {
+	if name, ok := world[position]; ok {
+		return name
+	}
+	return "air"
+}
+
+func (world testWorld) PlaceBlock(position blocks.Position, name string, _ int32, _ byte) {
+	world[position] = name
+}
+
+func (world testWorld) GetHeightRange() levels.HeightRange {
+	return levels.HeightRange{Min: 0, Max: 256}
+}
+
+func TestPlace(t *testing.T) {
+	var structure = New("test")
+	structure.Set(0, 0, 0, Block{"log", 17, 0})
+	structure.SetOptional(1, 0, 0, Block{"leaves", 18, 0})
+	structure.SetOptional(0, 0, 0, Block{"leaves", 18, 0})
+
+	var world = testWorld{blocks.NewPosition(1, 5, 0): "stone"}
+	if !structure.Place(world, blocks.NewPosition(0, 5, 0)) {
+		t.Fatal("expected the structure to be placed")
+	}
+	if world[blocks.NewPosition(0, 5, 0)] != "log" {
+		t.Error("expected optional blocks not to overwrite required blocks")
+	}
+	if world[blocks.NewPosition(1, 5, 0)] != "stone" {
+		t.Error("expected optional blocks not to replace stone")
+	}
+
+	world[blocks.NewPosition(0, 6, 0)] = "stone"
+	if structure.Place(world, blocks.NewPosition(0, 6, 0)) {
+		t.Error("expected the structure not to be placed over stone")
+	}
+	if structure.Place(world, blocks.NewPosition(0, 256, 0)) {
+		t.Error("expected the structure not to be placed above the world")
+	}
+}
+
+func TestTrees(t *testing.T) {
+	var random = rand.New(rand.NewSource(1))
+	for _, name := range []string{OakTree, SpruceTree, BirchTree, JungleTree, AcaciaTree} {
+		var template, ok = GetTemplate(name)
+		if !ok {
+			t.Fatalf("expected template %v to be registered", name)
+		}
+		var tree = template(random)
+		if block, ok := tree.Get(0, 0, 0); !ok || (block.Name != "log" && block.Name != "log2") {
+			t.Errorf("expected %v to have a trunk at its origin", name)
+		}
+		var world = testWorld{}
+		if !tree.Place(world, blocks.NewPosition(0, 64, 0)) || len(world) != tree.GetBlockCount() {
+			t.Errorf("expected all blocks of %v to be placed in an empty world", name)
+		}
+	}
+}
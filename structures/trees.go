@@ -0,0 +1,110 @@
+package structures
+
+import (
+	"math/rand"
+)
+
+// Names of the default tree templates.
+const (
+	OakTree    = "oak_tree"
+	SpruceTree = "spruce_tree"
+	BirchTree  = "birch_tree"
+	JungleTree = "jungle_tree"
+	AcaciaTree = "acacia_tree"
+)
+
+// Tree is a tree with a straight trunk and a round canopy of leaves, like an oak tree.
+type Tree struct {
+	// Name is the name of the generated structures.
+	Name string
+	// Log is the block the trunk consists of.
+	Log Block
+	// Leaves is the block the canopy consists of.
+	Leaves Block
+	// MinHeight and MaxHeight are the bounds of the height of the trunk, inclusive.
+	MinHeight, MaxHeight int32
+}
+
+// Generate generates a tree with a random height, and randomly trimmed corners of the canopy.
+// The origin of the structure is the bottom of the trunk.
+func (tree Tree) Generate(random *rand.Rand) *Structure {
+	var structure = New(tree.Name)
+	var height = tree.MinHeight + random.Int31n(tree.MaxHeight-tree.MinHeight+1)
+	for y := int32(0); y < height; y++ {
+		structure.Set(0, y, 0, tree.Log)
+	}
+	for y := height - 3; y <= height; y++ {
+		var layer = y - height
+		var radius = 1 - layer/2
+		for x := -radius; x <= radius; x++ {
+			for z := -radius; z <= radius; z++ {
+				if abs(x) == radius && abs(z) == radius && (layer == 0 || random.Intn(2) == 0) {
+					continue
+				}
+				structure.SetOptional(x, y, z, tree.Leaves)
+			}
+		}
+	}
+	return structure
+}
+
+// ConiferousTree is a tree with a straight trunk and a cone of leaves, like a spruce tree.
+type ConiferousTree struct {
+	// Name is the name of the generated structures.
+	Name string
+	// Log is the block the trunk consists of.
+	Log Block
+	// Leaves is the block the canopy consists of.
+	Leaves Block
+	// MinHeight and MaxHeight are the bounds of the height of the trunk, inclusive.
+	MinHeight, MaxHeight int32
+}
+
+// Generate generates a coniferous tree with a random height and width.
+// The origin of the structure is the bottom of the trunk.
+func (tree ConiferousTree) Generate(random *rand.Rand) *Structure {
+	var structure = New(tree.Name)
+	var height = tree.MinHeight + random.Int31n(tree.MaxHeight-tree.MinHeight+1)
+	var bottom = 1 + random.Int31n(2)
+	var maxRadius = 2 + random.Int31n(2)
+	for y := int32(0); y < height; y++ {
+		structure.Set(0, y, 0, tree.Log)
+	}
+
+	var radius = int32(0)
+	for y := height; y >= bottom; y-- {
+		for x := -radius; x <= radius; x++ {
+			for z := -radius; z <= radius; z++ {
+				if radius > 0 && abs(x) == radius && abs(z) == radius {
+					continue
+				}
+				structure.SetOptional(x, y, z, tree.Leaves)
+			}
+		}
+		if radius >= maxRadius {
+			radius = 1
+		} else {
+			radius++
+		}
+	}
+	return structure
+}
+
+// abs returns the absolute value of x.
+func abs(x int32) int32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func init() {
+	var log = func(data byte) Block { return Block{"log", 17, data} }
+	var leaves = func(data byte) Block { return Block{"leaves", 18, data} }
+
+	RegisterTemplate(OakTree, Tree{OakTree, log(0), leaves(0), 4, 6}.Generate)
+	RegisterTemplate(SpruceTree, ConiferousTree{SpruceTree, log(1), leaves(1), 6, 9}.Generate)
+	RegisterTemplate(BirchTree, Tree{BirchTree, log(2), leaves(2), 5, 7}.Generate)
+	RegisterTemplate(JungleTree, Tree{JungleTree, log(3), leaves(3), 4, 10}.Generate)
+	RegisterTemplate(AcaciaTree, Tree{AcaciaTree, Block{"log2", 162, 0}, Block{"leaves2", 161, 0}, 5, 7}.Generate)
+}
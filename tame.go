@@ -0,0 +1,131 @@
+package gomine
+
+import (
+	"math/rand"
+
+	"github.com/BobbyShrd/gominetest/ai"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/irmine/worlds"
+	data2 "github.com/irmine/worlds/entities/data"
+)
+
+const (
+	// TameChance is the chance of 1 in TameChance that feeding a taming item to a mob tames it.
+	TameChance = 3
+
+	// mobNamespace is the metadata namespace the state of mobs, such as their owner, is saved in.
+	mobNamespace = "gomine"
+)
+
+// tamingItems holds the item that tames each tameable mob, by legacy entity type ID.
+var tamingItems = map[uint32]string{
+	14: "minecraft:bone",
+	22: "minecraft:cod",
+	30: "minecraft:wheat_seeds",
+	75: "minecraft:cod",
+}
+
+// RegisterTamingItem makes mobs with the entity type tameable by feeding them the item with the given string ID.
+// Only mobs spawned after registering get the behaviors of tameable mobs.
+func RegisterTamingItem(entityType uint32, itemId string) {
+	tamingItems[entityType] = itemId
+}
+
+// IsTameable checks if mobs with the entity type can be tamed.
+func IsTameable(entityType uint32) bool {
+	var _, ok = tamingItems[entityType]
+	return ok
+}
+
+// interactPet handles the player of the session using the item on a mob. Wild tameable mobs fed with their
+// taming item get tamed by chance, and tamed mobs sit down or stand up when their owner uses anything but a lead on them.
+// Returns false if nothing happened.
+func (server *Server) interactPet(session *net.MinecraftSession, dimension *worlds.Dimension, mob *ai.Mob, item *items.Stack) bool {
+	var entity = mob.GetBody().(mobBody)
+	var tamingItem, ok = tamingItems[entity.GetEntityType()]
+	if !ok {
+		return false
+	}
+	if mob.IsTamed() {
+		if mob.GetOwner() != session.GetPlayer().GetUUID().String() || (item != nil && item.GetId() == LeadItemId) {
+			return false
+		}
+		server.SetSitting(mob, !mob.IsSitting())
+		return true
+	}
+	if item == nil || item.Count <= 0 || item.GetId() != tamingItem {
+		return false
+	}
+	if !server.IsCreative(session) {
+		server.consumeHeldItem(session)
+	}
+	var event = NewTameEvent(session, entity.PersistentEntity, rand.Intn(TameChance) == 0)
+	server.EventManager.Emit(event)
+	if event.IsCancelled() || !event.Tamed {
+		entity.BroadcastEvent(EntityEventTameFail, 0)
+		return true
+	}
+	server.Tame(mob, session)
+	entity.BroadcastEvent(EntityEventTameSuccess, 0)
+	return true
+}
+
+// Tame makes the player of the session the owner of the mob, and makes the mob sit.
+// The owner is saved with the entity of the mob.
+func (server *Server) Tame(mob *ai.Mob, session *net.MinecraftSession) {
+	var entity = mob.GetBody().(mobBody)
+	mob.SetOwner(session.GetPlayer().GetUUID().String())
+	entity.GetMetadata().SetPersistent(mobNamespace, "owner", mob.GetOwner())
+	entity.SetEntityProperty(data2.EntityDataTamed, true)
+	entity.SetEntityProperty(data2.EntityDataOwner, int64(session.GetPlayer().GetRuntimeId()))
+	server.SetSitting(mob, true)
+}
+
+// SetSitting makes the tamed mob sit down or stand up, and broadcasts it to the viewers of the mob.
+func (server *Server) SetSitting(mob *ai.Mob, sitting bool) {
+	var entity = mob.GetBody().(mobBody)
+	mob.SetSitting(sitting)
+	entity.GetMetadata().SetPersistent(mobNamespace, "sitting", sitting)
+	entity.SetEntityProperty(data2.EntityDataSitting, sitting)
+	entity.BroadcastUpdatedEntityData()
+}
+
+// loadOwnership restores the owner and sitting state saved with the entity of the mob.
+func loadOwnership(mob *ai.Mob) {
+	var entity = mob.GetBody().(mobBody)
+	var owner, ok = entity.GetMetadata().GetString(mobNamespace, "owner")
+	if !ok || owner == "" {
+		return
+	}
+	var sitting, _ = entity.GetMetadata().GetBool(mobNamespace, "sitting")
+	mob.SetOwner(owner)
+	mob.SetSitting(sitting)
+	entity.SetEntityProperty(data2.EntityDataTamed, true)
+	entity.SetEntityProperty(data2.EntityDataSitting, sitting)
+}
+
+// getOwnerFunction returns the function looking up the owners of tamed mobs in the dimension by UUID.
+// Owners are only found while they are spawned in the dimension.
+func (server *Server) getOwnerFunction(dimension *worlds.Dimension) func(owner string) (ai.Target, bool) {
+	return func(owner string) (ai.Target, bool) {
+		for _, session := range server.SessionManager.GetSessions() {
+			var player = session.GetPlayer()
+			if session.HasSpawned() && player.GetDimension() == dimension && player.GetUUID().String() == owner {
+				return player, true
+			}
+		}
+		return nil, false
+	}
+}
+
+// isOwnPet checks if the entity with the given runtime ID is a mob tamed by the player of the session.
+// Players can not hurt their own pets.
+func (server *Server) isOwnPet(session *net.MinecraftSession, runtimeId uint64) bool {
+	var dimension = session.GetPlayer().GetDimension()
+	if dimension == nil {
+		return false
+	}
+	var mob, ok = server.GetMobManager(dimension).GetMob(runtimeId)
+	return ok && mob.GetOwner() == session.GetPlayer().GetUUID().String()
+}
@@ -0,0 +1,158 @@
+package tasks
+
+import (
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/utils"
+)
+
+// Scheduler is a struct executing tasks driven by the server tick.
+// Synchronous tasks get executed on the goroutine ticking the scheduler,
+// while asynchronous tasks get executed on a pool of workers.
+// Plugins should use the scheduler, rather than spawning their own goroutines.
+type Scheduler struct {
+	mutex   sync.Mutex
+	tick    int64
+	tasks   []*Task
+	pending []*Task
+	pool    *utils.WorkerPool
+}
+
+// NewScheduler returns a new scheduler with the given amount of workers for asynchronous tasks.
+func NewScheduler(asyncWorkers int) *Scheduler {
+	return &Scheduler{pool: utils.NewWorkerPool(asyncWorkers, 256)}
+}
+
+// GetCurrentTick returns the amount of times the scheduler was ticked.
+func (scheduler *Scheduler) GetCurrentTick() int64 {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	return scheduler.tick
+}
+
+// Schedule schedules a task to be executed during the next tick.
+func (scheduler *Scheduler) Schedule(function func()) *Task {
+	return scheduler.schedule(function, 0, 0, false)
+}
+
+// ScheduleDelayed schedules a task to be executed after the given amount of ticks.
+func (scheduler *Scheduler) ScheduleDelayed(function func(), delay int64) *Task {
+	return scheduler.schedule(function, delay, 0, false)
+}
+
+// ScheduleRepeating schedules a task to be executed after the given delay in ticks,
+// and every period ticks after that until cancelled.
+func (scheduler *Scheduler) ScheduleRepeating(function func(), delay int64, period int64) *Task {
+	if period < 1 {
+		period = 1
+	}
+	return scheduler.schedule(function, delay, period, false)
+}
+
+// ScheduleAsync schedules a task to be executed on a worker as soon as possible.
+// The task is cancelled if the workers can not take it, because the queue is full or the scheduler was closed.
+func (scheduler *Scheduler) ScheduleAsync(function func()) *Task {
+	var task = newTask(function, 0, 0, true)
+	if scheduler.pool.Submit(task.run) != nil {
+		task.Cancel()
+	}
+	return task
+}
+
+// ScheduleDelayedAsync schedules a task to be executed on a worker after the given amount of ticks.
+func (scheduler *Scheduler) ScheduleDelayedAsync(function func(), delay int64) *Task {
+	return scheduler.schedule(function, delay, 0, true)
+}
+
+// ScheduleRepeatingAsync schedules a task to be executed on a worker after the given delay in ticks,
+// and every period ticks after that until cancelled.
+func (scheduler *Scheduler) ScheduleRepeatingAsync(function func(), delay int64, period int64) *Task {
+	if period < 1 {
+		period = 1
+	}
+	return scheduler.schedule(function, delay, period, true)
+}
+
+// schedule adds a new task to the tasks being added during the next tick.
+func (scheduler *Scheduler) schedule(function func(), delay int64, period int64, async bool) *Task {
+	if delay < 0 {
+		delay = 0
+	}
+	scheduler.mutex.Lock()
+	var task = newTask(function, scheduler.tick+delay, period, async)
+	scheduler.pending = append(scheduler.pending, task)
+	scheduler.mutex.Unlock()
+	return task
+}
+
+// GetTaskCount returns the amount of tasks waiting to be executed.
+func (scheduler *Scheduler) GetTaskCount() int {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	return len(scheduler.tasks) + len(scheduler.pending)
+}
+
+// Tick executes all tasks that are due.
+// Tasks scheduled during the tick will be executed in the next tick at the earliest.
+func (scheduler *Scheduler) Tick() {
+	scheduler.mutex.Lock()
+	var tick = scheduler.tick
+	scheduler.tasks = append(scheduler.tasks, scheduler.pending...)
+	scheduler.pending = nil
+	var tasks = scheduler.tasks
+	scheduler.tick++
+	scheduler.mutex.Unlock()
+
+	var remaining = tasks[:0]
+	for _, task := range tasks {
+		if task.IsCancelled() {
+			continue
+		}
+		if task.nextRun > tick {
+			remaining = append(remaining, task)
+			continue
+		}
+		if task.async {
+			var err = scheduler.pool.Submit(task.run)
+			if err == utils.QueueFull {
+				// The task is tried again during the next tick, once the workers caught up.
+				remaining = append(remaining, task)
+				continue
+			}
+			if err != nil {
+				task.Cancel()
+				continue
+			}
+		} else {
+			task.run()
+		}
+		if task.IsRepeating() {
+			task.nextRun = tick + task.period
+			remaining = append(remaining, task)
+		}
+	}
+
+	scheduler.mutex.Lock()
+	scheduler.tasks = remaining
+	scheduler.mutex.Unlock()
+}
+
+// CancelAll cancels all tasks waiting to be executed.
+func (scheduler *Scheduler) CancelAll() {
+	scheduler.mutex.Lock()
+	for _, task := range scheduler.tasks {
+		task.Cancel()
+	}
+	for _, task := range scheduler.pending {
+		task.Cancel()
+	}
+	scheduler.tasks = nil
+	scheduler.pending = nil
+	scheduler.mutex.Unlock()
+}
+
+// Close cancels all tasks and waits for asynchronous tasks being executed to finish.
+func (scheduler *Scheduler) Close() {
+	scheduler.CancelAll()
+	scheduler.pool.Close()
+}
@@ -0,0 +1,51 @@
+package tasks
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestScheduler(t *testing.T) {
+	var scheduler = NewScheduler(2)
+	var delayed, repeated int64
+
+	scheduler.ScheduleDelayed(func() {
+		delayed++
+	}, 5)
+	var repeating = scheduler.ScheduleRepeating(func() {
+		repeated++
+	}, 0, 2)
+	var cancelled = scheduler.ScheduleDelayed(func() {
+		t.Error("cancelled task was executed")
+	}, 1)
+	cancelled.Cancel()
+
+	for i := 0; i < 10; i++ {
+		scheduler.Tick()
+	}
+	repeating.Cancel()
+	scheduler.Tick()
+	scheduler.Tick()
+
+	if delayed != 1 {
+		t.Error("expected delayed task to be executed once, got", delayed)
+	}
+	if repeated != 5 {
+		t.Error("expected repeating task to be executed 5 times, got", repeated)
+	}
+	if scheduler.GetTaskCount() != 0 {
+		t.Error("expected no tasks left, got", scheduler.GetTaskCount())
+	}
+
+	var async int64
+	scheduler.ScheduleAsync(func() {
+		atomic.AddInt64(&async, 1)
+	})
+	scheduler.Close()
+	if async != 1 {
+		t.Error("expected async task to be executed")
+	}
+	if task := scheduler.ScheduleAsync(func() {}); !task.IsCancelled() {
+		t.Error("expected async task scheduled after closing to be cancelled")
+	}
+}
@@ -0,0 +1,67 @@
+package tasks
+
+import (
+	"sync/atomic"
+)
+
+// Task is a handle of a function scheduled to be executed.
+// Tasks can be cancelled at any time using their handle,
+// preventing any further executions of the function.
+type Task struct {
+	function func()
+	async    bool
+	period   int64
+	nextRun  int64
+
+	cancelled int32
+	finished  int32
+}
+
+// newTask returns a new task executing the function at the given tick.
+// The task gets executed every period ticks if the period is larger than 0.
+func newTask(function func(), nextRun int64, period int64, async bool) *Task {
+	return &Task{function: function, async: async, period: period, nextRun: nextRun}
+}
+
+// Cancel cancels the task, preventing it from being executed again.
+// Tasks that are currently being executed will finish executing.
+func (task *Task) Cancel() {
+	atomic.StoreInt32(&task.cancelled, 1)
+}
+
+// IsCancelled checks if the task was cancelled.
+func (task *Task) IsCancelled() bool {
+	return atomic.LoadInt32(&task.cancelled) == 1
+}
+
+// IsFinished checks if the task has been executed for the last time.
+// Repeating tasks are never finished, unless cancelled.
+func (task *Task) IsFinished() bool {
+	return atomic.LoadInt32(&task.finished) == 1
+}
+
+// IsActive checks if the task will still be executed in the future.
+func (task *Task) IsActive() bool {
+	return !task.IsCancelled() && !task.IsFinished()
+}
+
+// IsRepeating checks if the task is executed repeatedly.
+func (task *Task) IsRepeating() bool {
+	return task.period > 0
+}
+
+// IsAsync checks if the task is executed outside of the server tick.
+func (task *Task) IsAsync() bool {
+	return task.async
+}
+
+// run executes the function of the task, unless the task was cancelled.
+func (task *Task) run() {
+	if task.IsCancelled() {
+		return
+	}
+	task.function()
+	if !task.IsRepeating() {
+		atomic.StoreInt32(&task.finished, 1)
+	}
+}
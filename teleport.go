@@ -0,0 +1,72 @@
+package gomine
+
+import (
+	"time"
+
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+)
+
+const (
+	// TeleportTimeout is the time the movement of a teleported player is ignored at most, until the client moves to the teleport position.
+	TeleportTimeout = time.Second * 3
+	// teleportAcceptDistance is the distance to the teleport position within which movement of the client confirms the teleport.
+	teleportAcceptDistance = 2
+)
+
+// pendingTeleport is a teleport the client did not move to yet.
+type pendingTeleport struct {
+	position r3.Vector
+	time     time.Time
+}
+
+// Teleport teleports the player of the session to the position in the dimension.
+// The player is teleported within its current dimension if the dimension is nil or the dimension it is in,
+// and is sent to the other dimension otherwise.
+// Movement the client sent before it got teleported is ignored, and the player does not take fall damage from the teleport.
+func (server *Server) Teleport(session *net.MinecraftSession, position r3.Vector, dimension *worlds.Dimension) {
+	if dimension == nil {
+		dimension = session.GetPlayer().GetDimension()
+	}
+	server.TransferDimension(session, dimension, position)
+}
+
+// resetMovement resets the movement validation of the player of the session after it gets teleported to the position.
+func (server *Server) resetMovement(session *net.MinecraftSession, position r3.Vector) {
+	server.fallMutex.Lock()
+	delete(server.fallHeights, session.GetName())
+	server.fallMutex.Unlock()
+
+	server.riseMutex.Lock()
+	delete(server.rises, session.GetName())
+	server.riseMutex.Unlock()
+
+	server.teleportMutex.Lock()
+	server.teleports[session.GetName()] = pendingTeleport{position, time.Now()}
+	server.teleportMutex.Unlock()
+}
+
+// isMovementOutdated checks if the movement of the player of the session to the position was sent before the client got teleported,
+// in which case the movement should be ignored.
+// The teleport is confirmed once the client moves close to the teleport position, or when the teleport timeout passed.
+func (server *Server) isMovementOutdated(session *net.MinecraftSession, position r3.Vector) bool {
+	server.teleportMutex.Lock()
+	defer server.teleportMutex.Unlock()
+	var teleport, ok = server.teleports[session.GetName()]
+	if !ok {
+		return false
+	}
+	if position.Sub(teleport.position).Norm() <= teleportAcceptDistance || time.Since(teleport.time) > TeleportTimeout {
+		delete(server.teleports, session.GetName())
+		return false
+	}
+	return true
+}
+
+// removeTeleport forgets the pending teleport of a player leaving the server.
+func (server *Server) removeTeleport(session *net.MinecraftSession) {
+	server.teleportMutex.Lock()
+	delete(server.teleports, session.GetName())
+	server.teleportMutex.Unlock()
+}
@@ -0,0 +1,270 @@
+package text
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Component is a message built from styled parts, which can be rendered
+// both to legacy §-codes and to Bedrock rawtext JSON.
+// Components are built by chaining calls, each of which returns the component itself:
+//
+//	text.NewComponent().Color(text.Red).Bold().Text("Warning: ").Reset().Translate("commands.generic.unknown", "foo")
+type Component struct {
+	color   string
+	formats []string
+	parts   []part
+}
+
+// part is a single styled part of a component, holding either text, a translation key, a score or a selector.
+type part struct {
+	style     string
+	text      string
+	translate string
+	with      []string
+	score     *rawScore
+	selector  string
+}
+
+// rawText is the JSON representation of a component in Bedrock rawtext format.
+type rawText struct {
+	RawText []rawTextPart `json:"rawtext"`
+}
+
+// rawTextPart is a single part of rawtext JSON.
+type rawTextPart struct {
+	Text      string    `json:"text,omitempty"`
+	Translate string    `json:"translate,omitempty"`
+	With      *rawTexts `json:"with,omitempty"`
+	Score     *rawScore `json:"score,omitempty"`
+	Selector  string    `json:"selector,omitempty"`
+}
+
+// rawScore is the score of an entry in an objective, as used in rawtext JSON.
+type rawScore struct {
+	Name      string `json:"name"`
+	Objective string `json:"objective"`
+}
+
+// rawTexts holds the translation parameters of a rawtext part.
+type rawTexts struct {
+	RawText []rawTextPart `json:"rawtext"`
+}
+
+// NewComponent returns a new empty component without any style.
+func NewComponent() *Component {
+	return &Component{}
+}
+
+// Color sets the color of all text added after it, such as text.Red.
+// Setting a color clears the formatting, as it does for legacy codes.
+func (component *Component) Color(color string) *Component {
+	component.color = color
+	component.formats = nil
+	return component
+}
+
+// Bold makes all text added after it bold.
+func (component *Component) Bold() *Component {
+	return component.format(Bold)
+}
+
+// Italic makes all text added after it italic.
+func (component *Component) Italic() *Component {
+	return component.format(Italic)
+}
+
+// Underlined makes all text added after it underlined.
+func (component *Component) Underlined() *Component {
+	return component.format(Underlined)
+}
+
+// StrikeThrough makes all text added after it struck through.
+func (component *Component) StrikeThrough() *Component {
+	return component.format(StrikeThrough)
+}
+
+// Obfuscated makes all text added after it obfuscated.
+func (component *Component) Obfuscated() *Component {
+	return component.format(Obfuscated)
+}
+
+// Reset clears the color and formatting of all text added after it.
+func (component *Component) Reset() *Component {
+	component.color = ""
+	component.formats = nil
+	return component
+}
+
+// Text adds text in the current style.
+func (component *Component) Text(text string) *Component {
+	component.parts = append(component.parts, part{style: component.style(), text: text})
+	return component
+}
+
+// Newline adds a line break.
+func (component *Component) Newline() *Component {
+	component.parts = append(component.parts, part{text: "\n"})
+	return component
+}
+
+// Translate adds a translation key in the current style, which gets translated by the client
+// to its own language with the given parameters, such as "commands.generic.unknown".
+func (component *Component) Translate(key string, parameters ...string) *Component {
+	component.parts = append(component.parts, part{style: component.style(), translate: key, with: parameters})
+	return component
+}
+
+// Score adds the score of the entry in the objective in the current style.
+// The entry * stands for the player receiving the message.
+// Scores are filled in by ResolveScores, and otherwise left to clients, which only know the scores of displayed objectives.
+// Clients that do not support rawtext do not show unresolved scores.
+func (component *Component) Score(entry string, objective string) *Component {
+	component.parts = append(component.parts, part{style: component.style(), score: &rawScore{entry, objective}})
+	return component
+}
+
+// Selector adds the names of the entities matching the selector in the current style, such as "@p".
+// Clients that do not support rawtext are shown the selector itself.
+func (component *Component) Selector(selector string) *Component {
+	component.parts = append(component.parts, part{style: component.style(), selector: selector})
+	return component
+}
+
+// Command adds a command for the player to run, such as "/menu 1", shown underlined in the current color.
+// Chat has no click events, so players type the command shown to act on the message.
+func (component *Component) Command(command string) *Component {
+	var style = component.style()
+	if !strings.Contains(style, Underlined) {
+		style += Underlined
+	}
+	component.parts = append(component.parts, part{style: style, text: command})
+	return component
+}
+
+// ResolveScores returns a copy of the component with the scores filled in by the resolver,
+// which returns the score of the entry in the objective and a bool indicating if it has one.
+// The entry * is resolved as the receiver. Scores the resolver does not have are kept.
+func (component *Component) ResolveScores(receiver string, resolver func(entry string, objective string) (int, bool)) *Component {
+	var resolved = &Component{color: component.color, formats: component.formats, parts: make([]part, len(component.parts))}
+	copy(resolved.parts, component.parts)
+	for i, p := range resolved.parts {
+		if p.score == nil {
+			continue
+		}
+		var entry = p.score.Name
+		if entry == "*" {
+			entry = receiver
+		}
+		if value, ok := resolver(entry, p.score.Objective); ok {
+			resolved.parts[i] = part{style: p.style, text: strconv.Itoa(value)}
+		}
+	}
+	return resolved
+}
+
+// Append adds all parts of another component, keeping their style.
+func (component *Component) Append(other *Component) *Component {
+	component.parts = append(component.parts, other.parts...)
+	return component
+}
+
+// IsTranslated checks if the component holds any translation keys.
+func (component *Component) IsTranslated() bool {
+	for _, p := range component.parts {
+		if p.translate != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTranslationParameters returns the parameters of all translation keys in the component, in order.
+func (component *Component) GetTranslationParameters() []string {
+	var parameters []string
+	for _, p := range component.parts {
+		parameters = append(parameters, p.with...)
+	}
+	return parameters
+}
+
+// String renders the component to a string with legacy §-codes.
+// Translation keys are rendered as %key, which clients translate
+// if the message is sent as translation with the translation parameters of the component.
+// Selectors are rendered as the selector itself, and unresolved scores are left out.
+func (component *Component) String() string {
+	return component.Translated(func(key string, parameters []string) string {
+		return "%" + key
+	})
+}
+
+// Translated renders the component to a string with legacy §-codes like String,
+// with translation keys rendered by the translate function, such as for logging messages in the console.
+func (component *Component) Translated(translate func(key string, parameters []string) string) string {
+	var builder strings.Builder
+	var current = ""
+	for _, p := range component.parts {
+		if p.text != "\n" && p.style != current {
+			if current != "" {
+				builder.WriteString(Reset)
+			}
+			builder.WriteString(p.style)
+			current = p.style
+		}
+		if p.translate != "" {
+			builder.WriteString(translate(p.translate, p.with))
+			continue
+		}
+		builder.WriteString(p.selector + p.text)
+	}
+	return builder.String()
+}
+
+// RawText renders the component to Bedrock rawtext JSON.
+// Styles are kept as legacy codes inside the text, as rawtext has no style properties.
+// Codes carry over to the following parts, so every change of style, including to no style, starts with a reset.
+func (component *Component) RawText() string {
+	var raw = rawText{RawText: []rawTextPart{}}
+	var current = ""
+	for _, p := range component.parts {
+		var style = ""
+		if p.text != "\n" && p.style != current {
+			style = Reset + p.style
+			current = p.style
+		}
+		if p.translate == "" && p.score == nil && p.selector == "" {
+			raw.RawText = append(raw.RawText, rawTextPart{Text: style + p.text})
+			continue
+		}
+		if style != "" {
+			raw.RawText = append(raw.RawText, rawTextPart{Text: style})
+		}
+		var translated = rawTextPart{Translate: p.translate, Score: p.score, Selector: p.selector}
+		if len(p.with) != 0 {
+			translated.With = &rawTexts{}
+			for _, parameter := range p.with {
+				translated.With.RawText = append(translated.With.RawText, rawTextPart{Text: parameter})
+			}
+		}
+		raw.RawText = append(raw.RawText, translated)
+	}
+	var encoded, _ = json.Marshal(raw)
+	return string(encoded)
+}
+
+// format adds a formatting code to the current style, if it was not yet added.
+func (component *Component) format(code string) *Component {
+	for _, format := range component.formats {
+		if format == code {
+			return component
+		}
+	}
+	component.formats = append(component.formats, code)
+	return component
+}
+
+// style returns the legacy codes of the current style.
+func (component *Component) style() string {
+	return component.color + strings.Join(component.formats, "")
+}
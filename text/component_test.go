@@ -0,0 +1,66 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestComponentLegacy(t *testing.T) {
+	var component = NewComponent().Color(Red).Bold().Text("Warning:").Text(" ").Reset().Text("text").Newline().Color(Yellow).Translate("commands.generic.unknown", "foo")
+	if s := component.String(); s != Red+Bold+"Warning: "+Reset+"text\n"+Yellow+"%commands.generic.unknown" {
+		t.Errorf("unexpected legacy output %q", s)
+	}
+	if parameters := component.GetTranslationParameters(); len(parameters) != 1 || parameters[0] != "foo" {
+		t.Errorf("expected translation parameter foo, got %v", parameters)
+	}
+	var translated = component.Translated(func(key string, parameters []string) string {
+		return "Unknown command: " + parameters[0]
+	})
+	if translated != Red+Bold+"Warning: "+Reset+"text\n"+Yellow+"Unknown command: foo" {
+		t.Errorf("unexpected translated output %q", translated)
+	}
+}
+
+func TestComponentRawText(t *testing.T) {
+	var component = NewComponent().Color(Red).Text("Hi").Reset().Translate("key", "a")
+	var expected = `{"rawtext":[{"text":"§r§4Hi"},{"text":"§r"},{"translate":"key","with":{"rawtext":[{"text":"a"}]}}]}`
+	if raw := component.RawText(); raw != expected {
+		t.Errorf("expected %v, got %v", expected, raw)
+	}
+	if !component.IsTranslated() || NewComponent().Text("plain").IsTranslated() {
+		t.Error("expected only components with translation keys to be translated")
+	}
+
+	component = NewComponent().Color(Red).Text("a").Text("b").Newline().Reset().Text("c")
+	expected = `{"rawtext":[{"text":"§r§4a"},{"text":"b"},{"text":"\n"},{"text":"§rc"}]}`
+	if raw := component.RawText(); raw != expected {
+		t.Errorf("expected the style to be reset only on changes, got %v", raw)
+	}
+}
+
+func TestComponentScores(t *testing.T) {
+	var component = NewComponent().Text("Kills: ").Score("*", "kills").Text(" ").Score("Alex", "kills").Text(" ").Selector("@p")
+	var expected = `{"rawtext":[{"text":"Kills: "},{"score":{"name":"*","objective":"kills"}},{"text":" "},{"score":{"name":"Alex","objective":"kills"}},{"text":" "},{"selector":"@p"}]}`
+	if raw := component.RawText(); raw != expected {
+		t.Errorf("expected %v, got %v", expected, raw)
+	}
+	var resolved = component.ResolveScores("Steve", func(entry string, objective string) (int, bool) {
+		return 3, entry == "Steve" && objective == "kills"
+	})
+	if s := resolved.String(); s != "Kills: 3  @p" {
+		t.Errorf("unexpected resolved output %q", s)
+	}
+	if s := component.String(); s != "Kills:   @p" {
+		t.Errorf("expected the component to be unchanged, got %q", s)
+	}
+}
+
+func TestComponentCommand(t *testing.T) {
+	var component = NewComponent().Color(Gray).Text("Type ").Command("/menu 1").Text(".")
+	if s := component.String(); s != Gray+"Type "+Reset+Gray+Underlined+"/menu 1"+Reset+Gray+"." {
+		t.Errorf("unexpected legacy output %q", s)
+	}
+	var expected = `{"rawtext":[{"text":"§r§8Type "},{"text":"§r§8§n/menu 1"},{"text":"§r§8."},{"text":"§r"},{"selector":"@p"}]}`
+	if raw := component.Reset().Selector("@p").RawText(); raw != expected {
+		t.Errorf("expected the command style not to carry over, got %v", raw)
+	}
+}
@@ -0,0 +1,158 @@
+package text
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLocale is the code of the locale used for unknown locales.
+const DefaultLocale = "en_US"
+
+// Locale holds the conventions used to format numbers, durations and dates for a language.
+type Locale struct {
+	// Code is the locale code as sent by clients, such as en_US.
+	Code string
+	// ThousandsSeparator separates groups of three digits in large numbers.
+	ThousandsSeparator string
+	// DecimalSeparator separates the integer part from the fraction of a number.
+	DecimalSeparator string
+	// DateLayout is the layout used to format dates, as used by time.Format.
+	DateLayout string
+	// Units holds the singular and plural names of days, hours, minutes and seconds.
+	Units [4][2]string
+}
+
+var (
+	localeMutex sync.RWMutex
+	locales     = make(map[string]Locale)
+	languages   = make(map[string]string)
+)
+
+func init() {
+	var english = [4][2]string{{"day", "days"}, {"hour", "hours"}, {"minute", "minutes"}, {"second", "seconds"}}
+	RegisterLocale(Locale{"en_US", ",", ".", "Jan 2, 2006 3:04 PM", english})
+	RegisterLocale(Locale{"en_GB", ",", ".", "02/01/2006 15:04", english})
+	RegisterLocale(Locale{"de_DE", ".", ",", "02.01.2006 15:04", [4][2]string{{"Tag", "Tage"}, {"Stunde", "Stunden"}, {"Minute", "Minuten"}, {"Sekunde", "Sekunden"}}})
+	RegisterLocale(Locale{"fr_FR", " ", ",", "02/01/2006 15:04", [4][2]string{{"jour", "jours"}, {"heure", "heures"}, {"minute", "minutes"}, {"seconde", "secondes"}}})
+	RegisterLocale(Locale{"es_ES", ".", ",", "02/01/2006 15:04", [4][2]string{{"día", "días"}, {"hora", "horas"}, {"minuto", "minutos"}, {"segundo", "segundos"}}})
+	RegisterLocale(Locale{"nl_NL", ".", ",", "02-01-2006 15:04", [4][2]string{{"dag", "dagen"}, {"uur", "uur"}, {"minuut", "minuten"}, {"seconde", "seconden"}}})
+	RegisterLocale(Locale{"pt_BR", ".", ",", "02/01/2006 15:04", [4][2]string{{"dia", "dias"}, {"hora", "horas"}, {"minuto", "minutos"}, {"segundo", "segundos"}}})
+}
+
+// RegisterLocale registers a locale, or overwrites the locale with the same code.
+// The first locale registered for a language is used for other locales of that language.
+func RegisterLocale(locale Locale) {
+	localeMutex.Lock()
+	defer localeMutex.Unlock()
+	locales[locale.Code] = locale
+	var language = strings.SplitN(locale.Code, "_", 2)[0]
+	if _, ok := languages[language]; !ok {
+		languages[language] = locale.Code
+	}
+}
+
+// GetLocale returns the locale with the given code, such as the language of a session.
+// The locale of the same language is returned if the exact locale is unknown,
+// and the default locale if the language is unknown too.
+func GetLocale(code string) Locale {
+	localeMutex.RLock()
+	defer localeMutex.RUnlock()
+	if locale, ok := locales[code]; ok {
+		return locale
+	}
+	if fallback, ok := languages[strings.SplitN(code, "_", 2)[0]]; ok {
+		return locales[fallback]
+	}
+	return locales[DefaultLocale]
+}
+
+// FormatInt formats an integer with its digits grouped by the thousands separator.
+func (locale Locale) FormatInt(value int64) string {
+	var digits = strconv.FormatInt(value, 10)
+	var sign = ""
+	if value < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	return sign + locale.group(digits)
+}
+
+// FormatFloat formats a number with the given amount of decimals,
+// using the thousands and decimal separators of the locale.
+func (locale Locale) FormatFloat(value float64, decimals int) string {
+	var formatted = strconv.FormatFloat(value, 'f', decimals, 64)
+	var sign = ""
+	if strings.HasPrefix(formatted, "-") {
+		sign, formatted = "-", formatted[1:]
+	}
+	var parts = strings.SplitN(formatted, ".", 2)
+	if len(parts) == 1 {
+		return sign + locale.group(parts[0])
+	}
+	return sign + locale.group(parts[0]) + locale.DecimalSeparator + parts[1]
+}
+
+// FormatDuration formats a duration in days, hours, minutes and seconds,
+// such as the time left until a mute expires. Only the two largest units are shown,
+// as a mute lasting 2 days and 3 seconds is better described as 2 days.
+func (locale Locale) FormatDuration(duration time.Duration) string {
+	if duration < 0 {
+		duration = -duration
+	}
+	var amounts = [4]int64{
+		int64(duration / (time.Hour * 24)),
+		int64(duration / time.Hour % 24),
+		int64(duration / time.Minute % 60),
+		int64(duration / time.Second % 60),
+	}
+	var parts []string
+	for unit, amount := range amounts {
+		if amount == 0 {
+			if len(parts) != 0 {
+				break
+			}
+			continue
+		}
+		parts = append(parts, locale.formatUnit(amount, unit))
+		if len(parts) == 2 {
+			break
+		}
+	}
+	if len(parts) == 0 {
+		return locale.formatUnit(0, 3)
+	}
+	return strings.Join(parts, " ")
+}
+
+// FormatDate formats a time in the local time zone of the server, using the date layout of the locale.
+func (locale Locale) FormatDate(t time.Time) string {
+	return t.Local().Format(locale.DateLayout)
+}
+
+// formatUnit formats an amount of the unit at the given index in the units of the locale.
+func (locale Locale) formatUnit(amount int64, unit int) string {
+	var name = locale.Units[unit][1]
+	if amount == 1 {
+		name = locale.Units[unit][0]
+	}
+	return locale.FormatInt(amount) + " " + name
+}
+
+// group inserts the thousands separator between every three digits.
+func (locale Locale) group(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var builder strings.Builder
+	var first = len(digits) % 3
+	if first == 0 {
+		first = 3
+	}
+	builder.WriteString(digits[:first])
+	for i := first; i < len(digits); i += 3 {
+		builder.WriteString(locale.ThousandsSeparator)
+		builder.WriteString(digits[i : i+3])
+	}
+	return builder.String()
+}
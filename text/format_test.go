@@ -0,0 +1,41 @@
+package text
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatNumbers(t *testing.T) {
+	var english, german = GetLocale("en_US"), GetLocale("de_DE")
+	if s := english.FormatInt(-1234567); s != "-1,234,567" {
+		t.Errorf("expected -1,234,567, got %v", s)
+	}
+	if s := german.FormatInt(123); s != "123" {
+		t.Errorf("expected 123, got %v", s)
+	}
+	if s := german.FormatFloat(12345.678, 2); s != "12.345,68" {
+		t.Errorf("expected 12.345,68, got %v", s)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	var english = GetLocale("en_US")
+	if s := english.FormatDuration(time.Hour*25 + time.Minute*30); s != "1 day 1 hour" {
+		t.Errorf("expected 1 day 1 hour, got %v", s)
+	}
+	if s := english.FormatDuration(time.Hour*48 + time.Second*3); s != "2 days" {
+		t.Errorf("expected 2 days, got %v", s)
+	}
+	if s := english.FormatDuration(time.Millisecond); s != "0 seconds" {
+		t.Errorf("expected 0 seconds, got %v", s)
+	}
+	if s := GetLocale("de_AT").FormatDuration(time.Minute * 5); s != "5 Minuten" {
+		t.Errorf("expected the German locale for Austria, got %v", s)
+	}
+}
+
+func TestUnknownLocale(t *testing.T) {
+	if locale := GetLocale("xx_XX"); locale.Code != DefaultLocale {
+		t.Errorf("expected the default locale, got %v", locale.Code)
+	}
+}
@@ -0,0 +1,87 @@
+package text
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Sanitization policies, deciding what happens to client-sent text that needs sanitizing.
+const (
+	// SanitizeStrip strips everything that is not allowed from the text.
+	SanitizeStrip = "strip"
+	// SanitizeReject rejects the text completely.
+	SanitizeReject = "reject"
+)
+
+var (
+	UnsanitizedText = errors.New("text contains formatting codes, invisible characters or invalid UTF-8")
+	EmptyText       = errors.New("text is empty after sanitizing")
+)
+
+// invisibleCharacters are characters that are not rendered,
+// and could be used to make names look alike or to hide text.
+var invisibleCharacters = map[rune]bool{
+	'\u00ad': true, // soft hyphen
+	'\u034f': true, // combining grapheme joiner
+	'\u061c': true, // arabic letter mark
+	'\u115f': true, // hangul choseong filler
+	'\u1160': true, // hangul jungseong filler
+	'\u180e': true, // mongolian vowel separator
+	'\u3164': true, // hangul filler
+	'\ufeff': true, // zero width no-break space
+	'\uffa0': true, // halfwidth hangul filler
+}
+
+// isInvisible checks if the rune is a zero-width, bidirectional or other invisible formatting character.
+func isInvisible(r rune) bool {
+	if invisibleCharacters[r] {
+		return true
+	}
+	return (r >= '\u200b' && r <= '\u200f') || (r >= '\u202a' && r <= '\u202e') || (r >= '\u2060' && r <= '\u206f') || unicode.Is(unicode.Cf, r)
+}
+
+// Sanitize strips formatting codes, invisible characters, control characters
+// and invalid UTF-8 from client-sent text, such as chat messages and usernames.
+// Surrounding whitespace is trimmed.
+func Sanitize(text string) string {
+	var builder strings.Builder
+	for i := 0; i < len(text); {
+		var r, size = utf8.DecodeRuneInString(text[i:])
+		i += size
+		switch {
+		case r == utf8.RuneError && size <= 1:
+			continue
+		case r == '§':
+			if i < len(text) {
+				var _, next = utf8.DecodeRuneInString(text[i:])
+				i += next
+			}
+			continue
+		case isInvisible(r), unicode.IsControl(r):
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+// IsSanitized checks if the text does not change by sanitizing it.
+func IsSanitized(text string) bool {
+	return Sanitize(text) == text
+}
+
+// SanitizeWithPolicy sanitizes the text according to the given policy.
+// The reject policy returns UnsanitizedText if the text needed sanitizing,
+// and any other policy strips the text. EmptyText is returned if nothing is left of the text.
+func SanitizeWithPolicy(text string, policy string) (string, error) {
+	var sanitized = Sanitize(text)
+	if policy == SanitizeReject && sanitized != text {
+		return text, UnsanitizedText
+	}
+	if sanitized == "" {
+		return sanitized, EmptyText
+	}
+	return sanitized, nil
+}
@@ -0,0 +1,35 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	var tests = map[string]string{
+		"hello":               "hello",
+		Red + "Steve" + Reset: "Steve",
+		"Ste\u200bve":         "Steve",
+		"Steve\u202e":         "Steve",
+		"bad\xffutf8":         "badutf8",
+		" spaced \n":          "spaced",
+		"§":                   "",
+		"héllo wörld ✓":       "héllo wörld ✓",
+	}
+	for input, expected := range tests {
+		if output := Sanitize(input); output != expected {
+			t.Errorf("expected %q to be sanitized to %q, got %q", input, expected, output)
+		}
+	}
+}
+
+func TestSanitizeWithPolicy(t *testing.T) {
+	if _, err := SanitizeWithPolicy(Red+"hi", SanitizeReject); err != UnsanitizedText {
+		t.Errorf("expected UnsanitizedText, got %v", err)
+	}
+	if s, err := SanitizeWithPolicy(Red+"hi", SanitizeStrip); err != nil || s != "hi" {
+		t.Errorf("expected hi, got %q (%v)", s, err)
+	}
+	if _, err := SanitizeWithPolicy("\u200b", SanitizeStrip); err != EmptyText {
+		t.Errorf("expected EmptyText, got %v", err)
+	}
+}
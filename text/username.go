@@ -0,0 +1,53 @@
+package text
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	// MinimumUsernameLength is the minimum amount of characters a username has to have.
+	MinimumUsernameLength = 3
+	// MaximumUsernameLength is the maximum amount of characters a username may have.
+	MaximumUsernameLength = 16
+)
+
+var (
+	InvalidUsernameLength    = errors.New("username must be between 3 and 16 characters long")
+	InvalidUsernameCharacter = errors.New("username may only contain letters, digits, spaces and underscores")
+	InvalidUsernameSpacing   = errors.New("username may not start or end with a space")
+	ReservedUsername         = errors.New("username is reserved")
+)
+
+// ReservedUsernames are lower case names that may not be used by players,
+// because they are used to identify the server itself in messages.
+var ReservedUsernames = map[string]bool{
+	"server":  true,
+	"console": true,
+	"rcon":    true,
+}
+
+// ValidateUsername checks if the username is a valid player name.
+// Usernames are compared against reserved names case insensitively.
+func ValidateUsername(username string) error {
+	if len(username) < MinimumUsernameLength || len(username) > MaximumUsernameLength {
+		return InvalidUsernameLength
+	}
+	for _, r := range username {
+		if !isUsernameCharacter(r) {
+			return InvalidUsernameCharacter
+		}
+	}
+	if username[0] == ' ' || username[len(username)-1] == ' ' {
+		return InvalidUsernameSpacing
+	}
+	if ReservedUsernames[strings.ToLower(username)] {
+		return ReservedUsername
+	}
+	return nil
+}
+
+// isUsernameCharacter checks if the rune may be used in a username.
+func isUsernameCharacter(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == ' '
+}
@@ -0,0 +1,24 @@
+package text
+
+import "testing"
+
+func TestValidateUsername(t *testing.T) {
+	var tests = map[string]error{
+		"Steve":             nil,
+		"Alex_123":          nil,
+		"Some Player":       nil,
+		"ab":                InvalidUsernameLength,
+		"ThisNameIsTooLong": InvalidUsernameLength,
+		"Bad-Name":          InvalidUsernameCharacter,
+		"Jöhn":              InvalidUsernameCharacter,
+		" Steve":            InvalidUsernameSpacing,
+		"Steve ":            InvalidUsernameSpacing,
+		"Server":            ReservedUsername,
+		"CONSOLE":           ReservedUsername,
+	}
+	for username, expected := range tests {
+		if err := ValidateUsername(username); err != expected {
+			t.Errorf("ValidateUsername(%q) = %v, expected %v", username, err, expected)
+		}
+	}
+}
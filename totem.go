@@ -0,0 +1,65 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/combat"
+	"github.com/BobbyShrd/gominetest/effects"
+	"github.com/BobbyShrd/gominetest/net"
+)
+
+// OffhandWindowId is the window ID of the offhand inventory of a player.
+const OffhandWindowId uint32 = 119
+
+// totemEffects are the effects given to a player saved from death by a totem.
+var totemEffects = []struct {
+	id        int32
+	amplifier int32
+	duration  int32
+}{
+	{effects.Regeneration, 1, combat.TotemRegenerationDuration},
+	{effects.Absorption, 1, combat.TotemAbsorptionDuration},
+	{effects.FireResistance, 0, combat.TotemFireResistanceDuration},
+}
+
+// preventDeath is called right before the player of the session dies with the given message.
+// A totem held in the main hand or offhand saves the player first, after which a player death event
+// is emitted for plugins to cancel. Returns true if the death was prevented.
+// The message of the event replaces the message if the death was not prevented.
+func (server *Server) preventDeath(session *net.MinecraftSession, message *string) bool {
+	if server.useTotem(session) {
+		return true
+	}
+	var event = NewPlayerDeathEvent(session, *message)
+	server.EventManager.Emit(event)
+	*message = event.Message
+	return event.IsCancelled()
+}
+
+// useTotem consumes a totem held by the player of the session, preferring the main hand over the offhand.
+// The player is left with the totem health, loses its effects and gets the totem effects,
+// and the totem animation is shown to the player and its viewers. Returns false if the player held no totem.
+func (server *Server) useTotem(session *net.MinecraftSession) bool {
+	var player = session.GetPlayer()
+	var inventory, windowId, slot = player.GetInventory(), InventoryWindowId, player.GetHeldSlot()
+	if !combat.IsTotem(inventory.GetItem(slot)) {
+		inventory, windowId, slot = player.GetOffhand(), OffhandWindowId, 0
+		if !combat.IsTotem(inventory.GetItem(slot)) {
+			return false
+		}
+	}
+	var totem = inventory.GetItem(slot)
+	totem.Count--
+	inventory.SetItem(slot, totem)
+	session.SendInventorySlot(windowId, uint32(slot), inventory.GetItem(slot))
+
+	player.SetHealth(combat.TotemHealth)
+	session.SendUpdateAttributes(player.GetRuntimeId(), player.GetAttributeMap())
+	session.ClearEffects()
+	for _, totemEffect := range totemEffects {
+		if effectType, err := effects.GetType(totemEffect.id); err == nil {
+			session.AddEffect(effects.NewEffect(effectType, totemEffect.amplifier, totemEffect.duration, true))
+		}
+	}
+
+	server.BroadcastPlayerEvent(session, EntityEventConsumeTotem, 0)
+	return true
+}
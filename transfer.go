@@ -0,0 +1,22 @@
+package gomine
+
+import (
+	"github.com/BobbyShrd/gominetest/net"
+)
+
+// DefaultTransferPort is the port players get transferred to if no port is given.
+const DefaultTransferPort uint16 = 19132
+
+// Transfer transfers the player of the session to the server at the address and port,
+// which is useful to move players between servers of a network without a proxy.
+// The client disconnects and joins the other server, after which its player data gets saved like any other disconnect.
+// Returns false if a plugin cancelled the transfer.
+func (server *Server) Transfer(session *net.MinecraftSession, address string, port uint16) bool {
+	var event = NewTransferEvent(session, address, port)
+	server.EventManager.Emit(event)
+	if event.IsCancelled() {
+		return false
+	}
+	session.Transfer(event.Address, event.Port)
+	return true
+}
@@ -5,9 +5,37 @@ import (
 	"crypto/cipher"
 	"crypto/ecdsa"
 	"crypto/sha256"
-	"github.com/irmine/binutils"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
 )
 
+// EncryptionMode is a scheme batches are encrypted with, which depends on the protocol of the client.
+type EncryptionMode int
+
+const (
+	// ModeCFB8 encrypts batches with AES-256 in 8 bit cipher feedback mode, used by clients before CTRProtocol.
+	ModeCFB8 EncryptionMode = iota
+	// ModeCTR encrypts batches with AES-256 in counter mode, using the IV construction of GCM.
+	ModeCTR
+)
+
+// CTRProtocol is the first protocol in which clients encrypt batches in counter mode.
+const CTRProtocol = 428
+
+// ChecksumSize is the size of the checksum appended to every encrypted batch.
+const ChecksumSize = 8
+
+var InvalidChecksum = errors.New("invalid checksum of encrypted batch")
+
+// EncryptionModeFor returns the encryption mode clients with the given protocol use.
+func EncryptionModeFor(protocol int32) EncryptionMode {
+	if protocol >= CTRProtocol {
+		return ModeCTR
+	}
+	return ModeCFB8
+}
+
 type EncryptionData struct {
 	ClientPublicKey       *ecdsa.PublicKey
 	ServerPrivateKey      *ecdsa.PrivateKey
@@ -16,12 +44,13 @@ type EncryptionData struct {
 	DecryptSecretKeyBytes [32]byte
 	EncryptSecretKeyBytes [32]byte
 
-	DecryptIV     []byte
-	EncryptIV     []byte
-	DecryptCipher cipher.Block
-	EncryptCipher cipher.Block
+	// Mode is the encryption mode the streams were started with.
+	Mode          EncryptionMode
+	DecryptStream cipher.Stream
+	EncryptStream cipher.Stream
 
-	SendCounter int64
+	SendCounter    int64
+	ReceiveCounter int64
 }
 
 func (data *EncryptionData) ComputeSharedSecret() {
@@ -33,12 +62,27 @@ func (data *EncryptionData) ComputeSecretKeyBytes() {
 	var secret = sha256.Sum256(append(data.ServerToken, data.SharedSecret...))
 	data.DecryptSecretKeyBytes = secret
 	data.EncryptSecretKeyBytes = secret
+}
 
-	data.DecryptCipher, _ = aes.NewCipher(data.DecryptSecretKeyBytes[:])
-	data.EncryptCipher, _ = aes.NewCipher(data.EncryptSecretKeyBytes[:])
+// StartStreams creates the encryption and decryption streams of the given mode from the secret key bytes.
+// The streams keep their state between batches, so they are only started once for every session.
+func (data *EncryptionData) StartStreams(mode EncryptionMode) {
+	data.Mode = mode
+	data.EncryptStream = newStream(mode, data.EncryptSecretKeyBytes[:], false)
+	data.DecryptStream = newStream(mode, data.DecryptSecretKeyBytes[:], true)
+}
 
-	data.DecryptIV = data.DecryptSecretKeyBytes[:aes.BlockSize]
-	data.EncryptIV = data.DecryptSecretKeyBytes[:aes.BlockSize]
+// newStream returns a stream of the encryption mode with the key.
+// Counter mode streams start with the IV of GCM: the first 12 bytes of the key followed by counter 2.
+func newStream(mode EncryptionMode, key []byte, decrypt bool) cipher.Stream {
+	var block, _ = aes.NewCipher(key)
+	if mode == ModeCTR {
+		var iv = make([]byte, aes.BlockSize)
+		copy(iv, key[:12])
+		iv[aes.BlockSize-1] = 2
+		return cipher.NewCTR(block, iv)
+	}
+	return newCFB8(block, key[:aes.BlockSize], decrypt)
 }
 
 type EncryptionHandler struct {
@@ -49,18 +93,78 @@ func NewEncryptionHandler() *EncryptionHandler {
 	return &EncryptionHandler{&EncryptionData{}}
 }
 
-func (handler *EncryptionHandler) ComputeSendChecksum(d []byte) []byte {
-	var buffer []byte
-	var secret = handler.Data.EncryptSecretKeyBytes[:]
+// Encrypt appends the checksum to the batch data and encrypts it in place.
+// The encrypted data is returned, which may use a new array if the checksum did not fit.
+func (handler *EncryptionHandler) Encrypt(d []byte) []byte {
+	d = append(d, handler.ComputeSendChecksum(d)...)
+	handler.Data.EncryptStream.XORKeyStream(d, d)
+	return d
+}
 
-	binutils.WriteLittleLong(&buffer, handler.Data.SendCounter)
+// Decrypt decrypts the batch data in place, verifies its checksum and returns the data without the checksum.
+func (handler *EncryptionHandler) Decrypt(d []byte) ([]byte, error) {
+	handler.Data.DecryptStream.XORKeyStream(d, d)
+	if len(d) < ChecksumSize {
+		return nil, InvalidChecksum
+	}
+	var payload, checksum = d[:len(d)-ChecksumSize], d[len(d)-ChecksumSize:]
+	var expected = computeChecksum(handler.Data.ReceiveCounter, payload, handler.Data.DecryptSecretKeyBytes[:])
+	handler.Data.ReceiveCounter++
+	if subtle.ConstantTimeCompare(checksum, expected) != 1 {
+		return nil, InvalidChecksum
+	}
+	return payload, nil
+}
+
+func (handler *EncryptionHandler) ComputeSendChecksum(d []byte) []byte {
+	var sum = computeChecksum(handler.Data.SendCounter, d, handler.Data.EncryptSecretKeyBytes[:])
 	handler.Data.SendCounter++
+	return sum
+}
+
+// computeChecksum returns the checksum of the batch data with the given counter:
+// the first bytes of the SHA-256 hash of the little endian counter, the data and the secret key.
+func computeChecksum(counter int64, d []byte, secret []byte) []byte {
+	var buffer [8]byte
+	binary.LittleEndian.PutUint64(buffer[:], uint64(counter))
 
 	var hash = sha256.New()
-	hash.Write(buffer)
+	hash.Write(buffer[:])
 	hash.Write(d)
 	hash.Write(secret)
 
 	var sum = hash.Sum(nil)
-	return sum[:8]
+	return sum[:ChecksumSize]
+}
+
+// cfb8 is a stream encrypting or decrypting in 8 bit cipher feedback mode.
+// The standard library only implements full block feedback, so cfb8 keeps its own shift register,
+// encrypting it once for every byte without allocating.
+type cfb8 struct {
+	block    cipher.Block
+	register []byte
+	output   []byte
+	decrypt  bool
+}
+
+// newCFB8 returns a new 8 bit cipher feedback stream of the block with the IV.
+func newCFB8(block cipher.Block, iv []byte, decrypt bool) *cfb8 {
+	var register = make([]byte, block.BlockSize())
+	copy(register, iv)
+	return &cfb8{block: block, register: register, output: make([]byte, block.BlockSize()), decrypt: decrypt}
+}
+
+// XORKeyStream encrypts or decrypts the bytes of src into dst, which may overlap entirely.
+func (stream *cfb8) XORKeyStream(dst, src []byte) {
+	for i, b := range src {
+		stream.block.Encrypt(stream.output, stream.register)
+		var result = b ^ stream.output[0]
+		var feedback = result
+		if stream.decrypt {
+			feedback = b
+		}
+		copy(stream.register, stream.register[1:])
+		stream.register[len(stream.register)-1] = feedback
+		dst[i] = result
+	}
 }
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"testing"
+)
+
+// newTestHandlers returns a handler encrypting and a handler decrypting with the same key in the mode.
+func newTestHandlers(mode EncryptionMode) (*EncryptionHandler, *EncryptionHandler) {
+	var sender, receiver = NewEncryptionHandler(), NewEncryptionHandler()
+	for i := range sender.Data.EncryptSecretKeyBytes {
+		sender.Data.EncryptSecretKeyBytes[i] = byte(i * 7)
+	}
+	receiver.Data.DecryptSecretKeyBytes = sender.Data.EncryptSecretKeyBytes
+	sender.Data.StartStreams(mode)
+	receiver.Data.StartStreams(mode)
+	return sender, receiver
+}
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	for _, mode := range []EncryptionMode{ModeCFB8, ModeCTR} {
+		var sender, receiver = newTestHandlers(mode)
+		for _, batch := range []string{"first batch", "second batch", "third batch"} {
+			var encrypted = sender.Encrypt([]byte(batch))
+			var decrypted, err = receiver.Decrypt(encrypted)
+			if err != nil || string(decrypted) != batch {
+				t.Errorf("mode %v: expected %q, got %q with error %v", mode, batch, decrypted, err)
+			}
+		}
+	}
+}
+
+func TestEncryptionChecksum(t *testing.T) {
+	var sender, receiver = newTestHandlers(ModeCTR)
+	var encrypted = sender.Encrypt([]byte("batch"))
+	encrypted[0] ^= 1
+	if _, err := receiver.Decrypt(encrypted); err != InvalidChecksum {
+		t.Errorf("expected tampered batch to fail the checksum, got %v", err)
+	}
+}
+
+// TestCTRMatchesGCM checks that counter mode encrypts like AES-GCM with the first 12 bytes of the key as nonce,
+// which is how clients encrypt batches.
+func TestCTRMatchesGCM(t *testing.T) {
+	var sender, _ = newTestHandlers(ModeCTR)
+	var key = sender.Data.EncryptSecretKeyBytes
+	var data = bytes.Repeat([]byte("batch data "), 10)
+
+	var block, _ = aes.NewCipher(key[:])
+	var gcm, _ = cipher.NewGCM(block)
+	var expected = gcm.Seal(nil, key[:12], data, nil)[:len(data)]
+
+	var actual = make([]byte, len(data))
+	sender.Data.EncryptStream.XORKeyStream(actual, data)
+	if !bytes.Equal(actual, expected) {
+		t.Error("expected counter mode to match the ciphertext of GCM")
+	}
+}
+
+// TestCFB8MatchesFullBlockFeedback checks the 8 bit cipher feedback stream against encrypting every byte
+// with a new full block feedback encrypter, as batches were encrypted before.
+func TestCFB8MatchesFullBlockFeedback(t *testing.T) {
+	var sender, _ = newTestHandlers(ModeCFB8)
+	var key = sender.Data.EncryptSecretKeyBytes
+	var data = bytes.Repeat([]byte("batch data "), 10)
+
+	var block, _ = aes.NewCipher(key[:])
+	var iv = append([]byte(nil), key[:aes.BlockSize]...)
+	var expected = append([]byte(nil), data...)
+	for i := range expected {
+		cipher.NewCFBEncrypter(block, iv).XORKeyStream(expected[i:i+1], expected[i:i+1])
+		iv = append(iv[1:], expected[i])
+	}
+
+	var actual = make([]byte, len(data))
+	sender.Data.EncryptStream.XORKeyStream(actual, data)
+	if !bytes.Equal(actual, expected) {
+		t.Error("expected 8 bit cipher feedback to match the per byte encryption")
+	}
+}
+
+func TestEncryptionModeFor(t *testing.T) {
+	if EncryptionModeFor(CTRProtocol-1) != ModeCFB8 || EncryptionModeFor(CTRProtocol) != ModeCTR {
+		t.Error("expected counter mode from the CTR protocol on")
+	}
+}
+
+func BenchmarkEncryptCTR(b *testing.B) {
+	var sender, _ = newTestHandlers(ModeCTR)
+	var data = make([]byte, 16384)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		sender.Data.EncryptStream.XORKeyStream(data, data)
+	}
+}
+
+func BenchmarkEncryptCFB8(b *testing.B) {
+	var sender, _ = newTestHandlers(ModeCFB8)
+	var data = make([]byte, 16384)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		sender.Data.EncryptStream.XORKeyStream(data, data)
+	}
+}
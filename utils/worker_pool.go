@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	PoolClosed = errors.New("worker pool is closed")
+	QueueFull  = errors.New("worker pool queue is full")
+)
+
+// WorkerPool executes submitted jobs on a fixed amount of goroutines.
+// It is used to move expensive work off the goroutines reading packets.
+type WorkerPool struct {
+	mutex     sync.Mutex
+	jobs      chan func()
+	waitGroup sync.WaitGroup
+	closed    bool
+}
+
+// NewWorkerPool returns a new worker pool with the given amount of workers.
+// The queue size is the amount of jobs that may be waiting before Submit rejects new jobs.
+func NewWorkerPool(workers int, queueSize int) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	var pool = &WorkerPool{jobs: make(chan func(), queueSize)}
+	pool.waitGroup.Add(workers)
+	for i := 0; i < workers; i++ {
+		go pool.work()
+	}
+	return pool
+}
+
+// Submit queues a job to be executed by one of the workers, without blocking.
+// Returns PoolClosed if the pool was closed, or QueueFull if the queue is full,
+// in which case the job is dropped.
+func (pool *WorkerPool) Submit(job func()) error {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	if pool.closed {
+		return PoolClosed
+	}
+	select {
+	case pool.jobs <- job:
+		return nil
+	default:
+		return QueueFull
+	}
+}
+
+// Close stops accepting jobs and waits for all queued jobs to finish.
+func (pool *WorkerPool) Close() {
+	pool.mutex.Lock()
+	if !pool.closed {
+		pool.closed = true
+		close(pool.jobs)
+	}
+	pool.mutex.Unlock()
+	pool.waitGroup.Wait()
+}
+
+// work continuously executes jobs until the pool gets closed.
+func (pool *WorkerPool) work() {
+	defer pool.waitGroup.Done()
+	for job := range pool.jobs {
+		job()
+	}
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWorkerPool(t *testing.T) {
+	pool := NewWorkerPool(4, 16)
+	var count int64
+	for i := 0; i < 100; i++ {
+		for pool.Submit(func() {
+			atomic.AddInt64(&count, 1)
+		}) == QueueFull {
+			runtime.Gosched()
+		}
+	}
+	pool.Close()
+
+	if count != 100 {
+		t.Error("expected 100 executed jobs, got", count)
+	}
+}
+
+func TestWorkerPoolRejects(t *testing.T) {
+	pool := NewWorkerPool(1, 1)
+	var block = make(chan struct{})
+	var started = make(chan struct{})
+	pool.Submit(func() {
+		close(started)
+		<-block
+	})
+	<-started
+	if err := pool.Submit(func() {}); err != nil {
+		t.Error("expected queued job to be accepted, got", err)
+	}
+	if err := pool.Submit(func() {}); err != QueueFull {
+		t.Error("expected QueueFull with a full queue, got", err)
+	}
+	close(block)
+	pool.Close()
+
+	if err := pool.Submit(func() {}); err != PoolClosed {
+		t.Error("expected PoolClosed after closing, got", err)
+	}
+	pool.Close()
+}
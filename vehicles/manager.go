@@ -0,0 +1,71 @@
+package vehicles
+
+import "sync"
+
+// Manager manages the vehicles of a dimension.
+type Manager struct {
+	mutex    sync.Mutex
+	vehicles map[uint64]*Vehicle
+}
+
+// NewManager returns a new manager without vehicles.
+func NewManager() *Manager {
+	return &Manager{vehicles: make(map[uint64]*Vehicle)}
+}
+
+// Add adds the vehicle to the manager.
+func (manager *Manager) Add(vehicle *Vehicle) {
+	manager.mutex.Lock()
+	manager.vehicles[vehicle.GetBody().GetRuntimeId()] = vehicle
+	manager.mutex.Unlock()
+}
+
+// Remove removes the vehicle with the given runtime ID.
+// Returns false if there was no such vehicle.
+func (manager *Manager) Remove(runtimeId uint64) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var _, ok = manager.vehicles[runtimeId]
+	delete(manager.vehicles, runtimeId)
+	return ok
+}
+
+// GetVehicle returns the vehicle with the given runtime ID, and a bool indicating if it exists.
+func (manager *Manager) GetVehicle(runtimeId uint64) (*Vehicle, bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var vehicle, ok = manager.vehicles[runtimeId]
+	return vehicle, ok
+}
+
+// GetVehicleByRider returns the vehicle ridden by the entity with the given runtime ID, and a bool indicating if it exists.
+func (manager *Manager) GetVehicleByRider(runtimeId uint64) (*Vehicle, bool) {
+	for _, vehicle := range manager.GetVehicles() {
+		if vehicle.GetRider() == runtimeId {
+			return vehicle, true
+		}
+	}
+	return nil, false
+}
+
+// GetVehicles returns all vehicles of the manager.
+func (manager *Manager) GetVehicles() []*Vehicle {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var vehicles = make([]*Vehicle, 0, len(manager.vehicles))
+	for _, vehicle := range manager.vehicles {
+		vehicles = append(vehicles, vehicle)
+	}
+	return vehicles
+}
+
+// Tick ticks the vehicles in the world, and returns the vehicles that moved.
+func (manager *Manager) Tick(world World) []*Vehicle {
+	var moved []*Vehicle
+	for _, vehicle := range manager.GetVehicles() {
+		if vehicle.Tick(world) {
+			moved = append(moved, vehicle)
+		}
+	}
+	return moved
+}
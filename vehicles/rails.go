@@ -0,0 +1,99 @@
+package vehicles
+
+import "github.com/irmine/worlds/blocks"
+
+// Rail shapes, as held by the data value of rail blocks.
+const (
+	RailNorthSouth = iota
+	RailEastWest
+	RailAscendingEast
+	RailAscendingWest
+	RailAscendingNorth
+	RailAscendingSouth
+	RailSouthEast
+	RailSouthWest
+	RailNorthWest
+	RailNorthEast
+)
+
+// RailPowered is the bit set in the data value of powered golden, detector and activator rails.
+const RailPowered = 0x08
+
+// rails are the names of all rail blocks.
+var rails = map[string]bool{
+	"rail":           true,
+	"golden_rail":    true,
+	"detector_rail":  true,
+	"activator_rail": true,
+}
+
+// IsRail checks if the block with the given name is a rail.
+func IsRail(name string) bool {
+	return rails[name]
+}
+
+// direction is a horizontal direction along the block grid.
+type direction struct {
+	x, z int32
+}
+
+var (
+	north = direction{0, -1}
+	south = direction{0, 1}
+	west  = direction{-1, 0}
+	east  = direction{1, 0}
+)
+
+// railExits holds the two directions minecarts leave a rail in, indexed by rail shape.
+var railExits = [...][2]direction{
+	RailNorthSouth:     {north, south},
+	RailEastWest:       {west, east},
+	RailAscendingEast:  {west, east},
+	RailAscendingWest:  {west, east},
+	RailAscendingNorth: {north, south},
+	RailAscendingSouth: {north, south},
+	RailSouthEast:      {south, east},
+	RailSouthWest:      {south, west},
+	RailNorthWest:      {north, west},
+	RailNorthEast:      {north, east},
+}
+
+// railAscent holds the direction a sloped rail ascends in, indexed by rail shape.
+var railAscent = map[int]direction{
+	RailAscendingEast:  east,
+	RailAscendingWest:  west,
+	RailAscendingNorth: north,
+	RailAscendingSouth: south,
+}
+
+// Rail is a rail block a minecart is on.
+type Rail struct {
+	// Position is the position of the rail block.
+	Position blocks.Position
+	// Name is the name of the rail block, such as "golden_rail".
+	Name string
+	// Shape is the rail shape, which is one of the rail shape constants.
+	Shape int
+	// Powered is true for powered golden, detector and activator rails.
+	Powered bool
+}
+
+// NewRail returns the rail with the given name and data value at the position.
+// Only plain rails curve, so the powered bit of the other rails is taken from the data value.
+func NewRail(position blocks.Position, name string, data byte) Rail {
+	if name == "rail" {
+		return Rail{Position: position, Name: name, Shape: int(data) % len(railExits)}
+	}
+	return Rail{Position: position, Name: name, Shape: int(data&^RailPowered) % len(railExits), Powered: data&RailPowered != 0}
+}
+
+// IsCurved checks if the rail connects two perpendicular directions.
+func (rail Rail) IsCurved() bool {
+	return rail.Shape >= RailSouthEast
+}
+
+// IsAscending checks if the rail is sloped.
+func (rail Rail) IsAscending() bool {
+	var _, ok = railAscent[rail.Shape]
+	return ok
+}
@@ -0,0 +1,347 @@
+package vehicles
+
+import (
+	"math"
+	"sync"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds/blocks"
+)
+
+// Entity types of vehicles.
+const (
+	MinecartEntityType uint32 = 84
+	BoatEntityType     uint32 = 90
+)
+
+const (
+	// BoatWaterSpeed is the maximum amount of blocks a boat moves per tick on water.
+	BoatWaterSpeed = 0.4
+	// BoatIceSpeed is the maximum amount of blocks a boat moves per tick on ice.
+	BoatIceSpeed = 2.0
+	// BoatLandSpeed is the maximum amount of blocks a boat moves per tick on land.
+	BoatLandSpeed = 0.1
+	// BoatClimb is the maximum height a boat moves up per tick, for example to float up in water.
+	BoatClimb = 0.5
+
+	// BoatTolerance is the distance a boat may move further than its speed allows, to account for latency.
+	BoatTolerance = 0.3
+	// MaxDriveTicks is the maximum amount of ticks taken into account between two moves of a boat.
+	MaxDriveTicks = 20
+
+	// MinecartMaxSpeed is the maximum amount of blocks a minecart moves per tick.
+	MinecartMaxSpeed = 0.4
+	// MinecartAcceleration is the speed a rider adds per tick by pushing forward.
+	MinecartAcceleration = 0.01
+	// MinecartBoost is the speed powered golden rails add per tick.
+	MinecartBoost = 0.06
+	// MinecartFriction is the part of the speed a minecart keeps every tick.
+	MinecartFriction = 0.98
+	// MinecartGravity is the speed a minecart falls with per tick when not on rails.
+	MinecartGravity = 0.04
+	// MinecartSlope is the speed sloped rails add per tick to minecarts moving down.
+	MinecartSlope = 0.0078125
+)
+
+// ice are the blocks boats slide over quickly.
+var ice = map[string]bool{
+	"ice":         true,
+	"packed_ice":  true,
+	"blue_ice":    true,
+	"frosted_ice": true,
+}
+
+// water are the blocks boats float on.
+var water = map[string]bool{
+	"water":         true,
+	"flowing_water": true,
+}
+
+// Kind is the kind of a vehicle.
+type Kind int
+
+const (
+	Boat Kind = iota
+	Minecart
+)
+
+// Body is the entity of a vehicle.
+type Body interface {
+	// GetRuntimeId returns the runtime ID of the entity.
+	GetRuntimeId() uint64
+	// GetPosition returns the position of the entity.
+	GetPosition() r3.Vector
+	// Move moves the entity to the position, facing in the direction of the yaw and pitch.
+	Move(position r3.Vector, yaw float64, pitch float64)
+}
+
+// World gives vehicles access to the blocks they move over.
+type World interface {
+	// GetBlockName returns the name of the block at the given position.
+	GetBlockName(position blocks.Position) string
+	// GetBlockData returns the data value of the block at the given position.
+	GetBlockData(position blocks.Position) byte
+}
+
+// Input is the movement input of the rider of a vehicle.
+type Input struct {
+	// Forward is the forward input, ranging from -1 backwards to 1 forwards.
+	Forward float64
+	// Strafe is the sideways input, ranging from -1 to 1.
+	Strafe float64
+	// Yaw is the direction the rider is looking in.
+	Yaw float64
+}
+
+// Vehicle is an entity players ride. Boats are moved by the client of their rider and validated by the server,
+// while minecarts are moved by the server along the rails they are on.
+type Vehicle struct {
+	mutex  sync.Mutex
+	body   Body
+	kind   Kind
+	rider  uint64
+	input  Input
+	motion r3.Vector
+	yaw    float64
+	// lastDrive is the tick the boat was last moved by its rider.
+	lastDrive int64
+}
+
+// NewVehicle returns a new vehicle of the given kind, moving the body.
+func NewVehicle(body Body, kind Kind) *Vehicle {
+	return &Vehicle{body: body, kind: kind}
+}
+
+// GetKind returns the kind of the vehicle.
+func (vehicle *Vehicle) GetKind() Kind {
+	return vehicle.kind
+}
+
+// GetBody returns the entity of the vehicle.
+func (vehicle *Vehicle) GetBody() Body {
+	return vehicle.body
+}
+
+// GetRider returns the runtime ID of the rider of the vehicle, or 0 if nobody rides it.
+func (vehicle *Vehicle) GetRider() uint64 {
+	vehicle.mutex.Lock()
+	defer vehicle.mutex.Unlock()
+	return vehicle.rider
+}
+
+// SetRider sets the runtime ID of the rider of the vehicle. 0 removes the rider, which also clears its input.
+// Returns false if the vehicle already has another rider.
+func (vehicle *Vehicle) SetRider(runtimeId uint64) bool {
+	vehicle.mutex.Lock()
+	defer vehicle.mutex.Unlock()
+	if runtimeId != 0 && vehicle.rider != 0 && vehicle.rider != runtimeId {
+		return false
+	}
+	vehicle.rider = runtimeId
+	if runtimeId == 0 {
+		vehicle.input = Input{}
+	}
+	return true
+}
+
+// SetInput sets the movement input of the rider.
+func (vehicle *Vehicle) SetInput(input Input) {
+	vehicle.mutex.Lock()
+	vehicle.input = input
+	vehicle.mutex.Unlock()
+}
+
+// GetMotion returns the motion of the vehicle in blocks per tick.
+func (vehicle *Vehicle) GetMotion() r3.Vector {
+	vehicle.mutex.Lock()
+	defer vehicle.mutex.Unlock()
+	return vehicle.motion
+}
+
+// SetMotion sets the motion of the vehicle in blocks per tick, for example when it gets pushed.
+func (vehicle *Vehicle) SetMotion(motion r3.Vector) {
+	vehicle.mutex.Lock()
+	vehicle.motion = motion
+	vehicle.mutex.Unlock()
+}
+
+// ValidateMove checks if a boat moved by its rider could move to the position in the given amount of ticks.
+// The horizontal distance is limited by the block below the boat: water, ice or land,
+// and boats only move up a little every tick. Minecarts are moved by the server and are never valid.
+func (vehicle *Vehicle) ValidateMove(world World, position r3.Vector, ticks int64, tolerance float64) bool {
+	if vehicle.kind != Boat {
+		return false
+	}
+	if ticks < 1 {
+		ticks = 1
+	}
+	var from = vehicle.body.GetPosition()
+	var below = world.GetBlockName(blockPosition(r3.Vector{X: from.X, Y: from.Y - 0.5, Z: from.Z}))
+	var at = world.GetBlockName(blockPosition(from))
+	var speed = BoatLandSpeed
+	switch {
+	case ice[below]:
+		speed = BoatIceSpeed
+	case water[below] || water[at]:
+		speed = BoatWaterSpeed
+	}
+	var horizontal = math.Hypot(position.X-from.X, position.Z-from.Z)
+	return horizontal <= speed*float64(ticks)+tolerance && position.Y-from.Y <= BoatClimb*float64(ticks)+tolerance
+}
+
+// Drive moves a boat to the position sent by its rider in the given server tick, facing in the direction of the yaw.
+// Returns false without moving the boat if it could not have moved there since the rider last moved it.
+func (vehicle *Vehicle) Drive(world World, position r3.Vector, yaw float64, tick int64) bool {
+	vehicle.mutex.Lock()
+	defer vehicle.mutex.Unlock()
+	var ticks = tick - vehicle.lastDrive
+	if ticks > MaxDriveTicks {
+		ticks = MaxDriveTicks
+	}
+	if !vehicle.ValidateMove(world, position, ticks, BoatTolerance) {
+		return false
+	}
+	vehicle.lastDrive = tick
+	vehicle.motion = position.Sub(vehicle.body.GetPosition())
+	vehicle.yaw = yaw
+	vehicle.body.Move(position, yaw, 0)
+	return true
+}
+
+// Tick moves a minecart along the rails it is on, pushed by the input of its rider and powered rails.
+// Minecarts not on rails fall down, and stop once they land. Boats are not moved by the server.
+// Returns true if the vehicle moved.
+func (vehicle *Vehicle) Tick(world World) bool {
+	if vehicle.kind != Minecart {
+		return false
+	}
+	vehicle.mutex.Lock()
+	defer vehicle.mutex.Unlock()
+
+	var position = vehicle.body.GetPosition()
+	var rail, onRail = railAt(world, position)
+	if !onRail {
+		var below = blockPosition(r3.Vector{X: position.X, Y: position.Y - MinecartGravity, Z: position.Z})
+		if world.GetBlockName(below) != "air" {
+			vehicle.motion = r3.Vector{}
+			return false
+		}
+		vehicle.motion = r3.Vector{Y: vehicle.motion.Y - MinecartGravity}
+		vehicle.body.Move(position.Add(vehicle.motion), vehicle.yaw, 0)
+		return true
+	}
+
+	var exit = vehicle.chooseExit(rail)
+	var speed = vehicle.motion.Norm()
+	if vehicle.input.Forward != 0 {
+		var yaw = vehicle.input.Yaw * math.Pi / 180
+		var push = r3.Vector{X: -math.Sin(yaw), Z: math.Cos(yaw)}.Mul(vehicle.input.Forward)
+		if push.Dot(r3.Vector{X: float64(exit.x), Z: float64(exit.z)}) < 0 {
+			speed -= MinecartAcceleration
+		} else {
+			speed += MinecartAcceleration
+		}
+		if speed < 0 {
+			exit, speed = opposite(rail, exit), -speed
+		}
+	}
+	if rail.Name == "golden_rail" {
+		if rail.Powered {
+			speed += MinecartBoost
+		} else {
+			speed = 0
+		}
+	}
+	if ascent, ok := railAscent[rail.Shape]; ok && ascent != exit {
+		speed += MinecartSlope
+	}
+	speed *= MinecartFriction
+	if speed > MinecartMaxSpeed {
+		speed = MinecartMaxSpeed
+	}
+	if speed < 1e-3 {
+		vehicle.motion = r3.Vector{}
+		return false
+	}
+
+	var target = follow(rail, position, exit, speed)
+	vehicle.motion = target.Sub(position)
+	vehicle.yaw = math.Atan2(-float64(exit.x), float64(exit.z)) * 180 / math.Pi
+	vehicle.body.Move(target, vehicle.yaw, 0)
+	return true
+}
+
+// chooseExit returns the exit of the rail the minecart moves towards: the exit closest to its motion.
+func (vehicle *Vehicle) chooseExit(rail Rail) direction {
+	var exits = railExits[rail.Shape]
+	var first = vehicle.motion.X*float64(exits[0].x) + vehicle.motion.Z*float64(exits[0].z)
+	var second = vehicle.motion.X*float64(exits[1].x) + vehicle.motion.Z*float64(exits[1].z)
+	if second > first {
+		return exits[1]
+	}
+	return exits[0]
+}
+
+// opposite returns the other exit of the rail.
+func opposite(rail Rail, exit direction) direction {
+	var exits = railExits[rail.Shape]
+	if exits[0] == exit {
+		return exits[1]
+	}
+	return exits[0]
+}
+
+// follow returns the position of a minecart at the position after moving the given distance along the rail towards the exit.
+// Minecarts are kept in the middle of straight rails, pass through the center of curved rails,
+// and move up and down along sloped rails.
+func follow(rail Rail, position r3.Vector, exit direction, distance float64) r3.Vector {
+	var center = r3.Vector{X: float64(rail.Position.X) + 0.5, Y: float64(rail.Position.Y), Z: float64(rail.Position.Z) + 0.5}
+	if rail.IsCurved() {
+		var toCenter = r3.Vector{X: center.X - position.X, Z: center.Z - position.Z}
+		var away = toCenter.Dot(r3.Vector{X: float64(exit.x), Z: float64(exit.z)}) < 0
+		if !away && toCenter.Norm() > distance {
+			return position.Add(toCenter.Normalize().Mul(distance))
+		}
+		if !away {
+			distance -= toCenter.Norm()
+			position = r3.Vector{X: center.X, Y: position.Y, Z: center.Z}
+		}
+	}
+	if exit.x != 0 {
+		position.Z = center.Z
+	} else {
+		position.X = center.X
+	}
+	position.X += float64(exit.x) * distance
+	position.Z += float64(exit.z) * distance
+
+	position.Y = center.Y
+	if ascent, ok := railAscent[rail.Shape]; ok {
+		// The height on a sloped rail grows with the progress in the direction it ascends in.
+		var progress = (position.X-float64(rail.Position.X))*float64(ascent.x) + (position.Z-float64(rail.Position.Z))*float64(ascent.z)
+		if ascent.x+ascent.z < 0 {
+			progress += 1
+		}
+		position.Y += math.Max(0, math.Min(1, progress))
+	}
+	return position
+}
+
+// railAt returns the rail at the position, or right below it for minecarts at the top of sloped rails.
+func railAt(world World, position r3.Vector) (Rail, bool) {
+	for _, offset := range []float64{0, -1} {
+		var block = blockPosition(r3.Vector{X: position.X, Y: position.Y + offset, Z: position.Z})
+		if name := world.GetBlockName(block); IsRail(name) {
+			return NewRail(block, name, world.GetBlockData(block)), true
+		}
+	}
+	return Rail{}, false
+}
+
+// blockPosition returns the position of the block holding the position.
+func blockPosition(position r3.Vector) blocks.Position {
+	if position.Y < 0 {
+		position.Y = 0
+	}
+	return blocks.NewPosition(int32(math.Floor(position.X)), uint32(math.Floor(position.Y)), int32(math.Floor(position.Z)))
+}
@@ -0,0 +1,144 @@
+package vehicles
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds/blocks"
+)
+
+// testBlock is a block of a test world.
+type testBlock struct {
+	name string
+	data byte
+}
+
+// testWorld is a world of a flat stone floor at y 0, with extra blocks set.
+type testWorld map[blocks.Position]testBlock
+
+func (world testWorld) GetBlockName(position blocks.Position) string {
+	if block, ok := world[position]; ok {
+		return block.name
+	}
+	if position.Y == 0 {
+		return "stone"
+	}
+	return "air"
+}
+
+func (world testWorld) GetBlockData(position blocks.Position) byte {
+	return world[position].data
+}
+
+type testBody struct {
+	position r3.Vector
+}
+
+func (body *testBody) GetRuntimeId() uint64 {
+	return 1
+}
+
+func (body *testBody) GetPosition() r3.Vector {
+	return body.position
+}
+
+func (body *testBody) Move(position r3.Vector, yaw float64, pitch float64) {
+	body.position = position
+}
+
+func TestMinecartFollowsRails(t *testing.T) {
+	var world = testWorld{}
+	for x := int32(0); x < 10; x++ {
+		world[blocks.NewPosition(x, 1, 0)] = testBlock{"rail", RailEastWest}
+	}
+	var body = &testBody{r3.Vector{X: 0.5, Y: 1, Z: 0.5}}
+	var minecart = NewVehicle(body, Minecart)
+	minecart.SetRider(2)
+	minecart.SetInput(Input{Forward: 1, Yaw: -90})
+
+	for i := 0; i < 20; i++ {
+		minecart.Tick(world)
+	}
+	if body.position.X <= 1 {
+		t.Errorf("minecart did not move east: %v", body.position)
+	}
+	if body.position.Z != 0.5 || body.position.Y != 1 {
+		t.Errorf("minecart left the rail: %v", body.position)
+	}
+}
+
+func TestMinecartCurve(t *testing.T) {
+	var world = testWorld{
+		blocks.NewPosition(0, 1, 0): {"rail", RailEastWest},
+		blocks.NewPosition(1, 1, 0): {"rail", RailSouthWest},
+		blocks.NewPosition(1, 1, 1): {"rail", RailNorthSouth},
+		blocks.NewPosition(1, 1, 2): {"rail", RailNorthSouth},
+	}
+	var body = &testBody{r3.Vector{X: 0.5, Y: 1, Z: 0.5}}
+	var minecart = NewVehicle(body, Minecart)
+	minecart.SetMotion(r3.Vector{X: MinecartMaxSpeed})
+
+	for i := 0; i < 6; i++ {
+		minecart.Tick(world)
+	}
+	if body.position.X != 1.5 || body.position.Z <= 1 {
+		t.Errorf("minecart did not follow the curve south: %v", body.position)
+	}
+}
+
+func TestUnpoweredGoldenRailBrakes(t *testing.T) {
+	var world = testWorld{
+		blocks.NewPosition(0, 1, 0): {"golden_rail", RailEastWest},
+	}
+	var body = &testBody{r3.Vector{X: 0.5, Y: 1, Z: 0.5}}
+	var minecart = NewVehicle(body, Minecart)
+	minecart.SetMotion(r3.Vector{X: MinecartMaxSpeed})
+
+	if minecart.Tick(world) {
+		t.Error("minecart moved over an unpowered golden rail")
+	}
+	world[blocks.NewPosition(0, 1, 0)] = testBlock{"golden_rail", RailEastWest | RailPowered}
+	minecart.SetMotion(r3.Vector{X: 0.1})
+	if !minecart.Tick(world) {
+		t.Error("minecart did not move over a powered golden rail")
+	}
+}
+
+func TestBoatValidateMove(t *testing.T) {
+	var world = testWorld{}
+	for x := int32(0); x < 10; x++ {
+		world[blocks.NewPosition(x, 0, 0)] = testBlock{name: "water"}
+	}
+	var body = &testBody{r3.Vector{X: 0.5, Y: 1, Z: 0.5}}
+	var boat = NewVehicle(body, Boat)
+
+	if !boat.ValidateMove(world, r3.Vector{X: 0.8, Y: 1, Z: 0.5}, 1, 0) {
+		t.Error("boat could not move on water")
+	}
+	if boat.ValidateMove(world, r3.Vector{X: 5.5, Y: 1, Z: 0.5}, 1, 0) {
+		t.Error("boat moved too far on water")
+	}
+	body.position = r3.Vector{X: 0.5, Y: 1, Z: 5.5}
+	if boat.ValidateMove(world, r3.Vector{X: 0.8, Y: 1, Z: 5.5}, 1, 0) {
+		t.Error("boat moved as fast on land as on water")
+	}
+	if NewVehicle(body, Minecart).ValidateMove(world, body.position, 1, 0) {
+		t.Error("minecart moves were accepted from the client")
+	}
+}
+
+func TestBoatDrive(t *testing.T) {
+	var world = testWorld{blocks.NewPosition(0, 0, 0): {name: "water"}}
+	var body = &testBody{r3.Vector{X: 0.5, Y: 1, Z: 0.5}}
+	var boat = NewVehicle(body, Boat)
+
+	if boat.Drive(world, r3.Vector{X: 20, Y: 1, Z: 0.5}, 0, 1) {
+		t.Error("boat was driven too far")
+	}
+	if body.position.X != 0.5 {
+		t.Errorf("boat moved after an invalid drive: %v", body.position)
+	}
+	if !boat.Drive(world, r3.Vector{X: 0.7, Y: 1, Z: 0.5}, 0, 2) {
+		t.Error("boat could not be driven on water")
+	}
+}
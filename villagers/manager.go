@@ -0,0 +1,115 @@
+package villagers
+
+import (
+	"sync"
+
+	"github.com/irmine/worlds/blocks"
+)
+
+// Manager keeps track of the villagers of a dimension by the runtime ID of their entity,
+// and of the workstations claimed by them. A workstation can be claimed by one villager at a time.
+type Manager struct {
+	mutex     sync.Mutex
+	villagers map[uint64]*Villager
+	claims    map[blocks.Position]uint64
+}
+
+// NewManager returns a new manager without villagers.
+func NewManager() *Manager {
+	return &Manager{villagers: make(map[uint64]*Villager), claims: make(map[blocks.Position]uint64)}
+}
+
+// Add adds the villager of the entity with the given runtime ID.
+// The workstation of the villager is claimed for it, unless another villager claimed it first.
+func (manager *Manager) Add(runtimeId uint64, villager *Villager) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	manager.villagers[runtimeId] = villager
+	if position, ok := villager.GetWorkstation(); ok {
+		if _, claimed := manager.claims[position]; claimed {
+			villager.ClearWorkstation()
+		} else {
+			manager.claims[position] = runtimeId
+		}
+	}
+}
+
+// Remove removes the villager of the entity with the given runtime ID, releasing its workstation.
+// Returns false if the entity was not a villager.
+func (manager *Manager) Remove(runtimeId uint64) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var villager, ok = manager.villagers[runtimeId]
+	if !ok {
+		return false
+	}
+	if position, ok := villager.GetWorkstation(); ok && manager.claims[position] == runtimeId {
+		delete(manager.claims, position)
+	}
+	delete(manager.villagers, runtimeId)
+	return true
+}
+
+// Get returns the villager of the entity with the given runtime ID, and a bool indicating if it was found.
+func (manager *Manager) Get(runtimeId uint64) (*Villager, bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var villager, ok = manager.villagers[runtimeId]
+	return villager, ok
+}
+
+// GetVillagers returns all villagers of the manager by the runtime ID of their entity.
+func (manager *Manager) GetVillagers() map[uint64]*Villager {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var villagers = make(map[uint64]*Villager, len(manager.villagers))
+	for runtimeId, villager := range manager.villagers {
+		villagers[runtimeId] = villager
+	}
+	return villagers
+}
+
+// Claim claims the workstation at the position for the villager of the entity with the given runtime ID,
+// releasing the workstation it claimed before. Returns false if another villager already claimed the workstation.
+func (manager *Manager) Claim(runtimeId uint64, position blocks.Position) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var villager, ok = manager.villagers[runtimeId]
+	if !ok {
+		return false
+	}
+	if claimant, claimed := manager.claims[position]; claimed && claimant != runtimeId {
+		return false
+	}
+	if previous, ok := villager.GetWorkstation(); ok && manager.claims[previous] == runtimeId {
+		delete(manager.claims, previous)
+	}
+	manager.claims[position] = runtimeId
+	villager.SetWorkstation(position)
+	return true
+}
+
+// IsClaimed checks if the workstation at the position was claimed by a villager.
+func (manager *Manager) IsClaimed(position blocks.Position) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var _, ok = manager.claims[position]
+	return ok
+}
+
+// Release releases the workstation at the position, which happens when it gets broken.
+// The runtime ID of the villager that claimed the workstation is returned,
+// along with a bool indicating if the workstation was claimed.
+func (manager *Manager) Release(position blocks.Position) (uint64, bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var runtimeId, ok = manager.claims[position]
+	if !ok {
+		return 0, false
+	}
+	delete(manager.claims, position)
+	if villager, ok := manager.villagers[runtimeId]; ok {
+		villager.ClearWorkstation()
+	}
+	return runtimeId, true
+}
@@ -0,0 +1,70 @@
+package villagers
+
+import (
+	"strconv"
+
+	"github.com/irmine/gonbt"
+)
+
+// NBT tag names of trade offers, as sent to clients in the trading window.
+const (
+	TagRecipes             = "Recipes"
+	TagTierExpRequirements = "TierExpRequirements"
+	TagBuyA                = "buyA"
+	TagBuyB                = "buyB"
+	TagSell                = "sell"
+	TagMaxUses             = "maxUses"
+	TagUses                = "uses"
+	TagRewardExp           = "rewardExp"
+	TagTraderExp           = "traderExp"
+	TagTier                = "tier"
+	TagName                = "Name"
+	TagCount               = "Count"
+	TagDamage              = "Damage"
+)
+
+// ToNBT returns the offers of the villager and the experience it needs for every level
+// as the compound sent to clients when opening the trading window.
+func (villager *Villager) ToNBT() *gonbt.Compound {
+	var recipes = make([]gonbt.INamedTag, 0, len(villager.offers))
+	for _, offer := range villager.offers {
+		recipes = append(recipes, offer.ToNBT())
+	}
+	var tiers = make([]gonbt.INamedTag, 0, MaxLevel)
+	for level := 1; level <= MaxLevel; level++ {
+		var key = strconv.Itoa(level - 1)
+		tiers = append(tiers, gonbt.NewCompound("", map[string]gonbt.INamedTag{
+			key: gonbt.NewInt(key, int32(GetLevelExperience(level))),
+		}))
+	}
+	var compound = gonbt.NewCompound("", make(map[string]gonbt.INamedTag))
+	compound.SetList(TagRecipes, gonbt.TAG_Compound, recipes)
+	compound.SetList(TagTierExpRequirements, gonbt.TAG_Compound, tiers)
+	return compound
+}
+
+// ToNBT returns the offer as a recipe compound of the trading window.
+// Offers unlocked at level 1 have tier 0.
+func (offer *Offer) ToNBT() *gonbt.Compound {
+	var compound = gonbt.NewCompound("", make(map[string]gonbt.INamedTag))
+	compound.SetCompound(TagBuyA, ingredientToNBT(offer.BuyA).GetTags())
+	if !offer.BuyB.IsEmpty() {
+		compound.SetCompound(TagBuyB, ingredientToNBT(offer.BuyB).GetTags())
+	}
+	compound.SetCompound(TagSell, ingredientToNBT(offer.Sell).GetTags())
+	compound.SetInt(TagMaxUses, int32(offer.MaxUses))
+	compound.SetInt(TagUses, int32(offer.Uses))
+	compound.SetByte(TagRewardExp, 1)
+	compound.SetInt(TagTraderExp, int32(offer.Experience))
+	compound.SetInt(TagTier, int32(offer.Level-1))
+	return compound
+}
+
+// ingredientToNBT returns a compound holding the string ID and count of the ingredient.
+func ingredientToNBT(ingredient Ingredient) *gonbt.Compound {
+	var compound = gonbt.NewCompound("", make(map[string]gonbt.INamedTag))
+	compound.SetString(TagName, ingredient.Id)
+	compound.SetByte(TagCount, byte(ingredient.Count))
+	compound.SetShort(TagDamage, 0)
+	return compound
+}
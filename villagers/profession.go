@@ -0,0 +1,232 @@
+package villagers
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// Ingredient is an amount of items of a type, bought or sold in a trade.
+type Ingredient struct {
+	// Id is the string ID of the item, such as "minecraft:emerald".
+	Id string `json:"id"`
+	// Count is the amount of items. Ingredients with a count of 0 are left out of the trade.
+	Count int `json:"count"`
+}
+
+// IsEmpty checks if the ingredient holds no items.
+func (ingredient Ingredient) IsEmpty() bool {
+	return ingredient.Id == "" || ingredient.Count <= 0
+}
+
+// Offer is a trade offered by a villager.
+type Offer struct {
+	// BuyA and BuyB are the items the player pays. BuyB is optional.
+	BuyA Ingredient `json:"buyA"`
+	BuyB Ingredient `json:"buyB"`
+	// Sell is the item the player gets.
+	Sell Ingredient `json:"sell"`
+	// MaxUses is the amount of times the offer can be traded before the villager restocks.
+	MaxUses int `json:"maxUses"`
+	// Uses is the amount of times the offer was traded since the last restock.
+	Uses int `json:"uses"`
+	// Experience is the experience the villager gains when the offer is traded.
+	Experience int `json:"experience"`
+	// Level is the level of the villager at which the offer got unlocked.
+	Level int `json:"level"`
+}
+
+// IsExhausted checks if the offer can not be traded until the villager restocks.
+func (offer *Offer) IsExhausted() bool {
+	return offer.Uses >= offer.MaxUses
+}
+
+// Profession is a job of villagers, which decides the trades of the villager.
+// Villagers take a profession by claiming the workstation block of the profession.
+type Profession struct {
+	// Id is the ID of the profession, such as "farmer".
+	Id string
+	// Name is the name of the profession shown in the trading window.
+	Name string
+	// Variant is the variant entity data of villagers with the profession, which decides their clothing.
+	Variant int32
+	// Workstation is the name of the block villagers with the profession work at.
+	Workstation string
+	// Trades holds the trades villagers may unlock per level, starting at level 1.
+	// Villagers unlock TradesPerLevel random trades of a level once they reach it.
+	Trades [MaxLevel][]Offer
+}
+
+// PickOffers returns TradesPerLevel random offers of the profession unlocked at the given level.
+func (profession *Profession) PickOffers(level int, random *rand.Rand) []*Offer {
+	if level < 1 || level > MaxLevel {
+		return nil
+	}
+	var trades = profession.Trades[level-1]
+	var offers = make([]*Offer, 0, TradesPerLevel)
+	for _, i := range random.Perm(len(trades)) {
+		if len(offers) == TradesPerLevel {
+			break
+		}
+		var offer = trades[i]
+		offer.Level = level
+		offer.Uses = 0
+		offers = append(offers, &offer)
+	}
+	return offers
+}
+
+var professionMutex sync.RWMutex
+
+// professions holds all registered professions by ID.
+var professions = make(map[string]*Profession)
+
+// RegisterProfession registers the profession, replacing any profession registered with the same ID.
+func RegisterProfession(profession *Profession) {
+	professionMutex.Lock()
+	professions[profession.Id] = profession
+	professionMutex.Unlock()
+}
+
+// GetProfession returns the profession with the given ID, and a bool indicating if it was registered.
+func GetProfession(id string) (*Profession, bool) {
+	professionMutex.RLock()
+	defer professionMutex.RUnlock()
+	var profession, ok = professions[id]
+	return profession, ok
+}
+
+// GetProfessionByWorkstation returns the profession with the workstation block with the given name,
+// and a bool indicating if the block is the workstation of a profession.
+func GetProfessionByWorkstation(blockName string) (*Profession, bool) {
+	professionMutex.RLock()
+	defer professionMutex.RUnlock()
+	for _, profession := range professions {
+		if profession.Workstation == blockName {
+			return profession, true
+		}
+	}
+	return nil, false
+}
+
+// IsWorkstation checks if the block with the given name is the workstation of a profession.
+func IsWorkstation(blockName string) bool {
+	var _, ok = GetProfessionByWorkstation(blockName)
+	return ok
+}
+
+// emeralds returns an ingredient of the given amount of emeralds.
+func emeralds(count int) Ingredient {
+	return Ingredient{"minecraft:emerald", count}
+}
+
+// item returns an ingredient of the given amount of items with the string ID.
+func item(id string, count int) Ingredient {
+	return Ingredient{id, count}
+}
+
+func init() {
+	RegisterProfession(&Profession{Id: "farmer", Name: "Farmer", Variant: 1, Workstation: "composter", Trades: [MaxLevel][]Offer{
+		{
+			{BuyA: item("minecraft:wheat", 20), Sell: emeralds(1), MaxUses: 16, Experience: 2},
+			{BuyA: item("minecraft:potato", 26), Sell: emeralds(1), MaxUses: 16, Experience: 2},
+			{BuyA: item("minecraft:carrot", 22), Sell: emeralds(1), MaxUses: 16, Experience: 2},
+			{BuyA: item("minecraft:beetroot", 15), Sell: emeralds(1), MaxUses: 16, Experience: 2},
+		},
+		{
+			{BuyA: emeralds(1), Sell: item("minecraft:baked_potato", 6), MaxUses: 16, Experience: 5},
+			{BuyA: item("minecraft:wheat_seeds", 32), Sell: emeralds(1), MaxUses: 12, Experience: 10},
+		},
+		{
+			{BuyA: item("minecraft:sugar_cane", 24), Sell: emeralds(1), MaxUses: 12, Experience: 20},
+			{BuyA: item("minecraft:cactus", 16), Sell: emeralds(1), MaxUses: 12, Experience: 20},
+		},
+		{
+			{BuyA: item("minecraft:bamboo", 32), Sell: emeralds(1), MaxUses: 12, Experience: 30},
+			{BuyA: emeralds(1), Sell: item("minecraft:bone_meal", 8), MaxUses: 12, Experience: 15},
+		},
+		{
+			{BuyA: emeralds(3), Sell: item("minecraft:golden_hoe", 1), MaxUses: 12, Experience: 30},
+		},
+	}})
+	RegisterProfession(&Profession{Id: "librarian", Name: "Librarian", Variant: 5, Workstation: "lectern", Trades: [MaxLevel][]Offer{
+		{
+			{BuyA: item("minecraft:book", 4), Sell: emeralds(1), MaxUses: 12, Experience: 2},
+			{BuyA: emeralds(1), Sell: item("minecraft:glass", 4), MaxUses: 12, Experience: 1},
+		},
+		{
+			{BuyA: emeralds(1), Sell: item("minecraft:lapis_lazuli", 2), MaxUses: 12, Experience: 5},
+		},
+		{
+			{BuyA: emeralds(1), Sell: item("minecraft:glowstone_dust", 4), MaxUses: 12, Experience: 10},
+		},
+		{
+			{BuyA: item("minecraft:redstone", 16), Sell: emeralds(1), MaxUses: 12, Experience: 15},
+		},
+		{
+			{BuyA: emeralds(20), Sell: item("minecraft:obsidian", 1), MaxUses: 12, Experience: 30},
+		},
+	}})
+	RegisterProfession(&Profession{Id: "armorer", Name: "Armorer", Variant: 8, Workstation: "blast_furnace", Trades: [MaxLevel][]Offer{
+		{
+			{BuyA: item("minecraft:coal", 15), Sell: emeralds(1), MaxUses: 16, Experience: 2},
+			{BuyA: emeralds(5), Sell: item("minecraft:iron_helmet", 1), MaxUses: 12, Experience: 1},
+			{BuyA: emeralds(7), Sell: item("minecraft:iron_leggings", 1), MaxUses: 12, Experience: 1},
+			{BuyA: emeralds(4), Sell: item("minecraft:iron_boots", 1), MaxUses: 12, Experience: 1},
+		},
+		{
+			{BuyA: item("minecraft:iron_ingot", 4), Sell: emeralds(1), MaxUses: 12, Experience: 10},
+			{BuyA: emeralds(9), Sell: item("minecraft:iron_chestplate", 1), MaxUses: 12, Experience: 5},
+		},
+		{
+			{BuyA: emeralds(1), Sell: item("minecraft:chainmail_boots", 1), MaxUses: 12, Experience: 10},
+			{BuyA: emeralds(3), Sell: item("minecraft:chainmail_leggings", 1), MaxUses: 12, Experience: 10},
+		},
+		{
+			{BuyA: item("minecraft:diamond", 1), Sell: emeralds(1), MaxUses: 12, Experience: 15},
+			{BuyA: emeralds(1), Sell: item("minecraft:chainmail_helmet", 1), MaxUses: 12, Experience: 10},
+		},
+		{
+			{BuyA: emeralds(16), BuyB: item("minecraft:diamond", 2), Sell: item("minecraft:diamond_chestplate", 1), MaxUses: 3, Experience: 30},
+			{BuyA: emeralds(12), BuyB: item("minecraft:diamond", 2), Sell: item("minecraft:diamond_helmet", 1), MaxUses: 3, Experience: 30},
+		},
+	}})
+	RegisterProfession(&Profession{Id: "butcher", Name: "Butcher", Variant: 4, Workstation: "smoker", Trades: [MaxLevel][]Offer{
+		{
+			{BuyA: item("minecraft:chicken", 14), Sell: emeralds(1), MaxUses: 16, Experience: 2},
+			{BuyA: item("minecraft:porkchop", 7), Sell: emeralds(1), MaxUses: 16, Experience: 2},
+		},
+		{
+			{BuyA: item("minecraft:coal", 15), Sell: emeralds(1), MaxUses: 16, Experience: 2},
+			{BuyA: emeralds(1), Sell: item("minecraft:cooked_porkchop", 5), MaxUses: 16, Experience: 5},
+			{BuyA: emeralds(1), Sell: item("minecraft:cooked_chicken", 8), MaxUses: 16, Experience: 5},
+		},
+		{
+			{BuyA: item("minecraft:beef", 10), Sell: emeralds(1), MaxUses: 16, Experience: 20},
+		},
+		{
+			{BuyA: emeralds(1), Sell: item("minecraft:cooked_beef", 5), MaxUses: 16, Experience: 15},
+		},
+		{
+			{BuyA: item("minecraft:cooked_beef", 10), Sell: emeralds(1), MaxUses: 12, Experience: 30},
+		},
+	}})
+	RegisterProfession(&Profession{Id: "mason", Name: "Mason", Variant: 13, Workstation: "stonecutter_block", Trades: [MaxLevel][]Offer{
+		{
+			{BuyA: item("minecraft:clay_ball", 10), Sell: emeralds(1), MaxUses: 16, Experience: 2},
+			{BuyA: emeralds(1), Sell: item("minecraft:brick", 10), MaxUses: 16, Experience: 1},
+		},
+		{
+			{BuyA: item("minecraft:stone", 20), Sell: emeralds(1), MaxUses: 16, Experience: 10},
+		},
+		{
+			{BuyA: item("minecraft:cobblestone", 32), Sell: emeralds(1), MaxUses: 16, Experience: 20},
+			{BuyA: emeralds(1), Sell: item("minecraft:sandstone", 4), MaxUses: 16, Experience: 10},
+		},
+		{
+			{BuyA: item("minecraft:quartz", 12), Sell: emeralds(1), MaxUses: 12, Experience: 30},
+		},
+		{
+			{BuyA: emeralds(1), Sell: item("minecraft:obsidian", 1), MaxUses: 12, Experience: 30},
+		},
+	}})
+}
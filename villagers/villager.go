@@ -0,0 +1,214 @@
+package villagers
+
+import (
+	"encoding/json"
+	"math/rand"
+
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	// MaxLevel is the highest level of villagers.
+	MaxLevel = 5
+	// TradesPerLevel is the amount of trades villagers unlock for every level.
+	TradesPerLevel = 2
+	// MaxRestocks is the maximum amount of times a villager restocks its trades every day.
+	MaxRestocks = 2
+	// WorkStart and WorkEnd are the times of day between which villagers work at their workstation and restock.
+	WorkStart = 2000
+	WorkEnd   = 9000
+	// DayLength is the amount of ticks in a day.
+	DayLength = 24000
+)
+
+// levelExperience holds the experience villagers need to reach every level, starting at level 1.
+var levelExperience = [MaxLevel]int{0, 10, 70, 150, 250}
+
+// GetLevelExperience returns the experience villagers need to reach the level.
+func GetLevelExperience(level int) int {
+	if level < 1 {
+		return 0
+	}
+	if level > MaxLevel {
+		level = MaxLevel
+	}
+	return levelExperience[level-1]
+}
+
+// IsWorkTime checks if villagers work at the given world time.
+func IsWorkTime(time int64) bool {
+	var timeOfDay = time % DayLength
+	return timeOfDay >= WorkStart && timeOfDay < WorkEnd
+}
+
+// Villager is the profession, level and trades of a villager.
+// Unemployed villagers have no profession and do not trade.
+type Villager struct {
+	profession  *Profession
+	level       int
+	experience  int
+	offers      []*Offer
+	workstation blocks.Position
+	employed    bool
+	restockDay  int64
+	restocks    int
+}
+
+// New returns a new unemployed villager.
+func New() *Villager {
+	return &Villager{level: 1}
+}
+
+// GetProfession returns the profession of the villager, or nil if the villager is unemployed.
+func (villager *Villager) GetProfession() *Profession {
+	return villager.profession
+}
+
+// HasProfession checks if the villager has a profession.
+func (villager *Villager) HasProfession() bool {
+	return villager.profession != nil
+}
+
+// CanChangeProfession checks if the villager may lose or change its profession.
+// Villagers that were traded with keep their profession forever.
+func (villager *Villager) CanChangeProfession() bool {
+	return villager.experience == 0
+}
+
+// SetProfession sets the profession of the villager, resetting its level and unlocking the trades of level 1.
+// Nil makes the villager unemployed.
+func (villager *Villager) SetProfession(profession *Profession, random *rand.Rand) {
+	villager.profession = profession
+	villager.level = 1
+	villager.experience = 0
+	villager.offers = nil
+	if profession != nil {
+		villager.offers = profession.PickOffers(1, random)
+	}
+}
+
+// GetLevel returns the level of the villager, from 1 to MaxLevel.
+func (villager *Villager) GetLevel() int {
+	return villager.level
+}
+
+// GetExperience returns the experience the villager gained from trading.
+func (villager *Villager) GetExperience() int {
+	return villager.experience
+}
+
+// GetOffers returns the trades offered by the villager.
+func (villager *Villager) GetOffers() []*Offer {
+	return villager.offers
+}
+
+// GetWorkstation returns the position of the workstation claimed by the villager,
+// and a bool indicating if the villager claimed a workstation.
+func (villager *Villager) GetWorkstation() (blocks.Position, bool) {
+	return villager.workstation, villager.employed
+}
+
+// SetWorkstation sets the position of the workstation claimed by the villager.
+func (villager *Villager) SetWorkstation(position blocks.Position) {
+	villager.workstation = position
+	villager.employed = true
+}
+
+// ClearWorkstation clears the workstation of the villager, which happens when its workstation gets broken.
+func (villager *Villager) ClearWorkstation() {
+	villager.employed = false
+}
+
+// Trade trades the offer with the given index once, giving the villager the experience of the offer.
+// Villagers that gain enough experience level up and unlock new trades.
+// Returns false if the offer does not exist or is exhausted.
+func (villager *Villager) Trade(index int, random *rand.Rand) bool {
+	if index < 0 || index >= len(villager.offers) || villager.offers[index].IsExhausted() {
+		return false
+	}
+	var offer = villager.offers[index]
+	offer.Uses++
+	villager.experience += offer.Experience
+	for villager.level < MaxLevel && villager.experience >= GetLevelExperience(villager.level+1) {
+		villager.level++
+		villager.offers = append(villager.offers, villager.profession.PickOffers(villager.level, random)...)
+	}
+	return true
+}
+
+// NeedsRestock checks if any offer of the villager was traded since the last restock.
+func (villager *Villager) NeedsRestock() bool {
+	for _, offer := range villager.offers {
+		if offer.Uses > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// CanRestock checks if the villager may restock at the given world time.
+// Villagers restock during work time, at most MaxRestocks times a day.
+func (villager *Villager) CanRestock(time int64) bool {
+	if !IsWorkTime(time) {
+		return false
+	}
+	return villager.restockDay != time/DayLength || villager.restocks < MaxRestocks
+}
+
+// Restock makes all offers of the villager available again at the given world time.
+func (villager *Villager) Restock(time int64) {
+	if day := time / DayLength; villager.restockDay != day {
+		villager.restockDay, villager.restocks = day, 0
+	}
+	villager.restocks++
+	for _, offer := range villager.offers {
+		offer.Uses = 0
+	}
+}
+
+// state is the persisted state of a villager.
+type state struct {
+	Profession  string           `json:"profession,omitempty"`
+	Level       int              `json:"level"`
+	Experience  int              `json:"experience"`
+	Offers      []*Offer         `json:"offers,omitempty"`
+	Workstation *blocks.Position `json:"workstation,omitempty"`
+	RestockDay  int64            `json:"restockDay"`
+	Restocks    int              `json:"restocks"`
+}
+
+// Encode returns the villager encoded as JSON, to be saved with its entity.
+func (villager *Villager) Encode() string {
+	var s = state{Level: villager.level, Experience: villager.experience, Offers: villager.offers, RestockDay: villager.restockDay, Restocks: villager.restocks}
+	if villager.profession != nil {
+		s.Profession = villager.profession.Id
+	}
+	if villager.employed {
+		var workstation = villager.workstation
+		s.Workstation = &workstation
+	}
+	var encoded, _ = json.Marshal(s)
+	return string(encoded)
+}
+
+// Decode returns the villager encoded with Encode.
+// Villagers with a profession that is no longer registered become unemployed.
+func Decode(encoded string) (*Villager, error) {
+	var s state
+	if err := json.Unmarshal([]byte(encoded), &s); err != nil {
+		return nil, err
+	}
+	var villager = &Villager{level: s.Level, experience: s.Experience, offers: s.Offers, restockDay: s.RestockDay, restocks: s.Restocks}
+	if villager.level < 1 || villager.level > MaxLevel {
+		villager.level = 1
+	}
+	if profession, ok := GetProfession(s.Profession); ok {
+		villager.profession = profession
+	} else {
+		villager.level, villager.experience, villager.offers = 1, 0, nil
+	}
+	if s.Workstation != nil {
+		villager.SetWorkstation(*s.Workstation)
+	}
+	return villager, nil
+}
@@ -0,0 +1,119 @@
+package villagers
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds/blocks"
+)
+
+func TestTrade(t *testing.T) {
+	var random = rand.New(rand.NewSource(1))
+	var farmer, ok = GetProfessionByWorkstation("composter")
+	if !ok {
+		t.Fatal("expected composter to be the workstation of farmers")
+	}
+	var villager = New()
+	villager.SetProfession(farmer, random)
+	if len(villager.GetOffers()) != TradesPerLevel {
+		t.Fatalf("expected %v offers, got %v", TradesPerLevel, len(villager.GetOffers()))
+	}
+
+	var offer = villager.GetOffers()[0]
+	for i := 0; i < offer.MaxUses; i++ {
+		if !villager.Trade(0, random) {
+			t.Fatalf("trade %v failed", i)
+		}
+	}
+	if villager.Trade(0, random) {
+		t.Error("expected exhausted offer to not be tradeable")
+	}
+	if villager.GetLevel() < 2 || len(villager.GetOffers()) <= TradesPerLevel {
+		t.Errorf("expected villager to level up and unlock offers, got level %v with %v offers", villager.GetLevel(), len(villager.GetOffers()))
+	}
+	if villager.CanChangeProfession() {
+		t.Error("expected traded villager to keep its profession")
+	}
+}
+
+func TestRestock(t *testing.T) {
+	var random = rand.New(rand.NewSource(1))
+	var profession, _ = GetProfession("librarian")
+	var villager = New()
+	villager.SetProfession(profession, random)
+	villager.Trade(0, random)
+
+	if villager.CanRestock(DayLength + WorkEnd) {
+		t.Error("expected villager to not restock after work")
+	}
+	for i := 0; i < MaxRestocks; i++ {
+		if !villager.CanRestock(DayLength + WorkStart) {
+			t.Fatalf("expected restock %v to be allowed", i)
+		}
+		villager.Restock(DayLength + WorkStart)
+	}
+	if villager.NeedsRestock() {
+		t.Error("expected offers to be restocked")
+	}
+	if villager.CanRestock(DayLength + WorkStart) {
+		t.Error("expected no more restocks on the same day")
+	}
+	if !villager.CanRestock(2*DayLength + WorkStart) {
+		t.Error("expected restocks on the next day")
+	}
+}
+
+func TestClaims(t *testing.T) {
+	var manager = NewManager()
+	var position = blocks.NewPosition(1, 64, 1)
+	manager.Add(1, New())
+	manager.Add(2, New())
+	if !manager.Claim(1, position) || manager.Claim(2, position) {
+		t.Fatal("expected only the first villager to claim the workstation")
+	}
+	if runtimeId, ok := manager.Release(position); !ok || runtimeId != 1 {
+		t.Fatalf("expected workstation claimed by 1, got %v", runtimeId)
+	}
+	var villager, _ = manager.Get(1)
+	if _, ok := villager.GetWorkstation(); ok {
+		t.Error("expected released workstation to be cleared")
+	}
+	if !manager.Claim(2, position) || !manager.Remove(2) || manager.IsClaimed(position) {
+		t.Error("expected removed villager to release its workstation")
+	}
+}
+
+func TestEncode(t *testing.T) {
+	var random = rand.New(rand.NewSource(1))
+	var profession, _ = GetProfession("mason")
+	var villager = New()
+	villager.SetProfession(profession, random)
+	villager.SetWorkstation(blocks.NewPosition(4, 70, -2))
+	villager.Trade(1, random)
+
+	var decoded, err = Decode(villager.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.GetProfession() != profession || decoded.GetExperience() != villager.GetExperience() || len(decoded.GetOffers()) != len(villager.GetOffers()) {
+		t.Error("decoded villager does not match encoded villager")
+	}
+	if position, ok := decoded.GetWorkstation(); !ok || position != blocks.NewPosition(4, 70, -2) {
+		t.Errorf("unexpected workstation %v", position)
+	}
+	if decoded.GetOffers()[1].Uses != 1 {
+		t.Error("expected uses to be kept")
+	}
+}
+
+func TestWorkstationDistance(t *testing.T) {
+	var villager = New()
+	if villager.IsAtWorkstation(r3.Vector{}) {
+		t.Error("expected villager without workstation to never be at its workstation")
+	}
+	villager.SetWorkstation(blocks.NewPosition(0, 64, 0))
+	if !villager.IsAtWorkstation(r3.Vector{X: 1.5, Y: 64, Z: 0.5}) || villager.IsAtWorkstation(r3.Vector{X: 5, Y: 64, Z: 0.5}) {
+		t.Error("unexpected workstation distance")
+	}
+}
@@ -0,0 +1,55 @@
+package villagers
+
+import (
+	"github.com/BobbyShrd/gominetest/ai"
+	"github.com/golang/geo/r3"
+)
+
+const (
+	// WorkDistance is the distance to the center of their workstation within which villagers work at it.
+	WorkDistance = 2.0
+	// WorkRepathInterval is the amount of ticks after which villagers walking to their workstation find a new path.
+	WorkRepathInterval = 100
+)
+
+// IsAtWorkstation checks if the position is within WorkDistance of the workstation of the villager.
+func (villager *Villager) IsAtWorkstation(position r3.Vector) bool {
+	var workstation, ok = villager.GetWorkstation()
+	if !ok {
+		return false
+	}
+	var center = r3.Vector{X: float64(workstation.X) + 0.5, Y: float64(workstation.Y), Z: float64(workstation.Z) + 0.5}
+	return position.Distance(center) <= WorkDistance
+}
+
+// Work makes a villager with a workstation walk to it during work time.
+type Work struct {
+	villager *Villager
+	// TimeFunction returns the current time of the world the villager is in.
+	TimeFunction func() int64
+
+	lastPath int64
+}
+
+// NewWork returns a new work behavior for the villager, getting the time of the world with the function.
+func NewWork(villager *Villager, timeFunction func() int64) *Work {
+	return &Work{villager: villager, TimeFunction: timeFunction, lastPath: -WorkRepathInterval}
+}
+
+// IsActive checks if it is work time and the villager is not yet at its workstation.
+func (work *Work) IsActive(mob *ai.Mob, context *ai.Context) bool {
+	if _, ok := work.villager.GetWorkstation(); !ok || !IsWorkTime(work.TimeFunction()) {
+		return false
+	}
+	return !work.villager.IsAtWorkstation(mob.GetBody().GetPosition())
+}
+
+// Tick makes the mob walk to the workstation, finding a new path once in a while.
+func (work *Work) Tick(mob *ai.Mob, context *ai.Context) {
+	if context.Tick-work.lastPath < WorkRepathInterval {
+		return
+	}
+	var workstation, _ = work.villager.GetWorkstation()
+	mob.MoveTo(context.World, workstation)
+	work.lastPath = context.Tick
+}
@@ -0,0 +1,145 @@
+package waterlog
+
+import (
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/chunkstore"
+	"github.com/irmine/gonbt"
+	"github.com/irmine/worlds/blocks"
+)
+
+// TagWater is the name of the list holding the waterlogged positions of a chunk file.
+const TagWater = "Water"
+
+// Layer is the second block storage layer of a dimension.
+// It holds the positions of waterlogged blocks, whose water is stored in the second layer,
+// while the waterlogged block itself stays in the first layer of the sub-chunk.
+// The chunk format of the world only saves the first layer, so the layer saves its water
+// with one NBT file for every chunk holding waterlogged blocks.
+type Layer struct {
+	mutex     sync.RWMutex
+	store     *chunkstore.Store
+	positions map[blocks.Position]struct{}
+	loaded    map[[2]int32]bool
+}
+
+// NewLayer returns a new layer without any waterlogged blocks, keeping its files in the given directory.
+// The water of the layer is not saved if the directory is empty.
+func NewLayer(directory string) *Layer {
+	return &Layer{store: chunkstore.New(directory, TagWater), positions: make(map[blocks.Position]struct{}), loaded: make(map[[2]int32]bool)}
+}
+
+// Set sets water in the second layer at the given position.
+// Returns true if the position was not yet waterlogged.
+func (layer *Layer) Set(position blocks.Position) bool {
+	layer.mutex.Lock()
+	defer layer.mutex.Unlock()
+	if _, ok := layer.positions[position]; ok {
+		return false
+	}
+	layer.positions[position] = struct{}{}
+	layer.loaded[[2]int32{position.X >> 4, position.Z >> 4}] = true
+	return true
+}
+
+// Remove drains the water in the second layer at the given position.
+// Returns true if the position was waterlogged.
+func (layer *Layer) Remove(position blocks.Position) bool {
+	layer.mutex.Lock()
+	defer layer.mutex.Unlock()
+	if _, ok := layer.positions[position]; !ok {
+		return false
+	}
+	delete(layer.positions, position)
+	return true
+}
+
+// IsWaterlogged checks if the second layer holds water at the given position.
+func (layer *Layer) IsWaterlogged(position blocks.Position) bool {
+	layer.mutex.RLock()
+	var _, ok = layer.positions[position]
+	layer.mutex.RUnlock()
+	return ok
+}
+
+// GetInChunk returns all waterlogged positions in the chunk with the given coordinates.
+func (layer *Layer) GetInChunk(chunkX, chunkZ int32) []blocks.Position {
+	layer.mutex.RLock()
+	defer layer.mutex.RUnlock()
+	var positions []blocks.Position
+	for position := range layer.positions {
+		if position.X>>4 == chunkX && position.Z>>4 == chunkZ {
+			positions = append(positions, position)
+		}
+	}
+	return positions
+}
+
+// LoadChunk loads the water saved in the chunk at the given chunk coordinates into the layer.
+// Nothing is loaded if the chunk was loaded before.
+func (layer *Layer) LoadChunk(x, z int32) error {
+	layer.mutex.Lock()
+	defer layer.mutex.Unlock()
+	if !layer.store.IsPersistent() || layer.loaded[[2]int32{x, z}] {
+		return nil
+	}
+	layer.loaded[[2]int32{x, z}] = true
+	var compounds, err = layer.store.Load(x, z)
+	if err != nil {
+		return err
+	}
+	for _, compound := range compounds {
+		layer.positions[chunkstore.PositionFromNBT(compound)] = struct{}{}
+	}
+	return nil
+}
+
+// SaveChunk saves the water in the chunk at the given chunk coordinates, replacing the water saved before.
+// The file of the chunk is removed if it holds no waterlogged blocks.
+func (layer *Layer) SaveChunk(x, z int32) error {
+	var positions = layer.GetInChunk(x, z)
+	var tags = make([]gonbt.INamedTag, len(positions))
+	for i, position := range positions {
+		tags[i] = chunkstore.PositionToNBT(position)
+	}
+	return layer.store.Save(x, z, tags)
+}
+
+// SaveAll saves the water of all chunks loaded or changed since the layer was created.
+// The first error that occurred is returned, after all other chunks were saved.
+func (layer *Layer) SaveAll() error {
+	layer.mutex.RLock()
+	var chunks = make([][2]int32, 0, len(layer.loaded))
+	for chunk := range layer.loaded {
+		chunks = append(chunks, chunk)
+	}
+	layer.mutex.RUnlock()
+
+	var first error
+	for _, chunk := range chunks {
+		if err := layer.SaveChunk(chunk[0], chunk[1]); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// UnloadChunk saves the water in the chunk at the given chunk coordinates, and removes it from the layer.
+// The water is kept if the layer does not save its water.
+func (layer *Layer) UnloadChunk(x, z int32) error {
+	if !layer.store.IsPersistent() {
+		return nil
+	}
+	if err := layer.SaveChunk(x, z); err != nil {
+		return err
+	}
+	layer.mutex.Lock()
+	for position := range layer.positions {
+		if position.X>>4 == x && position.Z>>4 == z {
+			delete(layer.positions, position)
+		}
+	}
+	delete(layer.loaded, [2]int32{x, z})
+	layer.mutex.Unlock()
+	return nil
+}
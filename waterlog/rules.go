@@ -0,0 +1,97 @@
+package waterlog
+
+import (
+	"strings"
+)
+
+const (
+	// Water is the name of still water blocks.
+	Water = "water"
+	// FlowingWater is the name of flowing water blocks.
+	FlowingWater = "flowing_water"
+
+	// WaterId is the legacy ID of still water blocks.
+	WaterId = 9
+)
+
+// Layer flags of the UpdateBlock packet, which select the storage layer the block gets updated in.
+const (
+	LayerBlock  = 0
+	LayerLiquid = 1
+)
+
+// waterloggable holds the names of blocks that can hold water in their second layer,
+// besides the blocks matched by waterloggableSuffixes.
+var waterloggable = map[string]bool{
+	"chest":              true,
+	"trapped_chest":      true,
+	"ender_chest":        true,
+	"ladder":             true,
+	"standing_sign":      true,
+	"wall_sign":          true,
+	"iron_bars":          true,
+	"glass_pane":         true,
+	"stained_glass_pane": true,
+	"hopper":             true,
+	"lantern":            true,
+	"conduit":            true,
+	"scaffolding":        true,
+	"bell":               true,
+	"campfire":           true,
+	"lectern":            true,
+	"grindstone":         true,
+	"cobblestone_wall":   true,
+}
+
+// waterloggableSuffixes holds the name suffixes of block families that can all hold water.
+var waterloggableSuffixes = []string{"_stairs", "_slab", "_slab2", "_slab3", "_slab4", "_fence", "_trapdoor", "_fence_gate", "_wall", "_coral_fan"}
+
+// IsWaterloggable checks if a block with the given name can hold water in its second layer.
+func IsWaterloggable(name string) bool {
+	if waterloggable[name] {
+		return true
+	}
+	for _, suffix := range waterloggableSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return name == "fence" || name == "trapdoor" || name == "stone_slab" || name == "wooden_slab"
+}
+
+// IsWater checks if a block with the given name is water, either still or flowing.
+func IsWater(name string) bool {
+	return name == Water || name == FlowingWater
+}
+
+// IsWaterSource checks if the block with the given name and data value is a water source.
+// Only water sources waterlog the blocks placed in them.
+func IsWaterSource(name string, data byte) bool {
+	return IsWater(name) && data == 0
+}
+
+// Place returns whether a position holds water in its second layer after placing a block,
+// given the block it replaced and whether the position was already waterlogged.
+// Water is kept when a waterloggable block replaces a waterlogged block or a water source,
+// and drained when the placed block can not hold water.
+func Place(placed string, replaced string, replacedData byte, waterlogged bool) bool {
+	if !IsWaterloggable(placed) {
+		return false
+	}
+	return waterlogged || IsWaterSource(replaced, replacedData)
+}
+
+// Break returns the name of the block left in the first layer after breaking a block.
+// The water of a waterlogged block moves to the first layer, while other blocks leave air.
+func Break(waterlogged bool) string {
+	if waterlogged {
+		return Water
+	}
+	return "air"
+}
+
+// Fill checks if placing a water source at a position holding a block with the given name
+// waterlogs the block, rather than replacing it.
+func Fill(existing string) bool {
+	return IsWaterloggable(existing)
+}
@@ -0,0 +1,80 @@
+package waterlog
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/irmine/worlds/blocks"
+)
+
+func TestPlace(t *testing.T) {
+	var tests = []struct {
+		placed, replaced string
+		replacedData     byte
+		waterlogged      bool
+		expected         bool
+	}{
+		{"oak_stairs", Water, 0, false, true},
+		{"oak_stairs", Water, 3, false, false},
+		{"oak_stairs", "air", 0, false, false},
+		{"stone_slab", "oak_fence", 0, true, true},
+		{"stone", Water, 0, false, false},
+		{"stone", "oak_stairs", 0, true, false},
+	}
+	for _, test := range tests {
+		if waterlogged := Place(test.placed, test.replaced, test.replacedData, test.waterlogged); waterlogged != test.expected {
+			t.Errorf("placing %v in %v: expected waterlogged %v, got %v", test.placed, test.replaced, test.expected, waterlogged)
+		}
+	}
+}
+
+func TestBreak(t *testing.T) {
+	if name := Break(true); name != Water {
+		t.Errorf("expected water to be left behind, got %v", name)
+	}
+	if name := Break(false); name != "air" {
+		t.Errorf("expected air to be left behind, got %v", name)
+	}
+}
+
+func TestLayer(t *testing.T) {
+	var layer = NewLayer("")
+	var position = blocks.NewPosition(17, 64, -3)
+	if !layer.Set(position) || layer.Set(position) {
+		t.Error("expected only the first set to waterlog the position")
+	}
+	if positions := layer.GetInChunk(1, -1); len(positions) != 1 || positions[0] != position {
+		t.Errorf("expected the position in chunk 1, -1, got %v", positions)
+	}
+	if positions := layer.GetInChunk(0, 0); len(positions) != 0 {
+		t.Errorf("expected no positions in chunk 0, 0, got %v", positions)
+	}
+	if !layer.Remove(position) || layer.IsWaterlogged(position) {
+		t.Error("expected the position to be drained")
+	}
+}
+
+func TestLayerChunks(t *testing.T) {
+	var directory, err = ioutil.TempDir("", "waterlog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	var layer = NewLayer(directory)
+	var position = blocks.NewPosition(3, 64, 5)
+	layer.Set(position)
+	if err := layer.UnloadChunk(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if layer.IsWaterlogged(position) {
+		t.Fatal("expected the water to be removed when its chunk was unloaded")
+	}
+	if err := layer.LoadChunk(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !layer.IsWaterlogged(position) {
+		t.Error("expected the water to be loaded again")
+	}
+}
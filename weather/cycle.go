@@ -0,0 +1,258 @@
+package weather
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// Times of day in ticks, as used by the time command.
+const (
+	DayLength int64 = 24000
+
+	Day      int64 = 1000
+	Noon     int64 = 6000
+	Sunset   int64 = 12000
+	Night    int64 = 13000
+	Midnight int64 = 18000
+	Sunrise  int64 = 23000
+)
+
+// Weather is the weather of a world.
+type Weather int
+
+const (
+	Clear Weather = iota
+	Rain
+	Thunder
+)
+
+const (
+	// MinClearDuration and MaxClearDuration are the bounds of the amount of ticks clear weather lasts.
+	MinClearDuration int64 = 12000
+	MaxClearDuration int64 = 180000
+	// MinRainDuration and MaxRainDuration are the bounds of the amount of ticks rain and thunder last.
+	MinRainDuration int64 = 12000
+	MaxRainDuration int64 = 24000
+	// ThunderChance is the chance of rain starting as a thunderstorm, in percent.
+	ThunderChance = 20
+	// LightningChance is the chance of lightning striking near a player every tick of a thunderstorm,
+	// as one in LightningChance.
+	LightningChance = 400
+)
+
+// Level events sent to clients when the weather changes.
+const (
+	LevelEventStartRain    int32 = 3001
+	LevelEventStartThunder int32 = 3002
+	LevelEventStopRain     int32 = 3003
+	LevelEventStopThunder  int32 = 3004
+
+	// Intensity is the data of weather start events, which makes clients show rain and thunder at full strength.
+	Intensity int32 = 65535
+)
+
+// names holds the names of weathers, as used by the weather command.
+var names = map[Weather]string{
+	Clear:   "clear",
+	Rain:    "rain",
+	Thunder: "thunder",
+}
+
+// String returns the name of the weather, such as "rain".
+func (weather Weather) String() string {
+	return names[weather]
+}
+
+// FromName returns the weather with the given name, and a bool indicating if one was found.
+func FromName(name string) (Weather, bool) {
+	for weather, weatherName := range names {
+		if weatherName == name {
+			return weather, true
+		}
+	}
+	return Clear, false
+}
+
+// Cycle keeps track of the time and weather of a world.
+// Time only advances while the daylight cycle runs, and the weather only changes
+// by itself while the weather cycle runs.
+type Cycle struct {
+	mutex         sync.Mutex
+	random        *rand.Rand
+	time          int64
+	daylightCycle bool
+	weatherCycle  bool
+	weather       Weather
+	duration      int64
+}
+
+// NewCycle returns a new cycle starting at the given time with clear weather,
+// picking weather durations and lightning strikes with the random.
+func NewCycle(time int64, random *rand.Rand) *Cycle {
+	var cycle = &Cycle{random: random, time: time, daylightCycle: true, weatherCycle: true}
+	cycle.duration = cycle.pickDuration(Clear)
+	return cycle
+}
+
+// GetTime returns the total amount of ticks that passed in the world.
+func (cycle *Cycle) GetTime() int64 {
+	cycle.mutex.Lock()
+	defer cycle.mutex.Unlock()
+	return cycle.time
+}
+
+// GetTimeOfDay returns the time within the current day, from 0 to DayLength.
+func (cycle *Cycle) GetTimeOfDay() int64 {
+	cycle.mutex.Lock()
+	defer cycle.mutex.Unlock()
+	return cycle.timeOfDay()
+}
+
+// timeOfDay returns the time within the current day.
+func (cycle *Cycle) timeOfDay() int64 {
+	var time = cycle.time % DayLength
+	if time < 0 {
+		time += DayLength
+	}
+	return time
+}
+
+// SetTimeOfDay sets the time within the current day, keeping the amount of days that passed.
+func (cycle *Cycle) SetTimeOfDay(time int64) {
+	cycle.mutex.Lock()
+	cycle.time += time - cycle.timeOfDay()
+	cycle.mutex.Unlock()
+}
+
+// SetTime sets the total amount of ticks that passed in the world.
+func (cycle *Cycle) SetTime(time int64) {
+	cycle.mutex.Lock()
+	cycle.time = time
+	cycle.mutex.Unlock()
+}
+
+// AddTime adds the given amount of ticks to the time.
+func (cycle *Cycle) AddTime(ticks int64) {
+	cycle.mutex.Lock()
+	cycle.time += ticks
+	cycle.mutex.Unlock()
+}
+
+// IsDaylightCycle checks if the time advances every tick.
+func (cycle *Cycle) IsDaylightCycle() bool {
+	cycle.mutex.Lock()
+	defer cycle.mutex.Unlock()
+	return cycle.daylightCycle
+}
+
+// SetDaylightCycle sets if the time advances every tick.
+func (cycle *Cycle) SetDaylightCycle(value bool) {
+	cycle.mutex.Lock()
+	cycle.daylightCycle = value
+	cycle.mutex.Unlock()
+}
+
+// IsWeatherCycle checks if the weather changes by itself.
+func (cycle *Cycle) IsWeatherCycle() bool {
+	cycle.mutex.Lock()
+	defer cycle.mutex.Unlock()
+	return cycle.weatherCycle
+}
+
+// SetWeatherCycle sets if the weather changes by itself.
+func (cycle *Cycle) SetWeatherCycle(value bool) {
+	cycle.mutex.Lock()
+	cycle.weatherCycle = value
+	cycle.mutex.Unlock()
+}
+
+// GetWeather returns the current weather.
+func (cycle *Cycle) GetWeather() Weather {
+	cycle.mutex.Lock()
+	defer cycle.mutex.Unlock()
+	return cycle.weather
+}
+
+// GetWeatherDuration returns the amount of ticks left before the weather changes.
+func (cycle *Cycle) GetWeatherDuration() int64 {
+	cycle.mutex.Lock()
+	defer cycle.mutex.Unlock()
+	return cycle.duration
+}
+
+// SetWeather sets the weather for the given amount of ticks.
+// A random duration for the weather is picked if the duration is 0 or less.
+func (cycle *Cycle) SetWeather(weather Weather, duration int64) {
+	cycle.mutex.Lock()
+	defer cycle.mutex.Unlock()
+	if duration <= 0 {
+		duration = cycle.pickDuration(weather)
+	}
+	cycle.weather, cycle.duration = weather, duration
+}
+
+// Tick advances the time if the daylight cycle runs, and the weather if the weather cycle runs.
+// It returns the weather before the tick, and a bool indicating if the weather changed.
+func (cycle *Cycle) Tick() (Weather, bool) {
+	cycle.mutex.Lock()
+	defer cycle.mutex.Unlock()
+	if cycle.daylightCycle {
+		cycle.time++
+	}
+	var previous = cycle.weather
+	if !cycle.weatherCycle {
+		return previous, false
+	}
+	if cycle.duration--; cycle.duration > 0 {
+		return previous, false
+	}
+	if previous != Clear {
+		cycle.weather = Clear
+	} else if cycle.random.Intn(100) < ThunderChance {
+		cycle.weather = Thunder
+	} else {
+		cycle.weather = Rain
+	}
+	cycle.duration = cycle.pickDuration(cycle.weather)
+	return previous, true
+}
+
+// ShouldStrikeLightning checks if lightning should strike near a player this tick.
+// Lightning only strikes during thunderstorms.
+func (cycle *Cycle) ShouldStrikeLightning() bool {
+	cycle.mutex.Lock()
+	defer cycle.mutex.Unlock()
+	return cycle.weather == Thunder && cycle.random.Intn(LightningChance) == 0
+}
+
+// pickDuration returns a random amount of ticks the weather lasts.
+func (cycle *Cycle) pickDuration(weather Weather) int64 {
+	if weather == Clear {
+		return MinClearDuration + cycle.random.Int63n(MaxClearDuration-MinClearDuration+1)
+	}
+	return MinRainDuration + cycle.random.Int63n(MaxRainDuration-MinRainDuration+1)
+}
+
+// GetStartEvents returns the level events that start the weather for clients.
+// The events are sent with Intensity as data. Clear weather has no start events.
+func GetStartEvents(weather Weather) []int32 {
+	switch weather {
+	case Rain:
+		return []int32{LevelEventStartRain}
+	case Thunder:
+		return []int32{LevelEventStartRain, LevelEventStartThunder}
+	}
+	return nil
+}
+
+// GetStopEvents returns the level events that stop the weather for clients.
+// Clear weather has no stop events.
+func GetStopEvents(weather Weather) []int32 {
+	switch weather {
+	case Rain:
+		return []int32{LevelEventStopRain}
+	case Thunder:
+		return []int32{LevelEventStopRain, LevelEventStopThunder}
+	}
+	return nil
+}
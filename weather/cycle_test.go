@@ -0,0 +1,58 @@
+package weather
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestTime(t *testing.T) {
+	var cycle = NewCycle(DayLength*2+Noon, rand.New(rand.NewSource(1)))
+	cycle.Tick()
+	if cycle.GetTimeOfDay() != Noon+1 {
+		t.Errorf("expected time of day %v, got %v", Noon+1, cycle.GetTimeOfDay())
+	}
+	cycle.SetTimeOfDay(Night)
+	if cycle.GetTime() != DayLength*2+Night {
+		t.Errorf("expected days to be kept when setting the time of day, got %v", cycle.GetTime())
+	}
+
+	cycle.SetDaylightCycle(false)
+	cycle.Tick()
+	if cycle.GetTime() != DayLength*2+Night {
+		t.Error("expected time not to advance without daylight cycle")
+	}
+	cycle.SetTime(-1)
+	if cycle.GetTimeOfDay() != DayLength-1 {
+		t.Errorf("expected negative time to wrap around, got %v", cycle.GetTimeOfDay())
+	}
+}
+
+func TestWeather(t *testing.T) {
+	var cycle = NewCycle(0, rand.New(rand.NewSource(1)))
+	cycle.SetWeather(Thunder, 2)
+	if previous, changed := cycle.Tick(); changed || previous != Thunder {
+		t.Fatal("expected weather not to change before its duration ended")
+	}
+	if previous, changed := cycle.Tick(); !changed || previous != Thunder || cycle.GetWeather() != Clear {
+		t.Fatalf("expected thunder to clear up, got %v", cycle.GetWeather())
+	}
+	if duration := cycle.GetWeatherDuration(); duration < MinClearDuration || duration > MaxClearDuration {
+		t.Errorf("expected a clear weather duration, got %v", duration)
+	}
+
+	cycle.SetWeather(Rain, 1)
+	cycle.SetWeatherCycle(false)
+	if _, changed := cycle.Tick(); changed || cycle.GetWeather() != Rain {
+		t.Error("expected weather not to change without weather cycle")
+	}
+	if cycle.ShouldStrikeLightning() {
+		t.Error("expected lightning not to strike without thunder")
+	}
+
+	if weather, ok := FromName("thunder"); !ok || weather != Thunder || weather.String() != "thunder" {
+		t.Errorf("expected thunder, got %v", weather)
+	}
+	if events := GetStopEvents(Thunder); len(events) != 2 || events[1] != LevelEventStopThunder {
+		t.Errorf("expected rain and thunder to stop, got %v", events)
+	}
+}
@@ -0,0 +1,90 @@
+package gomine
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/BobbyShrd/gominetest/forms"
+	"github.com/BobbyShrd/gominetest/net"
+	"github.com/BobbyShrd/gominetest/text"
+)
+
+const (
+	WelcomeNone = "none"
+	WelcomeChat = "chat"
+	WelcomeForm = "form"
+	WelcomeBook = "book"
+)
+
+// FormatWelcome replaces the placeholders in the given welcome text
+// with the values of the player of the session.
+func (server *Server) FormatWelcome(session *net.MinecraftSession, message string) string {
+	return strings.NewReplacer(
+		"{name}", session.GetName(),
+		"{display_name}", session.GetDisplayName(),
+		"{online}", strconv.Itoa(server.SessionManager.GetSessionCount()),
+		"{max_players}", strconv.Itoa(int(server.GetMaximumPlayers())),
+		"{server}", server.GetName(),
+		"{motd}", server.GetMotd(),
+	).Replace(message)
+}
+
+// SendWelcome sends the welcome configured to the session.
+// The welcome is either sent as chat messages, a single form with all pages,
+// or a book-like form which can be paged through.
+func (server *Server) SendWelcome(session *net.MinecraftSession) {
+	var pages = server.Config.WelcomePages
+	if len(pages) == 0 {
+		return
+	}
+	switch strings.ToLower(server.Config.WelcomeType) {
+	case WelcomeChat:
+		for _, page := range pages {
+			session.SendMessage(server.FormatWelcome(session, page))
+		}
+	case WelcomeForm:
+		server.sendWelcomePage(session, []string{strings.Join(pages, "\n\n")}, 0)
+	case WelcomeBook:
+		server.sendWelcomePage(session, pages, 0)
+	}
+}
+
+// sendWelcomePage sends the page with the given index as a form.
+// Navigation buttons are added if there are multiple pages,
+// followed by the buttons configured to execute commands.
+func (server *Server) sendWelcomePage(session *net.MinecraftSession, pages []string, page int) {
+	var title = server.FormatWelcome(session, server.Config.WelcomeTitle)
+	if len(pages) > 1 {
+		title += " (" + strconv.Itoa(page+1) + "/" + strconv.Itoa(len(pages)) + ")"
+	}
+	var form = forms.NewSimpleForm(title, server.FormatWelcome(session, pages[page]))
+	var actions []func()
+
+	if page > 0 {
+		form.AddButton("< Previous")
+		actions = append(actions, func() {
+			server.sendWelcomePage(session, pages, page-1)
+		})
+	}
+	if page < len(pages)-1 {
+		form.AddButton("Next >")
+		actions = append(actions, func() {
+			server.sendWelcomePage(session, pages, page+1)
+		})
+	}
+	for _, button := range server.Config.WelcomeButtons {
+		var command = button.Command
+		form.AddButton(server.FormatWelcome(session, button.Text))
+		actions = append(actions, func() {
+			if !server.DispatchCommand(session, command) {
+				session.SendMessage(text.NewComponent().Color(text.Red).Text("Command could not be found."))
+			}
+		})
+	}
+
+	form.SubmitFunction = func(responder *net.MinecraftSession, button int) {
+		actions[button]()
+	}
+	var _, err = server.FormManager.SendForm(session, form)
+	text.DefaultLogger.LogError(err)
+}
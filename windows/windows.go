@@ -0,0 +1,156 @@
+package windows
+
+import (
+	"sync"
+
+	"github.com/BobbyShrd/gominetest/blockentities"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/irmine/worlds/blocks"
+)
+
+// Window types, as sent to clients when opening a window.
+const (
+	TypeContainer byte = 0
+	TypeFurnace   byte = 2
+	TypeHopper    byte = 8
+)
+
+const (
+	// FirstWindowId is the first window ID used for container windows.
+	// Lower IDs are used by the inventory of the player itself.
+	FirstWindowId byte = 1
+	// LastWindowId is the last window ID used for container windows,
+	// after which window IDs start again at FirstWindowId.
+	LastWindowId byte = 99
+
+	// MaxDistance is the maximum distance between a player and the container it opened.
+	// Windows of players further away get closed.
+	MaxDistance = 8.0
+)
+
+// GetType returns the window type of the container.
+func GetType(container blockentities.Container) byte {
+	switch container.(type) {
+	case *blockentities.Furnace:
+		return TypeFurnace
+	case *blockentities.Hopper:
+		return TypeHopper
+	}
+	return TypeContainer
+}
+
+// GetContents returns the item stacks in all slots of the container.
+func GetContents(container blockentities.Container) []*items.Stack {
+	var contents = make([]*items.Stack, container.GetSize())
+	for slot := range contents {
+		contents[slot] = container.GetItem(slot)
+	}
+	return contents
+}
+
+// Window is a container opened by a player.
+type Window struct {
+	id        byte
+	container blockentities.Container
+}
+
+// GetId returns the window ID of the window, which is unique for the player that opened it.
+func (window *Window) GetId() byte {
+	return window.id
+}
+
+// GetType returns the window type of the container of the window.
+func (window *Window) GetType() byte {
+	return GetType(window.container)
+}
+
+// GetContainer returns the container of the window.
+func (window *Window) GetContainer() blockentities.Container {
+	return window.container
+}
+
+// GetPosition returns the position of the container of the window.
+func (window *Window) GetPosition() blocks.Position {
+	return window.container.GetPosition()
+}
+
+// Manager keeps track of the windows opened by players, indexed by player name.
+// A player can have one window opened at a time.
+type Manager struct {
+	mutex   sync.Mutex
+	windows map[string]*Window
+	lastIds map[string]byte
+}
+
+// NewManager returns a new manager without opened windows.
+func NewManager() *Manager {
+	return &Manager{windows: make(map[string]*Window), lastIds: make(map[string]byte)}
+}
+
+// Open opens a window of the container for the player with the given name.
+// Any window the player had opened before is replaced, and should be closed first.
+func (manager *Manager) Open(viewer string, container blockentities.Container) *Window {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var id = manager.lastIds[viewer] + 1
+	if id < FirstWindowId || id > LastWindowId {
+		id = FirstWindowId
+	}
+	manager.lastIds[viewer] = id
+	var window = &Window{id, container}
+	manager.windows[viewer] = window
+	return window
+}
+
+// Get returns the window opened by the player with the given name,
+// and a bool indicating if the player has a window opened.
+func (manager *Manager) Get(viewer string) (*Window, bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var window, ok = manager.windows[viewer]
+	return window, ok
+}
+
+// Close closes the window opened by the player with the given name.
+// The window closed is returned, along with a bool indicating if the player had a window opened.
+func (manager *Manager) Close(viewer string) (*Window, bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var window, ok = manager.windows[viewer]
+	delete(manager.windows, viewer)
+	return window, ok
+}
+
+// Remove closes the window of the player with the given name,
+// and forgets the window IDs used by the player, for example because it left the server.
+func (manager *Manager) Remove(viewer string) {
+	manager.mutex.Lock()
+	delete(manager.windows, viewer)
+	delete(manager.lastIds, viewer)
+	manager.mutex.Unlock()
+}
+
+// GetViewers returns the windows opened by all players that opened a container at the given position,
+// indexed by player name.
+func (manager *Manager) GetViewers(position blocks.Position) map[string]*Window {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var viewers = make(map[string]*Window)
+	for viewer, window := range manager.windows {
+		if window.GetPosition() == position {
+			viewers[viewer] = window
+		}
+	}
+	return viewers
+}
+
+// GetAll returns the windows opened by all players, indexed by player name.
+func (manager *Manager) GetAll() map[string]*Window {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var windows = make(map[string]*Window, len(manager.windows))
+	for viewer, window := range manager.windows {
+		windows[viewer] = window
+	}
+	return windows
+}
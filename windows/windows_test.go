@@ -0,0 +1,60 @@
+package windows
+
+import (
+	"testing"
+
+	"github.com/BobbyShrd/gominetest/blockentities"
+	"github.com/BobbyShrd/gominetest/items"
+	"github.com/irmine/worlds/blocks"
+)
+
+func TestWindows(t *testing.T) {
+	var manager = NewManager()
+	var chest = blockentities.NewChest(blocks.NewPosition(0, 0, 0))
+	var furnace = blockentities.NewFurnace(blocks.NewPosition(5, 0, 0))
+
+	var window = manager.Open("a", chest)
+	if window.GetId() != FirstWindowId || window.GetType() != TypeContainer {
+		t.Errorf("expected first container window, got %v of type %v", window.GetId(), window.GetType())
+	}
+	manager.Open("b", chest)
+	if window := manager.Open("a", furnace); window.GetId() != FirstWindowId+1 || window.GetType() != TypeFurnace {
+		t.Errorf("expected second furnace window, got %v of type %v", window.GetId(), window.GetType())
+	}
+	if viewers := manager.GetViewers(chest.GetPosition()); len(viewers) != 1 || viewers["b"] == nil {
+		t.Errorf("expected only b to view the chest, got %v", viewers)
+	}
+
+	if _, ok := manager.Close("b"); !ok {
+		t.Error("expected window of b to be closed")
+	}
+	if _, ok := manager.Get("b"); ok {
+		t.Error("expected b to have no window opened")
+	}
+}
+
+func TestDoubleChest(t *testing.T) {
+	var manager = blockentities.NewManager()
+	var left = blockentities.NewChest(blocks.NewPosition(0, 0, 0))
+	var right = blockentities.NewChest(blocks.NewPosition(1, 0, 0))
+	manager.Add(left)
+	manager.Add(right)
+	if manager.PairChest(right) != left {
+		t.Fatal("expected chests to be paired")
+	}
+
+	var container, _ = manager.GetChest(right.GetPosition())
+	if container.GetSize() != blockentities.DoubleChestSize || container.GetPosition() != left.GetPosition() {
+		t.Fatalf("expected double chest led by the left chest, got %v", container)
+	}
+	var stone, _ = items.DefaultManager.Get("minecraft:stone", 1)
+	container.SetItem(blockentities.ChestSize, stone)
+	if right.GetItem(0) != stone || len(GetContents(container)) != blockentities.DoubleChestSize {
+		t.Error("expected second half of the double chest to be the right chest")
+	}
+
+	manager.Remove(left.GetPosition())
+	if container, _ := manager.GetChest(right.GetPosition()); container != right {
+		t.Error("expected right chest to be unpaired after removing the left chest")
+	}
+}